@@ -243,7 +243,7 @@ var Password action.GTSAction = func(ctx context.Context) error {
 		return err
 	}
 
-	pw, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	pw, err := bcrypt.GenerateFromPassword([]byte(password), viper.GetInt(config.Keys.AccountsPasswordBcryptCost))
 	if err != nil {
 		return fmt.Errorf("error hashing password: %s", err)
 	}
@@ -26,6 +26,7 @@ import (
 	"os/signal"
 	"path"
 	"syscall"
+	"time"
 
 	"codeberg.org/gruf/go-store/kv"
 	"codeberg.org/gruf/go-store/storage"
@@ -47,10 +48,14 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/list"
 	mediaModule "github.com/superseriousbusiness/gotosocial/internal/api/client/media"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/notification"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/oembed"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/search"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/status"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/suggestions"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tags"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/timeline"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/trends"
 	userClient "github.com/superseriousbusiness/gotosocial/internal/api/client/user"
 	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/nodeinfo"
 	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/user"
@@ -68,6 +73,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/oidc"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
+	"github.com/superseriousbusiness/gotosocial/internal/scheduler"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/web"
@@ -187,6 +193,10 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	favouritesModule := favourites.New(processor)
 	blocksModule := blocks.New(processor)
 	userClientModule := userClient.New(processor)
+	oEmbedModule := oembed.New(processor)
+	trendsModule := trends.New(processor)
+	suggestionsModule := suggestions.New(processor)
+	tagsModule := tags.New(processor)
 
 	apis := []api.ClientModule{
 		// modules with middleware go first
@@ -218,6 +228,10 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		favouritesModule,
 		blocksModule,
 		userClientModule,
+		oEmbedModule,
+		trendsModule,
+		suggestionsModule,
+		tagsModule,
 	}
 
 	for _, m := range apis {
@@ -226,7 +240,125 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		}
 	}
 
-	gts, err := gotosocial.NewServer(dbService, router, federator, mediaManager)
+	// set up recurring maintenance jobs
+	jobScheduler := scheduler.New()
+	cacheCleanupDays := viper.GetInt(config.Keys.MediaRemoteCacheDays)
+	cacheCleanupInterval := viper.GetDuration(config.Keys.MediaRemoteCachePruneInterval)
+	if cacheCleanupDays != 0 && cacheCleanupInterval > 0 {
+		if err := jobScheduler.Register(scheduler.Job{
+			Name:     "media remote cache prune",
+			Interval: cacheCleanupInterval,
+			Jitter:   cacheCleanupInterval / 24,
+			Run: func(ctx context.Context) error {
+				pruned, err := mediaManager.PruneRemote(ctx, cacheCleanupDays)
+				if err != nil {
+					return err
+				}
+				logrus.Infof("pruned %d remote media cache entries", pruned)
+				return nil
+			},
+		}); err != nil {
+			return fmt.Errorf("error registering media prune job: %s", err)
+		}
+	}
+	if unattachedRetention := viper.GetDuration(config.Keys.MediaUnattachedRetention); unattachedRetention > 0 {
+		if err := jobScheduler.Register(scheduler.Job{
+			Name:     "unattached media prune",
+			Interval: 1 * time.Hour,
+			Jitter:   5 * time.Minute,
+			Run: func(ctx context.Context) error {
+				pruned, err := mediaManager.PruneUnattached(ctx, time.Now().Add(-unattachedRetention))
+				if err != nil {
+					return err
+				}
+				logrus.Debugf("pruned %d unattached media attachments", pruned)
+				return nil
+			},
+		}); err != nil {
+			return fmt.Errorf("error registering unattached media prune job: %s", err)
+		}
+	}
+	if tokenCleanupInterval := viper.GetDuration(config.Keys.OAuthTokenCleanupInterval); tokenCleanupInterval > 0 {
+		if err := jobScheduler.Register(scheduler.Job{
+			Name:     "oauth token cleanup",
+			Interval: tokenCleanupInterval,
+			Jitter:   tokenCleanupInterval / 10,
+			Run: func(ctx context.Context) error {
+				now := time.Now()
+				deleted, err := dbService.DeleteExpiredTokens(ctx, now)
+				if err != nil {
+					return err
+				}
+				logrus.Debugf("deleted %d expired oauth tokens", deleted)
+
+				if inactivityExpiry := viper.GetDuration(config.Keys.OAuthTokenInactivityExpiry); inactivityExpiry > 0 {
+					deleted, err := dbService.DeleteInactiveTokens(ctx, now.Add(-inactivityExpiry))
+					if err != nil {
+						return err
+					}
+					logrus.Debugf("deleted %d inactive oauth tokens", deleted)
+				}
+				return nil
+			},
+		}); err != nil {
+			return fmt.Errorf("error registering oauth token cleanup job: %s", err)
+		}
+	}
+	if tombstoneRetention := viper.GetDuration(config.Keys.StatusesTombstoneRetention); tombstoneRetention > 0 {
+		if err := jobScheduler.Register(scheduler.Job{
+			Name:     "tombstone cleanup",
+			Interval: 24 * time.Hour,
+			Jitter:   1 * time.Hour,
+			Run: func(ctx context.Context) error {
+				deleted, err := dbService.DeleteExpiredTombstones(ctx, time.Now().Add(-tombstoneRetention))
+				if err != nil {
+					return err
+				}
+				logrus.Debugf("deleted %d expired tombstones", deleted)
+				return nil
+			},
+		}); err != nil {
+			return fmt.Errorf("error registering tombstone cleanup job: %s", err)
+		}
+	}
+
+	if verifyInterval := viper.GetDuration(config.Keys.AccountsFieldVerificationInterval); verifyInterval > 0 {
+		if err := jobScheduler.Register(scheduler.Job{
+			Name:     "account field verification",
+			Interval: verifyInterval,
+			Jitter:   verifyInterval / 10,
+			Run: func(ctx context.Context) error {
+				updated, err := processor.AccountVerifyAllFields(ctx)
+				if err != nil {
+					return err
+				}
+				logrus.Debugf("updated field verification of %d accounts", updated)
+				return nil
+			},
+		}); err != nil {
+			return fmt.Errorf("error registering account field verification job: %s", err)
+		}
+	}
+
+	if autoDeleteInterval := viper.GetDuration(config.Keys.StatusesAutoDeleteInterval); autoDeleteInterval > 0 {
+		if err := jobScheduler.Register(scheduler.Job{
+			Name:     "status auto-delete",
+			Interval: autoDeleteInterval,
+			Jitter:   autoDeleteInterval / 10,
+			Run: func(ctx context.Context) error {
+				deleted, err := processor.StatusAutoDeleteAll(ctx)
+				if err != nil {
+					return err
+				}
+				logrus.Debugf("auto-deleted %d statuses", deleted)
+				return nil
+			},
+		}); err != nil {
+			return fmt.Errorf("error registering status auto-delete job: %s", err)
+		}
+	}
+
+	gts, err := gotosocial.NewServer(dbService, router, federator, mediaManager, jobScheduler)
 	if err != nil {
 		return fmt.Errorf("error creating gotosocial service: %s", err)
 	}
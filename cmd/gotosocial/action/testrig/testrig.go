@@ -45,10 +45,14 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/list"
 	mediaModule "github.com/superseriousbusiness/gotosocial/internal/api/client/media"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/notification"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/oembed"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/search"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/status"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/streaming"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/suggestions"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tags"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/timeline"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/trends"
 	userClient "github.com/superseriousbusiness/gotosocial/internal/api/client/user"
 	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/nodeinfo"
 	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/user"
@@ -57,6 +61,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gotosocial"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/oidc"
+	"github.com/superseriousbusiness/gotosocial/internal/scheduler"
 	"github.com/superseriousbusiness/gotosocial/internal/web"
 	"github.com/superseriousbusiness/gotosocial/internal/worker"
 	"github.com/superseriousbusiness/gotosocial/testrig"
@@ -131,6 +136,10 @@ var Start action.GTSAction = func(ctx context.Context) error {
 	favouritesModule := favourites.New(processor)
 	blocksModule := blocks.New(processor)
 	userClientModule := userClient.New(processor)
+	oEmbedModule := oembed.New(processor)
+	trendsModule := trends.New(processor)
+	suggestionsModule := suggestions.New(processor)
+	tagsModule := tags.New(processor)
 
 	apis := []api.ClientModule{
 		// modules with middleware go first
@@ -162,6 +171,10 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		favouritesModule,
 		blocksModule,
 		userClientModule,
+		oEmbedModule,
+		trendsModule,
+		suggestionsModule,
+		tagsModule,
 	}
 
 	for _, m := range apis {
@@ -170,7 +183,7 @@ var Start action.GTSAction = func(ctx context.Context) error {
 		}
 	}
 
-	gts, err := gotosocial.NewServer(dbService, router, federator, mediaManager)
+	gts, err := gotosocial.NewServer(dbService, router, federator, mediaManager, scheduler.New())
 	if err != nil {
 		return fmt.Errorf("error creating gotosocial service: %s", err)
 	}
@@ -44,6 +44,10 @@ func preRun(cmd *cobra.Command) error {
 		return fmt.Errorf("error initializing config: %s", err)
 	}
 
+	if err := config.Validate(); err != nil {
+		return fmt.Errorf("invalid config: %s", err)
+	}
+
 	return nil
 }
 
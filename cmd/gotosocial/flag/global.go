@@ -32,6 +32,7 @@ func Global(cmd *cobra.Command, values config.Values) {
 	cmd.PersistentFlags().String(config.Keys.Protocol, values.Protocol, usage.Protocol)
 	cmd.PersistentFlags().String(config.Keys.LogLevel, values.LogLevel, usage.LogLevel)
 	cmd.PersistentFlags().Bool(config.Keys.LogDbQueries, values.LogDbQueries, usage.LogDbQueries)
+	cmd.PersistentFlags().Duration(config.Keys.LogSamplePeriod, values.LogSamplePeriod, usage.LogSamplePeriod)
 	cmd.PersistentFlags().String(config.Keys.ConfigPath, values.ConfigPath, usage.ConfigPath)
 
 	// database stuff
@@ -43,4 +44,5 @@ func Global(cmd *cobra.Command, values config.Values) {
 	cmd.PersistentFlags().String(config.Keys.DbDatabase, values.DbDatabase, usage.DbDatabase)
 	cmd.PersistentFlags().String(config.Keys.DbTLSMode, values.DbTLSMode, usage.DbTLSMode)
 	cmd.PersistentFlags().String(config.Keys.DbTLSCACert, values.DbTLSCACert, usage.DbTLSCACert)
+	cmd.PersistentFlags().Duration(config.Keys.DbSlowQueryThreshold, values.DbSlowQueryThreshold, usage.DbSlowQueryThreshold)
 }
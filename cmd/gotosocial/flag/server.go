@@ -26,6 +26,7 @@ import (
 // Server attaches all flags pertaining to running the GtS server or testrig.
 func Server(cmd *cobra.Command, values config.Values) {
 	Template(cmd, values)
+	Instance(cmd, values)
 	Accounts(cmd, values)
 	Media(cmd, values)
 	Storage(cmd, values)
@@ -42,12 +43,43 @@ func Router(cmd *cobra.Command, values config.Values) {
 	cmd.PersistentFlags().String(config.Keys.BindAddress, values.BindAddress, usage.BindAddress)
 	cmd.PersistentFlags().Int(config.Keys.Port, values.Port, usage.Port)
 	cmd.PersistentFlags().StringSlice(config.Keys.TrustedProxies, values.TrustedProxies, usage.TrustedProxies)
+	cmd.PersistentFlags().StringSlice(config.Keys.TrustedProxyHeaders, values.TrustedProxyHeaders, usage.TrustedProxyHeaders)
+	cmd.PersistentFlags().StringSlice(config.Keys.CORSAllowOrigins, values.CORSAllowOrigins, usage.CORSAllowOrigins)
+	cmd.PersistentFlags().StringSlice(config.Keys.CORSAllowMethods, values.CORSAllowMethods, usage.CORSAllowMethods)
+	cmd.PersistentFlags().StringSlice(config.Keys.CORSAllowHeaders, values.CORSAllowHeaders, usage.CORSAllowHeaders)
+	cmd.PersistentFlags().Int(config.Keys.GzipLevel, values.GzipLevel, usage.GzipLevel)
+	cmd.PersistentFlags().StringSlice(config.Keys.GzipExcludedContentTypes, values.GzipExcludedContentTypes, usage.GzipExcludedContentTypes)
+	cmd.PersistentFlags().Int(config.Keys.GzipMinSize, values.GzipMinSize, usage.GzipMinSize)
+	cmd.PersistentFlags().Int(config.Keys.GzipMaxSize, values.GzipMaxSize, usage.GzipMaxSize)
+	cmd.PersistentFlags().String(config.Keys.SessionCookieDomain, values.SessionCookieDomain, usage.SessionCookieDomain)
+	cmd.PersistentFlags().String(config.Keys.SessionCookieSameSite, values.SessionCookieSameSite, usage.SessionCookieSameSite)
+	cmd.PersistentFlags().Bool(config.Keys.SessionCookieSecure, values.SessionCookieSecure, usage.SessionCookieSecure)
+	cmd.PersistentFlags().Duration(config.Keys.SessionCookieMaxAge, values.SessionCookieMaxAge, usage.SessionCookieMaxAge)
 }
 
 // Template attaches flags pertaining to templating config.
 func Template(cmd *cobra.Command, values config.Values) {
 	cmd.Flags().String(config.Keys.WebTemplateBaseDir, values.WebTemplateBaseDir, usage.WebTemplateBaseDir)
 	cmd.Flags().String(config.Keys.WebAssetBaseDir, values.WebAssetBaseDir, usage.WebAssetBaseDir)
+	cmd.Flags().Duration(config.Keys.WebAssetCacheMaxAge, values.WebAssetCacheMaxAge, usage.WebAssetCacheMaxAge)
+	cmd.Flags().Duration(config.Keys.WebAssetHashedCacheMaxAge, values.WebAssetHashedCacheMaxAge, usage.WebAssetHashedCacheMaxAge)
+	cmd.Flags().String(config.Keys.WebContentSecurityPolicy, values.WebContentSecurityPolicy, usage.WebContentSecurityPolicy)
+}
+
+// Instance attaches flags pertaining to instance config.
+func Instance(cmd *cobra.Command, values config.Values) {
+	cmd.Flags().Bool(config.Keys.InstanceAuthorizedFetch, values.InstanceAuthorizedFetch, usage.InstanceAuthorizedFetch)
+	cmd.Flags().StringSlice(config.Keys.InstanceFederationAcceptedActivityTypes, values.InstanceFederationAcceptedActivityTypes, usage.InstanceFederationAcceptedActivityTypes)
+	cmd.Flags().StringSlice(config.Keys.InstanceFederationRejectedActivityTypes, values.InstanceFederationRejectedActivityTypes, usage.InstanceFederationRejectedActivityTypes)
+	cmd.Flags().StringSlice(config.Keys.InstanceLanguages, values.InstanceLanguages, usage.InstanceLanguages)
+	cmd.Flags().Bool(config.Keys.InstanceReadOnly, values.InstanceReadOnly, usage.InstanceReadOnly)
+	cmd.Flags().String(config.Keys.InstanceReadOnlyMessage, values.InstanceReadOnlyMessage, usage.InstanceReadOnlyMessage)
+	cmd.Flags().Bool(config.Keys.InstanceReadOnlyRejectFederation, values.InstanceReadOnlyRejectFederation, usage.InstanceReadOnlyRejectFederation)
+	cmd.Flags().Int(config.Keys.FederationMaxThreadDepth, values.FederationMaxThreadDepth, usage.FederationMaxThreadDepth)
+	cmd.Flags().Int(config.Keys.InstanceMaxPageSize, values.InstanceMaxPageSize, usage.InstanceMaxPageSize)
+	cmd.Flags().Bool(config.Keys.InstanceAPIJSONPretty, values.InstanceAPIJSONPretty, usage.InstanceAPIJSONPretty)
+	cmd.Flags().Bool(config.Keys.InstanceAPIJSONOmitEmpty, values.InstanceAPIJSONOmitEmpty, usage.InstanceAPIJSONOmitEmpty)
+	cmd.Flags().Duration(config.Keys.InstanceActiveUsersCacheDuration, values.InstanceActiveUsersCacheDuration, usage.InstanceActiveUsersCacheDuration)
 }
 
 // Accounts attaches flags pertaining to account config.
@@ -55,15 +87,52 @@ func Accounts(cmd *cobra.Command, values config.Values) {
 	cmd.Flags().Bool(config.Keys.AccountsRegistrationOpen, values.AccountsRegistrationOpen, usage.AccountsRegistrationOpen)
 	cmd.Flags().Bool(config.Keys.AccountsApprovalRequired, values.AccountsApprovalRequired, usage.AccountsApprovalRequired)
 	cmd.Flags().Bool(config.Keys.AccountsReasonRequired, values.AccountsReasonRequired, usage.AccountsReasonRequired)
+	cmd.Flags().Duration(config.Keys.AccountsArchiveInterval, values.AccountsArchiveInterval, usage.AccountsArchiveInterval)
+	cmd.Flags().Duration(config.Keys.AccountsKeyGracePeriod, values.AccountsKeyGracePeriod, usage.AccountsKeyGracePeriod)
+	cmd.Flags().Int(config.Keys.AccountsSignUpIPLimit, values.AccountsSignUpIPLimit, usage.AccountsSignUpIPLimit)
+	cmd.Flags().Duration(config.Keys.AccountsSignUpIPWindow, values.AccountsSignUpIPWindow, usage.AccountsSignUpIPWindow)
+	cmd.Flags().StringSlice(config.Keys.AccountsEmailDomainBlocklist, values.AccountsEmailDomainBlocklist, usage.AccountsEmailDomainBlocklist)
+	cmd.Flags().String(config.Keys.AccountsEmailDomainBlocklistFile, values.AccountsEmailDomainBlocklistFile, usage.AccountsEmailDomainBlocklistFile)
+	cmd.Flags().Int(config.Keys.AccountsMaxFields, values.AccountsMaxFields, usage.AccountsMaxFields)
+	cmd.Flags().Int(config.Keys.AccountsFieldNameMaxChars, values.AccountsFieldNameMaxChars, usage.AccountsFieldNameMaxChars)
+	cmd.Flags().Int(config.Keys.AccountsFieldValueMaxChars, values.AccountsFieldValueMaxChars, usage.AccountsFieldValueMaxChars)
+	cmd.Flags().Bool(config.Keys.AccountsCustomCSSEnabled, values.AccountsCustomCSSEnabled, usage.AccountsCustomCSSEnabled)
+	cmd.Flags().StringSlice(config.Keys.AccountsCustomCSSAllowedHosts, values.AccountsCustomCSSAllowedHosts, usage.AccountsCustomCSSAllowedHosts)
+	cmd.Flags().Duration(config.Keys.AccountsFieldVerificationInterval, values.AccountsFieldVerificationInterval, usage.AccountsFieldVerificationInterval)
+	cmd.Flags().Bool(config.Keys.AccountsSuggestionsEnabled, values.AccountsSuggestionsEnabled, usage.AccountsSuggestionsEnabled)
+	cmd.Flags().Int(config.Keys.AccountsMaxFollowing, values.AccountsMaxFollowing, usage.AccountsMaxFollowing)
+	cmd.Flags().Duration(config.Keys.AccountsMinAge, values.AccountsMinAge, usage.AccountsMinAge)
+	cmd.Flags().Duration(config.Keys.AccountsRemoteRefreshInterval, values.AccountsRemoteRefreshInterval, usage.AccountsRemoteRefreshInterval)
+	cmd.Flags().Int(config.Keys.AccountsPasswordBcryptCost, values.AccountsPasswordBcryptCost, usage.AccountsPasswordBcryptCost)
+	cmd.Flags().Int(config.Keys.AccountsSignInMaxFailures, values.AccountsSignInMaxFailures, usage.AccountsSignInMaxFailures)
+	cmd.Flags().Duration(config.Keys.AccountsSignInFailureWindow, values.AccountsSignInFailureWindow, usage.AccountsSignInFailureWindow)
+	cmd.Flags().Duration(config.Keys.AccountsSignInLockout, values.AccountsSignInLockout, usage.AccountsSignInLockout)
+	cmd.Flags().Duration(config.Keys.OAuthTokenCleanupInterval, values.OAuthTokenCleanupInterval, usage.OAuthTokenCleanupInterval)
+	cmd.Flags().Duration(config.Keys.OAuthAccessTokenExpiry, values.OAuthAccessTokenExpiry, usage.OAuthAccessTokenExpiry)
+	cmd.Flags().Duration(config.Keys.OAuthRefreshTokenExpiry, values.OAuthRefreshTokenExpiry, usage.OAuthRefreshTokenExpiry)
+	cmd.Flags().Duration(config.Keys.OAuthTokenInactivityExpiry, values.OAuthTokenInactivityExpiry, usage.OAuthTokenInactivityExpiry)
 }
 
 // Media attaches flags pertaining to media config.
 func Media(cmd *cobra.Command, values config.Values) {
 	cmd.Flags().Int(config.Keys.MediaImageMaxSize, values.MediaImageMaxSize, usage.MediaImageMaxSize)
 	cmd.Flags().Int(config.Keys.MediaVideoMaxSize, values.MediaVideoMaxSize, usage.MediaVideoMaxSize)
+	cmd.Flags().Int(config.Keys.MediaImageMaxDimension, values.MediaImageMaxDimension, usage.MediaImageMaxDimension)
+	cmd.Flags().Int(config.Keys.MediaImageMaxPixels, values.MediaImageMaxPixels, usage.MediaImageMaxPixels)
+	cmd.Flags().Int(config.Keys.MediaThumbnailMaxDimension, values.MediaThumbnailMaxDimension, usage.MediaThumbnailMaxDimension)
+	cmd.Flags().Int(config.Keys.MediaEmojiMaxSize, values.MediaEmojiMaxSize, usage.MediaEmojiMaxSize)
+	cmd.Flags().Int(config.Keys.MediaEmojiMaxDimension, values.MediaEmojiMaxDimension, usage.MediaEmojiMaxDimension)
 	cmd.Flags().Int(config.Keys.MediaDescriptionMinChars, values.MediaDescriptionMinChars, usage.MediaDescriptionMinChars)
 	cmd.Flags().Int(config.Keys.MediaDescriptionMaxChars, values.MediaDescriptionMaxChars, usage.MediaDescriptionMaxChars)
+	cmd.Flags().Bool(config.Keys.MediaAutoDescribeEnabled, values.MediaAutoDescribeEnabled, usage.MediaAutoDescribeEnabled)
+	cmd.Flags().String(config.Keys.MediaAutoDescribeURL, values.MediaAutoDescribeURL, usage.MediaAutoDescribeURL)
 	cmd.Flags().Int(config.Keys.MediaRemoteCacheDays, values.MediaRemoteCacheDays, usage.MediaRemoteCacheDays)
+	cmd.Flags().Duration(config.Keys.MediaRemoteCachePruneInterval, values.MediaRemoteCachePruneInterval, usage.MediaRemoteCachePruneInterval)
+	cmd.Flags().Int(config.Keys.MediaProcessingConcurrency, values.MediaProcessingConcurrency, usage.MediaProcessingConcurrency)
+	cmd.Flags().Int(config.Keys.MediaProcessingQueueSize, values.MediaProcessingQueueSize, usage.MediaProcessingQueueSize)
+	cmd.Flags().Int(config.Keys.MediaSyncProcessingMaxSize, values.MediaSyncProcessingMaxSize, usage.MediaSyncProcessingMaxSize)
+	cmd.Flags().Duration(config.Keys.MediaCacheMaxAge, values.MediaCacheMaxAge, usage.MediaCacheMaxAge)
+	cmd.Flags().Duration(config.Keys.MediaUnattachedRetention, values.MediaUnattachedRetention, usage.MediaUnattachedRetention)
 }
 
 // Storage attaches flags pertaining to storage config.
@@ -76,9 +145,26 @@ func Storage(cmd *cobra.Command, values config.Values) {
 func Statuses(cmd *cobra.Command, values config.Values) {
 	cmd.Flags().Int(config.Keys.StatusesMaxChars, values.StatusesMaxChars, usage.StatusesMaxChars)
 	cmd.Flags().Int(config.Keys.StatusesCWMaxChars, values.StatusesCWMaxChars, usage.StatusesCWMaxChars)
+	cmd.Flags().Bool(config.Keys.StatusesReplyInheritCW, values.StatusesReplyInheritCW, usage.StatusesReplyInheritCW)
+	cmd.Flags().Bool(config.Keys.StatusesReplyInheritVisibility, values.StatusesReplyInheritVisibility, usage.StatusesReplyInheritVisibility)
+	cmd.Flags().Int(config.Keys.StatusesArchiveAfterDays, values.StatusesArchiveAfterDays, usage.StatusesArchiveAfterDays)
+	cmd.Flags().Bool(config.Keys.StatusesArchiveHideDirect, values.StatusesArchiveHideDirect, usage.StatusesArchiveHideDirect)
 	cmd.Flags().Int(config.Keys.StatusesPollMaxOptions, values.StatusesPollMaxOptions, usage.StatusesPollMaxOptions)
 	cmd.Flags().Int(config.Keys.StatusesPollOptionMaxChars, values.StatusesPollOptionMaxChars, usage.StatusesPollOptionMaxChars)
 	cmd.Flags().Int(config.Keys.StatusesMediaMaxFiles, values.StatusesMediaMaxFiles, usage.StatusesMediaMaxFiles)
+	cmd.Flags().Duration(config.Keys.StatusesMentionResolveTimeout, values.StatusesMentionResolveTimeout, usage.StatusesMentionResolveTimeout)
+	cmd.Flags().StringSlice(config.Keys.StatusesRemoteAllowedElements, values.StatusesRemoteAllowedElements, usage.StatusesRemoteAllowedElements)
+	cmd.Flags().StringSlice(config.Keys.StatusesRemoteAllowedAttributes, values.StatusesRemoteAllowedAttributes, usage.StatusesRemoteAllowedAttributes)
+	cmd.Flags().Duration(config.Keys.StatusesTombstoneRetention, values.StatusesTombstoneRetention, usage.StatusesTombstoneRetention)
+	cmd.Flags().Duration(config.Keys.StatusesAutoDeleteInterval, values.StatusesAutoDeleteInterval, usage.StatusesAutoDeleteInterval)
+	cmd.Flags().Bool(config.Keys.StatusesLinkPreviewEnabled, values.StatusesLinkPreviewEnabled, usage.StatusesLinkPreviewEnabled)
+	cmd.Flags().Duration(config.Keys.StatusesLinkPreviewTimeout, values.StatusesLinkPreviewTimeout, usage.StatusesLinkPreviewTimeout)
+	cmd.Flags().Int(config.Keys.StatusesLinkPreviewMaxSize, values.StatusesLinkPreviewMaxSize, usage.StatusesLinkPreviewMaxSize)
+	cmd.Flags().Duration(config.Keys.StatusesDuplicateWindow, values.StatusesDuplicateWindow, usage.StatusesDuplicateWindow)
+	cmd.Flags().Bool(config.Keys.StatusesAutoCWMediaOnly, values.StatusesAutoCWMediaOnly, usage.StatusesAutoCWMediaOnly)
+	cmd.Flags().Int(config.Keys.StatusesAutoCWMaxLinks, values.StatusesAutoCWMaxLinks, usage.StatusesAutoCWMaxLinks)
+	cmd.Flags().String(config.Keys.StatusesAutoCWText, values.StatusesAutoCWText, usage.StatusesAutoCWText)
+	cmd.Flags().Bool(config.Keys.StatusesDeletePreserveMedia, values.StatusesDeletePreserveMedia, usage.StatusesDeletePreserveMedia)
 }
 
 // LetsEncrypt attaches flags pertaining to letsencrypt config.
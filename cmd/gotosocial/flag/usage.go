@@ -21,62 +21,145 @@ package flag
 import "github.com/superseriousbusiness/gotosocial/internal/config"
 
 var usage = config.KeyNames{
-	LogLevel:                   "Log level to run at: [trace, debug, info, warn, fatal]",
-	LogDbQueries:               "Log database queries verbosely when log-level is trace or debug",
-	ApplicationName:            "Name of the application, used in various places internally",
-	ConfigPath:                 "Path to a file containing gotosocial configuration. Values set in this file will be overwritten by values set as env vars or arguments",
-	Host:                       "Hostname to use for the server (eg., example.org, gotosocial.whatever.com). DO NOT change this on a server that's already run!",
-	AccountDomain:              "Domain to use in account names (eg., example.org, whatever.com). If not set, will default to the setting for host. DO NOT change this on a server that's already run!",
-	Protocol:                   "Protocol to use for the REST api of the server (only use http for debugging and tests!)",
-	BindAddress:                "Bind address to use for the GoToSocial server (eg., 0.0.0.0, 172.138.0.9, [::], localhost). For ipv6, enclose the address in square brackets, eg [2001:db8::fed1]. Default binds to all interfaces.",
-	Port:                       "Port to use for GoToSocial. Change this to 443 if you're running the binary directly on the host machine.",
-	TrustedProxies:             "Proxies to trust when parsing x-forwarded headers into real IPs.",
-	DbType:                     "Database type: eg., postgres",
-	DbAddress:                  "Database ipv4 address, hostname, or filename",
-	DbPort:                     "Database port",
-	DbUser:                     "Database username",
-	DbPassword:                 "Database password",
-	DbDatabase:                 "Database name",
-	DbTLSMode:                  "Database tls mode",
-	DbTLSCACert:                "Path to CA cert for db tls connection",
-	WebTemplateBaseDir:         "Basedir for html templating files for rendering pages and composing emails.",
-	WebAssetBaseDir:            "Directory to serve static assets from, accessible at example.org/assets/",
-	AccountsRegistrationOpen:   "Allow anyone to submit an account signup request. If false, server will be invite-only.",
-	AccountsApprovalRequired:   "Do account signups require approval by an admin or moderator before user can log in? If false, new registrations will be automatically approved.",
-	AccountsReasonRequired:     "Do new account signups require a reason to be submitted on registration?",
-	MediaImageMaxSize:          "Max size of accepted images in bytes",
-	MediaVideoMaxSize:          "Max size of accepted videos in bytes",
-	MediaDescriptionMinChars:   "Min required chars for an image description",
-	MediaDescriptionMaxChars:   "Max permitted chars for an image description",
-	MediaRemoteCacheDays:       "Number of days to locally cache media from remote instances. If set to 0, remote media will be kept indefinitely.",
-	StorageBackend:             "Storage backend to use for media attachments",
-	StorageLocalBasePath:       "Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir.",
-	StatusesMaxChars:           "Max permitted characters for posted statuses",
-	StatusesCWMaxChars:         "Max permitted characters for content/spoiler warnings on statuses",
-	StatusesPollMaxOptions:     "Max amount of options permitted on a poll",
-	StatusesPollOptionMaxChars: "Max amount of characters for a poll option",
-	StatusesMediaMaxFiles:      "Maximum number of media files/attachments per status",
-	LetsEncryptEnabled:         "Enable letsencrypt TLS certs for this server. If set to true, then cert dir also needs to be set (or take the default).",
-	LetsEncryptPort:            "Port to listen on for letsencrypt certificate challenges. Must not be the same as the GtS webserver/API port.",
-	LetsEncryptCertDir:         "Directory to store acquired letsencrypt certificates.",
-	LetsEncryptEmailAddress:    "Email address to use when requesting letsencrypt certs. Will receive updates on cert expiry etc.",
-	OIDCEnabled:                "Enabled OIDC authorization for this instance. If set to true, then the other OIDC flags must also be set.",
-	OIDCIdpName:                "Name of the OIDC identity provider. Will be shown to the user when logging in.",
-	OIDCSkipVerification:       "Skip verification of tokens returned by the OIDC provider. Should only be set to 'true' for testing purposes, never in a production environment!",
-	OIDCIssuer:                 "Address of the OIDC issuer. Should be the web address, including protocol, at which the issuer can be reached. Eg., 'https://example.org/auth'",
-	OIDCClientID:               "ClientID of GoToSocial, as registered with the OIDC provider.",
-	OIDCClientSecret:           "ClientSecret of GoToSocial, as registered with the OIDC provider.",
-	OIDCScopes:                 "OIDC scopes.",
-	SMTPHost:                   "Host of the smtp server. Eg., 'smtp.eu.mailgun.org'",
-	SMTPPort:                   "Port of the smtp server. Eg., 587",
-	SMTPUsername:               "Username to authenticate with the smtp server as. Eg., 'postmaster@mail.example.org'",
-	SMTPPassword:               "Password to pass to the smtp server.",
-	SMTPFrom:                   "Address to use as the 'from' field of the email. Eg., 'gotosocial@example.org'",
-	SyslogEnabled:              "Enable the syslog logging hook. Logs will be mirrored to the configured destination.",
-	SyslogProtocol:             "Protocol to use when directing logs to syslog. Leave empty to connect to local syslog.",
-	SyslogAddress:              "Address:port to send syslog logs to. Leave empty to connect to local syslog.",
-	AdminAccountUsername:       "the username to create/delete/etc",
-	AdminAccountEmail:          "the email address of this account",
-	AdminAccountPassword:       "the password to set for this account",
-	AdminTransPath:             "the path of the file to import from/export to",
+	LogLevel:                                "Log level to run at: [trace, debug, info, warn, fatal]",
+	LogDbQueries:                            "Log database queries verbosely when log-level is trace or debug",
+	LogSamplePeriod:                         "Period over which repeated federation warnings and errors from the same domain are collapsed into a summary. Repeats are still logged at debug level. 0 disables sampling.",
+	ApplicationName:                         "Name of the application, used in various places internally",
+	ConfigPath:                              "Path to a file containing gotosocial configuration. Values set in this file will be overwritten by values set as env vars or arguments",
+	Host:                                    "Hostname to use for the server (eg., example.org, gotosocial.whatever.com). DO NOT change this on a server that's already run!",
+	AccountDomain:                           "Domain to use in account names (eg., example.org, whatever.com). If not set, will default to the setting for host. DO NOT change this on a server that's already run!",
+	Protocol:                                "Protocol to use for the REST api of the server (only use http for debugging and tests!)",
+	BindAddress:                             "Bind address to use for the GoToSocial server (eg., 0.0.0.0, 172.138.0.9, [::], localhost). For ipv6, enclose the address in square brackets, eg [2001:db8::fed1]. Default binds to all interfaces.",
+	Port:                                    "Port to use for GoToSocial. Change this to 443 if you're running the binary directly on the host machine.",
+	TrustedProxies:                          "Proxies to trust when parsing x-forwarded headers into real IPs.",
+	TrustedProxyHeaders:                     "Headers, in order of preference, from which to take the real client IP of requests coming from a trusted proxy, eg., X-Real-IP or CF-Connecting-IP.",
+	CORSAllowOrigins:                        "Origins from which browsers may make cross-origin requests to the API, eg., https://my.web.client. Use * to allow all origins.",
+	CORSAllowMethods:                        "HTTP methods that may be used in cross-origin requests to the API.",
+	CORSAllowHeaders:                        "Request headers that may be sent in cross-origin requests to the API. Headers needed for websocket upgrades are always allowed.",
+	GzipLevel:                               "Gzip compression level for http responses, from 1 (fastest) to 9 (smallest). -1 uses the default level, 0 disables gzip compression.",
+	GzipExcludedContentTypes:                "Content types of http responses which should not be gzip compressed, eg., already compressed media. A subtype of * matches all subtypes, eg., image/*.",
+	GzipMinSize:                             "Http responses smaller than this many bytes will not be gzip compressed.",
+	GzipMaxSize:                             "Http responses larger than this many bytes will not be gzip compressed. 0 means no limit.",
+	SessionCookieDomain:                     "Domain to set on session cookies, eg., example.org to share them with subdomains. Defaults to the value of host.",
+	SessionCookieSameSite:                   "SameSite attribute of session cookies. Options: [lax, strict, none]",
+	SessionCookieSecure:                     "Only send session cookies over https. Defaults to true if protocol is https, and false otherwise. This cannot be disabled if protocol is https.",
+	SessionCookieMaxAge:                     "How long session cookies, used while signing in, should last for.",
+	DbType:                                  "Database type: eg., postgres",
+	DbAddress:                               "Database ipv4 address, hostname, or filename",
+	DbPort:                                  "Database port",
+	DbUser:                                  "Database username",
+	DbPassword:                              "Database password",
+	DbDatabase:                              "Database name",
+	DbTLSMode:                               "Database tls mode",
+	DbTLSCACert:                             "Path to CA cert for db tls connection",
+	DbSlowQueryThreshold:                    "Log database queries that take longer than this at warn level. 0 disables slow query logging.",
+	WebTemplateBaseDir:                      "Basedir for html templating files for rendering pages and composing emails.",
+	WebAssetBaseDir:                         "Directory to serve static assets from, accessible at example.org/assets/",
+	WebAssetCacheMaxAge:                     "How long browsers may cache web assets for. 0 means they must always be revalidated.",
+	WebAssetHashedCacheMaxAge:               "How long browsers may cache web assets with a content hash in their filename for. Such assets are also marked immutable.",
+	WebContentSecurityPolicy:                "Content-Security-Policy header to send with html pages. If not set, a default policy allowing only resources from this instance will be used.",
+	InstanceAuthorizedFetch:                 "Require a valid http signature on ActivityPub GET requests to actors and statuses on this instance (sometimes called 'secure mode').",
+	InstanceFederationAcceptedActivityTypes: "ActivityPub activity types to accept in inboxes. Activities of any other type will be accepted but dropped without being processed.",
+	InstanceFederationRejectedActivityTypes: "ActivityPub activity types to drop without processing when they're delivered to inboxes, even if they're in the accepted list, eg., Move or Flag.",
+	InstanceLanguages:                       "BCP47 language tags (eg., en, de) of the primary languages of this instance, most important first. Shown in the instance API.",
+	InstanceReadOnly:                        "Put the instance in read-only mode, so that it keeps serving reads but rejects writes like new statuses, follows and media uploads. Useful during upgrades and migrations. Can be overridden at runtime by an admin.",
+	InstanceReadOnlyMessage:                 "Message to show to users when the instance is in read-only mode. Can be overridden at runtime by an admin.",
+	InstanceReadOnlyRejectFederation:        "Also reject activities delivered to inboxes while the instance is in read-only mode, so that remote instances retry them later.",
+	FederationMaxThreadDepth:                "Maximum number of ancestors of a remote status to dereference when fetching its thread. 0 means no limit.",
+	InstanceMaxPageSize:                     "Maximum number of items that clients can request in a single page of results from the client API.",
+	InstanceAPIJSONPretty:                   "Pretty-print JSON responses from the client API by default. Clients can override this with the pretty query parameter.",
+	InstanceAPIJSONOmitEmpty:                "Omit null and empty fields from JSON responses from the client API by default. Clients can override this with the omit_empty query parameter.",
+	InstanceActiveUsersCacheDuration:        "How long to cache counts of active users, which are reported in nodeinfo, before counting them again.",
+	AccountsRegistrationOpen:                "Allow anyone to submit an account signup request. If false, server will be invite-only.",
+	AccountsApprovalRequired:                "Do account signups require approval by an admin or moderator before user can log in? If false, new registrations will be automatically approved.",
+	AccountsReasonRequired:                  "Do new account signups require a reason to be submitted on registration?",
+	AccountsArchiveInterval:                 "Minimum time between requests for an archive of an account's data. 0 means no limit.",
+	AccountsKeyGracePeriod:                  "How long an account's old public key remains valid after its keypair is rotated. 0 means old keys stop working immediately.",
+	AccountsSignUpIPLimit:                   "Maximum number of sign ups allowed from one IP address within accounts-sign-up-ip-window. 0 means no limit.",
+	AccountsSignUpIPWindow:                  "Window of time over which sign ups from one IP address are counted for accounts-sign-up-ip-limit.",
+	AccountsEmailDomainBlocklist:            "Email domains, eg., disposable email providers, from which sign ups are not allowed. Subdomains are blocked too.",
+	AccountsEmailDomainBlocklistFile:        "Path to a file of email domains, one per line, from which sign ups are not allowed, in addition to accounts-email-domain-blocklist.",
+	AccountsMaxFields:                       "Maximum amount of profile fields an account can have.",
+	AccountsFieldNameMaxChars:               "Maximum amount of characters allowed in the name of a profile field.",
+	AccountsFieldValueMaxChars:              "Maximum amount of characters allowed in the value of a profile field.",
+	AccountsCustomCSSEnabled:                "Allow accounts to set custom CSS which is included on their profile page.",
+	AccountsCustomCSSAllowedHosts:           "Hosts, other than this one, from which custom CSS is allowed to load resources with url().",
+	AccountsFieldVerificationInterval:       "Interval at which links in the profile fields of local accounts are re-verified. 0 disables re-verification.",
+	AccountsSuggestionsEnabled:              "Suggest accounts to follow to local accounts, based on who the accounts they follow follow. Set to false to not work out suggestions at all.",
+	AccountsMaxFollowing:                    "Maximum amount of accounts that a local account can follow. 0 means no limit.",
+	AccountsMinAge:                          "Minimum age of a local account before it can follow, post publicly, or mention accounts that didn't initiate contact. 0 means no minimum.",
+	AccountsRemoteRefreshInterval:           "How long to use a stored remote account before fetching it again from its instance in the background, to keep its profile current. 0 never refreshes it.",
+	AccountsPasswordBcryptCost:              "Bcrypt cost to hash passwords with. Passwords hashed with a lower cost are rehashed at this cost when their user next signs in.",
+	AccountsSignInMaxFailures:               "Number of failed sign ins for one account, or from one IP address, within accounts-sign-in-failure-window after which further sign ins are locked for accounts-sign-in-lockout. 0 never locks sign ins.",
+	AccountsSignInFailureWindow:             "Window of time over which failed sign ins are counted for accounts-sign-in-max-failures.",
+	AccountsSignInLockout:                   "How long sign ins are locked for, once accounts-sign-in-max-failures is reached.",
+	OAuthTokenCleanupInterval:               "Interval at which expired oauth tokens are removed from the database. 0 disables cleanup.",
+	OAuthAccessTokenExpiry:                  "How long newly issued oauth access tokens are valid for. If set, a refresh token is issued alongside each access token. 0 means access tokens never expire.",
+	OAuthRefreshTokenExpiry:                 "How long an unused oauth refresh token is valid for. 0 means refresh tokens never expire.",
+	OAuthTokenInactivityExpiry:              "How long an oauth token can go unused before it is removed by the token cleanup, even if it never expires otherwise. 0 means tokens never expire from inactivity.",
+	MediaImageMaxSize:                       "Max size of accepted images in bytes",
+	MediaVideoMaxSize:                       "Max size of accepted videos in bytes",
+	MediaImageMaxDimension:                  "Max width or height of accepted images in pixels. 0 means no limit.",
+	MediaImageMaxPixels:                     "Max total pixel count (width * height) of accepted images. 0 means no limit.",
+	MediaThumbnailMaxDimension:              "Max width or height in pixels of generated image thumbnails. Aspect ratio is preserved, and smaller images are not upscaled.",
+	MediaEmojiMaxSize:                       "Max size of custom emoji images in bytes, for both local uploads and emojis fetched from remote instances",
+	MediaEmojiMaxDimension:                  "Max width or height in pixels of custom emoji images. Larger emojis are scaled down to fit, preserving aspect ratio and animation. 0 means no limit.",
+	MediaDescriptionMinChars:                "Min required chars for an image description",
+	MediaDescriptionMaxChars:                "Max permitted chars for an image description",
+	MediaAutoDescribeEnabled:                "Send uploaded images without a description to an external provider, and use the returned text as the description.",
+	MediaAutoDescribeURL:                    "URL of the image description provider. Images are POSTed to this URL, and a JSON response with a 'description' field is expected.",
+	MediaRemoteCacheDays:                    "Number of days to locally cache media from remote instances. If set to 0, remote media will be kept indefinitely.",
+	MediaRemoteCachePruneInterval:           "How often to remove remote media older than media-remote-cache-days from the cache. If set to 0, remote media will not be pruned automatically.",
+	MediaProcessingConcurrency:              "Max number of media items to process (decode, thumbnail, etc) at the same time. If set to 0, defaults to the number of available CPUs.",
+	MediaProcessingQueueSize:                "Max number of media items waiting to be processed. Media beyond this will be rejected until the queue drains. If set to 0, defaults to 10 times the processing concurrency.",
+	MediaSyncProcessingMaxSize:              "Max size in bytes of uploaded media that will be processed before responding to the upload request. Bigger uploads are processed in the background.",
+	MediaCacheMaxAge:                        "How long browsers may cache media files served by this instance for. 0 means they must always be revalidated.",
+	MediaUnattachedRetention:                "How long to keep uploaded media which isn't attached to any status before deleting it. 0 means it's kept forever.",
+	StorageBackend:                          "Storage backend to use for media attachments",
+	StorageLocalBasePath:                    "Full path to an already-created directory where gts should store/retrieve media files. Subfolders will be created within this dir.",
+	StatusesMaxChars:                        "Max permitted characters for posted statuses",
+	StatusesCWMaxChars:                      "Max permitted characters for content/spoiler warnings on statuses",
+	StatusesReplyInheritCW:                  "Give replies the content warning and sensitivity of the status they reply to, if they don't set a content warning of their own.",
+	StatusesReplyInheritVisibility:          "Give replies that don't set a visibility of their own the default visibility, but never more visible than the status they reply to.",
+	StatusesArchiveAfterDays:                "Hide statuses older than this many days from public timelines and profiles, unless their author overrides it. 0 to never hide statuses.",
+	StatusesArchiveHideDirect:               "Also hide statuses that are old enough to be hidden from public view when they are fetched directly by their ID or URL.",
+	StatusesPollMaxOptions:                  "Max amount of options permitted on a poll",
+	StatusesPollOptionMaxChars:              "Max amount of characters for a poll option",
+	StatusesMediaMaxFiles:                   "Maximum number of media files/attachments per status",
+	StatusesMentionResolveTimeout:           "How long to wait for mentioned remote accounts to be resolved before posting a status. Mentions which aren't resolved in time are resolved and linked in the background.",
+	StatusesRemoteAllowedElements:           "HTML elements permitted in the content of statuses and account notes received from remote instances",
+	StatusesRemoteAllowedAttributes:         "HTML attributes permitted in remote content, in the form element.attribute",
+	StatusesTombstoneRetention:              "How long to remember the URIs of deleted statuses and accounts, so that they aren't recreated by delayed federation. 0 remembers them forever.",
+	StatusesAutoDeleteInterval:              "How often to delete the old statuses of accounts which have opted into auto-deletion. 0 disables auto-deletion.",
+	StatusesLinkPreviewEnabled:              "Fetch the first link in new statuses, and show a preview card for it built from the metadata of the linked page.",
+	StatusesLinkPreviewTimeout:              "How long to wait for a linked page, and its oEmbed metadata, to be fetched when generating a preview card.",
+	StatusesLinkPreviewMaxSize:              "Max size in bytes of a linked page to read when generating a preview card. Anything beyond this is ignored.",
+	StatusesDuplicateWindow:                 "Reject statuses which are identical to the previous status of the same account, if it was posted less than this long ago. 0 allows duplicates.",
+	StatusesAutoCWMediaOnly:                 "Automatically give a content warning, and mark as sensitive, new statuses which have media attached but no text.",
+	StatusesAutoCWMaxLinks:                  "Automatically give a content warning, and mark as sensitive, new statuses which contain more than this many links. 0 disables this.",
+	StatusesAutoCWText:                      "Content warning to give statuses which get one automatically.",
+	StatusesDeletePreserveMedia:             "Keep the media of deleted statuses, detached from them, so that it can be attached to a new status. Clients can override this with the delete_media param.",
+	LetsEncryptEnabled:                      "Enable letsencrypt TLS certs for this server. If set to true, then cert dir also needs to be set (or take the default).",
+	LetsEncryptPort:                         "Port to listen on for letsencrypt certificate challenges. Must not be the same as the GtS webserver/API port.",
+	LetsEncryptCertDir:                      "Directory to store acquired letsencrypt certificates.",
+	LetsEncryptEmailAddress:                 "Email address to use when requesting letsencrypt certs. Will receive updates on cert expiry etc.",
+	OIDCEnabled:                             "Enabled OIDC authorization for this instance. If set to true, then the other OIDC flags must also be set.",
+	OIDCIdpName:                             "Name of the OIDC identity provider. Will be shown to the user when logging in.",
+	OIDCSkipVerification:                    "Skip verification of tokens returned by the OIDC provider. Should only be set to 'true' for testing purposes, never in a production environment!",
+	OIDCIssuer:                              "Address of the OIDC issuer. Should be the web address, including protocol, at which the issuer can be reached. Eg., 'https://example.org/auth'",
+	OIDCClientID:                            "ClientID of GoToSocial, as registered with the OIDC provider.",
+	OIDCClientSecret:                        "ClientSecret of GoToSocial, as registered with the OIDC provider.",
+	OIDCScopes:                              "OIDC scopes.",
+	SMTPHost:                                "Host of the smtp server. Eg., 'smtp.eu.mailgun.org'",
+	SMTPPort:                                "Port of the smtp server. Eg., 587",
+	SMTPUsername:                            "Username to authenticate with the smtp server as. Eg., 'postmaster@mail.example.org'",
+	SMTPPassword:                            "Password to pass to the smtp server.",
+	SMTPFrom:                                "Address to use as the 'from' field of the email. Eg., 'gotosocial@example.org'",
+	SyslogEnabled:                           "Enable the syslog logging hook. Logs will be mirrored to the configured destination.",
+	SyslogProtocol:                          "Protocol to use when directing logs to syslog. Leave empty to connect to local syslog.",
+	SyslogAddress:                           "Address:port to send syslog logs to. Leave empty to connect to local syslog.",
+	AdminAccountUsername:                    "the username to create/delete/etc",
+	AdminAccountEmail:                       "the email address of this account",
+	AdminAccountPassword:                    "the password to set for this account",
+	AdminTransPath:                          "the path of the file to import from/export to",
 }
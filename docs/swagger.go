@@ -36,7 +36,10 @@
 //           read: grants read access to everything
 //           read:accounts: grants read access to accounts
 //           read:blocks: grant read access to blocks
+//           read:favourites: grant read access to favourites
+//           read:follows: grant read access to follows and follow requests
 //           read:media: grant read access to media
+//           read:notifications: grant read access to notifications
 //           read:search: grant read access to searches
 //           read:statuses: grants read access to statuses
 //           read:streaming: grants read access to streaming api
@@ -48,6 +51,7 @@
 //           write:media: grants write access to media
 //           write:statuses: grants write access to statuses
 //           write:user: grants write access to user-level info
+//           follow: grants read and write access to blocks, follows, and mutes
 //           admin: grants admin access to everything
 //           admin:accounts: grants admin access to accounts
 //       OAuth2 Application:
@@ -70,3 +70,29 @@ const (
 	ObjectCollection     = "Collection"     // ActivityStreamsCollection https://www.w3.org/TR/activitystreams-vocabulary/#dfn-collection
 	ObjectCollectionPage = "CollectionPage" // ActivityStreamsCollectionPage https://www.w3.org/TR/activitystreams-vocabulary/#dfn-collectionpage
 )
+
+// Extensions to the activitystreams vocabulary which are used by other fediverse software.
+const (
+	ActivityEmojiReact = "EmojiReact" // LitePubEmojiReact https://docs.pleroma.social/backend/development/ap_extensions/#emojireacts
+
+	ObjectPropertyValue = "PropertyValue" // SchemaPropertyValue https://schema.org/PropertyValue, used by Mastodon for profile fields
+
+	// VerifiedAtProperty is the property of a PropertyValue profile field which holds the time its link was verified at, if it was.
+	VerifiedAtProperty = "verifiedAt"
+
+	// MisskeyReactionProperty is the property of a Like which Misskey uses to turn it into an emoji reaction.
+	MisskeyReactionProperty = "_misskey_reaction"
+
+	// QuoteURIProperty is the property of a status which Fedibird uses for the uri of the status it quotes.
+	QuoteURIProperty = "quoteUri"
+	// QuoteURLProperty is the property of a status which Misskey and Akkoma use for the uri of the status it quotes.
+	QuoteURLProperty = "quoteUrl"
+	// MisskeyQuoteProperty is the legacy property of a status which Misskey uses for the uri of the status it quotes.
+	MisskeyQuoteProperty = "_misskey_quote"
+
+	// ReplyPolicyProperty is the property of a status which holds who is permitted to reply to it.
+	ReplyPolicyProperty = "replyPolicy"
+)
+
+// QuoteProperties are all the properties which might hold the uri of the status quoted by a status, in order of preference.
+var QuoteProperties = []string{QuoteURIProperty, QuoteURLProperty, MisskeyQuoteProperty}
@@ -83,6 +83,36 @@ func ExtractInReplyToURI(i WithInReplyTo) *url.URL {
 	return nil
 }
 
+// ExtractQuoteURI extracts the uri of the status quoted by a status (if present) from an interface.
+//
+// There's no standard way of doing quotes yet, so this checks all the properties that other software uses for them.
+func ExtractQuoteURI(i WithUnknownProperties) *url.URL {
+	unknown := i.GetUnknownProperties()
+	for _, p := range QuoteProperties {
+		quote, ok := unknown[p].(string)
+		if !ok || quote == "" {
+			continue
+		}
+		if quoteURI, err := url.Parse(quote); err == nil && quoteURI.IsAbs() {
+			return quoteURI
+		}
+	}
+	// couldn't find a URI
+	return nil
+}
+
+// ExtractReplyPolicy extracts the reply policy of a status from an interface, returning
+// ReplyPolicyEveryone if it isn't set, or isn't set to a policy we know about.
+func ExtractReplyPolicy(i WithUnknownProperties) gtsmodel.ReplyPolicy {
+	policy, _ := i.GetUnknownProperties()[ReplyPolicyProperty].(string)
+	switch p := gtsmodel.ReplyPolicy(policy); p {
+	case gtsmodel.ReplyPolicyFollowers, gtsmodel.ReplyPolicyMentioned, gtsmodel.ReplyPolicyNobody:
+		return p
+	default:
+		return gtsmodel.ReplyPolicyEveryone
+	}
+}
+
 // ExtractURLItems extracts a slice of URLs from a property that has withItems.
 func ExtractURLItems(i WithItems) []*url.URL {
 	urls := []*url.URL{}
@@ -349,6 +379,21 @@ func ExtractContent(i WithContent) (string, error) {
 	return "", errors.New("no content found")
 }
 
+// ExtractReaction returns the emoji reaction carried by the given like, or an empty string if it's just a plain like.
+//
+// Misskey puts the reaction in a _misskey_reaction property, while EmojiReacts (which we turn into likes on the way in)
+// put it in the content property, so both are checked. For custom emojis, the reaction will be a :shortcode:.
+func ExtractReaction(i Reactable) string {
+	if reaction, ok := i.GetUnknownProperties()[MisskeyReactionProperty].(string); ok && reaction != "" {
+		return strings.TrimSpace(reaction)
+	}
+	content, err := ExtractContent(i)
+	if err != nil {
+		return ""
+	}
+	return strings.TrimSpace(content)
+}
+
 // ExtractAttachments returns a slice of attachments on the interface.
 func ExtractAttachments(i WithAttachment) ([]*gtsmodel.MediaAttachment, error) {
 	attachments := []*gtsmodel.MediaAttachment{}
@@ -61,6 +61,7 @@ type Statusable interface {
 	WithAttachment
 	WithTag
 	WithReplies
+	WithUnknownProperties
 }
 
 // Attachmentable represents the minimum activitypub interface for representing a 'mediaAttachment'.
@@ -113,6 +114,15 @@ type Likeable interface {
 	WithObject
 }
 
+// Reactable represents the minimum interface for an activitystreams 'like' activity which might carry an emoji reaction.
+type Reactable interface {
+	Likeable
+
+	WithContent
+	WithTag
+	WithUnknownProperties
+}
+
 // Blockable represents the minimum interface for an activitystreams 'block' activity.
 type Blockable interface {
 	WithJSONLDId
@@ -275,6 +285,11 @@ type WithTag interface {
 	GetActivityStreamsTag() vocab.ActivityStreamsTagProperty
 }
 
+// WithUnknownProperties represents an activity with properties which aren't part of the vocabulary we know about
+type WithUnknownProperties interface {
+	GetUnknownProperties() map[string]interface{}
+}
+
 // WithReplies represents an activity with ActivityStreamsRepliesProperty
 type WithReplies interface {
 	GetActivityStreamsReplies() vocab.ActivityStreamsRepliesProperty
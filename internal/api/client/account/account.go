@@ -46,6 +46,10 @@ const (
 	OnlyMediaKey = "only_media"
 	// OnlyPublicKey is for specifying that only statuses with visibility public should be returned in a list of returned statuses by account.
 	OnlyPublicKey = "only_public"
+	// ArchiveExpiresKey is for the expiry time of a signed archive download link.
+	ArchiveExpiresKey = "expires"
+	// ArchiveSignatureKey is for the signature of a signed archive download link.
+	ArchiveSignatureKey = "signature"
 
 	// IDKey is the key to use for retrieving account ID in requests
 	IDKey = "id"
@@ -65,6 +69,8 @@ const (
 	GetFollowingPath = BasePathWithID + "/following"
 	// GetRelationshipsPath is for showing an account's relationship with other accounts
 	GetRelationshipsPath = BasePath + "/relationships"
+	// GetFamiliarFollowersPath is for showing which accounts followed by an account also follow other accounts
+	GetFamiliarFollowersPath = BasePath + "/familiar_followers"
 	// FollowPath is for POSTing new follows to, and updating existing follows
 	FollowPath = BasePathWithID + "/follow"
 	// UnfollowPath is for POSTing an unfollow
@@ -75,6 +81,22 @@ const (
 	UnblockPath = BasePathWithID + "/unblock"
 	// DeleteAccountPath is for deleting one's account via the API
 	DeleteAccountPath = BasePath + "/delete"
+	// RotateKeysPath is for rotating one's keypair via the API
+	RotateKeysPath = BasePath + "/rotate_keys"
+	// ArchivePath is for requesting and checking on archives of one's account data
+	ArchivePath = BasePath + "/archive"
+	// ArchiveDownloadPath is for downloading an archive of account data with a signed link
+	ArchiveDownloadPath = ArchivePath + "/:" + IDKey + "/download"
+	// FollowImportPath is for importing lists of accounts to follow, and checking on how the import is going
+	FollowImportPath = BasePath + "/import/follows"
+	// BlockImportPath is for importing lists of accounts to block, and checking on how the import is going
+	BlockImportPath = BasePath + "/import/blocks"
+	// BlocksExportPath is for exporting the list of accounts one blocks
+	BlocksExportPath = BasePath + "/export/blocks"
+	// ProfileAvatarPath is for removing one's avatar
+	ProfileAvatarPath = "/api/v1/profile/avatar"
+	// ProfileHeaderPath is for removing one's header
+	ProfileHeaderPath = "/api/v1/profile/header"
 )
 
 // Module implements the ClientAPIModule interface for account-related actions
@@ -113,6 +135,9 @@ func (m *Module) Route(r router.Router) error {
 	// get relationship with account
 	r.AttachHandler(http.MethodGet, GetRelationshipsPath, m.AccountRelationshipsGETHandler)
 
+	// get familiar followers
+	r.AttachHandler(http.MethodGet, GetFamiliarFollowersPath, m.AccountFamiliarFollowersGETHandler)
+
 	// follow or unfollow account
 	r.AttachHandler(http.MethodPost, FollowPath, m.AccountFollowPOSTHandler)
 	r.AttachHandler(http.MethodPost, UnfollowPath, m.AccountUnfollowPOSTHandler)
@@ -121,6 +146,27 @@ func (m *Module) Route(r router.Router) error {
 	r.AttachHandler(http.MethodPost, BlockPath, m.AccountBlockPOSTHandler)
 	r.AttachHandler(http.MethodPost, UnblockPath, m.AccountUnblockPOSTHandler)
 
+	// rotate account keypair
+	r.AttachHandler(http.MethodPost, RotateKeysPath, m.AccountRotateKeysPOSTHandler)
+
+	// request, check on, or download an archive of account data
+	r.AttachHandler(http.MethodPost, ArchivePath, m.AccountArchivePOSTHandler)
+	r.AttachHandler(http.MethodGet, ArchivePath, m.AccountArchiveGETHandler)
+	r.AttachHandler(http.MethodGet, ArchiveDownloadPath, m.AccountArchiveDownloadGETHandler)
+
+	// import accounts to follow, or check on how the import is going
+	r.AttachHandler(http.MethodPost, FollowImportPath, m.AccountFollowImportPOSTHandler)
+	r.AttachHandler(http.MethodGet, FollowImportPath, m.AccountFollowImportGETHandler)
+
+	// import or export accounts to block
+	r.AttachHandler(http.MethodPost, BlockImportPath, m.AccountBlockImportPOSTHandler)
+	r.AttachHandler(http.MethodGet, BlockImportPath, m.AccountBlockImportGETHandler)
+	r.AttachHandler(http.MethodGet, BlocksExportPath, m.AccountBlocksExportGETHandler)
+
+	// remove avatar or header
+	r.AttachHandler(http.MethodDelete, ProfileAvatarPath, m.AccountAvatarDELETEHandler)
+	r.AttachHandler(http.MethodDelete, ProfileHeaderPath, m.AccountHeaderDELETEHandler)
+
 	return nil
 }
 
@@ -30,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api"
 	"github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
@@ -68,34 +69,39 @@ import (
 //      description: bad request
 //   '404':
 //      description: not found
+//   '422':
+//      description: email address or username in use, or sign ups from this email domain are not allowed
+//   '429':
+//      description: too many sign ups from this ip address
 //   '500':
 //      description: internal error
 func (m *Module) AccountCreatePOSTHandler(c *gin.Context) {
 	l := logrus.WithField("func", "accountCreatePOSTHandler")
 	authed, err := oauth.Authed(c, true, true, false, false)
 	if err != nil {
-		l.Debugf("couldn't auth: %s", err)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		api.ErrorHandler(c, gtserror.NewError(http.StatusUnauthorized, err, err.Error()))
+		return
+	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeWriteAccounts) {
 		return
 	}
 
 	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
-		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		api.ErrorHandler(c, gtserror.NewError(http.StatusNotAcceptable, err, err.Error()))
 		return
 	}
 
 	l.Trace("parsing request form")
 	form := &model.AccountCreateRequest{}
 	if err := c.ShouldBind(form); err != nil || form == nil {
-		l.Debugf("could not parse form from request: %s", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "missing one or more required form values"})
+		api.ErrorHandler(c, gtserror.NewError(http.StatusBadRequest, err, "missing one or more required form values"))
 		return
 	}
 
 	l.Tracef("validating form %+v", form)
 	if err := validateCreateAccount(form); err != nil {
-		l.Debugf("error validating form: %s", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		api.ErrorHandler(c, gtserror.NewError(http.StatusBadRequest, err, err.Error()))
 		return
 	}
 
@@ -104,16 +110,16 @@ func (m *Module) AccountCreatePOSTHandler(c *gin.Context) {
 	signUpIP := net.ParseIP(clientIP)
 	if signUpIP == nil {
 		l.Debugf("error validating sign up ip address %s", clientIP)
-		c.JSON(http.StatusBadRequest, gin.H{"error": "ip address could not be parsed from request"})
+		err := errors.New("ip address could not be parsed from request")
+		api.ErrorHandler(c, gtserror.NewError(http.StatusBadRequest, err, err.Error()))
 		return
 	}
 
 	form.IP = signUpIP
 
-	ti, err := m.processor.AccountCreate(c.Request.Context(), authed, form)
-	if err != nil {
-		l.Errorf("internal server error while creating new account: %s", err)
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	ti, errWithCode := m.processor.AccountCreate(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		api.ErrorHandler(c, errWithCode)
 		return
 	}
 
@@ -125,10 +131,6 @@ func (m *Module) AccountCreatePOSTHandler(c *gin.Context) {
 func validateCreateAccount(form *model.AccountCreateRequest) error {
 	keys := config.Keys
 
-	if !viper.GetBool(keys.AccountsRegistrationOpen) {
-		return errors.New("registration is not open for this server")
-	}
-
 	if err := validate.Username(form.Username); err != nil {
 		return err
 	}
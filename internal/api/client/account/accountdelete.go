@@ -19,12 +19,15 @@
 package account
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
 	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
@@ -61,28 +64,31 @@ func (m *Module) AccountDeletePOSTHandler(c *gin.Context) {
 	l := logrus.WithField("func", "AccountDeletePOSTHandler")
 	authed, err := oauth.Authed(c, true, true, true, true)
 	if err != nil {
-		l.Debugf("couldn't auth: %s", err)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		api.ErrorHandler(c, gtserror.NewError(http.StatusUnauthorized, err, err.Error()))
+		return
+	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeWriteAccounts) {
 		return
 	}
 	l.Tracef("retrieved account %+v", authed.Account.ID)
 
 	form := &model.AccountDeleteRequest{}
 	if err := c.ShouldBind(&form); err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		api.ErrorHandler(c, gtserror.NewError(http.StatusBadRequest, err, err.Error()))
 		return
 	}
 
 	if form.Password == "" {
-		c.JSON(http.StatusBadRequest, gin.H{"error": "no password provided in account delete request"})
+		err := errors.New("no password provided in account delete request")
+		api.ErrorHandler(c, gtserror.NewError(http.StatusBadRequest, err, err.Error()))
 		return
 	}
 
 	form.DeleteOriginID = authed.Account.ID
 
 	if errWithCode := m.processor.AccountDeleteLocal(c.Request.Context(), authed, form); errWithCode != nil {
-		l.Debugf("could not delete account: %s", errWithCode.Error())
-		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		api.ErrorHandler(c, errWithCode)
 		return
 	}
 
@@ -19,8 +19,12 @@
 package account
 
 import (
+	"errors"
 	"fmt"
 	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
 	"strconv"
 
 	"github.com/sirupsen/logrus"
@@ -28,6 +32,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/api"
 	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
@@ -76,6 +81,30 @@ import (
 //   in: formData
 //   description: Require manual approval of follow requests.
 //   type: boolean
+// - name: unquotable
+//   in: formData
+//   description: Don't allow other accounts to quote statuses authored by this account.
+//   type: boolean
+// - name: archive_after_days
+//   in: formData
+//   description: Hide statuses authored by this account from public view after this many days. 0 to use the setting of the instance.
+//   type: integer
+// - name: custom_css
+//   in: formData
+//   description: |-
+//     Custom CSS to include on the account's profile page, if enabled on this instance.
+//     Selectors are scoped to the profile, and @import and url() references to other hosts aren't allowed.
+//   type: string
+// - name: fields_attributes[0][name]
+//   in: formData
+//   description: Name of the first profile field. Use fields_attributes[1][name] for the second field, and so on.
+//   type: string
+// - name: fields_attributes[0][value]
+//   in: formData
+//   description: |-
+//     Value of the first profile field. Use fields_attributes[1][value] for the second field, and so on.
+//     If the value is a link to a page which links back to the profile with rel="me", the field will be verified.
+//   type: string
 // - name: source[privacy]
 //   in: formData
 //   description: Default post privacy for authored statuses.
@@ -88,6 +117,12 @@ import (
 //   in: formData
 //   description: Default language to use for authored statuses (ISO 6391).
 //   type: string
+// - name: source[auto_delete_after_days]
+//   in: formData
+//   description: |-
+//     Delete authored statuses automatically once they're this many days old. 0 to never delete them.
+//     Statuses which are pinned, or which the account has bookmarked or faved, are never deleted automatically.
+//   type: integer
 //
 // security:
 // - OAuth2 Bearer:
@@ -102,24 +137,29 @@ import (
 //      description: unauthorized
 //   '400':
 //      description: bad request
+//   '422':
+//      description: unprocessable entity, for example because there are too many profile fields, or a field is too long
 func (m *Module) AccountUpdateCredentialsPATCHHandler(c *gin.Context) {
 	l := logrus.WithField("func", "accountUpdateCredentialsPATCHHandler")
 	authed, err := oauth.Authed(c, true, true, true, true)
 	if err != nil {
-		l.Debugf("couldn't auth: %s", err)
-		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		api.ErrorHandler(c, gtserror.NewError(http.StatusForbidden, err, err.Error()))
+		return
+	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeWriteAccounts) {
 		return
 	}
 	l.Tracef("retrieved account %+v", authed.Account.ID)
 
 	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
-		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		api.ErrorHandler(c, gtserror.NewError(http.StatusNotAcceptable, err, err.Error()))
 		return
 	}
 
 	form, err := parseUpdateAccountForm(c)
 	if err != nil {
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		api.ErrorHandler(c, gtserror.NewError(http.StatusBadRequest, err, err.Error()))
 		return
 	}
 
@@ -131,19 +171,23 @@ func (m *Module) AccountUpdateCredentialsPATCHHandler(c *gin.Context) {
 		form.Avatar == nil &&
 		form.Header == nil &&
 		form.Locked == nil &&
+		form.Unquotable == nil &&
+		form.ArchiveAfterDays == nil &&
+		form.CustomCSS == nil &&
 		form.Source.Privacy == nil &&
 		form.Source.Sensitive == nil &&
 		form.Source.Language == nil &&
+		form.Source.AutoDeleteAfterDays == nil &&
 		form.FieldsAttributes == nil {
 		l.Debugf("could not parse form from request")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "empty form submitted"})
+		err := errors.New("empty form submitted")
+		api.ErrorHandler(c, gtserror.NewError(http.StatusBadRequest, err, err.Error()))
 		return
 	}
 
-	acctSensitive, err := m.processor.AccountUpdate(c.Request.Context(), authed, form)
-	if err != nil {
-		l.Debugf("could not update account: %s", err)
-		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+	acctSensitive, errWithCode := m.processor.AccountUpdate(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		api.ErrorHandler(c, errWithCode)
 		return
 	}
 
@@ -179,5 +223,67 @@ func parseUpdateAccountForm(c *gin.Context) (*model.UpdateCredentialsRequest, er
 		form.Source.Language = &language
 	}
 
+	if autoDeleteAfterDays, ok := sourceMap["auto_delete_after_days"]; ok {
+		days, err := strconv.Atoi(autoDeleteAfterDays)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing form source[auto_delete_after_days]: %s", err)
+		}
+		form.Source.AutoDeleteAfterDays = &days
+	}
+
+	// parse fields, if they weren't already bound from a json body
+	if form.FieldsAttributes == nil {
+		form.FieldsAttributes = parseFieldsAttributes(c.Request.PostForm)
+	}
+
 	return form, nil
 }
+
+// fieldsAttributesKey matches form keys like fields_attributes[0][name].
+var fieldsAttributesKey = regexp.MustCompile(`^fields_attributes\[(\d+)\]\[(name|value)\]$`)
+
+// parseFieldsAttributes parses profile fields from form keys like fields_attributes[0][name]
+// and fields_attributes[0][value], ordered by their index. It returns nil if there are none.
+func parseFieldsAttributes(values url.Values) *[]model.UpdateField {
+	byIndex := map[int]*model.UpdateField{}
+	for k, v := range values {
+		matches := fieldsAttributesKey.FindStringSubmatch(k)
+		if matches == nil || len(v) == 0 {
+			continue
+		}
+
+		i, err := strconv.Atoi(matches[1])
+		if err != nil {
+			continue
+		}
+
+		field, ok := byIndex[i]
+		if !ok {
+			field = &model.UpdateField{}
+			byIndex[i] = field
+		}
+
+		value := v[0]
+		if matches[2] == "name" {
+			field.Name = &value
+		} else {
+			field.Value = &value
+		}
+	}
+
+	if len(byIndex) == 0 {
+		return nil
+	}
+
+	indexes := make([]int, 0, len(byIndex))
+	for i := range byIndex {
+		indexes = append(indexes, i)
+	}
+	sort.Ints(indexes)
+
+	fields := make([]model.UpdateField, 0, len(indexes))
+	for _, i := range indexes {
+		fields = append(fields, *byIndex[i])
+	}
+	return &fields
+}
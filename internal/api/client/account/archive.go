@@ -0,0 +1,204 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AccountArchivePOSTHandler swagger:operation POST /api/v1/accounts/archive accountArchiveCreate
+//
+// Request an archive of your account's data.
+//
+// The archive is generated in the background, and contains your profile, statuses, media, follows and blocks.
+// Poll GET /api/v1/accounts/archive to find out when it's ready to download.
+// If a new archive is already being generated, that archive will be returned instead, unless it has
+// been pending for more than six hours, in which case it's marked as failed and a new one is started.
+//
+// ---
+// tags:
+// - accounts
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:accounts
+//
+// responses:
+//   '202':
+//     description: The requested archive.
+//     schema:
+//       "$ref": "#/definitions/accountArchive"
+//   '401':
+//      description: unauthorized
+//   '429':
+//      description: an archive was requested too recently
+func (m *Module) AccountArchivePOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		api.ErrorHandler(c, gtserror.NewError(http.StatusUnauthorized, err, "unauthorized"))
+		return
+	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeReadAccounts) {
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		api.ErrorHandler(c, gtserror.NewError(http.StatusNotAcceptable, err, err.Error()))
+		return
+	}
+
+	archive, errWithCode := m.processor.AccountArchiveCreate(c.Request.Context(), authed)
+	if errWithCode != nil {
+		api.ErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, archive)
+}
+
+// AccountArchiveGETHandler swagger:operation GET /api/v1/accounts/archive accountArchiveGet
+//
+// Get the most recently requested archive of your account's data.
+//
+// Once the archive is ready, it will include a download link, which is valid for one hour.
+//
+// ---
+// tags:
+// - accounts
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:accounts
+//
+// responses:
+//   '200':
+//     description: The most recently requested archive.
+//     schema:
+//       "$ref": "#/definitions/accountArchive"
+//   '401':
+//      description: unauthorized
+//   '404':
+//      description: no archive has been requested
+func (m *Module) AccountArchiveGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		api.ErrorHandler(c, gtserror.NewError(http.StatusUnauthorized, err, "unauthorized"))
+		return
+	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeReadAccounts) {
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		api.ErrorHandler(c, gtserror.NewError(http.StatusNotAcceptable, err, err.Error()))
+		return
+	}
+
+	archive, errWithCode := m.processor.AccountArchiveGet(c.Request.Context(), authed)
+	if errWithCode != nil {
+		api.ErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.JSON(http.StatusOK, archive)
+}
+
+// AccountArchiveDownloadGETHandler swagger:operation GET /api/v1/accounts/archive/{id}/download accountArchiveDownload
+//
+// Download an archive of account data as a zip file.
+//
+// This endpoint doesn't need authorization, since the link is signed; use the url of a ready archive as-is.
+//
+// ---
+// tags:
+// - accounts
+//
+// produces:
+// - application/zip
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: The id of the archive.
+//   in: path
+//   required: true
+// - name: expires
+//   type: integer
+//   description: Unix time at which the download link expires.
+//   in: query
+//   required: true
+// - name: signature
+//   type: string
+//   description: Signature of the download link.
+//   in: query
+//   required: true
+//
+// responses:
+//   '200':
+//     description: The archive.
+//   '403':
+//      description: the download link is invalid or has expired
+//   '404':
+//      description: not found
+func (m *Module) AccountArchiveDownloadGETHandler(c *gin.Context) {
+	l := logrus.WithContext(c.Request.Context()).WithField("func", "AccountArchiveDownloadGETHandler")
+
+	archiveID := c.Param(IDKey)
+	if archiveID == "" {
+		err := errors.New("no archive id specified")
+		api.ErrorHandler(c, gtserror.NewError(http.StatusBadRequest, err, err.Error()))
+		return
+	}
+
+	content, errWithCode := m.processor.AccountArchiveDownload(c.Request.Context(), archiveID, c.Query(ArchiveExpiresKey), c.Query(ArchiveSignatureKey))
+	if errWithCode != nil {
+		api.ErrorHandler(c, errWithCode)
+		return
+	}
+
+	defer func() {
+		// if the content is a ReadCloser, close it when we're done
+		if closer, ok := content.Content.(io.ReadCloser); ok {
+			if err := closer.Close(); err != nil {
+				l.Errorf("error closing readcloser: %s", err)
+			}
+		}
+	}()
+
+	c.DataFromReader(http.StatusOK, content.ContentLength, content.ContentType, content.Content, map[string]string{
+		"Content-Disposition": fmt.Sprintf(`attachment; filename="archive-%s.zip"`, archiveID),
+	})
+}
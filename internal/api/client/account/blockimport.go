@@ -0,0 +1,199 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AccountBlockImportPOSTHandler swagger:operation POST /api/v1/accounts/import/blocks accountBlockImportCreate
+//
+// Import a list of accounts to block.
+//
+// The list should be a CSV file in the format that Mastodon exports blocks in, with one account address per line.
+//
+// The accounts are blocked in the background, since remote accounts may have to be looked up first.
+// Accounts which are already blocked, and your own account, are skipped.
+// Poll GET /api/v1/accounts/import/blocks to find out what happened to each line of the file.
+//
+// ---
+// tags:
+// - accounts
+//
+// consumes:
+// - multipart/form-data
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: data
+//   in: formData
+//   description: CSV file of accounts to block.
+//   type: file
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:blocks
+//
+// responses:
+//   '202':
+//     description: The import, with the lines which will be blocked in the background still pending.
+//     schema:
+//       "$ref": "#/definitions/blockImport"
+//   '400':
+//      description: bad request
+//   '401':
+//      description: unauthorized
+//   '409':
+//      description: a previous import is still being processed
+//   '422':
+//      description: too many accounts listed
+func (m *Module) AccountBlockImportPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		api.ErrorHandler(c, gtserror.NewError(http.StatusUnauthorized, err, "unauthorized"))
+		return
+	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeWriteBlocks) {
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		api.ErrorHandler(c, gtserror.NewError(http.StatusNotAcceptable, err, err.Error()))
+		return
+	}
+
+	form := &model.BlockImportCreateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		api.ErrorHandler(c, gtserror.NewError(http.StatusBadRequest, err, fmt.Sprintf("could not parse form: %s", err)))
+		return
+	}
+
+	blockImport, errWithCode := m.processor.AccountBlockImportCreate(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		api.ErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, blockImport)
+}
+
+// AccountBlockImportGETHandler swagger:operation GET /api/v1/accounts/import/blocks accountBlockImportGet
+//
+// Get the most recent import of accounts to block, and what happened to each line of it.
+//
+// ---
+// tags:
+// - accounts
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:blocks
+//
+// responses:
+//   '200':
+//     description: The most recent import.
+//     schema:
+//       "$ref": "#/definitions/blockImport"
+//   '401':
+//      description: unauthorized
+//   '404':
+//      description: no accounts to block have been imported
+func (m *Module) AccountBlockImportGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		api.ErrorHandler(c, gtserror.NewError(http.StatusUnauthorized, err, "unauthorized"))
+		return
+	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeReadBlocks) {
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		api.ErrorHandler(c, gtserror.NewError(http.StatusNotAcceptable, err, err.Error()))
+		return
+	}
+
+	blockImport, errWithCode := m.processor.AccountBlockImportGet(c.Request.Context(), authed)
+	if errWithCode != nil {
+		api.ErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.JSON(http.StatusOK, blockImport)
+}
+
+// AccountBlocksExportGETHandler swagger:operation GET /api/v1/accounts/export/blocks accountBlocksExport
+//
+// Export the accounts you block.
+//
+// The accounts are returned as a CSV file in the format that Mastodon exports blocks in, with one account address per line,
+// so that they can be imported again here or on another instance.
+//
+// ---
+// tags:
+// - accounts
+//
+// produces:
+// - text/csv
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:blocks
+//
+// responses:
+//   '200':
+//     description: CSV file of blocked accounts.
+//   '401':
+//      description: unauthorized
+func (m *Module) AccountBlocksExportGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		api.ErrorHandler(c, gtserror.NewError(http.StatusUnauthorized, err, "unauthorized"))
+		return
+	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeReadBlocks) {
+		return
+	}
+
+	content, errWithCode := m.processor.AccountBlocksExport(c.Request.Context(), authed)
+	if errWithCode != nil {
+		api.ErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.DataFromReader(http.StatusOK, content.ContentLength, content.ContentType, content.Content, map[string]string{
+		"Content-Disposition": `attachment; filename="blocked_accounts.csv"`,
+	})
+}
@@ -0,0 +1,113 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AccountFamiliarFollowersGETHandler swagger:operation GET /api/v1/accounts/familiar_followers accountFamiliarFollowers
+//
+// See which of the accounts you follow also follow the given account IDs.
+//
+// ---
+// tags:
+// - accounts
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: array
+//   items:
+//     type: string
+//   description: Account IDs.
+//   in: query
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:follows
+//
+// responses:
+//   '200':
+//     name: familiar followers
+//     description: Array of familiar followers, one for each requested account ID.
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/familiarFollowers"
+//   '401':
+//      description: unauthorized
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+func (m *Module) AccountFamiliarFollowersGETHandler(c *gin.Context) {
+	l := logrus.WithField("func", "AccountFamiliarFollowersGETHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		api.ErrorHandler(c, gtserror.NewError(http.StatusUnauthorized, err, "unauthorized"))
+		return
+	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeReadFollows) {
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		api.ErrorHandler(c, gtserror.NewError(http.StatusNotAcceptable, err, err.Error()))
+		return
+	}
+
+	targetAccountIDs := c.QueryArray("id[]")
+	if len(targetAccountIDs) == 0 {
+		// check fallback -- let's be generous and see if maybe it's just set as 'id'?
+		targetAccountIDs = c.QueryArray("id")
+		if len(targetAccountIDs) == 0 {
+			l.Debug("no account id specified in query")
+			err := errors.New("no account id specified")
+			api.ErrorHandler(c, gtserror.NewError(http.StatusBadRequest, err, err.Error()))
+			return
+		}
+	}
+
+	familiarFollowers := []model.FamiliarFollowers{}
+
+	for _, targetAccountID := range targetAccountIDs {
+		f, errWithCode := m.processor.AccountFamiliarFollowersGet(c.Request.Context(), authed, targetAccountID)
+		if errWithCode != nil {
+			api.ErrorHandler(c, errWithCode)
+			return
+		}
+		familiarFollowers = append(familiarFollowers, *f)
+	}
+
+	c.JSON(http.StatusOK, familiarFollowers)
+}
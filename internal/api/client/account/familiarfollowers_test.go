@@ -0,0 +1,90 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/account"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type FamiliarFollowersTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *FamiliarFollowersTestSuite) getFamiliarFollowers(targetAccountID string) []apimodel.FamiliarFollowers {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, account.GetFamiliarFollowersPath+"?id[]="+targetAccountID, "")
+
+	suite.accountModule.AccountFamiliarFollowersGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	familiarFollowers := []apimodel.FamiliarFollowers{}
+	suite.NoError(json.Unmarshal(b, &familiarFollowers))
+	return familiarFollowers
+}
+
+func (suite *FamiliarFollowersTestSuite) TestGetFamiliarFollowers() {
+	adminAccount := suite.testAccounts["admin_account"]
+	targetAccount := suite.testAccounts["local_account_2"]
+
+	// zork follows admin, so once admin follows turtle, admin is a familiar follower of turtle for zork
+	suite.NoError(suite.db.Put(context.Background(), &gtsmodel.Follow{
+		ID:              "01G5EZ7C1R3HJ5CGSSG2M1N3YJ",
+		URI:             "http://localhost:8080/users/admin/follow/01G5EZ7C1R3HJ5CGSSG2M1N3YJ",
+		AccountID:       adminAccount.ID,
+		TargetAccountID: targetAccount.ID,
+	}))
+
+	familiarFollowers := suite.getFamiliarFollowers(targetAccount.ID)
+	suite.Len(familiarFollowers, 1)
+	suite.Equal(targetAccount.ID, familiarFollowers[0].ID)
+	if suite.Len(familiarFollowers[0].Accounts, 1) {
+		suite.Equal(adminAccount.ID, familiarFollowers[0].Accounts[0].ID)
+	}
+
+	// if admin blocks zork, they shouldn't be revealed as a familiar follower anymore
+	suite.NoError(suite.db.Put(context.Background(), &gtsmodel.Block{
+		ID:              "01G5EZAJ0ZD2WPJ2D3Y5Q2WV6B",
+		URI:             "http://localhost:8080/users/admin/blocks/01G5EZAJ0ZD2WPJ2D3Y5Q2WV6B",
+		AccountID:       adminAccount.ID,
+		TargetAccountID: suite.testAccounts["local_account_1"].ID,
+	}))
+
+	familiarFollowers = suite.getFamiliarFollowers(targetAccount.ID)
+	suite.Len(familiarFollowers, 1)
+	suite.Empty(familiarFollowers[0].Accounts)
+}
+
+func TestFamiliarFollowersTestSuite(t *testing.T) {
+	suite.Run(t, new(FamiliarFollowersTestSuite))
+}
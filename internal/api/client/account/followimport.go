@@ -0,0 +1,155 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// AccountFollowImportPOSTHandler swagger:operation POST /api/v1/accounts/import/follows accountFollowImportCreate
+//
+// Import a list of accounts to follow.
+//
+// The list should be a CSV file in the format that Mastodon exports follows in, with the columns
+// `Account address`, `Show boosts`, `Notify on new posts`, and `Languages`. Languages are ignored.
+//
+// The accounts are followed in the background, since remote accounts may have to be looked up first.
+// Accounts which are already followed, and your own account, are skipped.
+// Poll GET /api/v1/accounts/import/follows to find out what happened to each line of the file.
+//
+// ---
+// tags:
+// - accounts
+//
+// consumes:
+// - multipart/form-data
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: data
+//   in: formData
+//   description: CSV file of accounts to follow.
+//   type: file
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:follows
+//
+// responses:
+//   '202':
+//     description: The import, with the lines which will be followed in the background still pending.
+//     schema:
+//       "$ref": "#/definitions/followImport"
+//   '400':
+//      description: bad request
+//   '401':
+//      description: unauthorized
+//   '409':
+//      description: a previous import is still being processed
+//   '422':
+//      description: too many accounts listed
+func (m *Module) AccountFollowImportPOSTHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		api.ErrorHandler(c, gtserror.NewError(http.StatusUnauthorized, err, "unauthorized"))
+		return
+	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeWriteFollows) {
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		api.ErrorHandler(c, gtserror.NewError(http.StatusNotAcceptable, err, err.Error()))
+		return
+	}
+
+	form := &model.FollowImportCreateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		api.ErrorHandler(c, gtserror.NewError(http.StatusBadRequest, err, fmt.Sprintf("could not parse form: %s", err)))
+		return
+	}
+
+	followImport, errWithCode := m.processor.AccountFollowImportCreate(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		api.ErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.JSON(http.StatusAccepted, followImport)
+}
+
+// AccountFollowImportGETHandler swagger:operation GET /api/v1/accounts/import/follows accountFollowImportGet
+//
+// Get the most recent import of accounts to follow, and what happened to each line of it.
+//
+// ---
+// tags:
+// - accounts
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:follows
+//
+// responses:
+//   '200':
+//     description: The most recent import.
+//     schema:
+//       "$ref": "#/definitions/followImport"
+//   '401':
+//      description: unauthorized
+//   '404':
+//      description: no accounts to follow have been imported
+func (m *Module) AccountFollowImportGETHandler(c *gin.Context) {
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		api.ErrorHandler(c, gtserror.NewError(http.StatusUnauthorized, err, "unauthorized"))
+		return
+	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeReadFollows) {
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		api.ErrorHandler(c, gtserror.NewError(http.StatusNotAcceptable, err, err.Error()))
+		return
+	}
+
+	followImport, errWithCode := m.processor.AccountFollowImportGet(c.Request.Context(), authed)
+	if errWithCode != nil {
+		api.ErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.JSON(http.StatusOK, followImport)
+}
@@ -0,0 +1,87 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/account"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type ProfileMediaDeleteTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *ProfileMediaDeleteTestSuite) TestAvatarDELETEHandler() {
+	// use a copy of zork so that the other tests still see the avatar
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodDelete, nil, account.ProfileAvatarPath, "")
+	ctx.Set(oauth.SessionAuthorizedAccount, testAccount)
+
+	suite.accountModule.AccountAvatarDELETEHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	apimodelAccount := &apimodel.Account{}
+	err = json.Unmarshal(b, apimodelAccount)
+	suite.NoError(err)
+	suite.Empty(apimodelAccount.Avatar)
+	suite.NotEmpty(apimodelAccount.Header)
+
+	dbAccount, err := suite.db.GetAccountByID(context.Background(), testAccount.ID)
+	suite.NoError(err)
+	suite.Empty(dbAccount.AvatarMediaAttachmentID)
+}
+
+func (suite *ProfileMediaDeleteTestSuite) TestHeaderDELETEHandler() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodDelete, nil, account.ProfileHeaderPath, "")
+	ctx.Set(oauth.SessionAuthorizedAccount, testAccount)
+
+	suite.accountModule.AccountHeaderDELETEHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	dbAccount, err := suite.db.GetAccountByID(context.Background(), testAccount.ID)
+	suite.NoError(err)
+	suite.Empty(dbAccount.HeaderMediaAttachmentID)
+	suite.NotEmpty(dbAccount.AvatarMediaAttachmentID)
+}
+
+func TestProfileMediaDeleteTestSuite(t *testing.T) {
+	suite.Run(t, new(ProfileMediaDeleteTestSuite))
+}
@@ -1,6 +1,7 @@
 package account
 
 import (
+	"errors"
 	"net/http"
 
 	"github.com/sirupsen/logrus"
@@ -8,6 +9,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/api"
 	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
@@ -54,13 +56,16 @@ func (m *Module) AccountRelationshipsGETHandler(c *gin.Context) {
 
 	authed, err := oauth.Authed(c, true, true, true, true)
 	if err != nil {
-		l.Debugf("error authing: %s", err)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		api.ErrorHandler(c, gtserror.NewError(http.StatusUnauthorized, err, "unauthorized"))
+		return
+	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeReadAccounts) {
 		return
 	}
 
 	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
-		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		api.ErrorHandler(c, gtserror.NewError(http.StatusNotAcceptable, err, err.Error()))
 		return
 	}
 
@@ -70,7 +75,8 @@ func (m *Module) AccountRelationshipsGETHandler(c *gin.Context) {
 		id := c.Query("id")
 		if id == "" {
 			l.Debug("no account id specified in query")
-			c.JSON(http.StatusBadRequest, gin.H{"error": "no account id specified"})
+			err := errors.New("no account id specified")
+			api.ErrorHandler(c, gtserror.NewError(http.StatusBadRequest, err, err.Error()))
 			return
 		}
 		targetAccountIDs = append(targetAccountIDs, id)
@@ -80,8 +86,8 @@ func (m *Module) AccountRelationshipsGETHandler(c *gin.Context) {
 
 	for _, targetAccountID := range targetAccountIDs {
 		r, errWithCode := m.processor.AccountRelationshipGet(c.Request.Context(), authed, targetAccountID)
-		if err != nil {
-			c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		if errWithCode != nil {
+			api.ErrorHandler(c, errWithCode)
 			return
 		}
 		relationships = append(relationships, *r)
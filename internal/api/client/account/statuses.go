@@ -19,6 +19,7 @@
 package account
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -26,6 +27,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
@@ -121,33 +123,31 @@ func (m *Module) AccountStatusesGETHandler(c *gin.Context) {
 
 	authed, err := oauth.Authed(c, false, false, false, false)
 	if err != nil {
-		l.Debugf("error authing: %s", err)
-		c.JSON(http.StatusUnauthorized, gin.H{"error": "unauthorized"})
+		api.ErrorHandler(c, gtserror.NewError(http.StatusUnauthorized, err, "unauthorized"))
+		return
+	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeReadAccounts) {
 		return
 	}
 
 	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
-		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		api.ErrorHandler(c, gtserror.NewError(http.StatusNotAcceptable, err, err.Error()))
 		return
 	}
 
 	targetAcctID := c.Param(IDKey)
 	if targetAcctID == "" {
 		l.Debug("no account id specified in query")
-		c.JSON(http.StatusBadRequest, gin.H{"error": "no account id specified"})
+		err := errors.New("no account id specified")
+		api.ErrorHandler(c, gtserror.NewError(http.StatusBadRequest, err, err.Error()))
 		return
 	}
 
-	limit := 30
-	limitString := c.Query(LimitKey)
-	if limitString != "" {
-		i, err := strconv.ParseInt(limitString, 10, 64)
-		if err != nil {
-			l.Debugf("error parsing limit string: %s", err)
-			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse limit query param"})
-			return
-		}
-		limit = int(i)
+	limit, err := api.ParseLimit(c, LimitKey, 30)
+	if err != nil {
+		api.ErrorHandler(c, gtserror.NewError(http.StatusBadRequest, err, "couldn't parse limit query param"))
+		return
 	}
 
 	excludeReplies := false
@@ -155,8 +155,7 @@ func (m *Module) AccountStatusesGETHandler(c *gin.Context) {
 	if excludeRepliesString != "" {
 		i, err := strconv.ParseBool(excludeRepliesString)
 		if err != nil {
-			l.Debugf("error parsing exclude replies string: %s", err)
-			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse exclude replies query param"})
+			api.ErrorHandler(c, gtserror.NewError(http.StatusBadRequest, err, "couldn't parse exclude replies query param"))
 			return
 		}
 		excludeReplies = i
@@ -167,23 +166,22 @@ func (m *Module) AccountStatusesGETHandler(c *gin.Context) {
 	if excludeReblogsString != "" {
 		i, err := strconv.ParseBool(excludeReblogsString)
 		if err != nil {
-			l.Debugf("error parsing exclude reblogs string: %s", err)
-			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse exclude reblogs query param"})
+			api.ErrorHandler(c, gtserror.NewError(http.StatusBadRequest, err, "couldn't parse exclude reblogs query param"))
 			return
 		}
 		excludeReblogs = i
 	}
 
-	maxID := ""
-	maxIDString := c.Query(MaxIDKey)
-	if maxIDString != "" {
-		maxID = maxIDString
+	maxID, err := api.ParsePagingID(c, MaxIDKey)
+	if err != nil {
+		api.ErrorHandler(c, gtserror.NewError(http.StatusBadRequest, err, err.Error()))
+		return
 	}
 
-	minID := ""
-	minIDString := c.Query(MinIDKey)
-	if minIDString != "" {
-		minID = minIDString
+	minID, err := api.ParsePagingID(c, MinIDKey)
+	if err != nil {
+		api.ErrorHandler(c, gtserror.NewError(http.StatusBadRequest, err, err.Error()))
+		return
 	}
 
 	pinnedOnly := false
@@ -191,8 +189,7 @@ func (m *Module) AccountStatusesGETHandler(c *gin.Context) {
 	if pinnedString != "" {
 		i, err := strconv.ParseBool(pinnedString)
 		if err != nil {
-			l.Debugf("error parsing pinned string: %s", err)
-			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse pinned query param"})
+			api.ErrorHandler(c, gtserror.NewError(http.StatusBadRequest, err, "couldn't parse pinned query param"))
 			return
 		}
 		pinnedOnly = i
@@ -203,8 +200,7 @@ func (m *Module) AccountStatusesGETHandler(c *gin.Context) {
 	if mediaOnlyString != "" {
 		i, err := strconv.ParseBool(mediaOnlyString)
 		if err != nil {
-			l.Debugf("error parsing media only string: %s", err)
-			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse media only query param"})
+			api.ErrorHandler(c, gtserror.NewError(http.StatusBadRequest, err, "couldn't parse media only query param"))
 			return
 		}
 		mediaOnly = i
@@ -215,8 +211,7 @@ func (m *Module) AccountStatusesGETHandler(c *gin.Context) {
 	if publicOnlyString != "" {
 		i, err := strconv.ParseBool(publicOnlyString)
 		if err != nil {
-			l.Debugf("error parsing public only string: %s", err)
-			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse public only query param"})
+			api.ErrorHandler(c, gtserror.NewError(http.StatusBadRequest, err, "couldn't parse public only query param"))
 			return
 		}
 		publicOnly = i
@@ -224,8 +219,7 @@ func (m *Module) AccountStatusesGETHandler(c *gin.Context) {
 
 	statuses, errWithCode := m.processor.AccountStatusesGet(c.Request.Context(), authed, targetAcctID, limit, excludeReplies, excludeReblogs, maxID, minID, pinnedOnly, mediaOnly, publicOnly)
 	if errWithCode != nil {
-		l.Debugf("error from processor account statuses get: %s", errWithCode)
-		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		api.ErrorHandler(c, errWithCode)
 		return
 	}
 
@@ -27,10 +27,12 @@ import (
 	"testing"
 
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/client/account"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 )
 
 type AccountStatusesTestSuite struct {
@@ -76,6 +78,55 @@ func (suite *AccountStatusesTestSuite) TestGetStatusesMediaOnly() {
 	}
 }
 
+func (suite *AccountStatusesTestSuite) TestGetStatusesLimitClamped() {
+	viper.Set(config.Keys.InstanceMaxPageSize, 2)
+
+	targetAccount := suite.testAccounts["local_account_1"]
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, fmt.Sprintf("/api/v1/accounts/%s/statuses?limit=1000000", targetAccount.ID), "")
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   account.IDKey,
+			Value: targetAccount.ID,
+		},
+	}
+
+	suite.accountModule.AccountStatusesGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	// the limit should have been lowered to the maximum page size
+	apimodelStatuses := []*apimodel.Status{}
+	err = json.Unmarshal(b, &apimodelStatuses)
+	suite.NoError(err)
+	suite.Len(apimodelStatuses, 2)
+}
+
+func (suite *AccountStatusesTestSuite) TestGetStatusesMalformedMaxID() {
+	targetAccount := suite.testAccounts["local_account_1"]
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, fmt.Sprintf("/api/v1/accounts/%s/statuses?max_id=1%%27%%20OR%%201=1", targetAccount.ID), "")
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   account.IDKey,
+			Value: targetAccount.ID,
+		},
+	}
+
+	suite.accountModule.AccountStatusesGETHandler(ctx)
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+	suite.Equal(`{"error":"max_id query param 1' OR 1=1 is not a valid id"}`, string(b))
+}
+
 func TestAccountStatusesTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountStatusesTestSuite))
 }
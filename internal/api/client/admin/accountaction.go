@@ -24,6 +24,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
 	"github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
@@ -51,7 +52,15 @@ import (
 // - name: type
 //   in: formData
 //   description: |-
-//     Type of action to be taken. One of: disable, silence, suspend.
+//     Type of action to be taken. One of: disable, silence, unsilence, sensitive, unsensitive, unlock, suspend.
+//
+//     A silenced account can keep posting, but its statuses are kept out of public timelines
+//     and search results, so that only its followers see them. Unsilence lifts a silence again.
+//
+//     Sensitive marks all media posted by the account as sensitive, whatever the author set,
+//     and unsensitive lifts this again.
+//
+//     Unlock lifts a lockout of sign ins to the account after too many failed ones.
 //   type: string
 //   required: true
 // - name: text
@@ -73,7 +82,7 @@ import (
 //   '403':
 //      description: forbidden
 func (m *Module) AccountActionPOSTHandler(c *gin.Context) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "AccountActionPOSTHandler",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
@@ -88,6 +97,10 @@ func (m *Module) AccountActionPOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if !oauth.RequireScope(c, authed, oauth.ScopeAdmin) {
+		return
+	}
+
 	// with an admin account
 	if !authed.User.Admin {
 		l.Debugf("user %s not an admin", authed.User.ID)
@@ -118,7 +131,7 @@ func (m *Module) AccountActionPOSTHandler(c *gin.Context) {
 
 	if errWithCode := m.processor.AdminAccountAction(c.Request.Context(), authed, form); errWithCode != nil {
 		l.Debugf("error performing account action: %s", errWithCode.Error())
-		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		api.ErrorHandler(c, errWithCode)
 		return
 	}
 
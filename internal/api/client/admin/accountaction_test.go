@@ -0,0 +1,128 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin_test
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type AccountActionTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *AccountActionTestSuite) accountAction(targetAccountID string, actionType string) (int, string) {
+	form := url.Values{"type": {actionType}, "text": {"spammy"}}
+	path := strings.Replace(admin.AccountsActionPath, ":"+admin.IDKey, targetAccountID, 1)
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, []byte(form.Encode()), path, "application/x-www-form-urlencoded")
+	ctx.Params = gin.Params{gin.Param{Key: admin.IDKey, Value: targetAccountID}}
+	suite.adminModule.AccountActionPOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+	return recorder.Code, string(b)
+}
+
+func (suite *AccountActionTestSuite) TestSilenceAndUnsilence() {
+	targetAccount := suite.testAccounts["remote_account_1"]
+
+	code, body := suite.accountAction(targetAccount.ID, "silence")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(`{"message":"OK"}`, body)
+
+	dbAccount, err := suite.db.GetAccountByID(context.Background(), targetAccount.ID)
+	suite.NoError(err)
+	suite.False(dbAccount.SilencedAt.IsZero())
+	suite.True(dbAccount.SuspendedAt.IsZero())
+
+	code, _ = suite.accountAction(targetAccount.ID, "unsilence")
+	suite.Equal(http.StatusOK, code)
+
+	dbAccount, err = suite.db.GetAccountByID(context.Background(), targetAccount.ID)
+	suite.NoError(err)
+	suite.True(dbAccount.SilencedAt.IsZero())
+}
+
+func (suite *AccountActionTestSuite) TestSensitiveAndUnsensitive() {
+	targetAccount := suite.testAccounts["remote_account_1"]
+
+	code, _ := suite.accountAction(targetAccount.ID, "sensitive")
+	suite.Equal(http.StatusOK, code)
+
+	dbAccount, err := suite.db.GetAccountByID(context.Background(), targetAccount.ID)
+	suite.NoError(err)
+	suite.False(dbAccount.SensitizedAt.IsZero())
+
+	code, _ = suite.accountAction(targetAccount.ID, "unsensitive")
+	suite.Equal(http.StatusOK, code)
+
+	dbAccount, err = suite.db.GetAccountByID(context.Background(), targetAccount.ID)
+	suite.NoError(err)
+	suite.True(dbAccount.SensitizedAt.IsZero())
+}
+
+func (suite *AccountActionTestSuite) TestUnlock() {
+	testUser := suite.testUsers["local_account_1"]
+
+	user := &gtsmodel.User{}
+	suite.NoError(suite.db.GetByID(context.Background(), testUser.ID, user))
+	user.FailedSignInCount = 3
+	user.FailedSignInAt = time.Now()
+	user.SignInLockedUntil = time.Now().Add(time.Hour)
+	suite.NoError(suite.db.UpdateByPrimaryKey(context.Background(), user))
+
+	code, _ := suite.accountAction(testUser.AccountID, "unlock")
+	suite.Equal(http.StatusOK, code)
+
+	user = &gtsmodel.User{}
+	suite.NoError(suite.db.GetByID(context.Background(), testUser.ID, user))
+	suite.Zero(user.FailedSignInCount)
+	suite.True(user.FailedSignInAt.IsZero())
+	suite.True(user.SignInLockedUntil.IsZero())
+}
+
+func (suite *AccountActionTestSuite) TestUnlockRemoteAccount() {
+	code, _ := suite.accountAction(suite.testAccounts["remote_account_1"].ID, "unlock")
+	suite.Equal(http.StatusBadRequest, code)
+}
+
+func (suite *AccountActionTestSuite) TestUnsupportedAction() {
+	code, body := suite.accountAction(suite.testAccounts["remote_account_1"].ID, "shout")
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal(`{"error":"bad request"}`, body)
+}
+
+func TestAccountActionTestSuite(t *testing.T) {
+	suite.Run(t, new(AccountActionTestSuite))
+}
@@ -31,16 +31,46 @@ const (
 	BasePath = "/api/v1/admin"
 	// EmojiPath is used for posting/deleting custom emojis.
 	EmojiPath = BasePath + "/custom_emojis"
+	// EmojiPathWithID is used for interacting with a single custom emoji.
+	EmojiPathWithID = EmojiPath + "/:" + IDKey
 	// DomainBlocksPath is used for posting domain blocks.
 	DomainBlocksPath = BasePath + "/domain_blocks"
 	// DomainBlocksPathWithID is used for interacting with a single domain block.
 	DomainBlocksPathWithID = DomainBlocksPath + "/:" + IDKey
+	// DomainPausesPath is used for posting and listing domain pauses.
+	DomainPausesPath = BasePath + "/domain_pauses"
+	// DomainPausesPathWithID is used for interacting with a single domain pause.
+	DomainPausesPathWithID = DomainPausesPath + "/:" + IDKey
+	// DomainSilencesPath is used for posting and listing domain silences.
+	DomainSilencesPath = BasePath + "/domain_silences"
+	// DomainSilencesPathWithID is used for interacting with a single domain silence.
+	DomainSilencesPathWithID = DomainSilencesPath + "/:" + IDKey
 	// AccountsPath is used for listing + acting on accounts.
 	AccountsPath = BasePath + "/accounts"
 	// AccountsPathWithID is used for interacting with a single account.
 	AccountsPathWithID = AccountsPath + "/:" + IDKey
 	// AccountsActionPath is used for taking action on a single account.
 	AccountsActionPath = AccountsPathWithID + "/action"
+	// RelationshipSeverancesPath is used for severing all relationships with a set of accounts.
+	RelationshipSeverancesPath = BasePath + "/relationship_severances"
+	// RelationshipSeverancesPathWithID is used for checking up on a single relationship severance.
+	RelationshipSeverancesPathWithID = RelationshipSeverancesPath + "/:" + IDKey
+	// RegistrationsPath is used for opening and closing registrations at runtime.
+	RegistrationsPath = BasePath + "/registrations"
+	// MediaQueuePath is used for checking up on the media processing queue.
+	MediaQueuePath = BasePath + "/media_queue"
+	// ReadOnlyPath is used for switching read-only mode on and off at runtime.
+	ReadOnlyPath = BasePath + "/read_only"
+	// ModerationRulesPath is used for posting and listing moderation rules.
+	ModerationRulesPath = BasePath + "/moderation_rules"
+	// ModerationRulesPathWithID is used for interacting with a single moderation rule.
+	ModerationRulesPathWithID = ModerationRulesPath + "/:" + IDKey
+	// ModeratedStatusesPath is used for listing statuses which are waiting for review.
+	ModeratedStatusesPath = BasePath + "/moderated_statuses"
+	// ModeratedStatusApprovePath is used for approving a single status which is waiting for review.
+	ModeratedStatusApprovePath = ModeratedStatusesPath + "/:" + IDKey + "/approve"
+	// ModeratedStatusRejectPath is used for rejecting a single status which is waiting for review.
+	ModeratedStatusRejectPath = ModeratedStatusesPath + "/:" + IDKey + "/reject"
 
 	// ExportQueryKey is for requesting a public export of some data.
 	ExportQueryKey = "export"
@@ -65,10 +95,28 @@ func New(processor processing.Processor) api.ClientModule {
 // Route attaches all routes from this module to the given router
 func (m *Module) Route(r router.Router) error {
 	r.AttachHandler(http.MethodPost, EmojiPath, m.EmojiCreatePOSTHandler)
+	r.AttachHandler(http.MethodPatch, EmojiPathWithID, m.EmojiUpdatePATCHHandler)
 	r.AttachHandler(http.MethodPost, DomainBlocksPath, m.DomainBlocksPOSTHandler)
 	r.AttachHandler(http.MethodGet, DomainBlocksPath, m.DomainBlocksGETHandler)
 	r.AttachHandler(http.MethodGet, DomainBlocksPathWithID, m.DomainBlockGETHandler)
 	r.AttachHandler(http.MethodDelete, DomainBlocksPathWithID, m.DomainBlockDELETEHandler)
+	r.AttachHandler(http.MethodPost, DomainPausesPath, m.DomainPausesPOSTHandler)
+	r.AttachHandler(http.MethodGet, DomainPausesPath, m.DomainPausesGETHandler)
+	r.AttachHandler(http.MethodDelete, DomainPausesPathWithID, m.DomainPauseDELETEHandler)
+	r.AttachHandler(http.MethodPost, DomainSilencesPath, m.DomainSilencesPOSTHandler)
+	r.AttachHandler(http.MethodGet, DomainSilencesPath, m.DomainSilencesGETHandler)
+	r.AttachHandler(http.MethodDelete, DomainSilencesPathWithID, m.DomainSilenceDELETEHandler)
 	r.AttachHandler(http.MethodPost, AccountsActionPath, m.AccountActionPOSTHandler)
+	r.AttachHandler(http.MethodPost, RelationshipSeverancesPath, m.RelationshipSeverancePOSTHandler)
+	r.AttachHandler(http.MethodGet, RelationshipSeverancesPathWithID, m.RelationshipSeveranceGETHandler)
+	r.AttachHandler(http.MethodPost, RegistrationsPath, m.RegistrationsPOSTHandler)
+	r.AttachHandler(http.MethodPost, ReadOnlyPath, m.ReadOnlyPOSTHandler)
+	r.AttachHandler(http.MethodGet, MediaQueuePath, m.MediaQueueGETHandler)
+	r.AttachHandler(http.MethodPost, ModerationRulesPath, m.ModerationRulesPOSTHandler)
+	r.AttachHandler(http.MethodGet, ModerationRulesPath, m.ModerationRulesGETHandler)
+	r.AttachHandler(http.MethodDelete, ModerationRulesPathWithID, m.ModerationRuleDELETEHandler)
+	r.AttachHandler(http.MethodGet, ModeratedStatusesPath, m.ModeratedStatusesGETHandler)
+	r.AttachHandler(http.MethodPost, ModeratedStatusApprovePath, m.ModeratedStatusApprovePOSTHandler)
+	r.AttachHandler(http.MethodPost, ModeratedStatusRejectPath, m.ModeratedStatusRejectPOSTHandler)
 	return nil
 }
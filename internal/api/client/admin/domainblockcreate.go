@@ -91,7 +91,7 @@ import (
 //   '400':
 //      description: bad request
 func (m *Module) DomainBlocksPOSTHandler(c *gin.Context) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "DomainBlocksPOSTHandler",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
@@ -105,6 +105,10 @@ func (m *Module) DomainBlocksPOSTHandler(c *gin.Context) {
 		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		return
 	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeAdmin) {
+		return
+	}
 	if !authed.User.Admin {
 		l.Debugf("user %s not an admin", authed.User.ID)
 		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
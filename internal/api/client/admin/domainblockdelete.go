@@ -43,7 +43,7 @@ import (
 //   '404':
 //      description: not found
 func (m *Module) DomainBlockDELETEHandler(c *gin.Context) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "DomainBlockDELETEHandler",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
@@ -57,6 +57,10 @@ func (m *Module) DomainBlockDELETEHandler(c *gin.Context) {
 		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		return
 	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeAdmin) {
+		return
+	}
 	if !authed.User.Admin {
 		l.Debugf("user %s not an admin", authed.User.ID)
 		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
@@ -77,7 +81,7 @@ func (m *Module) DomainBlockDELETEHandler(c *gin.Context) {
 	domainBlock, errWithCode := m.processor.AdminDomainBlockDelete(c.Request.Context(), authed, domainBlockID)
 	if errWithCode != nil {
 		l.Debugf("error deleting domain block: %s", errWithCode.Error())
-		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		api.ErrorHandler(c, errWithCode)
 		return
 	}
 
@@ -44,7 +44,7 @@ import (
 //   '404':
 //      description: not found
 func (m *Module) DomainBlockGETHandler(c *gin.Context) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "DomainBlockGETHandler",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
@@ -58,6 +58,10 @@ func (m *Module) DomainBlockGETHandler(c *gin.Context) {
 		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		return
 	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeAdmin) {
+		return
+	}
 	if !authed.User.Admin {
 		l.Debugf("user %s not an admin", authed.User.ID)
 		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
@@ -0,0 +1,100 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type DomainPauseTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DomainPauseTestSuite) readBody(recorder *httptest.ResponseRecorder) []byte {
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+	return b
+}
+
+func (suite *DomainPauseTestSuite) TestCreateListAndDeleteDomainPause() {
+	// pause federation with a domain
+	form := url.Values{"domain": {"Fossbros-Anonymous.io"}, "private_comment": {"<p>spam wave</p>"}}
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, []byte(form.Encode()), admin.DomainPausesPath, "application/x-www-form-urlencoded")
+	suite.adminModule.DomainPausesPOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	domainPause := &apimodel.DomainPause{}
+	suite.NoError(json.Unmarshal(suite.readBody(recorder), domainPause))
+	suite.NotEmpty(domainPause.ID)
+	suite.Equal("fossbros-anonymous.io", domainPause.Domain)
+	suite.Equal("spam wave", domainPause.PrivateComment)
+	suite.Equal(suite.testAccounts["admin_account"].ID, domainPause.CreatedBy)
+
+	paused, err := suite.db.IsDomainPaused(context.Background(), "fossbros-anonymous.io")
+	suite.NoError(err)
+	suite.True(paused)
+
+	// the pause should show up in the list
+	recorder = httptest.NewRecorder()
+	ctx = suite.newContext(recorder, http.MethodGet, nil, admin.DomainPausesPath, "")
+	suite.adminModule.DomainPausesGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	domainPauses := []*apimodel.DomainPause{}
+	suite.NoError(json.Unmarshal(suite.readBody(recorder), &domainPauses))
+	suite.Len(domainPauses, 1)
+	suite.Equal(domainPause.ID, domainPauses[0].ID)
+
+	// lift the pause again
+	recorder = httptest.NewRecorder()
+	ctx = suite.newContext(recorder, http.MethodDelete, nil, admin.DomainPausesPath+"/"+domainPause.ID, "")
+	ctx.Params = gin.Params{gin.Param{Key: admin.IDKey, Value: domainPause.ID}}
+	suite.adminModule.DomainPauseDELETEHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	paused, err = suite.db.IsDomainPaused(context.Background(), "fossbros-anonymous.io")
+	suite.NoError(err)
+	suite.False(paused)
+}
+
+func (suite *DomainPauseTestSuite) TestCreateDomainPauseNoDomain() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, []byte(url.Values{"private_comment": {"hmm"}}.Encode()), admin.DomainPausesPath, "application/x-www-form-urlencoded")
+	suite.adminModule.DomainPausesPOSTHandler(ctx)
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+	suite.Equal(`{"error":"empty domain provided"}`, string(suite.readBody(recorder)))
+}
+
+func TestDomainPauseTestSuite(t *testing.T) {
+	suite.Run(t, new(DomainPauseTestSuite))
+}
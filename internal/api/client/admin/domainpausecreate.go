@@ -0,0 +1,139 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// DomainPausesPOSTHandler swagger:operation POST /api/v1/admin/domain_pauses domainPauseCreate
+//
+// Pause federation with a domain.
+//
+// While federation with a domain is paused, activities delivered to our inboxes from that domain are
+// accepted but dropped without being processed, and activities we would deliver to that domain are dropped.
+// Unlike a domain block, a pause doesn't remove or suspend anything, so it can be lifted again without losing data.
+//
+// If federation with the domain is already paused, the existing pause will be returned.
+//
+// ---
+// tags:
+// - admin
+//
+// consumes:
+// - multipart/form-data
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: domain
+//   in: formData
+//   description: Domain to pause federation with.
+//   type: string
+//   required: true
+// - name: private_comment
+//   in: formData
+//   description: |-
+//     Private comment about this domain pause. Will only be shown to other admins, so this
+//     is a useful way of keeping track of why federation with a domain was paused.
+//   type: string
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The newly created domain pause.
+//     schema:
+//       "$ref": "#/definitions/domainPause"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+func (m *Module) DomainPausesPOSTHandler(c *gin.Context) {
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+		"func":        "DomainPausesPOSTHandler",
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+
+	// make sure we're authed with an admin account
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("couldn't auth: %s", err)
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeAdmin) {
+		return
+	}
+	if !authed.User.Admin {
+		l.Debugf("user %s not an admin", authed.User.ID)
+		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	l.Tracef("parsing request form: %+v", c.Request.Form)
+	form := &model.DomainPauseCreateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.Debugf("error parsing form %+v: %s", c.Request.Form, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("could not parse form: %s", err)})
+		return
+	}
+
+	if err := validateCreateDomainPause(form); err != nil {
+		l.Debugf("error validating form: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	domainPause, errWithCode := m.processor.AdminDomainPauseCreate(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		l.Debugf("error creating domain pause: %s", errWithCode.Error())
+		api.ErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.JSON(http.StatusOK, domainPause)
+}
+
+func validateCreateDomainPause(form *model.DomainPauseCreateRequest) error {
+	if form.Domain == "" {
+		return errors.New("empty domain provided")
+	}
+
+	return nil
+}
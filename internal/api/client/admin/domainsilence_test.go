@@ -0,0 +1,100 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type DomainSilenceTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *DomainSilenceTestSuite) readBody(recorder *httptest.ResponseRecorder) []byte {
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+	return b
+}
+
+func (suite *DomainSilenceTestSuite) TestCreateListAndDeleteDomainSilence() {
+	// silence a domain
+	form := url.Values{"domain": {"Fossbros-Anonymous.io"}, "private_comment": {"<p>spam wave</p>"}}
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, []byte(form.Encode()), admin.DomainSilencesPath, "application/x-www-form-urlencoded")
+	suite.adminModule.DomainSilencesPOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	domainSilence := &apimodel.DomainSilence{}
+	suite.NoError(json.Unmarshal(suite.readBody(recorder), domainSilence))
+	suite.NotEmpty(domainSilence.ID)
+	suite.Equal("fossbros-anonymous.io", domainSilence.Domain)
+	suite.Equal("spam wave", domainSilence.PrivateComment)
+	suite.Equal(suite.testAccounts["admin_account"].ID, domainSilence.CreatedBy)
+
+	silenced, err := suite.db.IsDomainSilenced(context.Background(), "fossbros-anonymous.io")
+	suite.NoError(err)
+	suite.True(silenced)
+
+	// the silence should show up in the list
+	recorder = httptest.NewRecorder()
+	ctx = suite.newContext(recorder, http.MethodGet, nil, admin.DomainSilencesPath, "")
+	suite.adminModule.DomainSilencesGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	domainSilences := []*apimodel.DomainSilence{}
+	suite.NoError(json.Unmarshal(suite.readBody(recorder), &domainSilences))
+	suite.Len(domainSilences, 1)
+	suite.Equal(domainSilence.ID, domainSilences[0].ID)
+
+	// lift the silence again
+	recorder = httptest.NewRecorder()
+	ctx = suite.newContext(recorder, http.MethodDelete, nil, admin.DomainSilencesPath+"/"+domainSilence.ID, "")
+	ctx.Params = gin.Params{gin.Param{Key: admin.IDKey, Value: domainSilence.ID}}
+	suite.adminModule.DomainSilenceDELETEHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	silenced, err = suite.db.IsDomainSilenced(context.Background(), "fossbros-anonymous.io")
+	suite.NoError(err)
+	suite.False(silenced)
+}
+
+func (suite *DomainSilenceTestSuite) TestCreateDomainSilenceNoDomain() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, []byte(url.Values{"private_comment": {"hmm"}}.Encode()), admin.DomainSilencesPath, "application/x-www-form-urlencoded")
+	suite.adminModule.DomainSilencesPOSTHandler(ctx)
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+	suite.Equal(`{"error":"empty domain provided"}`, string(suite.readBody(recorder)))
+}
+
+func TestDomainSilenceTestSuite(t *testing.T) {
+	suite.Run(t, new(DomainSilenceTestSuite))
+}
@@ -0,0 +1,140 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// DomainSilencesPOSTHandler swagger:operation POST /api/v1/admin/domain_silences domainSilenceCreate
+//
+// Silence a domain.
+//
+// Statuses from accounts on a silenced domain are still received, and shown to the accounts that follow them,
+// but they're kept out of the public timelines and trends of this instance. This sits between allowing a domain
+// and blocking it: unlike a domain block, a silence doesn't remove or suspend anything, so it can be lifted again
+// without losing data.
+//
+// If the domain is already silenced, the existing silence will be returned.
+//
+// ---
+// tags:
+// - admin
+//
+// consumes:
+// - multipart/form-data
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: domain
+//   in: formData
+//   description: Domain to silence.
+//   type: string
+//   required: true
+// - name: private_comment
+//   in: formData
+//   description: |-
+//     Private comment about this domain silence. Will only be shown to other admins, so this
+//     is a useful way of keeping track of why a domain was silenced.
+//   type: string
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The newly created domain silence.
+//     schema:
+//       "$ref": "#/definitions/domainSilence"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+func (m *Module) DomainSilencesPOSTHandler(c *gin.Context) {
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+		"func":        "DomainSilencesPOSTHandler",
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+
+	// make sure we're authed with an admin account
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("couldn't auth: %s", err)
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeAdmin) {
+		return
+	}
+	if !authed.User.Admin {
+		l.Debugf("user %s not an admin", authed.User.ID)
+		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	l.Tracef("parsing request form: %+v", c.Request.Form)
+	form := &model.DomainSilenceCreateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.Debugf("error parsing form %+v: %s", c.Request.Form, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("could not parse form: %s", err)})
+		return
+	}
+
+	if err := validateCreateDomainSilence(form); err != nil {
+		l.Debugf("error validating form: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	domainSilence, errWithCode := m.processor.AdminDomainSilenceCreate(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		l.Debugf("error creating domain silence: %s", errWithCode.Error())
+		api.ErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.JSON(http.StatusOK, domainSilence)
+}
+
+func validateCreateDomainSilence(form *model.DomainSilenceCreateRequest) error {
+	if form.Domain == "" {
+		return errors.New("empty domain provided")
+	}
+
+	return nil
+}
@@ -25,8 +25,10 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 	"github.com/superseriousbusiness/gotosocial/internal/api"
 	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
@@ -56,9 +58,17 @@ import (
 //   required: true
 // - name: image
 //   in: formData
-//   description: A png or gif image of the emoji. Animated pngs work too!
+//   description: |-
+//     A png or gif image of the emoji. Animated pngs work too!
+//     Images larger than the instance's emoji_max_size are rejected, and images wider or taller than its
+//     emoji_max_dimension are scaled down to fit. Animated pngs can't be scaled, so those are rejected instead.
 //   type: file
 //   required: true
+// - name: category
+//   in: formData
+//   description: Category in which to place the emoji in emoji pickers. Leave empty for no category.
+//   type: string
+//   maxLength: 64
 //
 // security:
 // - OAuth2 Bearer:
@@ -68,7 +78,7 @@ import (
 //   '200':
 //     description: The newly-created emoji.
 //     schema:
-//       "$ref": "#/definitions/emoji"
+//       "$ref": "#/definitions/adminEmoji"
 //   '403':
 //      description: forbidden
 //   '400':
@@ -76,7 +86,7 @@ import (
 //   '409':
 //      description: conflict -- domain/shortcode combo for emoji already exists
 func (m *Module) EmojiCreatePOSTHandler(c *gin.Context) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "emojiCreatePOSTHandler",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
@@ -90,6 +100,10 @@ func (m *Module) EmojiCreatePOSTHandler(c *gin.Context) {
 		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
 		return
 	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeAdmin) {
+		return
+	}
 	if !authed.User.Admin {
 		l.Debugf("user %s not an admin", authed.User.ID)
 		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
@@ -121,7 +135,7 @@ func (m *Module) EmojiCreatePOSTHandler(c *gin.Context) {
 	apiEmoji, errWithCode := m.processor.AdminEmojiCreate(c.Request.Context(), authed, form)
 	if errWithCode != nil {
 		l.Debugf("error creating emoji: %s", errWithCode.Error())
-		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		api.ErrorHandler(c, errWithCode)
 		return
 	}
 
@@ -134,5 +148,22 @@ func validateCreateEmoji(form *model.EmojiCreateRequest) error {
 		return errors.New("no emoji given")
 	}
 
+	if err := validateEmojiImageSize(form.Image.Size); err != nil {
+		return err
+	}
+
+	if err := validate.EmojiCategory(form.Category); err != nil {
+		return err
+	}
+
 	return validate.EmojiShortcode(form.Shortcode)
 }
+
+// validateEmojiImageSize checks the size of an uploaded emoji image against the configured maximum,
+// so that we can reject an image that's too large before going to the trouble of processing it.
+func validateEmojiImageSize(size int64) error {
+	if maxSize := viper.GetInt(config.Keys.MediaEmojiMaxSize); maxSize > 0 && size > int64(maxSize) {
+		return fmt.Errorf("emoji image size limit exceeded: limit is %d bytes but image was %d bytes", maxSize, size)
+	}
+	return nil
+}
@@ -0,0 +1,182 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// EmojiUpdatePATCHHandler swagger:operation PATCH /api/v1/admin/custom_emojis/{id} emojiUpdate
+//
+// Update the instance emoji with the given ID. Fields that aren't given are left as they were.
+//
+// The emoji keeps its ID when it's updated, so existing references to it aren't broken, even if it's renamed
+// or given a new image. A new image is processed in the same way as the image of a newly-created emoji.
+//
+// ---
+// tags:
+// - admin
+//
+// consumes:
+// - application/json
+// - application/xml
+// - application/x-www-form-urlencoded
+// - multipart/form-data
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: id
+//   type: string
+//   description: The id of the emoji.
+//   in: path
+//   required: true
+// - name: shortcode
+//   in: formData
+//   description: |-
+//     New code to use for the emoji, which will be used by instance denizens to select it.
+//     This must be unique on the instance.
+//   type: string
+//   pattern: \w{2,30}
+// - name: category
+//   in: formData
+//   description: Category in which to place the emoji in emoji pickers. Set to an empty string to remove the emoji from its category.
+//   type: string
+//   maxLength: 64
+// - name: disabled
+//   in: formData
+//   description: Disable the emoji, so that it isn't shown on this instance, or enable it again.
+//   type: boolean
+// - name: image
+//   in: formData
+//   description: |-
+//     A new png or gif image for the emoji. Animated pngs work too!
+//     The same size and dimension limits apply as for the image of a newly-created emoji.
+//   type: file
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The updated emoji.
+//     schema:
+//       "$ref": "#/definitions/adminEmoji"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+//   '409':
+//      description: conflict -- domain/shortcode combo for emoji already exists
+func (m *Module) EmojiUpdatePATCHHandler(c *gin.Context) {
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+		"func":        "EmojiUpdatePATCHHandler",
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+
+	// make sure we're authed with an admin account
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("couldn't auth: %s", err)
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeAdmin) {
+		return
+	}
+	if !authed.User.Admin {
+		l.Debugf("user %s not an admin", authed.User.ID)
+		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	emojiID := c.Param(IDKey)
+	if emojiID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no emoji id provided"})
+		return
+	}
+
+	form := &model.EmojiUpdateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.Debugf("error parsing form: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("could not parse form: %s", err)})
+		return
+	}
+
+	if err := validateUpdateEmoji(form); err != nil {
+		l.Debugf("error validating form: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	apiEmoji, errWithCode := m.processor.AdminEmojiUpdate(c.Request.Context(), authed, emojiID, form)
+	if errWithCode != nil {
+		l.Debugf("error updating emoji: %s", errWithCode.Error())
+		api.ErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.JSON(http.StatusOK, apiEmoji)
+}
+
+func validateUpdateEmoji(form *model.EmojiUpdateRequest) error {
+	if form.Shortcode != nil {
+		if err := validate.EmojiShortcode(*form.Shortcode); err != nil {
+			return err
+		}
+	}
+
+	if form.Category != nil {
+		if err := validate.EmojiCategory(*form.Category); err != nil {
+			return err
+		}
+	}
+
+	if form.Image != nil {
+		if form.Image.Size == 0 {
+			return errors.New("emoji image was empty")
+		}
+		if err := validateEmojiImageSize(form.Image.Size); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
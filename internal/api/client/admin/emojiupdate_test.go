@@ -0,0 +1,132 @@
+/*
+GoToSocial
+Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU Affero General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU Affero General Public License for more details.
+
+You should have received a copy of the GNU Affero General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type EmojiUpdateTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *EmojiUpdateTestSuite) updateEmoji(emojiID string, fileName string, fields map[string]string) *httptest.ResponseRecorder {
+	requestBody, w, err := testrig.CreateMultipartFormData("image", fileName, fields)
+	if err != nil {
+		panic(err)
+	}
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPatch, requestBody.Bytes(), admin.EmojiPath+"/"+emojiID, w.FormDataContentType())
+	ctx.Params = gin.Params{gin.Param{Key: admin.IDKey, Value: emojiID}}
+
+	suite.adminModule.EmojiUpdatePATCHHandler(ctx)
+	return recorder
+}
+
+func (suite *EmojiUpdateTestSuite) TestEmojiUpdateFields() {
+	testEmoji := testrig.NewTestEmojis()["rainbow"]
+
+	recorder := suite.updateEmoji(testEmoji.ID, "", map[string]string{
+		"shortcode": "double_rainbow",
+		"category":  "weather",
+		"disabled":  "true",
+	})
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	apiEmoji := &apimodel.AdminEmoji{}
+	suite.NoError(json.Unmarshal(b, apiEmoji))
+	suite.Equal(testEmoji.ID, apiEmoji.ID)
+	suite.Equal("double_rainbow", apiEmoji.Shortcode)
+	suite.Equal("weather", apiEmoji.Category)
+	suite.True(apiEmoji.Disabled)
+
+	// the image shouldn't have changed
+	suite.Equal(testEmoji.ImageURL, apiEmoji.URL)
+
+	dbEmoji := &gtsmodel.Emoji{}
+	suite.NoError(suite.db.GetByID(context.Background(), testEmoji.ID, dbEmoji))
+	suite.Equal("double_rainbow", dbEmoji.Shortcode)
+	suite.Equal("weather", dbEmoji.Category)
+	suite.True(dbEmoji.Disabled)
+	suite.Equal(testEmoji.ImagePath, dbEmoji.ImagePath)
+}
+
+func (suite *EmojiUpdateTestSuite) TestEmojiUpdateImage() {
+	testEmoji := testrig.NewTestEmojis()["rainbow"]
+
+	recorder := suite.updateEmoji(testEmoji.ID, "../../../../testrig/media/rainbow-static.png", nil)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	dbEmoji := &gtsmodel.Emoji{}
+	suite.NoError(suite.db.GetByID(context.Background(), testEmoji.ID, dbEmoji))
+	suite.Equal(testEmoji.Shortcode, dbEmoji.Shortcode)
+
+	// the new image should be stored under new paths, and the old one should be gone
+	suite.NotEqual(testEmoji.ImagePath, dbEmoji.ImagePath)
+	suite.NotEqual(testEmoji.ImageStaticPath, dbEmoji.ImageStaticPath)
+	suite.NotEqual(testEmoji.ImageFileSize, dbEmoji.ImageFileSize)
+
+	emojiBytes, err := suite.storage.Get(dbEmoji.ImagePath)
+	suite.NoError(err)
+	suite.Len(emojiBytes, dbEmoji.ImageFileSize)
+
+	_, err = suite.storage.Get(testEmoji.ImagePath)
+	suite.Error(err)
+}
+
+func (suite *EmojiUpdateTestSuite) TestEmojiUpdateShortcodeConflict() {
+	testEmoji := testrig.NewTestEmojis()["rainbow"]
+
+	// put a second emoji in the db to try to give the shortcode of the first
+	other := &gtsmodel.Emoji{}
+	*other = *testEmoji
+	other.ID = "01GEM7SFDZ7GZNRXFVZ3X4E4N1"
+	other.Shortcode = "another_rainbow"
+	other.URI = "http://localhost:8080/emoji/" + other.ID
+	suite.NoError(suite.db.Put(context.Background(), other))
+
+	recorder := suite.updateEmoji(other.ID, "", map[string]string{"shortcode": "rainbow"})
+	suite.Equal(http.StatusConflict, recorder.Code)
+}
+
+func (suite *EmojiUpdateTestSuite) TestEmojiUpdateNotFound() {
+	recorder := suite.updateEmoji("01GEM80JCFMQ7Y4GGBRTMX1GQ4", "", map[string]string{"category": "weather"})
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func TestEmojiUpdateTestSuite(t *testing.T) {
+	suite.Run(t, &EmojiUpdateTestSuite{})
+}
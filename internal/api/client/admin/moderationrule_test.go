@@ -0,0 +1,146 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+type ModerationRuleTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *ModerationRuleTestSuite) readBody(recorder *httptest.ResponseRecorder) []byte {
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+	return b
+}
+
+func (suite *ModerationRuleTestSuite) TestCreateListAndDeleteModerationRule() {
+	// create a rule, relying on the default actions
+	form := url.Values{"pattern": {"cheap sunglasses"}, "private_comment": {"<p>spam wave</p>"}}
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, []byte(form.Encode()), admin.ModerationRulesPath, "application/x-www-form-urlencoded")
+	suite.adminModule.ModerationRulesPOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	moderationRule := &apimodel.ModerationRule{}
+	suite.NoError(json.Unmarshal(suite.readBody(recorder), moderationRule))
+	suite.NotEmpty(moderationRule.ID)
+	suite.Equal("cheap sunglasses", moderationRule.Pattern)
+	suite.False(moderationRule.Regex)
+	suite.Equal("hold", moderationRule.LocalAction)
+	suite.Equal("flag", moderationRule.RemoteAction)
+	suite.Equal("spam wave", moderationRule.PrivateComment)
+	suite.Equal(suite.testAccounts["admin_account"].ID, moderationRule.CreatedBy)
+
+	// the rule should show up in the list
+	recorder = httptest.NewRecorder()
+	ctx = suite.newContext(recorder, http.MethodGet, nil, admin.ModerationRulesPath, "")
+	suite.adminModule.ModerationRulesGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	moderationRules := []*apimodel.ModerationRule{}
+	suite.NoError(json.Unmarshal(suite.readBody(recorder), &moderationRules))
+	suite.Len(moderationRules, 1)
+	suite.Equal(moderationRule.ID, moderationRules[0].ID)
+
+	// delete it again
+	recorder = httptest.NewRecorder()
+	ctx = suite.newContext(recorder, http.MethodDelete, nil, admin.ModerationRulesPath+"/"+moderationRule.ID, "")
+	ctx.Params = gin.Params{gin.Param{Key: admin.IDKey, Value: moderationRule.ID}}
+	suite.adminModule.ModerationRuleDELETEHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	recorder = httptest.NewRecorder()
+	ctx = suite.newContext(recorder, http.MethodGet, nil, admin.ModerationRulesPath, "")
+	suite.adminModule.ModerationRulesGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal(`[]`, string(suite.readBody(recorder)))
+}
+
+func (suite *ModerationRuleTestSuite) TestCreateModerationRuleInvalid() {
+	for _, form := range []url.Values{
+		{"pattern": {"(unclosed"}, "regex": {"true"}},
+		{"pattern": {"sunglasses"}, "local_action": {"flag"}},
+		{"private_comment": {"hmm"}},
+	} {
+		recorder := httptest.NewRecorder()
+		ctx := suite.newContext(recorder, http.MethodPost, []byte(form.Encode()), admin.ModerationRulesPath, "application/x-www-form-urlencoded")
+		suite.adminModule.ModerationRulesPOSTHandler(ctx)
+		suite.Equal(http.StatusBadRequest, recorder.Code)
+	}
+}
+
+func (suite *ModerationRuleTestSuite) TestListAndRejectModeratedStatus() {
+	// hold one of zork's statuses for review
+	status := suite.testStatuses["local_account_1_status_1"]
+	status.ModerationRuleID = "01G5Q2D4XJ0DVB0BXF6Z7QKQ3R"
+	status.HeldAt = time.Now()
+	suite.NoError(suite.db.UpdateStatus(context.Background(), status))
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, admin.ModeratedStatusesPath, "")
+	suite.adminModule.ModeratedStatusesGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	moderatedStatuses := []*apimodel.AdminModeratedStatus{}
+	suite.NoError(json.Unmarshal(suite.readBody(recorder), &moderatedStatuses))
+	suite.Len(moderatedStatuses, 1)
+	suite.Equal(status.ID, moderatedStatuses[0].Status.ID)
+	suite.Equal("01G5Q2D4XJ0DVB0BXF6Z7QKQ3R", moderatedStatuses[0].ModerationRuleID)
+	suite.True(moderatedStatuses[0].Held)
+
+	// reject it, which deletes it
+	recorder = httptest.NewRecorder()
+	ctx = suite.newContext(recorder, http.MethodPost, nil, admin.ModeratedStatusesPath+"/"+status.ID+"/reject", "")
+	ctx.Params = gin.Params{gin.Param{Key: admin.IDKey, Value: status.ID}}
+	suite.adminModule.ModeratedStatusRejectPOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	_, err := suite.db.GetStatusByID(context.Background(), status.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// statuses which aren't waiting for review can't be approved
+	other := suite.testStatuses["local_account_1_status_2"]
+	recorder = httptest.NewRecorder()
+	ctx = suite.newContext(recorder, http.MethodPost, nil, admin.ModeratedStatusesPath+"/"+other.ID+"/approve", "")
+	ctx.Params = gin.Params{gin.Param{Key: admin.IDKey, Value: other.ID}}
+	suite.adminModule.ModeratedStatusApprovePOSTHandler(ctx)
+	suite.Equal(http.StatusNotFound, recorder.Code)
+}
+
+func TestModerationRuleTestSuite(t *testing.T) {
+	suite.Run(t, new(ModerationRuleTestSuite))
+}
@@ -0,0 +1,148 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ModerationRulesPOSTHandler swagger:operation POST /api/v1/admin/moderation_rules moderationRuleCreate
+//
+// Create a moderation rule.
+//
+// New statuses are checked against all moderation rules, by matching the rule's pattern against their text
+// and content warning, ignoring case. A pattern which isn't a regular expression only matches whole words
+// or phrases.
+//
+// Statuses posted on this instance which match a rule are either held, which means only their author can see
+// them until an admin approves them, or rejected outright, in which case the author gets an error explaining why.
+// Statuses received from other instances which match a rule are either flagged for an admin to review, while
+// being delivered as usual, or dropped.
+//
+// ---
+// tags:
+// - admin
+//
+// consumes:
+// - multipart/form-data
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: pattern
+//   in: formData
+//   description: Word, phrase, or regular expression to match statuses against.
+//   type: string
+//   required: true
+// - name: regex
+//   in: formData
+//   description: Whether the pattern is a regular expression.
+//   type: boolean
+//   default: false
+// - name: local_action
+//   in: formData
+//   description: What to do with matching statuses posted on this instance.
+//   type: string
+//   enum:
+//     - hold
+//     - reject
+//   default: hold
+// - name: remote_action
+//   in: formData
+//   description: What to do with matching statuses received from other instances.
+//   type: string
+//   enum:
+//     - flag
+//     - drop
+//   default: flag
+// - name: private_comment
+//   in: formData
+//   description: |-
+//     Private comment about this moderation rule. Will only be shown to other admins, so this
+//     is a useful way of keeping track of why a rule was created.
+//   type: string
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The newly created moderation rule.
+//     schema:
+//       "$ref": "#/definitions/moderationRule"
+//   '403':
+//      description: forbidden
+//   '400':
+//      description: bad request
+func (m *Module) ModerationRulesPOSTHandler(c *gin.Context) {
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+		"func":        "ModerationRulesPOSTHandler",
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+
+	// make sure we're authed with an admin account
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("couldn't auth: %s", err)
+		c.JSON(http.StatusForbidden, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeAdmin) {
+		return
+	}
+	if !authed.User.Admin {
+		l.Debugf("user %s not an admin", authed.User.ID)
+		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	l.Tracef("parsing request form: %+v", c.Request.Form)
+	form := &model.ModerationRuleCreateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.Debugf("error parsing form %+v: %s", c.Request.Form, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("could not parse form: %s", err)})
+		return
+	}
+
+	moderationRule, errWithCode := m.processor.AdminModerationRuleCreate(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		l.Debugf("error creating moderation rule: %s", errWithCode.Error())
+		api.ErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.JSON(http.StatusOK, moderationRule)
+}
@@ -0,0 +1,135 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// ReadOnlyPOSTHandler swagger:operation POST /api/v1/admin/read_only readOnlySet
+//
+// Switch read-only (maintenance) mode on or off.
+//
+// While the instance is read-only, it keeps serving reads, but rejects writes like new statuses, follows, blocks,
+// and media uploads with 503 Service Unavailable and the read-only message. This is useful during upgrades and migrations.
+//
+// This takes effect immediately, without a restart, and takes precedence over the instance-read-only config setting.
+// The setting is stored in the database, so it persists across restarts until it is set again.
+// The current state is shown in the read_only field of the instance API.
+//
+// ---
+// tags:
+// - admin
+//
+// consumes:
+// - multipart/form-data
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: read_only
+//   in: formData
+//   description: Whether the instance should be in read-only mode.
+//   type: boolean
+//   required: true
+// - name: message
+//   in: formData
+//   description: |-
+//     Message to show to users while the instance is in read-only mode.
+//     If not set, the instance-read-only-message config setting will be used.
+//   type: string
+//
+// security:
+// - OAuth2 Bearer:
+//   - admin
+//
+// responses:
+//   '200':
+//     description: The new state of read-only mode.
+//     schema:
+//       "$ref": "#/definitions/adminReadOnly"
+//   '400':
+//      description: bad request
+//   '401':
+//      description: unauthorized
+//   '403':
+//      description: forbidden
+func (m *Module) ReadOnlyPOSTHandler(c *gin.Context) {
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+		"func":        "ReadOnlyPOSTHandler",
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+
+	// make sure we're authed...
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("couldn't auth: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeAdmin) {
+		return
+	}
+
+	// with an admin account
+	if !authed.User.Admin {
+		l.Debugf("user %s not an admin", authed.User.ID)
+		c.JSON(http.StatusForbidden, gin.H{"error": "not an admin"})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	// extract the form from the request context
+	l.Tracef("parsing request form: %+v", c.Request.Form)
+	form := &model.AdminReadOnlyRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.Debugf("error parsing form %+v: %s", c.Request.Form, err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("could not parse form: %s", err)})
+		return
+	}
+
+	if form.ReadOnly == nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "read_only not specified"})
+		return
+	}
+
+	readOnly, errWithCode := m.processor.AdminReadOnlySet(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		l.Debugf("error setting read-only mode: %s", errWithCode.Error())
+		api.ErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.JSON(http.StatusOK, readOnly)
+}
@@ -0,0 +1,85 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type ReadOnlyTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *ReadOnlyTestSuite) postReadOnly(form url.Values) (int, []byte) {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, []byte(form.Encode()), admin.ReadOnlyPath, "application/x-www-form-urlencoded")
+
+	suite.adminModule.ReadOnlyPOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+	return recorder.Code, b
+}
+
+func (suite *ReadOnlyTestSuite) TestSetAndUnsetReadOnly() {
+	code, b := suite.postReadOnly(url.Values{"read_only": {"true"}, "message": {"migrating, back soon"}})
+	suite.Equal(http.StatusOK, code)
+
+	readOnly := &apimodel.AdminReadOnly{}
+	suite.NoError(json.Unmarshal(b, readOnly))
+	suite.True(readOnly.ReadOnly)
+	suite.Equal("migrating, back soon", readOnly.Message)
+
+	dbReadOnly, message, err := suite.db.GetReadOnly(context.Background())
+	suite.NoError(err)
+	suite.True(dbReadOnly)
+	suite.Equal("migrating, back soon", message)
+
+	code, b = suite.postReadOnly(url.Values{"read_only": {"false"}})
+	suite.Equal(http.StatusOK, code)
+	suite.NoError(json.Unmarshal(b, readOnly))
+	suite.False(readOnly.ReadOnly)
+	suite.Equal("this instance is in read-only mode for maintenance, please try again later", readOnly.Message)
+
+	dbReadOnly, _, err = suite.db.GetReadOnly(context.Background())
+	suite.NoError(err)
+	suite.False(dbReadOnly)
+}
+
+func (suite *ReadOnlyTestSuite) TestReadOnlyNotSpecified() {
+	code, b := suite.postReadOnly(url.Values{"message": {"migrating, back soon"}})
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal(`{"error":"read_only not specified"}`, string(b))
+}
+
+func TestReadOnlyTestSuite(t *testing.T) {
+	suite.Run(t, new(ReadOnlyTestSuite))
+}
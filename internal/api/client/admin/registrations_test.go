@@ -0,0 +1,82 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type RegistrationsTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *RegistrationsTestSuite) postRegistrations(form url.Values) (int, []byte) {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, []byte(form.Encode()), admin.RegistrationsPath, "application/x-www-form-urlencoded")
+
+	suite.adminModule.RegistrationsPOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+	return recorder.Code, b
+}
+
+func (suite *RegistrationsTestSuite) TestCloseAndReopenRegistrations() {
+	code, b := suite.postRegistrations(url.Values{"open": {"false"}})
+	suite.Equal(http.StatusOK, code)
+
+	registrations := &apimodel.AdminRegistrations{}
+	suite.NoError(json.Unmarshal(b, registrations))
+	suite.False(registrations.Open)
+
+	open, err := suite.db.GetRegistrationsOpen(context.Background())
+	suite.NoError(err)
+	suite.False(open)
+
+	code, b = suite.postRegistrations(url.Values{"open": {"true"}})
+	suite.Equal(http.StatusOK, code)
+	suite.NoError(json.Unmarshal(b, registrations))
+	suite.True(registrations.Open)
+
+	open, err = suite.db.GetRegistrationsOpen(context.Background())
+	suite.NoError(err)
+	suite.True(open)
+}
+
+func (suite *RegistrationsTestSuite) TestRegistrationsNoOpen() {
+	code, b := suite.postRegistrations(url.Values{})
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal(`{"error":"open not specified"}`, string(b))
+}
+
+func TestRegistrationsTestSuite(t *testing.T) {
+	suite.Run(t, new(RegistrationsTestSuite))
+}
@@ -0,0 +1,172 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/admin"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type RelationshipSeveranceTestSuite struct {
+	AdminStandardTestSuite
+}
+
+func (suite *RelationshipSeveranceTestSuite) SetupTest() {
+	suite.AdminStandardTestSuite.SetupTest()
+	// severances are processed by the client API worker
+	suite.NoError(suite.processor.Start())
+}
+
+func (suite *RelationshipSeveranceTestSuite) TearDownTest() {
+	suite.NoError(suite.processor.Stop())
+	suite.AdminStandardTestSuite.TearDownTest()
+}
+
+func (suite *RelationshipSeveranceTestSuite) postSeverance(form url.Values) (int, *apimodel.AdminRelationshipSeverance) {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, []byte(form.Encode()), admin.RelationshipSeverancesPath, "application/x-www-form-urlencoded")
+
+	suite.adminModule.RelationshipSeverancePOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	severance := &apimodel.AdminRelationshipSeverance{}
+	suite.NoError(json.Unmarshal(b, severance))
+	return recorder.Code, severance
+}
+
+func (suite *RelationshipSeveranceTestSuite) getSeverance(id string) (int, *apimodel.AdminRelationshipSeverance) {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, nil, admin.RelationshipSeverancesPath+"/"+id, "")
+	ctx.Params = gin.Params{{Key: admin.IDKey, Value: id}}
+
+	suite.adminModule.RelationshipSeveranceGETHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	severance := &apimodel.AdminRelationshipSeverance{}
+	suite.NoError(json.Unmarshal(b, severance))
+	return recorder.Code, severance
+}
+
+// waitForSeverance polls the severance with the given ID until it's finished.
+func (suite *RelationshipSeveranceTestSuite) waitForSeverance(id string) *apimodel.AdminRelationshipSeverance {
+	var severance *apimodel.AdminRelationshipSeverance
+	suite.Eventually(func() bool {
+		var code int
+		code, severance = suite.getSeverance(id)
+		return code == http.StatusOK && severance.State == "finished"
+	}, 5*time.Second, 10*time.Millisecond)
+	return severance
+}
+
+func (suite *RelationshipSeveranceTestSuite) TestSeverDomain() {
+	code, severance := suite.postSeverance(url.Values{"domain": {"fossbros-anonymous.io"}})
+	suite.Equal(http.StatusAccepted, code)
+	suite.NotEmpty(severance.ID)
+	suite.Equal("fossbros-anonymous.io", severance.Domain)
+	suite.NotEmpty(severance.CreatedAt)
+
+	severance = suite.waitForSeverance(severance.ID)
+	suite.Equal(1, severance.AccountsTotal)
+	suite.Equal(1, severance.AccountsProcessed)
+	suite.Equal(1, severance.RelationshipsRemoved)
+	suite.Zero(severance.Errors)
+	suite.NotEmpty(severance.FinishedAt)
+
+	// the block of the remote account should be gone
+	_, err := suite.db.GetBlock(context.Background(), suite.testAccounts["local_account_2"].ID, suite.testAccounts["remote_account_1"].ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *RelationshipSeveranceTestSuite) TestSeverAccounts() {
+	turtle := suite.testAccounts["local_account_2"]
+	zork := suite.testAccounts["local_account_1"]
+	adminAccount := suite.testAccounts["admin_account"]
+
+	code, severance := suite.postSeverance(url.Values{"account_ids[]": {turtle.ID}})
+	suite.Equal(http.StatusAccepted, code)
+	suite.Equal([]string{turtle.ID}, severance.AccountIDs)
+
+	severance = suite.waitForSeverance(severance.ID)
+	suite.Equal(1, severance.AccountsTotal)
+	// two follows between turtle and zork, and turtle's block of a remote account
+	suite.Equal(3, severance.RelationshipsRemoved)
+	suite.Zero(severance.Errors)
+
+	follows, err := suite.db.IsFollowing(context.Background(), zork, turtle)
+	suite.NoError(err)
+	suite.False(follows)
+	follows, err = suite.db.IsFollowing(context.Background(), turtle, zork)
+	suite.NoError(err)
+	suite.False(follows)
+
+	// relationships not involving turtle should be untouched
+	follows, err = suite.db.IsFollowing(context.Background(), zork, adminAccount)
+	suite.NoError(err)
+	suite.True(follows)
+}
+
+func (suite *RelationshipSeveranceTestSuite) TestSeverBadRequests() {
+	for _, form := range []url.Values{
+		{},
+		{"domain": {"fossbros-anonymous.io"}, "account_ids[]": {suite.testAccounts["remote_account_1"].ID}},
+		{"domain": {"localhost:8080"}},
+		{"account_ids[]": {suite.testAccounts["admin_account"].ID}},
+	} {
+		code, _ := suite.postSeverance(form)
+		suite.Equal(http.StatusBadRequest, code, form.Encode())
+	}
+
+	code, _ := suite.postSeverance(url.Values{"account_ids[]": {"01GZZZZZZZZZZZZZZZZZZZZZZZ"}})
+	suite.Equal(http.StatusNotFound, code)
+
+	// no relationships should have been removed
+	blocks := []*gtsmodel.Block{}
+	suite.NoError(suite.db.GetAll(context.Background(), &blocks))
+	suite.Len(blocks, 1)
+}
+
+func (suite *RelationshipSeveranceTestSuite) TestGetSeveranceNotFound() {
+	code, _ := suite.getSeverance("01GZZZZZZZZZZZZZZZZZZZZZZZ")
+	suite.Equal(http.StatusNotFound, code)
+}
+
+func TestRelationshipSeveranceTestSuite(t *testing.T) {
+	suite.Run(t, new(RelationshipSeveranceTestSuite))
+}
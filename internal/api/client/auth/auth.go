@@ -49,6 +49,12 @@ const (
 	// OauthAuthorizePath is the API path for authorization requests (eg., authorize this app to act on my behalf as a user)
 	OauthAuthorizePath = "/oauth/authorize"
 
+	// OauthIntrospectPath is the API path for introspecting tokens, to check whether they're active and what they can be used for
+	OauthIntrospectPath = "/oauth/introspect"
+
+	// OauthRevokePath is the API path for revoking tokens, eg., when a user logs out of an application
+	OauthRevokePath = "/oauth/revoke"
+
 	// CallbackPath is the API path for receiving callback tokens from external OIDC providers
 	CallbackPath = oidc.CallbackPath
 
@@ -62,6 +68,9 @@ const (
 	sessionResponseType = "response_type"
 	sessionScope        = "scope"
 	sessionState        = "state"
+
+	// oobRedirectURI is the redirect uri used by apps which want the authorization code shown to the user instead
+	oobRedirectURI = "urn:ietf:wg:oauth:2.0:oob"
 )
 
 // Module implements the ClientAPIModule interface for
@@ -90,6 +99,9 @@ func (m *Module) Route(s router.Router) error {
 	s.AttachHandler(http.MethodGet, OauthAuthorizePath, m.AuthorizeGETHandler)
 	s.AttachHandler(http.MethodPost, OauthAuthorizePath, m.AuthorizePOSTHandler)
 
+	s.AttachHandler(http.MethodPost, OauthIntrospectPath, m.IntrospectPOSTHandler)
+	s.AttachHandler(http.MethodPost, OauthRevokePath, m.RevokePOSTHandler)
+
 	s.AttachHandler(http.MethodGet, CallbackPath, m.CallbackGETHandler)
 	return nil
 }
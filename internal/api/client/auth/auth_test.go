@@ -56,8 +56,11 @@ type AuthStandardTestSuite struct {
 }
 
 const (
-	sessionUserID   = "userid"
-	sessionClientID = "client_id"
+	sessionUserID       = "userid"
+	sessionClientID     = "client_id"
+	sessionRedirectURI  = "redirect_uri"
+	sessionResponseType = "response_type"
+	sessionScope        = "scope"
 )
 
 func (suite *AuthStandardTestSuite) SetupSuite() {
@@ -104,8 +107,12 @@ func (suite *AuthStandardTestSuite) newContext(requestMethod string, requestPath
 	ctx.Request.Header.Set("accept", "text/html")
 
 	// trigger the session middleware on the context
+	sessionOptions, err := router.SessionOptions()
+	if err != nil {
+		panic(err)
+	}
 	store := memstore.NewStore(make([]byte, 32), make([]byte, 32))
-	store.Options(router.SessionOptions())
+	store.Options(sessionOptions)
 	sessionMiddleware := sessions.Sessions("gotosocial-localhost", store)
 	sessionMiddleware(ctx)
 
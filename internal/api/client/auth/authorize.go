@@ -34,6 +34,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
 // AuthorizeGETHandler should be served as GET at https://example.org/oauth/authorize
@@ -120,21 +121,23 @@ func (m *Module) AuthorizeGETHandler(c *gin.Context) {
 	}
 
 	// the authorize template will display a form to the user where they can get some information
-	// about the app that's trying to authorize, and the scope of the request.
-	// They can then approve it if it looks OK to them, which will POST to the AuthorizePOSTHandler
+	// about the app that's trying to authorize, and a description of each scope of the request.
+	// They can then allow or deny it, which will POST to the AuthorizePOSTHandler
 	l.Trace("serving authorize html")
 	c.HTML(http.StatusOK, "authorize.tmpl", gin.H{
 		"appname":    app.Name,
 		"appwebsite": app.Website,
 		"redirect":   redirect,
 		sessionScope: scope,
+		"scopes":     oauth.DescribeScopes(scope),
 		"user":       acct.Username,
 	})
 }
 
 // AuthorizePOSTHandler should be served as POST at https://example.org/oauth/authorize
-// At this point we assume that the user has A) logged in and B) accepted that the app should act for them,
-// so we should proceed with the authentication flow and generate an oauth token for them if we can.
+// At this point we assume that the user has logged in, and either allowed or denied the app to act for them.
+// If they allowed it, we should proceed with the authentication flow and generate an oauth token for them if we can.
+// If they denied it, we should redirect them back to the app with an access_denied error.
 func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
 	l := logrus.WithField("func", "AuthorizePOSTHandler")
 	s := sessions.Default(c)
@@ -200,6 +203,11 @@ func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if c.PostForm("decision") == "deny" {
+		m.denyAuthorization(c, clientID, redirectURI)
+		return
+	}
+
 	// now set the values on the request
 	values := url.Values{}
 	values.Set(sessionForceLogin, forceLogin)
@@ -217,6 +225,42 @@ func (m *Module) AuthorizePOSTHandler(c *gin.Context) {
 	}
 }
 
+// denyAuthorization redirects the user back to the app with the given client ID, to let it know that
+// the user denied it access, as described in https://datatracker.ietf.org/doc/html/rfc6749#section-4.1.2.1
+func (m *Module) denyAuthorization(c *gin.Context, clientID string, redirectURI string) {
+	// make sure we only ever redirect to the uri registered by the app,
+	// since we can't leave it up to the oauth2 library to check this here
+	app := &gtsmodel.Application{}
+	if err := m.db.GetWhere(c.Request.Context(), []db.Where{{Key: sessionClientID, Value: clientID}}, app); err != nil {
+		c.HTML(http.StatusInternalServerError, "error.tmpl", gin.H{
+			"error": fmt.Sprintf("no application found for client id %s", clientID),
+		})
+		return
+	}
+	if redirectURI != app.RedirectURI {
+		c.HTML(http.StatusBadRequest, "error.tmpl", gin.H{"error": "redirect_uri does not match the application's redirect uri"})
+		return
+	}
+
+	if redirectURI == oobRedirectURI {
+		// there's nowhere to redirect to, so just tell the user
+		c.HTML(http.StatusOK, "error.tmpl", gin.H{"error": fmt.Sprintf("you denied %s access to your account", app.Name)})
+		return
+	}
+
+	u, err := url.Parse(redirectURI)
+	if err != nil {
+		c.HTML(http.StatusBadRequest, "error.tmpl", gin.H{"error": fmt.Sprintf("could not parse redirect_uri: %s", err)})
+		return
+	}
+	q := u.Query()
+	q.Set("error", "access_denied")
+	q.Set("error_description", "the user denied the request")
+	u.RawQuery = q.Encode()
+
+	c.Redirect(http.StatusFound, u.String())
+}
+
 // extractAuthForm checks the given OAuthAuthorize form, and stores
 // the values in the form into the session.
 func extractAuthForm(s sessions.Session, form *model.OAuthAuthorize) error {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
 	"testing"
 	"time"
 
@@ -106,6 +107,64 @@ func (suite *AuthAuthorizeTestSuite) TestAccountAuthorizeHandler() {
 	}
 }
 
+func (suite *AuthAuthorizeTestSuite) TestAuthorizeGETShowsScopes() {
+	ctx, recorder := suite.newContext(http.MethodGet, auth.OauthAuthorizePath)
+
+	testSession := sessions.Default(ctx)
+	testSession.Set(sessionUserID, suite.testUsers["local_account_1"].ID)
+	testSession.Set(sessionClientID, suite.testApplications["application_1"].ClientID)
+	testSession.Set(sessionRedirectURI, suite.testApplications["application_1"].RedirectURI)
+	testSession.Set(sessionScope, "read:statuses write:media")
+	suite.NoError(testSession.Save())
+
+	suite.authModule.AuthorizeGETHandler(ctx)
+
+	suite.Equal(http.StatusOK, recorder.Code)
+	body := recorder.Body.String()
+	suite.Contains(body, "really cool gts application")
+	suite.Contains(body, "See statuses, including your timelines")
+	suite.Contains(body, "Upload and modify media attachments")
+	suite.NotContains(body, "Change your password")
+	suite.Contains(body, `value="deny"`)
+}
+
+func (suite *AuthAuthorizeTestSuite) TestAuthorizePOSTDeny() {
+	ctx, recorder := suite.newContext(http.MethodPost, auth.OauthAuthorizePath)
+	ctx.Request.PostForm = url.Values{"decision": {"deny"}}
+
+	testSession := sessions.Default(ctx)
+	testSession.Set(sessionUserID, suite.testUsers["local_account_1"].ID)
+	testSession.Set(sessionClientID, suite.testApplications["application_1"].ClientID)
+	testSession.Set(sessionRedirectURI, suite.testApplications["application_1"].RedirectURI)
+	testSession.Set(sessionResponseType, "code")
+	testSession.Set(sessionScope, "read")
+	suite.NoError(testSession.Save())
+
+	suite.authModule.AuthorizePOSTHandler(ctx)
+
+	// the status of a redirect in response to a POST is only written out by the engine, so check the writer instead
+	suite.Equal(http.StatusFound, ctx.Writer.Status())
+	suite.Equal("http://localhost:8080?error=access_denied&error_description=the+user+denied+the+request", recorder.Header().Get("Location"))
+}
+
+func (suite *AuthAuthorizeTestSuite) TestAuthorizePOSTDenyWrongRedirect() {
+	ctx, recorder := suite.newContext(http.MethodPost, auth.OauthAuthorizePath)
+	ctx.Request.PostForm = url.Values{"decision": {"deny"}}
+
+	testSession := sessions.Default(ctx)
+	testSession.Set(sessionUserID, suite.testUsers["local_account_1"].ID)
+	testSession.Set(sessionClientID, suite.testApplications["application_1"].ClientID)
+	testSession.Set(sessionRedirectURI, "https://evil.example.org")
+	testSession.Set(sessionResponseType, "code")
+	testSession.Set(sessionScope, "read")
+	suite.NoError(testSession.Save())
+
+	suite.authModule.AuthorizePOSTHandler(ctx)
+
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+	suite.Empty(recorder.Header().Get("Location"))
+}
+
 func TestAccountUpdateTestSuite(t *testing.T) {
 	suite.Run(t, new(AuthAuthorizeTestSuite))
 }
@@ -30,6 +30,7 @@ import (
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
 	"github.com/google/uuid"
+	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/oidc"
@@ -92,6 +93,10 @@ func (m *Module) CallbackGETHandler(c *gin.Context) {
 		return
 	}
 
+	if err := m.recordSignIn(c.Request.Context(), user, c.ClientIP()); err != nil {
+		logrus.Errorf("error recording sign in for user %s: %s", user.Email, err)
+	}
+
 	s.Set(sessionUserID, user.ID)
 	if err := s.Save(); err != nil {
 		m.clearSession(s)
@@ -0,0 +1,160 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type introspectBody struct {
+	Token string `form:"token" json:"token" xml:"token"`
+}
+
+// IntrospectPOSTHandler swagger:operation POST /oauth/introspect oauthIntrospect
+//
+// Introspect an OAuth token, as described in RFC 7662.
+//
+// The request must be authorized with a token belonging to the same application as the token being introspected.
+// Tokens belonging to other applications, and tokens which don't exist or have expired, are always reported as inactive.
+//
+// ---
+// tags:
+// - oauth
+//
+// consumes:
+// - application/json
+// - application/xml
+// - application/x-www-form-urlencoded
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: token
+//   in: formData
+//   description: The token to introspect.
+//   type: string
+//   required: true
+//
+// security:
+// - OAuth2 Bearer: []
+//
+// responses:
+//   '200':
+//     description: The state of the introspected token.
+//     schema:
+//       "$ref": "#/definitions/tokenIntrospection"
+//   '400':
+//      description: bad request
+//   '401':
+//      description: unauthorized
+//   '500':
+//      description: internal error
+func (m *Module) IntrospectPOSTHandler(c *gin.Context) {
+	l := logrus.WithContext(c.Request.Context()).WithField("func", "IntrospectPOSTHandler")
+
+	authed, err := oauth.Authed(c, true, true, false, false)
+	if err != nil {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	form := &introspectBody{}
+	if err := c.ShouldBind(form); err != nil || form.Token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no token provided"})
+		return
+	}
+
+	inactive := &apimodel.TokenIntrospection{Active: false}
+
+	// LoadAccessToken also checks that the token hasn't expired
+	ti, err := m.server.LoadAccessToken(c.Request.Context(), form.Token)
+	if err != nil || ti == nil {
+		c.JSON(http.StatusOK, inactive)
+		return
+	}
+
+	// don't leak information about tokens which belong to other applications
+	if ti.GetClientID() != authed.Application.ClientID {
+		c.JSON(http.StatusOK, inactive)
+		return
+	}
+
+	introspection := &apimodel.TokenIntrospection{
+		Active:    true,
+		Scope:     ti.GetScope(),
+		ClientID:  ti.GetClientID(),
+		TokenType: "Bearer",
+		IssuedAt:  ti.GetAccessCreateAt().Unix(),
+	}
+	if expiresIn := ti.GetAccessExpiresIn(); expiresIn != 0 {
+		introspection.ExpiresAt = ti.GetAccessCreateAt().Add(expiresIn).Unix()
+	}
+
+	if userID := ti.GetUserID(); userID != "" {
+		// a user-level token is only active while the user could actually use it
+		user := &gtsmodel.User{}
+		if err := m.db.GetByID(c.Request.Context(), userID, user); err != nil {
+			if err != db.ErrNoEntries {
+				l.Errorf("database error looking for user with id %s: %s", userID, err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+				return
+			}
+			c.JSON(http.StatusOK, inactive)
+			return
+		}
+		if user.ConfirmedAt.IsZero() || !user.Approved || user.Disabled {
+			c.JSON(http.StatusOK, inactive)
+			return
+		}
+
+		acct, err := m.db.GetAccountByID(c.Request.Context(), user.AccountID)
+		if err != nil {
+			if err != db.ErrNoEntries {
+				l.Errorf("database error looking for account with id %s: %s", user.AccountID, err)
+				c.JSON(http.StatusInternalServerError, gin.H{"error": "internal error"})
+				return
+			}
+			c.JSON(http.StatusOK, inactive)
+			return
+		}
+		if !acct.SuspendedAt.IsZero() {
+			c.JSON(http.StatusOK, inactive)
+			return
+		}
+
+		introspection.Username = acct.Username
+		introspection.Subject = acct.ID
+	}
+
+	c.JSON(http.StatusOK, introspection)
+}
@@ -0,0 +1,100 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package auth_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/auth"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type AuthIntrospectTestSuite struct {
+	AuthStandardTestSuite
+}
+
+// introspect introspects the given token, as the application and token belonging to the given test account.
+func (suite *AuthIntrospectTestSuite) introspect(callerKey string, token string) (int, *apimodel.TokenIntrospection) {
+	ctx, recorder := suite.newContext(http.MethodPost, auth.OauthIntrospectPath)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Form = url.Values{"token": {token}}
+
+	callerApplications := map[string]string{
+		"local_account_1": "application_1",
+		"local_account_2": "application_2",
+	}
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[callerKey]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications[callerApplications[callerKey]])
+
+	suite.authModule.IntrospectPOSTHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	introspection := &apimodel.TokenIntrospection{}
+	if recorder.Code == http.StatusOK {
+		suite.NoError(json.Unmarshal(b, introspection))
+	}
+	return recorder.Code, introspection
+}
+
+func (suite *AuthIntrospectTestSuite) TestIntrospectActiveToken() {
+	token := suite.testTokens["local_account_1"]
+
+	code, introspection := suite.introspect("local_account_1", token.Access)
+	suite.Equal(http.StatusOK, code)
+	suite.True(introspection.Active)
+	suite.Equal("read write follow push", introspection.Scope)
+	suite.Equal(token.ClientID, introspection.ClientID)
+	suite.Equal("the_mighty_zork", introspection.Username)
+	suite.Equal(suite.testAccounts["local_account_1"].ID, introspection.Subject)
+	suite.Equal("Bearer", introspection.TokenType)
+	suite.NotZero(introspection.IssuedAt)
+	suite.NotZero(introspection.ExpiresAt)
+}
+
+func (suite *AuthIntrospectTestSuite) TestIntrospectUnknownToken() {
+	code, introspection := suite.introspect("local_account_1", "NOTAREALTOKEN")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(&apimodel.TokenIntrospection{Active: false}, introspection)
+}
+
+func (suite *AuthIntrospectTestSuite) TestIntrospectOtherApplicationsToken() {
+	// local_account_2's application shouldn't be able to introspect local_account_1's token
+	code, introspection := suite.introspect("local_account_2", suite.testTokens["local_account_1"].Access)
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(&apimodel.TokenIntrospection{Active: false}, introspection)
+}
+
+func (suite *AuthIntrospectTestSuite) TestIntrospectNoToken() {
+	code, _ := suite.introspect("local_account_1", "")
+	suite.Equal(http.StatusBadRequest, code)
+}
+
+func TestAuthIntrospectTestSuite(t *testing.T) {
+	suite.Run(t, &AuthIntrospectTestSuite{})
+}
@@ -0,0 +1,160 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package auth
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type revokeBody struct {
+	ClientID      string `form:"client_id" json:"client_id" xml:"client_id"`
+	ClientSecret  string `form:"client_secret" json:"client_secret" xml:"client_secret"`
+	Token         string `form:"token" json:"token" xml:"token"`
+	TokenTypeHint string `form:"token_type_hint" json:"token_type_hint" xml:"token_type_hint"`
+}
+
+// RevokePOSTHandler swagger:operation POST /oauth/revoke oauthRevoke
+//
+// Revoke an OAuth access token or refresh token, as described in RFC 7009.
+//
+// The client that the token was issued to must authenticate, either with the client_id and client_secret
+// parameters, or with HTTP Basic authentication. Revoking a token which doesn't exist, or has already been
+// revoked, succeeds. Revoking a refresh token also revokes every other token in its rotation lineage.
+//
+// ---
+// tags:
+// - oauth
+//
+// consumes:
+// - application/json
+// - application/xml
+// - application/x-www-form-urlencoded
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: token
+//   in: formData
+//   description: The token to revoke.
+//   type: string
+//   required: true
+// - name: token_type_hint
+//   in: formData
+//   description: The type of the token to revoke, either `access_token` or `refresh_token`.
+//   type: string
+// - name: client_id
+//   in: formData
+//   description: The client ID of the application that the token was issued to.
+//   type: string
+// - name: client_secret
+//   in: formData
+//   description: The client secret of the application that the token was issued to.
+//   type: string
+//
+// responses:
+//   '200':
+//     description: The token was revoked, or didn't exist.
+//   '400':
+//      description: bad request
+//   '401':
+//      description: client authentication failed
+//   '403':
+//      description: the token was issued to a different client
+//   '500':
+//      description: internal error
+func (m *Module) RevokePOSTHandler(c *gin.Context) {
+	l := logrus.WithContext(c.Request.Context()).WithField("func", "RevokePOSTHandler")
+
+	form := &revokeBody{}
+	if err := c.ShouldBind(form); err != nil || form.Token == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid_request", "error_description": "no token provided"})
+		return
+	}
+
+	if form.ClientID == "" {
+		// fall back to http basic authentication, which clients may use instead of form parameters
+		form.ClientID, form.ClientSecret, _ = c.Request.BasicAuth()
+	}
+
+	client := &gtsmodel.Client{}
+	if err := m.db.GetByID(c.Request.Context(), form.ClientID, client); err != nil || form.ClientID == "" ||
+		subtle.ConstantTimeCompare([]byte(client.Secret), []byte(form.ClientSecret)) != 1 {
+		if err != nil && err != db.ErrNoEntries {
+			l.Errorf("database error looking for client with id %s: %s", form.ClientID, err)
+		}
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid_client", "error_description": "client authentication failed"})
+		return
+	}
+
+	// look up the token using the hinted type first, falling back to the other type
+	keys := []string{"access", "refresh"}
+	if form.TokenTypeHint == "refresh_token" {
+		keys = []string{"refresh", "access"}
+	}
+
+	token := &gtsmodel.Token{}
+	var err error
+	for _, key := range keys {
+		if err = m.db.GetWhere(c.Request.Context(), []db.Where{{Key: key, Value: form.Token}}, token); err != db.ErrNoEntries {
+			break
+		}
+	}
+	if err != nil {
+		if err != db.ErrNoEntries {
+			l.Errorf("database error looking for token: %s", err)
+			c.JSON(http.StatusInternalServerError, gin.H{"error": "server_error"})
+			return
+		}
+		// invalid tokens don't need revoking
+		c.JSON(http.StatusOK, gin.H{})
+		return
+	}
+
+	if token.ClientID != client.ID {
+		c.JSON(http.StatusForbidden, gin.H{"error": "unauthorized_client", "error_description": "you are not authorized to revoke this token"})
+		return
+	}
+
+	// revoke the token and any other token descended from the same grant all together or not at all
+	if err := m.db.RunInTx(c.Request.Context(), func(tx db.DB) error {
+		if err := tx.DeleteByID(c.Request.Context(), token.ID, &gtsmodel.Token{}); err != nil {
+			return fmt.Errorf("error revoking token %s: %s", token.ID, err)
+		}
+		if token.FamilyID != "" {
+			if err := tx.DeleteWhere(c.Request.Context(), []db.Where{{Key: "family_id", Value: token.FamilyID}}, &gtsmodel.Token{}); err != nil {
+				return fmt.Errorf("error revoking token family %s: %s", token.FamilyID, err)
+			}
+		}
+		return nil
+	}); err != nil {
+		l.Errorf("database error: %s", err)
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "server_error"})
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{})
+}
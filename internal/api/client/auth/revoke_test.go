@@ -0,0 +1,89 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package auth_test
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/auth"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type AuthRevokeTestSuite struct {
+	AuthStandardTestSuite
+}
+
+// revoke tries to revoke the given token, authenticating as the given test application with the given secret.
+func (suite *AuthRevokeTestSuite) revoke(applicationKey string, secret string, token string) int {
+	ctx, recorder := suite.newContext(http.MethodPost, auth.OauthRevokePath)
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Form = url.Values{
+		"token":         {token},
+		"client_id":     {suite.testApplications[applicationKey].ClientID},
+		"client_secret": {secret},
+	}
+
+	suite.authModule.RevokePOSTHandler(ctx)
+	return recorder.Code
+}
+
+func (suite *AuthRevokeTestSuite) TestRevokeOwnToken() {
+	token := suite.testTokens["local_account_1"]
+
+	code := suite.revoke("application_1", suite.testApplications["application_1"].ClientSecret, token.Access)
+	suite.Equal(http.StatusOK, code)
+
+	err := suite.db.GetByID(context.Background(), token.ID, &gtsmodel.Token{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *AuthRevokeTestSuite) TestRevokeOtherApplicationsToken() {
+	token := suite.testTokens["local_account_1"]
+
+	code := suite.revoke("application_2", suite.testApplications["application_2"].ClientSecret, token.Access)
+	suite.Equal(http.StatusForbidden, code)
+
+	// the token should still be there
+	err := suite.db.GetByID(context.Background(), token.ID, &gtsmodel.Token{})
+	suite.NoError(err)
+}
+
+func (suite *AuthRevokeTestSuite) TestRevokeUnknownToken() {
+	code := suite.revoke("application_1", suite.testApplications["application_1"].ClientSecret, "NOTAREALTOKEN")
+	suite.Equal(http.StatusOK, code)
+}
+
+func (suite *AuthRevokeTestSuite) TestRevokeBadClientSecret() {
+	token := suite.testTokens["local_account_1"]
+
+	code := suite.revoke("application_1", "this is not the secret", token.Access)
+	suite.Equal(http.StatusUnauthorized, code)
+
+	err := suite.db.GetByID(context.Background(), token.ID, &gtsmodel.Token{})
+	suite.NoError(err)
+}
+
+func TestAuthRevokeTestSuite(t *testing.T) {
+	suite.Run(t, &AuthRevokeTestSuite{})
+}
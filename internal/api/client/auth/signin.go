@@ -22,12 +22,15 @@ import (
 	"context"
 	"errors"
 	"net/http"
+	"time"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
 	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"golang.org/x/crypto/bcrypt"
@@ -84,9 +87,15 @@ func (m *Module) SignInPOSTHandler(c *gin.Context) {
 	}
 	l.Tracef("parsed form: %+v", form)
 
-	userid, err := m.ValidatePassword(c.Request.Context(), form.Email, form.Password)
+	userid, err := m.ValidatePassword(c.Request.Context(), form.Email, form.Password, c.ClientIP())
 	if err != nil {
-		c.String(http.StatusForbidden, err.Error())
+		var lockedErr *signInLockedError
+		if errors.As(err, &lockedErr) {
+			c.Header("Retry-After", api.FormatRetryAfter(time.Until(lockedErr.until)))
+			c.String(http.StatusTooManyRequests, err.Error())
+		} else {
+			c.String(http.StatusForbidden, err.Error())
+		}
 		m.clearSession(s)
 		return
 	}
@@ -102,13 +111,23 @@ func (m *Module) SignInPOSTHandler(c *gin.Context) {
 	c.Redirect(http.StatusFound, OauthAuthorizePath)
 }
 
-// ValidatePassword takes an email address and a password.
+// ValidatePassword takes an email address, a password, and the ip address the sign in comes from.
 // The goal is to authenticate the password against the one for that email
 // address stored in the database. If OK, we return the userid (a ulid) for that user,
 // so that it can be used in further Oauth flows to generate a token/retreieve an oauth client from the db.
-func (m *Module) ValidatePassword(ctx context.Context, email string, password string) (userid string, err error) {
+//
+// Failed attempts are counted for both the user and the ip address, and once there have been too many,
+// further attempts for either are rejected with a *signInLockedError until the lockout is over. For a
+// locked user, this is only returned once the password has been checked, so that it doesn't give away
+// which email addresses are registered here.
+func (m *Module) ValidatePassword(ctx context.Context, email string, password string, ip string) (userid string, err error) {
 	l := logrus.WithField("func", "ValidatePassword")
 
+	if until := m.server.SignInFailures().LockedUntil(ip); !until.IsZero() {
+		l.Debugf("sign ins from %s are locked until %s", ip, until)
+		return "", &signInLockedError{until: until}
+	}
+
 	// make sure an email/password was provided and bail if not
 	if email == "" || password == "" {
 		l.Debug("email or password was not provided")
@@ -120,6 +139,7 @@ func (m *Module) ValidatePassword(ctx context.Context, email string, password st
 
 	if err := m.db.GetWhere(ctx, []db.Where{{Key: "email", Value: email}}, gtsUser); err != nil {
 		l.Debugf("user %s was not retrievable from db during oauth authorization attempt: %s", email, err)
+		m.server.SignInFailures().Fail(ip, "")
 		return incorrectPassword()
 	}
 
@@ -132,15 +152,62 @@ func (m *Module) ValidatePassword(ctx context.Context, email string, password st
 	// compare the provided password with the encrypted one from the db, bail if they don't match
 	if err := bcrypt.CompareHashAndPassword([]byte(gtsUser.EncryptedPassword), []byte(password)); err != nil {
 		l.Debugf("password hash didn't match for user %s during login attempt: %s", gtsUser.Email, err)
+		m.server.SignInFailures().Fail(ip, gtsUser.ID)
+		if err := m.failUserSignIn(ctx, gtsUser); err != nil {
+			l.Errorf("error counting failed sign in for user %s: %s", gtsUser.Email, err)
+		}
 		return incorrectPassword()
 	}
 
+	if gtsUser.SignInLockedUntil.After(time.Now()) {
+		l.Debugf("sign ins for user %s are locked until %s", gtsUser.Email, gtsUser.SignInLockedUntil)
+		return "", &signInLockedError{until: gtsUser.SignInLockedUntil}
+	}
+
+	// the sign in worked, so start counting failures from scratch
+	m.server.SignInFailures().Clear(ip)
+	if err := m.recordSignIn(ctx, gtsUser, ip); err != nil {
+		l.Errorf("error recording sign in for user %s: %s", gtsUser.Email, err)
+	}
+
+	// the password is correct, so now's our chance to bring its hash up to the configured cost if it's behind
+	go m.rehashPassword(gtsUser, password)
+
 	// If we've made it this far the email/password is correct, so we can just return the id of the user.
 	userid = gtsUser.ID
 	l.Tracef("returning (%s, %s)", userid, err)
 	return
 }
 
+// rehashPassword hashes the given, already verified, password of the given user again at the configured
+// bcrypt cost, and stores the new hash, if the user's stored hash was computed with a lower cost. The new
+// hash is only stored if the old one is still in place, so that a password changed in the meantime isn't undone.
+func (m *Module) rehashPassword(user *gtsmodel.User, password string) {
+	l := logrus.WithField("func", "rehashPassword")
+
+	cost := viper.GetInt(config.Keys.AccountsPasswordBcryptCost)
+	if currentCost, err := bcrypt.Cost([]byte(user.EncryptedPassword)); err != nil || currentCost >= cost {
+		return
+	}
+
+	newHash, err := bcrypt.GenerateFromPassword([]byte(password), cost)
+	if err != nil {
+		l.Errorf("error rehashing password for user %s: %s", user.ID, err)
+		return
+	}
+
+	where := []db.Where{
+		{Key: "id", Value: user.ID},
+		{Key: "encrypted_password", Value: user.EncryptedPassword},
+	}
+	if err := m.db.UpdateWhere(context.Background(), where, "encrypted_password", string(newHash), &gtsmodel.User{}); err != nil {
+		l.Errorf("error updating rehashed password for user %s: %s", user.ID, err)
+		return
+	}
+
+	l.Debugf("rehashed password for user %s with cost %d", user.ID, cost)
+}
+
 // incorrectPassword is just a little helper function to use in the ValidatePassword function
 func incorrectPassword() (string, error) {
 	return "", errors.New("password/email combination was incorrect")
@@ -0,0 +1,182 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type AuthSignInTestSuite struct {
+	AuthStandardTestSuite
+}
+
+func (suite *AuthSignInTestSuite) TestValidatePasswordRehashes() {
+	testUser := suite.testUsers["local_account_1"]
+	viper.Set(config.Keys.AccountsPasswordBcryptCost, 11)
+
+	userID, err := suite.authModule.ValidatePassword(context.Background(), testUser.Email, "password", "192.0.2.1")
+	suite.NoError(err)
+	suite.Equal(testUser.ID, userID)
+
+	// the password is rehashed in the background at the new cost, and still works
+	suite.Eventually(func() bool {
+		user := &gtsmodel.User{}
+		if err := suite.db.GetByID(context.Background(), testUser.ID, user); err != nil {
+			return false
+		}
+		cost, err := bcrypt.Cost([]byte(user.EncryptedPassword))
+		return err == nil && cost == 11 && bcrypt.CompareHashAndPassword([]byte(user.EncryptedPassword), []byte("password")) == nil
+	}, 5*time.Second, 50*time.Millisecond)
+}
+
+func (suite *AuthSignInTestSuite) TestValidatePasswordWrongPasswordNotRehashed() {
+	testUser := suite.testUsers["local_account_1"]
+	viper.Set(config.Keys.AccountsPasswordBcryptCost, 11)
+
+	_, err := suite.authModule.ValidatePassword(context.Background(), testUser.Email, "not the password", "192.0.2.1")
+	suite.EqualError(err, "password/email combination was incorrect")
+
+	user := &gtsmodel.User{}
+	suite.NoError(suite.db.GetByID(context.Background(), testUser.ID, user))
+	suite.Equal(testUser.EncryptedPassword, user.EncryptedPassword)
+}
+
+func (suite *AuthSignInTestSuite) TestValidatePasswordUserLockout() {
+	testUser := suite.testUsers["local_account_1"]
+	viper.Set(config.Keys.AccountsSignInMaxFailures, 3)
+
+	// fail from different addresses, so that only the user gets locked
+	for _, ip := range []string{"192.0.2.1", "192.0.2.2", "192.0.2.3"} {
+		_, err := suite.authModule.ValidatePassword(context.Background(), testUser.Email, "not the password", ip)
+		suite.EqualError(err, "password/email combination was incorrect")
+	}
+
+	// a wrong password doesn't give away that the user is locked...
+	_, err := suite.authModule.ValidatePassword(context.Background(), testUser.Email, "not the password", "192.0.2.4")
+	suite.EqualError(err, "password/email combination was incorrect")
+
+	// ...but now even the right password doesn't get in
+	_, err = suite.authModule.ValidatePassword(context.Background(), testUser.Email, "password", "192.0.2.4")
+	suite.EqualError(err, "too many failed sign in attempts, please try again later")
+
+	user := &gtsmodel.User{}
+	suite.NoError(suite.db.GetByID(context.Background(), testUser.ID, user))
+	suite.WithinDuration(time.Now().Add(15*time.Minute), user.SignInLockedUntil, time.Minute)
+
+	// other users can still sign in
+	otherUser := suite.testUsers["admin_account"]
+	userID, err := suite.authModule.ValidatePassword(context.Background(), otherUser.Email, "password", "192.0.2.4")
+	suite.NoError(err)
+	suite.Equal(otherUser.ID, userID)
+}
+
+func (suite *AuthSignInTestSuite) TestValidatePasswordIPLockout() {
+	testUser := suite.testUsers["local_account_1"]
+	viper.Set(config.Keys.AccountsSignInMaxFailures, 3)
+
+	// guessing at emails counts against the address too
+	for _, email := range []string{"a@example.org", "b@example.org", "c@example.org"} {
+		_, err := suite.authModule.ValidatePassword(context.Background(), email, "password", "192.0.2.1")
+		suite.EqualError(err, "password/email combination was incorrect")
+	}
+
+	_, err := suite.authModule.ValidatePassword(context.Background(), testUser.Email, "password", "192.0.2.1")
+	suite.EqualError(err, "too many failed sign in attempts, please try again later")
+
+	// but the user isn't locked from elsewhere
+	userID, err := suite.authModule.ValidatePassword(context.Background(), testUser.Email, "password", "192.0.2.2")
+	suite.NoError(err)
+	suite.Equal(testUser.ID, userID)
+}
+
+func (suite *AuthSignInTestSuite) TestValidatePasswordSuccessResetsFailures() {
+	testUser := suite.testUsers["local_account_1"]
+	viper.Set(config.Keys.AccountsSignInMaxFailures, 3)
+
+	for i := 0; i < 2; i++ {
+		_, err := suite.authModule.ValidatePassword(context.Background(), testUser.Email, "not the password", "192.0.2.1")
+		suite.EqualError(err, "password/email combination was incorrect")
+	}
+
+	user := &gtsmodel.User{}
+	suite.NoError(suite.db.GetByID(context.Background(), testUser.ID, user))
+	suite.Equal(2, user.FailedSignInCount)
+
+	_, err := suite.authModule.ValidatePassword(context.Background(), testUser.Email, "password", "192.0.2.1")
+	suite.NoError(err)
+
+	user = &gtsmodel.User{}
+	suite.NoError(suite.db.GetByID(context.Background(), testUser.ID, user))
+	suite.Zero(user.FailedSignInCount)
+	suite.True(user.FailedSignInAt.IsZero())
+
+	// the counts start from scratch, so two more failures don't lock anything
+	for i := 0; i < 2; i++ {
+		_, err := suite.authModule.ValidatePassword(context.Background(), testUser.Email, "not the password", "192.0.2.1")
+		suite.EqualError(err, "password/email combination was incorrect")
+	}
+	_, err = suite.authModule.ValidatePassword(context.Background(), testUser.Email, "password", "192.0.2.1")
+	suite.NoError(err)
+}
+
+func (suite *AuthSignInTestSuite) TestValidatePasswordRecordsSignIn() {
+	testUser := suite.testUsers["local_account_1"]
+
+	_, err := suite.authModule.ValidatePassword(context.Background(), testUser.Email, "password", "192.0.2.1")
+	suite.NoError(err)
+
+	user := &gtsmodel.User{}
+	suite.NoError(suite.db.GetByID(context.Background(), testUser.ID, user))
+	suite.WithinDuration(time.Now(), user.CurrentSignInAt, time.Minute)
+	suite.Equal(user.CurrentSignInAt, user.LastLogin)
+	suite.Equal("192.0.2.1", user.CurrentSignInIP.String())
+	suite.WithinDuration(testUser.CurrentSignInAt, user.LastSignInAt, time.Second)
+	suite.Equal(testUser.CurrentSignInIP.String(), user.LastSignInIP.String())
+	suite.Equal(testUser.SignInCount+1, user.SignInCount)
+}
+
+func (suite *AuthSignInTestSuite) TestValidatePasswordClearUserLiftsIPLockout() {
+	testUser := suite.testUsers["local_account_1"]
+	viper.Set(config.Keys.AccountsSignInMaxFailures, 3)
+
+	for i := 0; i < 3; i++ {
+		_, err := suite.authModule.ValidatePassword(context.Background(), testUser.Email, "not the password", "192.0.2.1")
+		suite.EqualError(err, "password/email combination was incorrect")
+	}
+
+	// lifting the lockout of the user, like an admin does, lifts it for the address they failed from too
+	suite.NoError(suite.db.LockSignIns(context.Background(), testUser.ID, time.Time{}))
+	suite.oauthServer.SignInFailures().ClearUser(testUser.ID)
+
+	userID, err := suite.authModule.ValidatePassword(context.Background(), testUser.Email, "password", "192.0.2.1")
+	suite.NoError(err)
+	suite.Equal(testUser.ID, userID)
+}
+
+func TestAuthSignInTestSuite(t *testing.T) {
+	suite.Run(t, &AuthSignInTestSuite{})
+}
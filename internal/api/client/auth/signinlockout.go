@@ -0,0 +1,75 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package auth
+
+import (
+	"context"
+	"net"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// signInLockedError is returned when sign ins are locked after too many failed ones,
+// either for the user who's signing in or for the ip address they're signing in from.
+type signInLockedError struct {
+	until time.Time
+}
+
+func (e *signInLockedError) Error() string {
+	return "too many failed sign in attempts, please try again later"
+}
+
+// failUserSignIn counts a failed sign in as the given user, locking their sign ins if that was one too many.
+func (m *Module) failUserSignIn(ctx context.Context, user *gtsmodel.User) error {
+	maxFailures := viper.GetInt(config.Keys.AccountsSignInMaxFailures)
+	if maxFailures <= 0 {
+		return nil
+	}
+
+	windowStart := time.Now().Add(-viper.GetDuration(config.Keys.AccountsSignInFailureWindow))
+	count, err := m.db.CountFailedSignIn(ctx, user.ID, windowStart)
+	if err != nil {
+		return err
+	}
+
+	if count >= maxFailures {
+		return m.db.LockSignIns(ctx, user.ID, time.Now().Add(viper.GetDuration(config.Keys.AccountsSignInLockout)))
+	}
+	return nil
+}
+
+// recordSignIn records a successful sign in as the given user from the given ip address, which also
+// means forgetting about any failed sign ins before it. The time of the sign in counts towards the
+// user being active, for the active user counts in nodeinfo.
+func (m *Module) recordSignIn(ctx context.Context, user *gtsmodel.User, ip string) error {
+	user.LastSignInAt = user.CurrentSignInAt
+	user.LastSignInIP = user.CurrentSignInIP
+	user.CurrentSignInAt = time.Now()
+	user.CurrentSignInIP = net.ParseIP(ip)
+	user.SignInCount++
+	user.LastLogin = user.CurrentSignInAt
+
+	user.FailedSignInCount = 0
+	user.FailedSignInAt = time.Time{}
+	user.SignInLockedUntil = time.Time{}
+	return m.db.UpdateByPrimaryKey(ctx, user)
+}
@@ -34,6 +34,7 @@ type tokenBody struct {
 	Code         *string `form:"code" json:"code" xml:"code"`
 	GrantType    *string `form:"grant_type" json:"grant_type" xml:"grant_type"`
 	RedirectURI  *string `form:"redirect_uri" json:"redirect_uri" xml:"redirect_uri"`
+	RefreshToken *string `form:"refresh_token" json:"refresh_token" xml:"refresh_token"`
 	Scope        *string `form:"scope" json:"scope" xml:"scope"`
 }
 
@@ -66,6 +67,9 @@ func (m *Module) TokenPOSTHandler(c *gin.Context) {
 		if form.RedirectURI != nil {
 			c.Request.Form.Set("redirect_uri", *form.RedirectURI)
 		}
+		if form.RefreshToken != nil {
+			c.Request.Form.Set("refresh_token", *form.RefreshToken)
+		}
 		if form.Scope != nil {
 			c.Request.Form.Set("scope", *form.Scope)
 		}
@@ -29,6 +29,8 @@ import (
 const (
 	// BasePath is the base path for serving the emoji API
 	BasePath = "/api/v1/custom_emojis"
+	// CategoriesPath is for serving the categories of custom emojis
+	CategoriesPath = BasePath + "/categories"
 )
 
 // Module implements the ClientAPIModule interface for everything related to emoji
@@ -46,5 +48,6 @@ func New(processor processing.Processor) api.ClientModule {
 // Route attaches all routes from this module to the given router
 func (m *Module) Route(r router.Router) error {
 	r.AttachHandler(http.MethodGet, BasePath, m.EmojisGETHandler)
+	r.AttachHandler(http.MethodGet, CategoriesPath, m.EmojiCategoriesGETHandler)
 	return nil
 }
@@ -1,18 +1,119 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
 package emoji
 
 import (
 	"net/http"
 
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/api"
 )
 
-// EmojisGETHandler returns a list of custom emojis enabled on the instance
+// EmojisGETHandler swagger:operation GET /api/v1/custom_emojis customEmojisGet
+//
+// Get the custom emojis of this instance.
+//
+// Emojis are grouped by category, with categories in alphabetical order, and uncategorized emojis at the end.
+// Within each category, emojis are in alphabetical order of shortcode. Disabled emojis are not included.
+//
+// ---
+// tags:
+// - custom_emojis
+//
+// produces:
+// - application/json
+//
+// responses:
+//   '200':
+//     description: Array of custom emojis.
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/emoji"
+//   '500':
+//      description: internal error
 func (m *Module) EmojisGETHandler(c *gin.Context) {
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+		"func":        "EmojisGETHandler",
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+
 	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
 		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, []string{})
+	emojis, errWithCode := m.processor.CustomEmojisGet(c.Request.Context())
+	if errWithCode != nil {
+		l.Debugf("error getting custom emojis: %s", errWithCode.Error())
+		api.ErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.JSON(http.StatusOK, emojis)
+}
+
+// EmojiCategoriesGETHandler swagger:operation GET /api/v1/custom_emojis/categories customEmojiCategoriesGet
+//
+// Get the names of the categories of the custom emojis of this instance.
+//
+// Categories are in the same order as emojis are grouped by GET /api/v1/custom_emojis.
+// Uncategorized emojis don't belong to any of them.
+//
+// ---
+// tags:
+// - custom_emojis
+//
+// produces:
+// - application/json
+//
+// responses:
+//   '200':
+//     description: Array of category names.
+//     schema:
+//       type: array
+//       items:
+//         type: string
+//   '500':
+//      description: internal error
+func (m *Module) EmojiCategoriesGETHandler(c *gin.Context) {
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
+		"func":        "EmojiCategoriesGETHandler",
+		"request_uri": c.Request.RequestURI,
+		"user_agent":  c.Request.UserAgent(),
+		"origin_ip":   c.ClientIP(),
+	})
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	categories, errWithCode := m.processor.CustomEmojiCategoriesGet(c.Request.Context())
+	if errWithCode != nil {
+		l.Debugf("error getting custom emoji categories: %s", errWithCode.Error())
+		api.ErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.JSON(http.StatusOK, categories)
 }
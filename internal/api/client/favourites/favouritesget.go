@@ -2,7 +2,6 @@ package favourites
 
 import (
 	"net/http"
-	"strconv"
 
 	"github.com/sirupsen/logrus"
 
@@ -22,39 +21,40 @@ func (m *Module) FavouritesGETHandler(c *gin.Context) {
 		return
 	}
 
+	if !oauth.RequireScope(c, authed, oauth.ScopeReadFavourites) {
+		return
+	}
+
 	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
 		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
 		return
 	}
 
-	maxID := ""
-	maxIDString := c.Query(MaxIDKey)
-	if maxIDString != "" {
-		maxID = maxIDString
+	maxID, err := api.ParsePagingID(c, MaxIDKey)
+	if err != nil {
+		l.Debugf("error parsing max id: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	minID := ""
-	minIDString := c.Query(MinIDKey)
-	if minIDString != "" {
-		minID = minIDString
+	minID, err := api.ParsePagingID(c, MinIDKey)
+	if err != nil {
+		l.Debugf("error parsing min id: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	limit := 20
-	limitString := c.Query(LimitKey)
-	if limitString != "" {
-		i, err := strconv.ParseInt(limitString, 10, 64)
-		if err != nil {
-			l.Debugf("error parsing limit string: %s", err)
-			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse limit query param"})
-			return
-		}
-		limit = int(i)
+	limit, err := api.ParseLimit(c, LimitKey, 20)
+	if err != nil {
+		l.Debugf("error parsing limit: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse limit query param"})
+		return
 	}
 
 	resp, errWithCode := m.processor.FavedTimelineGet(c.Request.Context(), authed, maxID, minID, limit)
 	if errWithCode != nil {
 		l.Debugf("error from processor FavedTimelineGet: %s", errWithCode)
-		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		api.ErrorHandler(c, errWithCode)
 		return
 	}
 
@@ -24,9 +24,12 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 	"github.com/superseriousbusiness/gotosocial/internal/api"
 	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/router"
 )
 
 // ServeFile is for serving attachments, headers, and avatars to the requester from instance storage.
@@ -34,7 +37,7 @@ import (
 // Note: to mitigate scraping attempts, no information should be given out on a bad request except "404 page not found".
 // Don't give away account ids or media ids or anything like that; callers shouldn't be able to infer anything.
 func (m *FileServer) ServeFile(c *gin.Context) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "ServeFile",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
@@ -87,7 +90,7 @@ func (m *FileServer) ServeFile(c *gin.Context) {
 	})
 	if errWithCode != nil {
 		l.Errorf(errWithCode.Error())
-		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		api.ErrorHandler(c, errWithCode)
 		return
 	}
 
@@ -109,5 +112,7 @@ func (m *FileServer) ServeFile(c *gin.Context) {
 		return
 	}
 
+	// media files are never changed once stored, but they can be deleted
+	c.Header("Cache-Control", router.CacheControl(viper.GetDuration(config.Keys.MediaCacheMaxAge), false))
 	c.DataFromReader(http.StatusOK, content.ContentLength, format, content.Content, nil)
 }
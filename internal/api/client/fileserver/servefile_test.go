@@ -155,6 +155,7 @@ func (suite *ServeFileTestSuite) TestServeOriginalFileSuccessful() {
 	suite.fileServer.ServeFile(ctx)
 	suite.EqualValues(http.StatusOK, recorder.Code)
 	suite.EqualValues("image/jpeg", recorder.Header().Get("content-type"))
+	suite.EqualValues("max-age=86400", recorder.Header().Get("cache-control"))
 
 	b, err := ioutil.ReadAll(recorder.Body)
 	suite.NoError(err)
@@ -0,0 +1,184 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package followrequest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// FollowRequestAutoAcceptGETHandler swagger:operation GET /api/v1/follow_requests/auto_accept getFollowRequestAutoAccept
+//
+// Get the rules by which follow requests to your account are accepted automatically, even if your account is locked.
+//
+// ---
+// tags:
+// - follow_requests
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - read:follows
+//
+// responses:
+//   '200':
+//     description: Your follow request auto-accept rules.
+//     schema:
+//       "$ref": "#/definitions/followRequestAutoAccept"
+//   '400':
+//      description: bad request
+//   '401':
+//      description: unauthorized
+//   '403':
+//      description: forbidden
+func (m *Module) FollowRequestAutoAcceptGETHandler(c *gin.Context) {
+	l := logrus.WithField("func", "FollowRequestAutoAcceptGETHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("couldn't auth: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeReadFollows) {
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	if authed.User.Disabled || !authed.User.Approved || !authed.Account.SuspendedAt.IsZero() {
+		l.Debugf("couldn't auth: %s", err)
+		c.JSON(http.StatusForbidden, gin.H{"error": "account is disabled, not yet approved, or suspended"})
+		return
+	}
+
+	autoAccept, errWithCode := m.processor.FollowRequestAutoAcceptGet(c.Request.Context(), authed)
+	if errWithCode != nil {
+		l.Debug(errWithCode.Error())
+		api.ErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.JSON(http.StatusOK, autoAccept)
+}
+
+// FollowRequestAutoAcceptPATCHHandler swagger:operation PATCH /api/v1/follow_requests/auto_accept updateFollowRequestAutoAccept
+//
+// Update the rules by which follow requests to your account are accepted automatically, even if your account is locked.
+//
+// Follow requests are checked against these rules when they arrive. Matching requests are accepted right away,
+// and all other requests are held for manual approval as usual. Rules that aren't given are left as they were.
+//
+// ---
+// tags:
+// - follow_requests
+//
+// consumes:
+// - application/json
+// - application/xml
+// - application/x-www-form-urlencoded
+// - multipart/form-data
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: followed
+//   in: formData
+//   description: Accept follow requests from accounts that you already follow.
+//   type: boolean
+// - name: domains[]
+//   in: formData
+//   description: |-
+//     Accept follow requests from accounts on these domains, or any of their subdomains.
+//     This replaces the current list of domains.
+//   type: array
+//   items:
+//     type: string
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:follows
+//
+// responses:
+//   '200':
+//     description: Your updated follow request auto-accept rules.
+//     schema:
+//       "$ref": "#/definitions/followRequestAutoAccept"
+//   '400':
+//      description: bad request
+//   '401':
+//      description: unauthorized
+//   '403':
+//      description: forbidden
+//   '500':
+//      description: internal server error
+func (m *Module) FollowRequestAutoAcceptPATCHHandler(c *gin.Context) {
+	l := logrus.WithField("func", "FollowRequestAutoAcceptPATCHHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("couldn't auth: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeWriteFollows) {
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	if authed.User.Disabled || !authed.User.Approved || !authed.Account.SuspendedAt.IsZero() {
+		l.Debugf("couldn't auth: %s", err)
+		c.JSON(http.StatusForbidden, gin.H{"error": "account is disabled, not yet approved, or suspended"})
+		return
+	}
+
+	form := &model.FollowRequestAutoAcceptUpdateRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.Debugf("error parsing form: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("could not parse form: %s", err)})
+		return
+	}
+
+	autoAccept, errWithCode := m.processor.FollowRequestAutoAcceptUpdate(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		l.Debug(errWithCode.Error())
+		api.ErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.JSON(http.StatusOK, autoAccept)
+}
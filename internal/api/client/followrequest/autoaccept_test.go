@@ -0,0 +1,85 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package followrequest_test
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type AutoAcceptTestSuite struct {
+	FollowRequestStandardTestSuite
+}
+
+func (suite *AutoAcceptTestSuite) TestGetAutoAccept() {
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodGet, []byte{}, "/api/v1/follow_requests/auto_accept", "")
+
+	suite.followRequestModule.FollowRequestAutoAcceptGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+	suite.Equal(`{"followed":false,"domains":[]}`, string(b))
+}
+
+func (suite *AutoAcceptTestSuite) TestUpdateAutoAccept() {
+	form := url.Values{
+		"followed":  []string{"true"},
+		"domains[]": []string{"Example.org", " gts.example.org ", "example.org"},
+	}
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPatch, []byte(form.Encode()), "/api/v1/follow_requests/auto_accept", "application/x-www-form-urlencoded")
+
+	suite.followRequestModule.FollowRequestAutoAcceptPATCHHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	// domains should be lowercased, trimmed, and deduplicated
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+	suite.Equal(`{"followed":true,"domains":["example.org","gts.example.org"]}`, string(b))
+}
+
+func (suite *AutoAcceptTestSuite) TestUpdateAutoAcceptInvalidDomain() {
+	form := url.Values{
+		"domains[]": []string{"https://example.org/"},
+	}
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPatch, []byte(form.Encode()), "/api/v1/follow_requests/auto_accept", "application/x-www-form-urlencoded")
+
+	suite.followRequestModule.FollowRequestAutoAcceptPATCHHandler(ctx)
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func TestAutoAcceptTestSuite(t *testing.T) {
+	suite.Run(t, &AutoAcceptTestSuite{})
+}
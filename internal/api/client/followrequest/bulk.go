@@ -0,0 +1,196 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package followrequest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+// FollowRequestAcceptAllPOSTHandler swagger:operation POST /api/v1/follow_requests/accept_all acceptAllFollowRequests
+//
+// Accept all pending follow requests, and put the requesting accounts in your 'followers' list.
+//
+// The outcome of each follow request is reported separately, so one follow request
+// that can't be accepted doesn't stop the others from being accepted.
+//
+// ---
+// tags:
+// - follow_requests
+//
+// produces:
+// - application/json
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:follows
+//
+// responses:
+//   '200':
+//     description: The outcome of each follow request.
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/followRequestBulkResult"
+//   '400':
+//      description: bad request
+//   '401':
+//      description: unauthorized
+//   '403':
+//      description: forbidden
+//   '500':
+//      description: internal server error
+func (m *Module) FollowRequestAcceptAllPOSTHandler(c *gin.Context) {
+	l := logrus.WithField("func", "FollowRequestAcceptAllPOSTHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("couldn't auth: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeWriteFollows) {
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	if authed.User.Disabled || !authed.User.Approved || !authed.Account.SuspendedAt.IsZero() {
+		l.Debugf("couldn't auth: %s", err)
+		c.JSON(http.StatusForbidden, gin.H{"error": "account is disabled, not yet approved, or suspended"})
+		return
+	}
+
+	results, errWithCode := m.processor.FollowRequestAcceptAll(c.Request.Context(), authed)
+	if errWithCode != nil {
+		l.Debug(errWithCode.Error())
+		api.ErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
+
+// FollowRequestBulkPOSTHandler swagger:operation POST /api/v1/follow_requests/bulk bulkFollowRequests
+//
+// Accept or reject the follow requests from several accounts at once.
+//
+// The outcome of each follow request is reported separately, so one follow request
+// that can't be accepted or rejected doesn't stop the others from being handled.
+//
+// ---
+// tags:
+// - follow_requests
+//
+// consumes:
+// - application/json
+// - application/xml
+// - application/x-www-form-urlencoded
+// - multipart/form-data
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: action
+//   in: formData
+//   description: What to do with the follow requests.
+//   type: string
+//   enum:
+//     - accept
+//     - reject
+//   required: true
+// - name: account_ids[]
+//   in: formData
+//   description: IDs of the accounts whose follow requests should be accepted or rejected. At most 200 can be given.
+//   type: array
+//   items:
+//     type: string
+//   required: true
+//
+// security:
+// - OAuth2 Bearer:
+//   - write:follows
+//
+// responses:
+//   '200':
+//     description: The outcome of each follow request.
+//     schema:
+//       type: array
+//       items:
+//         "$ref": "#/definitions/followRequestBulkResult"
+//   '400':
+//      description: bad request
+//   '401':
+//      description: unauthorized
+//   '403':
+//      description: forbidden
+//   '500':
+//      description: internal server error
+func (m *Module) FollowRequestBulkPOSTHandler(c *gin.Context) {
+	l := logrus.WithField("func", "FollowRequestBulkPOSTHandler")
+
+	authed, err := oauth.Authed(c, true, true, true, true)
+	if err != nil {
+		l.Debugf("couldn't auth: %s", err)
+		c.JSON(http.StatusUnauthorized, gin.H{"error": err.Error()})
+		return
+	}
+
+	if !oauth.RequireScope(c, authed, oauth.ScopeWriteFollows) {
+		return
+	}
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	if authed.User.Disabled || !authed.User.Approved || !authed.Account.SuspendedAt.IsZero() {
+		l.Debugf("couldn't auth: %s", err)
+		c.JSON(http.StatusForbidden, gin.H{"error": "account is disabled, not yet approved, or suspended"})
+		return
+	}
+
+	form := &model.FollowRequestBulkRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.Debugf("error parsing form: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("could not parse form: %s", err)})
+		return
+	}
+
+	results, errWithCode := m.processor.FollowRequestBulk(c.Request.Context(), authed, form)
+	if errWithCode != nil {
+		l.Debug(errWithCode.Error())
+		api.ErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.JSON(http.StatusOK, results)
+}
@@ -0,0 +1,125 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package followrequest_test
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type BulkTestSuite struct {
+	FollowRequestStandardTestSuite
+}
+
+// putFollowRequests puts follow requests from the given accounts to local_account_1 in the database.
+func (suite *BulkTestSuite) putFollowRequests(requestingAccounts ...*gtsmodel.Account) {
+	ids := []string{"01FJ1S8DX3STJJ6CEYPMZ1M0R3", "01G5MSTJAQTQ2BEQJ0CJDWH0A5"}
+	for i, requestingAccount := range requestingAccounts {
+		fr := &gtsmodel.FollowRequest{
+			ID:              ids[i],
+			CreatedAt:       time.Now(),
+			UpdatedAt:       time.Now(),
+			URI:             fmt.Sprintf("%s/follow/%s", requestingAccount.URI, ids[i]),
+			AccountID:       requestingAccount.ID,
+			TargetAccountID: suite.testAccounts["local_account_1"].ID,
+		}
+		suite.NoError(suite.db.Put(context.Background(), fr))
+	}
+}
+
+func (suite *BulkTestSuite) TestAcceptAll() {
+	remoteAccount1 := suite.testAccounts["remote_account_1"]
+	remoteAccount2 := suite.testAccounts["remote_account_2"]
+	suite.putFollowRequests(remoteAccount1, remoteAccount2)
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, []byte{}, "/api/v1/follow_requests/accept_all", "")
+
+	suite.followRequestModule.FollowRequestAcceptAllPOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+	suite.Contains(string(b), fmt.Sprintf(`{"account_id":"%s","success":true}`, remoteAccount1.ID))
+	suite.Contains(string(b), fmt.Sprintf(`{"account_id":"%s","success":true}`, remoteAccount2.ID))
+
+	// both accounts should now be following
+	for _, requestingAccount := range []*gtsmodel.Account{remoteAccount1, remoteAccount2} {
+		follows, err := suite.db.IsFollowing(context.Background(), requestingAccount, suite.testAccounts["local_account_1"])
+		suite.NoError(err)
+		suite.True(follows)
+	}
+}
+
+func (suite *BulkTestSuite) TestBulkReject() {
+	remoteAccount2 := suite.testAccounts["remote_account_2"]
+	suite.putFollowRequests(remoteAccount2)
+
+	// there's no follow request from admin, but that shouldn't stop the other one being rejected
+	form := url.Values{
+		"action":        []string{"reject"},
+		"account_ids[]": []string{suite.testAccounts["admin_account"].ID, remoteAccount2.ID},
+	}
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, []byte(form.Encode()), "/api/v1/follow_requests/bulk", "application/x-www-form-urlencoded")
+
+	suite.followRequestModule.FollowRequestBulkPOSTHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+	suite.Equal(fmt.Sprintf(`[{"account_id":"%s","success":false,"error":"404 not found"},{"account_id":"%s","success":true}]`, suite.testAccounts["admin_account"].ID, remoteAccount2.ID), string(b))
+
+	requested, err := suite.db.IsFollowRequested(context.Background(), remoteAccount2, suite.testAccounts["local_account_1"])
+	suite.NoError(err)
+	suite.False(requested)
+}
+
+func (suite *BulkTestSuite) TestBulkUnknownAction() {
+	form := url.Values{
+		"action":        []string{"ignore"},
+		"account_ids[]": []string{suite.testAccounts["remote_account_2"].ID},
+	}
+
+	recorder := httptest.NewRecorder()
+	ctx := suite.newContext(recorder, http.MethodPost, []byte(form.Encode()), "/api/v1/follow_requests/bulk", "application/x-www-form-urlencoded")
+
+	suite.followRequestModule.FollowRequestBulkPOSTHandler(ctx)
+	suite.Equal(http.StatusBadRequest, recorder.Code)
+}
+
+func TestBulkTestSuite(t *testing.T) {
+	suite.Run(t, &BulkTestSuite{})
+}
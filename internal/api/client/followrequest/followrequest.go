@@ -38,6 +38,12 @@ const (
 	AuthorizePath = BasePathWithID + "/authorize"
 	// RejectPath is used for rejecting follow requests
 	RejectPath = BasePathWithID + "/reject"
+	// AutoAcceptPath is used for getting and updating follow request auto-accept rules
+	AutoAcceptPath = BasePath + "/auto_accept"
+	// AcceptAllPath is used for accepting all pending follow requests at once
+	AcceptAllPath = BasePath + "/accept_all"
+	// BulkPath is used for accepting or rejecting several follow requests at once
+	BulkPath = BasePath + "/bulk"
 )
 
 // Module implements the ClientAPIModule interface
@@ -57,5 +63,9 @@ func (m *Module) Route(r router.Router) error {
 	r.AttachHandler(http.MethodGet, BasePath, m.FollowRequestGETHandler)
 	r.AttachHandler(http.MethodPost, AuthorizePath, m.FollowRequestAuthorizePOSTHandler)
 	r.AttachHandler(http.MethodPost, RejectPath, m.FollowRequestRejectPOSTHandler)
+	r.AttachHandler(http.MethodGet, AutoAcceptPath, m.FollowRequestAutoAcceptGETHandler)
+	r.AttachHandler(http.MethodPatch, AutoAcceptPath, m.FollowRequestAutoAcceptPATCHHandler)
+	r.AttachHandler(http.MethodPost, AcceptAllPath, m.FollowRequestAcceptAllPOSTHandler)
+	r.AttachHandler(http.MethodPost, BulkPath, m.FollowRequestBulkPOSTHandler)
 	return nil
 }
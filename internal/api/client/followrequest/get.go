@@ -85,6 +85,10 @@ func (m *Module) FollowRequestGETHandler(c *gin.Context) {
 		return
 	}
 
+	if !oauth.RequireScope(c, authed, oauth.ScopeReadFollows) {
+		return
+	}
+
 	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
 		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
 		return
@@ -98,7 +102,7 @@ func (m *Module) FollowRequestGETHandler(c *gin.Context) {
 
 	accts, errWithCode := m.processor.FollowRequestsGet(c.Request.Context(), authed)
 	if errWithCode != nil {
-		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		api.ErrorHandler(c, errWithCode)
 		return
 	}
 
@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
@@ -30,6 +31,8 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api"
 	"github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
@@ -77,6 +80,10 @@ import (
 //     description: The newly-created media attachment.
 //     schema:
 //       "$ref": "#/definitions/attachment"
+//   '202':
+//     description: The newly-created media attachment, which is still being processed. It won't have a url yet.
+//     schema:
+//       "$ref": "#/definitions/attachment"
 //   '400':
 //      description: bad request
 //   '401':
@@ -85,6 +92,8 @@ import (
 //      description: forbidden
 //   '422':
 //      description: unprocessable
+//   '503':
+//      description: media processing queue is full, try again later
 func (m *Module) MediaCreatePOSTHandler(c *gin.Context) {
 	l := logrus.WithField("func", "statusCreatePOSTHandler")
 	authed, err := oauth.Authed(c, true, true, true, true) // posting new media is serious business so we want *everything*
@@ -94,6 +103,10 @@ func (m *Module) MediaCreatePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if !oauth.RequireScope(c, authed, oauth.ScopeWriteMedia) {
+		return
+	}
+
 	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
 		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
 		return
@@ -112,6 +125,15 @@ func (m *Module) MediaCreatePOSTHandler(c *gin.Context) {
 	l.Tracef("validating form %+v", form)
 	if err := validateCreateMedia(form); err != nil {
 		l.Debugf("error validating form: %s", err)
+		var tooLarge *errMediaTooLarge
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusUnprocessableEntity, model.Error{
+				Error:   err.Error(),
+				Code:    string(gtserror.CodeMediaTooLarge),
+				Details: map[string]interface{}{"limit": tooLarge.limit, "size": tooLarge.size},
+			})
+			return
+		}
 		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
 		return
 	}
@@ -120,13 +142,40 @@ func (m *Module) MediaCreatePOSTHandler(c *gin.Context) {
 	apiAttachment, err := m.processor.MediaCreate(c.Request.Context(), authed, form)
 	if err != nil {
 		l.Debugf("error creating attachment: %s", err)
+		if errors.Is(err, media.ErrQueueFull) {
+			// too much media is being processed right now, so ask the client to try again shortly
+			api.ErrorHandler(c, gtserror.WithRetryAfter(gtserror.NewErrorServiceUnavailable(err, err.Error()), 30*time.Second))
+			return
+		}
+		var errWithCode gtserror.WithCode
+		if errors.As(err, &errWithCode) {
+			// eg., the instance is in read-only mode
+			api.ErrorHandler(c, errWithCode)
+			return
+		}
 		c.JSON(http.StatusUnprocessableEntity, gin.H{"error": err.Error()})
 		return
 	}
 
+	if apiAttachment.URL == "" {
+		// the attachment is still being processed in the background
+		c.JSON(http.StatusAccepted, apiAttachment)
+		return
+	}
+
 	c.JSON(http.StatusOK, apiAttachment)
 }
 
+// errMediaTooLarge is returned from validateCreateMedia when the attached file exceeds the size limit for media.
+type errMediaTooLarge struct {
+	limit int64
+	size  int64
+}
+
+func (e *errMediaTooLarge) Error() string {
+	return fmt.Sprintf("file size limit exceeded: limit is %d bytes but attachment was %d bytes", e.limit, e.size)
+}
+
 func validateCreateMedia(form *model.AttachmentRequest) error {
 	// check there actually is a file attached and it's not size 0
 	if form.File == nil {
@@ -146,7 +195,7 @@ func validateCreateMedia(form *model.AttachmentRequest) error {
 		maxSize = maxImageSize
 	}
 	if form.File.Size > int64(maxSize) {
-		return fmt.Errorf("file size limit exceeded: limit is %d bytes but attachment was %d bytes", maxSize, form.File.Size)
+		return &errMediaTooLarge{limit: int64(maxSize), size: form.File.Size}
 	}
 
 	if len(form.Description) > maxDescriptionChars {
@@ -29,6 +29,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"codeberg.org/gruf/go-store/kv"
 	"github.com/gin-gonic/gin"
@@ -259,6 +260,46 @@ func (suite *MediaCreateTestSuite) TestMediaCreateLongDescription() {
 	suite.Equal(expectedErr, string(b))
 }
 
+func (suite *MediaCreateTestSuite) TestMediaCreateTooLarge() {
+	// set the max sizes below the size of the test jpeg
+	viper.Set(config.Keys.MediaImageMaxSize, 1000)
+	viper.Set(config.Keys.MediaVideoMaxSize, 1000)
+	defer viper.Set(config.Keys.MediaImageMaxSize, testrig.TestDefaults.MediaImageMaxSize)
+	defer viper.Set(config.Keys.MediaVideoMaxSize, testrig.TestDefaults.MediaVideoMaxSize)
+
+	// set up the context for the request
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+
+	// create the request
+	buf, w, err := testrig.CreateMultipartFormData("file", "../../../../testrig/media/test-jpeg.jpg", map[string]string{})
+	if err != nil {
+		panic(err)
+	}
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080/%s", mediamodule.BasePathV1), bytes.NewReader(buf.Bytes())) // the endpoint we're hitting
+	ctx.Request.Header.Set("Content-Type", w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	// do the actual request
+	suite.mediaModule.MediaCreatePOSTHandler(ctx)
+
+	// check response
+	suite.EqualValues(http.StatusUnprocessableEntity, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	suite.Equal(`{"error":"file size limit exceeded: limit is 1000 bytes but attachment was 269739 bytes","code":"media_too_large","details":{"limit":1000,"size":269739}}`, string(b))
+}
+
 func (suite *MediaCreateTestSuite) TestMediaCreateTooShortDescription() {
 	// set the min description length
 	viper.Set(config.Keys.MediaDescriptionMinChars, 500)
@@ -292,6 +333,90 @@ func (suite *MediaCreateTestSuite) TestMediaCreateTooShortDescription() {
 	suite.EqualValues(http.StatusOK, recorder.Code)
 }
 
+func (suite *MediaCreateTestSuite) TestMediaCreateAsync() {
+	// process everything in the background
+	viper.Set(config.Keys.MediaSyncProcessingMaxSize, 0)
+	defer viper.Set(config.Keys.MediaSyncProcessingMaxSize, testrig.TestDefaults.MediaSyncProcessingMaxSize)
+
+	// set up the context for the request
+	t := suite.testTokens["local_account_1"]
+	oauthToken := oauth.DBTokenToToken(t)
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+
+	// create the request
+	buf, w, err := testrig.CreateMultipartFormData("file", "../../../../testrig/media/test-jpeg.jpg", map[string]string{
+		"description": "this is a test image -- a cool background from somewhere",
+	})
+	if err != nil {
+		panic(err)
+	}
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080/%s", mediamodule.BasePathV2), bytes.NewReader(buf.Bytes())) // the endpoint we're hitting
+	ctx.Request.Header.Set("Content-Type", w.FormDataContentType())
+	ctx.Request.Header.Set("accept", "application/json")
+
+	// do the actual request
+	suite.mediaModule.MediaCreatePOSTHandler(ctx)
+
+	// we should get the placeholder back before processing is done
+	suite.EqualValues(http.StatusAccepted, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	attachmentReply := &model.Attachment{}
+	err = json.Unmarshal(b, attachmentReply)
+	suite.NoError(err)
+
+	suite.NotEmpty(attachmentReply.ID)
+	suite.Empty(attachmentReply.URL)
+	suite.Equal("this is a test image -- a cool background from somewhere", attachmentReply.Description)
+
+	// keep checking the attachment until it's finished processing
+	var processed *model.Attachment
+	for i := 0; i < 50 && processed == nil; i++ {
+		recorder := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(recorder)
+		ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+		ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+		ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+		ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+		ctx.Request = httptest.NewRequest(http.MethodGet, fmt.Sprintf("http://localhost:8080/%s/%s", mediamodule.BasePathV1, attachmentReply.ID), nil)
+		ctx.Request.Header.Set("accept", "application/json")
+		ctx.Params = gin.Params{
+			gin.Param{
+				Key:   mediamodule.IDKey,
+				Value: attachmentReply.ID,
+			},
+		}
+
+		suite.mediaModule.MediaGETHandler(ctx)
+
+		if recorder.Code == http.StatusPartialContent {
+			time.Sleep(100 * time.Millisecond)
+			continue
+		}
+		suite.EqualValues(http.StatusOK, recorder.Code)
+
+		processed = &model.Attachment{}
+		err := json.Unmarshal(recorder.Body.Bytes(), processed)
+		suite.NoError(err)
+	}
+
+	if suite.NotNil(processed) {
+		suite.Equal(attachmentReply.ID, processed.ID)
+		suite.NotEmpty(processed.URL)
+		suite.Equal(1920, processed.Meta.Original.Width)
+		suite.Equal("LjBzUo#6RQR._NvzRjWF?urqV@a$", processed.Blurhash)
+	}
+}
+
 func TestMediaCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(MediaCreateTestSuite))
 }
@@ -55,6 +55,10 @@ import (
 //     description: The requested media attachment.
 //     schema:
 //       "$ref": "#/definitions/attachment"
+//   '206':
+//     description: The requested media attachment, which is still being processed. It won't have a url yet.
+//     schema:
+//       "$ref": "#/definitions/attachment"
 //   '400':
 //      description: bad request
 //   '401':
@@ -72,6 +76,10 @@ func (m *Module) MediaGETHandler(c *gin.Context) {
 		return
 	}
 
+	if !oauth.RequireScope(c, authed, oauth.ScopeReadMedia) {
+		return
+	}
+
 	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
 		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
 		return
@@ -85,7 +93,13 @@ func (m *Module) MediaGETHandler(c *gin.Context) {
 
 	attachment, errWithCode := m.processor.MediaGet(c.Request.Context(), authed, attachmentID)
 	if errWithCode != nil {
-		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		api.ErrorHandler(c, errWithCode)
+		return
+	}
+
+	if attachment.URL == "" {
+		// the attachment is still being processed in the background
+		c.JSON(http.StatusPartialContent, attachment)
 		return
 	}
 
@@ -0,0 +1,100 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package oembed
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+// OEmbedGETHandler swagger:operation GET /api/oembed oEmbedGet
+//
+// Get an oEmbed representation of a status, so that it can be embedded on other sites.
+//
+// Only public statuses from this instance can be embedded.
+//
+// See https://oembed.com/
+//
+// ---
+// tags:
+// - oembed
+//
+// produces:
+// - application/json
+//
+// parameters:
+// - name: url
+//   type: string
+//   description: URL of the status to embed.
+//   in: query
+//   required: true
+// - name: maxwidth
+//   type: integer
+//   description: Maximum width of the embed, in pixels.
+//   in: query
+// - name: maxheight
+//   type: integer
+//   description: Maximum height of the embed, in pixels.
+//   in: query
+// - name: format
+//   type: string
+//   description: Response format. Only json is supported.
+//   in: query
+//
+// responses:
+//   '200':
+//     description: The oEmbed representation of the status.
+//     schema:
+//       "$ref": "#/definitions/oEmbed"
+//   '400':
+//      description: bad request
+//   '404':
+//      description: not found
+//   '406':
+//      description: not acceptable
+//   '501':
+//      description: requested format not implemented
+func (m *Module) OEmbedGETHandler(c *gin.Context) {
+	l := logrus.WithField("func", "OEmbedGETHandler")
+
+	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
+		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
+		return
+	}
+
+	form := &apimodel.OEmbedRequest{}
+	if err := c.ShouldBind(form); err != nil {
+		l.Debugf("error parsing form: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "url not specified"})
+		return
+	}
+
+	oEmbed, errWithCode := m.processor.OEmbedGet(c.Request.Context(), form)
+	if errWithCode != nil {
+		l.Debugf("error getting oembed: %s", errWithCode.Error())
+		api.ErrorHandler(c, errWithCode)
+		return
+	}
+
+	c.JSON(http.StatusOK, oEmbed)
+}
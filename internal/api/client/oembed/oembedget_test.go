@@ -0,0 +1,125 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package oembed_test
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/oembed"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type OEmbedGetTestSuite struct {
+	OEmbedStandardTestSuite
+}
+
+func (suite *OEmbedGetTestSuite) getOEmbed(query url.Values) (int, []byte) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080"+oembed.BasePath+"?"+query.Encode(), nil)
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.oEmbedModule.OEmbedGETHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+	return recorder.Code, b
+}
+
+func (suite *OEmbedGetTestSuite) TestOEmbedByURL() {
+	status := suite.testStatuses["admin_account_status_1"]
+
+	code, b := suite.getOEmbed(url.Values{"url": {status.URL}})
+	suite.Equal(http.StatusOK, code)
+
+	oEmbed := &apimodel.OEmbed{}
+	suite.NoError(json.Unmarshal(b, oEmbed))
+	suite.Equal("rich", oEmbed.Type)
+	suite.Equal("1.0", oEmbed.Version)
+	suite.Equal("admin", oEmbed.AuthorName)
+	suite.Equal("http://localhost:8080/@admin", oEmbed.AuthorURL)
+	suite.Equal("http://localhost:8080/", oEmbed.ProviderURL)
+	suite.Equal(86400, oEmbed.CacheAge)
+	suite.Equal(400, oEmbed.Width)
+	suite.Equal(300, oEmbed.Height)
+	suite.Equal(`<iframe src="http://localhost:8080/@admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R/embed" class="gotosocial-embed" style="max-width: 100%; border: 0" width="400" height="300" allowfullscreen="allowfullscreen"></iframe>`, oEmbed.HTML)
+}
+
+func (suite *OEmbedGetTestSuite) TestOEmbedByURIWithMaxSize() {
+	status := suite.testStatuses["local_account_2_status_2"]
+
+	code, b := suite.getOEmbed(url.Values{
+		"url":       {status.URI},
+		"maxwidth":  {"250"},
+		"maxheight": {"1000"},
+	})
+	suite.Equal(http.StatusOK, code)
+
+	oEmbed := &apimodel.OEmbed{}
+	suite.NoError(json.Unmarshal(b, oEmbed))
+	suite.Equal(250, oEmbed.Width)
+	suite.Equal(300, oEmbed.Height)
+	suite.Contains(oEmbed.HTML, `width="250" height="300"`)
+}
+
+func (suite *OEmbedGetTestSuite) TestOEmbedNotPublic() {
+	status := suite.testStatuses["local_account_2_status_3"]
+
+	code, _ := suite.getOEmbed(url.Values{"url": {status.URL}})
+	suite.Equal(http.StatusNotFound, code)
+}
+
+func (suite *OEmbedGetTestSuite) TestOEmbedRemote() {
+	status := suite.testStatuses["remote_account_1_status_1"]
+
+	code, _ := suite.getOEmbed(url.Values{"url": {status.URL}})
+	suite.Equal(http.StatusNotFound, code)
+}
+
+func (suite *OEmbedGetTestSuite) TestOEmbedUnknown() {
+	code, _ := suite.getOEmbed(url.Values{"url": {"http://localhost:8080/@admin/statuses/01G5E8AHF4QBH6G2CX6RWYY7JA"}})
+	suite.Equal(http.StatusNotFound, code)
+}
+
+func (suite *OEmbedGetTestSuite) TestOEmbedXML() {
+	status := suite.testStatuses["admin_account_status_1"]
+
+	code, b := suite.getOEmbed(url.Values{"url": {status.URL}, "format": {"xml"}})
+	suite.Equal(http.StatusNotImplemented, code)
+	suite.Equal(`{"error":"not implemented: format xml not supported"}`, string(b))
+}
+
+func (suite *OEmbedGetTestSuite) TestOEmbedNoURL() {
+	code, b := suite.getOEmbed(url.Values{})
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal(`{"error":"url not specified"}`, string(b))
+}
+
+func TestOEmbedGetTestSuite(t *testing.T) {
+	suite.Run(t, &OEmbedGetTestSuite{})
+}
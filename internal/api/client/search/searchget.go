@@ -36,6 +36,11 @@ import (
 //
 // If statuses are in the result, they will be returned in descending chronological order (newest first), with sequential IDs (bigger = newer).
 //
+// If the query is the URL of a status or account, and resolve is true, a status or account which isn't known
+// to this instance yet is fetched from its own instance, and stored here, so that it can be interacted with.
+// Without resolve, only statuses and accounts already known to this instance are returned. Nothing is fetched
+// from blocked domains. This endpoint is also served at /api/v2/search.
+//
 // ---
 // tags:
 // - search
@@ -57,7 +62,7 @@ import (
 //   '400':
 //      description: bad request
 func (m *Module) SearchGETHandler(c *gin.Context) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "SearchGETHandler",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
@@ -72,14 +77,26 @@ func (m *Module) SearchGETHandler(c *gin.Context) {
 		return
 	}
 
+	if !oauth.RequireScope(c, authed, oauth.ScopeReadSearch) {
+		return
+	}
+
 	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
 		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
 		return
 	}
 
 	accountID := c.Query(AccountIDKey)
-	maxID := c.Query(MaxIDKey)
-	minID := c.Query(MinIDKey)
+	maxID, err := api.ParsePagingID(c, MaxIDKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	minID, err := api.ParsePagingID(c, MinIDKey)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
 	searchType := c.Query(TypeKey)
 
 	excludeUnreviewed := false
@@ -110,22 +127,11 @@ func (m *Module) SearchGETHandler(c *gin.Context) {
 		}
 	}
 
-	limit := 20
-	limitString := c.Query(LimitKey)
-	if limitString != "" {
-		i, err := strconv.ParseInt(limitString, 10, 64)
-		if err != nil {
-			l.Debugf("error parsing limit string: %s", err)
-			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse limit query param"})
-			return
-		}
-		limit = int(i)
-	}
-	if limit > 40 {
-		limit = 40
-	}
-	if limit < 1 {
-		limit = 1
+	limit, err := api.ParseLimit(c, LimitKey, 20)
+	if err != nil {
+		l.Debugf("error parsing limit: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse limit query param"})
+		return
 	}
 
 	offset := 0
@@ -173,7 +179,7 @@ func (m *Module) SearchGETHandler(c *gin.Context) {
 	results, errWithCode := m.processor.SearchGet(c.Request.Context(), authed, searchQuery)
 	if errWithCode != nil {
 		l.Debugf("error searching: %s", errWithCode.Error())
-		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		api.ErrorHandler(c, errWithCode)
 		return
 	}
 
@@ -33,6 +33,10 @@ import (
 const (
 	// IDKey is for status UUIDs
 	IDKey = "id"
+	// EmojiKey is for the emoji of a reaction
+	EmojiKey = "emoji"
+	// DeleteMediaKey is for specifying whether to delete the media of a deleted status
+	DeleteMediaKey = "delete_media"
 	// BasePath is the base path for serving the status API
 	BasePath = "/api/v1/statuses"
 	// BasePathWithID is just the base path with the ID key in it.
@@ -41,6 +45,10 @@ const (
 
 	// ContextPath is used for fetching context of posts
 	ContextPath = BasePathWithID + "/context"
+	// SourcePath is used for fetching the source text of posts
+	SourcePath = BasePathWithID + "/source"
+	// RedraftPath is used for deleting posts and getting their source back to draft them again
+	RedraftPath = BasePathWithID + "/redraft"
 
 	// FavouritedPath is for seeing who's faved a given status
 	FavouritedPath = BasePathWithID + "/favourited_by"
@@ -49,6 +57,9 @@ const (
 	// UnfavouritePath is for removing a fave from a status
 	UnfavouritePath = BasePathWithID + "/unfavourite"
 
+	// ReactPath is for adding or removing an emoji reaction on a status
+	ReactPath = BasePathWithID + "/react/:" + EmojiKey
+
 	// RebloggedPath is for seeing who's boosted a given status
 	RebloggedPath = BasePathWithID + "/reblogged_by"
 	// ReblogPath is for boosting/reblogging a given status
@@ -88,16 +99,21 @@ func New(processor processing.Processor) api.ClientModule {
 func (m *Module) Route(r router.Router) error {
 	r.AttachHandler(http.MethodPost, BasePath, m.StatusCreatePOSTHandler)
 	r.AttachHandler(http.MethodDelete, BasePathWithID, m.StatusDELETEHandler)
+	r.AttachHandler(http.MethodPost, RedraftPath, m.StatusRedraftPOSTHandler)
 
 	r.AttachHandler(http.MethodPost, FavouritePath, m.StatusFavePOSTHandler)
 	r.AttachHandler(http.MethodPost, UnfavouritePath, m.StatusUnfavePOSTHandler)
 	r.AttachHandler(http.MethodGet, FavouritedPath, m.StatusFavedByGETHandler)
 
+	r.AttachHandler(http.MethodPut, ReactPath, m.StatusReactPUTHandler)
+	r.AttachHandler(http.MethodDelete, ReactPath, m.StatusUnreactDELETEHandler)
+
 	r.AttachHandler(http.MethodPost, ReblogPath, m.StatusBoostPOSTHandler)
 	r.AttachHandler(http.MethodPost, UnreblogPath, m.StatusUnboostPOSTHandler)
 	r.AttachHandler(http.MethodGet, RebloggedPath, m.StatusBoostedByGETHandler)
 
 	r.AttachHandler(http.MethodGet, ContextPath, m.StatusContextGETHandler)
+	r.AttachHandler(http.MethodGet, SourcePath, m.StatusSourceGETHandler)
 
 	r.AttachHandler(http.MethodGet, BasePathWithID, m.muxHandler)
 	return nil
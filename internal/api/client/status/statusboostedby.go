@@ -63,7 +63,7 @@ import (
 //   '404':
 //      description: not found
 func (m *Module) StatusBoostedByGETHandler(c *gin.Context) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "StatusBoostedByGETHandler",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
@@ -78,6 +78,10 @@ func (m *Module) StatusBoostedByGETHandler(c *gin.Context) {
 		return
 	}
 
+	if !oauth.RequireScope(c, authed, oauth.ScopeReadAccounts) {
+		return
+	}
+
 	targetStatusID := c.Param(IDKey)
 	if targetStatusID == "" {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "no status id provided"})
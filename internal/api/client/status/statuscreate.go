@@ -30,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api"
 	"github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
@@ -66,8 +67,12 @@ import (
 //      description: unauthorized
 //   '400':
 //      description: bad request
+//   '403':
+//      description: forbidden, eg., because your account is too new to post publicly or mention an account
 //   '404':
 //      description: not found
+//   '422':
+//      description: unprocessable, eg., because the status is a duplicate of your previous status
 //   '500':
 //      description: internal error
 func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
@@ -79,6 +84,10 @@ func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 		return
 	}
 
+	if !oauth.RequireScope(c, authed, oauth.ScopeWriteStatuses) {
+		return
+	}
+
 	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
 		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
 		return
@@ -113,6 +122,12 @@ func (m *Module) StatusCreatePOSTHandler(c *gin.Context) {
 	apiStatus, err := m.processor.StatusCreate(c.Request.Context(), authed, form)
 	if err != nil {
 		l.Debugf("error processing status create: %s", err)
+		var errWithCode gtserror.WithCode
+		if errors.As(err, &errWithCode) && errWithCode.ErrorCode() != "" {
+			// the client can tell what went wrong from the error code, eg., the instance is in read-only mode or the status is a duplicate
+			api.ErrorHandler(c, errWithCode)
+			return
+		}
 		c.JSON(http.StatusBadRequest, gin.H{"error": "bad request"})
 		return
 	}
@@ -165,12 +180,19 @@ func validateCreateStatus(form *model.AdvancedStatusCreateForm) error {
 	}
 
 	// validate spoiler text/cw
-	if form.SpoilerText != "" {
-		if len(form.SpoilerText) > maxCwChars {
-			return fmt.Errorf("content-warning/spoilertext too long, %d characters provided but limit is %d", len(form.SpoilerText), maxCwChars)
+	if form.SpoilerText != nil {
+		if len(*form.SpoilerText) > maxCwChars {
+			return fmt.Errorf("content-warning/spoilertext too long, %d characters provided but limit is %d", len(*form.SpoilerText), maxCwChars)
 		}
 	}
 
+	// validate reply policy
+	switch form.ReplyPolicy {
+	case "", model.ReplyPolicyEveryone, model.ReplyPolicyFollowers, model.ReplyPolicyMentioned, model.ReplyPolicyNobody:
+	default:
+		return fmt.Errorf("reply policy %s not recognised", form.ReplyPolicy)
+	}
+
 	// validate post language
 	if form.Language != "" {
 		if err := validate.Language(form.Language); err != nil {
@@ -106,6 +106,36 @@ func (suite *StatusCreateTestSuite) TestPostNewStatus() {
 	suite.Equal(statusReply.Account.ID, gtsTag.FirstSeenFromAccountID)
 }
 
+// Try to post a new status with a token that's only allowed to read -- it should be rejected
+func (suite *StatusCreateTestSuite) TestPostNewStatusReadOnlyToken() {
+	t := *suite.testTokens["local_account_1"]
+	t.Scope = "read"
+	oauthToken := oauth.DBTokenToToken(&t)
+
+	// setup
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauthToken)
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodPost, fmt.Sprintf("http://localhost:8080/%s", status.BasePath), nil) // the endpoint we're hitting
+	ctx.Request.Header.Set("accept", "application/json")
+	ctx.Request.Form = url.Values{
+		"status": {"this status should never be posted"},
+	}
+	suite.statusModule.StatusCreatePOSTHandler(ctx)
+
+	suite.EqualValues(http.StatusForbidden, recorder.Code)
+	suite.Equal(`Bearer error="insufficient_scope", scope="write:statuses"`, recorder.Header().Get("WWW-Authenticate"))
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+	suite.Equal(`{"error":"token does not have required scope write:statuses"}`, string(b))
+}
+
 // mention an account that is not yet known to the instance -- it should be looked up and put in the db
 func (suite *StatusCreateTestSuite) TestMentionUnknownAccount() {
 
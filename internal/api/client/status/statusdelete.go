@@ -19,11 +19,15 @@
 package status
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
@@ -34,6 +38,9 @@ import (
 // The deleted status will be returned in the response. The `text` field will contain the original text of the status as it was submitted.
 // This is useful when doing a 'delete and redraft' type operation.
 //
+// By default, the media of the status is deleted along with it, unless the instance is configured to keep it.
+// Kept media is detached from the deleted status, so that its IDs can be used when creating a new status.
+//
 // ---
 // tags:
 // - statuses
@@ -47,6 +54,12 @@ import (
 //   description: Target status ID.
 //   in: path
 //   required: true
+// - name: delete_media
+//   type: boolean
+//   description: |-
+//     Delete the media of the status along with it. If false, the media is kept for attaching to a new status.
+//     Defaults to the instance configuration.
+//   in: query
 //
 // security:
 // - OAuth2 Bearer:
@@ -66,7 +79,7 @@ import (
 //   '404':
 //      description: not found
 func (m *Module) StatusDELETEHandler(c *gin.Context) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":        "StatusDELETEHandler",
 		"request_uri": c.Request.RequestURI,
 		"user_agent":  c.Request.UserAgent(),
@@ -81,6 +94,10 @@ func (m *Module) StatusDELETEHandler(c *gin.Context) {
 		return
 	}
 
+	if !oauth.RequireScope(c, authed, oauth.ScopeWriteStatuses) {
+		return
+	}
+
 	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
 		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
 		return
@@ -92,7 +109,17 @@ func (m *Module) StatusDELETEHandler(c *gin.Context) {
 		return
 	}
 
-	apiStatus, err := m.processor.StatusDelete(c.Request.Context(), authed, targetStatusID)
+	preserveMedia := viper.GetBool(config.Keys.StatusesDeletePreserveMedia)
+	if deleteMediaString := c.Query(DeleteMediaKey); deleteMediaString != "" {
+		deleteMedia, err := strconv.ParseBool(deleteMediaString)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("couldn't parse param %s: %s", deleteMediaString, err)})
+			return
+		}
+		preserveMedia = !deleteMedia
+	}
+
+	apiStatus, err := m.processor.StatusDelete(c.Request.Context(), authed, targetStatusID, preserveMedia)
 	if err != nil {
 		l.Debugf("error processing status delete: %s", err)
 		c.JSON(http.StatusBadRequest, gin.H{"error": "bad request"})
@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/gorilla/websocket"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
 )
 
 // StreamGETHandler swagger:operation GET /api/v1/streaming streamGet
@@ -134,7 +135,9 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 		ReadBufferSize:  1024,
 		WriteBufferSize: 1024,
 		CheckOrigin: func(r *http.Request) bool {
-			// we fully expect cors requests (via something like pinafore.social) so we should be lenient here
+			// we fully expect cors requests (via something like pinafore.social), and the origin of
+			// this request has already been checked by the router's cors middleware, which is configured
+			// with the allowed origins and applies to websocket upgrade requests too, so we can be lenient here
 			return true
 		},
 	}
@@ -150,7 +153,7 @@ func (m *Module) StreamGETHandler(c *gin.Context) {
 	// inform the processor that we have a new connection and want a s for it
 	s, errWithCode := m.processor.OpenStreamForAccount(c.Request.Context(), account, streamType)
 	if errWithCode != nil {
-		c.JSON(errWithCode.Code(), errWithCode.Safe())
+		api.ErrorHandler(c, errWithCode)
 		return
 	}
 	defer close(s.Hangup) // closing stream.Hangup indicates that we've finished with the connection (the client has gone), so we want to do this on exiting this handler
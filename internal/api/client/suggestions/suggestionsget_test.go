@@ -0,0 +1,94 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package suggestions_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/suggestions"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type SuggestionsGetTestSuite struct {
+	SuggestionsStandardTestSuite
+}
+
+func (suite *SuggestionsGetTestSuite) getSuggestions(accountKey string) []apimodel.Account {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts[accountKey])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens[accountKey]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers[accountKey])
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080"+suggestions.BasePath, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.suggestionsModule.SuggestionsGETHandler(ctx)
+	suite.Equal(http.StatusOK, recorder.Code)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	accounts := []apimodel.Account{}
+	suite.NoError(json.Unmarshal(b, &accounts))
+	return accounts
+}
+
+func (suite *SuggestionsGetTestSuite) TestGetSuggestions() {
+	// admin follows zork, who follows turtle
+	accounts := suite.getSuggestions("admin_account")
+	if suite.Len(accounts, 1) {
+		suite.Equal(suite.testAccounts["local_account_2"].ID, accounts[0].ID)
+	}
+
+	// once admin follows turtle, turtle shouldn't be suggested anymore, even though suggestions are cached
+	suite.NoError(suite.db.Put(context.Background(), &gtsmodel.Follow{
+		ID:              "01G5F9V2XQ0BCT0HCDZDN3M4A3",
+		URI:             "http://localhost:8080/users/admin/follow/01G5F9V2XQ0BCT0HCDZDN3M4A3",
+		AccountID:       suite.testAccounts["admin_account"].ID,
+		TargetAccountID: suite.testAccounts["local_account_2"].ID,
+	}))
+
+	accounts = suite.getSuggestions("admin_account")
+	suite.Empty(accounts)
+}
+
+func (suite *SuggestionsGetTestSuite) TestGetSuggestionsDisabled() {
+	viper.Set(config.Keys.AccountsSuggestionsEnabled, false)
+
+	accounts := suite.getSuggestions("admin_account")
+	suite.Empty(accounts)
+}
+
+func TestSuggestionsGetTestSuite(t *testing.T) {
+	suite.Run(t, &SuggestionsGetTestSuite{})
+}
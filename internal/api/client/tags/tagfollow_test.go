@@ -0,0 +1,96 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package tags_test
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/tags"
+)
+
+type TagFollowTestSuite struct {
+	TagsStandardTestSuite
+}
+
+func (suite *TagFollowTestSuite) TestFollowUnfollowTag() {
+	followPath := strings.Replace(tags.FollowPath, ":"+tags.NameKey, "welcome", 1)
+	unfollowPath := strings.Replace(tags.UnfollowPath, ":"+tags.NameKey, "welcome", 1)
+	tagPath := strings.Replace(tags.BasePathWithName, ":"+tags.NameKey, "welcome", 1)
+
+	code, b := suite.request(suite.tagsModule.TagGETHandler, http.MethodGet, tagPath, "welcome")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(`{"name":"welcome","url":"http://localhost:8080/tags/welcome","following":false}`, string(b))
+
+	code, b = suite.request(suite.tagsModule.TagFollowPOSTHandler, http.MethodPost, followPath, "welcome")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(`{"name":"welcome","url":"http://localhost:8080/tags/welcome","following":true}`, string(b))
+
+	// following again should be fine
+	code, b = suite.request(suite.tagsModule.TagFollowPOSTHandler, http.MethodPost, followPath, "welcome")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(`{"name":"welcome","url":"http://localhost:8080/tags/welcome","following":true}`, string(b))
+
+	code, b = suite.request(suite.tagsModule.FollowedTagsGETHandler, http.MethodGet, tags.FollowedTagsPath, "")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(`[{"name":"welcome","url":"http://localhost:8080/tags/welcome","following":true}]`, string(b))
+
+	code, b = suite.request(suite.tagsModule.TagUnfollowPOSTHandler, http.MethodPost, unfollowPath, "welcome")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(`{"name":"welcome","url":"http://localhost:8080/tags/welcome","following":false}`, string(b))
+
+	code, b = suite.request(suite.tagsModule.FollowedTagsGETHandler, http.MethodGet, tags.FollowedTagsPath, "")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(`[]`, string(b))
+}
+
+func (suite *TagFollowTestSuite) TestFollowNewTag() {
+	followPath := strings.Replace(tags.FollowPath, ":"+tags.NameKey, "brandnew", 1)
+
+	code, b := suite.request(suite.tagsModule.TagFollowPOSTHandler, http.MethodPost, followPath, "brandnew")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(`{"name":"brandnew","url":"http://localhost:8080/tags/brandnew","following":true}`, string(b))
+
+	// the tag should have been created
+	tag, err := suite.db.GetTagByName(context.Background(), "brandnew")
+	suite.NoError(err)
+	suite.Equal(suite.testAccounts["local_account_1"].ID, tag.FirstSeenFromAccountID)
+}
+
+func (suite *TagFollowTestSuite) TestFollowInvalidTag() {
+	followPath := strings.Replace(tags.FollowPath, ":"+tags.NameKey, "not-a-tag", 1)
+
+	code, b := suite.request(suite.tagsModule.TagFollowPOSTHandler, http.MethodPost, followPath, "not-a-tag")
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal(`{"error":"bad request: not-a-tag is not a valid hashtag"}`, string(b))
+}
+
+func (suite *TagFollowTestSuite) TestGetNonexistentTag() {
+	tagPath := strings.Replace(tags.BasePathWithName, ":"+tags.NameKey, "nonexistent", 1)
+
+	code, _ := suite.request(suite.tagsModule.TagGETHandler, http.MethodGet, tagPath, "nonexistent")
+	suite.Equal(http.StatusNotFound, code)
+}
+
+func TestTagFollowTestSuite(t *testing.T) {
+	suite.Run(t, &TagFollowTestSuite{})
+}
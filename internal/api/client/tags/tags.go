@@ -0,0 +1,63 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package tags
+
+import (
+	"net/http"
+
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/router"
+)
+
+const (
+	// NameKey is the key to use for retrieving the tag name in requests
+	NameKey = "name"
+	// BasePath is the base API path for this module
+	BasePath = "/api/v1/tags"
+	// BasePathWithName is the base path for this module with the name key
+	BasePathWithName = BasePath + "/:" + NameKey
+	// FollowPath is for POSTing new follows of a tag to
+	FollowPath = BasePathWithName + "/follow"
+	// UnfollowPath is for POSTing an unfollow of a tag
+	UnfollowPath = BasePathWithName + "/unfollow"
+	// FollowedTagsPath is for showing the tags that the requesting account follows
+	FollowedTagsPath = "/api/v1/followed_tags"
+)
+
+// Module implements the ClientAPIModule interface for everything related to tags
+type Module struct {
+	processor processing.Processor
+}
+
+// New returns a new tags module
+func New(processor processing.Processor) api.ClientModule {
+	return &Module{
+		processor: processor,
+	}
+}
+
+// Route attaches all routes from this module to the given router
+func (m *Module) Route(r router.Router) error {
+	r.AttachHandler(http.MethodGet, BasePathWithName, m.TagGETHandler)
+	r.AttachHandler(http.MethodPost, FollowPath, m.TagFollowPOSTHandler)
+	r.AttachHandler(http.MethodPost, UnfollowPath, m.TagUnfollowPOSTHandler)
+	r.AttachHandler(http.MethodGet, FollowedTagsPath, m.FollowedTagsGETHandler)
+	return nil
+}
@@ -83,6 +83,24 @@ import (
 //   default: false
 //   in: query
 //   required: false
+// - name: language
+//   type: array
+//   items:
+//     type: string
+//   collectionFormat: multi
+//   description: |-
+//     Show only statuses written in one of the given languages, eg., `en`.
+//     Can be repeated to allow more than one language, eg., `?language=en&language=de`.
+//   in: query
+//   required: false
+// - name: include_unknown_language
+//   type: boolean
+//   description: |-
+//     Whether to show statuses with no known language when filtering by language.
+//     If this is false and no language is given, only statuses with a known language will be shown.
+//   default: true
+//   in: query
+//   required: false
 //
 // security:
 // - OAuth2 Bearer:
@@ -114,39 +132,41 @@ func (m *Module) PublicTimelineGETHandler(c *gin.Context) {
 		return
 	}
 
+	if !oauth.RequireScope(c, authed, oauth.ScopeReadStatuses) {
+		return
+	}
+
 	if _, err := api.NegotiateAccept(c, api.JSONAcceptHeaders...); err != nil {
 		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
 		return
 	}
 
-	maxID := ""
-	maxIDString := c.Query(MaxIDKey)
-	if maxIDString != "" {
-		maxID = maxIDString
+	maxID, err := api.ParsePagingID(c, MaxIDKey)
+	if err != nil {
+		l.Debugf("error parsing max id: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	sinceID := ""
-	sinceIDString := c.Query(SinceIDKey)
-	if sinceIDString != "" {
-		sinceID = sinceIDString
+	sinceID, err := api.ParsePagingID(c, SinceIDKey)
+	if err != nil {
+		l.Debugf("error parsing since id: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	minID := ""
-	minIDString := c.Query(MinIDKey)
-	if minIDString != "" {
-		minID = minIDString
+	minID, err := api.ParsePagingID(c, MinIDKey)
+	if err != nil {
+		l.Debugf("error parsing min id: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
 	}
 
-	limit := 20
-	limitString := c.Query(LimitKey)
-	if limitString != "" {
-		i, err := strconv.ParseInt(limitString, 10, 64)
-		if err != nil {
-			l.Debugf("error parsing limit string: %s", err)
-			c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse limit query param"})
-			return
-		}
-		limit = int(i)
+	limit, err := api.ParseLimit(c, LimitKey, 20)
+	if err != nil {
+		l.Debugf("error parsing limit: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse limit query param"})
+		return
 	}
 
 	local := false
@@ -161,10 +181,17 @@ func (m *Module) PublicTimelineGETHandler(c *gin.Context) {
 		local = i
 	}
 
-	resp, errWithCode := m.processor.PublicTimelineGet(c.Request.Context(), authed, maxID, sinceID, minID, limit, local)
+	languages, err := parseLanguageFilter(c)
+	if err != nil {
+		l.Debugf("error parsing language filter: %s", err)
+		c.JSON(http.StatusBadRequest, gin.H{"error": "couldn't parse include_unknown_language query param"})
+		return
+	}
+
+	resp, errWithCode := m.processor.PublicTimelineGet(c.Request.Context(), authed, maxID, sinceID, minID, limit, local, languages)
 	if errWithCode != nil {
 		l.Debugf("error from processor PublicTimelineGet: %s", errWithCode)
-		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		api.ErrorHandler(c, errWithCode)
 		return
 	}
 
@@ -19,9 +19,13 @@
 package timeline
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 
+	"github.com/gin-gonic/gin"
 	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/processing"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
 )
@@ -43,6 +47,10 @@ const (
 	LimitKey = "limit"
 	// LocalKey is for specifying whether only local statuses should be returned
 	LocalKey = "local"
+	// LanguageKey is for specifying the languages of statuses that should be returned
+	LanguageKey = "language"
+	// IncludeUnknownLanguageKey is for specifying whether statuses with no known language should be returned when filtering by language
+	IncludeUnknownLanguageKey = "include_unknown_language"
 )
 
 // Module implements the ClientAPIModule interface for everything relating to viewing timelines
@@ -63,3 +71,34 @@ func (m *Module) Route(r router.Router) error {
 	r.AttachHandler(http.MethodGet, PublicTimeline, m.PublicTimelineGETHandler)
 	return nil
 }
+
+// parseLanguageFilter parses the language query params of the given request into a language filter.
+// If the request doesn't filter by language at all, the returned filter will be nil.
+func parseLanguageFilter(c *gin.Context) (*db.LanguageFilter, error) {
+	languages := []string{}
+	for _, l := range c.QueryArray(LanguageKey) {
+		if l != "" {
+			languages = append(languages, l)
+		}
+	}
+
+	includeUnknown := true
+	includeUnknownString := c.Query(IncludeUnknownLanguageKey)
+	if includeUnknownString != "" {
+		i, err := strconv.ParseBool(includeUnknownString)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing %s: %s", IncludeUnknownLanguageKey, err)
+		}
+		includeUnknown = i
+	}
+
+	if len(languages) == 0 && includeUnknown {
+		// nothing to filter
+		return nil, nil
+	}
+
+	return &db.LanguageFilter{
+		Languages:      languages,
+		IncludeUnknown: includeUnknown,
+	}, nil
+}
@@ -0,0 +1,116 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package trends_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/trends"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type TagsGetTestSuite struct {
+	TrendsStandardTestSuite
+}
+
+func (suite *TagsGetTestSuite) getTrendingTags(query string) (int, []byte) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Set(oauth.SessionAuthorizedAccount, suite.testAccounts["local_account_1"])
+	ctx.Set(oauth.SessionAuthorizedToken, oauth.DBTokenToToken(suite.testTokens["local_account_1"]))
+	ctx.Set(oauth.SessionAuthorizedApplication, suite.testApplications["application_1"])
+	ctx.Set(oauth.SessionAuthorizedUser, suite.testUsers["local_account_1"])
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080"+trends.TagsPath+query, nil)
+	ctx.Request.Header.Set("accept", "application/json")
+
+	suite.trendsModule.TrendingTagsGETHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+	return recorder.Code, b
+}
+
+func (suite *TagsGetTestSuite) TestGetTrendingTags() {
+	account := suite.testAccounts["local_account_2"]
+	tag := suite.testTags["Hashtag"]
+	statusID, err := id.NewULID()
+	suite.NoError(err)
+
+	suite.NoError(suite.db.PutStatus(context.Background(), &gtsmodel.Status{
+		ID:                  statusID,
+		URI:                 account.URI + "/statuses/" + statusID,
+		CreatedAt:           time.Now(),
+		UpdatedAt:           time.Now(),
+		Local:               true,
+		AccountID:           account.ID,
+		AccountURI:          account.URI,
+		TagIDs:              []string{tag.ID},
+		Visibility:          gtsmodel.VisibilityPublic,
+		ActivityStreamsType: ap.ObjectNote,
+	}))
+
+	code, b := suite.getTrendingTags("")
+	suite.Equal(http.StatusOK, code)
+
+	tags := []apimodel.Tag{}
+	suite.NoError(json.Unmarshal(b, &tags))
+	if !suite.Len(tags, 1) {
+		suite.FailNow("")
+	}
+	suite.Equal("Hashtag", tags[0].Name)
+	suite.Equal("http://localhost:8080/tags/Hashtag", tags[0].URL)
+
+	// a week of history, today first
+	if suite.Len(tags[0].History, 7) {
+		suite.Equal("1", tags[0].History[0].Uses)
+		suite.Equal("1", tags[0].History[0].Accounts)
+		suite.Equal("0", tags[0].History[6].Uses)
+	}
+}
+
+func (suite *TagsGetTestSuite) TestGetTrendingTagsNone() {
+	// all the test statuses are too old to be trending
+	code, b := suite.getTrendingTags("")
+	suite.Equal(http.StatusOK, code)
+	suite.Equal("[]", string(b))
+}
+
+func (suite *TagsGetTestSuite) TestGetTrendingTagsBadLimit() {
+	code, b := suite.getTrendingTags("?limit=-1")
+	suite.Equal(http.StatusBadRequest, code)
+	suite.Equal(`{"error":"couldn't parse limit query param"}`, string(b))
+}
+
+func TestTagsGetTestSuite(t *testing.T) {
+	suite.Run(t, &TagsGetTestSuite{})
+}
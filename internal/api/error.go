@@ -0,0 +1,79 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package api
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// ErrorResponse returns the response body for serving the given error to a client. Only the safe
+// parts of the error are included: its safe message, and its error code and details if it has them.
+func ErrorResponse(errWithCode gtserror.WithCode) apimodel.Error {
+	return apimodel.Error{
+		Error:   errWithCode.Safe(),
+		Code:    string(errWithCode.ErrorCode()),
+		Details: errWithCode.Details(),
+	}
+}
+
+// defaultRetryAfter is how long clients are told to wait before trying again after a 429 or 503,
+// if the error doesn't say how long that should be.
+const defaultRetryAfter = time.Minute
+
+// ErrorHandler serves the given error to the client, using the status code of the error and ErrorResponse
+// for the body. For errors which are only temporary (429 and 503), the Retry-After header is set too, so
+// that well-behaved clients back off for as long as the error says, or for a minute if it doesn't say.
+//
+// The underlying error, which isn't served to the client, is logged at debug level. Handlers should serve
+// all of their errors with this, rather than rendering them themselves, so that they're all consistent.
+func ErrorHandler(c *gin.Context, errWithCode gtserror.WithCode) {
+	logrus.WithFields(logrus.Fields{
+		"method": c.Request.Method,
+		"path":   c.Request.URL.Path,
+		"code":   errWithCode.Code(),
+	}).Debugf("serving error: %s", errWithCode.Error())
+
+	if code := errWithCode.Code(); code == http.StatusTooManyRequests || code == http.StatusServiceUnavailable {
+		retryAfter := errWithCode.RetryAfter()
+		if retryAfter <= 0 {
+			retryAfter = defaultRetryAfter
+		}
+		c.Header("Retry-After", FormatRetryAfter(retryAfter))
+	}
+
+	c.JSON(errWithCode.Code(), ErrorResponse(errWithCode))
+}
+
+// FormatRetryAfter formats the given duration as the value of a Retry-After header, which is a
+// whole number of seconds. It's rounded up, so that clients never try again too soon.
+func FormatRetryAfter(d time.Duration) string {
+	seconds := int64(math.Ceil(d.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return strconv.FormatInt(seconds, 10)
+}
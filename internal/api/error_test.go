@@ -0,0 +1,79 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package api_test
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+type ErrorTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ErrorTestSuite) handle(errWithCode gtserror.WithCode) *httptest.ResponseRecorder {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, "http://localhost:8080/api/v1/accounts", nil)
+	api.ErrorHandler(ctx, errWithCode)
+	return recorder
+}
+
+func (suite *ErrorTestSuite) TestRetryAfter() {
+	errWithCode := gtserror.NewErrorTooManyRequests(errors.New("slow down"), "slow down")
+	recorder := suite.handle(gtserror.WithRetryAfter(errWithCode, 90*time.Second+time.Millisecond))
+
+	suite.Equal(http.StatusTooManyRequests, recorder.Code)
+	suite.Equal("91", recorder.Header().Get("Retry-After"))
+	suite.Equal(`{"error":"too many requests: slow down","code":"rate_limited"}`, recorder.Body.String())
+}
+
+func (suite *ErrorTestSuite) TestRetryAfterDefault() {
+	recorder := suite.handle(gtserror.NewErrorServiceUnavailable(errors.New("read only")))
+
+	suite.Equal(http.StatusServiceUnavailable, recorder.Code)
+	suite.Equal("60", recorder.Header().Get("Retry-After"))
+}
+
+func (suite *ErrorTestSuite) TestNoRetryAfter() {
+	recorder := suite.handle(gtserror.NewErrorNotFound(errors.New("not found")))
+
+	suite.Equal(http.StatusNotFound, recorder.Code)
+	suite.Empty(recorder.Header().Get("Retry-After"))
+	suite.Equal(`{"error":"404 not found"}`, recorder.Body.String())
+}
+
+func (suite *ErrorTestSuite) TestFormatRetryAfter() {
+	suite.Equal("1", api.FormatRetryAfter(0))
+	suite.Equal("1", api.FormatRetryAfter(100*time.Millisecond))
+	suite.Equal("30", api.FormatRetryAfter(30*time.Second))
+	suite.Equal("3600", api.FormatRetryAfter(time.Hour))
+}
+
+func TestErrorTestSuite(t *testing.T) {
+	suite.Run(t, new(ErrorTestSuite))
+}
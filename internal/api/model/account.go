@@ -46,6 +46,11 @@ type Account struct {
 	Locked bool `json:"locked"`
 	// Account has opted into discovery features.
 	Discoverable bool `json:"discoverable,omitempty"`
+	// Account has opted out of having its statuses quoted by other accounts.
+	Unquotable bool `json:"unquotable,omitempty"`
+	// Statuses of this account are hidden from public view after this many days.
+	// Not set if the account doesn't override the setting of its instance.
+	ArchiveAfterDays int `json:"archive_after_days,omitempty"`
 	// Account identifies as a bot.
 	Bot bool `json:"bot"`
 	// When the account was created (ISO 8601 Datetime).
@@ -85,9 +90,14 @@ type Account struct {
 	Fields []Field `json:"fields"`
 	// Account has been suspended by our instance.
 	Suspended bool `json:"suspended,omitempty"`
+	// Account has been silenced by our instance, so its statuses are only shown to its followers.
+	Limited bool `json:"limited,omitempty"`
 	// If this account has been muted, when will the mute expire (ISO 8601 Datetime).
 	// example: 2021-07-30T09:20:25+00:00
 	MuteExpiresAt string `json:"mute_expires_at,omitempty"`
+	// Custom CSS which is included on this account's profile page, if enabled on this instance.
+	// Every selector is scoped to the profile, so it doesn't affect the rest of the page.
+	CustomCSS string `json:"custom_css,omitempty"`
 	// Extra profile information. Shown only if the requester owns the account being requested.
 	Source *Source `json:"source,omitempty"`
 }
@@ -147,6 +157,12 @@ type UpdateCredentialsRequest struct {
 	Header *multipart.FileHeader `form:"header" json:"header" xml:"header"`
 	// Require manual approval of follow requests.
 	Locked *bool `form:"locked" json:"locked" xml:"locked"`
+	// Don't allow other accounts to quote statuses authored by this account.
+	Unquotable *bool `form:"unquotable" json:"unquotable" xml:"unquotable"`
+	// Hide statuses authored by this account from public view after this many days. 0 to use the setting of the instance.
+	ArchiveAfterDays *int `form:"archive_after_days" json:"archive_after_days" xml:"archive_after_days"`
+	// Custom CSS to include on the account's profile page, if enabled on this instance.
+	CustomCSS *string `form:"custom_css" json:"custom_css" xml:"custom_css"`
 	// New Source values for this account.
 	Source *UpdateSource `form:"source" json:"source" xml:"source"`
 	// Profile metadata name and value
@@ -163,6 +179,8 @@ type UpdateSource struct {
 	Sensitive *bool `form:"sensitive" json:"sensitive" xml:"sensitive"`
 	// Default language to use for authored statuses. (ISO 6391)
 	Language *string `form:"language" json:"language" xml:"language"`
+	// Delete authored statuses automatically once they're this many days old. 0 to never delete them.
+	AutoDeleteAfterDays *int `form:"auto_delete_after_days" json:"auto_delete_after_days" xml:"auto_delete_after_days"`
 }
 
 // UpdateField is to be used specifically in an UpdateCredentialsRequest.
@@ -0,0 +1,42 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// AccountArchive represents an archive of all of an account's data,
+// including statuses, media, follows, blocks, and profile information.
+//
+// swagger:model accountArchive
+type AccountArchive struct {
+	// The ID of the archive.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// Whether the archive is still being generated (pending), is ready to download (ready), or couldn't be generated (failed).
+	// example: ready
+	Status string `json:"status"`
+	// When the archive was requested (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Size of the archive in bytes. Only set once the archive is ready.
+	// example: 1048576
+	Size int `json:"size,omitempty"`
+	// Link from which the archive zip can be downloaded, without authorization. Only set once the archive is ready.
+	// The link is only valid for a short time, so it should be fetched again if it's needed later.
+	// example: https://example.org/api/v1/accounts/archive/01FBVD42CQ3ZEEVMW180SBX03B/download?expires=1627640425&signature=f00ba4
+	URL string `json:"url,omitempty"`
+}
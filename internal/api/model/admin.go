@@ -84,10 +84,106 @@ type AdminReportInfo struct {
 //
 // swagger:ignore
 type AdminAccountActionRequest struct {
-	// Type of the account action. One of disable, silence, suspend.
+	// Type of the account action. One of disable, silence, unsilence, sensitive, unsensitive, unlock, suspend.
 	Type string `form:"type" json:"type" xml:"type"`
 	// Text describing why an action was taken.
 	Text string `form:"text" json:"text" xml:"text"`
 	// ID of the account to be acted on.
 	TargetAccountID string `form:"-" json:"-" xml:"-"`
 }
+
+// AdminRelationshipSeveranceRequest is the form submitted as a POST to /api/v1/admin/relationship_severances,
+// to remove all follows, follow requests, and blocks involving some accounts.
+//
+// swagger:ignore
+type AdminRelationshipSeveranceRequest struct {
+	// IDs of the accounts whose relationships should be severed.
+	AccountIDs []string `form:"account_ids[]" json:"account_ids" xml:"account_ids"`
+	// Domain whose accounts should have their relationships severed.
+	Domain string `form:"domain" json:"domain" xml:"domain"`
+}
+
+// AdminRelationshipSeverance represents the progress of severing all relationships with a set of accounts.
+//
+// swagger:model adminRelationshipSeverance
+type AdminRelationshipSeverance struct {
+	// The ID of the relationship severance.
+	// example: 01FBW21XJA09XYX51KV5JVBW0F
+	ID string `json:"id"`
+	// IDs of the accounts whose relationships were requested to be severed, if accounts were given.
+	AccountIDs []string `json:"account_ids,omitempty"`
+	// Domain whose accounts' relationships were requested to be severed, if a domain was given.
+	// example: example.org
+	Domain string `json:"domain,omitempty"`
+	// Current state of the severance. One of: pending, running, finished.
+	// example: running
+	State string `json:"state"`
+	// Number of accounts to process. Only known once the severance is running.
+	AccountsTotal int `json:"accounts_total"`
+	// Number of accounts processed so far.
+	AccountsProcessed int `json:"accounts_processed"`
+	// Number of follows, follow requests and blocks removed so far.
+	RelationshipsRemoved int `json:"relationships_removed"`
+	// Number of errors encountered so far. Details are logged.
+	Errors int `json:"errors"`
+	// Time at which this severance was requested (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// Time at which this severance finished (ISO 8601 Datetime), if it has.
+	// example: 2021-07-30T09:25:25+00:00
+	FinishedAt string `json:"finished_at,omitempty"`
+}
+
+// AdminRegistrationsRequest is the form submitted as a POST to /api/v1/admin/registrations,
+// to open or close registrations on this instance at runtime.
+//
+// swagger:ignore
+type AdminRegistrationsRequest struct {
+	// Whether new accounts should be able to sign up.
+	Open *bool `form:"open" json:"open" xml:"open"`
+}
+
+// AdminRegistrations represents whether registrations are open on this instance.
+//
+// swagger:model adminRegistrations
+type AdminRegistrations struct {
+	// Whether new accounts can currently sign up.
+	// example: false
+	Open bool `json:"open"`
+}
+
+// AdminMediaQueue represents the current state of the media processing queue of this instance.
+//
+// swagger:model adminMediaQueue
+type AdminMediaQueue struct {
+	// Number of media items currently waiting to be processed.
+	// example: 3
+	Depth int `json:"depth"`
+	// Maximum number of media items which may be waiting to be processed. Once the queue
+	// is this deep, further uploads are rejected with 503 Service Unavailable until it drains.
+	// example: 40
+	Size int `json:"size"`
+}
+
+// AdminReadOnlyRequest is the form submitted as a POST to /api/v1/admin/read_only,
+// to switch read-only (maintenance) mode on or off at runtime.
+//
+// swagger:ignore
+type AdminReadOnlyRequest struct {
+	// Whether the instance should be in read-only mode.
+	ReadOnly *bool `form:"read_only" json:"read_only" xml:"read_only"`
+	// Message to show to users while the instance is in read-only mode.
+	Message string `form:"message" json:"message" xml:"message"`
+}
+
+// AdminReadOnly represents whether this instance is in read-only (maintenance) mode.
+//
+// swagger:model adminReadOnly
+type AdminReadOnly struct {
+	// Whether the instance is currently in read-only mode, rejecting writes like new statuses, follows, and media uploads.
+	// example: true
+	ReadOnly bool `json:"read_only"`
+	// Message shown to users while the instance is in read-only mode.
+	// example: We're upgrading the database, back in half an hour!
+	Message string `json:"message"`
+}
@@ -0,0 +1,65 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import "mime/multipart"
+
+// BlockImport represents a list of accounts to block which was imported by an account,
+// and what happened when blocking each of them.
+//
+// swagger:model blockImport
+type BlockImport struct {
+	// The ID of the import.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// Whether some of the accounts are still waiting to be blocked (pending), or all of them have been processed (done).
+	// example: pending
+	Status string `json:"status"`
+	// When the list was imported (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// The lines of the imported list, and what happened to each of them.
+	Lines []BlockImportLine `json:"lines"`
+}
+
+// BlockImportLine represents one line of an imported list of accounts to block.
+//
+// swagger:model blockImportLine
+type BlockImportLine struct {
+	// Line number in the imported file, starting from 1.
+	// example: 2
+	Line int `json:"line"`
+	// Handle of the account to block.
+	// example: someone@example.org
+	Handle string `json:"handle"`
+	// What happened when blocking the account: pending, blocked, skipped (already blocked, or yourself), or failed.
+	// example: blocked
+	Result string `json:"result"`
+	// Why the account couldn't be blocked, if the result is failed.
+	// example: account not found
+	Error string `json:"error,omitempty"`
+}
+
+// BlockImportCreateRequest models a request to import a list of accounts to block.
+//
+// swagger:ignore
+type BlockImportCreateRequest struct {
+	// CSV file of accounts to block, in the format of a Mastodon blocks export.
+	Data *multipart.FileHeader `form:"data" json:"data" xml:"data"`
+}
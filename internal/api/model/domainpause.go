@@ -0,0 +1,51 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// DomainPause represents a temporary pause of federation with one domain.
+//
+// swagger:model domainPause
+type DomainPause struct {
+	// The ID of the domain pause.
+	// example: 01G5BHQ8ZE3MRVD4PKV1D8QW0R
+	// readonly: true
+	ID string `json:"id"`
+	// The hostname of the paused domain.
+	// example: example.org
+	Domain string `json:"domain"`
+	// Private comment for this pause, visible to our instance admins only.
+	// example: flooding us with spam, paused until they've sorted it out
+	PrivateComment string `json:"private_comment,omitempty"`
+	// ID of the account that created this domain pause.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	CreatedBy string `json:"created_by"`
+	// Time at which this pause was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+}
+
+// DomainPauseCreateRequest is the form submitted as a POST to /api/v1/admin/domain_pauses to pause federation with a domain.
+//
+// swagger:ignore
+type DomainPauseCreateRequest struct {
+	// hostname/domain to pause federation with
+	Domain string `form:"domain" json:"domain" xml:"domain"`
+	// private comment for other admins on why federation with the domain was paused
+	PrivateComment string `form:"private_comment" json:"private_comment" xml:"private_comment"`
+}
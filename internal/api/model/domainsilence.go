@@ -0,0 +1,51 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// DomainSilence represents a limit on federation with one domain, keeping its statuses out of public timelines.
+//
+// swagger:model domainSilence
+type DomainSilence struct {
+	// The ID of the domain silence.
+	// example: 01G5MAY3YV6AW3XJ5J1B0S7T2M
+	// readonly: true
+	ID string `json:"id"`
+	// The hostname of the silenced domain.
+	// example: example.org
+	Domain string `json:"domain"`
+	// Private comment for this silence, visible to our instance admins only.
+	// example: lots of unmoderated spam in the federated timeline
+	PrivateComment string `json:"private_comment,omitempty"`
+	// ID of the account that created this domain silence.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	CreatedBy string `json:"created_by"`
+	// Time at which this silence was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+}
+
+// DomainSilenceCreateRequest is the form submitted as a POST to /api/v1/admin/domain_silences to silence a domain.
+//
+// swagger:ignore
+type DomainSilenceCreateRequest struct {
+	// hostname/domain to silence
+	Domain string `form:"domain" json:"domain" xml:"domain"`
+	// private comment for other admins on why the domain was silenced
+	PrivateComment string `form:"private_comment" json:"private_comment" xml:"private_comment"`
+}
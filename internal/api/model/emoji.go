@@ -41,6 +41,22 @@ type Emoji struct {
 	Category string `json:"category,omitempty"`
 }
 
+// AdminEmoji models the admin view of a custom emoji.
+//
+// swagger:model adminEmoji
+type AdminEmoji struct {
+	*Emoji
+	// The ID of the emoji.
+	// example: 01GEM7SFDZ7GZNRXFVZ3X4E4N1
+	ID string `json:"id"`
+	// The emoji has been disabled, and isn't shown on this instance.
+	// example: false
+	Disabled bool `json:"disabled"`
+	// When the emoji was last updated (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	UpdatedAt string `json:"updated_at"`
+}
+
 // EmojiCreateRequest represents a request to create a custom emoji made through the admin API.
 //
 // swagger:model emojiCreateRequest
@@ -50,4 +66,24 @@ type EmojiCreateRequest struct {
 	Shortcode string `form:"shortcode" validation:"required"`
 	// Image file to use for the emoji. Must be png or gif and no larger than 50kb.
 	Image *multipart.FileHeader `form:"image" validation:"required"`
+	// Category in which to place the emoji in emoji pickers. Leave empty for no category.
+	// example: blobcats
+	Category string `form:"category"`
+}
+
+// EmojiUpdateRequest represents a request to update a custom emoji made through the admin API.
+//
+// swagger:model emojiUpdateRequest
+type EmojiUpdateRequest struct {
+	// New shortcode for the emoji, without surrounding colons. This must be unique for the domain.
+	// example: blobcat_owo
+	Shortcode *string `form:"shortcode" json:"shortcode" xml:"shortcode"`
+	// Category in which to place the emoji in emoji pickers. Set to an empty string to remove the emoji from its category.
+	// example: blobcats
+	Category *string `form:"category" json:"category" xml:"category"`
+	// Disable the emoji, so that it isn't shown on this instance, or enable it again.
+	// example: false
+	Disabled *bool `form:"disabled" json:"disabled" xml:"disabled"`
+	// New image file to use for the emoji. Must be png or gif and no larger than 50kb.
+	Image *multipart.FileHeader `form:"image" json:"-" xml:"-"`
 }
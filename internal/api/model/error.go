@@ -0,0 +1,34 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// Error represents an error message returned from the API.
+//
+// swagger:model error
+type Error struct {
+	// Human-readable description of the error.
+	// example: not found
+	Error string `json:"error"`
+	// Machine-readable code for the kind of error, so that clients can tell errors apart. Only set for some errors.
+	// Codes are stable, and will never change once defined.
+	// example: duplicate_status
+	Code string `json:"code,omitempty"`
+	// Further details about the error, such as a limit which was exceeded. Only set for some errors.
+	Details map[string]interface{} `json:"details,omitempty"`
+}
@@ -0,0 +1,65 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+import "mime/multipart"
+
+// FollowImport represents a list of accounts to follow which was imported by an account,
+// and what happened when following each of them.
+//
+// swagger:model followImport
+type FollowImport struct {
+	// The ID of the import.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// Whether some of the accounts are still waiting to be followed (pending), or all of them have been processed (done).
+	// example: pending
+	Status string `json:"status"`
+	// When the list was imported (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+	// The lines of the imported list, and what happened to each of them.
+	Lines []FollowImportLine `json:"lines"`
+}
+
+// FollowImportLine represents one line of an imported list of accounts to follow.
+//
+// swagger:model followImportLine
+type FollowImportLine struct {
+	// Line number in the imported file, starting from 1.
+	// example: 2
+	Line int `json:"line"`
+	// Handle of the account to follow.
+	// example: someone@example.org
+	Handle string `json:"handle"`
+	// What happened when following the account: pending, followed, skipped (already followed, or yourself), or failed.
+	// example: followed
+	Result string `json:"result"`
+	// Why the account couldn't be followed, if the result is failed.
+	// example: account not found
+	Error string `json:"error,omitempty"`
+}
+
+// FollowImportCreateRequest models a request to import a list of accounts to follow.
+//
+// swagger:ignore
+type FollowImportCreateRequest struct {
+	// CSV file of accounts to follow, in the format of a Mastodon follows export.
+	Data *multipart.FileHeader `form:"data" json:"data" xml:"data"`
+}
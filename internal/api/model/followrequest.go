@@ -0,0 +1,75 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// FollowRequestAutoAccept represents the rules by which follow requests to a locked account are accepted automatically.
+// Follow requests that don't match any rule are held for manual approval.
+//
+// swagger:model followRequestAutoAccept
+type FollowRequestAutoAccept struct {
+	// Follow requests from accounts that you already follow are accepted automatically.
+	// example: true
+	Followed bool `json:"followed"`
+	// Follow requests from accounts on these domains, or any of their subdomains, are accepted automatically.
+	// example: ["example.org","gts.example.org"]
+	Domains []string `json:"domains"`
+}
+
+// FollowRequestAutoAcceptUpdateRequest is the form submitted as a PATCH to /api/v1/follow_requests/auto_accept,
+// to change the follow request auto-accept rules of the requesting account. Rules that aren't set are left as they were.
+//
+// swagger:ignore
+type FollowRequestAutoAcceptUpdateRequest struct {
+	// Whether to accept follow requests from accounts that you already follow automatically.
+	Followed *bool `form:"followed" json:"followed" xml:"followed"`
+	// Domains to accept follow requests from automatically. This replaces the current list.
+	Domains *[]string `form:"domains[]" json:"domains" xml:"domains"`
+}
+
+// FollowRequestBulkActionAccept and FollowRequestBulkActionReject are the actions that can be taken on follow requests in bulk.
+const (
+	FollowRequestBulkActionAccept = "accept"
+	FollowRequestBulkActionReject = "reject"
+)
+
+// FollowRequestBulkRequest is the form submitted as a POST to /api/v1/follow_requests/bulk,
+// to accept or reject several follow requests at once.
+//
+// swagger:ignore
+type FollowRequestBulkRequest struct {
+	// What to do with the follow requests: accept or reject.
+	Action string `form:"action" json:"action" xml:"action"`
+	// IDs of the accounts whose follow requests should be accepted or rejected.
+	AccountIDs []string `form:"account_ids[]" json:"account_ids" xml:"account_ids"`
+}
+
+// FollowRequestBulkResult represents the outcome of accepting or rejecting one follow request as part of a bulk request.
+//
+// swagger:model followRequestBulkResult
+type FollowRequestBulkResult struct {
+	// ID of the account that requested to follow you.
+	// example: 01FBW9XGEP7G6K88VY4S9MPE1R
+	AccountID string `json:"account_id"`
+	// The follow request was accepted or rejected successfully.
+	// example: true
+	Success bool `json:"success"`
+	// Why the follow request couldn't be accepted or rejected. Only set if success is false.
+	// example: 404 not found
+	Error string `json:"error,omitempty"`
+}
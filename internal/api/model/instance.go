@@ -53,13 +53,18 @@ type Instance struct {
 	//
 	// example: 0.1.1 cb85f65
 	Version string `json:"version"`
-	// Primary language of the instance.
-	// example: en
+	// Primary languages of the instance, most important first.
+	// example: ["en"]
 	Languages []string `json:"languages,omitempty"`
 	// New account registrations are enabled on this instance.
 	Registrations bool `json:"registrations"`
 	// New account registrations require admin approval.
 	ApprovalRequired bool `json:"approval_required"`
+	// The instance is in read-only (maintenance) mode, so writes like new statuses, follows, and media uploads will be rejected.
+	ReadOnly bool `json:"read_only"`
+	// Message explaining why the instance is in read-only mode. Only set if read_only is true.
+	// example: We're upgrading the database, back in half an hour!
+	ReadOnlyMessage string `json:"read_only_message,omitempty"`
 	// Invites are enabled on this instance.
 	InvitesEnabled bool `json:"invites_enabled"`
 	// URLs of interest for client applications.
@@ -77,6 +82,34 @@ type Instance struct {
 	//
 	// example: 5000
 	MaxTootChars uint `json:"max_toot_chars"`
+	// Maximum width or height in pixels of image thumbnails generated by this instance.
+	//
+	// Thumbnails preserve the aspect ratio of the original image, and are never larger than the original.
+	//
+	// example: 512
+	ThumbnailMaxDimension uint `json:"thumbnail_max_dimension,omitempty"`
+	// Maximum size in bytes of custom emoji images accepted by this instance.
+	// example: 51200
+	EmojiMaxSize uint `json:"emoji_max_size,omitempty"`
+	// Maximum width or height in pixels of custom emoji images on this instance.
+	//
+	// Larger emojis are scaled down to fit, preserving their aspect ratio and animation.
+	//
+	// example: 128
+	EmojiMaxDimension uint `json:"emoji_max_dimension,omitempty"`
+	// Maximum amount of profile fields an account on this instance can have.
+	// example: 4
+	MaxProfileFields uint `json:"max_profile_fields,omitempty"`
+	// Maximum allowed length of the name of a profile field on this instance, in characters.
+	// example: 255
+	ProfileFieldNameMaxChars uint `json:"profile_field_name_max_chars,omitempty"`
+	// Maximum allowed length of the value of a profile field on this instance, in characters.
+	// example: 255
+	ProfileFieldValueMaxChars uint `json:"profile_field_value_max_chars,omitempty"`
+	// Minimum age of an account on this instance, in seconds, before it can follow other accounts, post publicly,
+	// or mention accounts that didn't initiate contact with it. Not set if there's no minimum.
+	// example: 86400
+	MinAccountAge uint `json:"min_account_age,omitempty"`
 }
 
 // InstanceURLs models instance-relevant URLs for client application consumption.
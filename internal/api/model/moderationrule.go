@@ -0,0 +1,80 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// ModerationRule represents an instance-wide rule for automatically moderating statuses which contain
+// certain words, or match a regular expression.
+//
+// swagger:model moderationRule
+type ModerationRule struct {
+	// The ID of the moderation rule.
+	// example: 01G5Q2D4XJ0DVB0BXF6Z7QKQ3R
+	// readonly: true
+	ID string `json:"id"`
+	// Word, phrase or regular expression that statuses are matched against, ignoring case.
+	// example: buy cheap sunglasses
+	Pattern string `json:"pattern"`
+	// Whether the pattern is a regular expression, rather than a word or phrase.
+	Regex bool `json:"regex"`
+	// What happens to matching statuses posted on this instance. One of: hold, reject.
+	// example: hold
+	LocalAction string `json:"local_action"`
+	// What happens to matching statuses received from other instances. One of: flag, drop.
+	// example: drop
+	RemoteAction string `json:"remote_action"`
+	// Private comment for this rule, visible to our instance admins only.
+	// example: sunglasses spam wave
+	PrivateComment string `json:"private_comment,omitempty"`
+	// ID of the account that created this moderation rule.
+	// example: 01FBW2758ZB6PBR200YPDDJK4C
+	CreatedBy string `json:"created_by"`
+	// Time at which this rule was created (ISO 8601 Datetime).
+	// example: 2021-07-30T09:20:25+00:00
+	CreatedAt string `json:"created_at"`
+}
+
+// ModerationRuleCreateRequest is the form submitted as a POST to /api/v1/admin/moderation_rules to create a moderation rule.
+//
+// swagger:ignore
+type ModerationRuleCreateRequest struct {
+	// word, phrase or regular expression to match statuses against
+	Pattern string `form:"pattern" json:"pattern" xml:"pattern"`
+	// whether the pattern is a regular expression
+	Regex bool `form:"regex" json:"regex" xml:"regex"`
+	// what to do with matching local statuses, hold or reject; defaults to hold
+	LocalAction string `form:"local_action" json:"local_action" xml:"local_action"`
+	// what to do with matching remote statuses, flag or drop; defaults to flag
+	RemoteAction string `form:"remote_action" json:"remote_action" xml:"remote_action"`
+	// private comment for other admins on why the rule was created
+	PrivateComment string `form:"private_comment" json:"private_comment" xml:"private_comment"`
+}
+
+// AdminModeratedStatus is a status which matched a moderation rule, and is waiting for an admin to review it.
+//
+// swagger:model adminModeratedStatus
+type AdminModeratedStatus struct {
+	// The status which matched the moderation rule.
+	Status *Status `json:"status"`
+	// ID of the moderation rule that the status matched.
+	// example: 01G5Q2D4XJ0DVB0BXF6Z7QKQ3R
+	ModerationRuleID string `json:"moderation_rule_id"`
+	// Whether the status is held, and so visible only to its author until it's approved.
+	// Statuses from other instances are never held, only flagged.
+	Held bool `json:"held"`
+}
@@ -0,0 +1,69 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package model
+
+// OEmbed represents an oEmbed response for a status, which other sites can use to embed it.
+//
+// See https://oembed.com/
+//
+// swagger:model oEmbed
+type OEmbed struct {
+	// The oEmbed resource type. Always rich.
+	// example: rich
+	Type string `json:"type"`
+	// The oEmbed version number. Always 1.0.
+	// example: 1.0
+	Version string `json:"version"`
+	// A text title describing the resource.
+	Title string `json:"title,omitempty"`
+	// The name of the author of the status.
+	// example: big jeff (he/him)
+	AuthorName string `json:"author_name"`
+	// A URL for the author of the status.
+	// example: https://example.org/@some_user
+	AuthorURL string `json:"author_url"`
+	// The name of the instance serving the embed.
+	// example: GoToSocial Example Instance
+	ProviderName string `json:"provider_name"`
+	// The URL of the instance serving the embed.
+	// example: https://example.org/
+	ProviderURL string `json:"provider_url"`
+	// The suggested cache lifetime for this resource, in seconds.
+	CacheAge int `json:"cache_age"`
+	// The HTML required to display the embed, which is an iframe of the status.
+	HTML string `json:"html"`
+	// The width in pixels required to display the embed.
+	Width int `json:"width"`
+	// The height in pixels required to display the embed.
+	Height int `json:"height"`
+}
+
+// OEmbedRequest models a request for an oEmbed of a status.
+//
+// swagger:ignore
+type OEmbedRequest struct {
+	// URL of the status to embed.
+	URL string `form:"url" binding:"required"`
+	// Maximum width of the embed, in pixels.
+	MaxWidth int `form:"maxwidth"`
+	// Maximum height of the embed, in pixels.
+	MaxHeight int `form:"maxheight"`
+	// Requested response format. Only json is supported.
+	Format string `form:"format"`
+}
@@ -31,6 +31,8 @@ type Source struct {
 	Sensitive bool `json:"sensitive,omitempty"`
 	// The default posting language for new statuses.
 	Language string `json:"language,omitempty"`
+	// Statuses are deleted automatically once they're this many days old, unless they're pinned, bookmarked or faved.
+	AutoDeleteAfterDays int `json:"auto_delete_after_days,omitempty"`
 	// Profile bio.
 	Note string `json:"note"`
 	// Metadata about the account.
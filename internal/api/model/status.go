@@ -43,6 +43,9 @@ type Status struct {
 	// Visibility of this status.
 	// example: unlisted
 	Visibility Visibility `json:"visibility"`
+	// Who may reply to this status, apart from its author.
+	// example: everyone
+	ReplyPolicy ReplyPolicy `json:"reply_policy"`
 	// Primary language of this status (ISO 639 Part 1 two-letter language code).
 	// example: en
 	Language string `json:"language"`
@@ -68,12 +71,20 @@ type Status struct {
 	Bookmarked bool `json:"bookmarked"`
 	// This status has been pinned by the account viewing it (only relevant for your own statuses).
 	Pinned bool `json:"pinned,omitempty"`
+	// This status is held for review by an admin, and isn't visible to anyone but its author yet.
+	PendingReview bool `json:"pending_review,omitempty"`
 	// The content of this status. Should be HTML, but might also be plaintext in some cases.
 	// example: <p>Hey this is a status!</p>
 	Content string `json:"content"`
 	// The status that this status reblogs/boosts.
 	// nullable: true
 	Reblog *StatusReblogged `json:"reblog,omitempty"`
+	// ID of the status being quoted.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	QuoteID string `json:"quote_id,omitempty"`
+	// The status that this status quotes. Only set if the quoted status is public or unlisted.
+	// nullable: true
+	Quote *StatusQuoted `json:"quote,omitempty"`
 	// The application used to post this status, if visible.
 	Application *Application `json:"application"`
 	// The account that authored this status.
@@ -90,6 +101,8 @@ type Status struct {
 	Card *Card `json:"card"`
 	// The poll attached to the status.
 	Poll *Poll `json:"poll"`
+	// Emoji reactions to this status, in the order they were first added.
+	Reactions []StatusReaction `json:"reactions,omitempty"`
 	// Plain-text source of a status. Returned instead of content when status is deleted,
 	// so the user may redraft from the source text without the client having to reverse-engineer
 	// the original text from the HTML content.
@@ -126,6 +139,53 @@ func (s *Status) GetBoostOfAccountID() string {
 	return ""
 }
 
+// StatusSource represents the source text of a status, as it was originally written, for editing.
+//
+// swagger:model statusSource
+type StatusSource struct {
+	// ID of the status.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// Plain text source of the status.
+	// example: this is a status!
+	Text string `json:"text"`
+	// Plain text version of the subject, summary, or content warning of the status.
+	// example: who's a good boy?
+	SpoilerText string `json:"spoiler_text"`
+	// The status was created before its source text was stored, so the text has
+	// been reconstructed from the formatted content, and may differ a bit from the original.
+	// example: false
+	Reconstructed bool `json:"reconstructed"`
+}
+
+// StatusRedraft represents the source of a status which has been deleted so that it can be drafted again.
+//
+// swagger:model statusRedraft
+type StatusRedraft struct {
+	// ID of the deleted status.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	ID string `json:"id"`
+	// Plain text source of the status.
+	// example: this is a status!
+	Text string `json:"text"`
+	// Plain text version of the subject, summary, or content warning of the status.
+	// example: who's a good boy?
+	SpoilerText string `json:"spoiler_text"`
+	// The status was created before its source text was stored, so the text has
+	// been reconstructed from the formatted content, and may differ a bit from the original.
+	// example: false
+	Reconstructed bool `json:"reconstructed"`
+	// IDs of the media that was attached to the status. The media has been detached
+	// from the deleted status rather than deleted, so it can be attached to a new one.
+	MediaIDs []string `json:"media_ids"`
+	// Visibility of the status.
+	// example: unlisted
+	Visibility Visibility `json:"visibility"`
+	// ISO 639 language code of the status.
+	// example: en
+	Language string `json:"language,omitempty"`
+}
+
 // StatusReblogged represents a reblogged status.
 //
 // swagger:model statusReblogged
@@ -133,6 +193,13 @@ type StatusReblogged struct {
 	*Status
 }
 
+// StatusQuoted represents a quoted status.
+//
+// swagger:model statusQuoted
+type StatusQuoted struct {
+	*Status
+}
+
 // StatusCreateRequest models status creation parameters.
 //
 // swagger:parameters statusCreate
@@ -152,13 +219,20 @@ type StatusCreateRequest struct {
 	// ID of the status being replied to, if status is a reply.
 	// in: formData
 	InReplyToID string `form:"in_reply_to_id" json:"in_reply_to_id" xml:"in_reply_to_id"`
+	// ID of the status being quoted, if status is a quote.
+	// in: formData
+	QuoteID string `form:"quote_id" json:"quote_id" xml:"quote_id"`
 	// Status and attached media should be marked as sensitive.
+	// If not provided, the sensitivity of the status being replied to is used (if the instance is configured to do so),
+	// or otherwise the default sensitivity of the account.
 	// in: formData
-	Sensitive bool `form:"sensitive" json:"sensitive" xml:"sensitive"`
+	Sensitive *bool `form:"sensitive" json:"sensitive" xml:"sensitive"`
 	// Text to be shown as a warning or subject before the actual content.
 	// Statuses are generally collapsed behind this field.
+	// If not provided, the content warning of the status being replied to is used (if the instance is configured to do so).
+	// Provide an empty string to post a reply without a content warning.
 	// in: formData
-	SpoilerText string `form:"spoiler_text" json:"spoiler_text" xml:"spoiler_text"`
+	SpoilerText *string `form:"spoiler_text" json:"spoiler_text" xml:"spoiler_text"`
 	// Visibility of the posted status.
 	// enum:
 	// - public
@@ -167,6 +241,14 @@ type StatusCreateRequest struct {
 	// - direct
 	// in: formData
 	Visibility Visibility `form:"visibility" json:"visibility" xml:"visibility"`
+	// Who may reply to the posted status, apart from its author. Defaults to everyone.
+	// enum:
+	// - everyone
+	// - followers
+	// - mentioned
+	// - nobody
+	// in: formData
+	ReplyPolicy ReplyPolicy `form:"reply_policy" json:"reply_policy" xml:"reply_policy"`
 	// ISO 8601 Datetime at which to schedule a status.
 	// Providing this paramter will cause ScheduledStatus to be returned instead of Status.
 	// Must be at least 5 minutes in the future.
@@ -181,6 +263,14 @@ type StatusCreateRequest struct {
 	// - plain
 	// in: formData
 	Format StatusFormat `form:"format" json:"format" xml:"format"`
+	// Post the status even if it's a duplicate of your previous status.
+	// Only relevant if the instance is configured to reject duplicate statuses.
+	// in: formData
+	AllowDuplicate bool `form:"allow_duplicate" json:"allow_duplicate" xml:"allow_duplicate"`
+	// Don't give the status a content warning automatically, even if it has media but no text, or lots of links.
+	// Only relevant if the instance is configured to add content warnings automatically.
+	// in: formData
+	SkipAutoCW bool `form:"skip_auto_cw" json:"skip_auto_cw" xml:"skip_auto_cw"`
 }
 
 // Visibility models the visibility of a status.
@@ -207,6 +297,27 @@ const (
 	VisibilityDirect Visibility = "direct"
 )
 
+// ReplyPolicy models who may reply to a status. The author of a status can always reply to it.
+//
+// swagger:model statusReplyPolicy
+// enum:
+// - everyone
+// - followers
+// - mentioned
+// - nobody
+type ReplyPolicy string
+
+const (
+	// ReplyPolicyEveryone permits anyone who can see the status to reply to it.
+	ReplyPolicyEveryone ReplyPolicy = "everyone"
+	// ReplyPolicyFollowers permits only followers of the author to reply to the status.
+	ReplyPolicyFollowers ReplyPolicy = "followers"
+	// ReplyPolicyMentioned permits only accounts mentioned in the status to reply to it.
+	ReplyPolicyMentioned ReplyPolicy = "mentioned"
+	// ReplyPolicyNobody permits nobody but the author to reply to the status.
+	ReplyPolicyNobody ReplyPolicy = "nobody"
+)
+
 // AdvancedStatusCreateForm wraps the mastodon-compatible status create form along with the GTS advanced
 // visibility settings.
 //
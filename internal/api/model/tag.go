@@ -28,4 +28,25 @@ type Tag struct {
 	// Web link to the hashtag.
 	// example: https://example.org/tags/helloworld
 	URL string `json:"url"`
+	// Usage statistics of the hashtag on recent days, most recent day first.
+	// Only included for trending hashtags.
+	History []TagHistory `json:"history,omitempty"`
+	// Whether the requesting account follows the hashtag.
+	// Only included when looking up or following hashtags.
+	Following *bool `json:"following,omitempty"`
+}
+
+// TagHistory represents the usage of a hashtag on one day.
+//
+// swagger:model tagHistory
+type TagHistory struct {
+	// UNIX timestamp of midnight (UTC) at the start of the day.
+	// example: 1654560000
+	Day string `json:"day"`
+	// Number of statuses which used the hashtag on this day.
+	// example: 12
+	Uses string `json:"uses"`
+	// Number of distinct accounts which used the hashtag on this day.
+	// example: 4
+	Accounts string `json:"accounts"`
 }
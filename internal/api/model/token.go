@@ -33,4 +33,42 @@ type Token struct {
 	// When the OAuth token was generated (UNIX timestamp seconds).
 	// example: 1627644520
 	CreatedAt int64 `json:"created_at"`
+	// How long until the access token expires (seconds). Not set if the access token never expires.
+	// example: 3600
+	ExpiresIn int64 `json:"expires_in,omitempty"`
+	// Refresh token which can be exchanged for a new access token and refresh token once the access token expires.
+	// Not set if the access token never expires.
+	RefreshToken string `json:"refresh_token,omitempty"`
+}
+
+// TokenIntrospection represents the result of introspecting an OAuth token, as described in RFC 7662.
+//
+// If the token is not active, only Active will be set.
+//
+// swagger:model tokenIntrospection
+type TokenIntrospection struct {
+	// Whether or not the token is currently active, ie., it exists, has not expired, and can be used.
+	// example: true
+	Active bool `json:"active"`
+	// OAuth scopes granted by this token, space-separated.
+	// example: read write
+	Scope string `json:"scope,omitempty"`
+	// Client ID of the application that this token was issued to.
+	// example: 01F8MH75CBF9JFX4ZAD54N0W0R
+	ClientID string `json:"client_id,omitempty"`
+	// Username of the account that this token belongs to, if it's a user-level token.
+	// example: some_user
+	Username string `json:"username,omitempty"`
+	// ID of the account that this token belongs to, if it's a user-level token.
+	// example: 01FBVD42CQ3ZEEVMW180SBX03B
+	Subject string `json:"sub,omitempty"`
+	// OAuth token type. Will always be 'Bearer'.
+	// example: Bearer
+	TokenType string `json:"token_type,omitempty"`
+	// When the token was issued (UNIX timestamp seconds).
+	// example: 1627644520
+	IssuedAt int64 `json:"iat,omitempty"`
+	// When the token will expire (UNIX timestamp seconds). Not set if the token never expires.
+	// example: 1627730920
+	ExpiresAt int64 `json:"exp,omitempty"`
 }
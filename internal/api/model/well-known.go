@@ -82,5 +82,15 @@ type NodeInfoUsage struct {
 	Users NodeInfoUsers `json:"users"`
 }
 
-// NodeInfoUsers is a stub for usage information, currently empty.
-type NodeInfoUsers struct{}
+// NodeInfoUsers represents how many users this server has, and how many of them are active.
+type NodeInfoUsers struct {
+	// Number of users on this server.
+	// example: 1234
+	Total int `json:"total"`
+	// Number of users who have been active (posted, signed in or used the client API) in the last 30 days.
+	// example: 321
+	ActiveMonth int `json:"activeMonth"`
+	// Number of users who have been active (posted, signed in or used the client API) in the last 180 days.
+	// example: 567
+	ActiveHalfyear int `json:"activeHalfyear"`
+}
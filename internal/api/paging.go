@@ -0,0 +1,68 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package api
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/validate"
+)
+
+// ParseLimit parses the limit in the given query parameter of the request, returning defaultLimit if it's not set.
+// Like Mastodon, rather than rejecting limits which are out of range, the limit is clamped using ClampLimit.
+// An error is returned if the limit is set but isn't a number.
+func ParseLimit(c *gin.Context, key string, defaultLimit int) (int, error) {
+	limit := defaultLimit
+	if limitString := c.Query(key); limitString != "" {
+		i, err := strconv.Atoi(limitString)
+		if err != nil {
+			return 0, fmt.Errorf("couldn't parse %s query param: %s", key, err)
+		}
+		limit = i
+	}
+
+	return ClampLimit(limit), nil
+}
+
+// ClampLimit clamps the given limit to between 1 and the configured maximum page size, so that
+// clients can't pull arbitrarily large pages of results out of the database.
+func ClampLimit(limit int) int {
+	if max := viper.GetInt(config.Keys.InstanceMaxPageSize); max > 0 && limit > max {
+		return max
+	}
+	if limit < 1 {
+		return 1
+	}
+	return limit
+}
+
+// ParsePagingID returns the id in the given query parameter of the request, such as max_id or min_id, which is
+// used to page through results. An empty string is returned if it's not set, and an error if it's not a valid ulid.
+func ParsePagingID(c *gin.Context, key string) (string, error) {
+	id := c.Query(key)
+	if id != "" && !validate.ULID(id) {
+		return "", fmt.Errorf("%s query param %s is not a valid id", key, id)
+	}
+
+	return id, nil
+}
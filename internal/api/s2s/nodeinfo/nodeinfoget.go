@@ -45,7 +45,7 @@ import (
 //     schema:
 //       "$ref": "#/definitions/nodeinfo"
 func (m *Module) NodeInfoGETHandler(c *gin.Context) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":       "NodeInfoGETHandler",
 		"user-agent": c.Request.UserAgent(),
 	})
@@ -58,7 +58,7 @@ func (m *Module) NodeInfoGETHandler(c *gin.Context) {
 	ni, err := m.processor.GetNodeInfo(c.Request.Context(), c.Request)
 	if err != nil {
 		l.Debugf("error with get node info request: %s", err)
-		c.JSON(err.Code(), err.Safe())
+		api.ErrorHandler(c, err)
 		return
 	}
 
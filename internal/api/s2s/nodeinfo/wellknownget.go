@@ -45,7 +45,7 @@ import (
 //     schema:
 //       "$ref": "#/definitions/wellKnownResponse"
 func (m *Module) NodeInfoWellKnownGETHandler(c *gin.Context) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func": "NodeInfoWellKnownGETHandler",
 	})
 
@@ -57,7 +57,7 @@ func (m *Module) NodeInfoWellKnownGETHandler(c *gin.Context) {
 	niRel, err := m.processor.GetNodeInfoRel(c.Request.Context(), c.Request)
 	if err != nil {
 		l.Debugf("error with get node info rel request: %s", err)
-		c.JSON(err.Code(), err.Safe())
+		api.ErrorHandler(c, err)
 		return
 	}
 
@@ -30,7 +30,7 @@ import (
 
 // FollowersGETHandler returns a collection of URIs for followers of the target user, formatted so that other AP servers can understand it.
 func (m *Module) FollowersGETHandler(c *gin.Context) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func": "FollowersGETHandler",
 		"url":  c.Request.RequestURI,
 	})
@@ -53,7 +53,7 @@ func (m *Module) FollowersGETHandler(c *gin.Context) {
 	followers, errWithCode := m.processor.GetFediFollowers(ctx, requestedUsername, c.Request.URL)
 	if errWithCode != nil {
 		l.Info(errWithCode.Error())
-		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		api.ErrorHandler(c, errWithCode)
 		return
 	}
 
@@ -30,7 +30,7 @@ import (
 
 // FollowingGETHandler returns a collection of URIs for accounts that the target user follows, formatted so that other AP servers can understand it.
 func (m *Module) FollowingGETHandler(c *gin.Context) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func": "FollowingGETHandler",
 		"url":  c.Request.RequestURI,
 	})
@@ -53,7 +53,7 @@ func (m *Module) FollowingGETHandler(c *gin.Context) {
 	following, errWithCode := m.processor.GetFediFollowing(ctx, requestedUsername, c.Request.URL)
 	if errWithCode != nil {
 		l.Info(errWithCode.Error())
-		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		api.ErrorHandler(c, errWithCode)
 		return
 	}
 
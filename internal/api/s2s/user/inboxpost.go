@@ -23,13 +23,14 @@ import (
 
 	"github.com/gin-gonic/gin"
 	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror" //nolint:typecheck
 )
 
 // InboxPOSTHandler deals with incoming POST requests to an actor's inbox.
 // Eg., POST to https://example.org/users/whatever/inbox.
 func (m *Module) InboxPOSTHandler(c *gin.Context) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func": "InboxPOSTHandler",
 		"url":  c.Request.RequestURI,
 	})
@@ -46,7 +47,7 @@ func (m *Module) InboxPOSTHandler(c *gin.Context) {
 	if err != nil {
 		if withCode, ok := err.(gtserror.WithCode); ok {
 			l.Debugf("InboxPOSTHandler: %s", withCode.Error())
-			c.JSON(withCode.Code(), withCode.Safe())
+			api.ErrorHandler(c, withCode)
 			return
 		}
 		l.Debugf("InboxPOSTHandler: error processing request: %s", err)
@@ -25,14 +25,17 @@ import (
 	"io/ioutil"
 	"net/http"
 	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/user"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -454,7 +457,7 @@ func (suite *InboxPostTestSuite) TestPostDelete() {
 	suite.ErrorIs(err, db.ErrNoEntries)
 
 	// no statuses from foss satan should be left in the database
-	dbStatuses, err := suite.db.GetAccountStatuses(ctx, deletedAccount.ID, 0, false, false, "", "", false, false, false)
+	dbStatuses, err := suite.db.GetAccountStatuses(ctx, deletedAccount.ID, 0, false, false, "", "", false, false, false, false)
 	suite.ErrorIs(err, db.ErrNoEntries)
 	suite.Empty(dbStatuses)
 
@@ -475,6 +478,374 @@ func (suite *InboxPostTestSuite) TestPostDelete() {
 	suite.Equal(dbAccount.ID, dbAccount.SuspensionOrigin)
 }
 
+func (suite *InboxPostTestSuite) TestPostDeleteStatus() {
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	receivingAccount := suite.testAccounts["local_account_1"]
+	deletedStatus := suite.testStatuses["remote_account_1_status_1"]
+	deletedAttachment := suite.testAttachments["remote_account_1_status_1_attachment_1"]
+
+	// the attachment of the status should be cached in storage, to begin with
+	_, err := suite.storage.Get(deletedAttachment.File.Path)
+	suite.NoError(err)
+
+	// create a delete of the status
+	delete := streams.NewActivityStreamsDelete()
+
+	deleteActor := streams.NewActivityStreamsActorProperty()
+	deleteActor.AppendIRI(testrig.URLMustParse(requestingAccount.URI))
+	delete.SetActivityStreamsActor(deleteActor)
+
+	deleteObject := streams.NewActivityStreamsObjectProperty()
+	deleteObject.AppendIRI(testrig.URLMustParse(deletedStatus.URI))
+	delete.SetActivityStreamsObject(deleteObject)
+
+	deleteTo := streams.NewActivityStreamsToProperty()
+	deleteTo.AppendIRI(testrig.URLMustParse(pub.PublicActivityPubIRI))
+	delete.SetActivityStreamsTo(deleteTo)
+
+	deleteID := streams.NewJSONLDIdProperty()
+	deleteID.SetIRI(testrig.URLMustParse("http://fossbros-anonymous.io/2f2b3b79-9d1b-4f26-a71d-45e3c2b1c6a4"))
+	delete.SetJSONLDId(deleteID)
+
+	targetURI := testrig.URLMustParse(receivingAccount.InboxURI)
+
+	signature, digestHeader, dateHeader := testrig.GetSignatureForActivity(delete, requestingAccount.PublicKeyURI, requestingAccount.PrivateKey, targetURI)
+	bodyI, err := streams.Serialize(delete)
+	suite.NoError(err)
+
+	bodyJson, err := json.Marshal(bodyI)
+	suite.NoError(err)
+	body := bytes.NewReader(bodyJson)
+
+	clientWorker := worker.New[messages.FromClientAPI](-1, -1)
+	fedWorker := worker.New[messages.FromFederator](-1, -1)
+
+	tc := testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db, fedWorker)
+	federator := testrig.NewTestFederator(suite.db, tc, suite.storage, suite.mediaManager, fedWorker)
+	emailSender := testrig.NewEmailSender("../../../../web/template/", nil)
+	processor := testrig.NewTestProcessor(suite.db, suite.storage, federator, emailSender, suite.mediaManager, clientWorker, fedWorker)
+	err = processor.Start()
+	suite.NoError(err)
+	userModule := user.New(processor).(*user.Module)
+
+	// setup request
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, targetURI.String(), body) // the endpoint we're hitting
+	ctx.Request.Header.Set("Signature", signature)
+	ctx.Request.Header.Set("Date", dateHeader)
+	ctx.Request.Header.Set("Digest", digestHeader)
+	ctx.Request.Header.Set("Content-Type", "application/activity+json")
+
+	// we need to pass the context through signature check first to set appropriate values on it
+	suite.securityModule.SignatureCheck(ctx)
+
+	// normally the router would populate these params from the path values,
+	// but because we're calling the function directly, we need to set them manually.
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   user.UsernameKey,
+			Value: receivingAccount.Username,
+		},
+	}
+
+	// trigger the function being tested
+	userModule.InboxPOSTHandler(ctx)
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+	suite.Empty(b)
+	suite.Equal(http.StatusOK, result.StatusCode)
+
+	// the status should be gone from the database
+	_, err = suite.db.GetStatusByURI(context.Background(), deletedStatus.URI)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// and its uri should be tombstoned
+	tombstoned, err := suite.db.TombstoneExistsWithURI(context.Background(), deletedStatus.URI)
+	suite.NoError(err)
+	suite.True(tombstoned)
+
+	// the attachment should be removed from the database and from storage in the background
+	suite.Eventually(func() bool {
+		err := suite.db.GetByID(context.Background(), deletedAttachment.ID, &gtsmodel.MediaAttachment{})
+		return err == db.ErrNoEntries
+	}, 5*time.Second, 10*time.Millisecond)
+	_, err = suite.storage.Get(deletedAttachment.File.Path)
+	suite.Error(err)
+	_, err = suite.storage.Get(deletedAttachment.Thumbnail.Path)
+	suite.Error(err)
+}
+
+// postFilteredBlock posts a block from remote_account_1 to local_account_1's inbox, and checks
+// that it's accepted without a block ever being created, as it would be if blocks were filtered.
+// TestPostEmojiReact verifies that a Pleroma-style EmojiReact is stored as a reaction to the target status.
+func (suite *InboxPostTestSuite) TestPostEmojiReact() {
+	reactingAccount := suite.testAccounts["remote_account_1"]
+	targetAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	like := streams.NewActivityStreamsLike()
+
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(testrig.URLMustParse(reactingAccount.URI))
+	like.SetActivityStreamsActor(actorProp)
+
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.Set(testrig.URLMustParse("http://fossbros-anonymous.io/activities/01G4B0WEKP2R5FVV8DKJ25TZ4N"))
+	like.SetJSONLDId(idProp)
+
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(testrig.URLMustParse(targetStatus.URI))
+	like.SetActivityStreamsObject(objectProp)
+
+	contentProp := streams.NewActivityStreamsContentProperty()
+	contentProp.AppendXMLSchemaString("🌈")
+	like.SetActivityStreamsContent(contentProp)
+
+	targetURI := testrig.URLMustParse(targetAccount.InboxURI)
+
+	// we can't build an EmojiReact with the activity library, so sign a like
+	// and then turn it into an EmojiReact; the body isn't covered by the signature
+	signature, digestHeader, dateHeader := testrig.GetSignatureForActivity(like, reactingAccount.PublicKeyURI, reactingAccount.PrivateKey, targetURI)
+	bodyI, err := streams.Serialize(like)
+	suite.NoError(err)
+	bodyI["type"] = "EmojiReact"
+
+	bodyJson, err := json.Marshal(bodyI)
+	suite.NoError(err)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, targetURI.String(), bytes.NewReader(bodyJson))
+	ctx.Request.Header.Set("Signature", signature)
+	ctx.Request.Header.Set("Date", dateHeader)
+	ctx.Request.Header.Set("Digest", digestHeader)
+	ctx.Request.Header.Set("Content-Type", "application/activity+json")
+	suite.securityModule.SignatureCheck(ctx)
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   user.UsernameKey,
+			Value: targetAccount.Username,
+		},
+	}
+
+	suite.userModule.InboxPOSTHandler(ctx)
+	suite.Equal(http.StatusOK, ctx.Writer.Status())
+
+	// there should be a reaction in the database now
+	reactions, err := suite.db.GetStatusReactions(context.Background(), targetStatus)
+	suite.NoError(err)
+	suite.Len(reactions, 1)
+	suite.Equal("🌈", reactions[0].Name)
+	suite.Equal(reactingAccount.ID, reactions[0].AccountID)
+	suite.Equal(targetAccount.ID, reactions[0].TargetAccountID)
+	suite.Equal("http://fossbros-anonymous.io/activities/01G4B0WEKP2R5FVV8DKJ25TZ4N", reactions[0].URI)
+	suite.Empty(reactions[0].EmojiID)
+}
+
+// TestPostUndoEmojiReact verifies that a remote account can undo its reaction by giving just the reaction's id.
+func (suite *InboxPostTestSuite) TestPostUndoEmojiReact() {
+	reactingAccount := suite.testAccounts["remote_account_1"]
+	targetAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+	reactionURI := "http://fossbros-anonymous.io/activities/01G4B0WEKP2R5FVV8DKJ25TZ4N"
+
+	reactionID, err := id.NewULID()
+	suite.NoError(err)
+	err = suite.db.Put(context.Background(), &gtsmodel.StatusReaction{
+		ID:              reactionID,
+		AccountID:       reactingAccount.ID,
+		TargetAccountID: targetAccount.ID,
+		StatusID:        targetStatus.ID,
+		Name:            "🌈",
+		URI:             reactionURI,
+	})
+	suite.NoError(err)
+
+	undo := streams.NewActivityStreamsUndo()
+
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(testrig.URLMustParse(reactingAccount.URI))
+	undo.SetActivityStreamsActor(actorProp)
+
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.Set(testrig.URLMustParse("http://fossbros-anonymous.io/activities/01G4B1A4M5KHC7R6GGB1J2M3ZS"))
+	undo.SetJSONLDId(idProp)
+
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(testrig.URLMustParse(reactionURI))
+	undo.SetActivityStreamsObject(objectProp)
+
+	toProp := streams.NewActivityStreamsToProperty()
+	toProp.AppendIRI(testrig.URLMustParse(targetAccount.URI))
+	undo.SetActivityStreamsTo(toProp)
+
+	targetURI := testrig.URLMustParse(targetAccount.InboxURI)
+
+	signature, digestHeader, dateHeader := testrig.GetSignatureForActivity(undo, reactingAccount.PublicKeyURI, reactingAccount.PrivateKey, targetURI)
+	bodyI, err := streams.Serialize(undo)
+	suite.NoError(err)
+
+	bodyJson, err := json.Marshal(bodyI)
+	suite.NoError(err)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, targetURI.String(), bytes.NewReader(bodyJson))
+	ctx.Request.Header.Set("Signature", signature)
+	ctx.Request.Header.Set("Date", dateHeader)
+	ctx.Request.Header.Set("Digest", digestHeader)
+	ctx.Request.Header.Set("Content-Type", "application/activity+json")
+	suite.securityModule.SignatureCheck(ctx)
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   user.UsernameKey,
+			Value: targetAccount.Username,
+		},
+	}
+
+	suite.userModule.InboxPOSTHandler(ctx)
+	suite.Equal(http.StatusOK, ctx.Writer.Status())
+
+	// the reaction should be gone
+	reactions, err := suite.db.GetStatusReactions(context.Background(), targetStatus)
+	suite.NoError(err)
+	suite.Empty(reactions)
+}
+
+// TestPostQuoteOfUnquotable verifies that remote quotes of statuses by local accounts who don't allow quotes are dropped.
+func (suite *InboxPostTestSuite) TestPostQuoteOfUnquotable() {
+	quotingAccount := suite.testAccounts["remote_account_1"]
+	targetAccount := suite.testAccounts["local_account_1"]
+	quotedStatus := suite.testStatuses["local_account_1_status_1"]
+
+	targetAccount.Unquotable = true
+	_, err := suite.db.UpdateAccount(context.Background(), targetAccount)
+	suite.NoError(err)
+
+	noteURI := testrig.URLMustParse("http://fossbros-anonymous.io/users/foss_satan/statuses/01G50W9GHKTV0YQ0Q9NTE3FHMB")
+	note := testrig.NewAPNote(
+		noteURI,
+		testrig.URLMustParse("http://fossbros-anonymous.io/@foss_satan/01G50W9GHKTV0YQ0Q9NTE3FHMB"),
+		time.Now(),
+		"what a post",
+		"",
+		testrig.URLMustParse(quotingAccount.URI),
+		[]*url.URL{testrig.URLMustParse(pub.PublicActivityPubIRI)},
+		[]*url.URL{testrig.URLMustParse(targetAccount.URI)},
+		false,
+		nil,
+		nil,
+	)
+	note.GetUnknownProperties()["quoteUri"] = quotedStatus.URI
+
+	create := testrig.WrapAPNoteInCreate(
+		testrig.URLMustParse("http://fossbros-anonymous.io/users/foss_satan/statuses/01G50W9GHKTV0YQ0Q9NTE3FHMB/activity"),
+		testrig.URLMustParse(quotingAccount.URI),
+		time.Now(),
+		note,
+	)
+
+	targetURI := testrig.URLMustParse(targetAccount.InboxURI)
+
+	signature, digestHeader, dateHeader := testrig.GetSignatureForActivity(create, quotingAccount.PublicKeyURI, quotingAccount.PrivateKey, targetURI)
+	bodyI, err := streams.Serialize(create)
+	suite.NoError(err)
+
+	bodyJson, err := json.Marshal(bodyI)
+	suite.NoError(err)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, targetURI.String(), bytes.NewReader(bodyJson))
+	ctx.Request.Header.Set("Signature", signature)
+	ctx.Request.Header.Set("Date", dateHeader)
+	ctx.Request.Header.Set("Digest", digestHeader)
+	ctx.Request.Header.Set("Content-Type", "application/activity+json")
+	suite.securityModule.SignatureCheck(ctx)
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   user.UsernameKey,
+			Value: targetAccount.Username,
+		},
+	}
+
+	suite.userModule.InboxPOSTHandler(ctx)
+	suite.Equal(http.StatusOK, ctx.Writer.Status())
+
+	// the quote should have been dropped
+	_, err = suite.db.GetStatusByURI(context.Background(), noteURI.String())
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *InboxPostTestSuite) postFilteredBlock() {
+	blockingAccount := suite.testAccounts["remote_account_1"]
+	blockedAccount := suite.testAccounts["local_account_1"]
+
+	block := streams.NewActivityStreamsBlock()
+
+	actorProp := streams.NewActivityStreamsActorProperty()
+	actorProp.AppendIRI(testrig.URLMustParse(blockingAccount.URI))
+	block.SetActivityStreamsActor(actorProp)
+
+	idProp := streams.NewJSONLDIdProperty()
+	idProp.Set(testrig.URLMustParse("http://fossbros-anonymous.io/users/foss_satan/blocks/01FG9C441MCTW3R2W117V2PQK3"))
+	block.SetJSONLDId(idProp)
+
+	objectProp := streams.NewActivityStreamsObjectProperty()
+	objectProp.AppendIRI(testrig.URLMustParse(blockedAccount.URI))
+	block.SetActivityStreamsObject(objectProp)
+
+	toProp := streams.NewActivityStreamsToProperty()
+	toProp.AppendIRI(testrig.URLMustParse(blockedAccount.URI))
+	block.SetActivityStreamsTo(toProp)
+
+	targetURI := testrig.URLMustParse(blockedAccount.InboxURI)
+
+	signature, digestHeader, dateHeader := testrig.GetSignatureForActivity(block, blockingAccount.PublicKeyURI, blockingAccount.PrivateKey, targetURI)
+	bodyI, err := streams.Serialize(block)
+	suite.NoError(err)
+
+	bodyJson, err := json.Marshal(bodyI)
+	suite.NoError(err)
+
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodPost, targetURI.String(), bytes.NewReader(bodyJson))
+	ctx.Request.Header.Set("Signature", signature)
+	ctx.Request.Header.Set("Date", dateHeader)
+	ctx.Request.Header.Set("Digest", digestHeader)
+	ctx.Request.Header.Set("Content-Type", "application/activity+json")
+	suite.securityModule.SignatureCheck(ctx)
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   user.UsernameKey,
+			Value: blockedAccount.Username,
+		},
+	}
+
+	suite.userModule.InboxPOSTHandler(ctx)
+	suite.Equal(http.StatusAccepted, ctx.Writer.Status())
+
+	// the block should have been dropped
+	_, err = suite.db.GetBlock(context.Background(), blockingAccount.ID, blockedAccount.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+// TestPostRejectedType verifies that activities of a rejected type are accepted, but not processed.
+func (suite *InboxPostTestSuite) TestPostRejectedType() {
+	viper.Set(config.Keys.InstanceFederationRejectedActivityTypes, []string{"Flag", "block"})
+	suite.postFilteredBlock()
+}
+
+// TestPostNotAcceptedType verifies that activities of a type that isn't in the accepted list are accepted, but not processed.
+func (suite *InboxPostTestSuite) TestPostNotAcceptedType() {
+	viper.Set(config.Keys.InstanceFederationAcceptedActivityTypes, []string{"Create", "Update", "Delete"})
+	suite.postFilteredBlock()
+}
+
 func TestInboxPostTestSuite(t *testing.T) {
 	suite.Run(t, &InboxPostTestSuite{})
 }
@@ -80,7 +80,7 @@ import (
 //   '404':
 //      description: not found
 func (m *Module) OutboxGETHandler(c *gin.Context) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func": "OutboxGETHandler",
 		"url":  c.Request.RequestURI,
 	})
@@ -126,7 +126,7 @@ func (m *Module) OutboxGETHandler(c *gin.Context) {
 	outbox, errWithCode := m.processor.GetFediOutbox(ctx, requestedUsername, page, maxID, minID, c.Request.URL)
 	if errWithCode != nil {
 		l.Info(errWithCode.Error())
-		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		api.ErrorHandler(c, errWithCode)
 		return
 	}
 
@@ -33,8 +33,12 @@ import (
 // The goal here is to return a MINIMAL activitypub representation of an account
 // in the form of a vocab.ActivityStreamsPerson. The account will only contain the id,
 // public key, username, and type of the account.
+//
+// It's also served at eg https://example.org/users/:username/main-key/:key, for keys
+// created by rotating the account's keypair. If the key at that path has been rotated
+// out but is still within its grace period, the account will contain the old key.
 func (m *Module) PublicKeyGETHandler(c *gin.Context) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func": "PublicKeyGETHandler",
 		"url":  c.Request.RequestURI,
 	})
@@ -57,7 +61,7 @@ func (m *Module) PublicKeyGETHandler(c *gin.Context) {
 	user, errWithCode := m.processor.GetFediUser(ctx, requestedUsername, c.Request.URL)
 	if errWithCode != nil {
 		l.Info(errWithCode.Error())
-		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		api.ErrorHandler(c, errWithCode)
 		return
 	}
 
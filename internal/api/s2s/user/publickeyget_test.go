@@ -0,0 +1,138 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package user_test
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/s2s/user"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type PublicKeyGetTestSuite struct {
+	UserStandardTestSuite
+}
+
+// rotateKeys rotates the keys of a copy of the given test account, returning the copy with its new keys.
+func (suite *PublicKeyGetTestSuite) rotateKeys(accountName string) *gtsmodel.Account {
+	account := *suite.testAccounts[accountName]
+	_, errWithCode := suite.processor.AccountRotateKeys(context.Background(), &oauth.Auth{Account: &account})
+	suite.NoError(errWithCode)
+	return &account
+}
+
+// getPublicKey dereferences the public key at the given uri, returning the response code and the served key id.
+func (suite *PublicKeyGetTestSuite) getPublicKey(username string, keyURI string) (int, string) {
+	recorder := httptest.NewRecorder()
+	ctx, _ := gin.CreateTestContext(recorder)
+	ctx.Request = httptest.NewRequest(http.MethodGet, keyURI, nil)
+	ctx.Request.Header.Set("accept", "application/activity+json")
+	ctx.Params = gin.Params{
+		gin.Param{
+			Key:   user.UsernameKey,
+			Value: username,
+		},
+	}
+
+	suite.userModule.PublicKeyGETHandler(ctx)
+
+	result := recorder.Result()
+	defer result.Body.Close()
+	b, err := ioutil.ReadAll(result.Body)
+	suite.NoError(err)
+
+	m := struct {
+		PublicKey struct {
+			ID string `json:"id"`
+		} `json:"publicKey"`
+	}{}
+	suite.NoError(json.Unmarshal(b, &m))
+	return recorder.Code, m.PublicKey.ID
+}
+
+func (suite *PublicKeyGetTestSuite) TestGetRotatedPublicKey() {
+	oldAccount := suite.testAccounts["local_account_1"]
+	newAccount := suite.rotateKeys("local_account_1")
+	suite.NotEqual(oldAccount.PublicKeyURI, newAccount.PublicKeyURI)
+
+	// the new key should be served at its own uri
+	code, keyID := suite.getPublicKey(newAccount.Username, newAccount.PublicKeyURI)
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(newAccount.PublicKeyURI, keyID)
+
+	// the old key should still be served at the old uri during the grace period
+	code, keyID = suite.getPublicKey(oldAccount.Username, oldAccount.PublicKeyURI)
+	suite.Equal(http.StatusOK, code)
+	suite.Equal(oldAccount.PublicKeyURI, keyID)
+
+	// but not after it's expired
+	err := suite.db.UpdateWhere(context.Background(), []db.Where{{Key: "public_key_uri", Value: oldAccount.PublicKeyURI}}, "expires_at", time.Now().Add(-1*time.Minute), &gtsmodel.AccountKey{})
+	suite.NoError(err)
+	code, _ = suite.getPublicKey(oldAccount.Username, oldAccount.PublicKeyURI)
+	suite.Equal(http.StatusNotFound, code)
+}
+
+func (suite *PublicKeyGetTestSuite) TestGetUserSignedWithRotatedKey() {
+	targetAccount := suite.testAccounts["local_account_1"]
+	oldAccount := suite.testAccounts["local_account_2"]
+	suite.rotateKeys("local_account_2")
+
+	// sign a request with the old key, as if it had been signed just before the rotation
+	sig, _, date := testrig.GetSignatureForDereference(oldAccount.PublicKeyURI, oldAccount.PrivateKey, testrig.URLMustParse(targetAccount.URI))
+	get := func() int {
+		recorder := httptest.NewRecorder()
+		ctx, _ := gin.CreateTestContext(recorder)
+		ctx.Request = httptest.NewRequest(http.MethodGet, targetAccount.URI, nil)
+		ctx.Request.Header.Set("accept", "application/activity+json")
+		ctx.Request.Header.Set("Signature", sig)
+		ctx.Request.Header.Set("Date", date)
+		suite.securityModule.SignatureCheck(ctx)
+		ctx.Params = gin.Params{
+			gin.Param{
+				Key:   user.UsernameKey,
+				Value: targetAccount.Username,
+			},
+		}
+		suite.userModule.UsersGETHandler(ctx)
+		return recorder.Code
+	}
+
+	// the old key is still in its grace period, so the request should be accepted
+	suite.Equal(http.StatusOK, get())
+
+	// once the old key has expired, it should be rejected
+	err := suite.db.UpdateWhere(context.Background(), []db.Where{{Key: "public_key_uri", Value: oldAccount.PublicKeyURI}}, "expires_at", time.Now().Add(-1*time.Minute), &gtsmodel.AccountKey{})
+	suite.NoError(err)
+	suite.Equal(http.StatusUnauthorized, get())
+}
+
+func TestPublicKeyGetTestSuite(t *testing.T) {
+	suite.Run(t, &PublicKeyGetTestSuite{})
+}
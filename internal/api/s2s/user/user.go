@@ -40,6 +40,8 @@ const (
 	MaxIDKey = "max_id"
 	// PageKey is for filtering status responses.
 	PageKey = "page"
+	// PublicKeyIDKey is for the IDs of rotated public keys.
+	PublicKeyIDKey = "key"
 
 	// UsersBasePath is the base path for serving information about Users eg https://example.org/users
 	UsersBasePath = "/" + uris.UsersPath
@@ -49,6 +51,8 @@ const (
 	UsersBasePathWithUsername = UsersBasePath + "/:" + UsernameKey
 	// UsersPublicKeyPath is a path to a user's public key, for serving bare minimum AP representations.
 	UsersPublicKeyPath = UsersBasePathWithUsername + "/" + uris.PublicKeyPath
+	// UsersPublicKeyPathWithID is a path to one of a user's rotated public keys, which may no longer be the current one.
+	UsersPublicKeyPathWithID = UsersPublicKeyPath + "/:" + PublicKeyIDKey
 	// UsersInboxPath is for serving POST requests to a user's inbox with the given username key.
 	UsersInboxPath = UsersBasePathWithUsername + "/" + uris.InboxPath
 	// UsersOutboxPath is for serving GET requests to a user's outbox with the given username key.
@@ -83,6 +87,7 @@ func (m *Module) Route(s router.Router) error {
 	s.AttachHandler(http.MethodGet, UsersFollowingPath, m.FollowingGETHandler)
 	s.AttachHandler(http.MethodGet, UsersStatusPath, m.StatusGETHandler)
 	s.AttachHandler(http.MethodGet, UsersPublicKeyPath, m.PublicKeyGETHandler)
+	s.AttachHandler(http.MethodGet, UsersPublicKeyPathWithID, m.PublicKeyGETHandler)
 	s.AttachHandler(http.MethodGet, UsersStatusRepliesPath, m.StatusRepliesGETHandler)
 	s.AttachHandler(http.MethodGet, UsersOutboxPath, m.OutboxGETHandler)
 	return nil
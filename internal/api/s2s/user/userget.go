@@ -37,8 +37,10 @@ import (
 //
 // And of course, the request should be refused if the account or server making the
 // request is blocked.
+//
+// Requests that prefer text/html are redirected to the web profile of the account instead.
 func (m *Module) UsersGETHandler(c *gin.Context) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func": "UsersGETHandler",
 		"url":  c.Request.RequestURI,
 	})
@@ -49,19 +51,25 @@ func (m *Module) UsersGETHandler(c *gin.Context) {
 		return
 	}
 
-	format, err := api.NegotiateAccept(c, api.ActivityPubAcceptHeaders...)
+	format, err := api.NegotiateAccept(c, api.AppActivityJSON, api.AppActivityLDJSON, api.TextHTML)
 	if err != nil {
 		c.JSON(http.StatusNotAcceptable, gin.H{"error": err.Error()})
 		return
 	}
 	l.Tracef("negotiated format: %s", format)
 
+	if format == string(api.TextHTML) {
+		// send browsers to the web view of the profile, which never requires a signature
+		c.Redirect(http.StatusSeeOther, "/@"+requestedUsername)
+		return
+	}
+
 	ctx := transferContext(c)
 
 	user, errWithCode := m.processor.GetFediUser(ctx, requestedUsername, c.Request.URL) // GetFediUser handles auth as well
 	if errWithCode != nil {
 		l.Info(errWithCode.Error())
-		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		api.ErrorHandler(c, errWithCode)
 		return
 	}
 
@@ -28,6 +28,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 )
 
@@ -55,7 +56,7 @@ import (
 //     schema:
 //       "$ref": "#/definitions/wellKnownResponse"
 func (m *Module) WebfingerGETRequest(c *gin.Context) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 		"func":       "WebfingerGETRequest",
 		"user-agent": c.Request.UserAgent(),
 	})
@@ -109,7 +110,7 @@ func (m *Module) WebfingerGETRequest(c *gin.Context) {
 	resp, err := m.processor.GetWebfingerAccount(ctx, username)
 	if err != nil {
 		l.Debugf("aborting request with an error: %s", err.Error())
-		c.JSON(err.Code(), gin.H{"error": err.Safe()})
+		api.ErrorHandler(c, err)
 		return
 	}
 
@@ -0,0 +1,136 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+// Package automod checks statuses against the instance-wide moderation rules set by admins.
+package automod
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"sort"
+	"sync"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Rules matches text against the moderation rules stored in the database. The rules are
+// loaded and compiled the first time they're needed, and cached until Invalidate is called.
+type Rules interface {
+	// Match returns the first moderation rule which matches any of the given texts, or nil if none of them match.
+	Match(ctx context.Context, texts ...string) (*gtsmodel.ModerationRule, error)
+	// Invalidate drops the cached rules, so that they're loaded again on the next match.
+	// It should be called whenever a moderation rule is created or deleted.
+	Invalidate()
+}
+
+type compiledRule struct {
+	rule   *gtsmodel.ModerationRule
+	regexp *regexp.Regexp
+}
+
+type rules struct {
+	db       db.DB
+	mu       sync.RWMutex
+	compiled []compiledRule
+	loaded   bool
+}
+
+// New returns a new Rules which loads moderation rules from the given database.
+func New(db db.DB) Rules {
+	return &rules{
+		db: db,
+	}
+}
+
+// Compile compiles the pattern of the given moderation rule into a case-insensitive regular expression.
+// Patterns which aren't regular expressions are matched as whole words or phrases.
+func Compile(rule *gtsmodel.ModerationRule) (*regexp.Regexp, error) {
+	pattern := rule.Pattern
+	if !rule.Regex {
+		pattern = `(?:^|\W)` + regexp.QuoteMeta(pattern) + `(?:\W|$)`
+	}
+	return regexp.Compile("(?i)" + pattern)
+}
+
+func (r *rules) Match(ctx context.Context, texts ...string) (*gtsmodel.ModerationRule, error) {
+	compiled, err := r.load(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, c := range compiled {
+		for _, text := range texts {
+			if text != "" && c.regexp.MatchString(text) {
+				return c.rule, nil
+			}
+		}
+	}
+
+	return nil, nil
+}
+
+func (r *rules) Invalidate() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.compiled = nil
+	r.loaded = false
+}
+
+// load returns the cached compiled rules, loading and compiling them first if necessary.
+func (r *rules) load(ctx context.Context) ([]compiledRule, error) {
+	r.mu.RLock()
+	if r.loaded {
+		defer r.mu.RUnlock()
+		return r.compiled, nil
+	}
+	r.mu.RUnlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.loaded {
+		return r.compiled, nil
+	}
+
+	moderationRules := []*gtsmodel.ModerationRule{}
+	if err := r.db.GetAll(ctx, &moderationRules); err != nil && err != db.ErrNoEntries {
+		return nil, fmt.Errorf("load: db error getting moderation rules: %s", err)
+	}
+
+	compiled := make([]compiledRule, 0, len(moderationRules))
+	for _, rule := range moderationRules {
+		re, err := Compile(rule)
+		if err != nil {
+			// rules are checked when they're created, so this shouldn't happen
+			logrus.Errorf("load: error compiling moderation rule %s: %s", rule.ID, err)
+			continue
+		}
+		compiled = append(compiled, compiledRule{rule: rule, regexp: re})
+	}
+
+	// check rules in the order they were created, so that matches are predictable
+	sort.Slice(compiled, func(i, j int) bool {
+		return compiled[i].rule.ID < compiled[j].rule.ID
+	})
+
+	r.compiled = compiled
+	r.loaded = true
+	return compiled, nil
+}
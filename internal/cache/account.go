@@ -117,6 +117,34 @@ func (c *AccountCache) Put(account *gtsmodel.Account) {
 	c.mutex.Unlock()
 }
 
+// Invalidate removes the account with the given ID from the cache, if it's there
+func (c *AccountCache) Invalidate(id string) {
+	c.mutex.Lock()
+	if v, ok := c.cache.Get(id); ok {
+		account := v.(*gtsmodel.Account)
+		delete(c.urls, account.URL)
+		delete(c.uris, account.URI)
+		c.cache.Remove(id)
+	}
+	c.mutex.Unlock()
+}
+
+// Close empties the cache and stops its expiration processing, it shouldn't be used afterwards
+func (c *AccountCache) Close() {
+	c.cache.Close()
+}
+
+// IDs returns the IDs of all accounts currently in the cache
+func (c *AccountCache) IDs() []string {
+	c.mutex.Lock()
+	ids := make([]string, 0, len(c.uris))
+	for _, id := range c.uris {
+		ids = append(ids, id)
+	}
+	c.mutex.Unlock()
+	return ids
+}
+
 // copyAccount performs a surface-level copy of account, only keeping attached IDs intact, not the objects.
 // due to all the data being copied being 99% primitive types or strings (which are immutable and passed by ptr)
 // this should be a relatively cheap process
@@ -135,6 +163,7 @@ func copyAccount(account *gtsmodel.Account) *gtsmodel.Account {
 		Fields:                  account.Fields,
 		Note:                    account.Note,
 		NoteRaw:                 account.NoteRaw,
+		CustomCSS:               account.CustomCSS,
 		Memorial:                account.Memorial,
 		MovedToAccountID:        account.MovedToAccountID,
 		CreatedAt:               account.CreatedAt,
@@ -142,13 +171,19 @@ func copyAccount(account *gtsmodel.Account) *gtsmodel.Account {
 		Bot:                     account.Bot,
 		Reason:                  account.Reason,
 		Locked:                  account.Locked,
+		AutoAcceptFollowed:      account.AutoAcceptFollowed,
+		AutoAcceptDomains:       account.AutoAcceptDomains,
 		Discoverable:            account.Discoverable,
+		Unquotable:              account.Unquotable,
+		ArchiveAfterDays:        account.ArchiveAfterDays,
+		AutoDeleteAfterDays:     account.AutoDeleteAfterDays,
 		Privacy:                 account.Privacy,
 		Sensitive:               account.Sensitive,
 		Language:                account.Language,
 		URI:                     account.URI,
 		URL:                     account.URL,
 		LastWebfingeredAt:       account.LastWebfingeredAt,
+		FetchedAt:               account.FetchedAt,
 		InboxURI:                account.InboxURI,
 		OutboxURI:               account.OutboxURI,
 		FollowingURI:            account.FollowingURI,
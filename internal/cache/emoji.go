@@ -0,0 +1,155 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package cache
+
+import (
+	"sync"
+
+	"github.com/ReneKroon/ttlcache"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// EmojiCache is a wrapper around ttlcache.Cache to provide shortcode + domain lookups for gtsmodel.Emoji
+type EmojiCache struct {
+	cache      *ttlcache.Cache   // map of IDs -> cached emojis
+	shortcodes map[string]string // map of shortcode + domain keys -> IDs
+	mutex      sync.Mutex
+}
+
+// NewEmojiCache returns a new instantiated EmojiCache object
+func NewEmojiCache() *EmojiCache {
+	c := EmojiCache{
+		cache:      ttlcache.NewCache(),
+		shortcodes: make(map[string]string, 100),
+		mutex:      sync.Mutex{},
+	}
+
+	// Set callback to purge lookup map on expiration
+	c.cache.SetExpirationCallback(func(key string, value interface{}) {
+		emoji, ok := value.(*gtsmodel.Emoji)
+		if !ok {
+			logrus.Panicf("EmojiCache could not assert entry with key %s to *gtsmodel.Emoji", key)
+		}
+
+		c.mutex.Lock()
+		delete(c.shortcodes, shortcodeDomainKey(emoji.Shortcode, emoji.Domain))
+		c.mutex.Unlock()
+	})
+
+	return &c
+}
+
+// GetByID attempts to fetch an emoji from the cache by its ID, you will receive a copy for thread-safety
+func (c *EmojiCache) GetByID(id string) (*gtsmodel.Emoji, bool) {
+	c.mutex.Lock()
+	emoji, ok := c.getByID(id)
+	c.mutex.Unlock()
+	return emoji, ok
+}
+
+// GetByShortcodeDomain attempts to fetch an emoji from the cache by its shortcode and domain,
+// where an empty domain means an emoji of this instance. You will receive a copy for thread-safety
+func (c *EmojiCache) GetByShortcodeDomain(shortcode string, domain string) (*gtsmodel.Emoji, bool) {
+	// Perform safe ID lookup
+	c.mutex.Lock()
+	id, ok := c.shortcodes[shortcodeDomainKey(shortcode, domain)]
+
+	// Not found, unlock early
+	if !ok {
+		c.mutex.Unlock()
+		return nil, false
+	}
+
+	// Attempt emoji lookup
+	emoji, ok := c.getByID(id)
+	c.mutex.Unlock()
+	return emoji, ok
+}
+
+// getByID performs an unsafe (no mutex locks) lookup of emoji by ID, returning a copy of emoji in cache
+func (c *EmojiCache) getByID(id string) (*gtsmodel.Emoji, bool) {
+	v, ok := c.cache.Get(id)
+	if !ok {
+		return nil, false
+	}
+
+	e, ok := v.(*gtsmodel.Emoji)
+	if !ok {
+		panic("emoji cache entry was not an emoji")
+	}
+
+	return copyEmoji(e), true
+}
+
+// Put places an emoji in the cache, ensuring that the object place is a copy for thread-safety.
+// If a previous version of the emoji was cached under a different shortcode, that lookup is removed.
+func (c *EmojiCache) Put(emoji *gtsmodel.Emoji) {
+	if emoji == nil || emoji.ID == "" {
+		panic("invalid emoji")
+	}
+
+	c.mutex.Lock()
+	if v, ok := c.cache.Get(emoji.ID); ok {
+		previous := v.(*gtsmodel.Emoji)
+		delete(c.shortcodes, shortcodeDomainKey(previous.Shortcode, previous.Domain))
+	}
+	c.cache.Set(emoji.ID, copyEmoji(emoji))
+	c.shortcodes[shortcodeDomainKey(emoji.Shortcode, emoji.Domain)] = emoji.ID
+	c.mutex.Unlock()
+}
+
+// Invalidate removes the emoji with the given ID from the cache, if it's there
+func (c *EmojiCache) Invalidate(id string) {
+	c.mutex.Lock()
+	if v, ok := c.cache.Get(id); ok {
+		emoji := v.(*gtsmodel.Emoji)
+		delete(c.shortcodes, shortcodeDomainKey(emoji.Shortcode, emoji.Domain))
+		c.cache.Remove(id)
+	}
+	c.mutex.Unlock()
+}
+
+// Close empties the cache and stops its expiration processing, it shouldn't be used afterwards
+func (c *EmojiCache) Close() {
+	c.cache.Close()
+}
+
+// IDs returns the IDs of all emojis currently in the cache
+func (c *EmojiCache) IDs() []string {
+	c.mutex.Lock()
+	ids := make([]string, 0, len(c.shortcodes))
+	for _, id := range c.shortcodes {
+		ids = append(ids, id)
+	}
+	c.mutex.Unlock()
+	return ids
+}
+
+// shortcodeDomainKey returns the key under which the ID of an emoji is stored in the shortcode lookup map
+func shortcodeDomainKey(shortcode string, domain string) string {
+	return shortcode + "@" + domain
+}
+
+// copyEmoji performs a copy of emoji. Emojis don't have any attached objects, so this is just a copy of
+// the struct itself, which is cheap since all of its fields are primitive types, strings or times.
+func copyEmoji(emoji *gtsmodel.Emoji) *gtsmodel.Emoji {
+	e := *emoji
+	return &e
+}
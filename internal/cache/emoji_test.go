@@ -0,0 +1,79 @@
+package cache_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type EmojiCacheTestSuite struct {
+	suite.Suite
+	data  map[string]*gtsmodel.Emoji
+	cache *cache.EmojiCache
+}
+
+func (suite *EmojiCacheTestSuite) SetupTest() {
+	suite.data = testrig.NewTestEmojis()
+	suite.cache = cache.NewEmojiCache()
+}
+
+func (suite *EmojiCacheTestSuite) TearDownTest() {
+	suite.data = nil
+	suite.cache = nil
+}
+
+func (suite *EmojiCacheTestSuite) TestEmojiCache() {
+	for _, emoji := range suite.data {
+		// Place in the cache
+		suite.cache.Put(emoji)
+	}
+
+	for _, emoji := range suite.data {
+		// Check we can retrieve
+		check, ok := suite.cache.GetByID(emoji.ID)
+		suite.True(ok)
+		suite.Equal(emoji, check)
+
+		check, ok = suite.cache.GetByShortcodeDomain(emoji.Shortcode, emoji.Domain)
+		suite.True(ok)
+		suite.Equal(emoji, check)
+
+		// an emoji with the same shortcode from another domain is a different emoji
+		_, ok = suite.cache.GetByShortcodeDomain(emoji.Shortcode, "some.other.domain")
+		suite.False(ok)
+	}
+}
+
+func (suite *EmojiCacheTestSuite) TestEmojiCacheRename() {
+	emoji := *suite.data["rainbow"]
+	suite.cache.Put(&emoji)
+
+	emoji.Shortcode = "double_rainbow"
+	suite.cache.Put(&emoji)
+
+	// the old shortcode shouldn't lead anywhere anymore
+	_, ok := suite.cache.GetByShortcodeDomain("rainbow", emoji.Domain)
+	suite.False(ok)
+
+	check, ok := suite.cache.GetByShortcodeDomain("double_rainbow", emoji.Domain)
+	suite.True(ok)
+	suite.Equal(emoji.ID, check.ID)
+}
+
+func (suite *EmojiCacheTestSuite) TestEmojiCacheInvalidate() {
+	emoji := suite.data["rainbow"]
+	suite.cache.Put(emoji)
+	suite.cache.Invalidate(emoji.ID)
+
+	_, ok := suite.cache.GetByID(emoji.ID)
+	suite.False(ok)
+	_, ok = suite.cache.GetByShortcodeDomain(emoji.Shortcode, emoji.Domain)
+	suite.False(ok)
+}
+
+func TestEmojiCache(t *testing.T) {
+	suite.Run(t, &EmojiCacheTestSuite{})
+}
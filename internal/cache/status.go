@@ -117,6 +117,34 @@ func (c *StatusCache) Put(status *gtsmodel.Status) {
 	c.mutex.Unlock()
 }
 
+// Invalidate removes the status with the given ID from the cache, if it's there
+func (c *StatusCache) Invalidate(id string) {
+	c.mutex.Lock()
+	if v, ok := c.cache.Get(id); ok {
+		status := v.(*gtsmodel.Status)
+		delete(c.urls, status.URL)
+		delete(c.uris, status.URI)
+		c.cache.Remove(id)
+	}
+	c.mutex.Unlock()
+}
+
+// Close empties the cache and stops its expiration processing, it shouldn't be used afterwards
+func (c *StatusCache) Close() {
+	c.cache.Close()
+}
+
+// IDs returns the IDs of all statuses currently in the cache
+func (c *StatusCache) IDs() []string {
+	c.mutex.Lock()
+	ids := make([]string, 0, len(c.uris))
+	for _, id := range c.uris {
+		ids = append(ids, id)
+	}
+	c.mutex.Unlock()
+	return ids
+}
+
 // copyStatus performs a surface-level copy of status, only keeping attached IDs intact, not the objects.
 // due to all the data being copied being 99% primitive types or strings (which are immutable and passed by ptr)
 // this should be a relatively cheap process
@@ -149,6 +177,13 @@ func copyStatus(status *gtsmodel.Status) *gtsmodel.Status {
 		BoostOf:                  nil,
 		BoostOfAccountID:         status.BoostOfAccountID,
 		BoostOfAccount:           nil,
+		QuoteOfID:                status.QuoteOfID,
+		QuoteOfURI:               status.QuoteOfURI,
+		QuoteOf:                  nil,
+		PreviewCardID:            status.PreviewCardID,
+		PreviewCard:              nil,
+		ModerationRuleID:         status.ModerationRuleID,
+		HeldAt:                   status.HeldAt,
 		ContentWarning:           status.ContentWarning,
 		Visibility:               status.Visibility,
 		Sensitive:                status.Sensitive,
@@ -158,6 +193,7 @@ func copyStatus(status *gtsmodel.Status) *gtsmodel.Status {
 		Boostable:                status.Boostable,
 		Replyable:                status.Replyable,
 		Likeable:                 status.Likeable,
+		ReplyPolicy:              status.ReplyPolicy,
 		ActivityStreamsType:      status.ActivityStreamsType,
 		Text:                     status.Text,
 		Pinned:                   status.Pinned,
@@ -18,52 +18,141 @@
 
 package config
 
-import "github.com/coreos/go-oidc/v3/oidc"
+import (
+	"time"
+
+	"github.com/coreos/go-oidc/v3/oidc"
+)
 
 // Defaults returns a populated Values struct with most of the values set to reasonable defaults.
 // Note that if you use this, you still need to set Host and, if desired, ConfigPath.
 var Defaults = Values{
-	LogLevel:        "info",
-	LogDbQueries:    false,
-	ApplicationName: "gotosocial",
-	ConfigPath:      "",
-	Host:            "",
-	AccountDomain:   "",
-	Protocol:        "https",
-	BindAddress:     "0.0.0.0",
-	Port:            8080,
-	TrustedProxies:  []string{"127.0.0.1/32"}, // localhost
-
-	DbType:      "postgres",
-	DbAddress:   "",
-	DbPort:      5432,
-	DbUser:      "",
-	DbPassword:  "",
-	DbDatabase:  "gotosocial",
-	DbTLSMode:   "disable",
-	DbTLSCACert: "",
-
-	WebTemplateBaseDir: "./web/template/",
-	WebAssetBaseDir:    "./web/assets/",
-
-	AccountsRegistrationOpen: true,
-	AccountsApprovalRequired: true,
-	AccountsReasonRequired:   true,
-
-	MediaImageMaxSize:        2097152,  // 2mb
-	MediaVideoMaxSize:        10485760, // 10mb
-	MediaDescriptionMinChars: 0,
-	MediaDescriptionMaxChars: 500,
-	MediaRemoteCacheDays:     30,
+	LogLevel:                 "info",
+	LogDbQueries:             false,
+	LogSamplePeriod:          time.Minute,
+	ApplicationName:          "gotosocial",
+	ConfigPath:               "",
+	Host:                     "",
+	AccountDomain:            "",
+	Protocol:                 "https",
+	BindAddress:              "0.0.0.0",
+	Port:                     8080,
+	TrustedProxies:           []string{"127.0.0.1/32"}, // localhost
+	TrustedProxyHeaders:      []string{"X-Forwarded-For", "X-Real-IP"},
+	CORSAllowOrigins:         []string{"*"},
+	CORSAllowMethods:         []string{"POST", "PUT", "DELETE", "GET", "PATCH", "OPTIONS"},
+	CORSAllowHeaders:         []string{"Origin", "Content-Length", "Content-Type", "Authorization"},
+	GzipLevel:                -1,
+	GzipExcludedContentTypes: []string{"image/*", "video/*", "audio/*", "application/gzip", "application/zip"},
+	GzipMinSize:              1024,
+	GzipMaxSize:              0,
+	SessionCookieDomain:      "",
+	SessionCookieSameSite:    "lax",
+	SessionCookieSecure:      true,
+	SessionCookieMaxAge:      2 * time.Minute,
+
+	DbType:               "postgres",
+	DbAddress:            "",
+	DbPort:               5432,
+	DbUser:               "",
+	DbPassword:           "",
+	DbDatabase:           "gotosocial",
+	DbTLSMode:            "disable",
+	DbTLSCACert:          "",
+	DbSlowQueryThreshold: time.Second,
+
+	WebTemplateBaseDir:        "./web/template/",
+	WebAssetBaseDir:           "./web/assets/",
+	WebAssetCacheMaxAge:       time.Hour,
+	WebAssetHashedCacheMaxAge: 365 * 24 * time.Hour,
+	WebContentSecurityPolicy:  "",
+
+	InstanceAuthorizedFetch:                 true,
+	InstanceFederationAcceptedActivityTypes: []string{"Accept", "Add", "Announce", "Arrive", "Block", "Create", "Delete", "Dislike", "EmojiReact", "Flag", "Follow", "Ignore", "Invite", "Join", "Leave", "Like", "Listen", "Move", "Offer", "Question", "Read", "Reject", "Remove", "TentativeAccept", "TentativeReject", "Travel", "Undo", "Update", "View"},
+	InstanceFederationRejectedActivityTypes: []string{},
+	InstanceLanguages:                       []string{},
+	InstanceReadOnly:                        false,
+	InstanceReadOnlyMessage:                 "",
+	InstanceReadOnlyRejectFederation:        false,
+	FederationMaxThreadDepth:                20,
+	InstanceMaxPageSize:                     40,
+	InstanceAPIJSONPretty:                   false,
+	InstanceAPIJSONOmitEmpty:                false,
+	InstanceActiveUsersCacheDuration:        time.Hour,
+
+	AccountsRegistrationOpen:          true,
+	AccountsApprovalRequired:          true,
+	AccountsReasonRequired:            true,
+	AccountsArchiveInterval:           7 * 24 * time.Hour,
+	AccountsKeyGracePeriod:            24 * time.Hour,
+	AccountsSignUpIPLimit:             0,
+	AccountsSignUpIPWindow:            24 * time.Hour,
+	AccountsEmailDomainBlocklist:      []string{},
+	AccountsEmailDomainBlocklistFile:  "",
+	AccountsMaxFields:                 4,
+	AccountsFieldNameMaxChars:         255,
+	AccountsFieldValueMaxChars:        255,
+	AccountsCustomCSSEnabled:          false,
+	AccountsCustomCSSAllowedHosts:     []string{},
+	AccountsFieldVerificationInterval: 24 * time.Hour,
+	AccountsSuggestionsEnabled:        true,
+	AccountsMaxFollowing:              7500,
+	AccountsMinAge:                    0,
+	AccountsRemoteRefreshInterval:     24 * time.Hour,
+	AccountsPasswordBcryptCost:        10,
+	AccountsSignInMaxFailures:         10,
+	AccountsSignInFailureWindow:       15 * time.Minute,
+	AccountsSignInLockout:             15 * time.Minute,
+
+	OAuthTokenCleanupInterval:  time.Hour,
+	OAuthAccessTokenExpiry:     0,
+	OAuthRefreshTokenExpiry:    30 * 24 * time.Hour,
+	OAuthTokenInactivityExpiry: 0,
+
+	MediaImageMaxSize:             2097152,  // 2mb
+	MediaVideoMaxSize:             10485760, // 10mb
+	MediaImageMaxDimension:        16384,
+	MediaImageMaxPixels:           40000000,
+	MediaThumbnailMaxDimension:    512,
+	MediaEmojiMaxSize:             51200,
+	MediaEmojiMaxDimension:        128,
+	MediaDescriptionMinChars:      0,
+	MediaDescriptionMaxChars:      500,
+	MediaAutoDescribeEnabled:      false,
+	MediaAutoDescribeURL:          "",
+	MediaRemoteCacheDays:          30,
+	MediaRemoteCachePruneInterval: 24 * time.Hour,
+	MediaProcessingConcurrency:    0,
+	MediaProcessingQueueSize:      0,
+	MediaSyncProcessingMaxSize:    1048576, // 1mb
+	MediaCacheMaxAge:              24 * time.Hour,
+	MediaUnattachedRetention:      24 * time.Hour,
 
 	StorageBackend:       "local",
 	StorageLocalBasePath: "/gotosocial/storage",
 
-	StatusesMaxChars:           5000,
-	StatusesCWMaxChars:         100,
-	StatusesPollMaxOptions:     6,
-	StatusesPollOptionMaxChars: 50,
-	StatusesMediaMaxFiles:      6,
+	StatusesMaxChars:                5000,
+	StatusesCWMaxChars:              100,
+	StatusesReplyInheritCW:          false,
+	StatusesReplyInheritVisibility:  false,
+	StatusesArchiveAfterDays:        0,
+	StatusesArchiveHideDirect:       false,
+	StatusesPollMaxOptions:          6,
+	StatusesPollOptionMaxChars:      50,
+	StatusesMediaMaxFiles:           6,
+	StatusesMentionResolveTimeout:   5 * time.Second,
+	StatusesRemoteAllowedElements:   []string{"p", "br", "span", "a", "del", "pre", "code", "em", "strong", "b", "i", "u", "ul", "ol", "li", "blockquote"},
+	StatusesRemoteAllowedAttributes: []string{"a.href", "a.rel", "a.class", "span.class", "ol.start", "ol.reversed", "li.value"},
+	StatusesTombstoneRetention:      30 * 24 * time.Hour,
+	StatusesAutoDeleteInterval:      1 * time.Hour,
+	StatusesLinkPreviewEnabled:      true,
+	StatusesLinkPreviewTimeout:      10 * time.Second,
+	StatusesLinkPreviewMaxSize:      1048576, // 1mb
+	StatusesDuplicateWindow:         0,
+	StatusesAutoCWMediaOnly:         false,
+	StatusesAutoCWMaxLinks:          0,
+	StatusesAutoCWText:              "Unlabelled media or links",
+	StatusesDeletePreserveMedia:     false,
 
 	LetsEncryptEnabled:      false,
 	LetsEncryptPort:         80,
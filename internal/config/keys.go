@@ -21,56 +21,143 @@ package config
 // KeyNames is a struct that just contains the names of configuration keys.
 type KeyNames struct {
 	// root
-	LogLevel     string
-	LogDbQueries string
-	ConfigPath   string
+	LogLevel        string
+	LogDbQueries    string
+	LogSamplePeriod string
+	ConfigPath      string
 
 	// general
-	ApplicationName string
-	Host            string
-	AccountDomain   string
-	Protocol        string
-	BindAddress     string
-	Port            string
-	TrustedProxies  string
-	SoftwareVersion string
+	ApplicationName          string
+	Host                     string
+	AccountDomain            string
+	Protocol                 string
+	BindAddress              string
+	Port                     string
+	TrustedProxies           string
+	TrustedProxyHeaders      string
+	CORSAllowOrigins         string
+	CORSAllowMethods         string
+	CORSAllowHeaders         string
+	GzipLevel                string
+	GzipExcludedContentTypes string
+	GzipMinSize              string
+	GzipMaxSize              string
+	SessionCookieDomain      string
+	SessionCookieSameSite    string
+	SessionCookieSecure      string
+	SessionCookieMaxAge      string
+	SoftwareVersion          string
 
 	// database
-	DbType      string
-	DbAddress   string
-	DbPort      string
-	DbUser      string
-	DbPassword  string
-	DbDatabase  string
-	DbTLSMode   string
-	DbTLSCACert string
+	DbType               string
+	DbAddress            string
+	DbPort               string
+	DbUser               string
+	DbPassword           string
+	DbDatabase           string
+	DbTLSMode            string
+	DbTLSCACert          string
+	DbSlowQueryThreshold string
 
 	// template
-	WebTemplateBaseDir string
-	WebAssetBaseDir    string
+	WebTemplateBaseDir        string
+	WebAssetBaseDir           string
+	WebAssetCacheMaxAge       string
+	WebAssetHashedCacheMaxAge string
+	WebContentSecurityPolicy  string
+
+	// instance
+	InstanceAuthorizedFetch                 string
+	InstanceFederationAcceptedActivityTypes string
+	InstanceFederationRejectedActivityTypes string
+	InstanceLanguages                       string
+	InstanceReadOnly                        string
+	InstanceReadOnlyMessage                 string
+	InstanceReadOnlyRejectFederation        string
+	FederationMaxThreadDepth                string
+	InstanceMaxPageSize                     string
+	InstanceAPIJSONPretty                   string
+	InstanceAPIJSONOmitEmpty                string
+	InstanceActiveUsersCacheDuration        string
 
 	// accounts
-	AccountsRegistrationOpen string
-	AccountsApprovalRequired string
-	AccountsReasonRequired   string
+	AccountsRegistrationOpen          string
+	AccountsApprovalRequired          string
+	AccountsReasonRequired            string
+	AccountsArchiveInterval           string
+	AccountsKeyGracePeriod            string
+	AccountsSignUpIPLimit             string
+	AccountsSignUpIPWindow            string
+	AccountsEmailDomainBlocklist      string
+	AccountsEmailDomainBlocklistFile  string
+	AccountsMaxFields                 string
+	AccountsFieldNameMaxChars         string
+	AccountsFieldValueMaxChars        string
+	AccountsCustomCSSEnabled          string
+	AccountsCustomCSSAllowedHosts     string
+	AccountsFieldVerificationInterval string
+	AccountsSuggestionsEnabled        string
+	AccountsMaxFollowing              string
+	AccountsMinAge                    string
+	AccountsRemoteRefreshInterval     string
+	AccountsPasswordBcryptCost        string
+	AccountsSignInMaxFailures         string
+	AccountsSignInFailureWindow       string
+	AccountsSignInLockout             string
+
+	// oauth
+	OAuthTokenCleanupInterval  string
+	OAuthAccessTokenExpiry     string
+	OAuthRefreshTokenExpiry    string
+	OAuthTokenInactivityExpiry string
 
 	// media
-	MediaImageMaxSize        string
-	MediaVideoMaxSize        string
-	MediaDescriptionMinChars string
-	MediaDescriptionMaxChars string
-	MediaRemoteCacheDays     string
+	MediaImageMaxSize             string
+	MediaVideoMaxSize             string
+	MediaImageMaxDimension        string
+	MediaImageMaxPixels           string
+	MediaThumbnailMaxDimension    string
+	MediaEmojiMaxSize             string
+	MediaEmojiMaxDimension        string
+	MediaDescriptionMinChars      string
+	MediaDescriptionMaxChars      string
+	MediaAutoDescribeEnabled      string
+	MediaAutoDescribeURL          string
+	MediaRemoteCacheDays          string
+	MediaRemoteCachePruneInterval string
+	MediaProcessingConcurrency    string
+	MediaProcessingQueueSize      string
+	MediaSyncProcessingMaxSize    string
+	MediaCacheMaxAge              string
+	MediaUnattachedRetention      string
 
 	// storage
 	StorageBackend       string
 	StorageLocalBasePath string
 
 	// statuses
-	StatusesMaxChars           string
-	StatusesCWMaxChars         string
-	StatusesPollMaxOptions     string
-	StatusesPollOptionMaxChars string
-	StatusesMediaMaxFiles      string
+	StatusesMaxChars                string
+	StatusesCWMaxChars              string
+	StatusesReplyInheritCW          string
+	StatusesReplyInheritVisibility  string
+	StatusesArchiveAfterDays        string
+	StatusesArchiveHideDirect       string
+	StatusesPollMaxOptions          string
+	StatusesPollOptionMaxChars      string
+	StatusesMediaMaxFiles           string
+	StatusesMentionResolveTimeout   string
+	StatusesRemoteAllowedElements   string
+	StatusesRemoteAllowedAttributes string
+	StatusesTombstoneRetention      string
+	StatusesAutoDeleteInterval      string
+	StatusesLinkPreviewEnabled      string
+	StatusesLinkPreviewTimeout      string
+	StatusesLinkPreviewMaxSize      string
+	StatusesDuplicateWindow         string
+	StatusesAutoCWMediaOnly         string
+	StatusesAutoCWMaxLinks          string
+	StatusesAutoCWText              string
+	StatusesDeletePreserveMedia     string
 
 	// letsencrypt
 	LetsEncryptEnabled      string
@@ -109,48 +196,133 @@ type KeyNames struct {
 // Keys contains the names of the various keys used for initializing and storing flag variables,
 // and retrieving values from the viper config store.
 var Keys = KeyNames{
-	LogLevel:        "log-level",
-	LogDbQueries:    "log-db-queries",
-	ApplicationName: "application-name",
-	ConfigPath:      "config-path",
-	Host:            "host",
-	AccountDomain:   "account-domain",
-	Protocol:        "protocol",
-	BindAddress:     "bind-address",
-	Port:            "port",
-	TrustedProxies:  "trusted-proxies",
-	SoftwareVersion: "software-version",
-
-	DbType:      "db-type",
-	DbAddress:   "db-address",
-	DbPort:      "db-port",
-	DbUser:      "db-user",
-	DbPassword:  "db-password",
-	DbDatabase:  "db-database",
-	DbTLSMode:   "db-tls-mode",
-	DbTLSCACert: "db-tls-ca-cert",
-
-	WebTemplateBaseDir: "web-template-base-dir",
-	WebAssetBaseDir:    "web-asset-base-dir",
-
-	AccountsRegistrationOpen: "accounts-registration-open",
-	AccountsApprovalRequired: "accounts-approval-required",
-	AccountsReasonRequired:   "accounts-reason-required",
-
-	MediaImageMaxSize:        "media-image-max-size",
-	MediaVideoMaxSize:        "media-video-max-size",
-	MediaDescriptionMinChars: "media-description-min-chars",
-	MediaDescriptionMaxChars: "media-description-max-chars",
-	MediaRemoteCacheDays:     "media-remote-cache-days",
+	LogLevel:                 "log-level",
+	LogDbQueries:             "log-db-queries",
+	LogSamplePeriod:          "log-sample-period",
+	ApplicationName:          "application-name",
+	ConfigPath:               "config-path",
+	Host:                     "host",
+	AccountDomain:            "account-domain",
+	Protocol:                 "protocol",
+	BindAddress:              "bind-address",
+	Port:                     "port",
+	TrustedProxies:           "trusted-proxies",
+	TrustedProxyHeaders:      "trusted-proxy-headers",
+	CORSAllowOrigins:         "cors-allow-origins",
+	CORSAllowMethods:         "cors-allow-methods",
+	CORSAllowHeaders:         "cors-allow-headers",
+	GzipLevel:                "gzip-level",
+	GzipExcludedContentTypes: "gzip-excluded-content-types",
+	GzipMinSize:              "gzip-min-size",
+	GzipMaxSize:              "gzip-max-size",
+	SessionCookieDomain:      "session-cookie-domain",
+	SessionCookieSameSite:    "session-cookie-samesite",
+	SessionCookieSecure:      "session-cookie-secure",
+	SessionCookieMaxAge:      "session-cookie-max-age",
+	SoftwareVersion:          "software-version",
+
+	DbType:               "db-type",
+	DbAddress:            "db-address",
+	DbPort:               "db-port",
+	DbUser:               "db-user",
+	DbPassword:           "db-password",
+	DbDatabase:           "db-database",
+	DbTLSMode:            "db-tls-mode",
+	DbTLSCACert:          "db-tls-ca-cert",
+	DbSlowQueryThreshold: "db-slow-query-threshold",
+
+	WebTemplateBaseDir:        "web-template-base-dir",
+	WebAssetBaseDir:           "web-asset-base-dir",
+	WebAssetCacheMaxAge:       "web-asset-cache-max-age",
+	WebAssetHashedCacheMaxAge: "web-asset-hashed-cache-max-age",
+	WebContentSecurityPolicy:  "web-content-security-policy",
+
+	InstanceAuthorizedFetch:                 "instance-authorized-fetch",
+	InstanceFederationAcceptedActivityTypes: "instance-federation-accepted-activity-types",
+	InstanceFederationRejectedActivityTypes: "instance-federation-rejected-activity-types",
+	InstanceLanguages:                       "instance-languages",
+	InstanceReadOnly:                        "instance-read-only",
+	InstanceReadOnlyMessage:                 "instance-read-only-message",
+	InstanceReadOnlyRejectFederation:        "instance-read-only-reject-federation",
+	FederationMaxThreadDepth:                "federation-max-thread-depth",
+	InstanceMaxPageSize:                     "instance-max-page-size",
+	InstanceAPIJSONPretty:                   "instance-api-json-pretty",
+	InstanceAPIJSONOmitEmpty:                "instance-api-json-omit-empty",
+	InstanceActiveUsersCacheDuration:        "instance-active-users-cache-duration",
+
+	AccountsRegistrationOpen:          "accounts-registration-open",
+	AccountsApprovalRequired:          "accounts-approval-required",
+	AccountsReasonRequired:            "accounts-reason-required",
+	AccountsArchiveInterval:           "accounts-archive-interval",
+	AccountsKeyGracePeriod:            "accounts-key-grace-period",
+	AccountsSignUpIPLimit:             "accounts-sign-up-ip-limit",
+	AccountsSignUpIPWindow:            "accounts-sign-up-ip-window",
+	AccountsEmailDomainBlocklist:      "accounts-email-domain-blocklist",
+	AccountsEmailDomainBlocklistFile:  "accounts-email-domain-blocklist-file",
+	AccountsMaxFields:                 "accounts-max-fields",
+	AccountsFieldNameMaxChars:         "accounts-field-name-max-chars",
+	AccountsFieldValueMaxChars:        "accounts-field-value-max-chars",
+	AccountsCustomCSSEnabled:          "accounts-custom-css-enabled",
+	AccountsCustomCSSAllowedHosts:     "accounts-custom-css-allowed-hosts",
+	AccountsFieldVerificationInterval: "accounts-field-verification-interval",
+	AccountsSuggestionsEnabled:        "accounts-suggestions-enabled",
+	AccountsMaxFollowing:              "accounts-max-following",
+	AccountsMinAge:                    "accounts-min-age",
+	AccountsRemoteRefreshInterval:     "accounts-remote-refresh-interval",
+	AccountsPasswordBcryptCost:        "accounts-password-bcrypt-cost",
+	AccountsSignInMaxFailures:         "accounts-sign-in-max-failures",
+	AccountsSignInFailureWindow:       "accounts-sign-in-failure-window",
+	AccountsSignInLockout:             "accounts-sign-in-lockout",
+
+	OAuthTokenCleanupInterval:  "oauth-token-cleanup-interval",
+	OAuthAccessTokenExpiry:     "oauth-access-token-expiry",
+	OAuthRefreshTokenExpiry:    "oauth-refresh-token-expiry",
+	OAuthTokenInactivityExpiry: "oauth-token-inactivity-expiry",
+
+	MediaImageMaxSize:             "media-image-max-size",
+	MediaVideoMaxSize:             "media-video-max-size",
+	MediaImageMaxDimension:        "media-image-max-dimension",
+	MediaImageMaxPixels:           "media-image-max-pixels",
+	MediaThumbnailMaxDimension:    "media-thumbnail-max-dimension",
+	MediaEmojiMaxSize:             "media-emoji-max-size",
+	MediaEmojiMaxDimension:        "media-emoji-max-dimension",
+	MediaDescriptionMinChars:      "media-description-min-chars",
+	MediaDescriptionMaxChars:      "media-description-max-chars",
+	MediaAutoDescribeEnabled:      "media-auto-describe-enabled",
+	MediaAutoDescribeURL:          "media-auto-describe-url",
+	MediaRemoteCacheDays:          "media-remote-cache-days",
+	MediaRemoteCachePruneInterval: "media-remote-cache-prune-interval",
+	MediaProcessingConcurrency:    "media-processing-concurrency",
+	MediaProcessingQueueSize:      "media-processing-queue-size",
+	MediaSyncProcessingMaxSize:    "media-sync-processing-max-size",
+	MediaCacheMaxAge:              "media-cache-max-age",
+	MediaUnattachedRetention:      "media-unattached-retention",
 
 	StorageBackend:       "storage-backend",
 	StorageLocalBasePath: "storage-local-base-path",
 
-	StatusesMaxChars:           "statuses-max-chars",
-	StatusesCWMaxChars:         "statuses-cw-max-chars",
-	StatusesPollMaxOptions:     "statuses-poll-max-options",
-	StatusesPollOptionMaxChars: "statuses-poll-option-max-chars",
-	StatusesMediaMaxFiles:      "statuses-media-max-files",
+	StatusesMaxChars:                "statuses-max-chars",
+	StatusesCWMaxChars:              "statuses-cw-max-chars",
+	StatusesReplyInheritCW:          "statuses-reply-inherit-cw",
+	StatusesReplyInheritVisibility:  "statuses-reply-inherit-visibility",
+	StatusesArchiveAfterDays:        "statuses-archive-after-days",
+	StatusesArchiveHideDirect:       "statuses-archive-hide-direct",
+	StatusesPollMaxOptions:          "statuses-poll-max-options",
+	StatusesPollOptionMaxChars:      "statuses-poll-option-max-chars",
+	StatusesMediaMaxFiles:           "statuses-media-max-files",
+	StatusesMentionResolveTimeout:   "statuses-mention-resolve-timeout",
+	StatusesRemoteAllowedElements:   "statuses-remote-allowed-elements",
+	StatusesRemoteAllowedAttributes: "statuses-remote-allowed-attributes",
+	StatusesTombstoneRetention:      "statuses-tombstone-retention",
+	StatusesAutoDeleteInterval:      "statuses-auto-delete-interval",
+	StatusesLinkPreviewEnabled:      "statuses-link-preview-enabled",
+	StatusesLinkPreviewTimeout:      "statuses-link-preview-timeout",
+	StatusesLinkPreviewMaxSize:      "statuses-link-preview-max-size",
+	StatusesDuplicateWindow:         "statuses-duplicate-window",
+	StatusesAutoCWMediaOnly:         "statuses-auto-cw-media-only",
+	StatusesAutoCWMaxLinks:          "statuses-auto-cw-max-links",
+	StatusesAutoCWText:              "statuses-auto-cw-text",
+	StatusesDeletePreserveMedia:     "statuses-delete-preserve-media",
 
 	LetsEncryptEnabled:      "letsencrypt-enabled",
 	LetsEncryptPort:         "letsencrypt-port",
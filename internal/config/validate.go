@@ -0,0 +1,63 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/spf13/viper"
+)
+
+// Validate checks the configuration currently in viper for
+// mistakes which would leave the instance unable to federate
+// properly, returning a descriptive error if it finds one.
+func Validate() error {
+	host := viper.GetString(Keys.Host)
+	accountDomain := viper.GetString(Keys.AccountDomain)
+
+	if accountDomain == "" {
+		return nil
+	}
+
+	if host == "" {
+		return fmt.Errorf("%s is set to %s, but %s is not set", Keys.AccountDomain, accountDomain, Keys.Host)
+	}
+
+	// usernames @username@accountDomain are only discoverable from the host if
+	// remote servers can find it from accountDomain, which is done by redirecting
+	// from accountDomain/.well-known/webfinger to the host, so the host has to be
+	// accountDomain itself or one of its subdomains
+	h := stripPort(strings.ToLower(host))
+	a := stripPort(strings.ToLower(accountDomain))
+	if h != a && !strings.HasSuffix(h, "."+a) {
+		return fmt.Errorf("%s %s is not the same as, or a subdomain of, %s %s: accounts would not be discoverable by their handle at %s", Keys.Host, host, Keys.AccountDomain, accountDomain, accountDomain)
+	}
+
+	return nil
+}
+
+// stripPort removes the port, if there is one, from the given host.
+func stripPort(host string) string {
+	if h, _, err := net.SplitHostPort(host); err == nil {
+		return h
+	}
+	return host
+}
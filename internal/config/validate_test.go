@@ -0,0 +1,67 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package config_test
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+type ValidateTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ValidateTestSuite) SetupTest() {
+	viper.Reset()
+}
+
+func (suite *ValidateTestSuite) TestValidateNoAccountDomain() {
+	viper.Set(config.Keys.Host, "gts.example.org")
+	suite.NoError(config.Validate())
+}
+
+func (suite *ValidateTestSuite) TestValidateAccountDomain() {
+	for _, host := range []string{"gts.example.org", "example.org", "GTS.Example.org", "gts.example.org:8080", "a.b.example.org"} {
+		viper.Set(config.Keys.Host, host)
+		viper.Set(config.Keys.AccountDomain, "example.org")
+		suite.NoError(config.Validate(), host)
+	}
+}
+
+func (suite *ValidateTestSuite) TestValidateAccountDomainMismatch() {
+	for _, host := range []string{"gts.example.com", "notexample.org", "example.org.evil.com", ""} {
+		viper.Set(config.Keys.Host, host)
+		viper.Set(config.Keys.AccountDomain, "example.org")
+		suite.Error(config.Validate(), host)
+	}
+}
+
+func (suite *ValidateTestSuite) TestValidateAccountDomainIsSubdomain() {
+	viper.Set(config.Keys.Host, "example.org")
+	viper.Set(config.Keys.AccountDomain, "gts.example.org")
+	err := config.Validate()
+	suite.EqualError(err, "host example.org is not the same as, or a subdomain of, account-domain gts.example.org: accounts would not be discoverable by their handle at gts.example.org")
+}
+
+func TestValidateTestSuite(t *testing.T) {
+	suite.Run(t, new(ValidateTestSuite))
+}
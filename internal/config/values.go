@@ -18,50 +18,137 @@
 
 package config
 
+import "time"
+
 // Values contains contains the type of each configuration value.
 type Values struct {
-	LogLevel        string
-	LogDbQueries    bool
-	ApplicationName string
-	ConfigPath      string
-	Host            string
-	AccountDomain   string
-	Protocol        string
-	BindAddress     string
-	Port            int
-	TrustedProxies  []string
-	SoftwareVersion string
-
-	DbType      string
-	DbAddress   string
-	DbPort      int
-	DbUser      string
-	DbPassword  string
-	DbDatabase  string
-	DbTLSMode   string
-	DbTLSCACert string
-
-	WebTemplateBaseDir string
-	WebAssetBaseDir    string
-
-	AccountsRegistrationOpen bool
-	AccountsApprovalRequired bool
-	AccountsReasonRequired   bool
-
-	MediaImageMaxSize        int
-	MediaVideoMaxSize        int
-	MediaDescriptionMinChars int
-	MediaDescriptionMaxChars int
-	MediaRemoteCacheDays     int
+	LogLevel                 string
+	LogDbQueries             bool
+	LogSamplePeriod          time.Duration
+	ApplicationName          string
+	ConfigPath               string
+	Host                     string
+	AccountDomain            string
+	Protocol                 string
+	BindAddress              string
+	Port                     int
+	TrustedProxies           []string
+	TrustedProxyHeaders      []string
+	CORSAllowOrigins         []string
+	CORSAllowMethods         []string
+	CORSAllowHeaders         []string
+	GzipLevel                int
+	GzipExcludedContentTypes []string
+	GzipMinSize              int
+	GzipMaxSize              int
+	SessionCookieDomain      string
+	SessionCookieSameSite    string
+	SessionCookieSecure      bool
+	SessionCookieMaxAge      time.Duration
+	SoftwareVersion          string
+
+	DbType               string
+	DbAddress            string
+	DbPort               int
+	DbUser               string
+	DbPassword           string
+	DbDatabase           string
+	DbTLSMode            string
+	DbTLSCACert          string
+	DbSlowQueryThreshold time.Duration
+
+	WebTemplateBaseDir        string
+	WebAssetBaseDir           string
+	WebAssetCacheMaxAge       time.Duration
+	WebAssetHashedCacheMaxAge time.Duration
+	WebContentSecurityPolicy  string
+
+	InstanceAuthorizedFetch                 bool
+	InstanceFederationAcceptedActivityTypes []string
+	InstanceFederationRejectedActivityTypes []string
+	InstanceLanguages                       []string
+	InstanceReadOnly                        bool
+	InstanceReadOnlyMessage                 string
+	InstanceReadOnlyRejectFederation        bool
+	FederationMaxThreadDepth                int
+	InstanceMaxPageSize                     int
+	InstanceAPIJSONPretty                   bool
+	InstanceAPIJSONOmitEmpty                bool
+	InstanceActiveUsersCacheDuration        time.Duration
+
+	AccountsRegistrationOpen          bool
+	AccountsApprovalRequired          bool
+	AccountsReasonRequired            bool
+	AccountsArchiveInterval           time.Duration
+	AccountsKeyGracePeriod            time.Duration
+	AccountsSignUpIPLimit             int
+	AccountsSignUpIPWindow            time.Duration
+	AccountsEmailDomainBlocklist      []string
+	AccountsEmailDomainBlocklistFile  string
+	AccountsMaxFields                 int
+	AccountsFieldNameMaxChars         int
+	AccountsFieldValueMaxChars        int
+	AccountsCustomCSSEnabled          bool
+	AccountsCustomCSSAllowedHosts     []string
+	AccountsFieldVerificationInterval time.Duration
+	AccountsSuggestionsEnabled        bool
+	AccountsMaxFollowing              int
+	AccountsMinAge                    time.Duration
+	AccountsRemoteRefreshInterval     time.Duration
+	AccountsPasswordBcryptCost        int
+	AccountsSignInMaxFailures         int
+	AccountsSignInFailureWindow       time.Duration
+	AccountsSignInLockout             time.Duration
+
+	OAuthTokenCleanupInterval  time.Duration
+	OAuthAccessTokenExpiry     time.Duration
+	OAuthRefreshTokenExpiry    time.Duration
+	OAuthTokenInactivityExpiry time.Duration
+
+	MediaImageMaxSize             int
+	MediaVideoMaxSize             int
+	MediaImageMaxDimension        int
+	MediaImageMaxPixels           int
+	MediaThumbnailMaxDimension    int
+	MediaEmojiMaxSize             int
+	MediaEmojiMaxDimension        int
+	MediaDescriptionMinChars      int
+	MediaDescriptionMaxChars      int
+	MediaAutoDescribeEnabled      bool
+	MediaAutoDescribeURL          string
+	MediaRemoteCacheDays          int
+	MediaRemoteCachePruneInterval time.Duration
+	MediaProcessingConcurrency    int
+	MediaProcessingQueueSize      int
+	MediaSyncProcessingMaxSize    int
+	MediaCacheMaxAge              time.Duration
+	MediaUnattachedRetention      time.Duration
 
 	StorageBackend       string
 	StorageLocalBasePath string
 
-	StatusesMaxChars           int
-	StatusesCWMaxChars         int
-	StatusesPollMaxOptions     int
-	StatusesPollOptionMaxChars int
-	StatusesMediaMaxFiles      int
+	StatusesMaxChars                int
+	StatusesCWMaxChars              int
+	StatusesReplyInheritCW          bool
+	StatusesReplyInheritVisibility  bool
+	StatusesArchiveAfterDays        int
+	StatusesArchiveHideDirect       bool
+	StatusesPollMaxOptions          int
+	StatusesPollOptionMaxChars      int
+	StatusesMediaMaxFiles           int
+	StatusesMentionResolveTimeout   time.Duration
+	StatusesRemoteAllowedElements   []string
+	StatusesRemoteAllowedAttributes []string
+	StatusesTombstoneRetention      time.Duration
+	StatusesAutoDeleteInterval      time.Duration
+	StatusesLinkPreviewEnabled      bool
+	StatusesLinkPreviewTimeout      time.Duration
+	StatusesLinkPreviewMaxSize      int
+	StatusesDuplicateWindow         time.Duration
+	StatusesAutoCWMediaOnly         bool
+	StatusesAutoCWMaxLinks          int
+	StatusesAutoCWText              string
+	StatusesDeletePreserveMedia     bool
 
 	LetsEncryptEnabled      bool
 	LetsEncryptCertDir      string
@@ -48,11 +48,17 @@ type Account interface {
 	// GetAccountStatusesCount is a shortcut for the common action of counting statuses produced by accountID.
 	CountAccountStatuses(ctx context.Context, accountID string) (int, Error)
 
+	// CountAccountStatusesByVisibility counts the statuses produced by accountID, broken down by visibility.
+	// Every visibility is included in the returned map, with a count of 0 if the account has no statuses of it.
+	CountAccountStatusesByVisibility(ctx context.Context, accountID string) (map[gtsmodel.Visibility]int, Error)
+
 	// GetAccountStatuses is a shortcut for getting the most recent statuses. accountID is optional, if not provided
 	// then all statuses will be returned. If limit is set to 0, the size of the returned slice will not be limited. This can
 	// be very memory intensive so you probably shouldn't do this!
+	// If publicOnly is set, only public statuses will be returned. If excludeDirect is set, statuses of any visibility
+	// except direct will be returned, so that followers-only statuses can be included without direct messages.
 	// In case of no entries, a 'no entries' error will be returned
-	GetAccountStatuses(ctx context.Context, accountID string, limit int, excludeReplies bool, excludeReblogs bool, maxID string, minID string, pinnedOnly bool, mediaOnly bool, publicOnly bool) ([]*gtsmodel.Status, Error)
+	GetAccountStatuses(ctx context.Context, accountID string, limit int, excludeReplies bool, excludeReblogs bool, maxID string, minID string, pinnedOnly bool, mediaOnly bool, publicOnly bool, excludeDirect bool) ([]*gtsmodel.Status, Error)
 
 	GetAccountBlocks(ctx context.Context, accountID string, maxID string, sinceID string, limit int) ([]*gtsmodel.Account, string, string, Error)
 
@@ -61,8 +67,18 @@ type Account interface {
 	// The returned time will be zero if account has never posted anything.
 	GetAccountLastPosted(ctx context.Context, accountID string) (time.Time, Error)
 
+	// GetAccountsLastPosted is like GetAccountLastPosted, but for many accounts at once, returning a map of account ID
+	// to the timestamp of the most recent post by that account.
+	//
+	// Every given account is included in the returned map, with a zero time if the account has never posted anything.
+	GetAccountsLastPosted(ctx context.Context, accountIDs []string) (map[string]time.Time, Error)
+
 	// SetAccountHeaderOrAvatar sets the header or avatar for the given accountID to the given media attachment.
-	SetAccountHeaderOrAvatar(ctx context.Context, mediaAttachment *gtsmodel.MediaAttachment, accountID string) Error
+	//
+	// If the account already had a header or avatar (as appropriate), the database entry of that
+	// attachment is removed in the same transaction, and the removed attachment is returned, so
+	// that the caller can remove its files from storage. Otherwise, the returned attachment is nil.
+	SetAccountHeaderOrAvatar(ctx context.Context, mediaAttachment *gtsmodel.MediaAttachment, accountID string) (*gtsmodel.MediaAttachment, Error)
 
 	// GetInstanceAccount returns the instance account for the given domain.
 	// If domain is empty, this instance account will be returned.
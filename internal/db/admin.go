@@ -21,6 +21,7 @@ package db
 import (
 	"context"
 	"net"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -51,4 +52,18 @@ type Admin interface {
 	// Ie., if the instance is hosted at 'example.org' the instance will have a domain of 'example.org'.
 	// This is needed for things like serving instance information through /api/v1/instance
 	CreateInstanceInstance(ctx context.Context) Error
+
+	// CountSignUpIPs returns the number of sign-ups recorded with the given ip hash since the given time.
+	CountSignUpIPs(ctx context.Context, ipHash string, since time.Time) (int, Error)
+
+	// DeleteSignUpIPsBefore deletes all sign-up ip records that were created before the given time.
+	DeleteSignUpIPsBefore(ctx context.Context, before time.Time) Error
+
+	// CountFailedSignIn counts one more failed sign in as the given user, and returns how many have now been counted.
+	// If the first of the failures being counted happened before windowStart, counting starts from scratch at this one.
+	// The count is incremented in the database itself, so that concurrent failures can't overwrite each other's counts.
+	CountFailedSignIn(ctx context.Context, userID string, windowStart time.Time) (int, Error)
+
+	// LockSignIns locks sign ins as the given user until the given time, and resets their count of failed sign ins.
+	LockSignIns(ctx context.Context, userID string, until time.Time) Error
 }
@@ -20,6 +20,7 @@ package bundb
 
 import (
 	"context"
+	"database/sql"
 	"errors"
 	"fmt"
 	"strings"
@@ -162,9 +163,41 @@ func (a *accountDB) GetAccountLastPosted(ctx context.Context, accountID string)
 	return status.CreatedAt, nil
 }
 
-func (a *accountDB) SetAccountHeaderOrAvatar(ctx context.Context, mediaAttachment *gtsmodel.MediaAttachment, accountID string) db.Error {
+func (a *accountDB) GetAccountsLastPosted(ctx context.Context, accountIDs []string) (map[string]time.Time, db.Error) {
+	lastPosted := make(map[string]time.Time, len(accountIDs))
+	if len(accountIDs) == 0 {
+		return lastPosted, nil
+	}
+
+	rows := []struct {
+		AccountID  string
+		LastPosted time.Time
+	}{}
+
+	q := a.conn.
+		NewSelect().
+		Model((*gtsmodel.Status)(nil)).
+		Column("account_id").
+		ColumnExpr("MAX(?) AS ?", bun.Ident("created_at"), bun.Ident("last_posted")).
+		Where("account_id IN (?)", bun.In(accountIDs)).
+		Group("account_id")
+
+	if err := q.Scan(ctx, &rows); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	for _, id := range accountIDs {
+		lastPosted[id] = time.Time{}
+	}
+	for _, row := range rows {
+		lastPosted[row.AccountID] = row.LastPosted
+	}
+	return lastPosted, nil
+}
+
+func (a *accountDB) SetAccountHeaderOrAvatar(ctx context.Context, mediaAttachment *gtsmodel.MediaAttachment, accountID string) (*gtsmodel.MediaAttachment, db.Error) {
 	if mediaAttachment.Avatar && mediaAttachment.Header {
-		return errors.New("one media attachment cannot be both header and avatar")
+		return nil, errors.New("one media attachment cannot be both header and avatar")
 	}
 
 	var headerOrAVI string
@@ -174,26 +207,79 @@ func (a *accountDB) SetAccountHeaderOrAvatar(ctx context.Context, mediaAttachmen
 	case mediaAttachment.Header:
 		headerOrAVI = "header"
 	default:
-		return errors.New("given media attachment was neither a header nor an avatar")
+		return nil, errors.New("given media attachment was neither a header nor an avatar")
 	}
+	column := fmt.Sprintf("%s_media_attachment_id", headerOrAVI)
+
+	var replaced *gtsmodel.MediaAttachment
+	if err := a.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		// find out which attachment is currently set, if any
+		var oldID string
+		if err := tx.
+			NewSelect().
+			Model(&gtsmodel.Account{}).
+			Column(column).
+			Where("id = ?", accountID).
+			Scan(ctx, &oldID); err != nil {
+			return err
+		}
+
+		if _, err := tx.
+			NewInsert().
+			Model(mediaAttachment).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		if _, err := tx.
+			NewUpdate().
+			Model(&gtsmodel.Account{}).
+			Set("? = ?", bun.Ident(column), mediaAttachment.ID).
+			Where("id = ?", accountID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		if oldID == "" || oldID == mediaAttachment.ID {
+			return nil
+		}
+
+		// remove the row of the attachment we just replaced
+		old := &gtsmodel.MediaAttachment{}
+		if err := tx.
+			NewSelect().
+			Model(old).
+			Where("id = ?", oldID).
+			Scan(ctx); err != nil {
+			if errors.Is(err, sql.ErrNoRows) {
+				// nothing to clean up
+				return nil
+			}
+			return err
+		}
+
+		if _, err := tx.
+			NewDelete().
+			Model(old).
+			WherePK().
+			Exec(ctx); err != nil {
+			return err
+		}
 
-	// TODO: there are probably more side effects here that need to be handled
-	if _, err := a.conn.
-		NewInsert().
-		Model(mediaAttachment).
-		Exec(ctx); err != nil {
-		return a.conn.ProcessError(err)
+		replaced = old
+		return nil
+	}); err != nil {
+		return nil, err
 	}
-	if _, err := a.conn.
-		NewUpdate().
-		Model(&gtsmodel.Account{}).
-		Set(fmt.Sprintf("%s_media_attachment_id = ?", headerOrAVI), mediaAttachment.ID).
-		Where("id = ?", accountID).
-		Exec(ctx); err != nil {
-		return a.conn.ProcessError(err)
+
+	// the account was updated behind the cache's back, so refresh it
+	account := &gtsmodel.Account{}
+	if err := a.newAccountQ(account).Where("account.id = ?", accountID).Scan(ctx); err != nil {
+		return nil, a.conn.ProcessError(err)
 	}
+	a.cache.Put(account)
 
-	return nil
+	return replaced, nil
 }
 
 func (a *accountDB) GetLocalAccountByUsername(ctx context.Context, username string) (*gtsmodel.Account, db.Error) {
@@ -231,7 +317,38 @@ func (a *accountDB) CountAccountStatuses(ctx context.Context, accountID string)
 		Count(ctx)
 }
 
-func (a *accountDB) GetAccountStatuses(ctx context.Context, accountID string, limit int, excludeReplies bool, excludeReblogs bool, maxID string, minID string, pinnedOnly bool, mediaOnly bool, publicOnly bool) ([]*gtsmodel.Status, db.Error) {
+func (a *accountDB) CountAccountStatusesByVisibility(ctx context.Context, accountID string) (map[gtsmodel.Visibility]int, db.Error) {
+	rows := []struct {
+		Visibility gtsmodel.Visibility
+		Count      int
+	}{}
+
+	q := a.conn.
+		NewSelect().
+		Model((*gtsmodel.Status)(nil)).
+		Column("visibility").
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("count")).
+		Where("account_id = ?", accountID).
+		Group("visibility")
+
+	if err := q.Scan(ctx, &rows); err != nil {
+		return nil, a.conn.ProcessError(err)
+	}
+
+	counts := map[gtsmodel.Visibility]int{
+		gtsmodel.VisibilityPublic:        0,
+		gtsmodel.VisibilityUnlocked:      0,
+		gtsmodel.VisibilityFollowersOnly: 0,
+		gtsmodel.VisibilityMutualsOnly:   0,
+		gtsmodel.VisibilityDirect:        0,
+	}
+	for _, row := range rows {
+		counts[row.Visibility] = row.Count
+	}
+	return counts, nil
+}
+
+func (a *accountDB) GetAccountStatuses(ctx context.Context, accountID string, limit int, excludeReplies bool, excludeReblogs bool, maxID string, minID string, pinnedOnly bool, mediaOnly bool, publicOnly bool, excludeDirect bool) ([]*gtsmodel.Status, db.Error) {
 	statuses := []*gtsmodel.Status{}
 
 	q := a.conn.
@@ -285,6 +402,10 @@ func (a *accountDB) GetAccountStatuses(ctx context.Context, accountID string, li
 		q = q.Where("visibility = ?", gtsmodel.VisibilityPublic)
 	}
 
+	if excludeDirect {
+		q = q.Where("visibility != ?", gtsmodel.VisibilityDirect)
+	}
+
 	if err := q.Scan(ctx); err != nil {
 		return nil, a.conn.ProcessError(err)
 	}
@@ -27,6 +27,7 @@ import (
 
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
@@ -46,6 +47,101 @@ func (suite *AccountTestSuite) TestGetAccountByIDWithExtras() {
 	suite.NotEmpty(account.HeaderMediaAttachment.URL)
 }
 
+func (suite *AccountTestSuite) TestGetAccountStatusesExcludeDirect() {
+	ctx := context.Background()
+	accountID := suite.testAccounts["local_account_2"].ID
+
+	all, err := suite.db.GetAccountStatuses(ctx, accountID, 0, false, false, "", "", false, false, false, false)
+	suite.NoError(err)
+
+	direct := 0
+	followersOnly := 0
+	for _, s := range all {
+		switch s.Visibility {
+		case gtsmodel.VisibilityDirect:
+			direct++
+		case gtsmodel.VisibilityFollowersOnly:
+			followersOnly++
+		}
+	}
+	suite.NotZero(direct)
+	suite.NotZero(followersOnly)
+
+	statuses, err := suite.db.GetAccountStatuses(ctx, accountID, 0, false, false, "", "", false, false, false, true)
+	suite.NoError(err)
+	suite.Len(statuses, len(all)-direct)
+	for _, s := range statuses {
+		suite.NotEqual(gtsmodel.VisibilityDirect, s.Visibility)
+	}
+}
+
+func (suite *AccountTestSuite) TestCountAccountStatusesByVisibility() {
+	ctx := context.Background()
+	accountID := suite.testAccounts["local_account_2"].ID
+
+	expected := map[gtsmodel.Visibility]int{
+		gtsmodel.VisibilityPublic:        0,
+		gtsmodel.VisibilityUnlocked:      0,
+		gtsmodel.VisibilityFollowersOnly: 0,
+		gtsmodel.VisibilityMutualsOnly:   0,
+		gtsmodel.VisibilityDirect:        0,
+	}
+	total := 0
+	for _, s := range suite.testStatuses {
+		if s.AccountID == accountID {
+			expected[s.Visibility]++
+			total++
+		}
+	}
+
+	counts, err := suite.db.CountAccountStatusesByVisibility(ctx, accountID)
+	suite.NoError(err)
+	suite.Equal(expected, counts)
+
+	// the breakdown adds up to the total
+	count, err := suite.db.CountAccountStatuses(ctx, accountID)
+	suite.NoError(err)
+	suite.Equal(total, count)
+}
+
+func (suite *AccountTestSuite) TestCountAccountStatusesByVisibilityNoStatuses() {
+	counts, err := suite.db.CountAccountStatusesByVisibility(context.Background(), "01GAXSMQ1Y6YQ3DEQ6ZAXXZ6NB")
+	suite.NoError(err)
+	suite.Len(counts, 5)
+	for _, count := range counts {
+		suite.Zero(count)
+	}
+}
+
+func (suite *AccountTestSuite) TestGetAccountsLastPosted() {
+	ctx := context.Background()
+	neverPosted := "01GAXSMQ1Y6YQ3DEQ6ZAXXZ6NB"
+	accountIDs := []string{
+		suite.testAccounts["local_account_1"].ID,
+		suite.testAccounts["local_account_2"].ID,
+		suite.testAccounts["admin_account"].ID,
+		neverPosted,
+	}
+
+	lastPosted, err := suite.db.GetAccountsLastPosted(ctx, accountIDs)
+	suite.NoError(err)
+	suite.Len(lastPosted, len(accountIDs))
+
+	// the batch agrees with getting them one by one
+	for _, id := range accountIDs[:3] {
+		expected, err := suite.db.GetAccountLastPosted(ctx, id)
+		suite.NoError(err)
+		suite.NotZero(lastPosted[id])
+		suite.True(expected.Equal(lastPosted[id]), "account %s: expected %s, got %s", id, expected, lastPosted[id])
+	}
+	suite.Zero(lastPosted[neverPosted])
+
+	// no accounts, no query
+	lastPosted, err = suite.db.GetAccountsLastPosted(ctx, nil)
+	suite.NoError(err)
+	suite.Empty(lastPosted)
+}
+
 func (suite *AccountTestSuite) TestUpdateAccount() {
 	testAccount := suite.testAccounts["local_account_1"]
 
@@ -88,6 +184,74 @@ func (suite *AccountTestSuite) TestInsertAccountWithDefaults() {
 	suite.False(newAccount.HideCollections)
 }
 
+func (suite *AccountTestSuite) TestSetAccountHeader() {
+	testAccount := suite.testAccounts["local_account_1"]
+	oldHeader := suite.testAttachments["local_account_1_header"]
+
+	newHeader := &gtsmodel.MediaAttachment{}
+	*newHeader = *oldHeader
+	newHeader.ID = "01G4A8Q0DJ0B7SC3Q5H3QH0P5G"
+	newHeader.URL = "http://localhost:8080/fileserver/01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01G4A8Q0DJ0B7SC3Q5H3QH0P5G.jpeg"
+	newHeader.File.Path = "01F8MH1H7YV1Z7D2C8K2730QBF/header/original/01G4A8Q0DJ0B7SC3Q5H3QH0P5G.jpeg"
+	newHeader.Thumbnail.Path = "01F8MH1H7YV1Z7D2C8K2730QBF/header/small/01G4A8Q0DJ0B7SC3Q5H3QH0P5G.jpeg"
+
+	replaced, err := suite.db.SetAccountHeaderOrAvatar(context.Background(), newHeader, testAccount.ID)
+	suite.NoError(err)
+	suite.NotNil(replaced)
+	suite.Equal(oldHeader.ID, replaced.ID)
+	suite.Equal(oldHeader.File.Path, replaced.File.Path)
+	suite.Equal(oldHeader.Thumbnail.Path, replaced.Thumbnail.Path)
+
+	// the old header should be gone
+	_, err = suite.db.GetAttachmentByID(context.Background(), oldHeader.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// the account should point at the new one, and the avatar should be untouched
+	updated, err := suite.db.GetAccountByID(context.Background(), testAccount.ID)
+	suite.NoError(err)
+	suite.Equal(newHeader.ID, updated.HeaderMediaAttachmentID)
+	suite.Equal(testAccount.AvatarMediaAttachmentID, updated.AvatarMediaAttachmentID)
+}
+
+func (suite *AccountTestSuite) TestSetAccountAvatarNoPrevious() {
+	// admin account has no avatar set
+	testAccount := suite.testAccounts["admin_account"]
+	suite.Empty(testAccount.AvatarMediaAttachmentID)
+
+	newAvatar := &gtsmodel.MediaAttachment{}
+	*newAvatar = *suite.testAttachments["local_account_1_avatar"]
+	newAvatar.ID = "01G4A8Q0DJ0B7SC3Q5H3QH0P5G"
+	newAvatar.AccountID = testAccount.ID
+
+	replaced, err := suite.db.SetAccountHeaderOrAvatar(context.Background(), newAvatar, testAccount.ID)
+	suite.NoError(err)
+	suite.Nil(replaced)
+
+	updated, err := suite.db.GetAccountByID(context.Background(), testAccount.ID)
+	suite.NoError(err)
+	suite.Equal(newAvatar.ID, updated.AvatarMediaAttachmentID)
+}
+
+func (suite *AccountTestSuite) TestSetAccountHeaderRollback() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// reuse the ID of an existing attachment, so that the insert fails partway through
+	attachment := &gtsmodel.MediaAttachment{}
+	*attachment = *suite.testAttachments["local_account_1_header"]
+	attachment.ID = suite.testAttachments["admin_account_status_1_attachment_1"].ID
+
+	_, err := suite.db.SetAccountHeaderOrAvatar(context.Background(), attachment, testAccount.ID)
+	suite.Error(err)
+
+	// nothing should have changed
+	_, err = suite.db.GetAttachmentByID(context.Background(), testAccount.HeaderMediaAttachmentID)
+	suite.NoError(err)
+
+	dbAccount, err := suite.db.GetAccountByID(context.Background(), testAccount.ID)
+	suite.NoError(err)
+	suite.Equal(testAccount.HeaderMediaAttachmentID, dbAccount.HeaderMediaAttachmentID)
+}
+
 func TestAccountTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountTestSuite))
 }
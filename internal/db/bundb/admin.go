@@ -38,6 +38,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/uptrace/bun"
 	"golang.org/x/crypto/bcrypt"
 )
 
@@ -134,7 +135,7 @@ func (a *adminDB) NewSignup(ctx context.Context, username string, reason string,
 		}
 	}
 
-	pw, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	pw, err := bcrypt.GenerateFromPassword([]byte(password), viper.GetInt(config.Keys.AccountsPasswordBcryptCost))
 	if err != nil {
 		return nil, fmt.Errorf("error hashing password: %s", err)
 	}
@@ -279,3 +280,59 @@ func (a *adminDB) CreateInstanceInstance(ctx context.Context) db.Error {
 	logrus.Infof("created instance instance %s with id %s", host, i.ID)
 	return nil
 }
+
+func (a *adminDB) CountSignUpIPs(ctx context.Context, ipHash string, since time.Time) (int, db.Error) {
+	count, err := a.conn.
+		NewSelect().
+		Model(&gtsmodel.SignUpIP{}).
+		Where("? = ?", bun.Ident("ip_hash"), ipHash).
+		Where("? >= ?", bun.Ident("created_at"), since).
+		Count(ctx)
+	if err != nil {
+		return 0, a.conn.ProcessError(err)
+	}
+	return count, nil
+}
+
+func (a *adminDB) DeleteSignUpIPsBefore(ctx context.Context, before time.Time) db.Error {
+	if _, err := a.conn.
+		NewDelete().
+		Model(&gtsmodel.SignUpIP{}).
+		Where("? < ?", bun.Ident("created_at"), before).
+		Exec(ctx); err != nil {
+		return a.conn.ProcessError(err)
+	}
+	return nil
+}
+
+func (a *adminDB) CountFailedSignIn(ctx context.Context, userID string, windowStart time.Time) (int, db.Error) {
+	// start counting from scratch if nothing's being counted yet, or if the current count is out of the window
+	restart := "failed_sign_in_count = 0 OR failed_sign_in_at IS NULL OR failed_sign_in_at < ?"
+
+	var count int
+	if _, err := a.conn.
+		NewUpdate().
+		Model(&gtsmodel.User{}).
+		Set("failed_sign_in_count = CASE WHEN "+restart+" THEN 1 ELSE failed_sign_in_count + 1 END", windowStart).
+		Set("failed_sign_in_at = CASE WHEN "+restart+" THEN ? ELSE failed_sign_in_at END", windowStart, time.Now()).
+		Where("? = ?", bun.Ident("id"), userID).
+		Returning("failed_sign_in_count").
+		Exec(ctx, &count); err != nil {
+		return 0, a.conn.ProcessError(err)
+	}
+	return count, nil
+}
+
+func (a *adminDB) LockSignIns(ctx context.Context, userID string, until time.Time) db.Error {
+	if _, err := a.conn.
+		NewUpdate().
+		Model(&gtsmodel.User{}).
+		Set("? = ?", bun.Ident("sign_in_locked_until"), until).
+		Set("? = 0", bun.Ident("failed_sign_in_count")).
+		Set("? = NULL", bun.Ident("failed_sign_in_at")).
+		Where("? = ?", bun.Ident("id"), userID).
+		Exec(ctx); err != nil {
+		return a.conn.ProcessError(err)
+	}
+	return nil
+}
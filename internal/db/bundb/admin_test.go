@@ -20,9 +20,12 @@ package bundb_test
 
 import (
 	"context"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -51,6 +54,55 @@ func (suite *AdminTestSuite) TestCreateInstanceAccount() {
 	suite.NotNil(acct)
 }
 
+func (suite *AdminTestSuite) TestCountFailedSignIn() {
+	ctx := context.Background()
+	testUser := suite.testUsers["local_account_1"]
+	windowStart := time.Now().Add(-time.Hour)
+
+	// concurrent failures all get counted
+	wg := sync.WaitGroup{}
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := suite.db.CountFailedSignIn(ctx, testUser.ID, windowStart)
+			suite.NoError(err)
+		}()
+	}
+	wg.Wait()
+
+	count, err := suite.db.CountFailedSignIn(ctx, testUser.ID, windowStart)
+	suite.NoError(err)
+	suite.Equal(6, count)
+
+	// once the first counted failure is out of the window, counting starts again
+	count, err = suite.db.CountFailedSignIn(ctx, testUser.ID, time.Now().Add(time.Second))
+	suite.NoError(err)
+	suite.Equal(1, count)
+
+	user := &gtsmodel.User{}
+	suite.NoError(suite.db.GetByID(ctx, testUser.ID, user))
+	suite.Equal(1, user.FailedSignInCount)
+	suite.WithinDuration(time.Now(), user.FailedSignInAt, time.Minute)
+}
+
+func (suite *AdminTestSuite) TestLockSignIns() {
+	ctx := context.Background()
+	testUser := suite.testUsers["local_account_1"]
+
+	_, err := suite.db.CountFailedSignIn(ctx, testUser.ID, time.Now().Add(-time.Hour))
+	suite.NoError(err)
+
+	until := time.Now().Add(time.Hour)
+	suite.NoError(suite.db.LockSignIns(ctx, testUser.ID, until))
+
+	user := &gtsmodel.User{}
+	suite.NoError(suite.db.GetByID(ctx, testUser.ID, user))
+	suite.WithinDuration(until, user.SignInLockedUntil, time.Second)
+	suite.Zero(user.FailedSignInCount)
+	suite.True(user.FailedSignInAt.IsZero())
+}
+
 func TestAdminTestSuite(t *testing.T) {
 	suite.Run(t, new(AdminTestSuite))
 }
@@ -161,10 +161,10 @@ func (b *basicDB) DropTable(ctx context.Context, i interface{}) db.Error {
 }
 
 func (b *basicDB) IsHealthy(ctx context.Context) db.Error {
-	return b.conn.Ping()
+	return b.conn.DB.Ping()
 }
 
 func (b *basicDB) Stop(ctx context.Context) db.Error {
 	logrus.Info("closing db connection")
-	return b.conn.Close()
+	return b.conn.DB.Close()
 }
@@ -20,6 +20,7 @@ package bundb_test
 
 import (
 	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/suite"
@@ -64,6 +65,80 @@ func (suite *BasicTestSuite) TestGetAllNotNull() {
 	}
 }
 
+func (suite *BasicTestSuite) TestRunInTxCommit() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// get the account once first so that it's cached
+	_, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	suite.NoError(err)
+
+	err = suite.db.RunInTx(ctx, func(tx db.DB) error {
+		account, err := tx.GetAccountByID(ctx, testAccount.ID)
+		if err != nil {
+			return err
+		}
+		account.DisplayName = "changed in a transaction"
+		if _, err := tx.UpdateAccount(ctx, account); err != nil {
+			return err
+		}
+
+		// reads in the transaction see its own writes
+		account, err = tx.GetAccountByID(ctx, testAccount.ID)
+		suite.NoError(err)
+		suite.Equal("changed in a transaction", account.DisplayName)
+		return nil
+	})
+	suite.NoError(err)
+
+	// the change was committed, and the old cached account dropped
+	account, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Equal("changed in a transaction", account.DisplayName)
+}
+
+func (suite *BasicTestSuite) TestRunInTxRollback() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	domainBlock := &gtsmodel.DomainBlock{
+		ID:                 "01G66QZK0D3S4W1A5M1CT9G9XK",
+		Domain:             "rolled.back.example.org",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}
+
+	txErr := errors.New("something went wrong")
+	err := suite.db.RunInTx(ctx, func(tx db.DB) error {
+		if err := tx.Put(ctx, domainBlock); err != nil {
+			return err
+		}
+
+		account, err := tx.GetAccountByID(ctx, testAccount.ID)
+		if err != nil {
+			return err
+		}
+		account.DisplayName = "changed in a transaction"
+		if _, err := tx.UpdateAccount(ctx, account); err != nil {
+			return err
+		}
+
+		// a transaction within the transaction is rolled back with it
+		return tx.RunInTx(ctx, func(tx db.DB) error {
+			return txErr
+		})
+	})
+	suite.ErrorIs(err, txErr)
+
+	// neither the domain block nor the account change made it
+	blocked, err := suite.db.IsDomainBlocked(ctx, domainBlock.Domain)
+	suite.NoError(err)
+	suite.False(blocked)
+
+	account, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Equal(testAccount.DisplayName, account.DisplayName)
+}
+
 func TestBasicTestSuite(t *testing.T) {
 	suite.Run(t, new(BasicTestSuite))
 }
@@ -77,15 +77,29 @@ type bunDBService struct {
 	db.Admin
 	db.Basic
 	db.Domain
+	db.Emoji
 	db.Instance
 	db.Media
 	db.Mention
 	db.Notification
+	db.PreviewCard
 	db.Relationship
 	db.Session
+	db.Setting
 	db.Status
+	db.Tag
 	db.Timeline
+	db.Token
+	db.Tombstone
 	conn *DBConn
+
+	// the cached sub-services, kept around so that
+	// their caches can be managed around transactions
+	accounts      *accountDB
+	statuses      *statusDB
+	emojis        *emojiDB
+	mentions      *mentionDB
+	notifications *notificationDB
 }
 
 func doMigration(ctx context.Context, db *bun.DB) error {
@@ -142,10 +156,15 @@ func NewBunDBService(ctx context.Context) (db.DB, error) {
 		conn.DB.AddQueryHook(newDebugQueryHook())
 	}
 
+	// add a hook to warn about slow queries, if a threshold is set
+	if threshold := viper.GetDuration(config.Keys.DbSlowQueryThreshold); threshold > 0 {
+		conn.DB.AddQueryHook(newSlowQueryHook(threshold))
+	}
+
 	// table registration is needed for many-to-many, see:
 	// https://bun.uptrace.dev/orm/many-to-many-relation/
 	for _, t := range registerTables {
-		conn.RegisterModel(t)
+		conn.DB.RegisterModel(t)
 	}
 
 	// perform any pending database migrations: this includes
@@ -155,9 +174,21 @@ func NewBunDBService(ctx context.Context) (db.DB, error) {
 		return nil, fmt.Errorf("db migration error: %s", err)
 	}
 
+	ps := newBunDBService(conn)
+
+	// we can confidently return this useable service now
+	return ps, nil
+}
+
+// newBunDBService returns a bunDBService which runs its queries on the given conn, with fresh caches.
+func newBunDBService(conn *DBConn) *bunDBService {
 	accounts := &accountDB{conn: conn, cache: cache.NewAccountCache()}
+	statuses := &statusDB{conn: conn, cache: cache.NewStatusCache(), accounts: accounts}
+	emojis := &emojiDB{conn: conn, cache: cache.NewEmojiCache()}
+	mentions := &mentionDB{conn: conn, cache: ttlcache.NewCache()}
+	notifications := &notificationDB{conn: conn, cache: ttlcache.NewCache()}
 
-	ps := &bunDBService{
+	return &bunDBService{
 		Account: accounts,
 		Admin: &adminDB{
 			conn: conn,
@@ -168,19 +199,18 @@ func NewBunDBService(ctx context.Context) (db.DB, error) {
 		Domain: &domainDB{
 			conn: conn,
 		},
+		Emoji: emojis,
 		Instance: &instanceDB{
-			conn: conn,
+			conn:     conn,
+			accounts: accounts,
 		},
 		Media: &mediaDB{
 			conn: conn,
 		},
-		Mention: &mentionDB{
-			conn:  conn,
-			cache: ttlcache.NewCache(),
-		},
-		Notification: &notificationDB{
-			conn:  conn,
-			cache: ttlcache.NewCache(),
+		Mention:      mentions,
+		Notification: notifications,
+		PreviewCard: &previewCardDB{
+			conn: conn,
 		},
 		Relationship: &relationshipDB{
 			conn: conn,
@@ -188,19 +218,77 @@ func NewBunDBService(ctx context.Context) (db.DB, error) {
 		Session: &sessionDB{
 			conn: conn,
 		},
-		Status: &statusDB{
-			conn:     conn,
-			cache:    cache.NewStatusCache(),
-			accounts: accounts,
+		Setting: &settingDB{
+			conn: conn,
+		},
+		Status: statuses,
+		Tag: &tagDB{
+			conn: conn,
 		},
 		Timeline: &timelineDB{
+			conn:     conn,
+			statuses: statuses,
+		},
+		Token: &tokenDB{
 			conn: conn,
 		},
-		conn: conn,
+		Tombstone: &tombstoneDB{
+			conn: conn,
+		},
+		conn:          conn,
+		accounts:      accounts,
+		statuses:      statuses,
+		emojis:        emojis,
+		mentions:      mentions,
+		notifications: notifications,
+	}
+}
+
+// RunInTx implements db.DB RunInTx.
+//
+// The DB passed to fn runs its queries in the transaction, and has its own caches, so that nothing
+// read or written in the transaction is visible outside of it before it's committed. Once it's been
+// committed, anything that was cached in the transaction is dropped from our own caches, since it may
+// have been changed.
+func (ps *bunDBService) RunInTx(ctx context.Context, fn func(tx db.DB) error) db.Error {
+	if ps.conn.inTx() {
+		return ps.conn.ProcessError(fn(ps))
 	}
 
-	// we can confidently return this useable service now
-	return ps, nil
+	var txService *bunDBService
+	defer func() {
+		if txService != nil {
+			txService.closeCaches()
+		}
+	}()
+
+	if err := ps.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		txService = newBunDBService(ps.conn.withTx(tx))
+		return fn(txService)
+	}); err != nil {
+		return err
+	}
+
+	for _, id := range txService.accounts.cache.IDs() {
+		ps.accounts.cache.Invalidate(id)
+	}
+	for _, id := range txService.statuses.cache.IDs() {
+		ps.statuses.cache.Invalidate(id)
+	}
+	for _, id := range txService.emojis.cache.IDs() {
+		ps.emojis.cache.Invalidate(id)
+	}
+
+	return nil
+}
+
+// closeCaches closes the caches of this service, which shouldn't be used afterwards.
+func (ps *bunDBService) closeCaches() {
+	ps.accounts.cache.Close()
+	ps.statuses.cache.Close()
+	ps.emojis.cache.Close()
+	ps.mentions.cache.Close()
+	ps.notifications.cache.Close()
 }
 
 func sqliteConn(ctx context.Context) (*DBConn, error) {
@@ -239,7 +327,7 @@ func sqliteConn(ctx context.Context) (*DBConn, error) {
 	conn := WrapDBConn(bun.NewDB(sqldb, sqlitedialect.New()))
 
 	// ping to check the db is there and listening
-	if err := conn.PingContext(ctx); err != nil {
+	if err := conn.DB.PingContext(ctx); err != nil {
 		if errWithCode, ok := err.(*sqlite.Error); ok {
 			err = errors.New(sqlite.ErrorCodeString[errWithCode.Code()])
 		}
@@ -263,7 +351,7 @@ func pgConn(ctx context.Context) (*DBConn, error) {
 	conn := WrapDBConn(bun.NewDB(sqldb, pgdialect.New()))
 
 	// ping to check the db is there and listening
-	if err := conn.PingContext(ctx); err != nil {
+	if err := conn.DB.PingContext(ctx); err != nil {
 		return nil, fmt.Errorf("postgres ping: %s", err)
 	}
 
@@ -422,18 +510,17 @@ func (ps *bunDBService) TagStringsToTags(ctx context.Context, tags []string, ori
 }
 
 func (ps *bunDBService) EmojiStringsToEmojis(ctx context.Context, emojis []string) ([]*gtsmodel.Emoji, error) {
+	// resolve all the emojis in one go; shortcodes that don't match an emoji are just left out
+	found, err := ps.GetEmojisByShortcodeDomain(ctx, emojis, "")
+	if err != nil {
+		return nil, fmt.Errorf("error getting emojis with shortcodes %v: %s", emojis, err)
+	}
+
 	newEmojis := []*gtsmodel.Emoji{}
-	for _, e := range emojis {
-		emoji := &gtsmodel.Emoji{}
-		err := ps.conn.NewSelect().Model(emoji).Where("shortcode = ?", e).Where("visible_in_picker = true").Where("disabled = false").Scan(ctx)
-		if err != nil {
-			if err == sql.ErrNoRows {
-				// no result found for this username/domain so just don't include it as an emoji and carry on about our business
-				logrus.Debugf("no emoji found with shortcode %s, skipping it", e)
-				continue
-			}
-			// a serious error has happened so bail
-			return nil, fmt.Errorf("error getting emoji with shortcode %s: %s", e, err)
+	for _, emoji := range found {
+		if !emoji.VisibleInPicker || emoji.Disabled {
+			logrus.Debugf("emoji with shortcode %s is not usable, skipping it", emoji.Shortcode)
+			continue
 		}
 		newEmojis = append(newEmojis, emoji)
 	}
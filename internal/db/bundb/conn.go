@@ -14,7 +14,9 @@ type DBConn struct {
 	// TODO: move *Config here, no need to be in each struct type
 
 	errProc func(error) db.Error // errProc is the SQL-type specific error processor
-	*bun.DB                      // DB is the underlying bun.DB connection
+	bun.IDB                      // IDB is what queries are run on: the underlying bun.DB, or a transaction on it
+	DB      *bun.DB              // DB is the underlying bun.DB connection
+	tx      *bun.Tx              // tx is the transaction this conn is scoped to, if any
 }
 
 // WrapDBConn @TODO
@@ -30,14 +32,36 @@ func WrapDBConn(dbConn *bun.DB) *DBConn {
 	}
 	return &DBConn{
 		errProc: errProc,
+		IDB:     dbConn,
 		DB:      dbConn,
 	}
 }
 
+// withTx returns a copy of this conn which runs all its queries in the given transaction.
+func (conn *DBConn) withTx(tx bun.Tx) *DBConn {
+	return &DBConn{
+		errProc: conn.errProc,
+		IDB:     tx,
+		DB:      conn.DB,
+		tx:      &tx,
+	}
+}
+
+// inTx returns true if this conn is scoped to a transaction.
+func (conn *DBConn) inTx() bool {
+	return conn.tx != nil
+}
+
 // RunInTx wraps execution of the supplied transaction function.
+// If this conn is already scoped to a transaction, the function is
+// just run in that one, and it's left to the outer caller to commit.
 func (conn *DBConn) RunInTx(ctx context.Context, fn func(bun.Tx) error) db.Error {
+	if conn.inTx() {
+		return conn.ProcessError(fn(*conn.tx))
+	}
+
 	// Acquire a new transaction
-	tx, err := conn.BeginTx(ctx, nil)
+	tx, err := conn.DB.BeginTx(ctx, nil)
 	if err != nil {
 		return conn.ProcessError(err)
 	}
@@ -76,3 +76,51 @@ func (d *domainDB) AreURIsBlocked(ctx context.Context, uris []*url.URL) (bool, d
 
 	return d.AreDomainsBlocked(ctx, domains)
 }
+
+func (d *domainDB) IsDomainPaused(ctx context.Context, domain string) (bool, db.Error) {
+	if domain == "" {
+		return false, nil
+	}
+
+	q := d.conn.
+		NewSelect().
+		Model(&gtsmodel.DomainPause{}).
+		ExcludeColumn("id", "created_at", "updated_at", "created_by_account_id", "private_comment").
+		Where("domain = ?", strings.ToLower(domain)).
+		Limit(1)
+
+	return d.conn.Exists(ctx, q)
+}
+
+func (d *domainDB) AreURIsPaused(ctx context.Context, uris []*url.URL) (bool, db.Error) {
+	domains := []string{}
+	for _, uri := range uris {
+		domains = append(domains, uri.Hostname())
+	}
+
+	for _, domain := range util.UniqueStrings(domains) {
+		if paused, err := d.IsDomainPaused(ctx, domain); err != nil {
+			return false, err
+		} else if paused {
+			return true, nil
+		}
+	}
+
+	// no pauses found
+	return false, nil
+}
+
+func (d *domainDB) IsDomainSilenced(ctx context.Context, domain string) (bool, db.Error) {
+	if domain == "" {
+		return false, nil
+	}
+
+	q := d.conn.
+		NewSelect().
+		Model(&gtsmodel.DomainSilence{}).
+		ExcludeColumn("id", "created_at", "updated_at", "created_by_account_id", "private_comment").
+		Where("domain = ?", strings.ToLower(domain)).
+		Limit(1)
+
+	return d.conn.Exists(ctx, q)
+}
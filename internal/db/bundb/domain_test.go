@@ -52,6 +52,58 @@ func (suite *DomainTestSuite) TestIsDomainBlocked() {
 	suite.True(blocked)
 }
 
+func (suite *DomainTestSuite) TestIsDomainPaused() {
+	ctx := context.Background()
+
+	domainPause := &gtsmodel.DomainPause{
+		ID:                 "01G5BHQ8ZE3MRVD4PKV1D8QW0R",
+		Domain:             "some.noisy.apples",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}
+
+	// no domain pause exists for the given domain yet
+	paused, err := suite.db.IsDomainPaused(ctx, domainPause.Domain)
+	suite.NoError(err)
+	suite.False(paused)
+
+	suite.NoError(suite.db.Put(ctx, domainPause))
+
+	// domain pause now exists, but the domain isn't blocked
+	paused, err = suite.db.IsDomainPaused(ctx, "Some.Noisy.Apples")
+	suite.NoError(err)
+	suite.True(paused)
+
+	blocked, err := suite.db.IsDomainBlocked(ctx, domainPause.Domain)
+	suite.NoError(err)
+	suite.False(blocked)
+}
+
+func (suite *DomainTestSuite) TestIsDomainSilenced() {
+	ctx := context.Background()
+
+	domainSilence := &gtsmodel.DomainSilence{
+		ID:                 "01G5MAY3YV6AW3XJ5J1B0S7T2M",
+		Domain:             "some.loud.apples",
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}
+
+	// no domain silence exists for the given domain yet
+	silenced, err := suite.db.IsDomainSilenced(ctx, domainSilence.Domain)
+	suite.NoError(err)
+	suite.False(silenced)
+
+	suite.NoError(suite.db.Put(ctx, domainSilence))
+
+	// domain silence now exists, but the domain isn't blocked
+	silenced, err = suite.db.IsDomainSilenced(ctx, "Some.Loud.Apples")
+	suite.NoError(err)
+	suite.True(silenced)
+
+	blocked, err := suite.db.IsDomainBlocked(ctx, domainSilence.Domain)
+	suite.NoError(err)
+	suite.False(blocked)
+}
+
 func TestDomainTestSuite(t *testing.T) {
 	suite.Run(t, new(DomainTestSuite))
 }
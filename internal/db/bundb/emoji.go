@@ -0,0 +1,182 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/cache"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type emojiDB struct {
+	conn  *DBConn
+	cache *cache.EmojiCache
+}
+
+func (e *emojiDB) GetEmojiByID(ctx context.Context, id string) (*gtsmodel.Emoji, db.Error) {
+	return e.getEmoji(
+		ctx,
+		func() (*gtsmodel.Emoji, bool) {
+			return e.cache.GetByID(id)
+		},
+		func(emoji *gtsmodel.Emoji) error {
+			return e.conn.NewSelect().Model(emoji).Where("emoji.id = ?", id).Scan(ctx)
+		},
+	)
+}
+
+func (e *emojiDB) GetEmojiByShortcodeDomain(ctx context.Context, shortcode string, domain string) (*gtsmodel.Emoji, db.Error) {
+	return e.getEmoji(
+		ctx,
+		func() (*gtsmodel.Emoji, bool) {
+			return e.cache.GetByShortcodeDomain(shortcode, domain)
+		},
+		func(emoji *gtsmodel.Emoji) error {
+			return e.conn.
+				NewSelect().
+				Model(emoji).
+				Where("emoji.shortcode = ?", shortcode).
+				Where("emoji.domain = ?", domain).
+				Scan(ctx)
+		},
+	)
+}
+
+func (e *emojiDB) GetEmojisByShortcodeDomain(ctx context.Context, shortcodes []string, domain string) ([]*gtsmodel.Emoji, db.Error) {
+	found := make(map[string]*gtsmodel.Emoji, len(shortcodes))
+
+	// take what we can from the cache, and note what we can't
+	uncached := []string{}
+	for _, shortcode := range shortcodes {
+		if emoji, cached := e.cache.GetByShortcodeDomain(shortcode, domain); cached {
+			found[shortcode] = emoji
+		} else {
+			uncached = append(uncached, shortcode)
+		}
+	}
+
+	// fetch the rest in one go
+	if len(uncached) != 0 {
+		fetched := []*gtsmodel.Emoji{}
+		if err := e.conn.
+			NewSelect().
+			Model(&fetched).
+			Where("emoji.shortcode IN (?)", bun.In(uncached)).
+			Where("emoji.domain = ?", domain).
+			Scan(ctx); err != nil {
+			return nil, e.conn.ProcessError(err)
+		}
+
+		for _, emoji := range fetched {
+			e.cache.Put(emoji)
+			found[emoji.Shortcode] = emoji
+		}
+	}
+
+	emojis := make([]*gtsmodel.Emoji, 0, len(found))
+	for _, shortcode := range shortcodes {
+		if emoji, ok := found[shortcode]; ok {
+			emojis = append(emojis, emoji)
+		}
+	}
+
+	return emojis, nil
+}
+
+func (e *emojiDB) getEmoji(ctx context.Context, cacheGet func() (*gtsmodel.Emoji, bool), dbQuery func(*gtsmodel.Emoji) error) (*gtsmodel.Emoji, db.Error) {
+	// Attempt to fetch cached emoji
+	emoji, cached := cacheGet()
+
+	if !cached {
+		emoji = &gtsmodel.Emoji{}
+
+		// Not cached! Perform database query
+		if err := dbQuery(emoji); err != nil {
+			return nil, e.conn.ProcessError(err)
+		}
+
+		// Place in the cache
+		e.cache.Put(emoji)
+	}
+
+	return emoji, nil
+}
+
+func (e *emojiDB) GetLocalEnabledEmojis(ctx context.Context) ([]*gtsmodel.Emoji, db.Error) {
+	emojis := []*gtsmodel.Emoji{}
+
+	if err := e.conn.
+		NewSelect().
+		Model(&emojis).
+		Where("emoji.domain = ''").
+		Where("emoji.disabled = false").
+		Order("emoji.shortcode ASC").
+		Scan(ctx); err != nil {
+		return nil, e.conn.ProcessError(err)
+	}
+
+	return emojis, nil
+}
+
+func (e *emojiDB) PutEmoji(ctx context.Context, emoji *gtsmodel.Emoji) db.Error {
+	if _, err := e.conn.
+		NewInsert().
+		Model(emoji).
+		Exec(ctx); err != nil {
+		return e.conn.ProcessError(err)
+	}
+
+	e.cache.Put(emoji)
+	return nil
+}
+
+func (e *emojiDB) UpdateEmoji(ctx context.Context, emoji *gtsmodel.Emoji) (*gtsmodel.Emoji, db.Error) {
+	emoji.UpdatedAt = time.Now()
+
+	if _, err := e.conn.
+		NewUpdate().
+		Model(emoji).
+		WherePK().
+		Exec(ctx); err != nil {
+		return nil, e.conn.ProcessError(err)
+	}
+
+	// Place updated emoji in cache
+	// (this will replace existing, i.e. invalidating)
+	e.cache.Put(emoji)
+
+	return emoji, nil
+}
+
+func (e *emojiDB) DeleteEmojiByID(ctx context.Context, id string) db.Error {
+	if _, err := e.conn.
+		NewDelete().
+		Model(&gtsmodel.Emoji{}).
+		Where("emoji.id = ?", id).
+		Exec(ctx); err != nil {
+		return e.conn.ProcessError(err)
+	}
+
+	e.cache.Invalidate(id)
+	return nil
+}
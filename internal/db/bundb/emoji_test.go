@@ -0,0 +1,96 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type EmojiTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *EmojiTestSuite) TestGetEmojiByShortcodeDomain() {
+	emoji, err := suite.db.GetEmojiByShortcodeDomain(context.Background(), "rainbow", "")
+	suite.NoError(err)
+	suite.Equal(testrig.NewTestEmojis()["rainbow"].ID, emoji.ID)
+
+	// there's no remote emoji with this shortcode
+	_, err = suite.db.GetEmojiByShortcodeDomain(context.Background(), "rainbow", "fossbros-anonymous.io")
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *EmojiTestSuite) TestGetEmojisByShortcodeDomain() {
+	ctx := context.Background()
+
+	// cache one of the emojis already, to make sure cached and uncached emojis are mixed properly
+	_, err := suite.db.GetEmojiByShortcodeDomain(ctx, "rainbow", "")
+	suite.NoError(err)
+
+	emojis, err := suite.db.GetEmojisByShortcodeDomain(ctx, []string{"not_an_emoji", "rainbow"}, "")
+	suite.NoError(err)
+	suite.Len(emojis, 1)
+	suite.Equal("rainbow", emojis[0].Shortcode)
+
+	emojis, err = suite.db.GetEmojisByShortcodeDomain(ctx, []string{"rainbow"}, "fossbros-anonymous.io")
+	suite.NoError(err)
+	suite.Empty(emojis)
+}
+
+func (suite *EmojiTestSuite) TestUpdateEmojiInvalidatesCache() {
+	ctx := context.Background()
+
+	emoji, err := suite.db.GetEmojiByShortcodeDomain(ctx, "rainbow", "")
+	suite.NoError(err)
+
+	emoji.Shortcode = "double_rainbow"
+	_, err = suite.db.UpdateEmoji(ctx, emoji)
+	suite.NoError(err)
+
+	// the emoji should only be found under its new shortcode now
+	_, err = suite.db.GetEmojiByShortcodeDomain(ctx, "rainbow", "")
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	updated, err := suite.db.GetEmojiByShortcodeDomain(ctx, "double_rainbow", "")
+	suite.NoError(err)
+	suite.Equal(emoji.ID, updated.ID)
+}
+
+func (suite *EmojiTestSuite) TestDeleteEmojiInvalidatesCache() {
+	ctx := context.Background()
+
+	emoji, err := suite.db.GetEmojiByShortcodeDomain(ctx, "rainbow", "")
+	suite.NoError(err)
+
+	suite.NoError(suite.db.DeleteEmojiByID(ctx, emoji.ID))
+
+	_, err = suite.db.GetEmojiByID(ctx, emoji.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	_, err = suite.db.GetEmojiByShortcodeDomain(ctx, "rainbow", "")
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func TestEmojiTestSuite(t *testing.T) {
+	suite.Run(t, new(EmojiTestSuite))
+}
@@ -20,6 +20,7 @@ package bundb
 
 import (
 	"context"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
@@ -31,7 +32,8 @@ import (
 )
 
 type instanceDB struct {
-	conn *DBConn
+	conn     *DBConn
+	accounts *accountDB
 }
 
 func (i *instanceDB) CountInstanceUsers(ctx context.Context, domain string) (int, db.Error) {
@@ -78,6 +80,51 @@ func (i *instanceDB) CountInstanceStatuses(ctx context.Context, domain string) (
 	return count, nil
 }
 
+// activeUsersBatchSize is how many accounts the last posted times are looked up for at once when counting active users,
+// so that the number of query parameters stays well within what databases allow.
+const activeUsersBatchSize = 500
+
+func (i *instanceDB) CountActiveUsers(ctx context.Context, since time.Time) (int, db.Error) {
+	users := []*gtsmodel.User{}
+	if err := i.conn.
+		NewSelect().
+		Model(&users).
+		Column("account_id", "last_login").
+		Scan(ctx); err != nil {
+		return 0, i.conn.ProcessError(err)
+	}
+
+	count := 0
+	for start := 0; start < len(users); start += activeUsersBatchSize {
+		end := start + activeUsersBatchSize
+		if end > len(users) {
+			end = len(users)
+		}
+
+		// users who've logged in since are active whenever they last posted, so only look up the others
+		accountIDs := []string{}
+		for _, user := range users[start:end] {
+			if !user.LastLogin.IsZero() && !user.LastLogin.Before(since) {
+				count++
+			} else {
+				accountIDs = append(accountIDs, user.AccountID)
+			}
+		}
+
+		lastPosted, err := i.accounts.GetAccountsLastPosted(ctx, accountIDs)
+		if err != nil {
+			return 0, err
+		}
+		for _, posted := range lastPosted {
+			if !posted.IsZero() && !posted.Before(since) {
+				count++
+			}
+		}
+	}
+
+	return count, nil
+}
+
 func (i *instanceDB) CountInstanceDomains(ctx context.Context, domain string) (int, db.Error) {
 	q := i.conn.
 		NewSelect().
@@ -0,0 +1,91 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type InstanceTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+// expectedActiveUsers counts the test users who have been active since the given time.
+func (suite *InstanceTestSuite) expectedActiveUsers(since time.Time) int {
+	count := 0
+	for _, user := range suite.testUsers {
+		active := !user.LastLogin.IsZero() && !user.LastLogin.Before(since)
+		for _, status := range suite.testStatuses {
+			if status.AccountID == user.AccountID && !status.CreatedAt.Before(since) {
+				active = true
+			}
+		}
+		if active {
+			count++
+		}
+	}
+	return count
+}
+
+func (suite *InstanceTestSuite) TestCountActiveUsers() {
+	ctx := context.Background()
+
+	for _, since := range []time.Time{
+		time.Now().Add(-20 * time.Minute),
+		time.Now().Add(-24 * time.Hour),
+		time.Now().Add(-180 * 24 * time.Hour),
+		{},
+	} {
+		count, err := suite.db.CountActiveUsers(ctx, since)
+		suite.NoError(err)
+		suite.Equal(suite.expectedActiveUsers(since), count, "since %s", since)
+	}
+
+	// nobody has been active in the future
+	count, err := suite.db.CountActiveUsers(ctx, time.Now().Add(time.Hour))
+	suite.NoError(err)
+	suite.Zero(count)
+}
+
+func (suite *InstanceTestSuite) TestCountActiveUsersLastLogin() {
+	ctx := context.Background()
+	since := time.Now().Add(-time.Minute)
+
+	before, err := suite.db.CountActiveUsers(ctx, since)
+	suite.NoError(err)
+
+	// an inactive user logging in counts as being active
+	user := suite.testUsers["local_account_2"]
+	suite.Less(user.LastLogin.Unix(), since.Unix())
+	suite.NoError(suite.db.UpdateWhere(ctx, []db.Where{{Key: "id", Value: user.ID}}, "last_login", time.Now(), &gtsmodel.User{}))
+
+	after, err := suite.db.CountActiveUsers(ctx, since)
+	suite.NoError(err)
+	suite.Equal(before+1, after)
+}
+
+func TestInstanceTestSuite(t *testing.T) {
+	suite.Run(t, new(InstanceTestSuite))
+}
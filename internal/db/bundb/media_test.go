@@ -43,6 +43,18 @@ func (suite *MediaTestSuite) TestGetOlder() {
 	suite.Len(attachments, 1)
 }
 
+func (suite *MediaTestSuite) TestGetLocalUnattachedOlder() {
+	// the unattached test attachment was created in the future
+	attachments, err := suite.db.GetLocalUnattachedOlderThan(context.Background(), time.Now(), 20)
+	suite.NoError(err)
+	suite.Empty(attachments)
+
+	attachments, err = suite.db.GetLocalUnattachedOlderThan(context.Background(), time.Now().Add(1*time.Minute), 20)
+	suite.NoError(err)
+	suite.Len(attachments, 1)
+	suite.Equal(suite.testAttachments["local_account_1_unattached_1"].ID, attachments[0].ID)
+}
+
 func TestMediaTestSuite(t *testing.T) {
 	suite.Run(t, new(MediaTestSuite))
 }
@@ -0,0 +1,35 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import (
+	"crypto/rsa"
+	"time"
+)
+
+// AccountKey models a public key which a local account used to sign requests with, before its keypair was rotated.
+// Retired keys remain valid until they expire, so that requests signed just before the rotation can still be verified.
+type AccountKey struct {
+	ID           string         `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt    time.Time      `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created (ie., when was the key retired)
+	AccountID    string         `validate:"required,ulid" bun:"type:CHAR(26),notnull,nullzero"`                  // Which account did this key belong to?
+	PublicKey    *rsa.PublicKey `validate:"required" bun:",notnull"`                                             // The retired public key
+	PublicKeyURI string         `validate:"required,url" bun:",nullzero,notnull,unique"`                         // Web-reachable location of the retired public key
+	ExpiresAt    time.Time      `validate:"-" bun:"type:timestamptz,nullzero,notnull"`                           // After this time, the key should no longer be accepted
+}
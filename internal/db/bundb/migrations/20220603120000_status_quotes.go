@@ -0,0 +1,68 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package migrations
+
+import (
+	"context"
+
+	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/db/bundb/migrations/20211113114307_init"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// add status quote_of_id column, for the id of the status being quoted
+			if _, err := tx.
+				NewAddColumn().
+				Model(&gtsmodel.Status{}).
+				ColumnExpr("? CHAR(26)", bun.Ident("quote_of_id")).
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// add status quote_of_uri column, for the uri of the status being quoted
+			if _, err := tx.
+				NewAddColumn().
+				Model(&gtsmodel.Status{}).
+				ColumnExpr("? VARCHAR", bun.Ident("quote_of_uri")).
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// add account unquotable column, for accounts that don't want to be quoted
+			_, err := tx.
+				NewAddColumn().
+				Model(&gtsmodel.Account{}).
+				ColumnExpr("? BOOLEAN DEFAULT false", bun.Ident("unquotable")).
+				Exec(ctx)
+			return err
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,42 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// PreviewCard represents a rich preview of a link in a status, generated from the OpenGraph
+// and oEmbed metadata of the linked page. Cards are stored once per url, and shared by all
+// statuses that link to it.
+type PreviewCard struct {
+	ID           string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt    time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt    time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	URL          string    `validate:"required,url" bun:",nullzero,notnull,unique"`                         // url of the linked page
+	Title        string    `validate:"-" bun:""`                                                            // title of the linked page
+	Description  string    `validate:"-" bun:""`                                                            // description of the linked page
+	Type         string    `validate:"oneof=link photo video rich" bun:",nullzero,notnull,default:'link'"`  // type of the card, one of link, photo, video or rich
+	AuthorName   string    `validate:"-" bun:""`                                                            // name of the author of the linked page
+	AuthorURL    string    `validate:"omitempty,url" bun:",nullzero"`                                       // url of the author of the linked page
+	ProviderName string    `validate:"-" bun:""`                                                            // name of the site the linked page is on
+	ProviderURL  string    `validate:"omitempty,url" bun:",nullzero"`                                       // url of the site the linked page is on
+	Width        int       `validate:"-" bun:",nullzero"`                                                   // width of the preview image, in pixels
+	Height       int       `validate:"-" bun:",nullzero"`                                                   // height of the preview image, in pixels
+	ImageURL     string    `validate:"omitempty,url" bun:",nullzero"`                                       // remote url of the preview image
+	EmbedURL     string    `validate:"omitempty,url" bun:",nullzero"`                                       // url of the embedded photo, for photo cards
+}
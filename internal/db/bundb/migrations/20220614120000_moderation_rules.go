@@ -0,0 +1,66 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package migrations
+
+import (
+	"context"
+
+	previousgtsmodel "github.com/superseriousbusiness/gotosocial/internal/db/bundb/migrations/20211113114307_init"
+	newgtsmodel "github.com/superseriousbusiness/gotosocial/internal/db/bundb/migrations/20220614120000_moderation_rules"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// create table for the new moderation rule struct; rules are
+			// only ever loaded all at once, so they don't need any indexes
+			if _, err := tx.NewCreateTable().Model(&newgtsmodel.ModerationRule{}).IfNotExists().Exec(ctx); err != nil {
+				return err
+			}
+
+			// add status moderation_rule_id column, for the id of the moderation rule the status matched
+			if _, err := tx.
+				NewAddColumn().
+				Model(&previousgtsmodel.Status{}).
+				ColumnExpr("? CHAR(26)", bun.Ident("moderation_rule_id")).
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// add status held_at column, for when the status was held for review by a moderation rule
+			_, err := tx.
+				NewAddColumn().
+				Model(&previousgtsmodel.Status{}).
+				ColumnExpr("? TIMESTAMPTZ", bun.Ident("held_at")).
+				Exec(ctx)
+			return err
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
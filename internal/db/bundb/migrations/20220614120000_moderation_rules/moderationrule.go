@@ -0,0 +1,55 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// ModerationRule is an instance-wide rule for automatically moderating statuses which contain certain words, or
+// match a regular expression. What happens to a matching status depends on whether it was posted on this instance.
+type ModerationRule struct {
+	ID                 string                     `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt          time.Time                  `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt          time.Time                  `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Pattern            string                     `validate:"required" bun:",nullzero,notnull"`                                    // word, phrase or regular expression to match statuses against
+	Regex              bool                       `validate:"-" bun:",notnull,default:false"`                                      // is the pattern a regular expression, rather than a word or phrase?
+	LocalAction        ModerationRuleLocalAction  `validate:"oneof=hold reject" bun:",nullzero,notnull"`                           // what to do with matching statuses posted on this instance
+	RemoteAction       ModerationRuleRemoteAction `validate:"oneof=flag drop" bun:",nullzero,notnull"`                             // what to do with matching statuses received from other instances
+	CreatedByAccountID string                     `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // Account ID of the creator of this rule
+	PrivateComment     string                     `validate:"-" bun:""`                                                            // Private comment on this rule, viewable to admins
+}
+
+// ModerationRuleLocalAction is what happens to a status posted on this instance which matches a moderation rule.
+type ModerationRuleLocalAction string
+
+const (
+	// ModerationRuleLocalHold -- the status is created, but only its author can see it until an admin approves it.
+	ModerationRuleLocalHold ModerationRuleLocalAction = "hold"
+	// ModerationRuleLocalReject -- the status is not created at all.
+	ModerationRuleLocalReject ModerationRuleLocalAction = "reject"
+)
+
+// ModerationRuleRemoteAction is what happens to a status received from another instance which matches a moderation rule.
+type ModerationRuleRemoteAction string
+
+const (
+	// ModerationRuleRemoteFlag -- the status is delivered as usual, but flagged for admins to review.
+	ModerationRuleRemoteFlag ModerationRuleRemoteAction = "flag"
+	// ModerationRuleRemoteDrop -- the status is dropped.
+	ModerationRuleRemoteDrop ModerationRuleRemoteAction = "drop"
+)
@@ -0,0 +1,68 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package migrations
+
+import (
+	"context"
+
+	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/db/bundb/migrations/20211113114307_init"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// add user failed_sign_in_count column, for counting failed sign ins towards a lockout
+			if _, err := tx.
+				NewAddColumn().
+				Model(&gtsmodel.User{}).
+				ColumnExpr("? INTEGER NOT NULL DEFAULT 0", bun.Ident("failed_sign_in_count")).
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// add user failed_sign_in_at column, for the start of the window failed sign ins are counted in
+			if _, err := tx.
+				NewAddColumn().
+				Model(&gtsmodel.User{}).
+				ColumnExpr("? TIMESTAMPTZ", bun.Ident("failed_sign_in_at")).
+				Exec(ctx); err != nil {
+				return err
+			}
+
+			// add user sign_in_locked_until column, for locking sign ins after too many failed ones
+			_, err := tx.
+				NewAddColumn().
+				Model(&gtsmodel.User{}).
+				ColumnExpr("? TIMESTAMPTZ", bun.Ident("sign_in_locked_until")).
+				Exec(ctx)
+			return err
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
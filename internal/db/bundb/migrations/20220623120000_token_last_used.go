@@ -0,0 +1,63 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package migrations
+
+import (
+	"context"
+	"time"
+
+	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/db/bundb/migrations/20211113114307_init"
+	"github.com/uptrace/bun"
+)
+
+func init() {
+	up := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			// add token last_used column, for expiring tokens which haven't been used in a long time
+			_, err := tx.
+				NewAddColumn().
+				Model(&gtsmodel.Token{}).
+				ColumnExpr("? TIMESTAMPTZ", bun.Ident("last_used")).
+				Exec(ctx)
+			if err != nil {
+				return err
+			}
+
+			// we don't know when existing tokens were last used, so count them as used now; otherwise
+			// tokens which are old but still in use would be removed as inactive straight away
+			_, err = tx.
+				NewUpdate().
+				Model(&gtsmodel.Token{}).
+				Set("? = ?", bun.Ident("last_used"), time.Now()).
+				Where("? IS NULL", bun.Ident("last_used")).
+				Exec(ctx)
+			return err
+		})
+	}
+
+	down := func(ctx context.Context, db *bun.DB) error {
+		return db.RunInTx(ctx, nil, func(ctx context.Context, tx bun.Tx) error {
+			return nil
+		})
+	}
+
+	if err := Migrations.Register(up, down); err != nil {
+		panic(err)
+	}
+}
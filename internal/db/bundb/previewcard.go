@@ -0,0 +1,53 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type previewCardDB struct {
+	conn *DBConn
+}
+
+func (p *previewCardDB) GetPreviewCardByID(ctx context.Context, id string) (*gtsmodel.PreviewCard, db.Error) {
+	return p.getPreviewCard(ctx, "id", id)
+}
+
+func (p *previewCardDB) GetPreviewCardByURL(ctx context.Context, url string) (*gtsmodel.PreviewCard, db.Error) {
+	return p.getPreviewCard(ctx, "url", url)
+}
+
+func (p *previewCardDB) getPreviewCard(ctx context.Context, column string, value string) (*gtsmodel.PreviewCard, db.Error) {
+	card := &gtsmodel.PreviewCard{}
+
+	if err := p.conn.
+		NewSelect().
+		Model(card).
+		Where("? = ?", bun.Ident(column), value).
+		Scan(ctx); err != nil {
+		return nil, p.conn.ProcessError(err)
+	}
+
+	return card, nil
+}
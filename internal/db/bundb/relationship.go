@@ -206,19 +206,21 @@ func (r *relationshipDB) IsMutualFollowing(ctx context.Context, account1 *gtsmod
 		return false, nil
 	}
 
-	// make sure account 1 follows account 2
-	f1, err := r.IsFollowing(ctx, account1, account2)
-	if err != nil {
-		return false, err
-	}
-
-	// make sure account 2 follows account 1
-	f2, err := r.IsFollowing(ctx, account2, account1)
-	if err != nil {
-		return false, err
-	}
+	// select a follow from account 1 to account 2, joined
+	// to a follow in the other direction, in one go
+	q := r.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("follow")).
+		Column("follow.id").
+		Join("JOIN ? AS ? ON ? = ? AND ? = ?",
+			bun.Ident("follows"), bun.Ident("reverse"),
+			bun.Ident("reverse.account_id"), bun.Ident("follow.target_account_id"),
+			bun.Ident("reverse.target_account_id"), bun.Ident("follow.account_id")).
+		Where("? = ?", bun.Ident("follow.account_id"), account1.ID).
+		Where("? = ?", bun.Ident("follow.target_account_id"), account2.ID).
+		Limit(1)
 
-	return f1 && f2, nil
+	return r.conn.Exists(ctx, q)
 }
 
 func (r *relationshipDB) AcceptFollowRequest(ctx context.Context, originAccountID string, targetAccountID string) (*gtsmodel.Follow, db.Error) {
@@ -350,3 +352,95 @@ func (r *relationshipDB) CountAccountFollowedBy(ctx context.Context, accountID s
 		Where("target_account_id = ?", accountID).
 		Count(ctx)
 }
+
+func (r *relationshipDB) GetAccountFamiliarFollowers(ctx context.Context, requestingAccountID string, targetAccountID string) ([]*gtsmodel.Follow, db.Error) {
+	follows := []*gtsmodel.Follow{}
+
+	// select follows of the target account, joined
+	// to follows of their owners by the requesting account
+	q := r.conn.
+		NewSelect().
+		Model(&follows).
+		Relation("Account").
+		Join("JOIN ? AS ? ON ? = ? AND ? = ?",
+			bun.Ident("follows"), bun.Ident("known"),
+			bun.Ident("known.target_account_id"), bun.Ident("follow.account_id"),
+			bun.Ident("known.account_id"), requestingAccountID).
+		Where("? = ?", bun.Ident("follow.target_account_id"), targetAccountID).
+		Order("follow.id DESC")
+
+	err := q.Scan(ctx)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, r.conn.ProcessError(err)
+	}
+	return follows, nil
+}
+
+func (r *relationshipDB) GetAccountFollowSuggestions(ctx context.Context, accountID string, limit int) ([]string, db.Error) {
+	ranked := []struct {
+		AccountID string `bun:"account_id"`
+		Followers int    `bun:"followers"`
+	}{}
+
+	// accounts which accountID already follows or has requested to follow
+	followed := r.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("followed")).
+		Column("followed.id").
+		Where("? = ?", bun.Ident("followed.account_id"), accountID).
+		Where("? = ?", bun.Ident("followed.target_account_id"), bun.Ident("suggested.target_account_id"))
+
+	requested := r.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("follow_requests"), bun.Ident("requested")).
+		Column("requested.id").
+		Where("? = ?", bun.Ident("requested.account_id"), accountID).
+		Where("? = ?", bun.Ident("requested.target_account_id"), bun.Ident("suggested.target_account_id"))
+
+	// blocks in either direction between accountID and the suggested account
+	blocked := r.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("blocks"), bun.Ident("block")).
+		Column("block.id").
+		WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? = ?", bun.Ident("block.account_id"), accountID).
+				Where("? = ?", bun.Ident("block.target_account_id"), bun.Ident("suggested.target_account_id"))
+		}).
+		WhereGroup(" OR ", func(q *bun.SelectQuery) *bun.SelectQuery {
+			return q.
+				Where("? = ?", bun.Ident("block.account_id"), bun.Ident("suggested.target_account_id")).
+				Where("? = ?", bun.Ident("block.target_account_id"), accountID)
+		})
+
+	// select follows of accounts followed by accountID, counting how often each target comes up
+	q := r.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("follows"), bun.Ident("follow")).
+		Join("JOIN ? AS ? ON ? = ?", bun.Ident("follows"), bun.Ident("suggested"), bun.Ident("suggested.account_id"), bun.Ident("follow.target_account_id")).
+		Join("JOIN ? AS ? ON ? = ?", bun.Ident("accounts"), bun.Ident("account"), bun.Ident("account.id"), bun.Ident("suggested.target_account_id")).
+		ColumnExpr("? AS ?", bun.Ident("suggested.target_account_id"), bun.Ident("account_id")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("followers")).
+		Where("? = ?", bun.Ident("follow.account_id"), accountID).
+		Where("? != ?", bun.Ident("suggested.target_account_id"), accountID).
+		Where("? IS NULL", bun.Ident("account.suspended_at")).
+		Where("NOT EXISTS (?)", followed).
+		Where("NOT EXISTS (?)", requested).
+		Where("NOT EXISTS (?)", blocked).
+		GroupExpr("?", bun.Ident("suggested.target_account_id")).
+		OrderExpr("? DESC, ? DESC", bun.Ident("followers"), bun.Ident("account_id"))
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &ranked); err != nil {
+		return nil, r.conn.ProcessError(err)
+	}
+
+	accountIDs := make([]string, 0, len(ranked))
+	for _, r := range ranked {
+		accountIDs = append(accountIDs, r.AccountID)
+	}
+	return accountIDs, nil
+}
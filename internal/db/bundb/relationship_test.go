@@ -75,7 +75,36 @@ func (suite *RelationshipTestSuite) TestIsBlocked() {
 }
 
 func (suite *RelationshipTestSuite) TestGetBlock() {
-	suite.Suite.T().Skip("TODO: implement")
+	ctx := context.Background()
+
+	account1 := suite.testAccounts["local_account_1"]
+	account2 := suite.testAccounts["local_account_2"]
+
+	// no block exists yet
+	block, err := suite.db.GetBlock(ctx, account1.ID, account2.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	suite.Nil(block)
+
+	// have account1 block account2
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.Block{
+		ID:              "01G5EVB6XZ1R8NQ4K7HM2TPW3D",
+		URI:             "http://localhost:8080/some_block_uri_1",
+		AccountID:       account1.ID,
+		TargetAccountID: account2.ID,
+	}))
+
+	// the block should be returned with its accounts populated
+	block, err = suite.db.GetBlock(ctx, account1.ID, account2.ID)
+	suite.NoError(err)
+	suite.Equal("01G5EVB6XZ1R8NQ4K7HM2TPW3D", block.ID)
+	suite.Equal("http://localhost:8080/some_block_uri_1", block.URI)
+	suite.Equal(account1.ID, block.Account.ID)
+	suite.Equal(account2.ID, block.TargetAccount.ID)
+
+	// the block only goes one way
+	block, err = suite.db.GetBlock(ctx, account2.ID, account1.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	suite.Nil(block)
 }
 
 func (suite *RelationshipTestSuite) TestGetRelationship() {
@@ -83,11 +112,94 @@ func (suite *RelationshipTestSuite) TestGetRelationship() {
 }
 
 func (suite *RelationshipTestSuite) TestIsFollowing() {
-	suite.Suite.T().Skip("TODO: implement")
+	ctx := context.Background()
+
+	account1 := suite.testAccounts["local_account_1"]
+	account2 := suite.testAccounts["remote_account_1"]
+
+	// no follow in either direction
+	following, err := suite.db.IsFollowing(ctx, account1, account2)
+	suite.NoError(err)
+	suite.False(following)
+
+	following, err = suite.db.IsFollowing(ctx, account2, account1)
+	suite.NoError(err)
+	suite.False(following)
+
+	// account 1 follows account 2, but not the other way around
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.Follow{
+		ID:              "01G5EX0B3N2WHQ7C9JZ8MRKD4V",
+		URI:             "http://localhost:8080/some_follow_uri_1",
+		AccountID:       account1.ID,
+		TargetAccountID: account2.ID,
+	}))
+
+	following, err = suite.db.IsFollowing(ctx, account1, account2)
+	suite.NoError(err)
+	suite.True(following)
+
+	following, err = suite.db.IsFollowing(ctx, account2, account1)
+	suite.NoError(err)
+	suite.False(following)
+
+	// nil accounts never follow anything
+	following, err = suite.db.IsFollowing(ctx, account1, nil)
+	suite.NoError(err)
+	suite.False(following)
 }
 
 func (suite *RelationshipTestSuite) TestIsMutualFollowing() {
-	suite.Suite.T().Skip("TODO: implement")
+	ctx := context.Background()
+
+	account1 := suite.testAccounts["local_account_1"]
+	account2 := suite.testAccounts["remote_account_1"]
+
+	// no follow in either direction
+	mutuals, err := suite.db.IsMutualFollowing(ctx, account1, account2)
+	suite.NoError(err)
+	suite.False(mutuals)
+
+	// account 1 follows account 2, but that's not enough
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.Follow{
+		ID:              "01G5EX0B3N2WHQ7C9JZ8MRKD4V",
+		URI:             "http://localhost:8080/some_follow_uri_1",
+		AccountID:       account1.ID,
+		TargetAccountID: account2.ID,
+	}))
+
+	mutuals, err = suite.db.IsMutualFollowing(ctx, account1, account2)
+	suite.NoError(err)
+	suite.False(mutuals)
+
+	mutuals, err = suite.db.IsMutualFollowing(ctx, account2, account1)
+	suite.NoError(err)
+	suite.False(mutuals)
+
+	// now account 2 follows account 1 back
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.Follow{
+		ID:              "01G5EX1K6T4FJ0PYQ2V8WNBH7C",
+		URI:             "http://fossbros-anonymous.io/some_follow_uri_2",
+		AccountID:       account2.ID,
+		TargetAccountID: account1.ID,
+	}))
+
+	mutuals, err = suite.db.IsMutualFollowing(ctx, account1, account2)
+	suite.NoError(err)
+	suite.True(mutuals)
+
+	mutuals, err = suite.db.IsMutualFollowing(ctx, account2, account1)
+	suite.NoError(err)
+	suite.True(mutuals)
+
+	// zork and turtle follow each other in the test models, but
+	// the follows of other accounts shouldn't have anything to do with it
+	mutuals, err = suite.db.IsMutualFollowing(ctx, suite.testAccounts["local_account_2"], account2)
+	suite.NoError(err)
+	suite.False(mutuals)
+
+	mutuals, err = suite.db.IsMutualFollowing(ctx, account1, suite.testAccounts["local_account_2"])
+	suite.NoError(err)
+	suite.True(mutuals)
 }
 
 func (suite *RelationshipTestSuite) AcceptFollowRequest() {
@@ -133,6 +245,51 @@ func (suite *RelationshipTestSuite) CountAccountFollowedBy() {
 	suite.Suite.T().Skip("TODO: implement")
 }
 
+func (suite *RelationshipTestSuite) TestGetAccountFamiliarFollowers() {
+	ctx := context.Background()
+
+	// admin follows zork, who follows turtle
+	follows, err := suite.db.GetAccountFamiliarFollowers(ctx, suite.testAccounts["admin_account"].ID, suite.testAccounts["local_account_2"].ID)
+	suite.NoError(err)
+	if suite.Len(follows, 1) {
+		suite.Equal(suite.testAccounts["local_account_1"].ID, follows[0].AccountID)
+		suite.NotNil(follows[0].Account)
+	}
+
+	// zork follows turtle, but nobody else zork follows does
+	follows, err = suite.db.GetAccountFamiliarFollowers(ctx, suite.testAccounts["local_account_1"].ID, suite.testAccounts["local_account_2"].ID)
+	suite.NoError(err)
+	suite.Empty(follows)
+}
+
+func (suite *RelationshipTestSuite) TestGetAccountFollowSuggestions() {
+	ctx := context.Background()
+	adminAccount := suite.testAccounts["admin_account"]
+	suggestedAccount := suite.testAccounts["local_account_2"]
+
+	// admin follows zork, who follows turtle and admin, but admin shouldn't be suggested to themself
+	suggestions, err := suite.db.GetAccountFollowSuggestions(ctx, adminAccount.ID, 10)
+	suite.NoError(err)
+	suite.Equal([]string{suggestedAccount.ID}, suggestions)
+
+	// zork follows admin and turtle, who only follow zork back
+	suggestions, err = suite.db.GetAccountFollowSuggestions(ctx, suite.testAccounts["local_account_1"].ID, 10)
+	suite.NoError(err)
+	suite.Empty(suggestions)
+
+	// once turtle blocks admin, they shouldn't be suggested to admin anymore
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.Block{
+		ID:              "01G5F4J9WQ6J0YB5RQ3E3D7QPZ",
+		URI:             "http://localhost:8080/users/1happyturtle/blocks/01G5F4J9WQ6J0YB5RQ3E3D7QPZ",
+		AccountID:       suggestedAccount.ID,
+		TargetAccountID: adminAccount.ID,
+	}))
+
+	suggestions, err = suite.db.GetAccountFollowSuggestions(ctx, adminAccount.ID, 10)
+	suite.NoError(err)
+	suite.Empty(suggestions)
+}
+
 func TestRelationshipTestSuite(t *testing.T) {
 	suite.Run(t, new(RelationshipTestSuite))
 }
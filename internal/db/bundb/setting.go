@@ -0,0 +1,127 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+// defaultReadOnlyMessage is shown to users while the instance is in read-only mode, if no other message is set.
+const defaultReadOnlyMessage = "this instance is in read-only mode for maintenance, please try again later"
+
+type settingDB struct {
+	conn *DBConn
+}
+
+func (s *settingDB) GetRegistrationsOpen(ctx context.Context) (bool, db.Error) {
+	value, err := s.getSetting(ctx, gtsmodel.SettingRegistrationsOpen)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			// not set at runtime, so fall back to the config
+			return viper.GetBool(config.Keys.AccountsRegistrationOpen), nil
+		}
+		return false, err
+	}
+
+	open, parseErr := strconv.ParseBool(value)
+	if parseErr != nil {
+		return false, parseErr
+	}
+	return open, nil
+}
+
+func (s *settingDB) SetRegistrationsOpen(ctx context.Context, open bool) db.Error {
+	return s.putSetting(ctx, gtsmodel.SettingRegistrationsOpen, strconv.FormatBool(open))
+}
+
+func (s *settingDB) GetReadOnly(ctx context.Context) (bool, string, db.Error) {
+	readOnly := viper.GetBool(config.Keys.InstanceReadOnly)
+	value, err := s.getSetting(ctx, gtsmodel.SettingReadOnly)
+	switch err {
+	case nil:
+		var parseErr error
+		if readOnly, parseErr = strconv.ParseBool(value); parseErr != nil {
+			return false, "", parseErr
+		}
+	case db.ErrNoEntries:
+		// not set at runtime, so stick with the config
+	default:
+		return false, "", err
+	}
+
+	message, err := s.getSetting(ctx, gtsmodel.SettingReadOnlyMessage)
+	if err != nil && err != db.ErrNoEntries {
+		return false, "", err
+	}
+	if message == "" {
+		message = viper.GetString(config.Keys.InstanceReadOnlyMessage)
+	}
+	if message == "" {
+		message = defaultReadOnlyMessage
+	}
+
+	return readOnly, message, nil
+}
+
+func (s *settingDB) SetReadOnly(ctx context.Context, readOnly bool, message string) db.Error {
+	if err := s.putSetting(ctx, gtsmodel.SettingReadOnlyMessage, message); err != nil {
+		return err
+	}
+	return s.putSetting(ctx, gtsmodel.SettingReadOnly, strconv.FormatBool(readOnly))
+}
+
+// getSetting returns the value of the setting with the given key, or db.ErrNoEntries if it isn't set.
+func (s *settingDB) getSetting(ctx context.Context, key string) (string, db.Error) {
+	setting := &gtsmodel.Setting{}
+	if err := s.conn.
+		NewSelect().
+		Model(setting).
+		Where("? = ?", bun.Ident("key"), key).
+		Scan(ctx); err != nil {
+		return "", s.conn.ProcessError(err)
+	}
+	return setting.Value, nil
+}
+
+// putSetting sets the setting with the given key to the given value, creating it if necessary.
+func (s *settingDB) putSetting(ctx context.Context, key string, value string) db.Error {
+	setting := &gtsmodel.Setting{
+		Key:       key,
+		Value:     value,
+		UpdatedAt: time.Now(),
+	}
+	if _, err := s.conn.
+		NewInsert().
+		Model(setting).
+		On("CONFLICT (?) DO UPDATE", bun.Ident("key")).
+		Set("? = EXCLUDED.?", bun.Ident("value"), bun.Ident("value")).
+		Set("? = EXCLUDED.?", bun.Ident("updated_at"), bun.Ident("updated_at")).
+		Exec(ctx); err != nil {
+		return s.conn.ProcessError(err)
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+type SettingTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *SettingTestSuite) TestRegistrationsOpenDefaultsToConfig() {
+	viper.Set(config.Keys.AccountsRegistrationOpen, true)
+	open, err := suite.db.GetRegistrationsOpen(context.Background())
+	suite.NoError(err)
+	suite.True(open)
+
+	viper.Set(config.Keys.AccountsRegistrationOpen, false)
+	open, err = suite.db.GetRegistrationsOpen(context.Background())
+	suite.NoError(err)
+	suite.False(open)
+}
+
+func (suite *SettingTestSuite) TestSetRegistrationsOpen() {
+	viper.Set(config.Keys.AccountsRegistrationOpen, true)
+
+	suite.NoError(suite.db.SetRegistrationsOpen(context.Background(), false))
+	open, err := suite.db.GetRegistrationsOpen(context.Background())
+	suite.NoError(err)
+	suite.False(open)
+
+	// setting it again should update the existing setting
+	suite.NoError(suite.db.SetRegistrationsOpen(context.Background(), true))
+	viper.Set(config.Keys.AccountsRegistrationOpen, false)
+	open, err = suite.db.GetRegistrationsOpen(context.Background())
+	suite.NoError(err)
+	suite.True(open)
+}
+
+func (suite *SettingTestSuite) TestReadOnlyDefaultsToConfig() {
+	readOnly, message, err := suite.db.GetReadOnly(context.Background())
+	suite.NoError(err)
+	suite.False(readOnly)
+	suite.Equal("this instance is in read-only mode for maintenance, please try again later", message)
+
+	viper.Set(config.Keys.InstanceReadOnly, true)
+	viper.Set(config.Keys.InstanceReadOnlyMessage, "upgrading, back soon")
+	readOnly, message, err = suite.db.GetReadOnly(context.Background())
+	suite.NoError(err)
+	suite.True(readOnly)
+	suite.Equal("upgrading, back soon", message)
+}
+
+func (suite *SettingTestSuite) TestSetReadOnly() {
+	viper.Set(config.Keys.InstanceReadOnlyMessage, "upgrading, back soon")
+
+	suite.NoError(suite.db.SetReadOnly(context.Background(), true, "migrating the database"))
+	readOnly, message, err := suite.db.GetReadOnly(context.Background())
+	suite.NoError(err)
+	suite.True(readOnly)
+	suite.Equal("migrating the database", message)
+
+	// setting it again without a message should fall back to the config message
+	suite.NoError(suite.db.SetReadOnly(context.Background(), false, ""))
+	viper.Set(config.Keys.InstanceReadOnly, true)
+	readOnly, message, err = suite.db.GetReadOnly(context.Background())
+	suite.NoError(err)
+	suite.False(readOnly)
+	suite.Equal("upgrading, back soon", message)
+}
+
+func TestSettingTestSuite(t *testing.T) {
+	suite.Run(t, new(SettingTestSuite))
+}
@@ -99,6 +99,82 @@ func (s *statusDB) GetStatusByURL(ctx context.Context, url string) (*gtsmodel.St
 	)
 }
 
+func (s *statusDB) GetStatusesByIDs(ctx context.Context, ids []string) ([]*gtsmodel.Status, db.Error) {
+	found := make(map[string]*gtsmodel.Status, len(ids))
+	missing := make([]string, 0, len(ids))
+
+	// Attempt to fetch cached statuses first
+	for _, id := range ids {
+		if _, ok := found[id]; ok {
+			continue
+		}
+		if status, cached := s.cache.GetByID(id); cached {
+			found[id] = status
+		} else {
+			missing = append(missing, id)
+		}
+	}
+
+	// Fetch everything that wasn't cached in one query
+	if len(missing) != 0 {
+		fromDB := make([]*gtsmodel.Status, 0, len(missing))
+		if err := s.newStatusQ(&fromDB).Where("status.id IN (?)", bun.In(missing)).Scan(ctx); err != nil {
+			if err := s.conn.ProcessError(err); err != db.ErrNoEntries {
+				return nil, err
+			}
+		}
+
+		// Fetch the boosted statuses in one go as well
+		boostOfIDs := make([]string, 0, len(fromDB))
+		for _, status := range fromDB {
+			if status.BoostOfID != "" {
+				boostOfIDs = append(boostOfIDs, status.BoostOfID)
+			}
+		}
+		boostsOf := make(map[string]*gtsmodel.Status, len(boostOfIDs))
+		if len(boostOfIDs) != 0 {
+			boostedStatuses, err := s.GetStatusesByIDs(ctx, boostOfIDs)
+			if err != nil {
+				return nil, err
+			}
+			for _, boostOf := range boostedStatuses {
+				boostsOf[boostOf.ID] = boostOf
+			}
+		}
+
+		for _, status := range fromDB {
+			status.BoostOf = boostsOf[status.BoostOfID]
+
+			// Place in the cache
+			s.cache.Put(status)
+			found[status.ID] = status
+		}
+	}
+
+	// Put the statuses in the order they were asked for, skipping
+	// any that don't exist (anymore), and set their author accounts
+	statuses := make([]*gtsmodel.Status, 0, len(ids))
+	for _, id := range ids {
+		status, ok := found[id]
+		if !ok {
+			continue
+		}
+
+		author, err := s.accounts.GetAccountByID(ctx, status.AccountID)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				continue
+			}
+			return nil, err
+		}
+
+		status.Account = author
+		statuses = append(statuses, status)
+	}
+
+	return statuses, nil
+}
+
 func (s *statusDB) getStatus(ctx context.Context, cacheGet func() (*gtsmodel.Status, bool), dbQuery func(*gtsmodel.Status) error) (*gtsmodel.Status, db.Error) {
 	// Attempt to fetch cached status
 	status, cached := cacheGet()
@@ -174,6 +250,32 @@ func (s *statusDB) PutStatus(ctx context.Context, status *gtsmodel.Status) db.Er
 	})
 }
 
+func (s *statusDB) UpdateStatus(ctx context.Context, status *gtsmodel.Status) db.Error {
+	if _, err := s.conn.
+		NewUpdate().
+		Model(status).
+		WherePK().
+		Exec(ctx); err != nil {
+		return s.conn.ProcessError(err)
+	}
+
+	s.cache.Put(status)
+	return nil
+}
+
+func (s *statusDB) DeleteStatusByID(ctx context.Context, id string) db.Error {
+	if _, err := s.conn.
+		NewDelete().
+		Model(&gtsmodel.Status{}).
+		Where("? = ?", bun.Ident("id"), id).
+		Exec(ctx); err != nil {
+		return s.conn.ProcessError(err)
+	}
+
+	s.cache.Invalidate(id)
+	return nil
+}
+
 func (s *statusDB) GetStatusParents(ctx context.Context, status *gtsmodel.Status, onlyDirect bool) ([]*gtsmodel.Status, db.Error) {
 	parents := []*gtsmodel.Status{}
 	s.statusParent(ctx, status, &parents, onlyDirect)
@@ -181,20 +283,23 @@ func (s *statusDB) GetStatusParents(ctx context.Context, status *gtsmodel.Status
 }
 
 func (s *statusDB) statusParent(ctx context.Context, status *gtsmodel.Status, foundStatuses *[]*gtsmodel.Status, onlyDirect bool) {
-	if status.InReplyToID == "" {
-		return
-	}
+	// keep track of the statuses we've been through, so that a cycle of replies can't keep us here forever
+	seen := map[string]bool{status.ID: true}
 
-	parentStatus, err := s.GetStatusByID(ctx, status.InReplyToID)
-	if err == nil {
+	for status.InReplyToID != "" && !seen[status.InReplyToID] {
+		parentStatus, err := s.GetStatusByID(ctx, status.InReplyToID)
+		if err != nil {
+			return
+		}
 		*foundStatuses = append(*foundStatuses, parentStatus)
-	}
 
-	if onlyDirect {
-		return
-	}
+		if onlyDirect {
+			return
+		}
 
-	s.statusParent(ctx, parentStatus, foundStatuses, false)
+		seen[parentStatus.ID] = true
+		status = parentStatus
+	}
 }
 
 func (s *statusDB) GetStatusChildren(ctx context.Context, status *gtsmodel.Status, onlyDirect bool, minID string) ([]*gtsmodel.Status, db.Error) {
@@ -319,6 +424,22 @@ func (s *statusDB) GetStatusFaves(ctx context.Context, status *gtsmodel.Status)
 	return faves, nil
 }
 
+func (s *statusDB) GetStatusReactions(ctx context.Context, status *gtsmodel.Status) ([]*gtsmodel.StatusReaction, db.Error) {
+	reactions := []*gtsmodel.StatusReaction{}
+
+	q := s.conn.
+		NewSelect().
+		Model(&reactions).
+		Relation("Emoji").
+		Where("status_reaction.status_id = ?", status.ID).
+		Order("status_reaction.id ASC")
+
+	if err := q.Scan(ctx); err != nil {
+		return nil, s.conn.ProcessError(err)
+	}
+	return reactions, nil
+}
+
 func (s *statusDB) GetStatusReblogs(ctx context.Context, status *gtsmodel.Status) ([]*gtsmodel.Status, db.Error) {
 	reblogs := []*gtsmodel.Status{}
 
@@ -25,6 +25,8 @@ import (
 	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type StatusTestSuite struct {
@@ -120,6 +122,26 @@ func (suite *StatusTestSuite) TestGetStatusTwice() {
 	suite.Less(duration2, duration1)
 }
 
+func (suite *StatusTestSuite) TestUpdateStatus() {
+	// get the status once so that it's cached
+	status, err := suite.db.GetStatusByID(context.Background(), suite.testStatuses["local_account_1_status_1"].ID)
+	suite.NoError(err)
+
+	status.Content = "<p>hello everyone, again!</p>"
+	err = suite.db.UpdateStatus(context.Background(), status)
+	suite.NoError(err)
+
+	// the updated status should come back from the cache as well as the db
+	updated, err := suite.db.GetStatusByID(context.Background(), status.ID)
+	suite.NoError(err)
+	suite.Equal("<p>hello everyone, again!</p>", updated.Content)
+
+	dbStatus := &gtsmodel.Status{}
+	err = suite.db.GetByID(context.Background(), status.ID, dbStatus)
+	suite.NoError(err)
+	suite.Equal("<p>hello everyone, again!</p>", dbStatus.Content)
+}
+
 func (suite *StatusTestSuite) TestGetStatusChildren() {
 	targetStatus := suite.testStatuses["local_account_1_status_1"]
 	children, err := suite.db.GetStatusChildren(context.Background(), targetStatus, true, "")
@@ -132,6 +154,86 @@ func (suite *StatusTestSuite) TestGetStatusChildren() {
 	}
 }
 
+func (suite *StatusTestSuite) TestGetStatusesByIDs() {
+	ids := []string{
+		suite.testStatuses["local_account_2_status_1"].ID,
+		suite.testStatuses["admin_account_status_1"].ID,
+		"01G5A2TKW6DQ3V2S6YJ2Y5JCQW", // doesn't exist
+		suite.testStatuses["local_account_1_status_1"].ID,
+	}
+
+	// get one of the statuses first so that it's cached
+	_, err := suite.db.GetStatusByID(context.Background(), ids[1])
+	suite.NoError(err)
+
+	statuses, err := suite.db.GetStatusesByIDs(context.Background(), ids)
+	suite.NoError(err)
+
+	// the statuses should be in the same order as the ids, just without the missing one
+	if suite.Len(statuses, 3) {
+		suite.Equal(ids[0], statuses[0].ID)
+		suite.Equal(ids[1], statuses[1].ID)
+		suite.Equal(ids[3], statuses[2].ID)
+	}
+	for _, status := range statuses {
+		suite.NotNil(status.Account)
+		suite.Equal(status.AccountID, status.Account.ID)
+	}
+}
+
+func (suite *StatusTestSuite) TestGetStatusesByIDsBoost() {
+	boostOf := suite.testStatuses["local_account_1_status_1"]
+	booster := suite.testAccounts["local_account_2"]
+	boost := &gtsmodel.Status{
+		ID:                  "01G5A3JZ1G0P6ZT4PVQS2R70YD",
+		URI:                 booster.URI + "/statuses/01G5A3JZ1G0P6ZT4PVQS2R70YD",
+		Local:               true,
+		AccountID:           booster.ID,
+		AccountURI:          booster.URI,
+		BoostOfID:           boostOf.ID,
+		BoostOfAccountID:    boostOf.AccountID,
+		Visibility:          gtsmodel.VisibilityPublic,
+		ActivityStreamsType: ap.ActivityAnnounce,
+	}
+	suite.NoError(suite.db.PutStatus(context.Background(), boost))
+
+	statuses, err := suite.db.GetStatusesByIDs(context.Background(), []string{boost.ID})
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.NotNil(statuses[0].BoostOf)
+		suite.Equal(boost.BoostOfID, statuses[0].BoostOf.ID)
+	}
+}
+
+func (suite *StatusTestSuite) TestGetStatusesByIDsNone() {
+	statuses, err := suite.db.GetStatusesByIDs(context.Background(), []string{"01G5A2TKW6DQ3V2S6YJ2Y5JCQW"})
+	suite.NoError(err)
+	suite.Empty(statuses)
+}
+
+func (suite *StatusTestSuite) TestGetStatusParents() {
+	targetStatus := suite.testStatuses["admin_account_status_3"]
+	parents, err := suite.db.GetStatusParents(context.Background(), targetStatus, false)
+	suite.NoError(err)
+	suite.Len(parents, 1)
+	suite.Equal(targetStatus.InReplyToID, parents[0].ID)
+}
+
+func (suite *StatusTestSuite) TestGetStatusParentsCycle() {
+	targetStatus := suite.testStatuses["admin_account_status_3"]
+
+	// make the parent reply to the target status, so the two reply to each other
+	parent, err := suite.db.GetStatusByID(context.Background(), targetStatus.InReplyToID)
+	suite.NoError(err)
+	parent.InReplyToID = targetStatus.ID
+	suite.NoError(suite.db.UpdateStatus(context.Background(), parent))
+
+	parents, err := suite.db.GetStatusParents(context.Background(), targetStatus, false)
+	suite.NoError(err)
+	suite.Len(parents, 1)
+	suite.Equal(parent.ID, parents[0].ID)
+}
+
 func TestStatusTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusTestSuite))
 }
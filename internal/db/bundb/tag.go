@@ -0,0 +1,232 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/uptrace/bun"
+)
+
+type tagDB struct {
+	conn *DBConn
+}
+
+// newTrendingTagsQuery returns a query on the status_to_tags table, joined to the statuses, accounts and tags tables,
+// selecting only tag usages which count towards trends: those in public, non-boost statuses with an id greater than
+// sinceID, by accounts which aren't suspended or silenced, and aren't on a silenced domain, of tags which are listable.
+//
+// Status ids are used rather than created_at timestamps to select recent statuses, since ids are ULIDs, and
+// comparing them is done the same way everywhere, whereas timestamps are stored differently by sqlite and postgres.
+func (t *tagDB) newTrendingTagsQuery(sinceID string) *bun.SelectQuery {
+	return t.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+		Join("JOIN ? AS ? ON ? = ?", bun.Ident("statuses"), bun.Ident("status"), bun.Ident("status.id"), bun.Ident("status_to_tag.status_id")).
+		Join("JOIN ? AS ? ON ? = ?", bun.Ident("accounts"), bun.Ident("account"), bun.Ident("account.id"), bun.Ident("status.account_id")).
+		Join("JOIN ? AS ? ON ? = ?", bun.Ident("tags"), bun.Ident("tag"), bun.Ident("tag.id"), bun.Ident("status_to_tag.tag_id")).
+		Where("? > ?", bun.Ident("status.id"), sinceID).
+		Where("? = ?", bun.Ident("status.visibility"), gtsmodel.VisibilityPublic).
+		WhereGroup(" AND ", whereEmptyOrNull("status.boost_of_id")).
+		Where("? IS NULL", bun.Ident("account.suspended_at")).
+		Where("? IS NULL", bun.Ident("account.silenced_at")).
+		WhereGroup(" AND ", whereNotSilencedDomain("account.id")).
+		Where("? = ?", bun.Ident("tag.listable"), true)
+}
+
+func (t *tagDB) GetTrendingTags(ctx context.Context, withinHours int, limit int) ([]*gtsmodel.TrendingTag, db.Error) {
+	now := time.Now()
+	sinceID, err := id.NewULIDFromTime(now.Add(-time.Duration(withinHours) * time.Hour))
+	if err != nil {
+		return nil, err
+	}
+
+	// first rank tags by how many distinct accounts have used them
+	ranked := []struct {
+		TagID    string `bun:"tag_id"`
+		Accounts int    `bun:"accounts"`
+		Uses     int    `bun:"uses"`
+	}{}
+
+	q := t.newTrendingTagsQuery(sinceID).
+		ColumnExpr("? AS ?", bun.Ident("status_to_tag.tag_id"), bun.Ident("tag_id")).
+		ColumnExpr("COUNT(DISTINCT ?) AS ?", bun.Ident("status.account_id"), bun.Ident("accounts")).
+		ColumnExpr("COUNT(*) AS ?", bun.Ident("uses")).
+		GroupExpr("?", bun.Ident("status_to_tag.tag_id")).
+		OrderExpr("? DESC, ? DESC", bun.Ident("accounts"), bun.Ident("uses"))
+
+	if limit > 0 {
+		q = q.Limit(limit)
+	}
+
+	if err := q.Scan(ctx, &ranked); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+
+	if len(ranked) == 0 {
+		return []*gtsmodel.TrendingTag{}, nil
+	}
+
+	tagIDs := make([]string, 0, len(ranked))
+	for _, r := range ranked {
+		tagIDs = append(tagIDs, r.TagID)
+	}
+
+	tags := []*gtsmodel.Tag{}
+	if err := t.conn.
+		NewSelect().
+		Model(&tags).
+		Where("? IN (?)", bun.Ident("tag.id"), bun.In(tagIDs)).
+		Scan(ctx); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+
+	tagsByID := make(map[string]*gtsmodel.Tag, len(tags))
+	for _, tag := range tags {
+		tagsByID[tag.ID] = tag
+	}
+
+	// then get every use of the ranked tags, so we can work out their usage per day
+	uses := []struct {
+		TagID     string    `bun:"tag_id"`
+		AccountID string    `bun:"account_id"`
+		CreatedAt time.Time `bun:"created_at"`
+	}{}
+
+	if err := t.newTrendingTagsQuery(sinceID).
+		ColumnExpr("? AS ?", bun.Ident("status_to_tag.tag_id"), bun.Ident("tag_id")).
+		ColumnExpr("? AS ?", bun.Ident("status.account_id"), bun.Ident("account_id")).
+		ColumnExpr("? AS ?", bun.Ident("status.created_at"), bun.Ident("created_at")).
+		Where("? IN (?)", bun.Ident("status_to_tag.tag_id"), bun.In(tagIDs)).
+		Scan(ctx, &uses); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+
+	// history covers every day that's at least partly within the window, most recent day first
+	today := now.UTC().Truncate(24 * time.Hour)
+	days := (withinHours + 23) / 24
+	if days < 1 {
+		days = 1
+	}
+
+	type dayAccount struct {
+		day       int
+		accountID string
+	}
+
+	histories := make(map[string][]*gtsmodel.TagHistory, len(tagIDs))
+	seen := make(map[string]map[dayAccount]bool, len(tagIDs))
+	for _, tagID := range tagIDs {
+		history := make([]*gtsmodel.TagHistory, 0, days)
+		for i := 0; i < days; i++ {
+			history = append(history, &gtsmodel.TagHistory{Day: today.AddDate(0, 0, -i)})
+		}
+		histories[tagID] = history
+		seen[tagID] = make(map[dayAccount]bool)
+	}
+
+	for _, u := range uses {
+		day := int(today.Sub(u.CreatedAt.UTC().Truncate(24*time.Hour)) / (24 * time.Hour))
+		if day < 0 || day >= days {
+			continue
+		}
+
+		history := histories[u.TagID][day]
+		history.Uses++
+
+		key := dayAccount{day: day, accountID: u.AccountID}
+		if !seen[u.TagID][key] {
+			seen[u.TagID][key] = true
+			history.Accounts++
+		}
+	}
+
+	trending := make([]*gtsmodel.TrendingTag, 0, len(ranked))
+	for _, r := range ranked {
+		tag, ok := tagsByID[r.TagID]
+		if !ok {
+			// tag was deleted in the meantime
+			continue
+		}
+		trending = append(trending, &gtsmodel.TrendingTag{
+			Tag:     tag,
+			History: histories[r.TagID],
+		})
+	}
+
+	return trending, nil
+}
+
+func (t *tagDB) GetTagByName(ctx context.Context, name string) (*gtsmodel.Tag, db.Error) {
+	tag := &gtsmodel.Tag{}
+	if err := t.conn.
+		NewSelect().
+		Model(tag).
+		Where("LOWER(?) = LOWER(?)", bun.Ident("tag.name"), name).
+		Scan(ctx); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return tag, nil
+}
+
+func (t *tagDB) GetFollowedTags(ctx context.Context, accountID string) ([]*gtsmodel.FollowedTag, db.Error) {
+	followedTags := []*gtsmodel.FollowedTag{}
+	if err := t.conn.
+		NewSelect().
+		Model(&followedTags).
+		Relation("Tag").
+		Where("? = ?", bun.Ident("followed_tag.account_id"), accountID).
+		Order("followed_tag.id ASC").
+		Scan(ctx); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return followedTags, nil
+}
+
+func (t *tagDB) IsFollowingTag(ctx context.Context, accountID string, tagID string) (bool, db.Error) {
+	q := t.conn.
+		NewSelect().
+		Model(&gtsmodel.FollowedTag{}).
+		Where("? = ?", bun.Ident("followed_tag.account_id"), accountID).
+		Where("? = ?", bun.Ident("followed_tag.tag_id"), tagID)
+
+	return t.conn.Exists(ctx, q)
+}
+
+func (t *tagDB) GetTagFollowerIDs(ctx context.Context, tagIDs []string) ([]string, db.Error) {
+	accountIDs := []string{}
+	if len(tagIDs) == 0 {
+		return accountIDs, nil
+	}
+
+	if err := t.conn.
+		NewSelect().
+		Model(&gtsmodel.FollowedTag{}).
+		Distinct().
+		Column("followed_tag.account_id").
+		Where("? IN (?)", bun.Ident("followed_tag.tag_id"), bun.In(tagIDs)).
+		Scan(ctx, &accountIDs); err != nil {
+		return nil, t.conn.ProcessError(err)
+	}
+	return accountIDs, nil
+}
@@ -0,0 +1,183 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+type TagTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+// putTaggedStatus puts a new status by the given account, created at the given time, which uses the given tags.
+func (suite *TagTestSuite) putTaggedStatus(account *gtsmodel.Account, createdAt time.Time, visibility gtsmodel.Visibility, tags ...*gtsmodel.Tag) {
+	statusID, err := id.NewULIDFromTime(createdAt)
+	suite.NoError(err)
+
+	tagIDs := []string{}
+	for _, tag := range tags {
+		tagIDs = append(tagIDs, tag.ID)
+	}
+
+	suite.NoError(suite.db.PutStatus(context.Background(), &gtsmodel.Status{
+		ID:                  statusID,
+		URI:                 account.URI + "/statuses/" + statusID,
+		CreatedAt:           createdAt,
+		UpdatedAt:           createdAt,
+		Local:               true,
+		AccountID:           account.ID,
+		AccountURI:          account.URI,
+		TagIDs:              tagIDs,
+		Visibility:          visibility,
+		ActivityStreamsType: ap.ObjectNote,
+	}))
+}
+
+func (suite *TagTestSuite) TestGetTrendingTags() {
+	welcome := suite.testTags["welcome"]
+	hashtag := suite.testTags["Hashtag"]
+	now := time.Now()
+	today := now.UTC().Truncate(24 * time.Hour)
+
+	// #Hashtag is used by two accounts, #welcome is used more often but by only one account
+	suite.putTaggedStatus(suite.testAccounts["local_account_1"], today.Add(1*time.Second), gtsmodel.VisibilityPublic, hashtag)
+	suite.putTaggedStatus(suite.testAccounts["local_account_2"], today.Add(2*time.Second), gtsmodel.VisibilityPublic, hashtag)
+	suite.putTaggedStatus(suite.testAccounts["admin_account"], today.Add(2*time.Second), gtsmodel.VisibilityPublic, welcome)
+	suite.putTaggedStatus(suite.testAccounts["admin_account"], today.Add(3*time.Second), gtsmodel.VisibilityPublic, welcome)
+	suite.putTaggedStatus(suite.testAccounts["admin_account"], today.Add(4*time.Second), gtsmodel.VisibilityPublic, welcome)
+
+	// these shouldn't be counted: they're not public, or too old
+	suite.putTaggedStatus(suite.testAccounts["remote_account_1"], now.Add(-5*time.Minute), gtsmodel.VisibilityFollowersOnly, welcome)
+	suite.putTaggedStatus(suite.testAccounts["remote_account_1"], now.Add(-48*time.Hour), gtsmodel.VisibilityPublic, welcome)
+
+	trending, err := suite.db.GetTrendingTags(context.Background(), 24, 10)
+	suite.NoError(err)
+	if !suite.Len(trending, 2) {
+		suite.FailNow("")
+	}
+
+	suite.Equal(hashtag.ID, trending[0].Tag.ID)
+	suite.Equal(welcome.ID, trending[1].Tag.ID)
+
+	// all the uses were today, so they should all be in the first day of the history
+	hashtagHistory := trending[0].History
+	suite.Len(hashtagHistory, 1)
+	suite.Equal(today, hashtagHistory[0].Day)
+	suite.Equal(2, hashtagHistory[0].Uses)
+	suite.Equal(2, hashtagHistory[0].Accounts)
+
+	welcomeHistory := trending[1].History
+	suite.Len(welcomeHistory, 1)
+	suite.Equal(3, welcomeHistory[0].Uses)
+	suite.Equal(1, welcomeHistory[0].Accounts)
+}
+
+func (suite *TagTestSuite) TestGetTrendingTagsLimit() {
+	now := time.Now()
+	suite.putTaggedStatus(suite.testAccounts["local_account_1"], now.Add(-1*time.Minute), gtsmodel.VisibilityPublic, suite.testTags["Hashtag"])
+	suite.putTaggedStatus(suite.testAccounts["local_account_2"], now.Add(-1*time.Minute), gtsmodel.VisibilityPublic, suite.testTags["welcome"])
+
+	trending, err := suite.db.GetTrendingTags(context.Background(), 24, 1)
+	suite.NoError(err)
+	suite.Len(trending, 1)
+}
+
+func (suite *TagTestSuite) TestGetTrendingTagsExcluded() {
+	welcome := suite.testTags["welcome"]
+	hashtag := suite.testTags["Hashtag"]
+	silenced := suite.testAccounts["local_account_2"]
+	now := time.Now()
+
+	suite.putTaggedStatus(suite.testAccounts["local_account_1"], now.Add(-1*time.Minute), gtsmodel.VisibilityPublic, welcome)
+	suite.putTaggedStatus(silenced, now.Add(-1*time.Minute), gtsmodel.VisibilityPublic, hashtag)
+
+	// statuses by silenced accounts don't count
+	suite.NoError(suite.db.UpdateWhere(context.Background(), []db.Where{{Key: "id", Value: silenced.ID}}, "silenced_at", now, &gtsmodel.Account{}))
+
+	trending, err := suite.db.GetTrendingTags(context.Background(), 24, 10)
+	suite.NoError(err)
+	if suite.Len(trending, 1) {
+		suite.Equal(welcome.ID, trending[0].Tag.ID)
+	}
+
+	// neither do tags which aren't listable
+	suite.NoError(suite.db.UpdateWhere(context.Background(), []db.Where{{Key: "id", Value: welcome.ID}}, "listable", false, &gtsmodel.Tag{}))
+
+	trending, err = suite.db.GetTrendingTags(context.Background(), 24, 10)
+	suite.NoError(err)
+	suite.Empty(trending)
+}
+
+func (suite *TagTestSuite) TestGetTagByName() {
+	tag, err := suite.db.GetTagByName(context.Background(), "WELCOME")
+	suite.NoError(err)
+	suite.Equal(suite.testTags["welcome"].ID, tag.ID)
+
+	_, err = suite.db.GetTagByName(context.Background(), "nonexistent")
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *TagTestSuite) TestFollowedTags() {
+	account := suite.testAccounts["local_account_1"]
+	welcome := suite.testTags["welcome"]
+	hashtag := suite.testTags["Hashtag"]
+
+	following, err := suite.db.IsFollowingTag(context.Background(), account.ID, welcome.ID)
+	suite.NoError(err)
+	suite.False(following)
+
+	suite.NoError(suite.db.Put(context.Background(), &gtsmodel.FollowedTag{
+		ID:        "01G5DCB6Q5ME4TWG5XRD0XS5R3",
+		AccountID: account.ID,
+		TagID:     welcome.ID,
+	}))
+	suite.NoError(suite.db.Put(context.Background(), &gtsmodel.FollowedTag{
+		ID:        "01G5DCBD9GJ4S8AAHQ1ZN8W9R4",
+		AccountID: account.ID,
+		TagID:     hashtag.ID,
+	}))
+
+	following, err = suite.db.IsFollowingTag(context.Background(), account.ID, welcome.ID)
+	suite.NoError(err)
+	suite.True(following)
+
+	followedTags, err := suite.db.GetFollowedTags(context.Background(), account.ID)
+	suite.NoError(err)
+	if suite.Len(followedTags, 2) {
+		suite.Equal("welcome", followedTags[0].Tag.Name)
+		suite.Equal("Hashtag", followedTags[1].Tag.Name)
+	}
+
+	followerIDs, err := suite.db.GetTagFollowerIDs(context.Background(), []string{welcome.ID, hashtag.ID})
+	suite.NoError(err)
+	suite.Equal([]string{account.ID}, followerIDs)
+}
+
+func TestTagTestSuite(t *testing.T) {
+	suite.Run(t, new(TagTestSuite))
+}
@@ -21,7 +21,6 @@ package bundb
 import (
 	"context"
 	"database/sql"
-	"sort"
 
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -29,10 +28,11 @@ import (
 )
 
 type timelineDB struct {
-	conn *DBConn
+	conn     *DBConn
+	statuses *statusDB
 }
 
-func (t *timelineDB) GetHomeTimeline(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int, local bool) ([]*gtsmodel.Status, db.Error) {
+func (t *timelineDB) GetHomeTimeline(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int, local bool, languages *db.LanguageFilter) ([]*gtsmodel.Status, db.Error) {
 	// Ensure reasonable
 	if limit < 0 {
 		limit = 0
@@ -71,20 +71,35 @@ func (t *timelineDB) GetHomeTimeline(ctx context.Context, accountID string, maxI
 		q = q.Where("status.local = ?", local)
 	}
 
+	if languages != nil {
+		// return only statuses in the requested languages
+		q = q.WhereGroup(" AND ", whereLanguage("status.language", languages))
+	}
+
 	if limit > 0 {
 		// limit amount of statuses returned
 		q = q.Limit(limit)
 	}
 
+	// Select the ids of statuses which use a tag that accountID follows.
+	followedTagStatuses := t.conn.
+		NewSelect().
+		TableExpr("? AS ?", bun.Ident("status_to_tags"), bun.Ident("status_to_tag")).
+		Column("status_to_tag.status_id").
+		Join("JOIN ? AS ? ON ? = ?", bun.Ident("followed_tags"), bun.Ident("followed_tag"), bun.Ident("followed_tag.tag_id"), bun.Ident("status_to_tag.tag_id")).
+		Where("? = ?", bun.Ident("followed_tag.account_id"), accountID)
+
 	// Use a WhereGroup here to specify that we want EITHER statuses posted by accounts that accountID follows,
-	// OR statuses posted by accountID itself (since a user should be able to see their own statuses).
+	// OR statuses posted by accountID itself (since a user should be able to see their own statuses),
+	// OR public statuses using a tag that accountID follows.
 	//
 	// This is equivalent to something like WHERE ... AND (... OR ...)
 	// See: https://bun.uptrace.dev/guide/queries.html#select
 	whereGroup := func(*bun.SelectQuery) *bun.SelectQuery {
 		return q.
 			WhereOr("f.account_id = ?", accountID).
-			WhereOr("status.account_id = ?", accountID)
+			WhereOr("status.account_id = ?", accountID).
+			WhereOr("(status.visibility = ? AND status.id IN (?))", gtsmodel.VisibilityPublic, followedTagStatuses)
 	}
 
 	q = q.WhereGroup(" AND ", whereGroup)
@@ -95,7 +110,7 @@ func (t *timelineDB) GetHomeTimeline(ctx context.Context, accountID string, maxI
 	return statuses, nil
 }
 
-func (t *timelineDB) GetPublicTimeline(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int, local bool) ([]*gtsmodel.Status, db.Error) {
+func (t *timelineDB) GetPublicTimeline(ctx context.Context, accountID string, maxID string, sinceID string, minID string, limit int, local bool, languages *db.LanguageFilter) ([]*gtsmodel.Status, db.Error) {
 	// Ensure reasonable
 	if limit < 0 {
 		limit = 0
@@ -111,6 +126,9 @@ func (t *timelineDB) GetPublicTimeline(ctx context.Context, accountID string, ma
 		WhereGroup(" AND ", whereEmptyOrNull("in_reply_to_id")).
 		WhereGroup(" AND ", whereEmptyOrNull("in_reply_to_uri")).
 		WhereGroup(" AND ", whereEmptyOrNull("boost_of_id")).
+		// statuses from silenced accounts and domains are still delivered to followers, just not shown publicly
+		WhereGroup(" AND ", whereNotSilencedAccount("status.account_id")).
+		WhereGroup(" AND ", whereNotSilencedDomain("status.account_id")).
 		Order("status.id DESC")
 
 	if maxID != "" {
@@ -129,6 +147,10 @@ func (t *timelineDB) GetPublicTimeline(ctx context.Context, accountID string, ma
 		q = q.Where("status.local = ?", local)
 	}
 
+	if languages != nil {
+		q = q.WhereGroup(" AND ", whereLanguage("status.language", languages))
+	}
+
 	if limit > 0 {
 		q = q.Limit(limit)
 	}
@@ -180,36 +202,21 @@ func (t *timelineDB) GetFavedTimeline(ctx context.Context, accountID string, max
 		return nil, "", "", db.ErrNoEntries
 	}
 
-	// map[statusID]faveID -- we need this to sort statuses by fave ID rather than status ID
-	statusesFavesMap := make(map[string]string, len(faves))
 	statusIDs := make([]string, 0, len(faves))
 	for _, f := range faves {
-		statusesFavesMap[f.StatusID] = f.ID
 		statusIDs = append(statusIDs, f.StatusID)
 	}
 
-	statuses := make([]*gtsmodel.Status, 0, len(statusIDs))
-
-	err = t.conn.
-		NewSelect().
-		Model(&statuses).
-		Where("id IN (?)", bun.In(statusIDs)).
-		Scan(ctx)
+	// statuses come back in the same order as the faves, ie., most recently faved first
+	statuses, err := t.statuses.GetStatusesByIDs(ctx, statusIDs)
 	if err != nil {
-		return nil, "", "", t.conn.ProcessError(err)
+		return nil, "", "", err
 	}
 
 	if len(statuses) == 0 {
 		return nil, "", "", db.ErrNoEntries
 	}
 
-	// arrange statuses by fave ID
-	sort.Slice(statuses, func(i int, j int) bool {
-		statusI := statuses[i]
-		statusJ := statuses[j]
-		return statusesFavesMap[statusI.ID] < statusesFavesMap[statusJ.ID]
-	})
-
 	nextMaxID := faves[len(faves)-1].ID
 	prevMinID := faves[0].ID
 	return statuses, nextMaxID, prevMinID, nil
@@ -21,8 +21,11 @@ package bundb_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type TimelineTestSuite struct {
@@ -32,12 +35,183 @@ type TimelineTestSuite struct {
 func (suite *TimelineTestSuite) TestGetPublicTimeline() {
 	viewingAccount := suite.testAccounts["local_account_1"]
 
-	s, err := suite.db.GetPublicTimeline(context.Background(), viewingAccount.ID, "", "", "", 20, false)
+	s, err := suite.db.GetPublicTimeline(context.Background(), viewingAccount.ID, "", "", "", 20, false, nil)
 	suite.NoError(err)
 
 	suite.Len(s, 6)
 }
 
+func (suite *TimelineTestSuite) TestGetPublicTimelineSilencedAccount() {
+	ctx := context.Background()
+	viewingAccount := suite.testAccounts["local_account_1"]
+
+	// copy the account so we don't mess with the shared test model
+	silencedAccount := &gtsmodel.Account{}
+	*silencedAccount = *suite.testAccounts["admin_account"]
+
+	all, err := suite.db.GetPublicTimeline(ctx, viewingAccount.ID, "", "", "", 20, false, nil)
+	suite.NoError(err)
+	suite.Len(all, 6)
+
+	fromSilenced := 0
+	for _, status := range all {
+		if status.AccountID == silencedAccount.ID {
+			fromSilenced++
+		}
+	}
+	suite.NotZero(fromSilenced)
+
+	silencedAccount.SilencedAt = time.Now()
+	_, err = suite.db.UpdateAccount(ctx, silencedAccount)
+	suite.NoError(err)
+
+	// statuses from the silenced account should be gone from the public timeline
+	s, err := suite.db.GetPublicTimeline(ctx, viewingAccount.ID, "", "", "", 20, false, nil)
+	suite.NoError(err)
+	suite.Len(s, len(all)-fromSilenced)
+	for _, status := range s {
+		suite.NotEqual(silencedAccount.ID, status.AccountID)
+	}
+}
+
+func (suite *TimelineTestSuite) TestGetPublicTimelineSilencedDomain() {
+	ctx := context.Background()
+	viewingAccount := suite.testAccounts["local_account_1"]
+	remoteStatus := suite.testStatuses["remote_account_1_status_1"]
+
+	// make the remote status public, so that it shows up in the public timeline
+	suite.NoError(suite.db.UpdateWhere(ctx, []db.Where{{Key: "id", Value: remoteStatus.ID}}, "visibility", gtsmodel.VisibilityPublic, &gtsmodel.Status{}))
+
+	s, err := suite.db.GetPublicTimeline(ctx, viewingAccount.ID, "", "", "", 20, false, nil)
+	suite.NoError(err)
+	suite.Len(s, 7)
+
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.DomainSilence{
+		ID:                 "01G5MB5QZ6T2SX8M5VYGWM0N4F",
+		Domain:             suite.testAccounts["remote_account_1"].Domain,
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}))
+
+	// the status from the silenced domain should be gone from the public timeline again
+	s, err = suite.db.GetPublicTimeline(ctx, viewingAccount.ID, "", "", "", 20, false, nil)
+	suite.NoError(err)
+	suite.Len(s, 6)
+	for _, status := range s {
+		suite.NotEqual(remoteStatus.ID, status.ID)
+	}
+}
+
+func (suite *TimelineTestSuite) TestGetPublicTimelineLanguages() {
+	viewingAccount := suite.testAccounts["local_account_1"]
+
+	all, err := suite.db.GetPublicTimeline(context.Background(), viewingAccount.ID, "", "", "", 20, false, nil)
+	suite.NoError(err)
+	suite.Len(all, 6)
+
+	// switch one of the statuses to german, and another to no language at all
+	german := all[0]
+	unknown := all[1]
+	suite.NoError(suite.db.UpdateWhere(context.Background(), []db.Where{{Key: "id", Value: german.ID}}, "language", "de", &gtsmodel.Status{}))
+	suite.NoError(suite.db.UpdateWhere(context.Background(), []db.Where{{Key: "id", Value: unknown.ID}}, "language", nil, &gtsmodel.Status{}))
+
+	getIDs := func(languages *db.LanguageFilter) []string {
+		statuses, err := suite.db.GetPublicTimeline(context.Background(), viewingAccount.ID, "", "", "", 20, false, languages)
+		suite.NoError(err)
+		ids := []string{}
+		for _, s := range statuses {
+			ids = append(ids, s.ID)
+		}
+		return ids
+	}
+
+	suite.Equal([]string{german.ID}, getIDs(&db.LanguageFilter{Languages: []string{"de"}}))
+	suite.Equal([]string{german.ID, unknown.ID}, getIDs(&db.LanguageFilter{Languages: []string{"de"}, IncludeUnknown: true}))
+
+	english := getIDs(&db.LanguageFilter{Languages: []string{"en"}})
+	suite.Len(english, 4)
+	suite.NotContains(english, german.ID)
+	suite.NotContains(english, unknown.ID)
+
+	suite.Len(getIDs(&db.LanguageFilter{Languages: []string{"en", "de"}}), 5)
+	suite.Len(getIDs(&db.LanguageFilter{Languages: []string{"en"}, IncludeUnknown: true}), 5)
+
+	// no languages means any known language
+	known := getIDs(&db.LanguageFilter{})
+	suite.Len(known, 5)
+	suite.NotContains(known, unknown.ID)
+}
+
+func (suite *TimelineTestSuite) TestGetHomeTimelineLanguages() {
+	viewingAccount := suite.testAccounts["local_account_1"]
+
+	all, err := suite.db.GetHomeTimeline(context.Background(), viewingAccount.ID, "", "", "", 20, false, nil)
+	suite.NoError(err)
+	suite.NotEmpty(all)
+
+	german := all[0]
+	suite.NoError(suite.db.UpdateWhere(context.Background(), []db.Where{{Key: "id", Value: german.ID}}, "language", "de", &gtsmodel.Status{}))
+
+	statuses, err := suite.db.GetHomeTimeline(context.Background(), viewingAccount.ID, "", "", "", 20, false, &db.LanguageFilter{Languages: []string{"de"}})
+	suite.NoError(err)
+	if suite.Len(statuses, 1) {
+		suite.Equal(german.ID, statuses[0].ID)
+	}
+}
+
+func (suite *TimelineTestSuite) TestGetHomeTimelineFollowedTag() {
+	// local_account_2 doesn't follow admin_account, so admin's first status shouldn't be in its home timeline...
+	viewingAccount := suite.testAccounts["local_account_2"]
+	taggedStatus := suite.testStatuses["admin_account_status_1"]
+
+	containsTaggedStatus := func() bool {
+		statuses, err := suite.db.GetHomeTimeline(context.Background(), viewingAccount.ID, "", "", "", 20, false, nil)
+		suite.NoError(err)
+		for _, s := range statuses {
+			if s.ID == taggedStatus.ID {
+				return true
+			}
+		}
+		return false
+	}
+	suite.False(containsTaggedStatus())
+
+	// ...until it follows the #welcome tag that the status uses
+	suite.NoError(suite.db.Put(context.Background(), &gtsmodel.FollowedTag{
+		ID:        "01G5DC5ZP7RXTCY5X8C2QH1K7Y",
+		AccountID: viewingAccount.ID,
+		TagID:     suite.testTags["welcome"].ID,
+	}))
+	suite.True(containsTaggedStatus())
+}
+
+func (suite *TimelineTestSuite) TestGetFavedTimeline() {
+	viewingAccount := suite.testAccounts["local_account_1"]
+	olderFave := suite.testFaves["local_account_1_admin_account_status_1"]
+	newerFaved := suite.testStatuses["local_account_2_status_1"]
+
+	// fave another status, more recently than the existing fave
+	newerFave := &gtsmodel.StatusFave{
+		ID:              "01G5A4F3WFQ9T1X4VEPXF7A6SB",
+		AccountID:       viewingAccount.ID,
+		TargetAccountID: newerFaved.AccountID,
+		StatusID:        newerFaved.ID,
+		URI:             viewingAccount.URI + "/liked/01G5A4F3WFQ9T1X4VEPXF7A6SB",
+	}
+	suite.NoError(suite.db.Put(context.Background(), newerFave))
+
+	statuses, nextMaxID, prevMinID, err := suite.db.GetFavedTimeline(context.Background(), viewingAccount.ID, "", "", 20)
+	suite.NoError(err)
+
+	// most recently faved statuses should come first
+	if suite.Len(statuses, 2) {
+		suite.Equal(newerFaved.ID, statuses[0].ID)
+		suite.Equal(olderFave.StatusID, statuses[1].ID)
+		suite.NotNil(statuses[0].Account)
+	}
+	suite.Equal(olderFave.ID, nextMaxID)
+	suite.Equal(newerFave.ID, prevMinID)
+}
+
 func TestTimelineTestSuite(t *testing.T) {
 	suite.Run(t, new(TimelineTestSuite))
 }
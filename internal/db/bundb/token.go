@@ -0,0 +1,167 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type tokenDB struct {
+	conn *DBConn
+}
+
+func (t *tokenDB) DeleteExpiredTokens(ctx context.Context, now time.Time) (int, db.Error) {
+	res, err := t.conn.
+		NewDelete().
+		Model(&gtsmodel.Token{}).
+		// null expiry times mean 'never expires', and
+		// comparisons with null are never true, so
+		// we don't need to check for them explicitly
+		WhereGroup(" AND ", func(q *bun.DeleteQuery) *bun.DeleteQuery {
+			return q.
+				WhereOr("? < ?", bun.Ident("code_expires_at"), now).
+				WhereOr("? < ?", bun.Ident("access_expires_at"), now).
+				WhereOr("? < ?", bun.Ident("refresh_expires_at"), now)
+		}).
+		// leave tokens alone if they still have a usable refresh token
+		WhereGroup(" AND ", func(q *bun.DeleteQuery) *bun.DeleteQuery {
+			return q.
+				WhereOr("? IS NULL", bun.Ident("refresh")).
+				WhereOr("? = ''", bun.Ident("refresh")).
+				WhereOr("? < ?", bun.Ident("refresh_expires_at"), now)
+		}).
+		Exec(ctx)
+	if err != nil {
+		return 0, t.conn.ProcessError(err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, t.conn.ProcessError(err)
+	}
+
+	return int(deleted), nil
+}
+
+func (t *tokenDB) DeleteInactiveTokens(ctx context.Context, since time.Time) (int, db.Error) {
+	res, err := t.conn.
+		NewDelete().
+		Model(&gtsmodel.Token{}).
+		Where("COALESCE(?, ?) < ?", bun.Ident("last_used"), bun.Ident("created_at"), since).
+		Exec(ctx)
+	if err != nil {
+		return 0, t.conn.ProcessError(err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, t.conn.ProcessError(err)
+	}
+
+	return int(deleted), nil
+}
+
+func (t *tokenDB) RotateToken(ctx context.Context, id string, rotatedAt time.Time) (bool, db.Error) {
+	res, err := t.conn.
+		NewUpdate().
+		Model(&gtsmodel.Token{}).
+		Set("? = ?", bun.Ident("rotated_at"), rotatedAt).
+		Set("? = ''", bun.Ident("access")).
+		Where("? = ?", bun.Ident("id"), id).
+		Where("? IS NULL", bun.Ident("rotated_at")).
+		Exec(ctx)
+	if err != nil {
+		return false, t.conn.ProcessError(err)
+	}
+
+	rotated, err := res.RowsAffected()
+	if err != nil {
+		return false, t.conn.ProcessError(err)
+	}
+
+	return rotated == 1, nil
+}
+
+func (t *tokenDB) DeleteUserTokens(ctx context.Context, userID string) db.Error {
+	return t.conn.RunInTx(ctx, func(tx bun.Tx) error {
+		clientIDs := []string{}
+		if err := tx.
+			NewSelect().
+			Model(&gtsmodel.Token{}).
+			Column("client_id").
+			Distinct().
+			Where("? = ?", bun.Ident("user_id"), userID).
+			Scan(ctx, &clientIDs); err != nil {
+			return err
+		}
+
+		if _, err := tx.
+			NewDelete().
+			Model(&gtsmodel.Token{}).
+			Where("? = ?", bun.Ident("user_id"), userID).
+			Exec(ctx); err != nil {
+			return err
+		}
+
+		// other users may have signed in through the same client,
+		// so it only goes once none of their tokens are left
+		for _, clientID := range clientIDs {
+			remaining, err := tx.
+				NewSelect().
+				Model(&gtsmodel.Token{}).
+				Where("? = ?", bun.Ident("client_id"), clientID).
+				Count(ctx)
+			if err != nil {
+				return err
+			}
+			if remaining != 0 {
+				continue
+			}
+
+			if err := deleteClient(ctx, tx, clientID); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+}
+
+// deleteClient deletes the oauth client with the given ID, and the application it belongs to.
+func deleteClient(ctx context.Context, tx bun.Tx, clientID string) error {
+	if _, err := tx.
+		NewDelete().
+		Model(&gtsmodel.Client{}).
+		Where("? = ?", bun.Ident("id"), clientID).
+		Exec(ctx); err != nil {
+		return err
+	}
+
+	_, err := tx.
+		NewDelete().
+		Model(&gtsmodel.Application{}).
+		Where("? = ?", bun.Ident("client_id"), clientID).
+		Exec(ctx)
+	return err
+}
@@ -0,0 +1,190 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type TokenTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *TokenTestSuite) TestDeleteExpiredTokens() {
+	ctx := context.Background()
+	now := time.Now()
+	existing := suite.testTokens["local_account_1"]
+
+	tokens := map[string]*gtsmodel.Token{
+		"expired code": {
+			ID:            "01G2FDQXW8FS36N0QDF6H9CG51",
+			Code:          "EXPIREDCODE",
+			CodeCreateAt:  now.Add(-20 * time.Minute),
+			CodeExpiresAt: now.Add(-10 * time.Minute),
+		},
+		"expired access": {
+			ID:              "01G2FDR7TXRQ7A7Z3TBXQ6EBW8",
+			Access:          "EXPIREDACCESS",
+			AccessCreateAt:  now.Add(-2 * time.Hour),
+			AccessExpiresAt: now.Add(-1 * time.Hour),
+		},
+		"expired access with non-expiring refresh": {
+			ID:              "01G2FDRF8VSZ8ZJ7S0KXK6EC1W",
+			Access:          "EXPIREDACCESSWITHREFRESH",
+			AccessCreateAt:  now.Add(-2 * time.Hour),
+			AccessExpiresAt: now.Add(-1 * time.Hour),
+			Refresh:         "NEVEREXPIRINGREFRESH",
+			RefreshCreateAt: now.Add(-2 * time.Hour),
+		},
+		"non-expiring access": {
+			ID:             "01G2FDRP0N3W3GT1K1KF1SD1EZ",
+			Access:         "NEVEREXPIRINGACCESS",
+			AccessCreateAt: now.Add(-2 * time.Hour),
+		},
+	}
+	for _, t := range tokens {
+		t.ClientID = existing.ClientID
+		t.UserID = existing.UserID
+		t.RedirectURI = existing.RedirectURI
+		t.Scope = existing.Scope
+		suite.NoError(suite.db.Put(ctx, t))
+	}
+
+	deleted, err := suite.db.DeleteExpiredTokens(ctx, now)
+	suite.NoError(err)
+	suite.Equal(2, deleted)
+
+	for desc, t := range tokens {
+		err := suite.db.GetByID(ctx, t.ID, &gtsmodel.Token{})
+		switch desc {
+		case "expired code", "expired access":
+			suite.ErrorIs(err, db.ErrNoEntries, desc)
+		default:
+			suite.NoError(err, desc)
+		}
+	}
+
+	// the standard test tokens haven't expired yet
+	for _, t := range suite.testTokens {
+		suite.NoError(suite.db.GetByID(ctx, t.ID, &gtsmodel.Token{}))
+	}
+}
+
+func (suite *TokenTestSuite) TestDeleteInactiveTokens() {
+	ctx := context.Background()
+	now := time.Now()
+	existing := suite.testTokens["local_account_1"]
+
+	tokens := map[string]*gtsmodel.Token{
+		"old and used recently": {
+			ID:        "01G6BZ7Q1W6V0V8XKJ4Y2R9S5D",
+			CreatedAt: now.Add(-100 * 24 * time.Hour),
+			Access:    "OLDUSEDRECENTLY",
+			LastUsed:  now.Add(-1 * time.Hour),
+		},
+		"old and used long ago": {
+			ID:        "01G6BZ7Y0T5C9Q7M3F8N1H2E4B",
+			CreatedAt: now.Add(-100 * 24 * time.Hour),
+			Access:    "OLDUSEDLONGAGO",
+			LastUsed:  now.Add(-60 * 24 * time.Hour),
+		},
+		"old and never used": {
+			ID:        "01G6BZ84YJ2D3K5W7P9A0C6G8F",
+			CreatedAt: now.Add(-100 * 24 * time.Hour),
+			Access:    "OLDNEVERUSED",
+		},
+		"new and never used": {
+			ID:        "01G6BZ8BQ4E6S8V0X2Z5B7D9H1",
+			CreatedAt: now.Add(-1 * time.Hour),
+			Access:    "NEWNEVERUSED",
+		},
+	}
+	for _, t := range tokens {
+		t.ClientID = existing.ClientID
+		t.UserID = existing.UserID
+		t.RedirectURI = existing.RedirectURI
+		t.Scope = existing.Scope
+		t.AccessCreateAt = t.CreatedAt
+		suite.NoError(suite.db.Put(ctx, t))
+	}
+
+	deleted, err := suite.db.DeleteInactiveTokens(ctx, now.Add(-30*24*time.Hour))
+	suite.NoError(err)
+	suite.Equal(2, deleted)
+
+	for desc, t := range tokens {
+		err := suite.db.GetByID(ctx, t.ID, &gtsmodel.Token{})
+		switch desc {
+		case "old and used long ago", "old and never used":
+			suite.ErrorIs(err, db.ErrNoEntries, desc)
+		default:
+			suite.NoError(err, desc)
+		}
+	}
+}
+
+func (suite *TokenTestSuite) TestDeleteUserTokens() {
+	ctx := context.Background()
+	application1 := suite.testApplications["application_1"]
+	application2 := suite.testApplications["application_2"]
+
+	// local_account_2 also signs in through the client of application_1
+	sharedToken := &gtsmodel.Token{
+		ID:              "01G6J8CF1NXPJJ4QBMJ0Y6WZ2K",
+		ClientID:        application1.ClientID,
+		UserID:          suite.testUsers["local_account_2"].ID,
+		RedirectURI:     "http://localhost:8080",
+		Scope:           "read",
+		Access:          "SHAREDCLIENTACCESS",
+		AccessCreateAt:  time.Now(),
+		AccessExpiresAt: time.Now().Add(72 * time.Hour),
+	}
+	suite.NoError(suite.db.Put(ctx, sharedToken))
+
+	suite.NoError(suite.db.DeleteUserTokens(ctx, suite.testUsers["local_account_1"].ID))
+
+	// the user's own token is gone, but the application is still in use by local_account_2
+	suite.ErrorIs(suite.db.GetByID(ctx, suite.testTokens["local_account_1"].ID, &gtsmodel.Token{}), db.ErrNoEntries)
+	suite.NoError(suite.db.GetByID(ctx, sharedToken.ID, &gtsmodel.Token{}))
+	suite.NoError(suite.db.GetByID(ctx, application1.ID, &gtsmodel.Application{}))
+	suite.NoError(suite.db.GetByID(ctx, application1.ClientID, &gtsmodel.Client{}))
+
+	suite.NoError(suite.db.DeleteUserTokens(ctx, suite.testUsers["local_account_2"].ID))
+
+	// now nobody is using either application, so they're gone too
+	suite.ErrorIs(suite.db.GetByID(ctx, sharedToken.ID, &gtsmodel.Token{}), db.ErrNoEntries)
+	suite.ErrorIs(suite.db.GetByID(ctx, suite.testTokens["local_account_2"].ID, &gtsmodel.Token{}), db.ErrNoEntries)
+	for _, application := range []*gtsmodel.Application{application1, application2} {
+		suite.ErrorIs(suite.db.GetByID(ctx, application.ID, &gtsmodel.Application{}), db.ErrNoEntries)
+		suite.ErrorIs(suite.db.GetByID(ctx, application.ClientID, &gtsmodel.Client{}), db.ErrNoEntries)
+	}
+
+	// other users' tokens are left alone
+	suite.NoError(suite.db.GetByID(ctx, suite.testTokens["admin_account"].ID, &gtsmodel.Token{}))
+}
+
+func TestTokenTestSuite(t *testing.T) {
+	suite.Run(t, new(TokenTestSuite))
+}
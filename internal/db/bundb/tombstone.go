@@ -0,0 +1,75 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/uptrace/bun"
+)
+
+type tombstoneDB struct {
+	conn *DBConn
+}
+
+func (t *tombstoneDB) TombstoneExistsWithURI(ctx context.Context, uri string) (bool, db.Error) {
+	q := t.conn.
+		NewSelect().
+		Model(&gtsmodel.Tombstone{}).
+		Where("? = ?", bun.Ident("uri"), uri)
+
+	return t.conn.Exists(ctx, q)
+}
+
+func (t *tombstoneDB) PutTombstone(ctx context.Context, tombstone *gtsmodel.Tombstone) db.Error {
+	if _, err := t.conn.
+		NewInsert().
+		Model(tombstone).
+		Exec(ctx); err != nil {
+		err = t.conn.ProcessError(err)
+		var alreadyExists *db.ErrAlreadyExists
+		if errors.As(err, &alreadyExists) {
+			return nil
+		}
+		return err
+	}
+	return nil
+}
+
+func (t *tombstoneDB) DeleteExpiredTombstones(ctx context.Context, before time.Time) (int, db.Error) {
+	res, err := t.conn.
+		NewDelete().
+		Model(&gtsmodel.Tombstone{}).
+		Where("? < ?", bun.Ident("created_at"), before).
+		Exec(ctx)
+	if err != nil {
+		return 0, t.conn.ProcessError(err)
+	}
+
+	deleted, err := res.RowsAffected()
+	if err != nil {
+		return 0, t.conn.ProcessError(err)
+	}
+
+	return int(deleted), nil
+}
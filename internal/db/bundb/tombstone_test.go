@@ -0,0 +1,68 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type TombstoneTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *TombstoneTestSuite) TestDeleteExpiredTombstones() {
+	ctx := context.Background()
+	now := time.Now()
+
+	old := &gtsmodel.Tombstone{
+		ID:        "01G5PJ7X9ZQDT6WN0GX6H0S9VE",
+		CreatedAt: now.Add(-48 * time.Hour),
+		UpdatedAt: now.Add(-48 * time.Hour),
+		Domain:    "fossbros-anonymous.io",
+		URI:       "http://fossbros-anonymous.io/users/foss_satan/statuses/01G5PJ8F5D3NQ4M7W6C9R0ZK2A",
+	}
+	recent := &gtsmodel.Tombstone{
+		ID:     "01G5PJ7YJ3E4XKD1B2W8V5N6TF",
+		Domain: "fossbros-anonymous.io",
+		URI:    "http://fossbros-anonymous.io/users/foss_satan/statuses/01G5PJ8QK0R7G8DH3YF4PBW1XM",
+	}
+	suite.NoError(suite.db.PutTombstone(ctx, old))
+	suite.NoError(suite.db.PutTombstone(ctx, recent))
+
+	deleted, err := suite.db.DeleteExpiredTombstones(ctx, now.Add(-24*time.Hour))
+	suite.NoError(err)
+	suite.Equal(1, deleted)
+
+	tombstoned, err := suite.db.TombstoneExistsWithURI(ctx, old.URI)
+	suite.NoError(err)
+	suite.False(tombstoned)
+
+	tombstoned, err = suite.db.TombstoneExistsWithURI(ctx, recent.URI)
+	suite.NoError(err)
+	suite.True(tombstoned)
+}
+
+func TestTombstoneTestSuite(t *testing.T) {
+	suite.Run(t, new(TombstoneTestSuite))
+}
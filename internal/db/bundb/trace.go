@@ -24,6 +24,7 @@ import (
 
 	"github.com/sirupsen/logrus"
 	"github.com/uptrace/bun"
+	"github.com/uptrace/bun/schema"
 )
 
 func newDebugQueryHook() bun.QueryHook {
@@ -40,21 +41,60 @@ func (q *debugQueryHook) BeforeQuery(ctx context.Context, _ *bun.QueryEvent) con
 }
 
 // AfterQuery logs the time taken to query, the operation (select, update, etc), and the query itself as translated by bun.
-func (q *debugQueryHook) AfterQuery(_ context.Context, event *bun.QueryEvent) {
+func (q *debugQueryHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
 	dur := time.Since(event.StartTime).Round(time.Microsecond)
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"duration":  dur,
 		"operation": event.Operation(),
 	})
 
-	if dur > 1*time.Second {
-		l.Warnf("SLOW DATABASE QUERY [%s] %s", dur, event.Query)
-		return
-	}
-
 	if logrus.GetLevel() == logrus.TraceLevel {
 		l.Tracef("[%s] %s", dur, event.Query)
 	} else {
 		l.Debugf("[%s] %s", dur, event.Operation())
 	}
 }
+
+func newSlowQueryHook(threshold time.Duration) bun.QueryHook {
+	return &slowQueryHook{threshold: threshold}
+}
+
+// slowQueryHook implements bun.QueryHook, logging queries that took longer than threshold
+type slowQueryHook struct {
+	threshold time.Duration
+}
+
+func (q *slowQueryHook) BeforeQuery(ctx context.Context, _ *bun.QueryEvent) context.Context {
+	// do nothing
+	return ctx
+}
+
+// AfterQuery logs the time taken, the operation, and the query template of queries that took longer than the threshold.
+func (q *slowQueryHook) AfterQuery(ctx context.Context, event *bun.QueryEvent) {
+	dur := time.Since(event.StartTime)
+	if dur < q.threshold {
+		return
+	}
+	dur = dur.Round(time.Microsecond)
+
+	logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"duration":  dur,
+		"operation": event.Operation(),
+	}).Warnf("SLOW DATABASE QUERY [%s] %s", dur, queryTemplate(event))
+}
+
+// queryTemplate returns the query of the given event with any parameter values replaced by '?',
+// so that it can be logged without leaking potentially sensitive values like passwords or tokens.
+func queryTemplate(event *bun.QueryEvent) string {
+	if event.IQuery != nil {
+		// format the query again with a nop formatter, which leaves out any args
+		if b, err := event.IQuery.AppendQuery(schema.NewNopFormatter(), nil); err == nil {
+			return string(b)
+		}
+	}
+	if event.QueryTemplate != "" {
+		// raw query, where the template has placeholders for args
+		return event.QueryTemplate
+	}
+	return "[query not available]"
+}
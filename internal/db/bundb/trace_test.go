@@ -0,0 +1,73 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package bundb_test
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TraceTestSuite struct {
+	BunDBStandardTestSuite
+}
+
+func (suite *TraceTestSuite) TestSlowQueryLogged() {
+	// every query is slow if the threshold is tiny
+	viper.Set(config.Keys.DbSlowQueryThreshold, time.Nanosecond)
+	defer viper.Set(config.Keys.DbSlowQueryThreshold, testrig.TestDefaults.DbSlowQueryThreshold)
+	slowDB := testrig.NewTestDB()
+
+	buf := &bytes.Buffer{}
+	logrus.SetOutput(buf)
+	defer testrig.InitTestLog()
+
+	ctx := log.WithRequestID(context.Background(), "01G1WQ1ZB8FGC4N7BDVS37X3S5")
+	account, err := slowDB.GetLocalAccountByUsername(ctx, "the_mighty_zork")
+	suite.NoError(err)
+	suite.NotNil(account)
+
+	var slowLines []string
+	for _, line := range strings.Split(buf.String(), "\n") {
+		if strings.Contains(line, "SLOW DATABASE QUERY") {
+			slowLines = append(slowLines, line)
+		}
+	}
+	suite.NotEmpty(slowLines)
+
+	for _, line := range slowLines {
+		suite.Contains(line, "level=warning")
+		suite.Contains(line, "requestID=01G1WQ1ZB8FGC4N7BDVS37X3S5")
+		// parameter values shouldn't be logged
+		suite.NotContains(line, "the_mighty_zork")
+	}
+}
+
+func TestTraceTestSuite(t *testing.T) {
+	suite.Run(t, new(TraceTestSuite))
+}
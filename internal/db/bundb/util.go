@@ -51,6 +51,65 @@ func whereNotEmptyAndNotNull(column string) func(*bun.SelectQuery) *bun.SelectQu
 	}
 }
 
+// whereLanguage is a convenience function to return a bun WhereGroup that specifies
+// that the given language column should match the given language filter.
+//
+// Use it as follows:
+//
+//   q = q.WhereGroup(" AND ", whereLanguage("status.language", languages))
+func whereLanguage(column string, languages *db.LanguageFilter) func(*bun.SelectQuery) *bun.SelectQuery {
+	return func(q *bun.SelectQuery) *bun.SelectQuery {
+		if len(languages.Languages) != 0 {
+			q = q.WhereOr("? IN (?)", bun.Ident(column), bun.In(languages.Languages))
+		} else {
+			q = q.WhereOr("(? IS NOT NULL AND ? != '')", bun.Ident(column), bun.Ident(column))
+		}
+
+		if languages.IncludeUnknown {
+			q = q.
+				WhereOr("? IS NULL", bun.Ident(column)).
+				WhereOr("? = ''", bun.Ident(column))
+		}
+
+		return q
+	}
+}
+
+// whereNotSilencedAccount is a convenience function to return a bun WhereGroup that specifies
+// that the account referenced by the given account id column should not be silenced.
+//
+// Use it as follows:
+//
+//   q = q.WhereGroup(" AND ", whereNotSilencedAccount("status.account_id"))
+func whereNotSilencedAccount(column string) func(*bun.SelectQuery) *bun.SelectQuery {
+	return func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			Where("? NOT IN (SELECT ? FROM ? AS ? WHERE ? IS NOT NULL)",
+				bun.Ident(column),
+				bun.Ident("silenced_account.id"),
+				bun.Ident("accounts"), bun.Ident("silenced_account"),
+				bun.Ident("silenced_account.silenced_at"))
+	}
+}
+
+// whereNotSilencedDomain is a convenience function to return a bun WhereGroup that specifies
+// that the account referenced by the given account id column should not be on a silenced domain.
+//
+// Use it as follows:
+//
+//   q = q.WhereGroup(" AND ", whereNotSilencedDomain("status.account_id"))
+func whereNotSilencedDomain(column string) func(*bun.SelectQuery) *bun.SelectQuery {
+	return func(q *bun.SelectQuery) *bun.SelectQuery {
+		return q.
+			Where("? NOT IN (SELECT ? FROM ? AS ? JOIN ? AS ? ON ? = ?)",
+				bun.Ident(column),
+				bun.Ident("silenced_account.id"),
+				bun.Ident("accounts"), bun.Ident("silenced_account"),
+				bun.Ident("domain_silences"), bun.Ident("domain_silence"),
+				bun.Ident("domain_silence.domain"), bun.Ident("silenced_account.domain"))
+	}
+}
+
 // updateWhere parses []db.Where and adds it to the given update query.
 func updateWhere(q *bun.UpdateQuery, where []db.Where) {
 	for _, w := range where {
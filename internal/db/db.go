@@ -35,14 +35,27 @@ type DB interface {
 	Admin
 	Basic
 	Domain
+	Emoji
 	Instance
 	Media
 	Mention
 	Notification
+	PreviewCard
 	Relationship
 	Session
+	Setting
 	Status
+	Tag
 	Timeline
+	Token
+	Tombstone
+
+	// RunInTx runs the given function in a single database transaction, passing it a DB whose reads and writes
+	// all happen in that transaction. If the function returns an error, the transaction is rolled back and the
+	// error is returned, otherwise the transaction is committed.
+	//
+	// Calling RunInTx on the DB passed to the function just runs the inner function in the same transaction.
+	RunInTx(ctx context.Context, fn func(tx DB) error) Error
 
 	/*
 		USEFUL CONVERSION FUNCTIONS
@@ -59,7 +72,8 @@ type DB interface {
 
 	// EmojiStringsToEmojis takes a slice of deduplicated, lowercase emojis in the form ":emojiname:", which have been
 	// used in a status. It takes the id of the account that wrote the status, and the id of the status itself, and then
-	// returns a slice of *model.Emoji corresponding to the given emojis.
+	// returns a slice of *model.Emoji corresponding to the given emojis. Only usable emojis of this instance are returned,
+	// and they're all resolved in one go, so this is cheap even for statuses with lots of emojis.
 	//
 	// Note: this func doesn't/shouldn't do any manipulation of the emoji in the DB, it's just for checking
 	// if they exist in the db and conveniently returning them if they do.
@@ -23,7 +23,7 @@ import (
 	"net/url"
 )
 
-// Domain contains DB functions related to domains and domain blocks.
+// Domain contains DB functions related to domains, domain blocks, domain pauses and domain silences.
 type Domain interface {
 	// IsDomainBlocked checks if an instance-level domain block exists for the given domain string (eg., `example.org`).
 	IsDomainBlocked(ctx context.Context, domain string) (bool, Error)
@@ -36,4 +36,13 @@ type Domain interface {
 
 	// AreURIsBlocked checks if an instance-level domain block exists for any `host` in the given URI slice, and returns true if even one is found.
 	AreURIsBlocked(ctx context.Context, uris []*url.URL) (bool, Error)
+
+	// IsDomainPaused checks if federation with the given domain string (eg., `example.org`) is currently paused.
+	IsDomainPaused(ctx context.Context, domain string) (bool, Error)
+
+	// AreURIsPaused checks if federation is paused with any `host` in the given URI slice, and returns true if even one is found.
+	AreURIsPaused(ctx context.Context, uris []*url.URL) (bool, Error)
+
+	// IsDomainSilenced checks if the given domain string (eg., `example.org`) is silenced, ie., kept out of public timelines.
+	IsDomainSilenced(ctx context.Context, domain string) (bool, Error)
 }
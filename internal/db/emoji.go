@@ -0,0 +1,51 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Emoji contains functions for getting custom emojis.
+type Emoji interface {
+	// GetEmojiByID returns the emoji with the given id.
+	GetEmojiByID(ctx context.Context, id string) (*gtsmodel.Emoji, Error)
+
+	// GetEmojiByShortcodeDomain returns the emoji with the given shortcode from the given domain.
+	// Use an empty domain for emojis of this instance.
+	GetEmojiByShortcodeDomain(ctx context.Context, shortcode string, domain string) (*gtsmodel.Emoji, Error)
+
+	// GetEmojisByShortcodeDomain returns the emojis with the given shortcodes from the given domain, in the order of the
+	// given shortcodes. Shortcodes for which there's no emoji are skipped. Use an empty domain for emojis of this instance.
+	GetEmojisByShortcodeDomain(ctx context.Context, shortcodes []string, domain string) ([]*gtsmodel.Emoji, Error)
+
+	// GetLocalEnabledEmojis returns all emojis of this instance which haven't been disabled, ordered by shortcode.
+	GetLocalEnabledEmojis(ctx context.Context) ([]*gtsmodel.Emoji, Error)
+
+	// PutEmoji stores the given new emoji in the database.
+	PutEmoji(ctx context.Context, emoji *gtsmodel.Emoji) Error
+
+	// UpdateEmoji updates the given emoji in the database, setting its updated time to now.
+	UpdateEmoji(ctx context.Context, emoji *gtsmodel.Emoji) (*gtsmodel.Emoji, Error)
+
+	// DeleteEmojiByID deletes the emoji with the given id from the database.
+	DeleteEmojiByID(ctx context.Context, id string) Error
+}
@@ -20,6 +20,7 @@ package db
 
 import (
 	"context"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -32,6 +33,10 @@ type Instance interface {
 	// CountInstanceStatuses returns the number of known statuses posted from the given domain.
 	CountInstanceStatuses(ctx context.Context, domain string) (int, Error)
 
+	// CountActiveUsers returns the number of local users who have been active since the given time, ie., who
+	// have posted a status or logged in since then, where using one of their oauth tokens counts as logging in.
+	CountActiveUsers(ctx context.Context, since time.Time) (int, Error)
+
 	// CountInstanceDomains returns the number of known instances known that the given domain federates with.
 	CountInstanceDomains(ctx context.Context, domain string) (int, Error)
 
@@ -35,4 +35,8 @@ type Media interface {
 	// The selected media attachments will be those with both a URL and a RemoteURL filled in.
 	// In other words, media attachments that originated remotely, and that we currently have cached locally.
 	GetRemoteOlderThan(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.MediaAttachment, Error)
+	// GetLocalUnattachedOlderThan gets limit n local media attachments older than the given olderThan time,
+	// which aren't attached to any status or scheduled status. Avatars and headers are not included.
+	// These will be returned in order of attachment.created_at descending (newest to oldest in other words).
+	GetLocalUnattachedOlderThan(ctx context.Context, olderThan time.Time, limit int) ([]*gtsmodel.MediaAttachment, Error)
 }
@@ -32,3 +32,12 @@ type Where struct {
 	// `WHERE k IS NULL` becomes `WHERE k IS NOT NULL`
 	Not bool
 }
+
+// LanguageFilter allows the caller of the DB to select only statuses written in certain languages.
+type LanguageFilter struct {
+	// Languages to match against the language of each status, eg., "en".
+	// If empty, statuses in any (known) language will be matched.
+	Languages []string
+	// Whether statuses with no (ie., unknown) language should be matched too.
+	IncludeUnknown bool
+}
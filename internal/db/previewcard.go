@@ -0,0 +1,35 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// PreviewCard contains functions for getting link preview cards.
+type PreviewCard interface {
+	// GetPreviewCardByID returns the preview card with the given id.
+	GetPreviewCardByID(ctx context.Context, id string) (*gtsmodel.PreviewCard, Error)
+
+	// GetPreviewCardByURL returns the preview card for the given url. Since cards are
+	// stored once per url, this can be used to avoid fetching the same page twice.
+	GetPreviewCardByURL(ctx context.Context, url string) (*gtsmodel.PreviewCard, Error)
+}
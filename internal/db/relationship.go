@@ -77,4 +77,16 @@ type Relationship interface {
 
 	// CountAccountFollowedBy returns the amounts that the given ID is followed by.
 	CountAccountFollowedBy(ctx context.Context, accountID string, localOnly bool) (int, Error)
+
+	// GetAccountFamiliarFollowers fetches follows that target the given targetAccountID, and which are
+	// owned by accounts that requestingAccountID also follows. In other words, it returns the followers
+	// of the target account that the requesting account knows. The Account of each follow is populated.
+	GetAccountFamiliarFollowers(ctx context.Context, requestingAccountID string, targetAccountID string) ([]*gtsmodel.Follow, Error)
+
+	// GetAccountFollowSuggestions returns the IDs of up to limit accounts which are followed by accounts that the given
+	// accountID follows, ranked by how many of those accounts follow them, most followed first.
+	//
+	// Accounts which the given accountID already follows or has requested to follow, accounts which are blocked in
+	// either direction, suspended accounts, and the given account itself are not suggested.
+	GetAccountFollowSuggestions(ctx context.Context, accountID string, limit int) ([]string, Error)
 }
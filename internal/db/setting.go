@@ -0,0 +1,39 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import "context"
+
+// Setting contains functions for getting and setting instance-wide settings which can be changed at runtime.
+type Setting interface {
+	// GetRegistrationsOpen returns whether new accounts can currently sign up on this instance.
+	// If an admin has opened or closed registrations at runtime, that takes precedence over the config.
+	GetRegistrationsOpen(ctx context.Context) (bool, Error)
+
+	// SetRegistrationsOpen opens or closes registrations, overriding the config until it is set again.
+	SetRegistrationsOpen(ctx context.Context, open bool) Error
+
+	// GetReadOnly returns whether this instance is currently in read-only mode, and the message to show
+	// to users while it is. If an admin has set read-only mode at runtime, that takes precedence over the config.
+	GetReadOnly(ctx context.Context) (bool, string, Error)
+
+	// SetReadOnly switches read-only mode on or off, overriding the config until it is set again.
+	// If message is empty, the message from the config will be used.
+	SetReadOnly(ctx context.Context, readOnly bool, message string) Error
+}
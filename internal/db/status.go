@@ -35,9 +35,19 @@ type Status interface {
 	// GetStatusByURL returns one status from the database, with no rel fields populated, only their linking ID / URIs
 	GetStatusByURL(ctx context.Context, uri string) (*gtsmodel.Status, Error)
 
+	// GetStatusesByIDs returns the statuses with the given IDs in the same order as the IDs, like GetStatusByID would,
+	// but fetching all statuses that aren't cached in one query. Statuses which don't exist are left out of the result.
+	GetStatusesByIDs(ctx context.Context, ids []string) ([]*gtsmodel.Status, Error)
+
 	// PutStatus stores one status in the database.
 	PutStatus(ctx context.Context, status *gtsmodel.Status) Error
 
+	// UpdateStatus updates one status in the database, and in the cache.
+	UpdateStatus(ctx context.Context, status *gtsmodel.Status) Error
+
+	// DeleteStatusByID deletes one status from the database, and from the cache.
+	DeleteStatusByID(ctx context.Context, id string) Error
+
 	// CountStatusReplies returns the amount of replies recorded for a status, or an error if something goes wrong
 	CountStatusReplies(ctx context.Context, status *gtsmodel.Status) (int, Error)
 
@@ -73,6 +83,10 @@ type Status interface {
 	// This slice will be unfiltered, not taking account of blocks and whatnot, so filter it before serving it back to a user.
 	GetStatusFaves(ctx context.Context, status *gtsmodel.Status) ([]*gtsmodel.StatusFave, Error)
 
+	// GetStatusReactions returns a slice of emoji reactions to the given status, oldest first.
+	// This slice will be unfiltered, not taking account of blocks and whatnot, so filter it before serving it back to a user.
+	GetStatusReactions(ctx context.Context, status *gtsmodel.Status) ([]*gtsmodel.StatusReaction, Error)
+
 	// GetStatusReblogs returns a slice of statuses that are a boost/reblog of the given status.
 	// This slice will be unfiltered, not taking account of blocks and whatnot, so filter it before serving it back to a user.
 	GetStatusReblogs(ctx context.Context, status *gtsmodel.Status) ([]*gtsmodel.Status, Error)
@@ -0,0 +1,46 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Tag contains functions for getting tags and information about their usage.
+type Tag interface {
+	// GetTrendingTags returns up to limit tags which have been used by the most distinct accounts
+	// in public statuses within the last withinHours hours, along with their daily usage history.
+	//
+	// Statuses by suspended or silenced accounts, and tags which aren't listable, are not counted.
+	GetTrendingTags(ctx context.Context, withinHours int, limit int) ([]*gtsmodel.TrendingTag, Error)
+
+	// GetTagByName returns the tag with the given name, ignoring case, or ErrNoEntries if there's no such tag.
+	GetTagByName(ctx context.Context, name string) (*gtsmodel.Tag, Error)
+
+	// GetFollowedTags returns the tags followed by the given account, with their tags populated, oldest follow first.
+	GetFollowedTags(ctx context.Context, accountID string) ([]*gtsmodel.FollowedTag, Error)
+
+	// IsFollowingTag returns whether the given account follows the given tag.
+	IsFollowingTag(ctx context.Context, accountID string, tagID string) (bool, Error)
+
+	// GetTagFollowerIDs returns the ids of accounts which follow at least one of the given tags.
+	GetTagFollowerIDs(ctx context.Context, tagIDs []string) ([]string, Error)
+}
@@ -0,0 +1,46 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+	"time"
+)
+
+// Token handles maintenance of oauth tokens, and of the clients and applications they're issued to.
+type Token interface {
+	// DeleteExpiredTokens deletes all oauth tokens which have expired as of the given time, and returns the number of tokens deleted.
+	//
+	// A token counts as expired if its code or access token expired before now, and it doesn't have
+	// a refresh token that's still usable. Refresh tokens without an expiry time are never deleted.
+	DeleteExpiredTokens(ctx context.Context, now time.Time) (int, Error)
+
+	// DeleteInactiveTokens deletes all oauth tokens which haven't been used since the given time, and returns the number of tokens deleted.
+	// Tokens which have never been used count as last used when they were created. Tokens which already
+	// existed when this started being tracked count as last used when it started being tracked.
+	DeleteInactiveTokens(ctx context.Context, since time.Time) (int, Error)
+
+	// RotateToken marks the refresh token of the token with the given ID as rotated at the given time, and removes its access token,
+	// so that neither can be used any more. It returns false if the token had already been rotated, or doesn't exist.
+	RotateToken(ctx context.Context, id string, rotatedAt time.Time) (bool, Error)
+
+	// DeleteUserTokens deletes every oauth token issued to the user with the given ID, in one transaction.
+	// Clients and applications the tokens were issued to are deleted too, unless they still have tokens issued to other users.
+	DeleteUserTokens(ctx context.Context, userID string) Error
+}
@@ -0,0 +1,39 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package db
+
+import (
+	"context"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// Tombstone contains functions for recording and checking the URIs of deleted statuses and accounts.
+type Tombstone interface {
+	// TombstoneExistsWithURI returns true if a tombstone exists for the given URI.
+	TombstoneExistsWithURI(ctx context.Context, uri string) (bool, Error)
+
+	// PutTombstone stores the given tombstone in the database. If a tombstone already exists for
+	// the URI of the given tombstone, no error is returned, so that repeated deletes are harmless.
+	PutTombstone(ctx context.Context, tombstone *gtsmodel.Tombstone) Error
+
+	// DeleteExpiredTombstones deletes all tombstones which were created before the given time, and returns the number of tombstones deleted.
+	DeleteExpiredTombstones(ctx context.Context, before time.Time) (int, Error)
+}
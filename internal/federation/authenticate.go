@@ -27,6 +27,7 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
@@ -116,8 +117,23 @@ func getPublicKeyFromResponse(c context.Context, b []byte, keyID *url.URL) (voca
 //
 // Also note that this function *does not* dereference the remote account that the signature key is associated with.
 // Other functions should use the returned URL to dereference the remote account, if required.
+// getValidOldKey returns the rotated-out public key of a local account with the given
+// key id, as long as it's still within its grace period and so should be accepted.
+func (f *federator) getValidOldKey(ctx context.Context, keyID *url.URL) (*gtsmodel.AccountKey, error) {
+	oldKey := &gtsmodel.AccountKey{}
+	if err := f.db.GetWhere(ctx, []db.Where{{Key: "public_key_uri", Value: keyID.String()}}, oldKey); err != nil {
+		return nil, err
+	}
+
+	if time.Now().After(oldKey.ExpiresAt) {
+		return nil, fmt.Errorf("key %s expired at %s", keyID, oldKey.ExpiresAt)
+	}
+
+	return oldKey, nil
+}
+
 func (f *federator) AuthenticateFederatedRequest(ctx context.Context, requestedUsername string) (*url.URL, gtserror.WithCode) {
-	l := logrus.WithField("func", "AuthenticateFederatedRequest")
+	l := logrus.WithContext(ctx).WithField("func", "AuthenticateFederatedRequest")
 
 	var publicKey interface{}
 	var pkOwnerURI *url.URL
@@ -173,12 +189,27 @@ func (f *federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 		// LOCAL ACCOUNT REQUEST
 		// the request is coming from INSIDE THE HOUSE so skip the remote dereferencing
 		l.Tracef("proceeding without dereference for local public key %s", requestingPublicKeyID)
-		if err := f.db.GetWhere(ctx, []db.Where{{Key: "public_key_uri", Value: requestingPublicKeyID.String()}}, requestingLocalAccount); err != nil {
+		if err := f.db.GetWhere(ctx, []db.Where{{Key: "public_key_uri", Value: requestingPublicKeyID.String()}}, requestingLocalAccount); err == nil {
+			publicKey = requestingLocalAccount.PublicKey
+		} else if err == db.ErrNoEntries {
+			// the key might belong to a local account whose keypair has been rotated since the request was signed
+			oldKey, err := f.getValidOldKey(ctx, requestingPublicKeyID)
+			if err != nil {
+				errWithCode := gtserror.NewErrorNotAuthorized(fmt.Errorf("couldn't get local public key %s: %s", requestingPublicKeyID.String(), err))
+				l.Debug(errWithCode)
+				return nil, errWithCode
+			}
+			if err := f.db.GetByID(ctx, oldKey.AccountID, requestingLocalAccount); err != nil {
+				errWithCode := gtserror.NewErrorInternalError(fmt.Errorf("couldn't get account %s from the database: %s", oldKey.AccountID, err))
+				l.Debug(errWithCode)
+				return nil, errWithCode
+			}
+			publicKey = oldKey.PublicKey
+		} else {
 			errWithCode := gtserror.NewErrorInternalError(fmt.Errorf("couldn't get account with public key uri %s from the database: %s", requestingPublicKeyID.String(), err))
 			l.Debug(errWithCode)
 			return nil, errWithCode
 		}
-		publicKey = requestingLocalAccount.PublicKey
 		pkOwnerURI, err = url.Parse(requestingLocalAccount.URI)
 		if err != nil {
 			errWithCode := gtserror.NewErrorBadRequest(err, fmt.Sprintf("couldn't parse public key owner URL %s", requestingLocalAccount.URI))
@@ -201,9 +232,11 @@ func (f *federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 		// the request is remote and we don't have the public key yet,
 		// so we need to authenticate the request properly by dereferencing the remote key
 		l.Tracef("proceeding with dereference for uncached public key %s", requestingPublicKeyID)
-		transport, err := f.transportController.NewTransportForUsername(ctx, requestedUsername)
+		// The key is fetched on behalf of the instance actor: remote servers which require
+		// authorized fetch will usually refuse a blind key fetch signed by a user.
+		transport, err := f.transportController.NewTransportForInstance(ctx)
 		if err != nil {
-			errWithCode := gtserror.NewErrorInternalError(fmt.Errorf("error creating transport for %s: %s", requestedUsername, err))
+			errWithCode := gtserror.NewErrorInternalError(fmt.Errorf("error creating instance transport: %s", err))
 			l.Debug(errWithCode)
 			return nil, errWithCode
 		}
@@ -212,7 +245,7 @@ func (f *federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 		b, err := transport.Dereference(ctx, requestingPublicKeyID)
 		if err != nil {
 			errWithCode := gtserror.NewErrorNotAuthorized(fmt.Errorf("error dereferencing public key %s: %s", requestingPublicKeyID, err))
-			l.Debug(errWithCode)
+			f.logSampler.Logf(l, logrus.WarnLevel, requestingHost, "public key dereference failures", "%s", errWithCode)
 			return nil, errWithCode
 		}
 
@@ -283,6 +316,6 @@ func (f *federator) AuthenticateFederatedRequest(ctx context.Context, requestedU
 	}
 
 	errWithCode := gtserror.NewErrorNotAuthorized(fmt.Errorf("authentication not passed for public key owner %s; signature value was '%s'", pkOwnerURI, signature))
-	l.Debug(errWithCode)
+	f.logSampler.Logf(l, logrus.WarnLevel, requestingHost, "signature failures", "%s", errWithCode)
 	return nil, errWithCode
 }
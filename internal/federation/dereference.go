@@ -24,6 +24,7 @@ import (
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
 )
 
 func (f *federator) GetRemoteAccount(ctx context.Context, username string, remoteAccountID *url.URL, blocking bool, refresh bool) (*gtsmodel.Account, error) {
@@ -42,10 +43,14 @@ func (f *federator) DereferenceRemoteThread(ctx context.Context, username string
 	return f.dereferencer.DereferenceThread(ctx, username, statusIRI)
 }
 
-func (f *federator) GetRemoteInstance(ctx context.Context, username string, remoteInstanceURI *url.URL) (*gtsmodel.Instance, error) {
-	return f.dereferencer.GetRemoteInstance(ctx, username, remoteInstanceURI)
+func (f *federator) GetRemoteInstance(ctx context.Context, remoteInstanceURI *url.URL) (*gtsmodel.Instance, error) {
+	return f.dereferencer.GetRemoteInstance(ctx, remoteInstanceURI)
 }
 
 func (f *federator) DereferenceAnnounce(ctx context.Context, announce *gtsmodel.Status, requestingUsername string) error {
 	return f.dereferencer.DereferenceAnnounce(ctx, announce, requestingUsername)
 }
+
+func (f *federator) GetRemoteEmoji(ctx context.Context, requestingUsername string, remoteURL string, shortcode string, id string, emojiURI string, ai *media.AdditionalEmojiInfo) (*media.ProcessingEmoji, error) {
+	return f.dereferencer.GetRemoteEmoji(ctx, requestingUsername, remoteURL, shortcode, id, emojiURI, ai)
+}
@@ -30,9 +30,11 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/activity/streams/vocab"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
@@ -75,7 +77,13 @@ func (d *deref) GetRemoteAccount(ctx context.Context, username string, remoteAcc
 				if err != nil {
 					return nil, fmt.Errorf("GetRemoteAccount: error updating remoteAccount: %s", err)
 				}
-				return updatedAccount, err
+				remoteAccount = updatedAccount
+			}
+
+			// if the account is getting on a bit, fetch it again in the background;
+			// the caller gets the copy we already have so that they don't have to wait
+			if accountStale(remoteAccount) {
+				d.refreshAccountAsync(remoteAccount, remoteAccountID)
 			}
 
 			return remoteAccount, nil
@@ -83,6 +91,15 @@ func (d *deref) GetRemoteAccount(ctx context.Context, username string, remoteAcc
 	}
 
 	if new {
+		// don't recreate accounts which have already been deleted
+		tombstoned, err := d.db.TombstoneExistsWithURI(ctx, remoteAccountID.String())
+		if err != nil {
+			return nil, fmt.Errorf("GetRemoteAccount: error checking for tombstone: %s", err)
+		}
+		if tombstoned {
+			return nil, fmt.Errorf("GetRemoteAccount: account %s has been deleted", remoteAccountID)
+		}
+
 		// we haven't seen this account before: dereference it from remote
 		accountable, err := d.dereferenceAccountable(ctx, username, remoteAccountID)
 		if err != nil {
@@ -99,6 +116,7 @@ func (d *deref) GetRemoteAccount(ctx context.Context, username string, remoteAcc
 			return nil, fmt.Errorf("GetRemoteAccount: error generating new id for account: %s", err)
 		}
 		newAccount.ID = ulid
+		newAccount.FetchedAt = time.Now()
 
 		if _, err := d.populateAccountFields(ctx, newAccount, username, refresh, blocking); err != nil {
 			return nil, fmt.Errorf("GetRemoteAccount: error populating further account fields: %s", err)
@@ -122,21 +140,80 @@ func (d *deref) GetRemoteAccount(ctx context.Context, username string, remoteAcc
 		return nil, fmt.Errorf("GetRemoteAccount: error converting refreshedAccountable to refreshedAccount: %s", err)
 	}
 	refreshedAccount.ID = remoteAccount.ID
+	refreshedAccount.FetchedAt = time.Now()
+	keepLocalAccountFields(remoteAccount, refreshedAccount)
 
-	changed, err := d.populateAccountFields(ctx, refreshedAccount, username, refresh, blocking)
-	if err != nil {
+	// only fetch the avatar and header again if they've changed since we last fetched them
+	if _, err := d.populateAccountFields(ctx, refreshedAccount, username, false, blocking); err != nil {
 		return nil, fmt.Errorf("GetRemoteAccount: error populating further refreshedAccount fields: %s", err)
 	}
 
-	if changed {
-		updatedAccount, err := d.db.UpdateAccount(ctx, refreshedAccount)
-		if err != nil {
-			return nil, fmt.Errorf("GetRemoteAccount: error updating refreshedAccount: %s", err)
-		}
-		return updatedAccount, nil
+	updatedAccount, err := d.db.UpdateAccount(ctx, refreshedAccount)
+	if err != nil {
+		return nil, fmt.Errorf("GetRemoteAccount: error updating refreshedAccount: %s", err)
+	}
+
+	return updatedAccount, nil
+}
+
+// keepLocalAccountFields copies fields that only we know about, and which the remote representation
+// of an account therefore can't tell us, from the account we already had onto its refreshed version.
+func keepLocalAccountFields(existing *gtsmodel.Account, refreshed *gtsmodel.Account) {
+	refreshed.CreatedAt = existing.CreatedAt
+	refreshed.Language = existing.Language
+	refreshed.Privacy = existing.Privacy
+	refreshed.Sensitive = existing.Sensitive
+	refreshed.AlsoKnownAs = existing.AlsoKnownAs
+	refreshed.MovedToAccountID = existing.MovedToAccountID
+	refreshed.SensitizedAt = existing.SensitizedAt
+	refreshed.SilencedAt = existing.SilencedAt
+	refreshed.SuspendedAt = existing.SuspendedAt
+	refreshed.SuspensionOrigin = existing.SuspensionOrigin
+	if refreshed.LastWebfingeredAt.IsZero() {
+		refreshed.LastWebfingeredAt = existing.LastWebfingeredAt
+	}
+
+	if refreshed.AvatarRemoteURL == existing.AvatarRemoteURL {
+		refreshed.AvatarMediaAttachmentID = existing.AvatarMediaAttachmentID
+	}
+	if refreshed.HeaderRemoteURL == existing.HeaderRemoteURL {
+		refreshed.HeaderMediaAttachmentID = existing.HeaderMediaAttachmentID
+	}
+}
+
+// accountStale returns true if the given remote account was last fetched
+// longer ago than the configured refresh interval allows.
+func accountStale(account *gtsmodel.Account) bool {
+	interval := viper.GetDuration(config.Keys.AccountsRemoteRefreshInterval)
+	if interval <= 0 || account.Domain == "" || instanceAccount(account) {
+		return false
 	}
+	return time.Since(account.FetchedAt) > interval
+}
 
-	return refreshedAccount, nil
+// refreshAccountAsync dereferences the given account again in the background, using the instance
+// account to do so. Only one refresh of any given account will be running at a time.
+func (d *deref) refreshAccountAsync(account *gtsmodel.Account, remoteAccountID *url.URL) {
+	d.refreshingAccountsLock.Lock()
+	if _, ok := d.refreshingAccounts[account.ID]; ok {
+		// someone else is already on it
+		d.refreshingAccountsLock.Unlock()
+		return
+	}
+	d.refreshingAccounts[account.ID] = struct{}{}
+	d.refreshingAccountsLock.Unlock()
+
+	go func() {
+		defer func() {
+			d.refreshingAccountsLock.Lock()
+			delete(d.refreshingAccounts, account.ID)
+			d.refreshingAccountsLock.Unlock()
+		}()
+
+		if _, err := d.GetRemoteAccount(context.Background(), "", remoteAccountID, false, true); err != nil {
+			logrus.Debugf("refreshAccountAsync: error refreshing account %s: %s", account.URI, err)
+		}
+	}()
 }
 
 // dereferenceAccountable calls remoteAccountID with a GET request, and tries to parse whatever
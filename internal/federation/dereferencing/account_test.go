@@ -21,9 +21,12 @@ package dereferencing_test
 import (
 	"context"
 	"testing"
+	"time"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
@@ -51,6 +54,40 @@ func (suite *AccountTestSuite) TestDereferenceGroup() {
 	suite.Equal(ap.ActorGroup, dbGroup.ActorType)
 }
 
+func (suite *AccountTestSuite) TestRefreshStaleAccount() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+	personURL := testrig.URLMustParse("https://unknown-instance.com/users/brand_new_person")
+
+	person, err := suite.dereferencer.GetRemoteAccount(context.Background(), fetchingAccount.Username, personURL, false, false)
+	suite.NoError(err)
+	suite.False(person.FetchedAt.IsZero())
+	displayName := person.DisplayName
+
+	// pretend we last fetched the account a couple of days ago, and that it had a different display name then
+	person.FetchedAt = time.Now().Add(-48 * time.Hour)
+	person.DisplayName = "some old name"
+	person.SilencedAt = time.Now()
+	_, err = suite.db.UpdateAccount(context.Background(), person)
+	suite.NoError(err)
+
+	viper.Set(config.Keys.AccountsRemoteRefreshInterval, 24*time.Hour)
+
+	// we should get the stale account back straight away...
+	stale, err := suite.dereferencer.GetRemoteAccount(context.Background(), fetchingAccount.Username, personURL, false, false)
+	suite.NoError(err)
+	suite.Equal("some old name", stale.DisplayName)
+
+	// ...and it should be refreshed in the background, keeping the fields only we know about
+	suite.Eventually(func() bool {
+		refreshed, err := suite.db.GetAccountByID(context.Background(), person.ID)
+		return err == nil && refreshed.DisplayName == displayName && time.Since(refreshed.FetchedAt) < time.Minute
+	}, 5*time.Second, 50*time.Millisecond)
+
+	refreshed, err := suite.db.GetAccountByID(context.Background(), person.ID)
+	suite.NoError(err)
+	suite.False(refreshed.SilencedAt.IsZero())
+}
+
 func TestAccountTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountTestSuite))
 }
@@ -23,9 +23,12 @@ import (
 	"net/url"
 	"sync"
 
+	"github.com/spf13/viper"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
@@ -38,9 +41,10 @@ type Dereferencer interface {
 	GetRemoteStatus(ctx context.Context, username string, remoteStatusID *url.URL, refresh, includeParent bool) (*gtsmodel.Status, ap.Statusable, bool, error)
 	EnrichRemoteStatus(ctx context.Context, username string, status *gtsmodel.Status, includeParent bool) (*gtsmodel.Status, error)
 
-	GetRemoteInstance(ctx context.Context, username string, remoteInstanceURI *url.URL) (*gtsmodel.Instance, error)
+	GetRemoteInstance(ctx context.Context, remoteInstanceURI *url.URL) (*gtsmodel.Instance, error)
 
 	GetRemoteMedia(ctx context.Context, requestingUsername string, accountID string, remoteURL string, ai *media.AdditionalMediaInfo) (*media.ProcessingMedia, error)
+	GetRemoteEmoji(ctx context.Context, requestingUsername string, remoteURL string, shortcode string, id string, emojiURI string, ai *media.AdditionalEmojiInfo) (*media.ProcessingEmoji, error)
 
 	DereferenceAnnounce(ctx context.Context, announce *gtsmodel.Status, requestingUsername string) error
 	DereferenceThread(ctx context.Context, username string, statusIRI *url.URL) error
@@ -59,6 +63,9 @@ type deref struct {
 	dereferencingHeadersLock *sync.Mutex
 	handshakes               map[string][]*url.URL
 	handshakeSync            *sync.Mutex // mutex to lock/unlock when checking or updating the handshakes map
+	refreshingAccounts       map[string]struct{}
+	refreshingAccountsLock   *sync.Mutex
+	logSampler               *log.Sampler // collapses repeated errors caused by remote instances
 }
 
 // NewDereferencer returns a Dereferencer initialized with the given parameters.
@@ -73,5 +80,8 @@ func NewDereferencer(db db.DB, typeConverter typeutils.TypeConverter, transportC
 		dereferencingHeaders:     make(map[string]*media.ProcessingMedia),
 		dereferencingHeadersLock: &sync.Mutex{},
 		handshakeSync:            &sync.Mutex{},
+		refreshingAccounts:       make(map[string]struct{}),
+		refreshingAccountsLock:   &sync.Mutex{},
+		logSampler:               log.NewSampler(viper.GetDuration(config.Keys.LogSamplePeriod)),
 	}
 }
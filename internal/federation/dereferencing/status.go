@@ -78,6 +78,17 @@ func (d *deref) GetRemoteStatus(ctx context.Context, username string, remoteStat
 		}
 	}
 
+	if new {
+		// don't recreate statuses which have already been deleted by their author
+		tombstoned, err := d.db.TombstoneExistsWithURI(ctx, remoteStatusID.String())
+		if err != nil {
+			return nil, nil, new, fmt.Errorf("GetRemoteStatus: error checking for tombstone: %s", err)
+		}
+		if tombstoned {
+			return nil, nil, new, fmt.Errorf("GetRemoteStatus: status %s has been deleted", remoteStatusID)
+		}
+	}
+
 	statusable, err := d.dereferenceStatusable(ctx, username, remoteStatusID)
 	if err != nil {
 		return nil, statusable, new, fmt.Errorf("GetRemoteStatus: error dereferencing statusable: %s", err)
@@ -99,6 +110,11 @@ func (d *deref) GetRemoteStatus(ctx context.Context, username string, remoteStat
 		return nil, statusable, new, fmt.Errorf("GetRemoteStatus: error converting statusable to status: %s", err)
 	}
 
+	// don't store quotes of statuses whose local author doesn't want to be quoted
+	if q := gtsStatus.QuoteOf; q != nil && q.Local && q.Account != nil && q.Account.Unquotable {
+		return nil, statusable, new, fmt.Errorf("GetRemoteStatus: status %s quotes status %s, whose author doesn't allow quotes", gtsStatus.URI, q.URI)
+	}
+
 	if new {
 		ulid, err := id.NewULIDFromTime(gtsStatus.CreatedAt)
 		if err != nil {
@@ -237,7 +253,7 @@ func (d *deref) dereferenceStatusable(ctx context.Context, username string, remo
 // and attach them to the status. The status itself will not be added to the database yet,
 // that's up the caller to do.
 func (d *deref) populateStatusFields(ctx context.Context, status *gtsmodel.Status, requestingUsername string, includeParent bool) error {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":   "dereferenceStatusFields",
 		"status": fmt.Sprintf("%+v", status),
 	})
@@ -387,6 +403,8 @@ func (d *deref) populateStatusAttachments(ctx context.Context, status *gtsmodel.
 	// * the remote URL (a.RemoteURL)
 	// This should be enough to dereference the piece of media.
 
+	l := logrus.WithContext(ctx).WithField("func", "populateStatusAttachments")
+
 	attachmentIDs := []string{}
 	attachments := []*gtsmodel.MediaAttachment{}
 
@@ -402,13 +420,13 @@ func (d *deref) populateStatusAttachments(ctx context.Context, status *gtsmodel.
 			Blurhash:    &a.Blurhash,
 		})
 		if err != nil {
-			logrus.Errorf("populateStatusAttachments: couldn't get remote media %s: %s", a.RemoteURL, err)
+			d.logSampler.Logf(l, logrus.ErrorLevel, remoteMediaHost(a.RemoteURL), "remote media failures", "populateStatusAttachments: couldn't get remote media %s: %s", a.RemoteURL, err)
 			continue
 		}
 
 		attachment, err := processingMedia.LoadAttachment(ctx)
 		if err != nil {
-			logrus.Errorf("populateStatusAttachments: couldn't load remote attachment %s: %s", a.RemoteURL, err)
+			d.logSampler.Logf(l, logrus.ErrorLevel, remoteMediaHost(a.RemoteURL), "remote media failures", "populateStatusAttachments: couldn't load remote attachment %s: %s", a.RemoteURL, err)
 			continue
 		}
 
@@ -448,3 +466,12 @@ func (d *deref) populateStatusRepliedTo(ctx context.Context, status *gtsmodel.St
 
 	return nil
 }
+
+// remoteMediaHost returns the host of the given remote media url, for sampling logs by domain.
+func remoteMediaHost(remoteURL string) string {
+	u, err := url.Parse(remoteURL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
@@ -37,7 +37,7 @@ import (
 // presented by remote instances as part of their replies collections, and will likely involve making several calls to
 // multiple different hosts.
 func (d *deref) DereferenceThread(ctx context.Context, username string, statusIRI *url.URL) error {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":      "DereferenceThread",
 		"username":  username,
 		"statusIRI": statusIRI.String(),
@@ -71,61 +71,82 @@ func (d *deref) DereferenceThread(ctx context.Context, username string, statusIR
 }
 
 // iterateAncestors has the goal of reaching the oldest ancestor of a given status, and stashing all statuses along the way.
+//
+// To stop a remote server from making us walk a thread of arbitrary depth, at most federation-max-thread-depth
+// ancestors are dereferenced. Any ancestors above that are left unresolved. Cycles in the thread are ignored.
 func (d *deref) iterateAncestors(ctx context.Context, username string, statusIRI url.URL) error {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":      "iterateAncestors",
 		"username":  username,
 		"statusIRI": statusIRI.String(),
 	})
 	l.Debug("entering iterateAncestors")
 
-	// if it's our status we don't need to dereference anything so we can immediately move up the chain
 	host := viper.GetString(config.Keys.Host)
-	if statusIRI.Host == host {
-		l.Debug("iri belongs to us, moving up to next ancestor")
+	maxDepth := viper.GetInt(config.Keys.FederationMaxThreadDepth)
+	seen := make(map[string]bool)
 
-		// since this is our status, we know we can extract the id from the status path
-		_, id, err := uris.ParseStatusesPath(&statusIRI)
-		if err != nil {
-			return err
+	currentIRI := &statusIRI
+	for depth := 0; ; depth++ {
+		if seen[currentIRI.String()] {
+			l.Debugf("ancestor %s has been seen before, thread has a cycle", currentIRI)
+			return nil
 		}
+		seen[currentIRI.String()] = true
 
-		status, err := d.db.GetStatusByID(ctx, id)
-		if err != nil {
-			return err
+		if maxDepth > 0 && depth > maxDepth {
+			l.Infof("thread reached the maximum depth of %d ancestors, not dereferencing ancestor %s or anything above it", maxDepth, currentIRI)
+			return nil
 		}
 
-		if status.InReplyToURI == "" {
-			// status doesn't reply to anything
-			return nil
+		// if it's our status we don't need to dereference anything so we can immediately move up the chain
+		if currentIRI.Host == host {
+			l.Debugf("iri %s belongs to us, moving up to next ancestor", currentIRI)
+
+			// since this is our status, we know we can extract the id from the status path
+			_, id, err := uris.ParseStatusesPath(currentIRI)
+			if err != nil {
+				return err
+			}
+
+			status, err := d.db.GetStatusByID(ctx, id)
+			if err != nil {
+				return err
+			}
+
+			if status.InReplyToURI == "" {
+				// status doesn't reply to anything
+				return nil
+			}
+
+			currentIRI, err = url.Parse(status.InReplyToURI)
+			if err != nil {
+				return err
+			}
+			continue
 		}
-		nextIRI, err := url.Parse(status.URI)
+
+		// If we reach here, we're looking at a remote status -- make sure we have it in our db by calling GetRemoteStatus
+		// We call it with refresh to true because we want the statusable representation to parse inReplyTo from.
+		_, statusable, _, err := d.GetRemoteStatus(ctx, username, currentIRI, true, false)
 		if err != nil {
-			return err
+			l.Debugf("error getting remote status %s: %s", currentIRI, err)
+			return nil
 		}
-		return d.iterateAncestors(ctx, username, *nextIRI)
-	}
 
-	// If we reach here, we're looking at a remote status -- make sure we have it in our db by calling GetRemoteStatus
-	// We call it with refresh to true because we want the statusable representation to parse inReplyTo from.
-	_, statusable, _, err := d.GetRemoteStatus(ctx, username, &statusIRI, true, false)
-	if err != nil {
-		l.Debugf("error getting remote status: %s", err)
-		return nil
-	}
+		inReplyTo := ap.ExtractInReplyToURI(statusable)
+		if inReplyTo == nil || inReplyTo.String() == "" {
+			// status doesn't reply to anything
+			return nil
+		}
 
-	inReplyTo := ap.ExtractInReplyToURI(statusable)
-	if inReplyTo == nil || inReplyTo.String() == "" {
-		// status doesn't reply to anything
-		return nil
+		// now move up to the next ancestor
+		currentIRI = inReplyTo
 	}
-
-	// now move up to the next ancestor
-	return d.iterateAncestors(ctx, username, *inReplyTo)
 }
 
 func (d *deref) iterateDescendants(ctx context.Context, username string, statusIRI url.URL, statusable ap.Statusable) error {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":      "iterateDescendants",
 		"username":  username,
 		"statusIRI": statusIRI.String(),
@@ -0,0 +1,143 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package dereferencing_test
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/activity/pub"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ThreadTestSuite struct {
+	DereferencerStandardTestSuite
+}
+
+// addThread adds a thread of remote notes to the statuses that the mock transport will return,
+// in which note 0 replies to note 1, which replies to note 2, and so on, returning their uris.
+// If cycle is true, the last note replies to note 0 again.
+func (suite *ThreadTestSuite) addThread(length int, cycle bool) []string {
+	uris := make([]string, length)
+	for i := range uris {
+		uris[i] = fmt.Sprintf("https://unknown-instance.com/users/brand_new_person/statuses/thread-%d", i)
+	}
+
+	for i, uri := range uris {
+		note := testrig.NewAPNote(
+			testrig.URLMustParse(uri),
+			testrig.URLMustParse(uri),
+			time.Now().Add(-time.Duration(i)*time.Minute),
+			fmt.Sprintf("post number %d in the thread", i),
+			"",
+			testrig.URLMustParse("https://unknown-instance.com/users/brand_new_person"),
+			[]*url.URL{testrig.URLMustParse(pub.PublicActivityPubIRI)},
+			[]*url.URL{},
+			false,
+			nil,
+			nil,
+		)
+
+		var inReplyTo string
+		if i < length-1 {
+			inReplyTo = uris[i+1]
+		} else if cycle {
+			inReplyTo = uris[0]
+		}
+		if inReplyTo != "" {
+			inReplyToProp := streams.NewActivityStreamsInReplyToProperty()
+			inReplyToProp.AppendIRI(testrig.URLMustParse(inReplyTo))
+			note.SetActivityStreamsInReplyTo(inReplyToProp)
+		}
+
+		suite.testRemoteStatuses[uri] = note
+	}
+
+	return uris
+}
+
+func (suite *ThreadTestSuite) TestDereferenceThread() {
+	fetchingAccount := suite.testAccounts["local_account_1"]
+	uris := suite.addThread(5, false)
+
+	err := suite.dereferencer.DereferenceThread(context.Background(), fetchingAccount.Username, testrig.URLMustParse(uris[0]))
+	suite.NoError(err)
+
+	// every post in the thread should be in the database
+	for _, uri := range uris {
+		_, err := suite.db.GetStatusByURI(context.Background(), uri)
+		suite.NoError(err)
+	}
+}
+
+func (suite *ThreadTestSuite) TestDereferenceThreadMaxDepth() {
+	viper.Set(config.Keys.FederationMaxThreadDepth, 2)
+
+	fetchingAccount := suite.testAccounts["local_account_1"]
+	uris := suite.addThread(5, false)
+
+	err := suite.dereferencer.DereferenceThread(context.Background(), fetchingAccount.Username, testrig.URLMustParse(uris[0]))
+	suite.NoError(err)
+
+	// the post itself and two ancestors should be in the database
+	for _, uri := range uris[:3] {
+		_, err := suite.db.GetStatusByURI(context.Background(), uri)
+		suite.NoError(err)
+	}
+
+	// but nothing above that
+	for _, uri := range uris[3:] {
+		_, err := suite.db.GetStatusByURI(context.Background(), uri)
+		suite.ErrorIs(err, db.ErrNoEntries)
+	}
+
+	// the topmost stored ancestor should be left unresolved
+	topmost, err := suite.db.GetStatusByURI(context.Background(), uris[2])
+	suite.NoError(err)
+	suite.Equal(uris[3], topmost.InReplyToURI)
+	suite.Empty(topmost.InReplyToID)
+}
+
+func (suite *ThreadTestSuite) TestDereferenceThreadCycle() {
+	viper.Set(config.Keys.FederationMaxThreadDepth, 0)
+
+	fetchingAccount := suite.testAccounts["local_account_1"]
+	uris := suite.addThread(3, true)
+
+	// this would never return if the cycle wasn't caught
+	err := suite.dereferencer.DereferenceThread(context.Background(), fetchingAccount.Username, testrig.URLMustParse(uris[0]))
+	suite.NoError(err)
+
+	for _, uri := range uris {
+		_, err := suite.db.GetStatusByURI(context.Background(), uri)
+		suite.NoError(err)
+	}
+}
+
+func TestThreadTestSuite(t *testing.T) {
+	suite.Run(t, &ThreadTestSuite{})
+}
@@ -136,6 +136,67 @@ func (suite *FederatingActorTestSuite) TestSendRemoteFollower() {
 	suite.Equal(testRemoteAccount.InboxURI, sentMessages[0].String())
 }
 
+func (suite *FederatingActorTestSuite) TestSendRemoteFollowerPausedDomain() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+	testRemoteAccount := suite.testAccounts["remote_account_1"]
+
+	err := suite.db.Put(ctx, &gtsmodel.Follow{
+		ID:              "01G1TRWV4AYCDBX5HRWT2EVBCV",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		AccountID:       testRemoteAccount.ID,
+		TargetAccountID: testAccount.ID,
+		ShowReblogs:     true,
+		URI:             "http://fossbros-anonymous.io/users/foss_satan/follows/01G1TRWV4AYCDBX5HRWT2EVBCV",
+		Notify:          false,
+	})
+	suite.NoError(err)
+
+	// pause federation with the domain of the remote follower
+	err = suite.db.Put(ctx, &gtsmodel.DomainPause{
+		ID:                 "01G5BJ8YWQ1H3XK0E6ZT5N2RDM",
+		Domain:             testRemoteAccount.Domain,
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	})
+	suite.NoError(err)
+
+	testNote := testrig.NewAPNote(
+		testrig.URLMustParse("http://localhost:8080/users/the_mighty_zork/statuses/01G1TR6BADACCZWQMNF9X21TV5"),
+		testrig.URLMustParse("http://localhost:8080/@the_mighty_zork/statuses/01G1TR6BADACCZWQMNF9X21TV5"),
+		time.Now(),
+		"boobies",
+		"",
+		testrig.URLMustParse(testAccount.URI),
+		[]*url.URL{testrig.URLMustParse(testAccount.FollowersURI)},
+		nil,
+		false,
+		nil,
+		nil,
+	)
+	testActivity := testrig.WrapAPNoteInCreate(testrig.URLMustParse("http://localhost:8080/whatever_some_create"), testrig.URLMustParse(testAccount.URI), time.Now(), testNote)
+
+	fedWorker := worker.New[messages.FromFederator](-1, -1)
+
+	sentMessages := []*url.URL{}
+	tc := testrig.NewTestTransportController(testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		sentMessages = append(sentMessages, req.URL)
+		r := ioutil.NopCloser(bytes.NewReader([]byte{}))
+		return &http.Response{
+			StatusCode: 200,
+			Body:       r,
+		}, nil
+	}), suite.db, fedWorker)
+	federator := federation.NewFederator(suite.db, testrig.NewTestFederatingDB(suite.db, fedWorker), tc, suite.tc, testrig.NewTestMediaManager(suite.db, suite.storage))
+
+	activity, err := federator.FederatingActor().Send(ctx, testrig.URLMustParse(testAccount.OutboxURI), testActivity)
+	suite.NoError(err)
+	suite.NotNil(activity)
+
+	// the delivery to the paused domain should have been dropped
+	suite.Empty(sentMessages)
+}
+
 func TestFederatingActorTestSuite(t *testing.T) {
 	suite.Run(t, new(FederatingActorTestSuite))
 }
@@ -33,7 +33,7 @@ import (
 )
 
 func (f *federatingDB) Accept(ctx context.Context, accept vocab.ActivityStreamsAccept) error {
-	l := logrus.WithFields(
+	l := logrus.WithContext(ctx).WithFields(
 		logrus.Fields{
 			"func": "Accept",
 		},
@@ -29,7 +29,7 @@ import (
 )
 
 func (f *federatingDB) Announce(ctx context.Context, announce vocab.ActivityStreamsAnnounce) error {
-	l := logrus.WithFields(
+	l := logrus.WithContext(ctx).WithFields(
 		logrus.Fields{
 			"func": "Announce",
 		},
@@ -46,7 +46,7 @@ import (
 // Under certain conditions and network activities, Create may be called
 // multiple times for the same ActivityStreams object.
 func (f *federatingDB) Create(ctx context.Context, asType vocab.Type) error {
-	l := logrus.WithFields(
+	l := logrus.WithContext(ctx).WithFields(
 		logrus.Fields{
 			"func": "Create",
 		},
@@ -169,12 +169,24 @@ func (f *federatingDB) activityCreate(ctx context.Context, asType vocab.Type, re
 
 // createNote handles a Create activity with a Note type.
 func (f *federatingDB) createNote(ctx context.Context, note vocab.ActivityStreamsNote, receivingAccount *gtsmodel.Account, requestingAccount *gtsmodel.Account) error {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":              "createNote",
 		"receivingAccount":  receivingAccount.URI,
 		"requestingAccount": requestingAccount.URI,
 	})
 
+	// don't recreate statuses which have already been deleted by their author
+	if noteID := note.GetJSONLDId(); noteID != nil && noteID.IsIRI() {
+		tombstoned, err := f.db.TombstoneExistsWithURI(ctx, noteID.GetIRI().String())
+		if err != nil {
+			return fmt.Errorf("createNote: error checking for tombstone: %s", err)
+		}
+		if tombstoned {
+			l.Debugf("dropping note %s because it has been deleted", noteID.GetIRI())
+			return nil
+		}
+	}
+
 	// Check if we have a forward.
 	// In other words, was the note posted to our inbox by at least one actor who actually created the note, or are they just forwarding it?
 	forward := true
@@ -223,6 +235,24 @@ func (f *federatingDB) createNote(ctx context.Context, note vocab.ActivityStream
 		return fmt.Errorf("createNote: error converting note to status: %s", err)
 	}
 
+	// drop quotes of statuses whose local author doesn't want to be quoted
+	if q := status.QuoteOf; q != nil && q.Local && q.Account != nil && q.Account.Unquotable {
+		l.Debugf("dropping note %s because it quotes status %s, whose author doesn't allow quotes", status.URI, q.URI)
+		return nil
+	}
+
+	// drop replies to local statuses whose reply policy doesn't permit them
+	if r := status.InReplyTo; r != nil && r.Local {
+		replyable, err := f.filter.StatusReplyable(ctx, r, status.Account)
+		if err != nil {
+			return fmt.Errorf("createNote: error checking whether status %s is replyable: %s", r.URI, err)
+		}
+		if !replyable {
+			l.Debugf("dropping note %s because it replies to status %s, whose reply policy doesn't permit it", status.URI, r.URI)
+			return nil
+		}
+	}
+
 	// id the status based on the time it was created
 	statusID, err := id.NewULIDFromTime(status.CreatedAt)
 	if err != nil {
@@ -296,6 +326,11 @@ func (f *federatingDB) activityLike(ctx context.Context, asType vocab.Type, rece
 		return errors.New("activityLike: could not convert type to like")
 	}
 
+	if ap.ExtractReaction(like) != "" {
+		// this like is really an emoji reaction
+		return f.activityReaction(ctx, like, receivingAccount)
+	}
+
 	fave, err := f.typeConverter.ASLikeToFave(ctx, like)
 	if err != nil {
 		return fmt.Errorf("activityLike: could not convert Like to fave: %s", err)
@@ -320,3 +355,43 @@ func (f *federatingDB) activityLike(ctx context.Context, asType vocab.Type, rece
 
 	return nil
 }
+
+/*
+	REACTION HANDLERS
+*/
+
+func (f *federatingDB) activityReaction(ctx context.Context, like vocab.ActivityStreamsLike, receivingAccount *gtsmodel.Account) error {
+	reaction, err := f.typeConverter.ASLikeToReaction(ctx, like)
+	if err != nil {
+		return fmt.Errorf("activityReaction: could not convert Like to reaction: %s", err)
+	}
+
+	// reacting to a status requires being able to see it, which also covers blocks
+	visible, err := f.filter.StatusVisible(ctx, reaction.Status, reaction.Account)
+	if err != nil {
+		return fmt.Errorf("activityReaction: error checking visibility of status %s: %s", reaction.StatusID, err)
+	}
+	if !visible {
+		logrus.Debugf("activityReaction: status %s is not visible to reacting account %s, ignoring reaction", reaction.StatusID, reaction.AccountID)
+		return nil
+	}
+
+	newID, err := id.NewULID()
+	if err != nil {
+		return err
+	}
+	reaction.ID = newID
+
+	if err := f.db.Put(ctx, reaction); err != nil {
+		return fmt.Errorf("activityReaction: database error inserting reaction: %s", err)
+	}
+
+	f.fedWorker.Queue(messages.FromFederator{
+		APObjectType:     ap.ActivityEmojiReact,
+		APActivityType:   ap.ActivityCreate,
+		GTSModel:         reaction,
+		ReceivingAccount: receivingAccount,
+	})
+
+	return nil
+}
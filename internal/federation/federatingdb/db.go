@@ -27,6 +27,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
+	"github.com/superseriousbusiness/gotosocial/internal/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/worker"
 )
 
@@ -46,6 +47,7 @@ type federatingDB struct {
 	db            db.DB
 	fedWorker     *worker.Worker[messages.FromFederator]
 	typeConverter typeutils.TypeConverter
+	filter        visibility.Filter
 }
 
 // New returns a DB interface using the given database and config
@@ -55,6 +57,7 @@ func New(db db.DB, fedWorker *worker.Worker[messages.FromFederator]) DB {
 		db:            db,
 		fedWorker:     fedWorker,
 		typeConverter: typeutils.NewConverter(db),
+		filter:        visibility.NewFilter(db),
 	}
 	return &fdb
 }
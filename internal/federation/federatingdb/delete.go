@@ -26,6 +26,7 @@ import (
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 )
 
@@ -35,16 +36,16 @@ import (
 // Protocol instead call Update to create a Tombstone.
 //
 // The library makes this call only after acquiring a lock first.
-func (f *federatingDB) Delete(ctx context.Context, id *url.URL) error {
-	l := logrus.WithFields(
+func (f *federatingDB) Delete(ctx context.Context, uri *url.URL) error {
+	l := logrus.WithContext(ctx).WithFields(
 		logrus.Fields{
 			"func": "Delete",
-			"id":   id,
+			"id":   uri,
 		},
 	)
 	l.Debug("entering Delete")
 
-	receivingAccount, _ := extractFromCtx(ctx)
+	receivingAccount, requestingAccount := extractFromCtx(ctx)
 	if receivingAccount == nil {
 		// If the receiving account wasn't set on the context, that means this request didn't pass
 		// through the API, but came from inside GtS as the result of another activity on this instance. That being so,
@@ -54,13 +55,36 @@ func (f *federatingDB) Delete(ctx context.Context, id *url.URL) error {
 
 	// in a delete we only get the URI, we can't know if we have a status or a profile or something else,
 	// so we have to try a few different things...
-	s, err := f.db.GetStatusByURI(ctx, id.String())
+	s, err := f.db.GetStatusByURI(ctx, uri.String())
 	if err == nil {
 		// it's a status
 		l.Debugf("uri is for status with id: %s", s.ID)
-		if err := f.db.DeleteByID(ctx, s.ID, &gtsmodel.Status{}); err != nil {
+		if s.Local || requestingAccount == nil || s.AccountID != requestingAccount.ID {
+			// only the author of a remote status may delete our copy of it
+			l.Debugf("ignoring delete of status %s, since it wasn't sent by the status author", s.URI)
+			return nil
+		}
+
+		// tombstone the uri first, so that any activities for this status that
+		// are still in flight can't put it back while we're busy deleting it
+		tombstoneID, err := id.NewRandomULID()
+		if err != nil {
+			return fmt.Errorf("DELETE: err generating tombstone id: %s", err)
+		}
+		if err := f.db.PutTombstone(ctx, &gtsmodel.Tombstone{
+			ID:     tombstoneID,
+			Domain: uri.Host,
+			URI:    s.URI,
+		}); err != nil {
+			return fmt.Errorf("DELETE: err putting tombstone: %s", err)
+		}
+
+		if err := f.db.DeleteStatusByID(ctx, s.ID); err != nil {
 			return fmt.Errorf("DELETE: err deleting status: %s", err)
 		}
+
+		// the processor cleans up everything else belonging to the status;
+		// the status isn't ours, so the delete is not federated any further
 		f.fedWorker.Queue(messages.FromFederator{
 			APObjectType:     ap.ObjectNote,
 			APActivityType:   ap.ActivityDelete,
@@ -69,7 +93,7 @@ func (f *federatingDB) Delete(ctx context.Context, id *url.URL) error {
 		})
 	}
 
-	a, err := f.db.GetAccountByURI(ctx, id.String())
+	a, err := f.db.GetAccountByURI(ctx, uri.String())
 	if err == nil {
 		// it's an account
 		l.Debugf("uri is for an account with id %s, passing delete message to the processor", a.ID)
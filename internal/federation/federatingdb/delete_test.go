@@ -0,0 +1,109 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package federatingdb_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type DeleteTestSuite struct {
+	FederatingDBTestSuite
+}
+
+func (suite *DeleteTestSuite) TestDeleteStatus() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+	deletedStatus := suite.testStatuses["remote_account_1_status_1"]
+
+	ctx := createTestContext(receivingAccount, requestingAccount)
+
+	err := suite.federatingDB.Delete(ctx, testrig.URLMustParse(deletedStatus.URI))
+	suite.NoError(err)
+
+	// should be a message heading to the processor now, which we can intercept here
+	msg := <-suite.fromFederator
+	suite.Equal(ap.ObjectNote, msg.APObjectType)
+	suite.Equal(ap.ActivityDelete, msg.APActivityType)
+	suite.Equal(deletedStatus.ID, msg.GTSModel.(*gtsmodel.Status).ID)
+
+	// the status should be gone, not just from the cache
+	_, err = suite.db.GetStatusByURI(context.Background(), deletedStatus.URI)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	_, err = suite.db.GetStatusByID(context.Background(), deletedStatus.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	tombstoned, err := suite.db.TombstoneExistsWithURI(context.Background(), deletedStatus.URI)
+	suite.NoError(err)
+	suite.True(tombstoned)
+}
+
+func (suite *DeleteTestSuite) TestDeleteStatusNotAuthor() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_2"]
+	deletedStatus := suite.testStatuses["remote_account_1_status_1"]
+
+	ctx := createTestContext(receivingAccount, requestingAccount)
+
+	err := suite.federatingDB.Delete(ctx, testrig.URLMustParse(deletedStatus.URI))
+	suite.NoError(err)
+
+	// nothing should have been deleted
+	_, err = suite.db.GetStatusByURI(context.Background(), deletedStatus.URI)
+	suite.NoError(err)
+
+	tombstoned, err := suite.db.TombstoneExistsWithURI(context.Background(), deletedStatus.URI)
+	suite.NoError(err)
+	suite.False(tombstoned)
+}
+
+func (suite *DeleteTestSuite) TestCreateTombstonedNote() {
+	receivingAccount := suite.testAccounts["local_account_1"]
+	requestingAccount := suite.testAccounts["remote_account_1"]
+
+	ctx := createTestContext(receivingAccount, requestingAccount)
+
+	create := suite.testActivities["dm_for_zork"].Activity
+	noteURI := create.GetActivityStreamsObject().At(0).GetActivityStreamsNote().GetJSONLDId().GetIRI()
+
+	// the note was deleted while the create was in flight
+	suite.NoError(suite.db.PutTombstone(context.Background(), &gtsmodel.Tombstone{
+		ID:     "01G56JRZ5TX3WFY7NAP4WCRMVW",
+		Domain: noteURI.Host,
+		URI:    noteURI.String(),
+	}))
+
+	err := suite.federatingDB.Create(ctx, create)
+	suite.NoError(err)
+
+	// so it shouldn't be recreated
+	_, err = suite.db.GetStatusByURI(context.Background(), noteURI.String())
+	suite.ErrorIs(err, db.ErrNoEntries)
+	suite.Empty(suite.fromFederator)
+}
+
+func TestDeleteTestSuite(t *testing.T) {
+	suite.Run(t, &DeleteTestSuite{})
+}
@@ -32,7 +32,7 @@ import (
 //
 // Implementation note: this just straight up isn't implemented, and doesn't *really* need to be either.
 func (f *federatingDB) Exists(c context.Context, id *url.URL) (exists bool, err error) {
-	l := logrus.WithFields(
+	l := logrus.WithContext(c).WithFields(
 		logrus.Fields{
 			"func": "Exists",
 			"id":   id,
@@ -35,7 +35,7 @@ import (
 //
 // The library makes this call only after acquiring a lock first.
 func (f *federatingDB) Following(ctx context.Context, actorIRI *url.URL) (following vocab.ActivityStreamsCollection, err error) {
-	l := logrus.WithFields(
+	l := logrus.WithContext(ctx).WithFields(
 		logrus.Fields{
 			"func": "Following",
 			"id":   actorIRI,
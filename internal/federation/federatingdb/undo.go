@@ -31,7 +31,7 @@ import (
 )
 
 func (f *federatingDB) Undo(ctx context.Context, undo vocab.ActivityStreamsUndo) error {
-	l := logrus.WithFields(
+	l := logrus.WithContext(ctx).WithFields(
 		logrus.Fields{
 			"func": "Undo",
 		},
@@ -46,7 +46,7 @@ func (f *federatingDB) Undo(ctx context.Context, undo vocab.ActivityStreamsUndo)
 		l.Debug("entering Undo")
 	}
 
-	receivingAccount, _ := extractFromCtx(ctx)
+	receivingAccount, requestingAccount := extractFromCtx(ctx)
 	if receivingAccount == nil {
 		// If the receiving account wasn't set on the context, that means this request didn't pass
 		// through the API, but came from inside GtS as the result of another activity on this instance. That being so,
@@ -60,6 +60,14 @@ func (f *federatingDB) Undo(ctx context.Context, undo vocab.ActivityStreamsUndo)
 	}
 
 	for iter := undoObject.Begin(); iter != undoObject.End(); iter = iter.Next() {
+		if iter.IsIRI() && requestingAccount != nil {
+			// some implementations only give the id of the activity they're undoing; we can only
+			// handle that for activities whose uri we've stored and can look up, like reactions
+			if err := f.db.DeleteWhere(ctx, []db.Where{{Key: "uri", Value: iter.GetIRI().String()}, {Key: "account_id", Value: requestingAccount.ID}}, &gtsmodel.StatusReaction{}); err != nil {
+				return fmt.Errorf("UNDO: db error removing reaction: %s", err)
+			}
+			continue
+		}
 		if iter.GetType() == nil {
 			continue
 		}
@@ -95,6 +103,28 @@ func (f *federatingDB) Undo(ctx context.Context, undo vocab.ActivityStreamsUndo)
 			return nil
 		case ap.ActivityLike:
 			// UNDO LIKE
+			ASLike, ok := iter.GetType().(vocab.ActivityStreamsLike)
+			if !ok {
+				return errors.New("UNDO: couldn't parse like into vocab.ActivityStreamsLike")
+			}
+			// make sure the actor owns the like
+			if !sameActor(undo.GetActivityStreamsActor(), ASLike.GetActivityStreamsActor()) {
+				return errors.New("UNDO: like actor and activity actor not the same")
+			}
+			if ap.ExtractReaction(ASLike) == "" || requestingAccount == nil {
+				// TODO: undo plain likes/faves
+				continue
+			}
+			idProp := ASLike.GetJSONLDId()
+			if idProp == nil || !idProp.IsIRI() {
+				return errors.New("UNDO: no id property set on like, or was not an iri")
+			}
+			// delete any existing REACTION
+			if err := f.db.DeleteWhere(ctx, []db.Where{{Key: "uri", Value: idProp.GetIRI().String()}, {Key: "account_id", Value: requestingAccount.ID}}, &gtsmodel.StatusReaction{}); err != nil {
+				return fmt.Errorf("UNDO: db error removing reaction: %s", err)
+			}
+			l.Debug("reaction undone")
+			return nil
 		case ap.ActivityAnnounce:
 			// UNDO BOOST/REBLOG/ANNOUNCE
 		case ap.ActivityBlock:
@@ -62,7 +62,7 @@ import (
 // write a response to the ResponseWriter as is expected that the caller
 // to PostInbox will do so when handling the error.
 func (f *federator) PostInboxRequestBodyHook(ctx context.Context, r *http.Request, activity pub.Activity) (context.Context, error) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":      "PostInboxRequestBodyHook",
 		"useragent": r.UserAgent(),
 		"url":       r.URL.String(),
@@ -94,7 +94,7 @@ func (f *federator) PostInboxRequestBodyHook(ctx context.Context, r *http.Reques
 // authenticated must be true and error nil. The request will continue
 // to be processed.
 func (f *federator) AuthenticatePostInbox(ctx context.Context, w http.ResponseWriter, r *http.Request) (context.Context, bool, error) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":      "AuthenticatePostInbox",
 		"useragent": r.UserAgent(),
 		"url":       r.URL.String(),
@@ -132,6 +132,18 @@ func (f *federator) AuthenticatePostInbox(ctx context.Context, w http.ResponseWr
 		}
 	}
 
+	// if federation with the requesting domain is paused, accept the activity so that the remote
+	// doesn't keep retrying delivery, but drop it without processing or dereferencing anything
+	paused, err := f.db.IsDomainPaused(ctx, publicKeyOwnerURI.Host)
+	if err != nil {
+		return ctx, false, fmt.Errorf("error checking domain pause for %s: %s", publicKeyOwnerURI.Host, err)
+	}
+	if paused {
+		l.Debugf("dropping activity from paused domain %s", publicKeyOwnerURI.Host)
+		w.WriteHeader(http.StatusAccepted)
+		return ctx, false, nil
+	}
+
 	// authentication has passed, so add an instance entry for this instance if it hasn't been done already
 	i := &gtsmodel.Instance{}
 	if err := f.db.GetWhere(ctx, []db.Where{{Key: "domain", Value: publicKeyOwnerURI.Host}}, i); err != nil {
@@ -141,7 +153,7 @@ func (f *federator) AuthenticatePostInbox(ctx context.Context, w http.ResponseWr
 		}
 
 		// we don't have an entry for this instance yet so dereference it
-		i, err = f.GetRemoteInstance(ctx, username, &url.URL{
+		i, err = f.GetRemoteInstance(ctx, &url.URL{
 			Scheme: publicKeyOwnerURI.Scheme,
 			Host:   publicKeyOwnerURI.Host,
 		})
@@ -180,7 +192,7 @@ func (f *federator) AuthenticatePostInbox(ctx context.Context, w http.ResponseWr
 // blocked must be false and error nil. The request will continue
 // to be processed.
 func (f *federator) Blocked(ctx context.Context, actorIRIs []*url.URL) (bool, error) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func": "Blocked",
 	})
 	l.Debugf("entering BLOCKED function with IRI list: %+v", actorIRIs)
@@ -280,7 +292,7 @@ func (f *federator) FederatingCallbacks(ctx context.Context) (wrapped pub.Federa
 // type and extension, so the unhandled ones are passed to
 // DefaultCallback.
 func (f *federator) DefaultCallback(ctx context.Context, activity pub.Activity) error {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":   "DefaultCallback",
 		"aptype": activity.GetTypeName(),
 	})
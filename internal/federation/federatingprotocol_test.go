@@ -117,6 +117,47 @@ func (suite *FederatingProtocolTestSuite) TestAuthenticatePostInbox() {
 	suite.Equal(sendingAccount.Username, requestingAccount.Username)
 }
 
+func (suite *FederatingProtocolTestSuite) TestAuthenticatePostInboxPausedDomain() {
+	activity := suite.testActivities["dm_for_zork"]
+	sendingAccount := suite.testAccounts["remote_account_1"]
+	inboxAccount := suite.testAccounts["local_account_1"]
+
+	fedWorker := worker.New[messages.FromFederator](-1, -1)
+
+	tc := testrig.NewTestTransportController(testrig.NewMockHTTPClient(nil), suite.db, fedWorker)
+	federator := federation.NewFederator(suite.db, testrig.NewTestFederatingDB(suite.db, fedWorker), tc, suite.tc, testrig.NewTestMediaManager(suite.db, suite.storage))
+
+	// pause federation with the domain of the sending account
+	suite.NoError(suite.db.Put(context.Background(), &gtsmodel.DomainPause{
+		ID:                 "01G5BJ2V8SZ6VYP2HMC3TK9XWQ",
+		Domain:             sendingAccount.Domain,
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}))
+
+	request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/users/the_mighty_zork/inbox", nil)
+	request.Header.Set("Signature", activity.SignatureHeader)
+	request.Header.Set("Date", activity.DateHeader)
+	request.Header.Set("Digest", activity.DigestHeader)
+
+	verifier, err := httpsig.NewVerifier(request)
+	suite.NoError(err)
+
+	ctx := context.Background()
+	ctxWithAccount := context.WithValue(ctx, ap.ContextReceivingAccount, inboxAccount)
+	ctxWithActivity := context.WithValue(ctxWithAccount, ap.ContextActivity, activity)
+	ctxWithVerifier := context.WithValue(ctxWithActivity, ap.ContextRequestingPublicKeyVerifier, verifier)
+	ctxWithSignature := context.WithValue(ctxWithVerifier, ap.ContextRequestingPublicKeySignature, activity.SignatureHeader)
+
+	recorder := httptest.NewRecorder()
+
+	// the activity should be accepted but dropped, without setting the requesting account
+	newContext, authed, err := federator.AuthenticatePostInbox(ctxWithSignature, recorder, request)
+	suite.NoError(err)
+	suite.False(authed)
+	suite.Equal(http.StatusAccepted, recorder.Code)
+	suite.Nil(newContext.Value(ap.ContextRequestingAccount))
+}
+
 func TestFederatingProtocolTestSuite(t *testing.T) {
 	suite.Run(t, new(FederatingProtocolTestSuite))
 }
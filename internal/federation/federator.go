@@ -22,13 +22,16 @@ import (
 	"context"
 	"net/url"
 
+	"github.com/spf13/viper"
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation/dereferencing"
 	"github.com/superseriousbusiness/gotosocial/internal/federation/federatingdb"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
@@ -55,7 +58,9 @@ type Federator interface {
 
 	// FingerRemoteAccount performs a webfinger lookup for a remote account, using the .well-known path. It will return the ActivityPub URI for that
 	// account, or an error if it doesn't exist or can't be retrieved.
-	FingerRemoteAccount(ctx context.Context, requestingUsername string, targetUsername string, targetDomain string) (*url.URL, error)
+	//
+	// The lookup is always made on behalf of the instance actor, so that the remote server can't tell which user triggered it.
+	FingerRemoteAccount(ctx context.Context, targetUsername string, targetDomain string) (*url.URL, error)
 
 	DereferenceRemoteThread(ctx context.Context, username string, statusURI *url.URL) error
 	DereferenceAnnounce(ctx context.Context, announce *gtsmodel.Status, requestingUsername string) error
@@ -65,7 +70,11 @@ type Federator interface {
 	GetRemoteStatus(ctx context.Context, username string, remoteStatusID *url.URL, refresh, includeParent bool) (*gtsmodel.Status, ap.Statusable, bool, error)
 	EnrichRemoteStatus(ctx context.Context, username string, status *gtsmodel.Status, includeParent bool) (*gtsmodel.Status, error)
 
-	GetRemoteInstance(ctx context.Context, username string, remoteInstanceURI *url.URL) (*gtsmodel.Instance, error)
+	// GetRemoteInstance dereferences information about the remote instance at remoteInstanceURI, on behalf of the instance actor.
+	GetRemoteInstance(ctx context.Context, remoteInstanceURI *url.URL) (*gtsmodel.Instance, error)
+
+	// GetRemoteEmoji fetches the image of a remote custom emoji from remoteURL, and stores it as an emoji with the given shortcode and id.
+	GetRemoteEmoji(ctx context.Context, requestingUsername string, remoteURL string, shortcode string, id string, emojiURI string, ai *media.AdditionalEmojiInfo) (*media.ProcessingEmoji, error)
 
 	// Handshaking returns true if the given username is currently in the process of dereferencing the remoteAccountID.
 	Handshaking(ctx context.Context, username string, remoteAccountID *url.URL) bool
@@ -82,6 +91,7 @@ type federator struct {
 	dereferencer        dereferencing.Dereferencer
 	mediaManager        media.Manager
 	actor               pub.FederatingActor
+	logSampler          *log.Sampler // collapses repeated errors caused by remote instances
 }
 
 // NewFederator returns a new federator
@@ -97,6 +107,7 @@ func NewFederator(db db.DB, federatingDB federatingdb.DB, transportController tr
 		transportController: transportController,
 		dereferencer:        dereferencer,
 		mediaManager:        mediaManager,
+		logSampler:          log.NewSampler(viper.GetDuration(config.Keys.LogSamplePeriod)),
 	}
 	actor := newFederatingActor(f, f, federatingDB, clock)
 	f.actor = actor
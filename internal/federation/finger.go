@@ -29,24 +29,24 @@ import (
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 )
 
-func (f *federator) FingerRemoteAccount(ctx context.Context, requestingUsername string, targetUsername string, targetDomain string) (*url.URL, error) {
+func (f *federator) FingerRemoteAccount(ctx context.Context, targetUsername string, targetDomain string) (*url.URL, error) {
 	if blocked, err := f.db.IsDomainBlocked(ctx, targetDomain); blocked || err != nil {
 		return nil, fmt.Errorf("FingerRemoteAccount: domain %s is blocked", targetDomain)
 	}
 
-	t, err := f.transportController.NewTransportForUsername(ctx, requestingUsername)
+	t, err := f.transportController.NewTransportForInstance(ctx)
 	if err != nil {
-		return nil, fmt.Errorf("FingerRemoteAccount: error getting transport for username %s while dereferencing @%s@%s: %s", requestingUsername, targetUsername, targetDomain, err)
+		return nil, fmt.Errorf("FingerRemoteAccount: error getting instance transport while dereferencing @%s@%s: %s", targetUsername, targetDomain, err)
 	}
 
 	b, err := t.Finger(ctx, targetUsername, targetDomain)
 	if err != nil {
-		return nil, fmt.Errorf("FingerRemoteAccount: error doing request on behalf of username %s while dereferencing @%s@%s: %s", requestingUsername, targetUsername, targetDomain, err)
+		return nil, fmt.Errorf("FingerRemoteAccount: error doing request while dereferencing @%s@%s: %s", targetUsername, targetDomain, err)
 	}
 
 	resp := &apimodel.WellKnownResponse{}
 	if err := json.Unmarshal(b, resp); err != nil {
-		return nil, fmt.Errorf("FingerRemoteAccount: could not unmarshal server response as WebfingerAccountResponse on behalf of username %s while dereferencing @%s@%s: %s", requestingUsername, targetUsername, targetDomain, err)
+		return nil, fmt.Errorf("FingerRemoteAccount: could not unmarshal server response as WebfingerAccountResponse while dereferencing @%s@%s: %s", targetUsername, targetDomain, err)
 	}
 
 	if len(resp.Links) == 0 {
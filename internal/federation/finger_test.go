@@ -0,0 +1,68 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package federation_test
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/federation"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/worker"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type FingerTestSuite struct {
+	FederatorStandardTestSuite
+}
+
+func (suite *FingerTestSuite) TestFingerSignedByInstance() {
+	ctx := context.Background()
+
+	instanceAccount, err := suite.db.GetInstanceAccount(ctx, "")
+	suite.NoError(err)
+
+	fedWorker := worker.New[messages.FromFederator](-1, -1)
+
+	var signature string
+	tc := testrig.NewTestTransportController(testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		signature = req.Header.Get("Signature")
+		r := ioutil.NopCloser(bytes.NewReader([]byte(`{"subject":"acct:some_user@example.org","links":[{"rel":"self","type":"application/activity+json","href":"https://example.org/users/some_user"}]}`)))
+		return &http.Response{
+			StatusCode: 200,
+			Body:       r,
+		}, nil
+	}), suite.db, fedWorker)
+	federator := federation.NewFederator(suite.db, testrig.NewTestFederatingDB(suite.db, fedWorker), tc, suite.tc, testrig.NewTestMediaManager(suite.db, suite.storage))
+
+	acctURI, err := federator.FingerRemoteAccount(ctx, "some_user", "example.org")
+	suite.NoError(err)
+	suite.Equal("https://example.org/users/some_user", acctURI.String())
+
+	// the request should have been signed by the instance actor rather than any user
+	suite.Contains(signature, `keyId="`+instanceAccount.PublicKeyURI+`"`)
+}
+
+func TestFingerTestSuite(t *testing.T) {
+	suite.Run(t, new(FingerTestSuite))
+}
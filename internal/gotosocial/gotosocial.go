@@ -25,6 +25,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/router"
+	"github.com/superseriousbusiness/gotosocial/internal/scheduler"
 )
 
 // Server is the 'main' function of the gotosocial server, and the place where everything hangs together.
@@ -33,8 +34,8 @@ type Server interface {
 	// Start starts up the gotosocial server. If something goes wrong
 	// while starting the server, then an error will be returned.
 	Start(context.Context) error
-	// Stop closes down the gotosocial server, first closing the router
-	// then the database. If something goes wrong while stopping, an
+	// Stop closes down the gotosocial server, first closing the router,
+	// then the scheduler, then the database. If something goes wrong while stopping, an
 	// error will be returned.
 	Stop(context.Context) error
 }
@@ -42,12 +43,13 @@ type Server interface {
 // NewServer returns a new gotosocial server, initialized with the given configuration.
 // An error will be returned the caller if something goes wrong during initialization
 // eg., no db or storage connection, port for router already in use, etc.
-func NewServer(db db.DB, apiRouter router.Router, federator federation.Federator, mediaManager media.Manager) (Server, error) {
+func NewServer(db db.DB, apiRouter router.Router, federator federation.Federator, mediaManager media.Manager, jobScheduler *scheduler.Scheduler) (Server, error) {
 	return &gotosocial{
 		db:           db,
 		apiRouter:    apiRouter,
 		federator:    federator,
 		mediaManager: mediaManager,
+		scheduler:    jobScheduler,
 	}, nil
 }
 
@@ -57,22 +59,26 @@ type gotosocial struct {
 	apiRouter    router.Router
 	federator    federation.Federator
 	mediaManager media.Manager
+	scheduler    *scheduler.Scheduler
 }
 
 // Start starts up the gotosocial server. If something goes wrong
 // while starting the server, then an error will be returned.
 func (gts *gotosocial) Start(ctx context.Context) error {
 	gts.apiRouter.Start()
-	return nil
+	return gts.scheduler.Start()
 }
 
 // Stop closes down the gotosocial server, first closing the router,
-// then the media manager, then the database.
+// then the scheduler and the media manager, then the database.
 // If something goes wrong while stopping, an error will be returned.
 func (gts *gotosocial) Stop(ctx context.Context) error {
 	if err := gts.apiRouter.Stop(ctx); err != nil {
 		return err
 	}
+	if err := gts.scheduler.Stop(ctx); err != nil {
+		return err
+	}
 	if err := gts.mediaManager.Stop(); err != nil {
 		return err
 	}
@@ -0,0 +1,34 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtserror
+
+// ErrorCode is a stable, machine-readable code for a kind of error, which is served to clients alongside
+// the safe error message, so that they can tell errors apart. Once defined, codes must never change.
+type ErrorCode string
+
+const (
+	// CodeDuplicateStatus means that a status was rejected for duplicating one which was just posted.
+	CodeDuplicateStatus ErrorCode = "duplicate_status"
+	// CodeRateLimited means that too many requests of some kind have been made; try again later.
+	CodeRateLimited ErrorCode = "rate_limited"
+	// CodeMediaTooLarge means that an uploaded media file exceeds the size limit for media.
+	CodeMediaTooLarge ErrorCode = "media_too_large"
+	// CodeReadOnly means that the instance is in read-only mode, and doesn't accept changes right now.
+	CodeReadOnly ErrorCode = "read_only"
+)
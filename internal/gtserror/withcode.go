@@ -22,6 +22,7 @@ import (
 	"errors"
 	"net/http"
 	"strings"
+	"time"
 )
 
 // WithCode wraps an internal error with an http code, and a 'safe' version of
@@ -38,15 +39,29 @@ type WithCode interface {
 	Safe() string
 	//  Code returns the status code for serving to a client.
 	Code() int
+	// ErrorCode returns the machine-readable code of the error for serving to a client, so that clients
+	// can tell errors apart without parsing the safe message. Not all errors have one, in which case it's empty.
+	ErrorCode() ErrorCode
+	// Details returns further details about the error which are safe to serve to a client, if there are any.
+	Details() map[string]interface{}
+	// RetryAfter returns how long the client should wait before trying again, for errors such as 429 and 503
+	// which are only temporary. If it's 0, a client should be told to wait for a default period instead.
+	RetryAfter() time.Duration
 }
 
 type withCode struct {
-	original error
-	safe     error
-	code     int
+	original   error
+	safe       error
+	code       int
+	errorCode  ErrorCode
+	details    map[string]interface{}
+	retryAfter time.Duration
 }
 
 func (e withCode) Error() string {
+	if e.original == nil {
+		return e.safe.Error()
+	}
 	return e.original.Error()
 }
 
@@ -58,6 +73,62 @@ func (e withCode) Code() int {
 	return e.code
 }
 
+func (e withCode) ErrorCode() ErrorCode {
+	return e.errorCode
+}
+
+func (e withCode) Details() map[string]interface{} {
+	return e.details
+}
+
+func (e withCode) RetryAfter() time.Duration {
+	return e.retryAfter
+}
+
+// copyWithCode returns a copy of the given error which wraps it, so that its other fields can be changed.
+func copyWithCode(err WithCode) withCode {
+	return withCode{
+		original:   err,
+		safe:       errors.New(err.Safe()),
+		code:       err.Code(),
+		errorCode:  err.ErrorCode(),
+		details:    err.Details(),
+		retryAfter: err.RetryAfter(),
+	}
+}
+
+// WithErrorCode returns a copy of the given error with the given machine-readable error code.
+func WithErrorCode(err WithCode, errorCode ErrorCode) WithCode {
+	e := copyWithCode(err)
+	e.errorCode = errorCode
+	return e
+}
+
+// WithDetails returns a copy of the given error with the given details, which must be safe to serve to a client.
+func WithDetails(err WithCode, details map[string]interface{}) WithCode {
+	e := copyWithCode(err)
+	e.details = details
+	return e
+}
+
+// WithRetryAfter returns a copy of the given error telling the client to wait for the given duration before trying again.
+func WithRetryAfter(err WithCode, retryAfter time.Duration) WithCode {
+	e := copyWithCode(err)
+	e.retryAfter = retryAfter
+	return e
+}
+
+// NewError returns an ErrorWithCode with the given http status code, original error, and safe text. Unlike
+// the other constructors, the safe text isn't prefixed with a description of the status code, so this is for
+// handlers which already serve their own messages to clients, and shouldn't change what those look like.
+func NewError(code int, original error, safe string) WithCode {
+	return withCode{
+		original: original,
+		safe:     errors.New(safe),
+		code:     code,
+	}
+}
+
 // NewErrorBadRequest returns an ErrorWithCode 400 with the given original error and optional help text.
 func NewErrorBadRequest(original error, helpText ...string) WithCode {
 	safe := "bad request"
@@ -123,6 +194,19 @@ func NewErrorInternalError(original error, helpText ...string) WithCode {
 	}
 }
 
+// NewErrorNotAcceptable returns an ErrorWithCode 406 with the given original error and optional help text.
+func NewErrorNotAcceptable(original error, helpText ...string) WithCode {
+	safe := "not acceptable"
+	if helpText != nil {
+		safe = safe + ": " + strings.Join(helpText, ": ")
+	}
+	return withCode{
+		original: original,
+		safe:     errors.New(safe),
+		code:     http.StatusNotAcceptable,
+	}
+}
+
 // NewErrorConflict returns an ErrorWithCode 409 with the given original error and optional help text.
 func NewErrorConflict(original error, helpText ...string) WithCode {
 	safe := "conflict"
@@ -135,3 +219,57 @@ func NewErrorConflict(original error, helpText ...string) WithCode {
 		code:     http.StatusConflict,
 	}
 }
+
+// NewErrorTooManyRequests returns an ErrorWithCode 429 with the given original error and optional help text.
+// The error has the error code CodeRateLimited.
+func NewErrorTooManyRequests(original error, helpText ...string) WithCode {
+	safe := "too many requests"
+	if helpText != nil {
+		safe = safe + ": " + strings.Join(helpText, ": ")
+	}
+	return withCode{
+		original:  original,
+		safe:      errors.New(safe),
+		code:      http.StatusTooManyRequests,
+		errorCode: CodeRateLimited,
+	}
+}
+
+// NewErrorUnprocessableEntity returns an ErrorWithCode 422 with the given original error and optional help text.
+func NewErrorUnprocessableEntity(original error, helpText ...string) WithCode {
+	safe := "unprocessable entity"
+	if helpText != nil {
+		safe = safe + ": " + strings.Join(helpText, ": ")
+	}
+	return withCode{
+		original: original,
+		safe:     errors.New(safe),
+		code:     http.StatusUnprocessableEntity,
+	}
+}
+
+// NewErrorNotImplemented returns an ErrorWithCode 501 with the given original error and optional help text.
+func NewErrorNotImplemented(original error, helpText ...string) WithCode {
+	safe := "not implemented"
+	if helpText != nil {
+		safe = safe + ": " + strings.Join(helpText, ": ")
+	}
+	return withCode{
+		original: original,
+		safe:     errors.New(safe),
+		code:     http.StatusNotImplemented,
+	}
+}
+
+// NewErrorServiceUnavailable returns an ErrorWithCode 503 with the given original error and optional help text.
+func NewErrorServiceUnavailable(original error, helpText ...string) WithCode {
+	safe := "service unavailable"
+	if helpText != nil {
+		safe = safe + ": " + strings.Join(helpText, ": ")
+	}
+	return withCode{
+		original: original,
+		safe:     errors.New(safe),
+		code:     http.StatusServiceUnavailable,
+	}
+}
@@ -44,19 +44,26 @@ type Account struct {
 	Fields                  []Field          `validate:"-"`                                                                                                          // a key/value map of fields that this account has added to their profile
 	Note                    string           `validate:"-" bun:""`                                                                                                   // A note that this account has on their profile (ie., the account's bio/description of themselves)
 	NoteRaw                 string           `validate:"-" bun:""`                                                                                                   // The raw contents of .Note without conversion to HTML, only available when requester = target
+	CustomCSS               string           `validate:"-" bun:",nullzero"`                                                                                          // Custom CSS to include on this account's profile page, if enabled on this instance
 	Memorial                bool             `validate:"-" bun:",default:false"`                                                                                     // Is this a memorial account, ie., has the user passed away?
 	AlsoKnownAs             string           `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                                                                // This account is associated with x account id (TODO: migrate to be AlsoKnownAsID)
 	MovedToAccountID        string           `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                                                                // This account has moved this account id in the database
 	Bot                     bool             `validate:"-" bun:",default:false"`                                                                                     // Does this account identify itself as a bot?
 	Reason                  string           `validate:"-" bun:""`                                                                                                   // What reason was given for signing up when this account was created?
 	Locked                  bool             `validate:"-" bun:",default:true"`                                                                                      // Does this account need an approval for new followers?
+	AutoAcceptFollowed      bool             `validate:"-" bun:",default:false"`                                                                                     // Should follow requests from accounts that this account follows be accepted automatically, even if this account is locked?
+	AutoAcceptDomains       []string         `validate:"-"`                                                                                                          // Follow requests from accounts on these domains, or their subdomains, are accepted automatically, even if this account is locked.
 	Discoverable            bool             `validate:"-" bun:",default:false"`                                                                                     // Should this account be shown in the instance's profile directory?
+	Unquotable              bool             `validate:"-" bun:",default:false"`                                                                                     // Has this account opted out of having its statuses quoted by others?
+	ArchiveAfterDays        int              `validate:"min=0" bun:",nullzero"`                                                                                      // Hide statuses of this account from public view after this many days, overriding the instance setting. 0 means use the instance setting.
+	AutoDeleteAfterDays     int              `validate:"min=0" bun:",nullzero"`                                                                                      // Automatically delete statuses of this account after this many days. 0 means never.
 	Privacy                 Visibility       `validate:"required_without=Domain,omitempty,oneof=public unlocked followers_only mutuals_only direct" bun:",nullzero"` // Default post privacy for this account
 	Sensitive               bool             `validate:"-" bun:",default:false"`                                                                                     // Set posts from this account to sensitive by default?
 	Language                string           `validate:"omitempty,bcp47_language_tag" bun:",nullzero,notnull,default:'en'"`                                          // What language does this account post in?
 	URI                     string           `validate:"required,url" bun:",nullzero,notnull,unique"`                                                                // ActivityPub URI for this account.
 	URL                     string           `validate:"required_without=Domain,omitempty,url" bun:",nullzero,unique"`                                               // Web URL for this account's profile
 	LastWebfingeredAt       time.Time        `validate:"required_with=Domain" bun:"type:timestamptz,nullzero"`                                                       // Last time this account was refreshed/located with webfinger.
+	FetchedAt               time.Time        `validate:"-" bun:"type:timestamptz,nullzero"`                                                                          // Last time this account was dereferenced from its instance, for remote accounts.
 	InboxURI                string           `validate:"required_without=Domain,omitempty,url" bun:",nullzero,unique"`                                               // Address of this account's ActivityPub inbox, for sending activity to
 	OutboxURI               string           `validate:"required_without=Domain,omitempty,url" bun:",nullzero,unique"`                                               // Address of this account's activitypub outbox
 	FollowingURI            string           `validate:"required_without=Domain,omitempty,url" bun:",nullzero,unique"`                                               // URI for getting the following list of this account
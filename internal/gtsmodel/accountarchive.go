@@ -0,0 +1,45 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// AccountArchive models an archive of a local account's data, which the owner of the account can download.
+type AccountArchive struct {
+	ID        string               `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt time.Time            `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time            `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID string               `validate:"required,ulid" bun:"type:CHAR(26),notnull,nullzero"`                  // Which account is this an archive of?
+	Status    AccountArchiveStatus `validate:"oneof=pending ready failed" bun:",nullzero,notnull"`                  // Has the archive been generated yet?
+	Path      string               `validate:"-" bun:",nullzero"`                                                   // Path of the archive zip in storage, once it's ready
+	Size      int                  `validate:"-" bun:",nullzero"`                                                   // Size of the archive zip in bytes, once it's ready
+	Secret    string               `validate:"required" bun:",nullzero,notnull"`                                    // Key used to sign download links for this archive
+}
+
+// AccountArchiveStatus describes the progress of generating an account archive.
+type AccountArchiveStatus string
+
+const (
+	// AccountArchivePending -- the archive is still being generated.
+	AccountArchivePending AccountArchiveStatus = "pending"
+	// AccountArchiveReady -- the archive has been generated and can be downloaded.
+	AccountArchiveReady AccountArchiveStatus = "ready"
+	// AccountArchiveFailed -- something went wrong while generating the archive.
+	AccountArchiveFailed AccountArchiveStatus = "failed"
+)
@@ -0,0 +1,63 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// BlockImport models a list of accounts to block, imported by a local account, and the outcome of blocking each of them.
+type BlockImport struct {
+	ID        string            `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt time.Time         `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time         `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID string            `validate:"required,ulid" bun:"type:CHAR(26),notnull,nullzero"`                  // Which account imported the list?
+	Status    BlockImportStatus `validate:"oneof=pending done" bun:",nullzero,notnull"`                          // Have all the lines of the import been processed yet?
+	Lines     []BlockImportLine `validate:"-"`                                                                   // The lines of the imported list, and what happened to each of them
+}
+
+// BlockImportLine is one line of an imported list of accounts to block.
+type BlockImportLine struct {
+	Line   int                   `validate:"-"` // Line number in the imported file, starting from 1.
+	Handle string                `validate:"-"` // Handle of the account to block, in the form username@domain.
+	Result BlockImportLineResult `validate:"-"` // What happened when blocking the account?
+	Error  string                `validate:"-"` // Why the account couldn't be blocked, if it couldn't.
+}
+
+// BlockImportStatus describes the progress of processing a block import.
+type BlockImportStatus string
+
+const (
+	// BlockImportPending -- some lines of the import are still waiting to be processed.
+	BlockImportPending BlockImportStatus = "pending"
+	// BlockImportDone -- all lines of the import have been processed.
+	BlockImportDone BlockImportStatus = "done"
+)
+
+// BlockImportLineResult describes what happened to one line of a block import.
+type BlockImportLineResult string
+
+const (
+	// BlockImportLinePending -- the line hasn't been processed yet.
+	BlockImportLinePending BlockImportLineResult = "pending"
+	// BlockImportLineBlocked -- the account was blocked.
+	BlockImportLineBlocked BlockImportLineResult = "blocked"
+	// BlockImportLineSkipped -- the account was already blocked, or is the importing account itself.
+	BlockImportLineSkipped BlockImportLineResult = "skipped"
+	// BlockImportLineFailed -- the line couldn't be parsed, or the account couldn't be found or blocked.
+	BlockImportLineFailed BlockImportLineResult = "failed"
+)
@@ -42,4 +42,5 @@ type Emoji struct {
 	URI                    string    `validate:"url" bun:",nullzero,notnull,unique"`                                                          // ActivityPub uri of this emoji. Something like 'https://example.org/emojis/1234'
 	VisibleInPicker        bool      `validate:"-" bun:",notnull,default:true"`                                                               // Is this emoji visible in the admin emoji picker?
 	CategoryID             string    `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                                                 // In which emoji category is this emoji visible?
+	Category               string    `validate:"-" bun:",nullzero"`                                                                           // Name of the category this emoji is sorted into in emoji pickers. Empty for uncategorized emojis.
 }
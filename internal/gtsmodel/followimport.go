@@ -0,0 +1,65 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// FollowImport models a list of accounts to follow, imported by a local account, and the outcome of following each of them.
+type FollowImport struct {
+	ID        string             `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt time.Time          `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time          `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID string             `validate:"required,ulid" bun:"type:CHAR(26),notnull,nullzero"`                  // Which account imported the list?
+	Status    FollowImportStatus `validate:"oneof=pending done" bun:",nullzero,notnull"`                          // Have all the lines of the import been processed yet?
+	Lines     []FollowImportLine `validate:"-"`                                                                   // The lines of the imported list, and what happened to each of them
+}
+
+// FollowImportLine is one line of an imported list of accounts to follow.
+type FollowImportLine struct {
+	Line       int                    `validate:"-"` // Line number in the imported file, starting from 1.
+	Handle     string                 `validate:"-"` // Handle of the account to follow, in the form username@domain.
+	ShowBoosts bool                   `validate:"-"` // Should boosts by the account be shown in the home timeline?
+	Notify     bool                   `validate:"-"` // Should new posts by the account cause a notification?
+	Result     FollowImportLineResult `validate:"-"` // What happened when following the account?
+	Error      string                 `validate:"-"` // Why the account couldn't be followed, if it couldn't.
+}
+
+// FollowImportStatus describes the progress of processing a follow import.
+type FollowImportStatus string
+
+const (
+	// FollowImportPending -- some lines of the import are still waiting to be processed.
+	FollowImportPending FollowImportStatus = "pending"
+	// FollowImportDone -- all lines of the import have been processed.
+	FollowImportDone FollowImportStatus = "done"
+)
+
+// FollowImportLineResult describes what happened to one line of a follow import.
+type FollowImportLineResult string
+
+const (
+	// FollowImportLinePending -- the line hasn't been processed yet.
+	FollowImportLinePending FollowImportLineResult = "pending"
+	// FollowImportLineFollowed -- the account was followed, or a follow request was sent to it.
+	FollowImportLineFollowed FollowImportLineResult = "followed"
+	// FollowImportLineSkipped -- the account was already followed, or is the importing account itself.
+	FollowImportLineSkipped FollowImportLineResult = "skipped"
+	// FollowImportLineFailed -- the line couldn't be parsed, or the account couldn't be found or followed.
+	FollowImportLineFailed FollowImportLineResult = "failed"
+)
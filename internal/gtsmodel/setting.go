@@ -0,0 +1,43 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// Setting is an instance-wide setting which can be changed at runtime, and takes
+// precedence over the equivalent value from the config, if there is one.
+type Setting struct {
+	Key       string    `validate:"required" bun:",pk,nullzero,notnull,unique"`                          // key of this setting
+	CreatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	Value     string    `validate:"-" bun:",notnull"`                                                    // value of this setting
+}
+
+// SettingRegistrationsOpen is the key of the setting which overrides whether
+// new accounts can sign up on this instance. Its value is "true" or "false".
+const SettingRegistrationsOpen = "registrations_open"
+
+// SettingReadOnly is the key of the setting which overrides whether this
+// instance is in read-only (maintenance) mode. Its value is "true" or "false".
+const SettingReadOnly = "read_only"
+
+// SettingReadOnlyMessage is the key of the setting which overrides the message
+// shown to users while this instance is in read-only mode. An empty value means
+// that the message from the config should be used instead.
+const SettingReadOnlyMessage = "read_only_message"
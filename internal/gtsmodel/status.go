@@ -51,6 +51,13 @@ type Status struct {
 	BoostOfAccountID         string             `validate:"required_with=BoostOfID,omitempty,ulid" bun:"type:CHAR(26),nullzero"`                       // id of the account that owns the boosted status
 	BoostOf                  *Status            `validate:"-" bun:"-"`                                                                                 // status that corresponds to boostOfID
 	BoostOfAccount           *Account           `validate:"-" bun:"rel:belongs-to"`                                                                    // account that corresponds to boostOfAccountID
+	QuoteOfID                string             `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                                               // id of the status this status quotes
+	QuoteOfURI               string             `validate:"required_with=QuoteOfID,omitempty,url" bun:",nullzero"`                                     // activitypub uri of the status this status quotes
+	QuoteOf                  *Status            `validate:"-" bun:"-"`                                                                                 // status corresponding to quoteOfID
+	PreviewCardID            string             `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                                               // id of the preview card of the first link in this status
+	PreviewCard              *PreviewCard       `validate:"-" bun:"-"`                                                                                 // preview card corresponding to previewCardID
+	ModerationRuleID         string             `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                                               // id of the moderation rule this status matched, if it was held or flagged for review
+	HeldAt                   time.Time          `validate:"-" bun:"type:timestamptz,nullzero"`                                                         // when was this status held for review by a moderation rule? Held statuses are only visible to their author.
 	ContentWarning           string             `validate:"-" bun:",nullzero"`                                                                         // cw string for this status
 	Visibility               Visibility         `validate:"oneof=public unlocked followers_only mutuals_only direct" bun:",nullzero,notnull"`          // visibility entry for this status
 	Sensitive                bool               `validate:"-" bun:",notnull,default:false"`                                                            // mark the status as sensitive?
@@ -64,6 +71,7 @@ type Status struct {
 	Boostable                bool               `validate:"-" bun:",notnull"`                                                                          // This status can be boosted/reblogged
 	Replyable                bool               `validate:"-" bun:",notnull"`                                                                          // This status can be replied to
 	Likeable                 bool               `validate:"-" bun:",notnull"`                                                                          // This status can be liked/faved
+	ReplyPolicy              ReplyPolicy        `validate:"omitempty,oneof=everyone followers mentioned nobody" bun:",nullzero"`                       // Who may reply to this status? Empty means everyone.
 }
 
 /*
@@ -120,3 +128,17 @@ const (
 	// VisibilityDefault is used when no other setting can be found.
 	VisibilityDefault Visibility = VisibilityUnlocked
 )
+
+// ReplyPolicy represents who is permitted to reply to a status. The author of a status can always reply to it.
+type ReplyPolicy string
+
+const (
+	// ReplyPolicyEveryone means anyone who can see the status can reply to it.
+	ReplyPolicyEveryone ReplyPolicy = "everyone"
+	// ReplyPolicyFollowers means only followers of the author can reply to the status.
+	ReplyPolicyFollowers ReplyPolicy = "followers"
+	// ReplyPolicyMentioned means only accounts mentioned in the status can reply to it.
+	ReplyPolicyMentioned ReplyPolicy = "mentioned"
+	// ReplyPolicyNobody means nobody but the author can reply to the status.
+	ReplyPolicyNobody ReplyPolicy = "nobody"
+)
@@ -0,0 +1,39 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package gtsmodel
+
+import "time"
+
+// StatusReaction refers to an emoji reaction to a status, from one account, targeting the status of another account.
+// Reactions are federated as EmojiReact activities, or as Likes carrying a reaction, as Misskey and Pleroma do.
+type StatusReaction struct {
+	ID              string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`        // id of this item in the database
+	CreatedAt       time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item created
+	UpdatedAt       time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was item last updated
+	AccountID       string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id of the account that created ('did') the reaction
+	Account         *Account  `validate:"-" bun:"rel:belongs-to"`                                              // account that created the reaction
+	TargetAccountID string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // id the account owning the reacted-to status
+	TargetAccount   *Account  `validate:"-" bun:"rel:belongs-to"`                                              // account owning the reacted-to status
+	StatusID        string    `validate:"required,ulid" bun:"type:CHAR(26),nullzero,notnull"`                  // database id of the status that has been reacted to
+	Status          *Status   `validate:"-" bun:"rel:belongs-to"`                                              // the reacted-to status
+	Name            string    `validate:"required" bun:",nullzero,notnull"`                                    // the reaction itself: either a unicode emoji, or the shortcode of a custom emoji wrapped in colons, eg ':blobcat:'
+	EmojiID         string    `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                         // database id of the custom emoji used for this reaction, if it's not a unicode emoji
+	Emoji           *Emoji    `validate:"-" bun:"rel:belongs-to"`                                              // the custom emoji used for this reaction, if it's not a unicode emoji
+	URI             string    `validate:"required,url" bun:",nullzero,notnull,unique"`                         // ActivityPub URI of this reaction
+}
@@ -32,3 +32,29 @@ type Tag struct {
 	Listable               bool      `validate:"-" bun:",notnull,default:true"`                                       // can our instance users look up this tag?
 	LastStatusAt           time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"` // when was this tag last used?
 }
+
+// TrendingTag is a tag which has been used a lot recently, along with its usage history.
+// It's not stored in the database, but calculated from recent statuses.
+type TrendingTag struct {
+	Tag     *Tag          // the trending tag
+	History []*TagHistory // usage of the tag on each recent day, most recent day first
+}
+
+// TagHistory is the usage of a tag on one day.
+type TagHistory struct {
+	Day      time.Time // midnight (UTC) at the start of the day
+	Uses     int       // number of statuses using the tag on this day
+	Accounts int       // number of distinct accounts using the tag on this day
+}
+
+// FollowedTag represents a local account following a hashtag, so that public statuses using
+// the hashtag show up in the home timeline of the account, as if it followed their authors.
+type FollowedTag struct {
+	ID        string    `validate:"required,ulid" bun:"type:CHAR(26),pk,nullzero,notnull,unique"`         // id of this item in the database
+	CreatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`  // when was item created
+	UpdatedAt time.Time `validate:"-" bun:"type:timestamptz,nullzero,notnull,default:current_timestamp"`  // when was item last updated
+	AccountID string    `validate:"required,ulid" bun:"type:CHAR(26),unique:accounttag,notnull,nullzero"` // Who follows the tag?
+	Account   *Account  `validate:"-" bun:"rel:belongs-to"`                                               // Account corresponding to accountID
+	TagID     string    `validate:"required,ulid" bun:"type:CHAR(26),unique:accounttag,notnull,nullzero"` // Which tag is followed?
+	Tag       *Tag      `validate:"-" bun:"rel:belongs-to"`                                               // Tag corresponding to tagID
+}
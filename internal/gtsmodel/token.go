@@ -40,4 +40,7 @@ type Token struct {
 	Refresh             string    `validate:"-" bun:",pk,nullzero,notnull,default:''"`                             // Refresh token, if present
 	RefreshCreateAt     time.Time `validate:"required_with=Refresh" bun:"type:timestamptz,nullzero"`               // Refresh created at, if refresh present
 	RefreshExpiresAt    time.Time `validate:"-" bun:"type:timestamptz,nullzero"`                                   // Refresh expires at -- null means the refresh token never expires
+	FamilyID            string    `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                         // ID of the first token in the refresh rotation lineage of this token, if refresh present
+	RotatedAt           time.Time `validate:"-" bun:"type:timestamptz,nullzero"`                                   // When the refresh token was exchanged for a new token -- a rotated refresh token must never be used again
+	LastUsed            time.Time `validate:"-" bun:"type:timestamptz,nullzero"`                                   // When the token was last used, roughly -- null means the token hasn't been used since it was created
 }
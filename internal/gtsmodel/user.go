@@ -39,6 +39,10 @@ type User struct {
 	LastSignInAt           time.Time    `validate:"-" bun:"type:timestamptz,nullzero"`                                   // When did this user last sign in?
 	LastSignInIP           net.IP       `validate:"-" bun:",nullzero"`                                                   // What's the previous IP of this user?
 	SignInCount            int          `validate:"min=0" bun:",notnull,default:0"`                                      // How many times has this user signed in?
+	LastLogin              time.Time    `validate:"-" bun:"type:timestamptz,nullzero"`                                   // When did this user last log in, either by signing in or by using one of their access tokens? Token use is only recorded roughly.
+	FailedSignInCount      int          `validate:"min=0" bun:",notnull,default:0"`                                      // How many times has signing in as this user failed since FailedSignInAt?
+	FailedSignInAt         time.Time    `validate:"-" bun:"type:timestamptz,nullzero"`                                   // When did the first of the failed sign ins currently being counted happen?
+	SignInLockedUntil      time.Time    `validate:"-" bun:"type:timestamptz,nullzero"`                                   // Until when are sign ins as this user locked, after too many failed ones?
 	InviteID               string       `validate:"omitempty,ulid" bun:"type:CHAR(26),nullzero"`                         // id of the user who invited this user (who let this joker in?)
 	ChosenLanguages        []string     `validate:"-" bun:",nullzero"`                                                   // What languages does this user want to see?
 	FilteredLanguages      []string     `validate:"-" bun:",nullzero"`                                                   // What languages does this user not want to see?
@@ -23,6 +23,7 @@ import (
 	"io"
 	"log/syslog"
 	"os"
+	"sync"
 
 	"github.com/sirupsen/logrus"
 	lSyslog "github.com/sirupsen/logrus/hooks/syslog"
@@ -30,6 +31,9 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 )
 
+// addRequestIDHook makes sure the request ID hook is only added to the logger once.
+var addRequestIDHook sync.Once
+
 // Initialize initializes the global Logrus logger, reading the desired
 // log level from the viper store, or using a default if the level
 // has not been set in viper.
@@ -64,6 +68,12 @@ func Initialize() error {
 		}
 	}
 
+	// include request IDs in logs made with a request context;
+	// this needs to be in place before syslog so it gets them too
+	addRequestIDHook.Do(func() {
+		logrus.AddHook(requestIDHook{})
+	})
+
 	// check if syslog has been enabled, and configure it if so
 	if syslogEnabled := viper.GetBool(keys.SyslogEnabled); syslogEnabled {
 		protocol := viper.GetString(keys.SyslogProtocol)
@@ -20,10 +20,13 @@ package log_test
 
 import (
 	"bytes"
+	"context"
+	"strings"
 	"testing"
 
 	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/gotosocial/internal/log"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 func TestOutputSplitFunc(t *testing.T) {
@@ -67,3 +70,28 @@ func TestOutputSplitFunc(t *testing.T) {
 		errbuf.Reset()
 	}
 }
+
+func TestRequestIDFromContext(t *testing.T) {
+	testrig.InitTestConfig()
+	testrig.InitTestLog()
+
+	var buf bytes.Buffer
+	logrus.SetOutput(&buf)
+	defer testrig.InitTestLog()
+
+	ctx := log.WithRequestID(context.Background(), "01G1TR6BADACCN9R9AMG8Y6GQ5")
+	if id := log.RequestID(ctx); id != "01G1TR6BADACCN9R9AMG8Y6GQ5" {
+		t.Fatalf("expected request id to be stored in context, got %q", id)
+	}
+
+	logrus.WithContext(ctx).WithField("func", "TestRequestIDFromContext").Info("hello world")
+	if !strings.Contains(buf.String(), "requestID=01G1TR6BADACCN9R9AMG8Y6GQ5") {
+		t.Errorf("expected log line to contain request id, got %q", buf.String())
+	}
+	buf.Reset()
+
+	logrus.WithContext(context.Background()).Info("hello world")
+	if strings.Contains(buf.String(), "requestID=") {
+		t.Errorf("expected log line without request context to not contain request id, got %q", buf.String())
+	}
+}
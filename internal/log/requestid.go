@@ -0,0 +1,64 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package log
+
+import (
+	"context"
+
+	"github.com/sirupsen/logrus"
+)
+
+type ctxKey string
+
+// requestIDKey is the context key used to store the ID of the request being served.
+const requestIDKey ctxKey = "requestID"
+
+// requestIDField is the name of the logrus field that request IDs are logged under.
+const requestIDField = "requestID"
+
+// WithRequestID returns a copy of ctx which carries the given request ID.
+//
+// Logrus entries created with logrus.WithContext(ctx) will include the request ID as a field.
+func WithRequestID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// RequestID returns the request ID carried by ctx, or an empty string if there isn't one.
+func RequestID(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// requestIDHook is a logrus hook that adds the request ID from the
+// context of an entry (if set) to the fields of that entry.
+type requestIDHook struct{}
+
+func (requestIDHook) Fire(e *logrus.Entry) error {
+	if e.Context == nil {
+		return nil
+	}
+	if id := RequestID(e.Context); id != "" {
+		e.Data[requestIDField] = id
+	}
+	return nil
+}
+
+func (requestIDHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
@@ -0,0 +1,131 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package log
+
+import (
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// sampleKey identifies a kind of message coming from a particular domain.
+type sampleKey struct {
+	domain string
+	what   string
+}
+
+// sample tracks how often a kind of message has been logged in the current period.
+type sample struct {
+	start      time.Time    // start of the current period
+	level      logrus.Level // level that the first message of the period was logged at
+	suppressed int          // number of messages not logged at their proper level in the current period
+}
+
+// Sampler collapses repeated, high-frequency log messages into periodic summaries, to
+// stop a single misbehaving remote from drowning everything else out of the logs.
+//
+// For each combination of domain and kind of message, only the first message in each
+// period is logged at the requested level. Any further messages in that period are logged
+// at debug level instead, and counted, so that a summary like "12 signature failures from
+// example.org in last 1m0s" can be logged at the requested level once the period is over.
+//
+// Summaries are logged the next time the sampler is used once their period has passed.
+type Sampler struct {
+	period    time.Duration
+	mu        sync.Mutex
+	samples   map[sampleKey]*sample
+	lastPrune time.Time
+}
+
+// NewSampler returns a new Sampler which samples messages over the given period.
+// If period is 0 or less, the sampler will log every message at the requested level.
+func NewSampler(period time.Duration) *Sampler {
+	return &Sampler{
+		period:    period,
+		samples:   make(map[sampleKey]*sample),
+		lastPrune: time.Now(),
+	}
+}
+
+// Logf logs the given message to l at the given level, unless a message of the same
+// kind, from the same domain, has already been logged at that level in the current period,
+// in which case the message is logged at debug level instead.
+//
+// what should be a short, plural description of the kind of message being
+// logged, such as "signature failures", which will be used in summaries.
+func (s *Sampler) Logf(l *logrus.Entry, level logrus.Level, domain string, what string, format string, args ...interface{}) {
+	if s.period <= 0 {
+		l.Logf(level, format, args...)
+		return
+	}
+
+	now := time.Now()
+	key := sampleKey{domain: domain, what: what}
+
+	s.mu.Lock()
+	s.prune(l.Logger, now)
+
+	smpl, ok := s.samples[key]
+	if ok && now.Sub(smpl.start) < s.period {
+		// we've already logged one of these this
+		// period, so only log this one at debug
+		smpl.suppressed++
+		s.mu.Unlock()
+		l.Debugf(format, args...)
+		return
+	}
+
+	if ok && smpl.suppressed > 0 {
+		s.summarize(l.Logger, key, smpl)
+	}
+	s.samples[key] = &sample{start: now, level: level}
+	s.mu.Unlock()
+
+	l.Logf(level, format, args...)
+}
+
+// prune removes samples whose period has passed, logging summaries for
+// them if necessary, so that the samples map doesn't grow forever.
+// It only does anything once per period. s.mu must be held by the caller.
+func (s *Sampler) prune(logger *logrus.Logger, now time.Time) {
+	if now.Sub(s.lastPrune) < s.period {
+		return
+	}
+	s.lastPrune = now
+
+	for key, smpl := range s.samples {
+		if now.Sub(smpl.start) < s.period {
+			continue
+		}
+		if smpl.suppressed > 0 {
+			s.summarize(logger, key, smpl)
+		}
+		delete(s.samples, key)
+	}
+}
+
+// summarize logs a summary of the given sample, counting the first message of the period as well as suppressed ones.
+func (s *Sampler) summarize(logger *logrus.Logger, key sampleKey, smpl *sample) {
+	logger.WithFields(logrus.Fields{
+		"domain":   key.domain,
+		"count":    smpl.suppressed + 1,
+		"sampling": s.period,
+	}).Logf(smpl.level, "%d %s from %s in last %s", smpl.suppressed+1, key.what, key.domain, s.period)
+}
@@ -0,0 +1,86 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package log_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/log"
+)
+
+func newSamplerTestLogger(buf *bytes.Buffer) *logrus.Entry {
+	logger := logrus.New()
+	logger.SetOutput(buf)
+	logger.SetLevel(logrus.DebugLevel)
+	logger.SetFormatter(&logrus.TextFormatter{DisableColors: true, DisableQuote: true, DisableTimestamp: true})
+	return logrus.NewEntry(logger)
+}
+
+func TestSamplerCollapsesRepeats(t *testing.T) {
+	var buf bytes.Buffer
+	l := newSamplerTestLogger(&buf)
+	sampler := log.NewSampler(200 * time.Millisecond)
+
+	for i := 0; i < 3; i++ {
+		sampler.Logf(l, logrus.WarnLevel, "example.org", "signature failures", "bad signature %d", i)
+	}
+	sampler.Logf(l, logrus.WarnLevel, "example.com", "signature failures", "bad signature from elsewhere")
+
+	got := buf.String()
+	if n := strings.Count(got, "level=warning"); n != 2 {
+		t.Errorf("expected 2 lines at warn level, got %d: %q", n, got)
+	}
+	if n := strings.Count(got, "level=debug"); n != 2 {
+		t.Errorf("expected 2 repeats at debug level, got %d: %q", n, got)
+	}
+	buf.Reset()
+
+	// once the period has passed, the next message should bring a summary of the last period with it
+	time.Sleep(250 * time.Millisecond)
+	sampler.Logf(l, logrus.WarnLevel, "example.org", "signature failures", "bad signature again")
+
+	got = buf.String()
+	if !strings.Contains(got, "3 signature failures from example.org in last 200ms") {
+		t.Errorf("expected summary of last period, got %q", got)
+	}
+	if strings.Contains(got, "from example.com in last") {
+		t.Errorf("expected no summary for domain without repeats, got %q", got)
+	}
+	if !strings.Contains(got, "level=warning msg=bad signature again") {
+		t.Errorf("expected new message to be logged at warn level, got %q", got)
+	}
+}
+
+func TestSamplerDisabled(t *testing.T) {
+	var buf bytes.Buffer
+	l := newSamplerTestLogger(&buf)
+	sampler := log.NewSampler(0)
+
+	for i := 0; i < 3; i++ {
+		sampler.Logf(l, logrus.ErrorLevel, "example.org", "signature failures", "bad signature %d", i)
+	}
+
+	if n := strings.Count(buf.String(), "level=error"); n != 3 {
+		t.Errorf("expected every message at error level with sampling disabled, got %d: %q", n, buf.String())
+	}
+}
@@ -0,0 +1,148 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package media
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// describeTimeout is the maximum amount of time we'll wait for a description provider to describe one image.
+const describeTimeout = 1 * time.Minute
+
+// DescriptionProvider generates a textual description of an image, for
+// example using OCR or an image captioning model, so that uploaded images
+// which weren't given a description by their uploader are more accessible.
+type DescriptionProvider interface {
+	// Describe returns a description of the image in data, which has the given content type.
+	Describe(ctx context.Context, data io.Reader, contentType string) (string, error)
+}
+
+type httpDescriptionProvider struct {
+	url    string
+	client *http.Client
+}
+
+// NewHTTPDescriptionProvider returns a DescriptionProvider which POSTs images to the given
+// url, and expects a JSON response of the form {"description": "some text"}.
+func NewHTTPDescriptionProvider(url string, client *http.Client) DescriptionProvider {
+	if client == nil {
+		client = &http.Client{Timeout: describeTimeout}
+	}
+	return &httpDescriptionProvider{
+		url:    url,
+		client: client,
+	}
+}
+
+func (h *httpDescriptionProvider) Describe(ctx context.Context, data io.Reader, contentType string) (string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, data)
+	if err != nil {
+		return "", fmt.Errorf("Describe: error creating request: %s", err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("Describe: error doing request: %s", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("Describe: provider returned status %s", resp.Status)
+	}
+
+	r := struct {
+		Description string `json:"description"`
+	}{}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, 64*1024)).Decode(&r); err != nil {
+		return "", fmt.Errorf("Describe: error decoding response: %s", err)
+	}
+
+	return r.Description, nil
+}
+
+// newDescriptionProvider returns a DescriptionProvider based on the current
+// configuration, or nil if automatic descriptions are not enabled.
+func newDescriptionProvider() (DescriptionProvider, error) {
+	if !viper.GetBool(config.Keys.MediaAutoDescribeEnabled) {
+		return nil, nil
+	}
+
+	url := viper.GetString(config.Keys.MediaAutoDescribeURL)
+	if url == "" {
+		return nil, errors.New("newDescriptionProvider: automatic media descriptions are enabled, but no provider url is set")
+	}
+
+	return NewHTTPDescriptionProvider(url, nil), nil
+}
+
+// describeAttachment asks the manager's description provider to describe the given attachment,
+// and stores the result as the attachment's description, unless it has been given one in the meantime.
+func (m *manager) describeAttachment(ctx context.Context, attachment *gtsmodel.MediaAttachment) error {
+	ctx, cancel := context.WithTimeout(ctx, describeTimeout)
+	defer cancel()
+
+	stored, err := m.storage.GetStream(attachment.File.Path)
+	if err != nil {
+		return fmt.Errorf("describeAttachment: error fetching file from storage: %s", err)
+	}
+	defer stored.Close()
+
+	description, err := m.describer.Describe(ctx, stored, attachment.File.ContentType)
+	if err != nil {
+		return fmt.Errorf("describeAttachment: error describing attachment %s: %s", attachment.ID, err)
+	}
+
+	description = strings.TrimSpace(description)
+	if description == "" {
+		logrus.Debugf("describeAttachment: provider returned no description for attachment %s", attachment.ID)
+		return nil
+	}
+
+	if maxChars := viper.GetInt(config.Keys.MediaDescriptionMaxChars); maxChars > 0 {
+		if runes := []rune(description); len(runes) > maxChars {
+			description = string(runes[:maxChars])
+		}
+	}
+
+	// only set the description if it's still empty, so that we
+	// never overwrite a description set by the uploader
+	if err := m.db.UpdateWhere(ctx, []db.Where{
+		{Key: "id", Value: attachment.ID},
+		{Key: "description", Value: ""},
+	}, "description", description, &gtsmodel.MediaAttachment{}); err != nil {
+		return fmt.Errorf("describeAttachment: error updating description of attachment %s: %s", attachment.ID, err)
+	}
+
+	return nil
+}
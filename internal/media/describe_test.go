@@ -0,0 +1,128 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package media_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
+)
+
+type DescribeTestSuite struct {
+	MediaStandardTestSuite
+}
+
+type errorDescriptionProvider struct{}
+
+func (errorDescriptionProvider) Describe(ctx context.Context, data io.Reader, contentType string) (string, error) {
+	return "", errors.New("provider unavailable")
+}
+
+func (suite *DescribeTestSuite) processJpeg(ai *media.AdditionalMediaInfo) *gtsmodel.MediaAttachment {
+	data := func(_ context.Context) (io.Reader, int, error) {
+		// load bytes from a test image
+		b, err := os.ReadFile("./test/test-jpeg.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return bytes.NewBuffer(b), len(b), nil
+	}
+
+	processingMedia, err := suite.manager.ProcessMedia(context.Background(), data, nil, "01FS1X72SK9ZPW0J1QQ68BD264", ai)
+	suite.NoError(err)
+
+	attachment, err := processingMedia.LoadAttachment(context.Background())
+	suite.NoError(err)
+	suite.NotNil(attachment)
+	return attachment
+}
+
+func (suite *DescribeTestSuite) TestDescribeHTTP() {
+	var contentType string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		contentType = r.Header.Get("Content-Type")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"description":"  a fluffy dog in the snow  "}`))
+	}))
+	defer server.Close()
+	suite.manager.SetDescriptionProvider(media.NewHTTPDescriptionProvider(server.URL, nil))
+
+	attachment := suite.processJpeg(nil)
+
+	// the description is generated in the background, so it shouldn't be on the returned attachment
+	suite.Empty(attachment.Description)
+
+	suite.Eventually(func() bool {
+		dbAttachment, err := suite.db.GetAttachmentByID(context.Background(), attachment.ID)
+		return err == nil && dbAttachment.Description == "a fluffy dog in the snow"
+	}, 5*time.Second, 10*time.Millisecond)
+	suite.Equal("image/jpeg", contentType)
+}
+
+func (suite *DescribeTestSuite) TestDescribeDoesNotOverwrite() {
+	called := make(chan struct{}, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called <- struct{}{}
+		_, _ = w.Write([]byte(`{"description":"a generated description"}`))
+	}))
+	defer server.Close()
+	suite.manager.SetDescriptionProvider(media.NewHTTPDescriptionProvider(server.URL, nil))
+
+	description := "my own description"
+	attachment := suite.processJpeg(&media.AdditionalMediaInfo{Description: &description})
+
+	// give the provider a chance to be (wrongly) called
+	select {
+	case <-called:
+		suite.FailNow("provider should not be called for an attachment with a description")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	dbAttachment, err := suite.db.GetAttachmentByID(context.Background(), attachment.ID)
+	suite.NoError(err)
+	suite.Equal("my own description", dbAttachment.Description)
+}
+
+func (suite *DescribeTestSuite) TestDescribeProviderError() {
+	suite.manager.SetDescriptionProvider(errorDescriptionProvider{})
+
+	attachment := suite.processJpeg(nil)
+
+	// wait a moment for the provider to fail in the background
+	time.Sleep(100 * time.Millisecond)
+
+	dbAttachment, err := suite.db.GetAttachmentByID(context.Background(), attachment.ID)
+	suite.NoError(err)
+	suite.Empty(dbAttachment.Description)
+	suite.Equal(gtsmodel.ProcessingStatusProcessed, dbAttachment.Processing)
+}
+
+func TestDescribeTestSuite(t *testing.T) {
+	suite.Run(t, &DescribeTestSuite{})
+}
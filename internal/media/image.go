@@ -20,22 +20,34 @@ package media
 
 import (
 	"bytes"
+	"encoding/binary"
 	"errors"
 	"fmt"
 	"image"
+	"image/draw"
 	"image/gif"
 	"image/jpeg"
 	"image/png"
 	"io"
+	"math"
 
 	"github.com/buckket/go-blurhash"
 	"github.com/nfnt/resize"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 )
 
-const (
-	thumbnailMaxWidth  = 512
-	thumbnailMaxHeight = 512
-)
+// defaultThumbnailMaxDimension is used when media-thumbnail-max-dimension is not set to a positive value.
+const defaultThumbnailMaxDimension = 512
+
+// ThumbnailMaxDimension returns the maximum width or height in pixels of image thumbnails
+// generated by this instance, taking the default into account if media-thumbnail-max-dimension isn't set.
+func ThumbnailMaxDimension() uint {
+	if configured := viper.GetInt(config.Keys.MediaThumbnailMaxDimension); configured > 0 {
+		return uint(configured)
+	}
+	return defaultThumbnailMaxDimension
+}
 
 type imageMeta struct {
 	width    int
@@ -46,6 +58,41 @@ type imageMeta struct {
 	small    []byte // defined only for calls to deriveStaticEmoji or deriveThumbnail
 }
 
+// checkImageDimensions decodes only the header of the given jpeg, png, or gif,
+// and returns an error if its dimensions exceed the configured maximum width/height
+// or total pixel count. This lets us reject pixel bombs without decoding them.
+func checkImageDimensions(r io.Reader, contentType string) error {
+	var cfg image.Config
+	var err error
+
+	switch contentType {
+	case mimeImageJpeg:
+		cfg, err = jpeg.DecodeConfig(r)
+	case mimeImagePng:
+		cfg, err = png.DecodeConfig(r)
+	case mimeImageGif:
+		cfg, err = gif.DecodeConfig(r)
+	default:
+		err = fmt.Errorf("content type %s not recognised", contentType)
+	}
+
+	if err != nil {
+		return fmt.Errorf("error decoding image header as %s: %s", contentType, err)
+	}
+
+	maxDimension := viper.GetInt(config.Keys.MediaImageMaxDimension)
+	if maxDimension > 0 && (cfg.Width > maxDimension || cfg.Height > maxDimension) {
+		return fmt.Errorf("image dimensions %dx%d exceed the maximum of %d pixels per side", cfg.Width, cfg.Height, maxDimension)
+	}
+
+	maxPixels := viper.GetInt(config.Keys.MediaImageMaxPixels)
+	if maxPixels > 0 && cfg.Width*cfg.Height > maxPixels {
+		return fmt.Errorf("image pixel count %d exceeds the maximum of %d pixels", cfg.Width*cfg.Height, maxPixels)
+	}
+
+	return nil
+}
+
 func decodeGif(r io.Reader) (*imageMeta, error) {
 	gif, err := gif.DecodeAll(r)
 	if err != nil {
@@ -132,7 +179,12 @@ func deriveThumbnail(r io.Reader, contentType string, createBlurhash bool) (*ima
 		return nil, errors.New("processed image was nil")
 	}
 
-	thumb := resize.Thumbnail(thumbnailMaxWidth, thumbnailMaxHeight, i, resize.NearestNeighbor)
+	maxDimension := ThumbnailMaxDimension()
+
+	// resize.Thumbnail preserves aspect ratio, and returns the
+	// original image unchanged if it already fits within the bounds,
+	// so small images are never upscaled
+	thumb := resize.Thumbnail(maxDimension, maxDimension, i, resize.NearestNeighbor)
 	width := thumb.Bounds().Size().X
 	height := thumb.Bounds().Size().Y
 	size := width * height
@@ -196,3 +248,126 @@ func deriveStaticEmoji(r io.Reader, contentType string) (*imageMeta, error) {
 		small: out.Bytes(),
 	}, nil
 }
+
+// resizeEmoji scales the given png or gif emoji down so that neither its width nor its height exceeds
+// maxDimension, preserving its aspect ratio and, for gifs, its animation. If maxDimension is not positive,
+// or the emoji already fits, the given bytes are returned unchanged.
+//
+// Animated pngs can't be scaled without losing all but their first frame, so an error is returned for those instead.
+func resizeEmoji(b []byte, contentType string, maxDimension int) ([]byte, error) {
+	if maxDimension <= 0 {
+		return b, nil
+	}
+
+	var cfg image.Config
+	var err error
+
+	switch contentType {
+	case mimeImagePng:
+		cfg, err = png.DecodeConfig(bytes.NewReader(b))
+	case mimeImageGif:
+		cfg, err = gif.DecodeConfig(bytes.NewReader(b))
+	default:
+		err = fmt.Errorf("content type %s not allowed for emoji", contentType)
+	}
+
+	if err != nil {
+		return nil, fmt.Errorf("error decoding emoji header as %s: %s", contentType, err)
+	}
+
+	if cfg.Width <= maxDimension && cfg.Height <= maxDimension {
+		return b, nil
+	}
+
+	if contentType == mimeImageGif {
+		scale := math.Min(float64(maxDimension)/float64(cfg.Width), float64(maxDimension)/float64(cfg.Height))
+		return resizeGif(b, scale)
+	}
+
+	if isAnimatedPng(b) {
+		return nil, fmt.Errorf("animated png emoji dimensions %dx%d exceed the maximum of %d pixels per side", cfg.Width, cfg.Height, maxDimension)
+	}
+
+	i, err := StrippedPngDecode(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding emoji as %s: %s", contentType, err)
+	}
+
+	// resize.Thumbnail preserves aspect ratio
+	resized := resize.Thumbnail(uint(maxDimension), uint(maxDimension), i, resize.Lanczos3)
+
+	out := &bytes.Buffer{}
+	if err := png.Encode(out, resized); err != nil {
+		return nil, fmt.Errorf("error encoding resized emoji: %s", err)
+	}
+	return out.Bytes(), nil
+}
+
+// resizeGif scales every frame of the given gif by the given factor, keeping the delays, disposal
+// methods and palettes of the frames as they were, so that animations still play the same way.
+func resizeGif(b []byte, scale float64) ([]byte, error) {
+	g, err := gif.DecodeAll(bytes.NewReader(b))
+	if err != nil {
+		return nil, fmt.Errorf("error decoding emoji as %s: %s", mimeImageGif, err)
+	}
+
+	g.Config.Width = scaleDimension(g.Config.Width, scale)
+	g.Config.Height = scaleDimension(g.Config.Height, scale)
+	bounds := image.Rect(0, 0, g.Config.Width, g.Config.Height)
+
+	for n, frame := range g.Image {
+		// frames can cover only part of the image, so their position has to be scaled too
+		r := frame.Bounds()
+		rect := image.Rect(
+			int(math.Floor(float64(r.Min.X)*scale)),
+			int(math.Floor(float64(r.Min.Y)*scale)),
+			int(math.Ceil(float64(r.Max.X)*scale)),
+			int(math.Ceil(float64(r.Max.Y)*scale)),
+		).Intersect(bounds)
+		if rect.Empty() {
+			rect = image.Rect(0, 0, 1, 1)
+		}
+
+		// nearest neighbor scaling doesn't blend pixels, so every pixel of the
+		// scaled frame still has a color from the palette of the original frame
+		scaled := resize.Resize(uint(rect.Dx()), uint(rect.Dy()), frame, resize.NearestNeighbor)
+		paletted := image.NewPaletted(rect, frame.Palette)
+		draw.Draw(paletted, rect, scaled, scaled.Bounds().Min, draw.Src)
+		g.Image[n] = paletted
+	}
+
+	out := &bytes.Buffer{}
+	if err := gif.EncodeAll(out, g); err != nil {
+		return nil, fmt.Errorf("error encoding resized emoji: %s", err)
+	}
+	return out.Bytes(), nil
+}
+
+// scaleDimension scales the given width or height by the given factor, without letting it drop to zero.
+func scaleDimension(dimension int, scale float64) int {
+	if scaled := int(math.Round(float64(dimension) * scale)); scaled > 0 {
+		return scaled
+	}
+	return 1
+}
+
+// isAnimatedPng returns true if the given png contains an animation control chunk, which
+// marks it as an apng. The chunk has to come before the image data, so we can stop looking there.
+func isAnimatedPng(b []byte) bool {
+	const signatureLength = 8
+
+	for i := signatureLength; i+8 <= len(b); {
+		length := int(binary.BigEndian.Uint32(b[i : i+4]))
+		chunkType := string(b[i+4 : i+8])
+		switch chunkType {
+		case "acTL":
+			return true
+		case "IDAT":
+			return false
+		}
+		// length, type, payload, crc
+		i += 4 + 4 + length + 4
+	}
+
+	return false
+}
@@ -20,18 +20,24 @@ package media
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"runtime"
 	"time"
 
 	"codeberg.org/gruf/go-store/kv"
-	"github.com/robfig/cron/v3"
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/worker"
 )
 
+// ErrQueueFull is returned when media can't be accepted for processing because too much
+// media is already waiting to be processed. Callers should try again a bit later.
+var ErrQueueFull = errors.New("media processing queue is full")
+
 // Manager provides an interface for managing media: parsing, storing, and retrieving media objects like photos, videos, and gifs.
 type Manager interface {
 	// ProcessMedia begins the process of decoding and storing the given data as an attachment.
@@ -47,6 +53,14 @@ type Manager interface {
 	//
 	// ai is optional and can be nil. Any additional information about the attachment provided will be put in the database.
 	ProcessMedia(ctx context.Context, data DataFunc, postData PostDataCallbackFunc, accountID string, ai *AdditionalMediaInfo) (*ProcessingMedia, error)
+	// ProcessMediaAsync is like ProcessMedia, except that it streams the given data into storage straight away,
+	// and puts a placeholder for the attachment in the database before queueing the rest of the processing.
+	// The placeholder, which has processing status 'received', is returned so that callers can respond
+	// without waiting for decoding and thumbnailing to finish.
+	//
+	// Once processing finishes, the placeholder will be updated with the processed attachment, or its
+	// processing status will be set to 'error' if processing fails.
+	ProcessMediaAsync(ctx context.Context, data DataFunc, postData PostDataCallbackFunc, accountID string, ai *AdditionalMediaInfo) (*gtsmodel.MediaAttachment, error)
 	// ProcessEmoji begins the process of decoding and storing the given data as an emoji.
 	// It will return a pointer to a ProcessingEmoji struct upon which further actions can be performed, such as getting
 	// the finished media, thumbnail, attachment, etc.
@@ -66,10 +80,28 @@ type Manager interface {
 	ProcessEmoji(ctx context.Context, data DataFunc, postData PostDataCallbackFunc, shortcode string, id string, uri string, ai *AdditionalEmojiInfo) (*ProcessingEmoji, error)
 	// RecacheMedia refetches, reprocesses, and recaches an existing attachment that has been uncached via pruneRemote.
 	RecacheMedia(ctx context.Context, data DataFunc, postData PostDataCallbackFunc, attachmentID string) (*ProcessingMedia, error)
+	// RefreshEmoji replaces the image of the existing emoji with the given ID by processing the given data, in the same
+	// way as ProcessEmoji. The emoji keeps its ID, and the rest of its fields are left alone. The old image is removed
+	// from storage once the new one has been stored and the emoji has been updated in the database.
+	RefreshEmoji(ctx context.Context, data DataFunc, postData PostDataCallbackFunc, emojiID string) (*ProcessingEmoji, error)
 	// PruneRemote prunes all remote media cached on this instance that's older than the given amount of days.
 	// 'Pruning' in this context means removing the locally stored data of the attachment (both thumbnail and full size),
 	// and setting 'cached' to false on the associated attachment.
 	PruneRemote(ctx context.Context, olderThanDays int) (int, error)
+	// PruneUnattached deletes all local media created before olderThan which isn't attached to any status,
+	// such as media uploaded for a status that was never posted. Both the stored data and the database entries
+	// of the attachments are removed. Avatars and headers are left alone. The number of pruned attachments is returned.
+	PruneUnattached(ctx context.Context, olderThan time.Time) (int, error)
+	// SetDescriptionProvider sets the provider used to automatically generate descriptions for uploaded images
+	// which don't have one. It overrides any provider set up from the config, and should be called before any
+	// media is processed. If provider is nil, descriptions will not be automatically generated.
+	SetDescriptionProvider(provider DescriptionProvider)
+	// QueueDepth returns the number of media items currently waiting to be processed.
+	// It is intended to be used for monitoring how backed up media processing is.
+	QueueDepth() int
+	// QueueSize returns the maximum number of media items which may be waiting to be processed
+	// before further media is rejected with ErrQueueFull.
+	QueueSize() int
 	// Stop stops the underlying worker pool of the manager. It should be called
 	// when closing GoToSocial in order to cleanly finish any in-progress jobs.
 	// It will block until workers are finished processing.
@@ -77,27 +109,53 @@ type Manager interface {
 }
 
 type manager struct {
-	db           db.DB
-	storage      *kv.KVStore
-	emojiWorker  *worker.Worker[*ProcessingEmoji]
-	mediaWorker  *worker.Worker[*ProcessingMedia]
-	stopCronJobs func() error
+	db          db.DB
+	storage     *kv.KVStore
+	emojiWorker *worker.Worker[*ProcessingEmoji]
+	mediaWorker *worker.Worker[*ProcessingMedia]
+	mediaSlots  chan struct{} // semaphore limiting how many media are processed at once
+	mediaQueue  int           // max amount of media allowed to wait in the worker queue
+
+	describer      DescriptionProvider // optional, used to generate descriptions for uploaded images
+	describeWorker *worker.Worker[*gtsmodel.MediaAttachment]
 }
 
 // NewManager returns a media manager with the given db and underlying storage.
 //
 // A worker pool will also be initialized for the manager, to ensure that only
 // a limited number of media will be processed in parallel. The numbers of workers
-// is determined from the $GOMAXPROCS environment variable (usually no. CPU cores).
+// is determined by media-processing-concurrency, or from the $GOMAXPROCS environment
+// variable (usually no. CPU cores) if that isn't set. The same limit also applies to
+// media that is loaded synchronously by callers of ProcessMedia or RecacheMedia.
 // See internal/worker.New() documentation for further information.
 func NewManager(database db.DB, storage *kv.KVStore) (Manager, error) {
+	concurrency := viper.GetInt(config.Keys.MediaProcessingConcurrency)
+	if concurrency < 1 {
+		concurrency = runtime.GOMAXPROCS(0)
+	}
+
+	queueSize := viper.GetInt(config.Keys.MediaProcessingQueueSize)
+	if queueSize < 1 {
+		queueSize = concurrency * 10
+	}
+
+	describer, err := newDescriptionProvider()
+	if err != nil {
+		return nil, err
+	}
+
 	m := &manager{
-		db:      database,
-		storage: storage,
+		db:         database,
+		storage:    storage,
+		mediaSlots: make(chan struct{}, concurrency),
+		mediaQueue: queueSize,
+		describer:  describer,
 	}
 
-	// Prepare the media worker pool
-	m.mediaWorker = worker.New[*ProcessingMedia](-1, 10)
+	// Prepare the media worker pool, making sure the
+	// underlying queue is at least as big as we need it
+	queueRatio := (queueSize + concurrency - 1) / concurrency
+	m.mediaWorker = worker.New[*ProcessingMedia](concurrency, queueRatio)
 	m.mediaWorker.SetProcessor(func(ctx context.Context, media *ProcessingMedia) error {
 		if err := ctx.Err(); err != nil {
 			return err
@@ -120,6 +178,17 @@ func NewManager(database db.DB, storage *kv.KVStore) (Manager, error) {
 		return nil
 	})
 
+	// Prepare the description worker pool; descriptions
+	// are generated by an external provider, so there's
+	// no point doing lots of them at once
+	m.describeWorker = worker.New[*gtsmodel.MediaAttachment](1, 100)
+	m.describeWorker.SetProcessor(func(ctx context.Context, attachment *gtsmodel.MediaAttachment) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		return m.describeAttachment(ctx, attachment)
+	})
+
 	// Start the worker pools
 	if err := m.mediaWorker.Start(); err != nil {
 		return nil, err
@@ -127,54 +196,8 @@ func NewManager(database db.DB, storage *kv.KVStore) (Manager, error) {
 	if err := m.emojiWorker.Start(); err != nil {
 		return nil, err
 	}
-
-	// start remote cache cleanup cronjob if configured
-	cacheCleanupDays := viper.GetInt(config.Keys.MediaRemoteCacheDays)
-	if cacheCleanupDays != 0 {
-		// we need a way of cancelling running jobs if the media manager is told to stop
-		pruneCtx, pruneCancel := context.WithCancel(context.Background())
-
-		// create a new cron instance and add a function to it
-		c := cron.New(cron.WithLogger(&logrusWrapper{}))
-
-		pruneFunc := func() {
-			begin := time.Now()
-			pruned, err := m.PruneRemote(pruneCtx, cacheCleanupDays)
-			if err != nil {
-				logrus.Errorf("media manager: error pruning remote cache: %s", err)
-				return
-			}
-			logrus.Infof("media manager: pruned %d remote cache entries in %s", pruned, time.Since(begin))
-		}
-
-		// run every night
-		entryID, err := c.AddFunc("@midnight", pruneFunc)
-		if err != nil {
-			pruneCancel()
-			return nil, fmt.Errorf("error starting media manager remote cache cleanup job: %s", err)
-		}
-
-		// since we're running a cron job, we should define how the manager should stop them
-		m.stopCronJobs = func() error {
-			// try to stop any jobs gracefully by waiting til they're finished
-			cronCtx := c.Stop()
-
-			select {
-			case <-cronCtx.Done():
-				logrus.Infof("media manager: cron finished jobs and stopped gracefully")
-			case <-time.After(1 * time.Minute):
-				logrus.Infof("media manager: cron didn't stop after 60 seconds, will force close")
-				break
-			}
-
-			// whether the job is finished neatly or we had to wait a minute, cancel the context on the prune job
-			pruneCancel()
-			return nil
-		}
-
-		// now start all the cron stuff we've lined up
-		c.Start()
-		logrus.Infof("media manager: next scheduled remote cache cleanup is %q", c.Entry(entryID).Next)
+	if err := m.describeWorker.Start(); err != nil {
+		return nil, err
 	}
 
 	return m, nil
@@ -185,10 +208,47 @@ func (m *manager) ProcessMedia(ctx context.Context, data DataFunc, postData Post
 	if err != nil {
 		return nil, err
 	}
-	m.mediaWorker.Queue(processingMedia)
+	if err := m.queueMedia(processingMedia); err != nil {
+		return nil, err
+	}
 	return processingMedia, nil
 }
 
+func (m *manager) ProcessMediaAsync(ctx context.Context, data DataFunc, postData PostDataCallbackFunc, accountID string, ai *AdditionalMediaInfo) (*gtsmodel.MediaAttachment, error) {
+	processingMedia, err := m.preProcessMedia(ctx, data, postData, accountID, ai)
+	if err != nil {
+		return nil, err
+	}
+
+	// nobody else has a reference to the processing media
+	// yet, so we don't need to hold the lock while storing
+	if err := processingMedia.store(ctx); err != nil {
+		return nil, err
+	}
+
+	processingMedia.placeholder = true
+	if err := m.db.Put(ctx, processingMedia.attachment); err != nil {
+		return nil, err
+	}
+
+	// take a copy of the placeholder before
+	// the media worker starts modifying it
+	placeholder := *processingMedia.attachment
+
+	if err := m.queueMedia(processingMedia); err != nil {
+		// we won't be processing this after all, so tidy up what we stored
+		if err := m.db.DeleteByID(ctx, placeholder.ID, &gtsmodel.MediaAttachment{}); err != nil {
+			logrus.Errorf("ProcessMediaAsync: error deleting placeholder attachment %s: %s", placeholder.ID, err)
+		}
+		if err := m.storage.Delete(placeholder.File.Path); err != nil {
+			logrus.Errorf("ProcessMediaAsync: error deleting stored file for attachment %s: %s", placeholder.ID, err)
+		}
+		return nil, err
+	}
+
+	return &placeholder, nil
+}
+
 func (m *manager) ProcessEmoji(ctx context.Context, data DataFunc, postData PostDataCallbackFunc, shortcode string, id string, uri string, ai *AdditionalEmojiInfo) (*ProcessingEmoji, error) {
 	processingEmoji, err := m.preProcessEmoji(ctx, data, postData, shortcode, id, uri, ai)
 	if err != nil {
@@ -198,31 +258,75 @@ func (m *manager) ProcessEmoji(ctx context.Context, data DataFunc, postData Post
 	return processingEmoji, nil
 }
 
+func (m *manager) RefreshEmoji(ctx context.Context, data DataFunc, postData PostDataCallbackFunc, emojiID string) (*ProcessingEmoji, error) {
+	processingEmoji, err := m.preProcessEmojiRefresh(ctx, data, postData, emojiID)
+	if err != nil {
+		return nil, err
+	}
+	m.emojiWorker.Queue(processingEmoji)
+	return processingEmoji, nil
+}
+
 func (m *manager) RecacheMedia(ctx context.Context, data DataFunc, postData PostDataCallbackFunc, attachmentID string) (*ProcessingMedia, error) {
 	processingRecache, err := m.preProcessRecache(ctx, data, postData, attachmentID)
 	if err != nil {
 		return nil, err
 	}
-	m.mediaWorker.Queue(processingRecache)
+	if err := m.queueMedia(processingRecache); err != nil {
+		return nil, err
+	}
 	return processingRecache, nil
 }
 
-func (m *manager) Stop() error {
-	// Stop media and emoji worker pools
-	mediaErr := m.mediaWorker.Stop()
-	emojiErr := m.emojiWorker.Stop()
+func (m *manager) SetDescriptionProvider(provider DescriptionProvider) {
+	m.describer = provider
+}
+
+func (m *manager) QueueDepth() int {
+	return m.mediaWorker.Queued()
+}
+
+func (m *manager) QueueSize() int {
+	return m.mediaQueue
+}
+
+// queueMedia queues the given media for processing by the media worker,
+// or returns ErrQueueFull if too much media is already waiting.
+func (m *manager) queueMedia(processingMedia *ProcessingMedia) error {
+	if depth := m.mediaWorker.Queued(); depth >= m.mediaQueue {
+		logrus.Warnf("media manager: rejecting media %s, processing queue is full (depth=%d)", processingMedia.AttachmentID(), depth)
+		return ErrQueueFull
+	}
+	if !m.mediaWorker.QueueNoBlock(processingMedia) {
+		return ErrQueueFull
+	}
+	return nil
+}
 
-	var cronErr error
+// queueDescribe queues the given attachment to have a description generated for it, if the
+// manager has a description provider and the attachment is a local image with no description.
+func (m *manager) queueDescribe(attachment *gtsmodel.MediaAttachment) {
+	if m.describer == nil || attachment.Description != "" || attachment.RemoteURL != "" || attachment.Avatar || attachment.Header {
+		return
+	}
 
-	if m.stopCronJobs != nil {
-		// only set if cache prune age > 0
-		cronErr = m.stopCronJobs()
+	// take a copy, since the caller may still be using the attachment
+	a := *attachment
+	if !m.describeWorker.QueueNoBlock(&a) {
+		logrus.Warnf("media manager: not generating description for attachment %s, description queue is full", a.ID)
 	}
+}
+
+func (m *manager) Stop() error {
+	// Stop media, emoji and description worker pools
+	mediaErr := m.mediaWorker.Stop()
+	emojiErr := m.emojiWorker.Stop()
+	describeErr := m.describeWorker.Stop()
 
 	if mediaErr != nil {
 		return mediaErr
 	} else if emojiErr != nil {
 		return emojiErr
 	}
-	return cronErr
+	return describeErr
 }
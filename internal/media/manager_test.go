@@ -21,6 +21,7 @@ package media_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"os"
@@ -30,9 +31,13 @@ import (
 
 	"codeberg.org/gruf/go-store/kv"
 	"codeberg.org/gruf/go-store/storage"
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	gtsmodel "github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type ManagerTestSuite struct {
@@ -587,6 +592,197 @@ func (suite *ManagerTestSuite) TestSimpleJpegProcessBlockingWithDiskStorage() {
 	suite.Equal(processedThumbnailBytesExpected, processedThumbnailBytes)
 }
 
+func (suite *ManagerTestSuite) TestJpegTooManyPixels() {
+	ctx := context.Background()
+
+	// the test jpeg is 1920x1080, so 2073600 pixels in total
+	viper.Set(config.Keys.MediaImageMaxPixels, 2073599)
+	defer viper.Set(config.Keys.MediaImageMaxPixels, testrig.TestDefaults.MediaImageMaxPixels)
+
+	data := func(_ context.Context) (io.Reader, int, error) {
+		// load bytes from a test image
+		b, err := os.ReadFile("./test/test-jpeg.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return bytes.NewBuffer(b), len(b), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	processingMedia, err := suite.manager.ProcessMedia(ctx, data, nil, accountID, nil)
+	suite.NoError(err)
+	attachmentID := processingMedia.AttachmentID()
+
+	// loading the attachment should fail before the image is decoded
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.EqualError(err, "store: image pixel count 2073600 exceeds the maximum of 2073599 pixels")
+	suite.Nil(attachment)
+
+	// nothing should have been put in the database
+	dbAttachment, err := suite.db.GetAttachmentByID(ctx, attachmentID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	suite.Nil(dbAttachment)
+}
+
+func (suite *ManagerTestSuite) TestPngTooWide() {
+	ctx := context.Background()
+
+	viper.Set(config.Keys.MediaImageMaxDimension, 100)
+	defer viper.Set(config.Keys.MediaImageMaxDimension, testrig.TestDefaults.MediaImageMaxDimension)
+
+	data := func(_ context.Context) (io.Reader, int, error) {
+		// load bytes from a test image
+		b, err := os.ReadFile("./test/test-png-noalphachannel.png")
+		if err != nil {
+			panic(err)
+		}
+		return bytes.NewBuffer(b), len(b), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	processingMedia, err := suite.manager.ProcessMedia(ctx, data, nil, accountID, nil)
+	suite.NoError(err)
+
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.ErrorContains(err, "exceed the maximum of 100 pixels per side")
+	suite.Nil(attachment)
+}
+
+func (suite *ManagerTestSuite) TestJpegCustomThumbnailSize() {
+	ctx := context.Background()
+
+	viper.Set(config.Keys.MediaThumbnailMaxDimension, 256)
+	defer viper.Set(config.Keys.MediaThumbnailMaxDimension, testrig.TestDefaults.MediaThumbnailMaxDimension)
+
+	data := func(_ context.Context) (io.Reader, int, error) {
+		// load bytes from a test image
+		b, err := os.ReadFile("./test/test-jpeg.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return bytes.NewBuffer(b), len(b), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	processingMedia, err := suite.manager.ProcessMedia(ctx, data, nil, accountID, nil)
+	suite.NoError(err)
+
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// thumbnail should fit within 256px while keeping the original aspect ratio
+	suite.EqualValues(gtsmodel.Original{
+		Width: 1920, Height: 1080, Size: 2073600, Aspect: 1.7777777777777777,
+	}, attachment.FileMeta.Original)
+	suite.EqualValues(gtsmodel.Small{
+		Width: 256, Height: 144, Size: 36864, Aspect: 1.7777777777777777,
+	}, attachment.FileMeta.Small)
+}
+
+func (suite *ManagerTestSuite) TestJpegThumbnailNotUpscaled() {
+	ctx := context.Background()
+
+	viper.Set(config.Keys.MediaThumbnailMaxDimension, 4096)
+	defer viper.Set(config.Keys.MediaThumbnailMaxDimension, testrig.TestDefaults.MediaThumbnailMaxDimension)
+
+	data := func(_ context.Context) (io.Reader, int, error) {
+		// load bytes from a test image
+		b, err := os.ReadFile("./test/test-jpeg.jpg")
+		if err != nil {
+			panic(err)
+		}
+		return bytes.NewBuffer(b), len(b), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	processingMedia, err := suite.manager.ProcessMedia(ctx, data, nil, accountID, nil)
+	suite.NoError(err)
+
+	attachment, err := processingMedia.LoadAttachment(ctx)
+	suite.NoError(err)
+	suite.NotNil(attachment)
+
+	// original is smaller than the max thumbnail size, so the thumbnail should be the same size as the original
+	suite.EqualValues(gtsmodel.Small{
+		Width: 1920, Height: 1080, Size: 2073600, Aspect: 1.7777777777777777,
+	}, attachment.FileMeta.Small)
+}
+
+func (suite *ManagerTestSuite) TestQueueFull() {
+	ctx := context.Background()
+
+	// only allow one media to be processed + one to wait at a time
+	viper.Set(config.Keys.MediaProcessingConcurrency, 1)
+	viper.Set(config.Keys.MediaProcessingQueueSize, 1)
+	defer func() {
+		viper.Set(config.Keys.MediaProcessingConcurrency, testrig.TestDefaults.MediaProcessingConcurrency)
+		viper.Set(config.Keys.MediaProcessingQueueSize, testrig.TestDefaults.MediaProcessingQueueSize)
+	}()
+
+	manager := testrig.NewTestMediaManager(suite.db, suite.storage)
+	defer func() {
+		suite.NoError(manager.Stop())
+	}()
+
+	b, err := os.ReadFile("./test/test-jpeg.jpg")
+	if err != nil {
+		panic(err)
+	}
+
+	started := make(chan struct{})
+	release := make(chan struct{})
+	blockingData := func(_ context.Context) (io.Reader, int, error) {
+		// hold up the only worker until we're done queueing
+		close(started)
+		<-release
+		return bytes.NewReader(b), len(b), nil
+	}
+
+	data := func(_ context.Context) (io.Reader, int, error) {
+		return bytes.NewReader(b), len(b), nil
+	}
+
+	accountID := "01FS1X72SK9ZPW0J1QQ68BD264"
+
+	// the first media is picked up by the worker straight away
+	first, err := manager.ProcessMedia(ctx, blockingData, nil, accountID, nil)
+	suite.NoError(err)
+	<-started
+
+	// keep queueing media until the queue is full; depending on whether the
+	// worker pool has already pulled the next media off the queue to wait
+	// for a free worker, this should happen on the second or third attempt
+	accepted := []*media.ProcessingMedia{first}
+	var rejected bool
+	for i := 0; i < 4 && !rejected; i++ {
+		processingMedia, err := manager.ProcessMedia(ctx, data, nil, accountID, nil)
+		if errors.Is(err, media.ErrQueueFull) {
+			suite.Nil(processingMedia)
+			rejected = true
+			break
+		}
+		suite.NoError(err)
+		accepted = append(accepted, processingMedia)
+	}
+	suite.True(rejected)
+	suite.LessOrEqual(len(accepted), 3)
+	suite.Equal(1, manager.QueueDepth())
+
+	// once we let processing continue, all accepted media should load fine
+	close(release)
+
+	for _, processingMedia := range accepted {
+		attachment, err := processingMedia.LoadAttachment(ctx)
+		suite.NoError(err)
+		suite.NotNil(attachment)
+	}
+}
+
 func TestManagerTestSuite(t *testing.T) {
 	suite.Run(t, &ManagerTestSuite{})
 }
@@ -29,9 +29,13 @@ import (
 	"time"
 
 	"codeberg.org/gruf/go-store/kv"
+	"codeberg.org/gruf/go-store/storage"
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
@@ -51,7 +55,8 @@ type ProcessingEmoji struct {
 	emoji    *gtsmodel.Emoji
 	data     DataFunc
 	postData PostDataCallbackFunc
-	read     bool // bool indicating that data function has been triggered already
+	read     bool   // bool indicating that data function has been triggered already
+	fileID   string // name under which the image files of the emoji are stored, without extension
 
 	/*
 		below fields represent the processing state of the static of the emoji
@@ -70,6 +75,12 @@ type ProcessingEmoji struct {
 
 	// track whether this emoji has already been put in the databse
 	insertedInDB bool
+
+	// true if this is a refresh of the image of an existing emoji, false if it's a brand new emoji
+	refresh bool
+	// storage paths of the image files being replaced by a refresh, to be removed once it's done
+	oldImagePath       string
+	oldImageStaticPath string
 }
 
 // EmojiID returns the ID of the underlying emoji without blocking processing.
@@ -93,8 +104,16 @@ func (p *ProcessingEmoji) LoadEmoji(ctx context.Context) (*gtsmodel.Emoji, error
 
 	// store the result in the database before returning it
 	if !p.insertedInDB {
-		if err := p.database.Put(ctx, p.emoji); err != nil {
-			return nil, err
+		if p.refresh {
+			// if it's a refresh, the emoji is already in the database, so we just need to update it
+			if _, err := p.database.UpdateEmoji(ctx, p.emoji); err != nil {
+				return nil, err
+			}
+			p.removeOldImages()
+		} else {
+			if err := p.database.PutEmoji(ctx, p.emoji); err != nil {
+				return nil, err
+			}
 		}
 		p.insertedInDB = true
 	}
@@ -102,6 +121,19 @@ func (p *ProcessingEmoji) LoadEmoji(ctx context.Context) (*gtsmodel.Emoji, error
 	return p.emoji, nil
 }
 
+// removeOldImages removes the image files replaced by a refresh from storage. Failing to do so
+// is only logged, since the emoji itself has already been successfully updated by then.
+func (p *ProcessingEmoji) removeOldImages() {
+	for _, path := range []string{p.oldImagePath, p.oldImageStaticPath} {
+		if path == "" || path == p.emoji.ImagePath || path == p.emoji.ImageStaticPath {
+			continue
+		}
+		if err := p.storage.Delete(path); err != nil && err != storage.ErrNotFound {
+			logrus.Errorf("removeOldImages: error removing old image %s of emoji %s: %s", path, p.emoji.ID, err)
+		}
+	}
+}
+
 // Finished returns true if processing has finished for both the thumbnail
 // and full fized version of this piece of media.
 func (p *ProcessingEmoji) Finished() bool {
@@ -156,8 +188,9 @@ func (p *ProcessingEmoji) loadStatic(ctx context.Context) error {
 }
 
 // store calls the data function attached to p if it hasn't been called yet,
-// and updates the underlying attachment fields as necessary. It will then stream
-// bytes from p's reader directly into storage so that it can be retrieved later.
+// and updates the underlying attachment fields as necessary. Emojis over the configured
+// maximum size are rejected, and emojis over the configured maximum dimension are scaled
+// down, before being put in storage so that they can be retrieved later.
 func (p *ProcessingEmoji) store(ctx context.Context) error {
 	// check if we've already done this and bail early if we have
 	if p.read {
@@ -165,7 +198,7 @@ func (p *ProcessingEmoji) store(ctx context.Context) error {
 	}
 
 	// execute the data function to get the reader out of it
-	reader, fileSize, err := p.data(ctx)
+	reader, _, err := p.data(ctx)
 	if err != nil {
 		return fmt.Errorf("store: error executing data function: %s", err)
 	}
@@ -179,10 +212,26 @@ func (p *ProcessingEmoji) store(ctx context.Context) error {
 		}
 	}()
 
+	// emojis are small, so read the whole thing into memory, which lets us resize it if we need to;
+	// read at most one byte more than the limit, which is enough to tell if the limit is exceeded
+	maxSize := viper.GetInt(config.Keys.MediaEmojiMaxSize)
+	if maxSize > 0 {
+		reader = io.LimitReader(reader, int64(maxSize)+1)
+	}
+
+	b, err := io.ReadAll(reader)
+	if err != nil {
+		return fmt.Errorf("store: error reading emoji: %s", err)
+	}
+
+	if maxSize > 0 && len(b) > maxSize {
+		return fmt.Errorf("store: emoji exceeds the maximum size of %d bytes", maxSize)
+	}
+
 	// extract no more than 261 bytes from the beginning of the file -- this is the header
-	firstBytes := make([]byte, maxFileHeaderBytes)
-	if _, err := reader.Read(firstBytes); err != nil {
-		return fmt.Errorf("store: error reading initial %d bytes: %s", maxFileHeaderBytes, err)
+	firstBytes := b
+	if len(firstBytes) > maxFileHeaderBytes {
+		firstBytes = firstBytes[:maxFileHeaderBytes]
 	}
 
 	// now we have the file header we can work out the content type from it
@@ -196,6 +245,17 @@ func (p *ProcessingEmoji) store(ctx context.Context) error {
 		return fmt.Errorf("store: content type %s was not valid for an emoji", contentType)
 	}
 
+	// make sure this isn't a pixel bomb before we try to decode the whole thing
+	if err := checkImageDimensions(bytes.NewReader(b), contentType); err != nil {
+		return fmt.Errorf("store: %s", err)
+	}
+
+	// scale the emoji down if it's larger than we allow
+	b, err = resizeEmoji(b, contentType, viper.GetInt(config.Keys.MediaEmojiMaxDimension))
+	if err != nil {
+		return fmt.Errorf("store: error resizing emoji: %s", err)
+	}
+
 	// extract the file extension
 	split := strings.Split(contentType, "/")
 	extension := split[1] // something like 'gif'
@@ -203,16 +263,13 @@ func (p *ProcessingEmoji) store(ctx context.Context) error {
 	// set some additional fields on the emoji now that
 	// we know more about what the underlying image actually is
 	p.emoji.ImageURL = uris.GenerateURIForAttachment(p.instanceAccountID, string(TypeEmoji), string(SizeOriginal), p.emoji.ID, extension)
-	p.emoji.ImagePath = fmt.Sprintf("%s/%s/%s/%s.%s", p.instanceAccountID, TypeEmoji, SizeOriginal, p.emoji.ID, extension)
+	p.emoji.ImagePath = fmt.Sprintf("%s/%s/%s/%s.%s", p.instanceAccountID, TypeEmoji, SizeOriginal, p.fileID, extension)
 	p.emoji.ImageContentType = contentType
-	p.emoji.ImageFileSize = fileSize
-
-	// concatenate the first bytes with the existing bytes still in the reader (thanks Mara)
-	multiReader := io.MultiReader(bytes.NewBuffer(firstBytes), reader)
+	p.emoji.ImageFileSize = len(b)
 
 	// store this for now -- other processes can pull it out of storage as they please
-	if err := p.storage.PutStream(p.emoji.ImagePath, multiReader); err != nil {
-		return fmt.Errorf("store: error storing stream: %s", err)
+	if err := p.storage.Put(p.emoji.ImagePath, b); err != nil {
+		return fmt.Errorf("store: error storing emoji: %s", err)
 	}
 
 	p.read = true
@@ -284,6 +341,10 @@ func (m *manager) preProcessEmoji(ctx context.Context, data DataFunc, postData P
 		if ai.CategoryID != nil {
 			emoji.CategoryID = *ai.CategoryID
 		}
+
+		if ai.Category != nil {
+			emoji.Category = *ai.Category
+		}
 	}
 
 	processingEmoji := &ProcessingEmoji{
@@ -291,6 +352,7 @@ func (m *manager) preProcessEmoji(ctx context.Context, data DataFunc, postData P
 		emoji:             emoji,
 		data:              data,
 		postData:          postData,
+		fileID:            id,
 		staticState:       int32(received),
 		database:          m.db,
 		storage:           m.storage,
@@ -298,3 +360,44 @@ func (m *manager) preProcessEmoji(ctx context.Context, data DataFunc, postData P
 
 	return processingEmoji, nil
 }
+
+func (m *manager) preProcessEmojiRefresh(ctx context.Context, data DataFunc, postData PostDataCallbackFunc, emojiID string) (*ProcessingEmoji, error) {
+	instanceAccount, err := m.db.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("preProcessEmojiRefresh: error fetching this instance account from the db: %s", err)
+	}
+
+	// get the existing emoji
+	emoji, err := m.db.GetEmojiByID(ctx, emojiID)
+	if err != nil {
+		return nil, err
+	}
+
+	// store the new image files under a new name, since storage won't let us
+	// overwrite the old ones; the emoji id in the urls stays the same though
+	fileID, err := id.NewRandomULID()
+	if err != nil {
+		return nil, err
+	}
+
+	oldImagePath := emoji.ImagePath
+	oldImageStaticPath := emoji.ImageStaticPath
+	emoji.ImageStaticPath = fmt.Sprintf("%s/%s/%s/%s.%s", instanceAccount.ID, TypeEmoji, SizeStatic, fileID, mimePng)
+	emoji.ImageUpdatedAt = time.Now()
+
+	processingEmoji := &ProcessingEmoji{
+		instanceAccountID:  instanceAccount.ID,
+		emoji:              emoji,
+		data:               data,
+		postData:           postData,
+		fileID:             fileID,
+		staticState:        int32(received),
+		database:           m.db,
+		storage:            m.storage,
+		refresh:            true, // indicate it's a refresh
+		oldImagePath:       oldImagePath,
+		oldImageStaticPath: oldImageStaticPath,
+	}
+
+	return processingEmoji, nil
+}
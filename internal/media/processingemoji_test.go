@@ -0,0 +1,194 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package media_test
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/gif"
+	"image/png"
+	"io"
+	"os"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ProcessingEmojiTestSuite struct {
+	MediaStandardTestSuite
+}
+
+func bytesDataFunc(b []byte) func(context.Context) (io.Reader, int, error) {
+	return func(_ context.Context) (io.Reader, int, error) {
+		return bytes.NewReader(b), len(b), nil
+	}
+}
+
+func (suite *ProcessingEmojiTestSuite) TestEmojiPngResized() {
+	ctx := context.Background()
+
+	// make a 256x64 png, which is too wide for the default max dimension of 128
+	i := image.NewNRGBA(image.Rect(0, 0, 256, 64))
+	for x := 0; x < 256; x++ {
+		for y := 0; y < 64; y++ {
+			i.Set(x, y, color.NRGBA{R: uint8(x), G: uint8(y), B: 255, A: 255})
+		}
+	}
+	b := &bytes.Buffer{}
+	suite.NoError(png.Encode(b, i))
+
+	emojiID := "01GEQ5XM9JTGV8S4ZP5Z3NHJNZ"
+	processingEmoji, err := suite.manager.ProcessEmoji(ctx, bytesDataFunc(b.Bytes()), nil, "wide_boi", emojiID, "http://localhost:8080/emoji/"+emojiID, nil)
+	suite.NoError(err)
+
+	emoji, err := processingEmoji.LoadEmoji(ctx)
+	suite.NoError(err)
+	suite.Equal("image/png", emoji.ImageContentType)
+
+	stored, err := suite.storage.Get(emoji.ImagePath)
+	suite.NoError(err)
+	suite.Len(stored, emoji.ImageFileSize)
+
+	// aspect ratio should be preserved
+	cfg, err := png.DecodeConfig(bytes.NewReader(stored))
+	suite.NoError(err)
+	suite.Equal(128, cfg.Width)
+	suite.Equal(32, cfg.Height)
+}
+
+func (suite *ProcessingEmojiTestSuite) TestEmojiGifResizedKeepsAnimation() {
+	ctx := context.Background()
+
+	// make a 200x200 gif with two frames, the second of which only covers the bottom right corner
+	frames := []*image.Paletted{
+		image.NewPaletted(image.Rect(0, 0, 200, 200), palette.Plan9),
+		image.NewPaletted(image.Rect(100, 100, 200, 200), palette.Plan9),
+	}
+	for n, frame := range frames {
+		for x := frame.Rect.Min.X; x < frame.Rect.Max.X; x++ {
+			for y := frame.Rect.Min.Y; y < frame.Rect.Max.Y; y++ {
+				frame.SetColorIndex(x, y, uint8(n*100+x%50))
+			}
+		}
+	}
+	b := &bytes.Buffer{}
+	suite.NoError(gif.EncodeAll(b, &gif.GIF{
+		Image:    frames,
+		Delay:    []int{10, 20},
+		Disposal: []byte{gif.DisposalNone, gif.DisposalBackground},
+	}))
+
+	emojiID := "01GEQ61GX6YR3TW2TYCWSBK4K1"
+	processingEmoji, err := suite.manager.ProcessEmoji(ctx, bytesDataFunc(b.Bytes()), nil, "spinny_boi", emojiID, "http://localhost:8080/emoji/"+emojiID, nil)
+	suite.NoError(err)
+
+	emoji, err := processingEmoji.LoadEmoji(ctx)
+	suite.NoError(err)
+	suite.Equal("image/gif", emoji.ImageContentType)
+
+	stored, err := suite.storage.Get(emoji.ImagePath)
+	suite.NoError(err)
+
+	g, err := gif.DecodeAll(bytes.NewReader(stored))
+	suite.NoError(err)
+	suite.Equal(128, g.Config.Width)
+	suite.Equal(128, g.Config.Height)
+
+	// the animation should be intact, with the frames scaled along with the image
+	suite.Len(g.Image, 2)
+	suite.Equal([]int{10, 20}, g.Delay)
+	suite.Equal([]byte{gif.DisposalNone, gif.DisposalBackground}, g.Disposal)
+	suite.Equal(image.Rect(0, 0, 128, 128), g.Image[0].Bounds())
+	suite.Equal(image.Rect(64, 64, 128, 128), g.Image[1].Bounds())
+}
+
+func (suite *ProcessingEmojiTestSuite) TestEmojiNotUpscaled() {
+	ctx := context.Background()
+
+	// the test emoji is 127x128, which fits within the default max dimension already
+	b, err := os.ReadFile("./test/rainbow-original.png")
+	if err != nil {
+		panic(err)
+	}
+
+	emojiID := "01GEQ64MFCRQ9XGWQRMQHCN4HS"
+	processingEmoji, err := suite.manager.ProcessEmoji(ctx, bytesDataFunc(b), nil, "rainbow_again", emojiID, "http://localhost:8080/emoji/"+emojiID, nil)
+	suite.NoError(err)
+
+	emoji, err := processingEmoji.LoadEmoji(ctx)
+	suite.NoError(err)
+	suite.Equal(len(b), emoji.ImageFileSize)
+}
+
+func (suite *ProcessingEmojiTestSuite) TestEmojiAnimatedPngTooLarge() {
+	ctx := context.Background()
+
+	viper.Set(config.Keys.MediaEmojiMaxDimension, 64)
+	defer viper.Set(config.Keys.MediaEmojiMaxDimension, testrig.TestDefaults.MediaEmojiMaxDimension)
+
+	// the test emoji is an animated png, which can't be scaled down
+	b, err := os.ReadFile("./test/rainbow-original.png")
+	if err != nil {
+		panic(err)
+	}
+
+	emojiID := "01GEQ67JYBFNHHQ5QW9Z2Y2PMF"
+	processingEmoji, err := suite.manager.ProcessEmoji(ctx, bytesDataFunc(b), nil, "rainbow_again", emojiID, "http://localhost:8080/emoji/"+emojiID, nil)
+	suite.NoError(err)
+
+	emoji, err := processingEmoji.LoadEmoji(ctx)
+	suite.EqualError(err, "store: error resizing emoji: animated png emoji dimensions 127x128 exceed the maximum of 64 pixels per side")
+	suite.Nil(emoji)
+
+	// nothing should have been put in the database
+	_, err = suite.db.GetEmojiByID(ctx, emojiID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+}
+
+func (suite *ProcessingEmojiTestSuite) TestEmojiTooBig() {
+	ctx := context.Background()
+
+	viper.Set(config.Keys.MediaEmojiMaxSize, 10000)
+	defer viper.Set(config.Keys.MediaEmojiMaxSize, testrig.TestDefaults.MediaEmojiMaxSize)
+
+	// the test emoji is 36702 bytes
+	b, err := os.ReadFile("./test/rainbow-original.png")
+	if err != nil {
+		panic(err)
+	}
+
+	emojiID := "01GEQ6AH2NNP7JW0Y9Q4QHMV6S"
+	processingEmoji, err := suite.manager.ProcessEmoji(ctx, bytesDataFunc(b), nil, "rainbow_again", emojiID, "http://localhost:8080/emoji/"+emojiID, nil)
+	suite.NoError(err)
+
+	emoji, err := processingEmoji.LoadEmoji(ctx)
+	suite.EqualError(err, "store: emoji exceeds the maximum size of 10000 bytes")
+	suite.Nil(emoji)
+}
+
+func TestProcessingEmojiTestSuite(t *testing.T) {
+	suite.Run(t, &ProcessingEmojiTestSuite{})
+}
@@ -62,6 +62,7 @@ type ProcessingMedia struct {
 
 	database db.DB
 	storage  *kv.KVStore
+	slots    chan struct{} // shared semaphore limiting concurrent processing
 
 	err error // error created during processing, if any
 
@@ -70,6 +71,14 @@ type ProcessingMedia struct {
 
 	// true if this is a recache, false if it's brand new media
 	recache bool
+
+	// true if a placeholder for this media has already
+	// been put in the database, so it only needs updating
+	placeholder bool
+
+	// called once the processed media has been stored in
+	// the database, to generate a description for it if needed
+	describe func(*gtsmodel.MediaAttachment)
 }
 
 // AttachmentID returns the ID of the underlying media attachment without blocking processing.
@@ -85,22 +94,31 @@ func (p *ProcessingMedia) LoadAttachment(ctx context.Context) (*gtsmodel.MediaAt
 	defer p.mu.Unlock()
 	logrus.Tracef("LoadAttachment: got lock for attachment %s", p.attachment.URL)
 
-	if err := p.store(ctx); err != nil {
-		return nil, err
-	}
-
-	if err := p.loadThumb(ctx); err != nil {
-		return nil, err
+	if !p.Finished() {
+		// wait for a free processing slot before doing any heavy lifting
+		select {
+		case p.slots <- struct{}{}:
+			defer func() { <-p.slots }()
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
 	}
 
-	if err := p.loadFullSize(ctx); err != nil {
+	if err := p.load(ctx); err != nil {
+		if p.placeholder && p.attachment.Processing != gtsmodel.ProcessingStatusError {
+			// let anyone looking at the placeholder know it's not going to be processed
+			p.attachment.Processing = gtsmodel.ProcessingStatusError
+			if dbErr := p.database.UpdateByPrimaryKey(ctx, p.attachment); dbErr != nil {
+				logrus.Errorf("LoadAttachment: error marking placeholder attachment %s as errored: %s", p.attachment.ID, dbErr)
+			}
+		}
 		return nil, err
 	}
 
 	// store the result in the database before returning it
 	if !p.insertedInDB {
-		if p.recache {
-			// if it's a recache we should only need to update
+		if p.recache || p.placeholder {
+			// if it's a recache or we already put a placeholder, we should only need to update
 			if err := p.database.UpdateByPrimaryKey(ctx, p.attachment); err != nil {
 				return nil, err
 			}
@@ -111,12 +129,29 @@ func (p *ProcessingMedia) LoadAttachment(ctx context.Context) (*gtsmodel.MediaAt
 			}
 		}
 		p.insertedInDB = true
+
+		if p.describe != nil {
+			p.describe(p.attachment)
+		}
 	}
 
 	logrus.Tracef("LoadAttachment: finished, returning attachment %s", p.attachment.URL)
 	return p.attachment, nil
 }
 
+// load stores the media, then derives its thumbnail and full size metadata.
+func (p *ProcessingMedia) load(ctx context.Context) error {
+	if err := p.store(ctx); err != nil {
+		return err
+	}
+
+	if err := p.loadThumb(ctx); err != nil {
+		return err
+	}
+
+	return p.loadFullSize(ctx)
+}
+
 // Finished returns true if processing has finished for both the thumbnail
 // and full fized version of this piece of media.
 func (p *ProcessingMedia) Finished() bool {
@@ -308,6 +343,15 @@ func (p *ProcessingMedia) store(ctx context.Context) error {
 	// concatenate the cleaned up first bytes with the existing bytes still in the reader (thanks Mara)
 	multiReader := io.MultiReader(bytes.NewBuffer(firstBytes), reader)
 
+	// check the dimensions of the image from its header before we go any further,
+	// so that we never store or fully decode an image that's too big to handle safely;
+	// whatever gets read for the check is kept in header so it can be put back in front
+	header := &bytes.Buffer{}
+	if err := checkImageDimensions(io.TeeReader(multiReader, header), contentType); err != nil {
+		return fmt.Errorf("store: %s", err)
+	}
+	multiReader = io.MultiReader(header, multiReader)
+
 	// we'll need to clean exif data from the first bytes; while we're
 	// here, we can also use the extension to derive the attachment type
 	var clean io.Reader
@@ -450,6 +494,8 @@ func (m *manager) preProcessMedia(ctx context.Context, data DataFunc, postData P
 		fullSizeState: int32(received),
 		database:      m.db,
 		storage:       m.storage,
+		slots:         m.mediaSlots,
+		describe:      m.queueDescribe,
 	}
 
 	return processingMedia, nil
@@ -470,6 +516,7 @@ func (m *manager) preProcessRecache(ctx context.Context, data DataFunc, postData
 		fullSizeState: int32(received),
 		database:      m.db,
 		storage:       m.storage,
+		slots:         m.mediaSlots,
 		recache:       true, // indicate it's a recache
 	}
 
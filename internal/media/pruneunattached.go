@@ -0,0 +1,82 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package media
+
+import (
+	"context"
+	"time"
+
+	"codeberg.org/gruf/go-store/storage"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (m *manager) PruneUnattached(ctx context.Context, olderThan time.Time) (int, error) {
+	var totalPruned int
+	var totalBytes int
+	logrus.Infof("PruneUnattached: pruning unattached media older than %s", olderThan)
+
+	// select 20 attachments at a time and prune them
+	var attachments []*gtsmodel.MediaAttachment
+	var err error
+	for attachments, err = m.db.GetLocalUnattachedOlderThan(ctx, olderThan, selectPruneLimit); err == nil && len(attachments) != 0; attachments, err = m.db.GetLocalUnattachedOlderThan(ctx, olderThan, selectPruneLimit) {
+
+		// use the age of the oldest attachment (the last one in the slice) as the next 'older than' value
+		l := len(attachments)
+		logrus.Tracef("PruneUnattached: got %d attachments older than %s", l, olderThan)
+		olderThan = attachments[l-1].CreatedAt
+
+		// delete each attachment
+		for _, attachment := range attachments {
+			if err := m.deleteAttachment(ctx, attachment); err != nil {
+				return totalPruned, err
+			}
+			totalPruned++
+			totalBytes += attachment.File.FileSize + attachment.Thumbnail.FileSize
+		}
+
+		if ctx.Err() != nil {
+			return totalPruned, ctx.Err()
+		}
+	}
+
+	// make sure we don't have a real error when we leave the loop
+	if err != nil && err != db.ErrNoEntries {
+		return totalPruned, err
+	}
+
+	logrus.Infof("PruneUnattached: finished pruning unattached media: pruned %d entries, reclaiming %d bytes", totalPruned, totalBytes)
+	return totalPruned, nil
+}
+
+// deleteAttachment removes both the stored data and the database entry of the given attachment.
+func (m *manager) deleteAttachment(ctx context.Context, attachment *gtsmodel.MediaAttachment) error {
+	for _, path := range []string{attachment.File.Path, attachment.Thumbnail.Path} {
+		if path == "" {
+			continue
+		}
+		logrus.Tracef("deleteAttachment: deleting %s", path)
+		if err := m.storage.Delete(path); err != nil && err != storage.ErrNotFound {
+			return err
+		}
+	}
+
+	return m.db.DeleteByID(ctx, attachment.ID, &gtsmodel.MediaAttachment{})
+}
@@ -0,0 +1,67 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package media_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"codeberg.org/gruf/go-store/storage"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+type PruneUnattachedTestSuite struct {
+	MediaStandardTestSuite
+}
+
+func (suite *PruneUnattachedTestSuite) TestPruneUnattached() {
+	ctx := context.Background()
+	testAttachment := suite.testAttachments["local_account_1_unattached_1"]
+
+	// the test attachment was created in the future, so look a bit further ahead
+	totalPruned, err := suite.manager.PruneUnattached(ctx, time.Now().Add(1*time.Minute))
+	suite.NoError(err)
+	suite.Equal(1, totalPruned)
+
+	// the attachment should be gone from the db and from storage
+	_, err = suite.db.GetAttachmentByID(ctx, testAttachment.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	_, err = suite.storage.Get(testAttachment.File.Path)
+	suite.ErrorIs(err, storage.ErrNotFound)
+	_, err = suite.storage.Get(testAttachment.Thumbnail.Path)
+	suite.ErrorIs(err, storage.ErrNotFound)
+
+	// avatars and headers should be left alone
+	_, err = suite.db.GetAttachmentByID(ctx, suite.testAttachments["local_account_1_avatar"].ID)
+	suite.NoError(err)
+	_, err = suite.db.GetAttachmentByID(ctx, suite.testAttachments["local_account_1_header"].ID)
+	suite.NoError(err)
+}
+
+func (suite *PruneUnattachedTestSuite) TestPruneUnattachedNothingOldEnough() {
+	totalPruned, err := suite.manager.PruneUnattached(context.Background(), time.Now().Add(-24*time.Hour))
+	suite.NoError(err)
+	suite.Equal(0, totalPruned)
+}
+
+func TestPruneUnattachedTestSuite(t *testing.T) {
+	suite.Run(t, new(PruneUnattachedTestSuite))
+}
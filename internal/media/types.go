@@ -53,9 +53,6 @@ const (
 	errored                      // processing order has been completed with an error
 )
 
-// EmojiMaxBytes is the maximum permitted bytes of an emoji upload (50kb)
-// const EmojiMaxBytes = 51200
-
 type Size string
 
 const (
@@ -115,6 +112,8 @@ type AdditionalEmojiInfo struct {
 	VisibleInPicker *bool
 	// ID of the category this emoji should be placed in; defaults to "".
 	CategoryID *string
+	// Name of the category this emoji should be sorted into in emoji pickers; defaults to "".
+	Category *string
 }
 
 // DataFunc represents a function used to retrieve the raw bytes of a piece of media.
@@ -0,0 +1,160 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package oauth
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+)
+
+// Top-level scopes which can be granted to a token. Granting one
+// of these also grants all of its sub-scopes, eg., a token with
+// scope 'read' is also allowed to do anything requiring 'read:accounts'.
+const (
+	ScopeRead   = "read"
+	ScopeWrite  = "write"
+	ScopeFollow = "follow"
+	ScopePush   = "push"
+	ScopeAdmin  = "admin"
+)
+
+// Fine-grained scopes which can be required by API endpoints.
+const (
+	ScopeReadAccounts      = "read:accounts"
+	ScopeReadBlocks        = "read:blocks"
+	ScopeReadFavourites    = "read:favourites"
+	ScopeReadFollows       = "read:follows"
+	ScopeReadMedia         = "read:media"
+	ScopeReadNotifications = "read:notifications"
+	ScopeReadSearch        = "read:search"
+	ScopeReadStatuses      = "read:statuses"
+	ScopeReadStreaming     = "read:streaming"
+	ScopeWriteAccounts     = "write:accounts"
+	ScopeWriteBlocks       = "write:blocks"
+	ScopeWriteFollows      = "write:follows"
+	ScopeWriteMedia        = "write:media"
+	ScopeWriteStatuses     = "write:statuses"
+	ScopeWriteUser         = "write:user"
+)
+
+// followScopes are the scopes granted by the legacy 'follow' scope.
+var followScopes = []string{
+	"read:blocks",
+	"write:blocks",
+	"read:follows",
+	"write:follows",
+	"read:mutes",
+	"write:mutes",
+}
+
+// HasScope returns true if the given space-separated list of granted scopes covers the required scope,
+// either because the required scope was granted directly, or because one of its parent scopes was granted.
+func HasScope(granted string, required string) bool {
+	for _, g := range strings.Fields(granted) {
+		if g == required || strings.HasPrefix(required, g+":") {
+			return true
+		}
+
+		if g == ScopeFollow {
+			for _, f := range followScopes {
+				if f == required {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// RequireScope checks whether the token in authed grants the required scope.
+//
+// If it doesn't, then a 403 response with an appropriate WWW-Authenticate
+// header is written to c, and false is returned, so the caller should return.
+//
+// If authed has no token (eg., for endpoints which allow unauthenticated access), true is returned.
+func RequireScope(c *gin.Context, authed *Auth, required string) bool {
+	if authed == nil || authed.Token == nil {
+		return true
+	}
+
+	if HasScope(authed.Token.GetScope(), required) {
+		return true
+	}
+
+	c.Header("WWW-Authenticate", fmt.Sprintf(`Bearer error="insufficient_scope", scope="%s"`, required))
+	c.JSON(http.StatusForbidden, gin.H{"error": fmt.Sprintf("token does not have required scope %s", required)})
+	return false
+}
+
+// ScopeDescription is a human-readable description of a scope,
+// which can be shown to a user who is asked to grant that scope.
+type ScopeDescription struct {
+	Scope       string
+	Description string
+}
+
+var scopeDescriptions = map[string]string{
+	ScopeRead:              "Read all of your account's data, including your statuses, follows, and notifications",
+	ScopeWrite:             "Modify all of your account's data, including posting statuses and changing your profile",
+	ScopeFollow:            "See, create, and remove your follows, blocks, and mutes",
+	ScopePush:              "Receive push notifications",
+	ScopeAdmin:             "Perform administrative actions on this instance",
+	ScopeReadAccounts:      "See account information, including your own profile and settings",
+	ScopeReadBlocks:        "See which accounts you've blocked",
+	ScopeReadFavourites:    "See which statuses you've favourited",
+	ScopeReadFollows:       "See your follows and follow requests",
+	ScopeReadMedia:         "See media attachments",
+	ScopeReadNotifications: "See your notifications",
+	ScopeReadSearch:        "Search for accounts, statuses, and hashtags",
+	ScopeReadStatuses:      "See statuses, including your timelines",
+	ScopeReadStreaming:     "Receive live updates to your timelines and notifications",
+	ScopeWriteAccounts:     "Change your profile and account settings",
+	ScopeWriteBlocks:       "Block and unblock accounts",
+	ScopeWriteFollows:      "Follow and unfollow accounts, and accept or reject follow requests",
+	ScopeWriteMedia:        "Upload and modify media attachments",
+	ScopeWriteStatuses:     "Post, delete, favourite, and boost statuses",
+	ScopeWriteUser:         "Change your password",
+	"read:mutes":           "See which accounts you've muted",
+	"write:mutes":          "Mute and unmute accounts",
+}
+
+// DescribeScopes parses the given space-separated list of scopes, and returns a human-readable
+// description of each of them, in the order they were given, without duplicates.
+func DescribeScopes(scope string) []ScopeDescription {
+	descriptions := []ScopeDescription{}
+	seen := make(map[string]bool)
+
+	for _, s := range strings.Fields(scope) {
+		if seen[s] {
+			continue
+		}
+		seen[s] = true
+
+		description, ok := scopeDescriptions[s]
+		if !ok {
+			description = "Unrecognized permission"
+		}
+		descriptions = append(descriptions, ScopeDescription{Scope: s, Description: description})
+	}
+
+	return descriptions
+}
@@ -0,0 +1,67 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package oauth_test
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type ScopeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ScopeTestSuite) TestHasScope() {
+	for _, test := range []struct {
+		granted  string
+		required string
+		expected bool
+	}{
+		{granted: "read write follow push", required: oauth.ScopeReadAccounts, expected: true},
+		{granted: "read write follow push", required: oauth.ScopeWriteStatuses, expected: true},
+		{granted: "read", required: oauth.ScopeWriteStatuses, expected: false},
+		{granted: "read:accounts", required: oauth.ScopeReadAccounts, expected: true},
+		{granted: "read:accounts", required: oauth.ScopeReadStatuses, expected: false},
+		{granted: "follow", required: oauth.ScopeWriteFollows, expected: true},
+		{granted: "follow", required: oauth.ScopeWriteStatuses, expected: false},
+		{granted: "read write", required: oauth.ScopeAdmin, expected: false},
+		{granted: "read write admin", required: oauth.ScopeAdmin, expected: true},
+		{granted: "readx", required: oauth.ScopeReadAccounts, expected: false},
+		{granted: "", required: oauth.ScopeReadAccounts, expected: false},
+	} {
+		suite.Equal(test.expected, oauth.HasScope(test.granted, test.required), "granted %q, required %q", test.granted, test.required)
+	}
+}
+
+func (suite *ScopeTestSuite) TestDescribeScopes() {
+	descriptions := oauth.DescribeScopes("read write:media read nonsense")
+	suite.Equal([]oauth.ScopeDescription{
+		{Scope: "read", Description: "Read all of your account's data, including your statuses, follows, and notifications"},
+		{Scope: "write:media", Description: "Upload and modify media attachments"},
+		{Scope: "nonsense", Description: "Unrecognized permission"},
+	}, descriptions)
+
+	suite.Empty(oauth.DescribeScopes(""))
+}
+
+func TestScopeTestSuite(t *testing.T) {
+	suite.Run(t, new(ScopeTestSuite))
+}
@@ -24,6 +24,8 @@ import (
 	"net/http"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/oauth2/v4"
 	"github.com/superseriousbusiness/oauth2/v4/errors"
@@ -57,24 +59,46 @@ type Server interface {
 	ValidationBearerToken(r *http.Request) (oauth2.TokenInfo, error)
 	GenerateUserAccessToken(ctx context.Context, ti oauth2.TokenInfo, clientSecret string, userID string) (accessToken oauth2.TokenInfo, err error)
 	LoadAccessToken(ctx context.Context, access string) (accessToken oauth2.TokenInfo, err error)
+	SignInFailures() *SignInFailures
 }
 
 // s fulfils the Server interface using the underlying oauth2 server
 type s struct {
-	server *server.Server
+	server         *server.Server
+	signInFailures *SignInFailures
 }
 
 // New returns a new oauth server that implements the Server interface
-func New(ctx context.Context, database db.Basic) Server {
-	ts := newTokenStore(ctx, database)
+func New(ctx context.Context, database db.DB) Server {
+	ts := newTokenStore(database)
 	cs := NewClientStore(database)
 
 	manager := manage.NewDefaultManager()
 	manager.MapTokenStorage(ts)
 	manager.MapClientStorage(cs)
+
+	// Access tokens only expire if an expiry is configured; if they do, then we
+	// also issue refresh tokens, so that clients can get new access tokens without
+	// having to go through the authorization flow again. Otherwise, access tokens
+	// don't expire, and must be revoked instead.
+	accessTokenExp := viper.GetDuration(config.Keys.OAuthAccessTokenExpiry)
+	refreshTokenExp := viper.GetDuration(config.Keys.OAuthRefreshTokenExpiry)
 	manager.SetAuthorizeCodeTokenCfg(&manage.Config{
-		AccessTokenExp:    0,     // access tokens don't expire -- they must be revoked
-		IsGenerateRefresh: false, // don't use refresh tokens
+		AccessTokenExp:    accessTokenExp,
+		RefreshTokenExp:   refreshTokenExp,
+		IsGenerateRefresh: accessTokenExp > 0,
+	})
+
+	// Refresh tokens are rotated: each use issues a new refresh token alongside
+	// the new access token. The old token is not removed by the manager, since
+	// the token store needs to keep it around to mark it as rotated; see tokenStore.Create.
+	manager.SetRefreshTokenCfg(&manage.RefreshingConfig{
+		AccessTokenExp:     accessTokenExp,
+		RefreshTokenExp:    refreshTokenExp,
+		IsGenerateRefresh:  true,
+		IsResetRefreshTime: true,
+		IsRemoveAccess:     false,
+		IsRemoveRefreshing: false,
 	})
 	sc := &server.Config{
 		TokenType: "Bearer",
@@ -85,9 +109,11 @@ func New(ctx context.Context, database db.Basic) Server {
 		// Allow:
 		// - Authorization Code (for first & third parties)
 		// - Client Credentials (for applications)
+		// - Refreshing (for exchanging refresh tokens, if they're enabled)
 		AllowedGrantTypes: []oauth2.GrantType{
 			oauth2.AuthorizationCode,
 			oauth2.ClientCredentials,
+			oauth2.Refreshing,
 		},
 		AllowedCodeChallengeMethods: []oauth2.CodeChallengeMethod{oauth2.CodeChallengePlain},
 	}
@@ -111,12 +137,17 @@ func New(ctx context.Context, database db.Basic) Server {
 	})
 	srv.SetClientInfoHandler(server.ClientFormHandler)
 	return &s{
-		server: srv,
+		server:         srv,
+		signInFailures: NewSignInFailures(),
 	}
 }
 
 // HandleTokenRequest wraps the oauth2 library's HandleTokenRequest function
 func (s *s) HandleTokenRequest(w http.ResponseWriter, r *http.Request) error {
+	if r.FormValue("grant_type") == oauth2.Refreshing.String() {
+		// let the token store know which refresh token is being rotated, so it can track the lineage of the new token
+		r = r.WithContext(withRotatingRefresh(r.Context(), r.FormValue("refresh_token")))
+	}
 	return s.server.HandleTokenRequest(w, r)
 }
 
@@ -174,3 +205,9 @@ func (s *s) GenerateUserAccessToken(ctx context.Context, ti oauth2.TokenInfo, cl
 func (s *s) LoadAccessToken(ctx context.Context, access string) (accessToken oauth2.TokenInfo, err error) {
 	return s.server.Manager.LoadAccessToken(ctx, access)
 }
+
+// SignInFailures returns the failed sign ins by ip address that this server keeps track of,
+// so that both signing in and lifting lockouts work with the same ones.
+func (s *s) SignInFailures() *SignInFailures {
+	return s.signInFailures
+}
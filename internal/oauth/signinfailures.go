@@ -0,0 +1,123 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package oauth
+
+import (
+	"sync"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// SignInFailures keeps track of failed sign ins by ip address, so that sign ins from addresses which
+// fail too often can be locked for a while. Unlike the failed sign ins of users, these are only kept
+// in memory, since they only need to outlast a lockout.
+type SignInFailures struct {
+	failures map[string]*ipSignInFailures
+	mutex    sync.Mutex
+}
+
+// ipSignInFailures counts failed sign ins from one ip address.
+type ipSignInFailures struct {
+	count       int
+	since       time.Time
+	lockedUntil time.Time
+	userIDs     map[string]struct{} // users that sign ins from this address failed as
+}
+
+// NewSignInFailures returns a new, empty, SignInFailures.
+func NewSignInFailures() *SignInFailures {
+	return &SignInFailures{
+		failures: make(map[string]*ipSignInFailures),
+	}
+}
+
+// LockedUntil returns until when sign ins from the given ip are locked, or the zero time if they aren't.
+func (f *SignInFailures) LockedUntil(ip string) time.Time {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if failures, ok := f.failures[ip]; ok && failures.lockedUntil.After(time.Now()) {
+		return failures.lockedUntil
+	}
+	return time.Time{}
+}
+
+// Fail counts a failed sign in from the given ip, locking sign ins from it if that was one too many.
+// userID is the id of the user the sign in failed as, or an empty string if there was no such user.
+func (f *SignInFailures) Fail(ip string, userID string) {
+	maxFailures := viper.GetInt(config.Keys.AccountsSignInMaxFailures)
+	if maxFailures <= 0 || ip == "" {
+		return
+	}
+	window := viper.GetDuration(config.Keys.AccountsSignInFailureWindow)
+	now := time.Now()
+
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	// forget about addresses which are neither locked nor failing any more, so this doesn't grow forever
+	for k, failures := range f.failures {
+		if now.Sub(failures.since) > window && !failures.lockedUntil.After(now) {
+			delete(f.failures, k)
+		}
+	}
+
+	failures, ok := f.failures[ip]
+	if !ok {
+		failures = &ipSignInFailures{userIDs: make(map[string]struct{})}
+		f.failures[ip] = failures
+	}
+	if userID != "" {
+		failures.userIDs[userID] = struct{}{}
+	}
+
+	if failures.count == 0 || now.Sub(failures.since) > window {
+		failures.count = 0
+		failures.since = now
+	}
+
+	failures.count++
+	if failures.count >= maxFailures {
+		failures.lockedUntil = now.Add(viper.GetDuration(config.Keys.AccountsSignInLockout))
+		failures.count = 0
+		failures.since = time.Time{}
+	}
+}
+
+// Clear forgets about failed sign ins from the given ip.
+func (f *SignInFailures) Clear(ip string) {
+	f.mutex.Lock()
+	delete(f.failures, ip)
+	f.mutex.Unlock()
+}
+
+// ClearUser forgets about failed sign ins from every ip which sign ins as the given user have failed from,
+// so that when an admin lifts the lockout of a user, the user isn't still locked out by their address.
+func (f *SignInFailures) ClearUser(userID string) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	for ip, failures := range f.failures {
+		if _, ok := failures.userIDs[userID]; ok {
+			delete(f.failures, ip)
+		}
+	}
+}
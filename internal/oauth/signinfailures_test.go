@@ -0,0 +1,74 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package oauth_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type SignInFailuresTestSuite struct {
+	suite.Suite
+}
+
+func (suite *SignInFailuresTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+	viper.Set(config.Keys.AccountsSignInMaxFailures, 3)
+}
+
+func (suite *SignInFailuresTestSuite) TestLockout() {
+	failures := oauth.NewSignInFailures()
+
+	failures.Fail("192.0.2.1", "")
+	failures.Fail("192.0.2.1", "")
+	suite.True(failures.LockedUntil("192.0.2.1").IsZero())
+
+	failures.Fail("192.0.2.1", "")
+	suite.WithinDuration(time.Now().Add(15*time.Minute), failures.LockedUntil("192.0.2.1"), time.Minute)
+	suite.True(failures.LockedUntil("192.0.2.2").IsZero())
+
+	failures.Clear("192.0.2.1")
+	suite.True(failures.LockedUntil("192.0.2.1").IsZero())
+}
+
+func (suite *SignInFailuresTestSuite) TestClearUser() {
+	failures := oauth.NewSignInFailures()
+
+	// one address failed as the user, the other only guessed at emails
+	for i := 0; i < 3; i++ {
+		failures.Fail("192.0.2.1", "01F8MH1H7YV1Z7D2C8K2730QBF")
+		failures.Fail("192.0.2.2", "")
+	}
+	suite.False(failures.LockedUntil("192.0.2.1").IsZero())
+	suite.False(failures.LockedUntil("192.0.2.2").IsZero())
+
+	failures.ClearUser("01F8MH1H7YV1Z7D2C8K2730QBF")
+	suite.True(failures.LockedUntil("192.0.2.1").IsZero())
+	suite.False(failures.LockedUntil("192.0.2.2").IsZero())
+}
+
+func TestSignInFailuresTestSuite(t *testing.T) {
+	suite.Run(t, new(SignInFailuresTestSuite))
+}
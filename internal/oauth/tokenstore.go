@@ -29,70 +29,30 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/oauth2/v4"
+	oautherrors "github.com/superseriousbusiness/oauth2/v4/errors"
 	"github.com/superseriousbusiness/oauth2/v4/models"
 )
 
 // tokenStore is an implementation of oauth2.TokenStore, which uses our db interface as a storage backend.
 type tokenStore struct {
 	oauth2.TokenStore
-	db db.Basic
+	db db.DB
 }
 
 // newTokenStore returns a token store that satisfies the oauth2.TokenStore interface.
 //
-// In order to allow tokens to 'expire', it will also set off a goroutine that iterates through
-// the tokens in the DB once per minute and deletes any that have expired.
-func newTokenStore(ctx context.Context, db db.Basic) oauth2.TokenStore {
-	ts := &tokenStore{
+// Expired tokens are not removed by the token store itself; see db.Token for that.
+func newTokenStore(db db.DB) oauth2.TokenStore {
+	return &tokenStore{
 		db: db,
 	}
-
-	// set the token store to clean out expired tokens once per minute, or return if we're done
-	go func(ctx context.Context, ts *tokenStore) {
-	cleanloop:
-		for {
-			select {
-			case <-ctx.Done():
-				logrus.Info("breaking cleanloop")
-				break cleanloop
-			case <-time.After(1 * time.Minute):
-				logrus.Trace("sweeping out old oauth entries broom broom")
-				if err := ts.sweep(ctx); err != nil {
-					logrus.Errorf("error while sweeping oauth entries: %s", err)
-				}
-			}
-		}
-	}(ctx, ts)
-	return ts
-}
-
-// sweep clears out old tokens that have expired; it should be run on a loop about once per minute or so.
-func (ts *tokenStore) sweep(ctx context.Context) error {
-	// select *all* tokens from the db
-	// todo: if this becomes expensive (ie., there are fucking LOADS of tokens) then figure out a better way.
-	tokens := new([]*gtsmodel.Token)
-	if err := ts.db.GetAll(ctx, tokens); err != nil {
-		return err
-	}
-
-	// iterate through and remove expired tokens
-	now := time.Now()
-	for _, dbt := range *tokens {
-		// The zero value of a time.Time is 00:00 january 1 1970, which will always be before now. So:
-		// we only want to check if a token expired before now if the expiry time is *not zero*;
-		// ie., if it's been explicity set.
-		if !dbt.CodeExpiresAt.IsZero() && dbt.CodeExpiresAt.Before(now) || !dbt.RefreshExpiresAt.IsZero() && dbt.RefreshExpiresAt.Before(now) || !dbt.AccessExpiresAt.IsZero() && dbt.AccessExpiresAt.Before(now) {
-			if err := ts.db.DeleteByID(ctx, dbt.ID, dbt); err != nil {
-				return err
-			}
-		}
-	}
-
-	return nil
 }
 
 // Create creates and store the new token information.
 // For the original implementation, see https://github.com/superseriousbusiness/oauth2/blob/master/store/token.go#L34
+//
+// If the token is being created in exchange for a refresh token (see withRotatingRefresh),
+// then the old token is marked as rotated, and the new token joins its rotation lineage.
 func (ts *tokenStore) Create(ctx context.Context, info oauth2.TokenInfo) error {
 	t, ok := info.(*models.Token)
 	if !ok {
@@ -108,6 +68,34 @@ func (ts *tokenStore) Create(ctx context.Context, info oauth2.TokenInfo) error {
 		dbt.ID = dbtID
 	}
 
+	if refresh := rotatingRefresh(ctx); refresh != "" && dbt.Refresh != "" && dbt.Refresh != refresh {
+		rotated := &gtsmodel.Token{}
+		if err := ts.db.GetWhere(ctx, []db.Where{{Key: "refresh", Value: refresh}}, rotated); err != nil {
+			return fmt.Errorf("error in tokenstore create: couldn't get rotated token: %s", err)
+		}
+
+		// mark the old token as rotated *before* storing the new one, so that if the
+		// same refresh token is being exchanged more than once at the same time, only
+		// one of the exchanges succeeds, and the others are treated as reuse
+		ok, err := ts.db.RotateToken(ctx, rotated.ID, time.Now())
+		if err != nil {
+			return fmt.Errorf("error in tokenstore create: couldn't rotate token: %s", err)
+		}
+		if !ok {
+			if err := ts.revokeFamily(ctx, rotated); err != nil {
+				return err
+			}
+			return oautherrors.ErrInvalidRefreshToken
+		}
+
+		dbt.FamilyID = familyID(rotated)
+	}
+
+	if dbt.Refresh != "" && dbt.FamilyID == "" {
+		// this token starts a new rotation lineage
+		dbt.FamilyID = dbt.ID
+	}
+
 	if err := ts.db.Put(ctx, dbt); err != nil {
 		return fmt.Errorf("error in tokenstore create: %s", err)
 	}
@@ -154,6 +142,7 @@ func (ts *tokenStore) GetByAccess(ctx context.Context, access string) (oauth2.To
 	if err := ts.db.GetWhere(ctx, []db.Where{{Key: "access", Value: access}}, dbt); err != nil {
 		return nil, err
 	}
+	ts.markUsed(ctx, dbt)
 	return DBTokenToToken(dbt), nil
 }
 
@@ -166,37 +155,97 @@ func (ts *tokenStore) GetByRefresh(ctx context.Context, refresh string) (oauth2.
 		Refresh: refresh,
 	}
 	if err := ts.db.GetWhere(ctx, []db.Where{{Key: "refresh", Value: refresh}}, dbt); err != nil {
+		if err == db.ErrNoEntries {
+			// let the manager treat this as an invalid refresh token
+			return nil, nil
+		}
 		return nil, err
 	}
+
+	if !dbt.RotatedAt.IsZero() {
+		// This refresh token has already been exchanged for a new token, so it should never be used
+		// again. Whoever is using it now may well have stolen it, and we can't tell whether they or the
+		// legitimate client got the new token, so revoke every token in the lineage to be safe.
+		logrus.WithContext(ctx).Warnf("refresh token of token %s was used again after being rotated at %s; revoking all tokens in its rotation lineage", dbt.ID, dbt.RotatedAt)
+		if err := ts.revokeFamily(ctx, dbt); err != nil {
+			return nil, err
+		}
+		return nil, nil
+	}
+
+	ts.markUsed(ctx, dbt)
 	return DBTokenToToken(dbt), nil
 }
 
-/*
-	The following models are basically helpers for the token store implementation, they should only be used internally.
-*/
+// lastUsedInterval is how often the time a token was last used is updated. Tokens are used on every
+// request, so recording every use would mean a database write per request, which isn't worth it.
+const lastUsedInterval = time.Hour
 
-// TokenToDBToken is a lil util function that takes a gotosocial token and gives back a token for inserting into a database.
-func TokenToDBToken(tkn *models.Token) *gtsmodel.Token {
+// markUsed records that the given token is being used now, if that hasn't been recorded recently,
+// so that it's not removed for inactivity. Using a user's token counts as the user logging in too.
+// Failing to record either isn't fatal, so errors are just logged.
+func (ts *tokenStore) markUsed(ctx context.Context, dbt *gtsmodel.Token) {
 	now := time.Now()
+	if now.Sub(dbt.LastUsed) < lastUsedInterval {
+		return
+	}
 
-	// For the following, we want to make sure we're not adding a time.Now() to an *empty* ExpiresIn, otherwise that's
-	// going to cause all sorts of interesting problems. So check first to make sure that the ExpiresIn is not equal
-	// to the zero value of a time.Duration, which is 0s. If it *is* empty/nil, just leave the ExpiresAt at nil as well.
+	if err := ts.db.UpdateWhere(ctx, []db.Where{{Key: "id", Value: dbt.ID}}, "last_used", now, &gtsmodel.Token{}); err != nil {
+		logrus.WithContext(ctx).Errorf("error marking token %s as used: %s", dbt.ID, err)
+		return
+	}
+	dbt.LastUsed = now
 
-	cea := time.Time{}
-	if tkn.CodeExpiresIn != 0*time.Second {
-		cea = now.Add(tkn.CodeExpiresIn)
+	if dbt.UserID != "" {
+		if err := ts.db.UpdateWhere(ctx, []db.Where{{Key: "id", Value: dbt.UserID}}, "last_login", now, &gtsmodel.User{}); err != nil {
+			logrus.WithContext(ctx).Errorf("error recording login of user %s: %s", dbt.UserID, err)
+		}
 	}
+}
 
-	aea := time.Time{}
-	if tkn.AccessExpiresIn != 0*time.Second {
-		aea = now.Add(tkn.AccessExpiresIn)
+// revokeFamily deletes every token in the rotation lineage of the given token.
+func (ts *tokenStore) revokeFamily(ctx context.Context, dbt *gtsmodel.Token) error {
+	if err := ts.db.DeleteWhere(ctx, []db.Where{{Key: "family_id", Value: familyID(dbt)}}, &gtsmodel.Token{}); err != nil {
+		return fmt.Errorf("error revoking token family: %s", err)
 	}
+	return nil
+}
 
-	rea := time.Time{}
-	if tkn.RefreshExpiresIn != 0*time.Second {
-		rea = now.Add(tkn.RefreshExpiresIn)
+// familyID returns the ID of the rotation lineage that the given token belongs to.
+func familyID(dbt *gtsmodel.Token) string {
+	if dbt.FamilyID == "" {
+		return dbt.ID
 	}
+	return dbt.FamilyID
+}
+
+type ctxKey string
+
+// rotatingRefreshKey is the context key used to store the refresh token being exchanged in a token request.
+const rotatingRefreshKey ctxKey = "rotatingRefresh"
+
+// withRotatingRefresh returns a copy of ctx, which tells the token store
+// that the given refresh token is being exchanged for a new token.
+func withRotatingRefresh(ctx context.Context, refresh string) context.Context {
+	return context.WithValue(ctx, rotatingRefreshKey, refresh)
+}
+
+// rotatingRefresh returns the refresh token being exchanged for a new token, or an empty string if there isn't one.
+func rotatingRefresh(ctx context.Context) string {
+	refresh, _ := ctx.Value(rotatingRefreshKey).(string)
+	return refresh
+}
+
+/*
+	The following models are basically helpers for the token store implementation, they should only be used internally.
+*/
+
+// TokenToDBToken is a lil util function that takes a gotosocial token and gives back a token for inserting into a database.
+func TokenToDBToken(tkn *models.Token) *gtsmodel.Token {
+	// An empty ExpiresIn means the token never expires, in which case the ExpiresAt is left at nil as well.
+	cea := expiresAt(tkn.CodeCreateAt, tkn.CodeExpiresIn)
+	aea := expiresAt(tkn.AccessCreateAt, tkn.AccessExpiresIn)
+	rea := expiresAt(tkn.RefreshCreateAt, tkn.RefreshExpiresIn)
 
 	return &gtsmodel.Token{
 		ClientID:            tkn.ClientID,
@@ -219,22 +268,9 @@ func TokenToDBToken(tkn *models.Token) *gtsmodel.Token {
 
 // DBTokenToToken is a lil util function that takes a database token and gives back a gotosocial token
 func DBTokenToToken(dbt *gtsmodel.Token) *models.Token {
-	now := time.Now()
-
-	var codeExpiresIn time.Duration
-	if !dbt.CodeExpiresAt.IsZero() {
-		codeExpiresIn = dbt.CodeExpiresAt.Sub(now)
-	}
-
-	var accessExpiresIn time.Duration
-	if !dbt.AccessExpiresAt.IsZero() {
-		accessExpiresIn = dbt.AccessExpiresAt.Sub(now)
-	}
-
-	var refreshExpiresIn time.Duration
-	if !dbt.RefreshExpiresAt.IsZero() {
-		refreshExpiresIn = dbt.RefreshExpiresAt.Sub(now)
-	}
+	codeExpiresIn := expiresIn(dbt.CodeCreateAt, dbt.CodeExpiresAt)
+	accessExpiresIn := expiresIn(dbt.AccessCreateAt, dbt.AccessExpiresAt)
+	refreshExpiresIn := expiresIn(dbt.RefreshCreateAt, dbt.RefreshExpiresAt)
 
 	return &models.Token{
 		ClientID:            dbt.ClientID,
@@ -254,3 +290,32 @@ func DBTokenToToken(dbt *gtsmodel.Token) *models.Token {
 		RefreshExpiresIn:    refreshExpiresIn,
 	}
 }
+
+// expiresAt returns the time at which something created at createdAt, which expires after expiresIn, expires.
+// If expiresIn is 0, the zero time is returned, which means it never expires.
+func expiresAt(createdAt time.Time, expiresIn time.Duration) time.Time {
+	if expiresIn == 0 {
+		return time.Time{}
+	}
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	return createdAt.Add(expiresIn)
+}
+
+// expiresIn is the inverse of expiresAt. The oauth2 library considers a token to be expired
+// once createdAt + expiresIn has passed, so the result is relative to createdAt, not to now.
+func expiresIn(createdAt time.Time, expiresAt time.Time) time.Duration {
+	if expiresAt.IsZero() {
+		return 0
+	}
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+	if d := expiresAt.Sub(createdAt); d != 0 {
+		return d
+	}
+	// 0 would mean 'never expires', so make sure something that
+	// expired the moment it was created is still treated as expired
+	return -1
+}
@@ -18,4 +18,184 @@
 
 package oauth_test
 
-// TODO: write tests
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type TokenStoreTestSuite struct {
+	suite.Suite
+	db          db.DB
+	oauthServer oauth.Server
+
+	testTokens  map[string]*gtsmodel.Token
+	testClients map[string]*gtsmodel.Client
+	testUsers   map[string]*gtsmodel.User
+}
+
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+	Error        string `json:"error"`
+}
+
+func (suite *TokenStoreTestSuite) SetupSuite() {
+	suite.testTokens = testrig.NewTestTokens()
+	suite.testClients = testrig.NewTestClients()
+	suite.testUsers = testrig.NewTestUsers()
+}
+
+func (suite *TokenStoreTestSuite) SetupTest() {
+	testrig.InitTestLog()
+	testrig.InitTestConfig()
+	viper.Set(config.Keys.OAuthAccessTokenExpiry, time.Hour)
+	suite.db = testrig.NewTestDB()
+	suite.oauthServer = testrig.NewTestOauthServer(suite.db)
+	testrig.StandardDBSetup(suite.db, nil)
+}
+
+func (suite *TokenStoreTestSuite) TearDownTest() {
+	testrig.StandardDBTeardown(suite.db)
+}
+
+// newUserToken creates a new user-level token for local_account_1, as if they'd just signed in.
+func (suite *TokenStoreTestSuite) newUserToken() (access string, refresh string) {
+	ti, err := suite.oauthServer.GenerateUserAccessToken(context.Background(), oauth.DBTokenToToken(suite.testTokens["local_account_1"]), suite.testClients["local_account_1"].Secret, suite.testUsers["local_account_1"].ID)
+	suite.NoError(err)
+	return ti.GetAccess(), ti.GetRefresh()
+}
+
+// refresh exchanges the given refresh token for a new token, using the token endpoint.
+func (suite *TokenStoreTestSuite) refresh(refresh string) (int, *tokenResponse) {
+	client := suite.testClients["local_account_1"]
+	form := url.Values{
+		"grant_type":    {"refresh_token"},
+		"client_id":     {client.ID},
+		"client_secret": {client.Secret},
+		"refresh_token": {refresh},
+	}
+	request := httptest.NewRequest(http.MethodPost, "http://localhost:8080/oauth/token", strings.NewReader(form.Encode()))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	recorder := httptest.NewRecorder()
+
+	suite.NoError(suite.oauthServer.HandleTokenRequest(recorder, request))
+
+	response := &tokenResponse{}
+	suite.NoError(json.Unmarshal(recorder.Body.Bytes(), response))
+	return recorder.Code, response
+}
+
+func (suite *TokenStoreTestSuite) accessValid(access string) bool {
+	ti, err := suite.oauthServer.LoadAccessToken(context.Background(), access)
+	return err == nil && ti != nil
+}
+
+func (suite *TokenStoreTestSuite) TestRefreshRotation() {
+	access, refresh := suite.newUserToken()
+	suite.NotEmpty(refresh)
+	suite.True(suite.accessValid(access))
+
+	code, response := suite.refresh(refresh)
+	suite.Equal(http.StatusOK, code)
+	suite.NotEmpty(response.AccessToken)
+	suite.NotEqual(access, response.AccessToken)
+	suite.NotEmpty(response.RefreshToken)
+	suite.NotEqual(refresh, response.RefreshToken)
+	suite.EqualValues(time.Hour.Seconds(), response.ExpiresIn)
+
+	// the old access token should no longer work, but the new one should
+	suite.False(suite.accessValid(access))
+	suite.True(suite.accessValid(response.AccessToken))
+
+	// the new token should be in the same lineage as the old one
+	oldToken := &gtsmodel.Token{}
+	suite.NoError(suite.db.GetWhere(context.Background(), []db.Where{{Key: "refresh", Value: refresh}}, oldToken))
+	suite.NotZero(oldToken.RotatedAt)
+	newToken := &gtsmodel.Token{}
+	suite.NoError(suite.db.GetWhere(context.Background(), []db.Where{{Key: "refresh", Value: response.RefreshToken}}, newToken))
+	suite.Zero(newToken.RotatedAt)
+	suite.Equal(oldToken.FamilyID, newToken.FamilyID)
+
+	// and the new refresh token can be rotated in turn
+	code, response = suite.refresh(response.RefreshToken)
+	suite.Equal(http.StatusOK, code)
+	suite.True(suite.accessValid(response.AccessToken))
+}
+
+func (suite *TokenStoreTestSuite) TestRefreshReuseRevokesLineage() {
+	_, refresh := suite.newUserToken()
+
+	code, rotated := suite.refresh(refresh)
+	suite.Equal(http.StatusOK, code)
+
+	// use the old refresh token again: this should be
+	// rejected, and the token obtained with it revoked
+	code, response := suite.refresh(refresh)
+	suite.Equal(http.StatusUnauthorized, code)
+	suite.Equal("invalid_grant", response.Error)
+	suite.False(suite.accessValid(rotated.AccessToken))
+
+	code, response = suite.refresh(rotated.RefreshToken)
+	suite.Equal(http.StatusUnauthorized, code)
+	suite.Equal("invalid_grant", response.Error)
+}
+
+func (suite *TokenStoreTestSuite) TestNoExpiryNoRefresh() {
+	viper.Set(config.Keys.OAuthAccessTokenExpiry, 0)
+	suite.oauthServer = testrig.NewTestOauthServer(suite.db)
+
+	access, refresh := suite.newUserToken()
+	suite.Empty(refresh)
+
+	token := &gtsmodel.Token{}
+	suite.NoError(suite.db.GetWhere(context.Background(), []db.Where{{Key: "access", Value: access}}, token))
+	suite.Zero(token.AccessExpiresAt)
+	suite.True(suite.accessValid(access))
+}
+
+func (suite *TokenStoreTestSuite) TestExistingTokenStillWorks() {
+	// tokens issued before access tokens started expiring should keep working
+	suite.True(suite.accessValid(suite.testTokens["local_account_1"].Access))
+}
+
+func (suite *TokenStoreTestSuite) TestAccessMarksUsed() {
+	testToken := suite.testTokens["local_account_1"]
+	suite.True(suite.accessValid(testToken.Access))
+
+	token := &gtsmodel.Token{}
+	suite.NoError(suite.db.GetByID(context.Background(), testToken.ID, token))
+	suite.WithinDuration(time.Now(), token.LastUsed, time.Minute)
+
+	// using the token counts as its user logging in
+	user := &gtsmodel.User{}
+	suite.NoError(suite.db.GetByID(context.Background(), testToken.UserID, user))
+	suite.WithinDuration(time.Now(), user.LastLogin, time.Minute)
+
+	// recent uses aren't recorded again
+	lastUsed := time.Now().Add(-30 * time.Minute)
+	suite.NoError(suite.db.UpdateWhere(context.Background(), []db.Where{{Key: "id", Value: token.ID}}, "last_used", lastUsed, &gtsmodel.Token{}))
+	suite.True(suite.accessValid(testToken.Access))
+
+	token = &gtsmodel.Token{}
+	suite.NoError(suite.db.GetByID(context.Background(), testToken.ID, token))
+	suite.WithinDuration(lastUsed, token.LastUsed, time.Second)
+}
+
+func TestTokenStoreTestSuite(t *testing.T) {
+	suite.Run(t, new(TokenStoreTestSuite))
+}
@@ -26,7 +26,10 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
-func (p *processor) AccountCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountCreateRequest) (*apimodel.Token, error) {
+func (p *processor) AccountCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountCreateRequest) (*apimodel.Token, gtserror.WithCode) {
+	if errWithCode := p.checkReadOnly(ctx); errWithCode != nil {
+		return nil, errWithCode
+	}
 	return p.accountProcessor.Create(ctx, authed.Token, authed.Application, form)
 }
 
@@ -42,7 +45,7 @@ func (p *processor) AccountGetLocalByUsername(ctx context.Context, authed *oauth
 	return p.accountProcessor.GetLocalByUsername(ctx, authed.Account, username)
 }
 
-func (p *processor) AccountUpdate(ctx context.Context, authed *oauth.Auth, form *apimodel.UpdateCredentialsRequest) (*apimodel.Account, error) {
+func (p *processor) AccountUpdate(ctx context.Context, authed *oauth.Auth, form *apimodel.UpdateCredentialsRequest) (*apimodel.Account, gtserror.WithCode) {
 	return p.accountProcessor.Update(ctx, authed.Account, form)
 }
 
@@ -50,6 +53,10 @@ func (p *processor) AccountStatusesGet(ctx context.Context, authed *oauth.Auth,
 	return p.accountProcessor.StatusesGet(ctx, authed.Account, targetAccountID, limit, excludeReplies, excludeReblogs, maxID, minID, pinnedOnly, mediaOnly, publicOnly)
 }
 
+func (p *processor) AccountStatusesFeedGet(ctx context.Context, username string, atom bool) ([]byte, gtserror.WithCode) {
+	return p.accountProcessor.StatusesFeedGet(ctx, username, atom)
+}
+
 func (p *processor) AccountFollowersGet(ctx context.Context, authed *oauth.Auth, targetAccountID string) ([]apimodel.Account, gtserror.WithCode) {
 	return p.accountProcessor.FollowersGet(ctx, authed.Account, targetAccountID)
 }
@@ -58,11 +65,18 @@ func (p *processor) AccountFollowingGet(ctx context.Context, authed *oauth.Auth,
 	return p.accountProcessor.FollowingGet(ctx, authed.Account, targetAccountID)
 }
 
+func (p *processor) AccountFamiliarFollowersGet(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.FamiliarFollowers, gtserror.WithCode) {
+	return p.accountProcessor.FamiliarFollowersGet(ctx, authed.Account, targetAccountID)
+}
+
 func (p *processor) AccountRelationshipGet(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode) {
 	return p.accountProcessor.RelationshipGet(ctx, authed.Account, targetAccountID)
 }
 
 func (p *processor) AccountFollowCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountFollowRequest) (*apimodel.Relationship, gtserror.WithCode) {
+	if errWithCode := p.checkReadOnly(ctx); errWithCode != nil {
+		return nil, errWithCode
+	}
 	return p.accountProcessor.FollowCreate(ctx, authed.Account, form)
 }
 
@@ -71,9 +85,66 @@ func (p *processor) AccountFollowRemove(ctx context.Context, authed *oauth.Auth,
 }
 
 func (p *processor) AccountBlockCreate(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode) {
+	if errWithCode := p.checkReadOnly(ctx); errWithCode != nil {
+		return nil, errWithCode
+	}
 	return p.accountProcessor.BlockCreate(ctx, authed.Account, targetAccountID)
 }
 
 func (p *processor) AccountBlockRemove(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode) {
 	return p.accountProcessor.BlockRemove(ctx, authed.Account, targetAccountID)
 }
+
+func (p *processor) AccountAvatarDelete(ctx context.Context, authed *oauth.Auth) (*apimodel.Account, gtserror.WithCode) {
+	return p.accountProcessor.RemoveAvatar(ctx, authed.Account)
+}
+
+func (p *processor) AccountHeaderDelete(ctx context.Context, authed *oauth.Auth) (*apimodel.Account, gtserror.WithCode) {
+	return p.accountProcessor.RemoveHeader(ctx, authed.Account)
+}
+
+func (p *processor) AccountRotateKeys(ctx context.Context, authed *oauth.Auth) (*apimodel.Account, gtserror.WithCode) {
+	return p.accountProcessor.RotateKeys(ctx, authed.Account)
+}
+
+func (p *processor) AccountVerifyAllFields(ctx context.Context) (int, error) {
+	return p.accountProcessor.VerifyAllFields(ctx)
+}
+
+func (p *processor) AccountArchiveCreate(ctx context.Context, authed *oauth.Auth) (*apimodel.AccountArchive, gtserror.WithCode) {
+	return p.accountProcessor.ArchiveCreate(ctx, authed.Account)
+}
+
+func (p *processor) AccountArchiveGet(ctx context.Context, authed *oauth.Auth) (*apimodel.AccountArchive, gtserror.WithCode) {
+	return p.accountProcessor.ArchiveGet(ctx, authed.Account)
+}
+
+func (p *processor) AccountArchiveDownload(ctx context.Context, archiveID string, expires string, signature string) (*apimodel.Content, gtserror.WithCode) {
+	return p.accountProcessor.ArchiveDownload(ctx, archiveID, expires, signature)
+}
+
+func (p *processor) AccountFollowImportCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.FollowImportCreateRequest) (*apimodel.FollowImport, gtserror.WithCode) {
+	if errWithCode := p.checkReadOnly(ctx); errWithCode != nil {
+		return nil, errWithCode
+	}
+	return p.accountProcessor.FollowImportCreate(ctx, authed.Account, form)
+}
+
+func (p *processor) AccountFollowImportGet(ctx context.Context, authed *oauth.Auth) (*apimodel.FollowImport, gtserror.WithCode) {
+	return p.accountProcessor.FollowImportGet(ctx, authed.Account)
+}
+
+func (p *processor) AccountBlockImportCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.BlockImportCreateRequest) (*apimodel.BlockImport, gtserror.WithCode) {
+	if errWithCode := p.checkReadOnly(ctx); errWithCode != nil {
+		return nil, errWithCode
+	}
+	return p.accountProcessor.BlockImportCreate(ctx, authed.Account, form)
+}
+
+func (p *processor) AccountBlockImportGet(ctx context.Context, authed *oauth.Auth) (*apimodel.BlockImport, gtserror.WithCode) {
+	return p.accountProcessor.BlockImportGet(ctx, authed.Account)
+}
+
+func (p *processor) AccountBlocksExport(ctx context.Context, authed *oauth.Auth) (*apimodel.Content, gtserror.WithCode) {
+	return p.accountProcessor.BlocksExport(ctx, authed.Account)
+}
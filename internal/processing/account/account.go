@@ -22,6 +22,9 @@ import (
 	"context"
 	"mime/multipart"
 
+	"codeberg.org/gruf/go-store/kv"
+	"github.com/ReneKroon/ttlcache"
+	"github.com/superseriousbusiness/activity/pub"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
@@ -40,7 +43,7 @@ import (
 // Processor wraps a bunch of functions for processing account actions.
 type Processor interface {
 	// Create processes the given form for creating a new account, returning an oauth token for that account if successful.
-	Create(ctx context.Context, applicationToken oauth2.TokenInfo, application *gtsmodel.Application, form *apimodel.AccountCreateRequest) (*apimodel.Token, error)
+	Create(ctx context.Context, applicationToken oauth2.TokenInfo, application *gtsmodel.Application, form *apimodel.AccountCreateRequest) (*apimodel.Token, gtserror.WithCode)
 	// Delete deletes an account, and all of that account's statuses, media, follows, notifications, etc etc etc.
 	// The origin passed here should be either the ID of the account doing the delete (can be itself), or the ID of a domain block.
 	Delete(ctx context.Context, account *gtsmodel.Account, origin string) gtserror.WithCode
@@ -52,20 +55,32 @@ type Processor interface {
 	// GetLocalByUsername processes the given request for account information targeting a local account by username.
 	GetLocalByUsername(ctx context.Context, requestingAccount *gtsmodel.Account, username string) (*apimodel.Account, gtserror.WithCode)
 	// Update processes the update of an account with the given form
-	Update(ctx context.Context, account *gtsmodel.Account, form *apimodel.UpdateCredentialsRequest) (*apimodel.Account, error)
+	Update(ctx context.Context, account *gtsmodel.Account, form *apimodel.UpdateCredentialsRequest) (*apimodel.Account, gtserror.WithCode)
 	// StatusesGet fetches a number of statuses (in time descending order) from the given account, filtered by visibility for
 	// the account given in authed.
 	StatusesGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string, limit int, excludeReplies bool, excludeReblogs bool, maxID string, minID string, pinned bool, mediaOnly bool, publicOnly bool) ([]apimodel.Status, gtserror.WithCode)
+	// StatusesFeedGet renders an RSS feed, or an Atom feed if atom is true, of the recent public statuses of the local account
+	// with the given username. Rendered feeds are cached briefly.
+	StatusesFeedGet(ctx context.Context, username string, atom bool) ([]byte, gtserror.WithCode)
 	// FollowersGet fetches a list of the target account's followers.
 	FollowersGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) ([]apimodel.Account, gtserror.WithCode)
 	// FollowingGet fetches a list of the accounts that target account is following.
 	FollowingGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) ([]apimodel.Account, gtserror.WithCode)
+	// FamiliarFollowersGet fetches the followers of the target account that the requesting account also follows.
+	FamiliarFollowersGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.FamiliarFollowers, gtserror.WithCode)
 	// RelationshipGet returns a relationship model describing the relationship of the targetAccount to the Authed account.
 	RelationshipGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
 	// FollowCreate handles a follow request to an account, either remote or local.
 	FollowCreate(ctx context.Context, requestingAccount *gtsmodel.Account, form *apimodel.AccountFollowRequest) (*apimodel.Relationship, gtserror.WithCode)
 	// FollowRemove handles the removal of a follow/follow request to an account, either remote or local.
 	FollowRemove(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
+	// AutoAcceptsFollowRequest checks whether a follow request from requestingAccount to targetAccount matches one
+	// of targetAccount's auto-accept rules, in which case it should be accepted right away even if targetAccount is locked.
+	AutoAcceptsFollowRequest(ctx context.Context, targetAccount *gtsmodel.Account, requestingAccount *gtsmodel.Account) (bool, error)
+	// AutoAcceptGet returns the follow request auto-accept rules of the given account.
+	AutoAcceptGet(ctx context.Context, account *gtsmodel.Account) (*apimodel.FollowRequestAutoAccept, gtserror.WithCode)
+	// AutoAcceptUpdate updates the follow request auto-accept rules of the given account with the given form.
+	AutoAcceptUpdate(ctx context.Context, account *gtsmodel.Account, form *apimodel.FollowRequestAutoAcceptUpdateRequest) (*apimodel.FollowRequestAutoAccept, gtserror.WithCode)
 	// BlockCreate handles the creation of a block from requestingAccount to targetAccountID, either remote or local.
 	BlockCreate(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
 	// BlockRemove handles the removal of a block from requestingAccount to targetAccountID, either remote or local.
@@ -79,6 +94,48 @@ type Processor interface {
 	// parsing and checking the image, and doing the necessary updates in the database for this to become
 	// the account's new avatar image.
 	UpdateHeader(ctx context.Context, header *multipart.FileHeader, accountID string) (*gtsmodel.MediaAttachment, error)
+
+	// RemoveAvatar removes the avatar of the given account, deleting the media attachment, and federates the change.
+	RemoveAvatar(ctx context.Context, account *gtsmodel.Account) (*apimodel.Account, gtserror.WithCode)
+	// RemoveHeader removes the header of the given account, deleting the media attachment, and federates the change.
+	RemoveHeader(ctx context.Context, account *gtsmodel.Account) (*apimodel.Account, gtserror.WithCode)
+	// RotateKeys replaces the keypair of the given account with a newly generated one, and federates the new public key.
+	// The old public key stays valid for the configured grace period, so that requests signed with it can still be verified.
+	RotateKeys(ctx context.Context, account *gtsmodel.Account) (*apimodel.Account, gtserror.WithCode)
+
+	// VerifyFields checks each profile field of the given local account whose value is a link, and marks it verified if the linked
+	// page contains a rel="me" link back to the account's profile. Fields whose page can't be fetched are left as they were.
+	// It returns true if the verification of any field changed, in which case the account will have been updated in the database.
+	VerifyFields(ctx context.Context, account *gtsmodel.Account) (bool, error)
+	// VerifyAllFields re-verifies the profile fields of all local accounts, returning the number of accounts that changed.
+	VerifyAllFields(ctx context.Context) (int, error)
+	// ArchiveCreate starts generating a new archive of the given account's data in the background, or returns the
+	// archive that's currently being generated, if there is one. Requests are limited to one per configured interval.
+	ArchiveCreate(ctx context.Context, account *gtsmodel.Account) (*apimodel.AccountArchive, gtserror.WithCode)
+	// ArchiveGet returns the most recently requested archive of the given account, with a download link if it's ready.
+	ArchiveGet(ctx context.Context, account *gtsmodel.Account) (*apimodel.AccountArchive, gtserror.WithCode)
+	// ArchiveProcess writes an archive of the given account's data into storage, and marks the archive as ready once
+	// it's done, or as failed if something went wrong. Any older archives of the account are removed once a new one is ready.
+	// Archives which are no longer pending, because they were given up on while waiting to be processed, are skipped.
+	ArchiveProcess(ctx context.Context, account *gtsmodel.Account, archive *gtsmodel.AccountArchive) error
+	// ArchiveDownload returns the content of the archive with the given ID, if expires and signature are valid for it.
+	ArchiveDownload(ctx context.Context, archiveID string, expires string, signature string) (*apimodel.Content, gtserror.WithCode)
+	// FollowImportCreate parses the given csv of accounts to follow, and queues them to be followed by the given account.
+	FollowImportCreate(ctx context.Context, account *gtsmodel.Account, form *apimodel.FollowImportCreateRequest) (*apimodel.FollowImport, gtserror.WithCode)
+	// FollowImportGet returns the most recent follow import of the given account, with the outcome of each line so far.
+	FollowImportGet(ctx context.Context, account *gtsmodel.Account) (*apimodel.FollowImport, gtserror.WithCode)
+	// FollowImportProcess follows each of the accounts on the pending lines of the given follow import, recording
+	// the outcome as it goes. This is slow, and should only be called from the client API worker.
+	FollowImportProcess(ctx context.Context, account *gtsmodel.Account, followImport *gtsmodel.FollowImport) error
+	// BlockImportCreate parses the given csv of accounts to block, and queues them to be blocked by the given account.
+	BlockImportCreate(ctx context.Context, account *gtsmodel.Account, form *apimodel.BlockImportCreateRequest) (*apimodel.BlockImport, gtserror.WithCode)
+	// BlockImportGet returns the most recent block import of the given account, with the outcome of each line so far.
+	BlockImportGet(ctx context.Context, account *gtsmodel.Account) (*apimodel.BlockImport, gtserror.WithCode)
+	// BlockImportProcess blocks each of the accounts on the pending lines of the given block import, recording
+	// the outcome as it goes. This is slow, and should only be called from the client API worker.
+	BlockImportProcess(ctx context.Context, account *gtsmodel.Account, blockImport *gtsmodel.BlockImport) error
+	// BlocksExport returns a csv of the accounts blocked by the given account, in the format Mastodon exports blocks in.
+	BlocksExport(ctx context.Context, account *gtsmodel.Account) (*apimodel.Content, gtserror.WithCode)
 }
 
 type processor struct {
@@ -90,11 +147,19 @@ type processor struct {
 	formatter    text.Formatter
 	db           db.DB
 	federator    federation.Federator
+	verifyClient pub.HttpClient
 	parseMention gtsmodel.ParseMentionFunc
+	storage      *kv.KVStore
+	feedCache    *ttlcache.Cache
 }
 
-// New returns a new account processor.
-func New(db db.DB, tc typeutils.TypeConverter, mediaManager media.Manager, oauthServer oauth.Server, clientWorker *worker.Worker[messages.FromClientAPI], federator federation.Federator, parseMention gtsmodel.ParseMentionFunc) Processor {
+// New returns a new account processor. The given verify client is used for fetching the links in profile fields to
+// verify them, and since those links come from users, it shouldn't be able to reach private addresses.
+func New(db db.DB, tc typeutils.TypeConverter, verifyClient pub.HttpClient, mediaManager media.Manager, oauthServer oauth.Server, clientWorker *worker.Worker[messages.FromClientAPI], federator federation.Federator, parseMention gtsmodel.ParseMentionFunc, storage *kv.KVStore) Processor {
+	feedCache := ttlcache.NewCache()
+	feedCache.SetTTL(feedCacheTTL)
+	feedCache.SkipTtlExtensionOnHit(true)
+
 	return &processor{
 		tc:           tc,
 		mediaManager: mediaManager,
@@ -104,6 +169,9 @@ func New(db db.DB, tc typeutils.TypeConverter, mediaManager media.Manager, oauth
 		formatter:    text.NewFormatter(db),
 		db:           db,
 		federator:    federator,
+		verifyClient: verifyClient,
 		parseMention: parseMention,
+		storage:      storage,
+		feedCache:    feedCache,
 	}
 }
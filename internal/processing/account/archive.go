@@ -0,0 +1,489 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"archive/zip"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+const (
+	// archiveDownloadPath is the path that archives can be downloaded from; it must match the path served by the account api module.
+	archiveDownloadPath = "/api/v1/accounts/archive/%s/download"
+	// archiveLinkExpiry is how long signed archive download links are valid for.
+	archiveLinkExpiry = 1 * time.Hour
+	// archivePageSize is how many statuses are fetched from the database at once while generating an archive.
+	archivePageSize = 100
+	// archivePendingTimeout is how long an archive may stay pending before we give up on it, eg., because the
+	// instance was restarted while it was being generated, so that the account owner can request another one.
+	archivePendingTimeout = 6 * time.Hour
+	// activityStreamsContext is the json-ld context of the activitystreams collections in an archive.
+	activityStreamsContext = "https://www.w3.org/ns/activitystreams"
+)
+
+func (p *processor) ArchiveCreate(ctx context.Context, account *gtsmodel.Account) (*apimodel.AccountArchive, gtserror.WithCode) {
+	latest, err := p.latestArchive(ctx, account.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("ArchiveCreate: error fetching archives: %s", err))
+	}
+
+	if latest != nil {
+		switch latest.Status {
+		case gtsmodel.AccountArchivePending:
+			// an archive is already being generated, so there's no need to start another one
+			return p.archiveToAPI(latest), nil
+		case gtsmodel.AccountArchiveReady:
+			if interval := viper.GetDuration(config.Keys.AccountsArchiveInterval); interval > 0 {
+				if next := latest.CreatedAt.Add(interval); time.Now().Before(next) {
+					err := fmt.Errorf("ArchiveCreate: account %s last requested an archive at %s", account.ID, latest.CreatedAt)
+					errWithCode := gtserror.NewErrorTooManyRequests(err, fmt.Sprintf("a new archive can be requested after %s", next.Format(time.RFC3339)))
+					errWithCode = gtserror.WithDetails(errWithCode, map[string]interface{}{"next_request_at": next.Format(time.RFC3339)})
+					return nil, gtserror.WithRetryAfter(errWithCode, time.Until(next))
+				}
+			}
+		}
+	}
+
+	archiveID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	secret := make([]byte, 32)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	now := time.Now()
+	archive := &gtsmodel.AccountArchive{
+		ID:        archiveID,
+		CreatedAt: now,
+		UpdatedAt: now,
+		AccountID: account.ID,
+		Status:    gtsmodel.AccountArchivePending,
+		Secret:    hex.EncodeToString(secret),
+	}
+	if err := p.db.Put(ctx, archive); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("ArchiveCreate: error putting archive: %s", err))
+	}
+
+	// generate the archive asynchronously
+	p.clientWorker.Queue(messages.FromClientAPI{
+		APObjectType:   ap.ObjectCollection,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       archive,
+		OriginAccount:  account,
+	})
+
+	return p.archiveToAPI(archive), nil
+}
+
+func (p *processor) ArchiveGet(ctx context.Context, account *gtsmodel.Account) (*apimodel.AccountArchive, gtserror.WithCode) {
+	latest, err := p.latestArchive(ctx, account.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("ArchiveGet: error fetching archives: %s", err))
+	}
+
+	if latest == nil {
+		return nil, gtserror.NewErrorNotFound(errors.New("ArchiveGet: no archive found"), "no archive has been requested")
+	}
+
+	return p.archiveToAPI(latest), nil
+}
+
+func (p *processor) ArchiveDownload(ctx context.Context, archiveID string, expires string, signature string) (*apimodel.Content, gtserror.WithCode) {
+	archive := &gtsmodel.AccountArchive{}
+	if err := p.db.GetByID(ctx, archiveID, archive); err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("ArchiveDownload: archive %s not found", archiveID))
+		}
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("ArchiveDownload: error fetching archive %s: %s", archiveID, err))
+	}
+
+	expiresAt, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || !hmac.Equal([]byte(signArchive(archive, expiresAt)), []byte(signature)) {
+		return nil, gtserror.NewErrorForbidden(fmt.Errorf("ArchiveDownload: bad signature for archive %s", archiveID), "download link is invalid")
+	}
+
+	if time.Now().Unix() > expiresAt {
+		return nil, gtserror.NewErrorForbidden(fmt.Errorf("ArchiveDownload: link for archive %s expired", archiveID), "download link has expired")
+	}
+
+	if archive.Status != gtsmodel.AccountArchiveReady {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("ArchiveDownload: archive %s is not ready", archiveID))
+	}
+
+	reader, err := p.storage.GetStream(archive.Path)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("ArchiveDownload: error fetching archive %s from storage: %s", archiveID, err))
+	}
+
+	return &apimodel.Content{
+		ContentType:   "application/zip",
+		ContentLength: int64(archive.Size),
+		Content:       reader,
+	}, nil
+}
+
+// latestArchive returns the most recently requested archive of the given account, or nil if there isn't one.
+// If the archive has been pending for longer than archivePendingTimeout, it's marked as failed first.
+func (p *processor) latestArchive(ctx context.Context, accountID string) (*gtsmodel.AccountArchive, error) {
+	archives := []*gtsmodel.AccountArchive{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: accountID}}, &archives); err != nil && err != db.ErrNoEntries {
+		return nil, err
+	}
+
+	var latest *gtsmodel.AccountArchive
+	for _, a := range archives {
+		// ulids sort by creation time
+		if latest == nil || a.ID > latest.ID {
+			latest = a
+		}
+	}
+
+	if latest != nil && latest.Status == gtsmodel.AccountArchivePending && time.Since(latest.UpdatedAt) > archivePendingTimeout {
+		// generating the archive was interrupted, or is taking far too long, so give up on it
+		latest.Status = gtsmodel.AccountArchiveFailed
+		latest.UpdatedAt = time.Now()
+		if err := p.db.UpdateByPrimaryKey(ctx, latest); err != nil {
+			return nil, err
+		}
+	}
+
+	return latest, nil
+}
+
+// deleteArchives removes all of the given account's archives from storage and the database, except for the one with keepID.
+func (p *processor) deleteArchives(ctx context.Context, accountID string, keepID string) error {
+	archives := []*gtsmodel.AccountArchive{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: accountID}}, &archives); err != nil {
+		if err == db.ErrNoEntries {
+			return nil
+		}
+		return err
+	}
+
+	for _, a := range archives {
+		if a.ID == keepID {
+			continue
+		}
+		if a.Path != "" {
+			if err := p.storage.Delete(a.Path); err != nil {
+				logrus.Errorf("deleteArchives: error removing archive %s from storage: %s", a.ID, err)
+			}
+		}
+		if err := p.db.DeleteByID(ctx, a.ID, &gtsmodel.AccountArchive{}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// archiveToAPI converts the given archive to its api representation, including a freshly signed download link if it's ready.
+func (p *processor) archiveToAPI(archive *gtsmodel.AccountArchive) *apimodel.AccountArchive {
+	apiArchive := &apimodel.AccountArchive{
+		ID:        archive.ID,
+		Status:    string(archive.Status),
+		CreatedAt: archive.CreatedAt.Format(time.RFC3339),
+	}
+
+	if archive.Status == gtsmodel.AccountArchiveReady {
+		expires := time.Now().Add(archiveLinkExpiry).Unix()
+		apiArchive.Size = archive.Size
+		apiArchive.URL = fmt.Sprintf("%s://%s"+archiveDownloadPath+"?expires=%d&signature=%s",
+			viper.GetString(config.Keys.Protocol), viper.GetString(config.Keys.Host), archive.ID, expires, signArchive(archive, expires))
+	}
+
+	return apiArchive
+}
+
+// signArchive returns a signature for a download link to the given archive which expires at the given unix time.
+func signArchive(archive *gtsmodel.AccountArchive, expires int64) string {
+	mac := hmac.New(sha256.New, []byte(archive.Secret))
+	mac.Write([]byte(fmt.Sprintf("%s:%d", archive.ID, expires)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+func (p *processor) ArchiveProcess(ctx context.Context, account *gtsmodel.Account, archive *gtsmodel.AccountArchive) error {
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":      "ArchiveProcess",
+		"username":  account.Username,
+		"archiveID": archive.ID,
+	})
+
+	// the archive may have been given up on while it was waiting in the queue
+	if err := p.db.GetByID(ctx, archive.ID, archive); err != nil {
+		return fmt.Errorf("ArchiveProcess: error fetching archive %s: %s", archive.ID, err)
+	}
+	if archive.Status != gtsmodel.AccountArchivePending {
+		l.Debugf("archive is no longer pending, skipping")
+		return nil
+	}
+
+	storagePath := fmt.Sprintf("%s/archive/%s.zip", account.ID, archive.ID)
+
+	size, err := p.storeArchive(ctx, account, storagePath)
+	if err != nil {
+		l.Errorf("error generating archive: %s", err)
+		if err := p.storage.Delete(storagePath); err != nil {
+			l.Debugf("error removing partial archive from storage: %s", err)
+		}
+		archive.Status = gtsmodel.AccountArchiveFailed
+	} else {
+		archive.Status = gtsmodel.AccountArchiveReady
+		archive.Path = storagePath
+		archive.Size = size
+	}
+
+	archive.UpdatedAt = time.Now()
+	if err := p.db.UpdateByPrimaryKey(ctx, archive); err != nil {
+		return fmt.Errorf("ArchiveProcess: error updating archive %s: %s", archive.ID, err)
+	}
+
+	if archive.Status == gtsmodel.AccountArchiveReady {
+		if err := p.deleteArchives(ctx, account.ID, archive.ID); err != nil {
+			l.Errorf("error removing old archives: %s", err)
+		}
+		l.Infof("generated archive of %d bytes", size)
+	}
+	return nil
+}
+
+// countingReader counts the bytes read through it.
+type countingReader struct {
+	io.Reader
+	n int
+}
+
+func (c *countingReader) Read(b []byte) (int, error) {
+	n, err := c.Reader.Read(b)
+	c.n += n
+	return n, err
+}
+
+// storeArchive streams a zip of the given account's data into storage at the given path, returning its size.
+func (p *processor) storeArchive(ctx context.Context, account *gtsmodel.Account, storagePath string) (int, error) {
+	pr, pw := io.Pipe()
+	go func() {
+		pw.CloseWithError(p.writeArchive(ctx, account, pw))
+	}()
+
+	cr := &countingReader{Reader: pr}
+	err := p.storage.PutStream(storagePath, cr)
+
+	// make sure the writer finishes up if storage gave up early
+	pr.CloseWithError(err)
+	return cr.n, err
+}
+
+// writeArchive writes a zip of the given account's data to w. The zip contains:
+//
+//   - actor.json, the account's profile as an activitystreams Person
+//   - outbox.json, an activitystreams OrderedCollection of Create activities for the account's statuses (not including boosts)
+//   - following.json and blocks.json, activitystreams OrderedCollections of the uris of accounts followed and blocked by the account
+//   - media/, containing the account's avatar and header, and all media attached to the account's statuses
+func (p *processor) writeArchive(ctx context.Context, account *gtsmodel.Account, w io.Writer) error {
+	zw := zip.NewWriter(w)
+
+	person, err := p.tc.AccountToAS(ctx, account)
+	if err != nil {
+		return fmt.Errorf("writeArchive: error converting account: %s", err)
+	}
+	if err := writeArchiveActivityStreams(zw, "actor.json", person); err != nil {
+		return err
+	}
+
+	mediaIDs := []string{}
+	if account.AvatarMediaAttachmentID != "" {
+		mediaIDs = append(mediaIDs, account.AvatarMediaAttachmentID)
+	}
+	if account.HeaderMediaAttachmentID != "" {
+		mediaIDs = append(mediaIDs, account.HeaderMediaAttachmentID)
+	}
+
+	// page through all of the account's statuses, newest first
+	creates := []interface{}{}
+	maxID := ""
+	for {
+		statuses, err := p.db.GetAccountStatuses(ctx, account.ID, archivePageSize, false, true, maxID, "", false, false, false, false)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				break
+			}
+			return fmt.Errorf("writeArchive: error fetching statuses: %s", err)
+		}
+
+		for _, s := range statuses {
+			note, err := p.tc.StatusToAS(ctx, s)
+			if err != nil {
+				return fmt.Errorf("writeArchive: error converting status %s: %s", s.ID, err)
+			}
+			create, err := p.tc.WrapNoteInCreate(note, false)
+			if err != nil {
+				return fmt.Errorf("writeArchive: error wrapping status %s: %s", s.ID, err)
+			}
+			createI, err := streams.Serialize(create)
+			if err != nil {
+				return fmt.Errorf("writeArchive: error serializing status %s: %s", s.ID, err)
+			}
+			// the collection has the context already
+			delete(createI, "@context")
+			creates = append(creates, createI)
+			mediaIDs = append(mediaIDs, s.AttachmentIDs...)
+		}
+
+		maxID = statuses[len(statuses)-1].ID
+	}
+	if err := writeArchiveCollection(zw, "outbox.json", account.OutboxURI, creates); err != nil {
+		return err
+	}
+
+	follows, err := p.db.GetAccountFollows(ctx, account.ID)
+	if err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("writeArchive: error fetching follows: %s", err)
+	}
+	following := []interface{}{}
+	for _, f := range follows {
+		if f.TargetAccount == nil {
+			if f.TargetAccount, err = p.db.GetAccountByID(ctx, f.TargetAccountID); err != nil {
+				return fmt.Errorf("writeArchive: error fetching followed account %s: %s", f.TargetAccountID, err)
+			}
+		}
+		following = append(following, f.TargetAccount.URI)
+	}
+	if err := writeArchiveCollection(zw, "following.json", account.FollowingURI, following); err != nil {
+		return err
+	}
+
+	blockedAccounts, _, _, err := p.db.GetAccountBlocks(ctx, account.ID, "", "", 0)
+	if err != nil && err != db.ErrNoEntries {
+		return fmt.Errorf("writeArchive: error fetching blocks: %s", err)
+	}
+	blocks := []interface{}{}
+	for _, a := range blockedAccounts {
+		blocks = append(blocks, a.URI)
+	}
+	if err := writeArchiveCollection(zw, "blocks.json", "", blocks); err != nil {
+		return err
+	}
+
+	for _, mediaID := range mediaIDs {
+		if err := p.writeArchiveMedia(ctx, zw, mediaID); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+// writeArchiveMedia copies the original file of the given media attachment from storage into the media folder of the zip.
+func (p *processor) writeArchiveMedia(ctx context.Context, zw *zip.Writer, attachmentID string) error {
+	attachment, err := p.db.GetAttachmentByID(ctx, attachmentID)
+	if err != nil {
+		return fmt.Errorf("writeArchiveMedia: error fetching attachment %s: %s", attachmentID, err)
+	}
+
+	if attachment.File.Path == "" || !attachment.Cached {
+		// nothing for us to copy
+		return nil
+	}
+
+	stored, err := p.storage.GetStream(attachment.File.Path)
+	if err != nil {
+		return fmt.Errorf("writeArchiveMedia: error fetching attachment %s from storage: %s", attachmentID, err)
+	}
+	defer stored.Close()
+
+	// media is already compressed, so just store it as it is
+	f, err := zw.CreateHeader(&zip.FileHeader{
+		Name:     path.Join("media", path.Base(attachment.File.Path)),
+		Method:   zip.Store,
+		Modified: attachment.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("writeArchiveMedia: error creating zip entry for attachment %s: %s", attachmentID, err)
+	}
+
+	if _, err := io.Copy(f, stored); err != nil {
+		return fmt.Errorf("writeArchiveMedia: error copying attachment %s: %s", attachmentID, err)
+	}
+	return nil
+}
+
+// writeArchiveActivityStreams serializes the given activitystreams type as json into a file in the zip.
+func writeArchiveActivityStreams(zw *zip.Writer, name string, t vocab.Type) error {
+	m, err := streams.Serialize(t)
+	if err != nil {
+		return fmt.Errorf("writeArchiveActivityStreams: error serializing %s: %s", name, err)
+	}
+	return writeArchiveJSON(zw, name, m)
+}
+
+// writeArchiveCollection writes the given items as an activitystreams OrderedCollection into a file in the zip.
+func writeArchiveCollection(zw *zip.Writer, name string, collectionID string, items []interface{}) error {
+	collection := map[string]interface{}{
+		"@context":     activityStreamsContext,
+		"type":         "OrderedCollection",
+		"totalItems":   len(items),
+		"orderedItems": items,
+	}
+	if collectionID != "" {
+		collection["id"] = collectionID
+	}
+	return writeArchiveJSON(zw, name, collection)
+}
+
+// writeArchiveJSON writes v as json into a file in the zip.
+func writeArchiveJSON(zw *zip.Writer, name string, v interface{}) error {
+	f, err := zw.Create(name)
+	if err != nil {
+		return fmt.Errorf("writeArchiveJSON: error creating zip entry %s: %s", name, err)
+	}
+
+	enc := json.NewEncoder(f)
+	enc.SetIndent("", "  ")
+	if err := enc.Encode(v); err != nil {
+		return fmt.Errorf("writeArchiveJSON: error writing %s: %s", name, err)
+	}
+	return nil
+}
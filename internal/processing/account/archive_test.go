@@ -0,0 +1,213 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type AccountArchiveTestSuite struct {
+	AccountStandardTestSuite
+}
+
+// waitForArchive processes the archive queued by ArchiveCreate, and returns the account's latest archive.
+func (suite *AccountArchiveTestSuite) waitForArchive() *apimodel.AccountArchive {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	// archives are generated in the background
+	msg := <-suite.fromClientAPIChan
+	dbArchive, ok := msg.GTSModel.(*gtsmodel.AccountArchive)
+	suite.True(ok)
+	suite.NoError(suite.accountProcessor.ArchiveProcess(context.Background(), msg.OriginAccount, dbArchive))
+
+	archive, errWithCode := suite.accountProcessor.ArchiveGet(context.Background(), testAccount)
+	suite.NoError(errWithCode)
+	return archive
+}
+
+func (suite *AccountArchiveTestSuite) download(archive *apimodel.AccountArchive) *zip.Reader {
+	u, err := url.Parse(archive.URL)
+	suite.NoError(err)
+
+	content, errWithCode := suite.accountProcessor.ArchiveDownload(context.Background(), archive.ID, u.Query().Get("expires"), u.Query().Get("signature"))
+	suite.NoError(errWithCode)
+	suite.Equal("application/zip", content.ContentType)
+
+	b, err := io.ReadAll(content.Content)
+	suite.NoError(err)
+	suite.NoError(content.Content.(io.Closer).Close())
+	suite.EqualValues(content.ContentLength, len(b))
+
+	zr, err := zip.NewReader(bytes.NewReader(b), int64(len(b)))
+	suite.NoError(err)
+	return zr
+}
+
+func (suite *AccountArchiveTestSuite) TestArchiveCreate() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	archive, errWithCode := suite.accountProcessor.ArchiveCreate(context.Background(), testAccount)
+	suite.NoError(errWithCode)
+	suite.Equal("pending", archive.Status)
+	suite.Empty(archive.URL)
+
+	archive = suite.waitForArchive()
+	suite.Equal("ready", archive.Status)
+	suite.NotZero(archive.Size)
+	suite.True(strings.HasPrefix(archive.URL, "http://localhost:8080/api/v1/accounts/archive/"+archive.ID+"/download?"))
+
+	zr := suite.download(archive)
+	files := map[string]*zip.File{}
+	media := 0
+	for _, f := range zr.File {
+		files[f.Name] = f
+		if strings.HasPrefix(f.Name, "media/") {
+			media++
+		}
+	}
+	for _, name := range []string{"actor.json", "outbox.json", "following.json", "blocks.json"} {
+		suite.Contains(files, name)
+	}
+	suite.NotZero(media)
+
+	r, err := files["outbox.json"].Open()
+	suite.NoError(err)
+	outbox := map[string]interface{}{}
+	suite.NoError(json.NewDecoder(r).Decode(&outbox))
+	suite.Equal("OrderedCollection", outbox["type"])
+	suite.Equal(testAccount.OutboxURI, outbox["id"])
+
+	items, ok := outbox["orderedItems"].([]interface{})
+	suite.True(ok)
+	suite.NotEmpty(items)
+	suite.EqualValues(len(items), outbox["totalItems"])
+	for _, i := range items {
+		item := i.(map[string]interface{})
+		suite.Equal("Create", item["type"])
+		suite.NotContains(item, "@context")
+	}
+}
+
+func (suite *AccountArchiveTestSuite) TestArchiveCreateTooSoon() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	_, errWithCode := suite.accountProcessor.ArchiveCreate(context.Background(), testAccount)
+	suite.NoError(errWithCode)
+
+	// while the archive is pending, asking again just returns the same archive
+	archive := suite.waitForArchive()
+	suite.Equal("ready", archive.Status)
+
+	// now that it's ready, we have to wait for the interval to pass
+	_, errWithCode = suite.accountProcessor.ArchiveCreate(context.Background(), testAccount)
+	suite.Error(errWithCode)
+	suite.Equal(http.StatusTooManyRequests, errWithCode.Code())
+	suite.Greater(errWithCode.RetryAfter(), time.Duration(0))
+
+	// unless the interval is disabled
+	viper.Set(config.Keys.AccountsArchiveInterval, 0)
+	newArchive, errWithCode := suite.accountProcessor.ArchiveCreate(context.Background(), testAccount)
+	suite.NoError(errWithCode)
+	suite.NotEqual(archive.ID, newArchive.ID)
+
+	// the old archive should be gone once the new one is ready
+	suite.waitForArchive()
+	_, errWithCode = suite.accountProcessor.ArchiveDownload(context.Background(), archive.ID, "0", "")
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *AccountArchiveTestSuite) TestArchiveCreateStalePending() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	archive, errWithCode := suite.accountProcessor.ArchiveCreate(ctx, testAccount)
+	suite.NoError(errWithCode)
+	msg := <-suite.fromClientAPIChan
+
+	// while the archive is pending, asking again just returns the same archive
+	again, errWithCode := suite.accountProcessor.ArchiveCreate(ctx, testAccount)
+	suite.NoError(errWithCode)
+	suite.Equal(archive.ID, again.ID)
+
+	// the instance restarted before the archive was generated, so it's stuck pending
+	suite.NoError(suite.db.UpdateWhere(ctx, []db.Where{{Key: "id", Value: archive.ID}}, "updated_at", time.Now().Add(-24*time.Hour), &gtsmodel.AccountArchive{}))
+
+	stale, errWithCode := suite.accountProcessor.ArchiveGet(ctx, testAccount)
+	suite.NoError(errWithCode)
+	suite.Equal("failed", stale.Status)
+
+	// so a new one can be requested
+	newArchive, errWithCode := suite.accountProcessor.ArchiveCreate(ctx, testAccount)
+	suite.NoError(errWithCode)
+	suite.NotEqual(archive.ID, newArchive.ID)
+	suite.Equal("pending", newArchive.Status)
+
+	// and the stale one is skipped if it turns up in the queue after all
+	suite.NoError(suite.accountProcessor.ArchiveProcess(ctx, msg.OriginAccount, msg.GTSModel.(*gtsmodel.AccountArchive)))
+	dbArchive := &gtsmodel.AccountArchive{}
+	suite.NoError(suite.db.GetByID(ctx, archive.ID, dbArchive))
+	suite.Equal(gtsmodel.AccountArchiveFailed, dbArchive.Status)
+	suite.Empty(dbArchive.Path)
+}
+
+func (suite *AccountArchiveTestSuite) TestArchiveDownloadBadSignature() {
+	testAccount := suite.testAccounts["local_account_1"]
+
+	_, errWithCode := suite.accountProcessor.ArchiveCreate(context.Background(), testAccount)
+	suite.NoError(errWithCode)
+	archive := suite.waitForArchive()
+
+	u, err := url.Parse(archive.URL)
+	suite.NoError(err)
+	expires := u.Query().Get("expires")
+	signature := u.Query().Get("signature")
+
+	// tampered signature
+	_, errWithCode = suite.accountProcessor.ArchiveDownload(context.Background(), archive.ID, expires, signature[1:]+"0")
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// tampered expiry
+	_, errWithCode = suite.accountProcessor.ArchiveDownload(context.Background(), archive.ID, expires+"0", signature)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+}
+
+func (suite *AccountArchiveTestSuite) TestArchiveGetNone() {
+	_, errWithCode := suite.accountProcessor.ArchiveGet(context.Background(), suite.testAccounts["local_account_2"])
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestAccountArchiveTestSuite(t *testing.T) {
+	suite.Run(t, &AccountArchiveTestSuite{})
+}
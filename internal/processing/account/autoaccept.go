@@ -0,0 +1,116 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/viper"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// maxAutoAcceptDomains is the maximum number of domains an account can accept follow requests from automatically.
+const maxAutoAcceptDomains = 100
+
+func (p *processor) AutoAcceptsFollowRequest(ctx context.Context, targetAccount *gtsmodel.Account, requestingAccount *gtsmodel.Account) (bool, error) {
+	if targetAccount.AutoAcceptFollowed {
+		follows, err := p.db.IsFollowing(ctx, targetAccount, requestingAccount)
+		if err != nil {
+			return false, fmt.Errorf("AutoAcceptsFollowRequest: error checking follow in db: %s", err)
+		}
+		if follows {
+			return true, nil
+		}
+	}
+
+	domain := strings.ToLower(requestingAccount.Domain)
+	if domain == "" {
+		domain = strings.ToLower(viper.GetString(config.Keys.Host))
+	}
+
+	for _, allowed := range targetAccount.AutoAcceptDomains {
+		if domain == allowed || strings.HasSuffix(domain, "."+allowed) {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+func (p *processor) AutoAcceptGet(ctx context.Context, account *gtsmodel.Account) (*apimodel.FollowRequestAutoAccept, gtserror.WithCode) {
+	domains := account.AutoAcceptDomains
+	if domains == nil {
+		domains = []string{}
+	}
+
+	return &apimodel.FollowRequestAutoAccept{
+		Followed: account.AutoAcceptFollowed,
+		Domains:  domains,
+	}, nil
+}
+
+func (p *processor) AutoAcceptUpdate(ctx context.Context, account *gtsmodel.Account, form *apimodel.FollowRequestAutoAcceptUpdateRequest) (*apimodel.FollowRequestAutoAccept, gtserror.WithCode) {
+	if form.Followed != nil {
+		account.AutoAcceptFollowed = *form.Followed
+	}
+
+	if form.Domains != nil {
+		domains := []string{}
+		for _, domain := range *form.Domains {
+			// domains are always compared in lowercase
+			domain = strings.ToLower(strings.TrimSpace(domain))
+			if domain == "" || containsDomain(domains, domain) {
+				continue
+			}
+			if strings.ContainsAny(domain, " /@") {
+				err := fmt.Errorf("%s is not a valid domain", domain)
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+			domains = append(domains, domain)
+		}
+
+		if len(domains) > maxAutoAcceptDomains {
+			err := fmt.Errorf("no more than %d domains can be given, but %d were given", maxAutoAcceptDomains, len(domains))
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+
+		account.AutoAcceptDomains = domains
+	}
+
+	updatedAccount, err := p.db.UpdateAccount(ctx, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("AutoAcceptUpdate: error updating account %s: %s", account.ID, err))
+	}
+
+	return p.AutoAcceptGet(ctx, updatedAccount)
+}
+
+func containsDomain(domains []string, domain string) bool {
+	for _, d := range domains {
+		if d == domain {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,306 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"bytes"
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// blockImportMaxLines is the most accounts that can be listed in a single block import.
+const blockImportMaxLines = 10000
+
+func (p *processor) BlockImportCreate(ctx context.Context, account *gtsmodel.Account, form *apimodel.BlockImportCreateRequest) (*apimodel.BlockImport, gtserror.WithCode) {
+	if form.Data == nil || form.Data.Size == 0 {
+		return nil, gtserror.NewErrorBadRequest(errors.New("BlockImportCreate: no data provided"), "no file of accounts to block was provided")
+	}
+
+	latest, err := p.latestBlockImport(ctx, account.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("BlockImportCreate: error fetching block imports: %s", err))
+	}
+	if latest != nil && latest.Status == gtsmodel.BlockImportPending {
+		err := fmt.Errorf("BlockImportCreate: block import %s is still pending", latest.ID)
+		return nil, gtserror.NewErrorConflict(err, "a previous import is still being processed")
+	}
+
+	f, err := form.Data.Open()
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("BlockImportCreate: error opening data: %s", err))
+	}
+	defer f.Close()
+
+	lines, err := parseBlockImport(f)
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("BlockImportCreate: error parsing data: %s", err), err.Error())
+	}
+	if len(lines) == 0 {
+		return nil, gtserror.NewErrorBadRequest(errors.New("BlockImportCreate: no accounts in data"), "the provided file didn't list any accounts")
+	}
+	if len(lines) > blockImportMaxLines {
+		err := fmt.Errorf("BlockImportCreate: %d accounts in data, the maximum is %d", len(lines), blockImportMaxLines)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, fmt.Sprintf("you can't import more than %d accounts at once", blockImportMaxLines))
+	}
+
+	importID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	now := time.Now()
+	blockImport := &gtsmodel.BlockImport{
+		ID:        importID,
+		CreatedAt: now,
+		UpdatedAt: now,
+		AccountID: account.ID,
+		Status:    gtsmodel.BlockImportPending,
+		Lines:     lines,
+	}
+	if err := p.db.Put(ctx, blockImport); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("BlockImportCreate: error putting block import: %s", err))
+	}
+
+	// blocking remote accounts may mean webfingering and dereferencing
+	// them, which can be slow, so we do the blocking asynchronously
+	p.clientWorker.Queue(messages.FromClientAPI{
+		APObjectType:   ap.ActivityBlock,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       blockImport,
+		OriginAccount:  account,
+	})
+
+	return blockImportToAPI(blockImport), nil
+}
+
+func (p *processor) BlockImportGet(ctx context.Context, account *gtsmodel.Account) (*apimodel.BlockImport, gtserror.WithCode) {
+	latest, err := p.latestBlockImport(ctx, account.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("BlockImportGet: error fetching block imports: %s", err))
+	}
+
+	if latest == nil {
+		return nil, gtserror.NewErrorNotFound(errors.New("BlockImportGet: no block import found"), "no accounts to block have been imported")
+	}
+
+	return blockImportToAPI(latest), nil
+}
+
+func (p *processor) BlockImportProcess(ctx context.Context, account *gtsmodel.Account, blockImport *gtsmodel.BlockImport) error {
+	l := logrus.WithFields(logrus.Fields{
+		"func":          "BlockImportProcess",
+		"username":      account.Username,
+		"blockImportID": blockImport.ID,
+	})
+
+	for i := range blockImport.Lines {
+		line := &blockImport.Lines[i]
+		if line.Result != gtsmodel.BlockImportLinePending {
+			continue
+		}
+
+		if err := p.blockImportLine(ctx, account, line); err != nil {
+			l.Debugf("error blocking %s from line %d: %s", line.Handle, line.Line, err)
+		}
+
+		// store progress as we go, so that the account can see how the import is getting on
+		blockImport.UpdatedAt = time.Now()
+		if err := p.db.UpdateByPrimaryKey(ctx, blockImport); err != nil {
+			return fmt.Errorf("BlockImportProcess: error updating block import %s: %s", blockImport.ID, err)
+		}
+	}
+
+	blockImport.Status = gtsmodel.BlockImportDone
+	blockImport.UpdatedAt = time.Now()
+	if err := p.db.UpdateByPrimaryKey(ctx, blockImport); err != nil {
+		return fmt.Errorf("BlockImportProcess: error updating block import %s: %s", blockImport.ID, err)
+	}
+
+	return nil
+}
+
+func (p *processor) BlocksExport(ctx context.Context, account *gtsmodel.Account) (*apimodel.Content, gtserror.WithCode) {
+	blocked, _, _, err := p.db.GetAccountBlocks(ctx, account.ID, "", "", 0)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("BlocksExport: error fetching blocks: %s", err))
+	}
+
+	buf := &bytes.Buffer{}
+	w := csv.NewWriter(buf)
+	for _, b := range blocked {
+		if b == nil {
+			// the blocked account may have been removed
+			continue
+		}
+		if err := w.Write([]string{accountHandle(b)}); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("BlocksExport: error writing csv: %s", err))
+		}
+	}
+	w.Flush()
+	if err := w.Error(); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("BlocksExport: error writing csv: %s", err))
+	}
+
+	return &apimodel.Content{
+		ContentType:   "text/csv",
+		ContentLength: int64(buf.Len()),
+		Content:       buf,
+	}, nil
+}
+
+// blockImportLine blocks the account on the given line of a block import, recording the outcome on the line.
+// The returned error is only for logging: the error recorded on the line is the one which is safe to show to the account.
+func (p *processor) blockImportLine(ctx context.Context, account *gtsmodel.Account, line *gtsmodel.BlockImportLine) error {
+	targetAccount, err := p.resolveImportHandle(ctx, account, line.Handle)
+	if err != nil {
+		line.Result = gtsmodel.BlockImportLineFailed
+		line.Error = "account not found"
+		return err
+	}
+
+	if targetAccount.ID == account.ID {
+		line.Result = gtsmodel.BlockImportLineSkipped
+		return nil
+	}
+
+	if blocked, err := p.db.IsBlocked(ctx, account.ID, targetAccount.ID, false); err != nil {
+		line.Result = gtsmodel.BlockImportLineFailed
+		line.Error = "internal error"
+		return err
+	} else if blocked {
+		line.Result = gtsmodel.BlockImportLineSkipped
+		return nil
+	}
+
+	if _, errWithCode := p.BlockCreate(ctx, account, targetAccount.ID); errWithCode != nil {
+		line.Result = gtsmodel.BlockImportLineFailed
+		line.Error = errWithCode.Safe()
+		return errWithCode
+	}
+
+	line.Result = gtsmodel.BlockImportLineBlocked
+	return nil
+}
+
+// latestBlockImport returns the most recent block import of the given account, or nil if there isn't one.
+func (p *processor) latestBlockImport(ctx context.Context, accountID string) (*gtsmodel.BlockImport, error) {
+	blockImports := []*gtsmodel.BlockImport{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: accountID}}, &blockImports); err != nil && err != db.ErrNoEntries {
+		return nil, err
+	}
+
+	var latest *gtsmodel.BlockImport
+	for _, b := range blockImports {
+		// ulids sort by creation time
+		if latest == nil || b.ID > latest.ID {
+			latest = b
+		}
+	}
+	return latest, nil
+}
+
+// parseBlockImport parses a block import in the csv format that Mastodon exports blocks in, ie., one
+// account address per line. A header line starting with "Account address" is skipped if it's present,
+// and any other columns are ignored. Lines with a handle that doesn't look right are marked as failed
+// straight away, while all other lines are left pending.
+func parseBlockImport(r io.Reader) ([]gtsmodel.BlockImportLine, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	lines := []gtsmodel.BlockImportLine{}
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read csv: %s", err)
+		}
+
+		handle := strings.TrimPrefix(strings.TrimSpace(record[0]), "@")
+		if handle == "" || (first && strings.EqualFold(handle, "account address")) {
+			continue
+		}
+
+		lineNumber, _ := reader.FieldPos(0)
+		line := gtsmodel.BlockImportLine{
+			Line:   lineNumber,
+			Handle: handle,
+			Result: gtsmodel.BlockImportLinePending,
+		}
+		if _, _, err := util.ExtractMentionParts("@" + handle); err != nil {
+			line.Result = gtsmodel.BlockImportLineFailed
+			line.Error = "invalid account address"
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
+// accountHandle returns the handle of the given account in the form username@domain, as used in exports.
+func accountHandle(account *gtsmodel.Account) string {
+	domain := account.Domain
+	if domain == "" {
+		domain = viper.GetString(config.Keys.AccountDomain)
+	}
+	if domain == "" {
+		domain = viper.GetString(config.Keys.Host)
+	}
+	return account.Username + "@" + domain
+}
+
+func blockImportToAPI(blockImport *gtsmodel.BlockImport) *apimodel.BlockImport {
+	apiBlockImport := &apimodel.BlockImport{
+		ID:        blockImport.ID,
+		Status:    string(blockImport.Status),
+		CreatedAt: blockImport.CreatedAt.Format(time.RFC3339),
+		Lines:     make([]apimodel.BlockImportLine, 0, len(blockImport.Lines)),
+	}
+
+	for _, line := range blockImport.Lines {
+		apiBlockImport.Lines = append(apiBlockImport.Lines, apimodel.BlockImportLine{
+			Line:   line.Line,
+			Handle: line.Handle,
+			Result: string(line.Result),
+			Error:  line.Error,
+		})
+	}
+
+	return apiBlockImport
+}
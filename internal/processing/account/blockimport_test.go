@@ -0,0 +1,107 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"mime/multipart"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type BlockImportTestSuite struct {
+	AccountStandardTestSuite
+}
+
+// blockImportForm returns a block import request with the given csv as its data.
+func (suite *BlockImportTestSuite) blockImportForm(data string) *apimodel.BlockImportCreateRequest {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	part, err := w.CreateFormFile("data", "blocked_accounts.csv")
+	suite.NoError(err)
+	_, err = part.Write([]byte(data))
+	suite.NoError(err)
+	suite.NoError(w.Close())
+
+	form, err := multipart.NewReader(body, w.Boundary()).ReadForm(int64(body.Len()))
+	suite.NoError(err)
+
+	return &apimodel.BlockImportCreateRequest{
+		Data: form.File["data"][0],
+	}
+}
+
+func (suite *BlockImportTestSuite) TestBlockImport() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_2"]
+
+	data := "1happyturtle\n" +
+		"the_mighty_zork\n" +
+		"foss_satan@fossbros-anonymous.io\n" +
+		"@some_user@example.org\n" +
+		"not a handle!!\n"
+
+	blockImport, errWithCode := suite.accountProcessor.BlockImportCreate(ctx, testAccount, suite.blockImportForm(data))
+	suite.NoError(errWithCode)
+	suite.Equal("pending", blockImport.Status)
+	suite.Len(blockImport.Lines, 5)
+	suite.Equal("some_user@example.org", blockImport.Lines[3].Handle)
+	suite.Equal("failed", blockImport.Lines[4].Result)
+
+	// the accounts are blocked in the background
+	msg := <-suite.fromClientAPIChan
+	dbBlockImport, ok := msg.GTSModel.(*gtsmodel.BlockImport)
+	suite.True(ok)
+
+	suite.NoError(suite.accountProcessor.BlockImportProcess(ctx, testAccount, dbBlockImport))
+
+	blockImport, errWithCode = suite.accountProcessor.BlockImportGet(ctx, testAccount)
+	suite.NoError(errWithCode)
+	suite.Equal("done", blockImport.Status)
+	suite.Equal("skipped", blockImport.Lines[0].Result) // self
+	suite.Equal("blocked", blockImport.Lines[1].Result)
+	suite.Equal("skipped", blockImport.Lines[2].Result) // already blocked
+	suite.Equal("blocked", blockImport.Lines[3].Result)
+	suite.Equal("failed", blockImport.Lines[4].Result)
+
+	blocked, err := suite.db.IsBlocked(ctx, testAccount.ID, suite.testAccounts["remote_account_2"].ID, false)
+	suite.NoError(err)
+	suite.True(blocked)
+}
+
+func (suite *BlockImportTestSuite) TestBlocksExport() {
+	testAccount := suite.testAccounts["local_account_2"]
+
+	content, errWithCode := suite.accountProcessor.BlocksExport(context.Background(), testAccount)
+	suite.NoError(errWithCode)
+	suite.Equal("text/csv", content.ContentType)
+
+	b, err := io.ReadAll(content.Content)
+	suite.NoError(err)
+	suite.Equal("foss_satan@fossbros-anonymous.io\n", string(b))
+}
+
+func TestBlockImportTestSuite(t *testing.T) {
+	suite.Run(t, &BlockImportTestSuite{})
+}
@@ -20,6 +20,7 @@ package account
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
 	"github.com/sirupsen/logrus"
@@ -28,29 +29,49 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/oauth2/v4"
 )
 
-func (p *processor) Create(ctx context.Context, applicationToken oauth2.TokenInfo, application *gtsmodel.Application, form *apimodel.AccountCreateRequest) (*apimodel.Token, error) {
+func (p *processor) Create(ctx context.Context, applicationToken oauth2.TokenInfo, application *gtsmodel.Application, form *apimodel.AccountCreateRequest) (*apimodel.Token, gtserror.WithCode) {
 	l := logrus.WithField("func", "accountCreate")
 
+	registrationsOpen, err := p.db.GetRegistrationsOpen(ctx)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error checking whether registrations are open: %s", err))
+	}
+	if !registrationsOpen {
+		err := errors.New("registration is not open for this server")
+		return nil, gtserror.NewErrorForbidden(err, err.Error())
+	}
+
+	if errWithCode := p.checkSignUpIP(ctx, form.IP); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := checkEmailDomain(form.Email); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	emailAvailable, err := p.db.IsEmailAvailable(ctx, form.Email)
 	if err != nil {
-		return nil, err
+		return nil, gtserror.NewErrorInternalError(err)
 	}
 	if !emailAvailable {
-		return nil, fmt.Errorf("email address %s in use", form.Email)
+		err := fmt.Errorf("email address %s in use", form.Email)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
 	}
 
 	usernameAvailable, err := p.db.IsUsernameAvailable(ctx, form.Username)
 	if err != nil {
-		return nil, err
+		return nil, gtserror.NewErrorInternalError(err)
 	}
 	if !usernameAvailable {
-		return nil, fmt.Errorf("username %s in use", form.Username)
+		err := fmt.Errorf("username %s in use", form.Username)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
 	}
 
 	keys := config.Keys
@@ -66,19 +87,24 @@ func (p *processor) Create(ctx context.Context, applicationToken oauth2.TokenInf
 	l.Trace("creating new username and account")
 	user, err := p.db.NewSignup(ctx, form.Username, text.RemoveHTML(reason), approvalRequired, form.Email, form.Password, form.IP, form.Locale, application.ID, false, false)
 	if err != nil {
-		return nil, fmt.Errorf("error creating new signup in the database: %s", err)
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error creating new signup in the database: %s", err))
+	}
+
+	if err := p.putSignUpIP(ctx, form.IP); err != nil {
+		// the account exists now, so don't fail the whole sign up because of this
+		l.Errorf("error recording sign up ip: %s", err)
 	}
 
 	l.Tracef("generating a token for user %s with account %s and application %s", user.ID, user.AccountID, application.ID)
 	accessToken, err := p.oauthServer.GenerateUserAccessToken(ctx, applicationToken, application.ClientSecret, user.ID)
 	if err != nil {
-		return nil, fmt.Errorf("error creating new access token for user %s: %s", user.ID, err)
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error creating new access token for user %s: %s", user.ID, err))
 	}
 
 	if user.Account == nil {
 		a, err := p.db.GetAccountByID(ctx, user.AccountID)
 		if err != nil {
-			return nil, fmt.Errorf("error getting new account from the database: %s", err)
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error getting new account from the database: %s", err))
 		}
 		user.Account = a
 	}
@@ -93,9 +119,11 @@ func (p *processor) Create(ctx context.Context, applicationToken oauth2.TokenInf
 	})
 
 	return &apimodel.Token{
-		AccessToken: accessToken.GetAccess(),
-		TokenType:   "Bearer",
-		Scope:       accessToken.GetScope(),
-		CreatedAt:   accessToken.GetAccessCreateAt().Unix(),
+		AccessToken:  accessToken.GetAccess(),
+		TokenType:    "Bearer",
+		Scope:        accessToken.GetScope(),
+		CreatedAt:    accessToken.GetAccessCreateAt().Unix(),
+		ExpiresIn:    int64(accessToken.GetAccessExpiresIn().Seconds()),
+		RefreshToken: accessToken.GetRefresh(),
 	}, nil
 }
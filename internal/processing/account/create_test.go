@@ -0,0 +1,186 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type AccountCreateTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *AccountCreateTestSuite) createForm(username string, email string, ip string) *apimodel.AccountCreateRequest {
+	return &apimodel.AccountCreateRequest{
+		Username:  username,
+		Email:     email,
+		Password:  "this is a very secure password 12345",
+		Agreement: true,
+		Locale:    "en",
+		IP:        net.ParseIP(ip),
+	}
+}
+
+func (suite *AccountCreateTestSuite) create(form *apimodel.AccountCreateRequest) (*apimodel.Token, int, string) {
+	token := oauth.DBTokenToToken(suite.testTokens["local_account_1"])
+	application := suite.testApplications["application_1"]
+
+	apiToken, errWithCode := suite.accountProcessor.Create(context.Background(), token, application, form)
+	if errWithCode != nil {
+		return nil, errWithCode.Code(), errWithCode.Safe()
+	}
+	return apiToken, http.StatusOK, ""
+}
+
+func (suite *AccountCreateTestSuite) TestCreate() {
+	apiToken, code, _ := suite.create(suite.createForm("new_user", "new_user@example.org", "192.0.2.1"))
+	suite.Equal(http.StatusOK, code)
+	suite.NotEmpty(apiToken.AccessToken)
+}
+
+func (suite *AccountCreateTestSuite) TestCreateEmailInUse() {
+	_, code, safe := suite.create(suite.createForm("new_user", suite.testUsers["local_account_1"].Email, "192.0.2.1"))
+	suite.Equal(http.StatusUnprocessableEntity, code)
+	suite.Equal("unprocessable entity: email address "+suite.testUsers["local_account_1"].Email+" in use", safe)
+}
+
+func (suite *AccountCreateTestSuite) TestCreateRegistrationsClosed() {
+	suite.NoError(suite.db.SetRegistrationsOpen(context.Background(), false))
+
+	_, code, safe := suite.create(suite.createForm("new_user", "new_user@example.org", "192.0.2.1"))
+	suite.Equal(http.StatusForbidden, code)
+	suite.Equal("forbidden: registration is not open for this server", safe)
+
+	// and they can be opened again
+	suite.NoError(suite.db.SetRegistrationsOpen(context.Background(), true))
+	_, code, _ = suite.create(suite.createForm("new_user", "new_user@example.org", "192.0.2.1"))
+	suite.Equal(http.StatusOK, code)
+}
+
+func (suite *AccountCreateTestSuite) TestCreateRegistrationsOpenedAtRuntime() {
+	// the runtime setting takes precedence over the config
+	viper.Set(config.Keys.AccountsRegistrationOpen, false)
+	suite.NoError(suite.db.SetRegistrationsOpen(context.Background(), true))
+
+	_, code, _ := suite.create(suite.createForm("new_user", "new_user@example.org", "192.0.2.1"))
+	suite.Equal(http.StatusOK, code)
+}
+
+func (suite *AccountCreateTestSuite) TestCreateBlockedEmailDomain() {
+	viper.Set(config.Keys.AccountsEmailDomainBlocklist, []string{"disposable.example"})
+
+	_, code, safe := suite.create(suite.createForm("new_user", "new_user@Disposable.Example", "192.0.2.1"))
+	suite.Equal(http.StatusUnprocessableEntity, code)
+	suite.Equal("unprocessable entity: sign ups from email domain disposable.example are not allowed", safe)
+
+	// subdomains of a blocked domain are blocked too
+	_, code, _ = suite.create(suite.createForm("new_user", "new_user@mail.disposable.example", "192.0.2.1"))
+	suite.Equal(http.StatusUnprocessableEntity, code)
+
+	// but domains that only end with the same string are not
+	_, code, _ = suite.create(suite.createForm("new_user", "new_user@notdisposable.example", "192.0.2.1"))
+	suite.Equal(http.StatusOK, code)
+}
+
+func (suite *AccountCreateTestSuite) TestCreateBlockedEmailDomainFromFile() {
+	path := filepath.Join(suite.T().TempDir(), "blocklist.txt")
+	suite.NoError(os.WriteFile(path, []byte("# disposable email providers\n\nthrowaway.example\n"), 0600))
+	viper.Set(config.Keys.AccountsEmailDomainBlocklistFile, path)
+
+	_, code, safe := suite.create(suite.createForm("new_user", "new_user@throwaway.example", "192.0.2.1"))
+	suite.Equal(http.StatusUnprocessableEntity, code)
+	suite.Equal("unprocessable entity: sign ups from email domain throwaway.example are not allowed", safe)
+}
+
+func (suite *AccountCreateTestSuite) TestCreateIPLimit() {
+	viper.Set(config.Keys.AccountsSignUpIPLimit, 2)
+
+	suite.create(suite.createForm("new_user_1", "new_user_1@example.org", "192.0.2.1"))
+	_, code, _ := suite.create(suite.createForm("new_user_2", "new_user_2@example.org", "192.0.2.1"))
+	suite.Equal(http.StatusOK, code)
+
+	_, code, safe := suite.create(suite.createForm("new_user_3", "new_user_3@example.org", "192.0.2.1"))
+	suite.Equal(http.StatusTooManyRequests, code)
+	suite.Equal("too many requests: sign up limit of 2 reached for this ip address", safe)
+
+	// a different ip address shouldn't be limited
+	_, code, _ = suite.create(suite.createForm("new_user_3", "new_user_3@example.org", "192.0.2.2"))
+	suite.Equal(http.StatusOK, code)
+
+	// only the hashes of the addresses should have been stored
+	signUpIPs := []*gtsmodel.SignUpIP{}
+	suite.NoError(suite.db.GetAll(context.Background(), &signUpIPs))
+	suite.Len(signUpIPs, 3)
+	for _, signUpIP := range signUpIPs {
+		suite.NotContains(signUpIP.IPHash, "192.0.2")
+	}
+}
+
+func (suite *AccountCreateTestSuite) TestCreateIPLimitIPv6() {
+	viper.Set(config.Keys.AccountsSignUpIPLimit, 1)
+
+	_, code, _ := suite.create(suite.createForm("new_user_1", "new_user_1@example.org", "2001:db8:1:1::1"))
+	suite.Equal(http.StatusOK, code)
+
+	// addresses in the same /64 count as the same address
+	_, code, _ = suite.create(suite.createForm("new_user_2", "new_user_2@example.org", "2001:db8:1:1::2"))
+	suite.Equal(http.StatusTooManyRequests, code)
+
+	_, code, _ = suite.create(suite.createForm("new_user_2", "new_user_2@example.org", "2001:db8:1:2::1"))
+	suite.Equal(http.StatusOK, code)
+}
+
+func (suite *AccountCreateTestSuite) TestCreateIPLimitWindow() {
+	viper.Set(config.Keys.AccountsSignUpIPLimit, 1)
+
+	_, code, _ := suite.create(suite.createForm("new_user_1", "new_user_1@example.org", "192.0.2.1"))
+	suite.Equal(http.StatusOK, code)
+
+	// move the existing sign up to before the window
+	signUpIPs := []*gtsmodel.SignUpIP{}
+	suite.NoError(suite.db.GetAll(context.Background(), &signUpIPs))
+	suite.Len(signUpIPs, 1)
+	signUpIPs[0].CreatedAt = time.Now().Add(-25 * time.Hour)
+	suite.NoError(suite.db.UpdateByPrimaryKey(context.Background(), signUpIPs[0]))
+
+	_, code, _ = suite.create(suite.createForm("new_user_2", "new_user_2@example.org", "192.0.2.1"))
+	suite.Equal(http.StatusOK, code)
+
+	// the old sign up should have been pruned
+	signUpIPs = []*gtsmodel.SignUpIP{}
+	suite.NoError(suite.db.GetAll(context.Background(), &signUpIPs))
+	suite.Len(signUpIPs, 1)
+}
+
+func TestAccountCreateTestSuite(t *testing.T) {
+	suite.Run(t, &AccountCreateTestSuite{})
+}
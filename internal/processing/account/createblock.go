@@ -59,56 +59,95 @@ func (p *processor) BlockCreate(ctx context.Context, requestingAccount *gtsmodel
 	block.TargetAccount = targetAccount
 	block.URI = uris.GenerateURIForBlock(requestingAccount.Username, newBlockID)
 
-	// whack it in the database
-	if err := p.db.Put(ctx, block); err != nil {
-		return nil, gtserror.NewErrorInternalError(fmt.Errorf("BlockCreate: error creating block in db: %s", err))
-	}
+	// the block goes in, and any follows or follow requests between the two accounts go out, all together or not at all
+	var reverseFRs []*gtsmodel.FollowRequest
+	var frChanged, fChanged bool
+	var frURI, fURI string
+	if err := p.db.RunInTx(ctx, func(tx db.DB) error {
+		// whack it in the database
+		if err := tx.Put(ctx, block); err != nil {
+			return fmt.Errorf("BlockCreate: error creating block in db: %s", err)
+		}
 
-	// clear any follows or follow requests from the blocked account to the target account -- this is a simple delete
-	if err := p.db.DeleteWhere(ctx, []db.Where{
-		{Key: "account_id", Value: targetAccountID},
-		{Key: "target_account_id", Value: requestingAccount.ID},
-	}, &gtsmodel.Follow{}); err != nil {
-		return nil, gtserror.NewErrorInternalError(fmt.Errorf("BlockCreate: error removing follow in db: %s", err))
-	}
-	if err := p.db.DeleteWhere(ctx, []db.Where{
-		{Key: "account_id", Value: targetAccountID},
-		{Key: "target_account_id", Value: requestingAccount.ID},
-	}, &gtsmodel.FollowRequest{}); err != nil {
-		return nil, gtserror.NewErrorInternalError(fmt.Errorf("BlockCreate: error removing follow in db: %s", err))
-	}
+		// clear any follows or follow requests from the target account to the requesting account -- if the target
+		// account is remote, we need to let its instance know that it doesn't follow us anymore, so reject them
+
+		// check if a follow request exists from the target account to the requesting account, and remove it if it does
+		reverseFR := &gtsmodel.FollowRequest{}
+		if err := tx.GetWhere(ctx, []db.Where{
+			{Key: "account_id", Value: targetAccountID},
+			{Key: "target_account_id", Value: requestingAccount.ID},
+		}, reverseFR); err == nil {
+			if err := tx.DeleteByID(ctx, reverseFR.ID, reverseFR); err != nil {
+				return fmt.Errorf("BlockCreate: error removing follow request from db: %s", err)
+			}
+			reverseFRs = append(reverseFRs, reverseFR)
+		}
 
-	// clear any follows or follow requests from the requesting account to the target account --
-	// this might require federation so we need to pass some messages around
-
-	// check if a follow request exists from the requesting account to the target account, and remove it if it does (storing the URI for later)
-	var frChanged bool
-	var frURI string
-	fr := &gtsmodel.FollowRequest{}
-	if err := p.db.GetWhere(ctx, []db.Where{
-		{Key: "account_id", Value: requestingAccount.ID},
-		{Key: "target_account_id", Value: targetAccountID},
-	}, fr); err == nil {
-		frURI = fr.URI
-		if err := p.db.DeleteByID(ctx, fr.ID, fr); err != nil {
-			return nil, gtserror.NewErrorInternalError(fmt.Errorf("BlockCreate: error removing follow request from db: %s", err))
+		// now do the same thing for any existing follow
+		reverseF := &gtsmodel.Follow{}
+		if err := tx.GetWhere(ctx, []db.Where{
+			{Key: "account_id", Value: targetAccountID},
+			{Key: "target_account_id", Value: requestingAccount.ID},
+		}, reverseF); err == nil {
+			if err := tx.DeleteByID(ctx, reverseF.ID, reverseF); err != nil {
+				return fmt.Errorf("BlockCreate: error removing follow from db: %s", err)
+			}
+			reverseFRs = append(reverseFRs, &gtsmodel.FollowRequest{
+				ID:              reverseF.ID,
+				URI:             reverseF.URI,
+				AccountID:       reverseF.AccountID,
+				TargetAccountID: reverseF.TargetAccountID,
+			})
 		}
-		frChanged = true
+
+		// clear any follows or follow requests from the requesting account to the target account --
+		// this might require federation so we need to pass some messages around
+
+		// check if a follow request exists from the requesting account to the target account, and remove it if it does (storing the URI for later)
+		fr := &gtsmodel.FollowRequest{}
+		if err := tx.GetWhere(ctx, []db.Where{
+			{Key: "account_id", Value: requestingAccount.ID},
+			{Key: "target_account_id", Value: targetAccountID},
+		}, fr); err == nil {
+			frURI = fr.URI
+			if err := tx.DeleteByID(ctx, fr.ID, fr); err != nil {
+				return fmt.Errorf("BlockCreate: error removing follow request from db: %s", err)
+			}
+			frChanged = true
+		}
+
+		// now do the same thing for any existing follow
+		f := &gtsmodel.Follow{}
+		if err := tx.GetWhere(ctx, []db.Where{
+			{Key: "account_id", Value: requestingAccount.ID},
+			{Key: "target_account_id", Value: targetAccountID},
+		}, f); err == nil {
+			fURI = f.URI
+			if err := tx.DeleteByID(ctx, f.ID, f); err != nil {
+				return fmt.Errorf("BlockCreate: error removing follow from db: %s", err)
+			}
+			fChanged = true
+		}
+
+		return nil
+	}); err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	// now do the same thing for any existing follow
-	var fChanged bool
-	var fURI string
-	f := &gtsmodel.Follow{}
-	if err := p.db.GetWhere(ctx, []db.Where{
-		{Key: "account_id", Value: requestingAccount.ID},
-		{Key: "target_account_id", Value: targetAccountID},
-	}, f); err == nil {
-		fURI = f.URI
-		if err := p.db.DeleteByID(ctx, f.ID, f); err != nil {
-			return nil, gtserror.NewErrorInternalError(fmt.Errorf("BlockCreate: error removing follow from db: %s", err))
+	// follows of local accounts are just gone, but remote ones have to be rejected
+	if targetAccount.Domain != "" {
+		for _, fr := range reverseFRs {
+			fr.Account = targetAccount
+			fr.TargetAccount = requestingAccount
+			p.clientWorker.Queue(messages.FromClientAPI{
+				APObjectType:   ap.ActivityFollow,
+				APActivityType: ap.ActivityReject,
+				GTSModel:       fr,
+				OriginAccount:  targetAccount,
+				TargetAccount:  requestingAccount,
+			})
 		}
-		fChanged = true
 	}
 
 	// follow request status changed so send the UNDO activity to the channel for async processing
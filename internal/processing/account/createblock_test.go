@@ -0,0 +1,100 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+type BlockCreateTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *BlockCreateTestSuite) TestBlockMutualRemoteFollow() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetAccount := suite.testAccounts["remote_account_1"]
+
+	// zork and foss_satan follow each other
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.Follow{
+		ID:              "01G5ESN9W7G3Y0MA2PTDX8Q6KZ",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		URI:             "http://localhost:8080/users/the_mighty_zork/follow/01G5ESN9W7G3Y0MA2PTDX8Q6KZ",
+		AccountID:       requestingAccount.ID,
+		TargetAccountID: targetAccount.ID,
+	}))
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.Follow{
+		ID:              "01G5ESP3D1JH4S8RBQZ2VW0XTN",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		URI:             "http://fossbros-anonymous.io/users/foss_satan/follows/01G5ESP3D1JH4S8RBQZ2VW0XTN",
+		AccountID:       targetAccount.ID,
+		TargetAccountID: requestingAccount.ID,
+	}))
+
+	relationship, errWithCode := suite.accountProcessor.BlockCreate(ctx, requestingAccount, targetAccount.ID)
+	suite.NoError(errWithCode)
+	suite.True(relationship.Blocking)
+	suite.False(relationship.Following)
+	suite.False(relationship.FollowedBy)
+
+	// both follows should be gone
+	following, err := suite.db.IsFollowing(ctx, requestingAccount, targetAccount)
+	suite.NoError(err)
+	suite.False(following)
+	followedBy, err := suite.db.IsFollowing(ctx, targetAccount, requestingAccount)
+	suite.NoError(err)
+	suite.False(followedBy)
+
+	// their follow of us should be rejected, our follow of them should be undone, and the block should be federated
+	msgs := []messages.FromClientAPI{<-suite.fromClientAPIChan, <-suite.fromClientAPIChan, <-suite.fromClientAPIChan}
+
+	suite.Equal(ap.ActivityReject, msgs[0].APActivityType)
+	suite.Equal(ap.ActivityFollow, msgs[0].APObjectType)
+	reject := msgs[0].GTSModel.(*gtsmodel.FollowRequest)
+	suite.Equal("http://fossbros-anonymous.io/users/foss_satan/follows/01G5ESP3D1JH4S8RBQZ2VW0XTN", reject.URI)
+	suite.Equal(targetAccount.ID, reject.AccountID)
+	suite.Equal(requestingAccount.ID, reject.TargetAccountID)
+
+	suite.Equal(ap.ActivityUndo, msgs[1].APActivityType)
+	suite.Equal(ap.ActivityFollow, msgs[1].APObjectType)
+	suite.Equal("http://localhost:8080/users/the_mighty_zork/follow/01G5ESN9W7G3Y0MA2PTDX8Q6KZ", msgs[1].GTSModel.(*gtsmodel.Follow).URI)
+
+	suite.Equal(ap.ActivityCreate, msgs[2].APActivityType)
+	suite.Equal(ap.ActivityBlock, msgs[2].APObjectType)
+
+	// unblocking shouldn't bring any of the follows back
+	relationship, errWithCode = suite.accountProcessor.BlockRemove(ctx, requestingAccount, targetAccount.ID)
+	suite.NoError(errWithCode)
+	suite.False(relationship.Blocking)
+	suite.False(relationship.Following)
+	suite.False(relationship.FollowedBy)
+}
+
+func TestBlockCreateTestSuite(t *testing.T) {
+	suite.Run(t, &BlockCreateTestSuite{})
+}
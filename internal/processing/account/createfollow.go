@@ -20,19 +20,38 @@ package account
 
 import (
 	"context"
+	"errors"
 	"fmt"
 
+	"github.com/spf13/viper"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
 func (p *processor) FollowCreate(ctx context.Context, requestingAccount *gtsmodel.Account, form *apimodel.AccountFollowRequest) (*apimodel.Relationship, gtserror.WithCode) {
+	// accounts can't follow themselves
+	if form.ID == requestingAccount.ID {
+		return nil, gtserror.NewErrorUnprocessableEntity(errors.New("accountfollowcreate: account tried to follow itself"), "you can't follow yourself")
+	}
+
+	// very new accounts can't follow others, to make throwaway accounts less useful for spam
+	user := &gtsmodel.User{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: requestingAccount.ID}}, user); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("accountfollowcreate: error getting user for account %s: %s", requestingAccount.ID, err))
+	}
+	if util.AccountTooNew(requestingAccount, user) {
+		minAge := viper.GetDuration(config.Keys.AccountsMinAge)
+		return nil, gtserror.NewErrorForbidden(errors.New("accountfollowcreate: account is too new to follow"), fmt.Sprintf("accounts younger than %s can't follow other accounts", minAge))
+	}
+
 	// if there's a block between the accounts we shouldn't create the request ofc
 	if blocked, err := p.db.IsBlocked(ctx, requestingAccount.ID, form.ID, true); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
@@ -65,6 +84,18 @@ func (p *processor) FollowCreate(ctx context.Context, requestingAccount *gtsmode
 		return p.RelationshipGet(ctx, requestingAccount, form.ID)
 	}
 
+	// make sure the account isn't following too many accounts already
+	if maxFollowing := viper.GetInt(config.Keys.AccountsMaxFollowing); maxFollowing > 0 {
+		following, err := p.db.CountAccountFollows(ctx, requestingAccount.ID, false)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("accountfollowcreate: error counting follows in db: %s", err))
+		}
+		if following >= maxFollowing {
+			err := fmt.Errorf("accountfollowcreate: account already follows %d accounts, the maximum is %d", following, maxFollowing)
+			return nil, gtserror.NewErrorUnprocessableEntity(err, fmt.Sprintf("you can't follow more than %d accounts", maxFollowing))
+		}
+	}
+
 	// make the follow request
 	newFollowID, err := id.NewRandomULID()
 	if err != nil {
@@ -91,13 +122,24 @@ func (p *processor) FollowCreate(ctx context.Context, requestingAccount *gtsmode
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("accountfollowcreate: error creating follow request in db: %s", err))
 	}
 
-	// if it's a local account that's not locked we can just straight up accept the follow request
-	if !targetAcct.Locked && targetAcct.Domain == "" {
-		if _, err := p.db.AcceptFollowRequest(ctx, requestingAccount.ID, form.ID); err != nil {
-			return nil, gtserror.NewErrorInternalError(fmt.Errorf("accountfollowcreate: error accepting folow request for local unlocked account: %s", err))
+	// if it's a local account that's not locked, or whose auto-accept rules
+	// match the requesting account, we can just straight up accept the follow request
+	if targetAcct.Domain == "" {
+		accept := !targetAcct.Locked
+		if !accept {
+			accept, err = p.AutoAcceptsFollowRequest(ctx, targetAcct, requestingAccount)
+			if err != nil {
+				return nil, gtserror.NewErrorInternalError(fmt.Errorf("accountfollowcreate: error checking auto-accept rules: %s", err))
+			}
+		}
+
+		if accept {
+			if _, err := p.db.AcceptFollowRequest(ctx, requestingAccount.ID, form.ID); err != nil {
+				return nil, gtserror.NewErrorInternalError(fmt.Errorf("accountfollowcreate: error accepting folow request for local account: %s", err))
+			}
+			// return the new relationship
+			return p.RelationshipGet(ctx, requestingAccount, form.ID)
 		}
-		// return the new relationship
-		return p.RelationshipGet(ctx, requestingAccount, form.ID)
 	}
 
 	// otherwise we leave the follow request as it is and we handle the rest of the process asynchronously
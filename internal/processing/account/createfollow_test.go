@@ -0,0 +1,167 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type FollowCreateTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *FollowCreateTestSuite) TestFollowSelf() {
+	requestingAccount := suite.testAccounts["local_account_1"]
+
+	relationship, errWithCode := suite.accountProcessor.FollowCreate(context.Background(), requestingAccount, &apimodel.AccountFollowRequest{ID: requestingAccount.ID})
+	suite.Nil(relationship)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *FollowCreateTestSuite) TestFollowLockedAccount() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["admin_account"]
+	targetAccount := suite.testAccounts["local_account_2"]
+
+	// turtle is locked, so the follow should be left as a request
+	relationship, errWithCode := suite.accountProcessor.FollowCreate(ctx, requestingAccount, &apimodel.AccountFollowRequest{ID: targetAccount.ID})
+	suite.NoError(errWithCode)
+	suite.True(relationship.Requested)
+	suite.False(relationship.Following)
+
+	following, err := suite.db.IsFollowing(ctx, requestingAccount, targetAccount)
+	suite.NoError(err)
+	suite.False(following)
+
+	msg := <-suite.fromClientAPIChan
+	suite.Equal(ap.ActivityCreate, msg.APActivityType)
+	suite.Equal(ap.ActivityFollow, msg.APObjectType)
+	fr, ok := msg.GTSModel.(*gtsmodel.FollowRequest)
+	if suite.True(ok) {
+		suite.Equal(requestingAccount.ID, fr.AccountID)
+		suite.Equal(targetAccount.ID, fr.TargetAccountID)
+	}
+}
+
+func (suite *FollowCreateTestSuite) TestFollowLockedAccountAutoAcceptFollowed() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["admin_account"]
+	targetAccount := &gtsmodel.Account{}
+	*targetAccount = *suite.testAccounts["local_account_2"]
+
+	// turtle auto-accepts follow requests from accounts it follows, and follows admin
+	targetAccount.AutoAcceptFollowed = true
+	_, err := suite.db.UpdateAccount(ctx, targetAccount)
+	suite.NoError(err)
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.Follow{
+		ID:              "01G5GEMW2PBQ14TH8XCTBJX6ZX",
+		URI:             "http://localhost:8080/users/1happyturtle/follow/01G5GEMW2PBQ14TH8XCTBJX6ZX",
+		AccountID:       targetAccount.ID,
+		TargetAccountID: requestingAccount.ID,
+	}))
+
+	relationship, errWithCode := suite.accountProcessor.FollowCreate(ctx, requestingAccount, &apimodel.AccountFollowRequest{ID: targetAccount.ID})
+	suite.NoError(errWithCode)
+	suite.False(relationship.Requested)
+	suite.True(relationship.Following)
+}
+
+func (suite *FollowCreateTestSuite) TestFollowLockedAccountAutoAcceptDomain() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["admin_account"]
+	targetAccount := &gtsmodel.Account{}
+	*targetAccount = *suite.testAccounts["local_account_2"]
+
+	// turtle auto-accepts follow requests from accounts on this instance
+	targetAccount.AutoAcceptDomains = []string{viper.GetString(config.Keys.Host)}
+	_, err := suite.db.UpdateAccount(ctx, targetAccount)
+	suite.NoError(err)
+
+	relationship, errWithCode := suite.accountProcessor.FollowCreate(ctx, requestingAccount, &apimodel.AccountFollowRequest{ID: targetAccount.ID})
+	suite.NoError(errWithCode)
+	suite.False(relationship.Requested)
+	suite.True(relationship.Following)
+}
+
+func (suite *FollowCreateTestSuite) TestFollowTooNew() {
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetAccount := suite.testAccounts["remote_account_1"]
+
+	// zork's account is younger than this
+	viper.Set(config.Keys.AccountsMinAge, 72*time.Hour)
+
+	relationship, errWithCode := suite.accountProcessor.FollowCreate(context.Background(), requestingAccount, &apimodel.AccountFollowRequest{ID: targetAccount.ID})
+	suite.Nil(relationship)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+	suite.Equal("forbidden: accounts younger than 72h0m0s can't follow other accounts", errWithCode.Safe())
+
+	// admin's account is too, but admins are exempt
+	relationship, errWithCode = suite.accountProcessor.FollowCreate(context.Background(), suite.testAccounts["admin_account"], &apimodel.AccountFollowRequest{ID: targetAccount.ID})
+	suite.NoError(errWithCode)
+	suite.True(relationship.Requested)
+}
+
+func (suite *FollowCreateTestSuite) TestFollowLimit() {
+	ctx := context.Background()
+	requestingAccount := suite.testAccounts["admin_account"]
+	targetAccount := suite.testAccounts["local_account_2"]
+
+	// admin already follows one account, so they can't follow any more
+	viper.Set(config.Keys.AccountsMaxFollowing, 1)
+
+	relationship, errWithCode := suite.accountProcessor.FollowCreate(ctx, requestingAccount, &apimodel.AccountFollowRequest{ID: targetAccount.ID})
+	suite.Nil(relationship)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+
+	requested, err := suite.db.IsFollowRequested(ctx, requestingAccount, targetAccount)
+	suite.NoError(err)
+	suite.False(requested)
+
+	// following up to the limit is fine
+	viper.Set(config.Keys.AccountsMaxFollowing, 2)
+
+	relationship, errWithCode = suite.accountProcessor.FollowCreate(ctx, requestingAccount, &apimodel.AccountFollowRequest{ID: targetAccount.ID})
+	suite.NoError(errWithCode)
+	suite.True(relationship.Requested)
+}
+
+func (suite *FollowCreateTestSuite) TestFollowNoLimit() {
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetAccount := suite.testAccounts["remote_account_1"]
+
+	viper.Set(config.Keys.AccountsMaxFollowing, 0)
+
+	relationship, errWithCode := suite.accountProcessor.FollowCreate(context.Background(), requestingAccount, &apimodel.AccountFollowRequest{ID: targetAccount.ID})
+	suite.NoError(errWithCode)
+	suite.True(relationship.Requested)
+}
+
+func TestFollowCreateTestSuite(t *testing.T) {
+	suite.Run(t, new(FollowCreateTestSuite))
+}
@@ -21,6 +21,7 @@ package account
 import (
 	"context"
 	"errors"
+	"net/url"
 	"time"
 
 	"github.com/sirupsen/logrus"
@@ -29,6 +30,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 	"golang.org/x/crypto/bcrypt"
 )
@@ -51,9 +53,10 @@ import (
 // 13. Delete account's mutes
 // 14. Delete account's streams
 // 15. Delete account's tags
-// 16. Delete account's user
-// 17. Delete account's timeline
-// 18. Delete account itself
+// 16. Delete account's archives and imports
+// 17. Delete account's user
+// 18. Delete account's timeline
+// 19. Delete account itself
 func (p *processor) Delete(ctx context.Context, account *gtsmodel.Account, origin string) gtserror.WithCode {
 	fields := logrus.Fields{
 		"func":     "Delete",
@@ -62,7 +65,7 @@ func (p *processor) Delete(ctx context.Context, account *gtsmodel.Account, origi
 	if account.Domain != "" {
 		fields["domain"] = account.Domain
 	}
-	l := logrus.WithFields(fields)
+	l := logrus.WithContext(ctx).WithFields(fields)
 
 	l.Debug("beginning account delete process")
 
@@ -72,24 +75,10 @@ func (p *processor) Delete(ctx context.Context, account *gtsmodel.Account, origi
 		// see if we can get a user for this account
 		u := &gtsmodel.User{}
 		if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, u); err == nil {
-			// we got one! select all tokens with the user's ID
-			tokens := []*gtsmodel.Token{}
-			if err := p.db.GetWhere(ctx, []db.Where{{Key: "user_id", Value: u.ID}}, &tokens); err == nil {
-				// we have some tokens to delete
-				for _, t := range tokens {
-					// delete client(s) associated with this token
-					if err := p.db.DeleteByID(ctx, t.ClientID, &gtsmodel.Client{}); err != nil {
-						l.Errorf("error deleting oauth client: %s", err)
-					}
-					// delete application(s) associated with this token
-					if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "client_id", Value: t.ClientID}}, &gtsmodel.Application{}); err != nil {
-						l.Errorf("error deleting application: %s", err)
-					}
-					// delete the token itself
-					if err := p.db.DeleteByID(ctx, t.ID, t); err != nil {
-						l.Errorf("error deleting oauth token: %s", err)
-					}
-				}
+			// we got one! delete all the user's tokens, and the application(s)
+			// they were issued to, unless other users are still using them
+			if err := p.db.DeleteUserTokens(ctx, u.ID); err != nil {
+				l.Errorf("error deleting oauth tokens, clients, and applications for user %s: %s", u.ID, err)
 			}
 		}
 	}
@@ -143,7 +132,7 @@ func (p *processor) Delete(ctx context.Context, account *gtsmodel.Account, origi
 	var maxID string
 selectStatusesLoop:
 	for {
-		statuses, err := p.db.GetAccountStatuses(ctx, account.ID, 20, false, false, maxID, "", false, false, false)
+		statuses, err := p.db.GetAccountStatuses(ctx, account.ID, 20, false, false, maxID, "", false, false, false, false)
 		if err != nil {
 			if err == db.ErrNoEntries {
 				// no statuses left for this instance so we're done
@@ -255,18 +244,34 @@ selectStatusesLoop:
 	// TODO
 
 	// 15. Delete account's tags
-	// TODO
+	// tags themselves aren't owned by anyone, but the account's follows of tags are
+	l.Debug("deleting account followed tags")
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.FollowedTag{}); err != nil {
+		l.Errorf("error deleting followed tags of account: %s", err)
+	}
+
+	// 16. Delete account's archives and imports
+	l.Debug("deleting account archives and imports")
+	if err := p.deleteArchives(ctx, account.ID, ""); err != nil {
+		l.Errorf("error deleting archives of account: %s", err)
+	}
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.FollowImport{}); err != nil {
+		l.Errorf("error deleting follow imports of account: %s", err)
+	}
+	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &[]*gtsmodel.BlockImport{}); err != nil {
+		l.Errorf("error deleting block imports of account: %s", err)
+	}
 
-	// 16. Delete account's user
+	// 17. Delete account's user
 	l.Debug("deleting account user")
 	if err := p.db.DeleteWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &gtsmodel.User{}); err != nil {
 		return gtserror.NewErrorInternalError(err)
 	}
 
-	// 17. Delete account's timeline
+	// 18. Delete account's timeline
 	// TODO
 
-	// 18. Delete account itself
+	// 19. Delete account itself
 	// to prevent the account being created again, set all these fields and update it in the db
 	// the account won't actually be *removed* from the database but it will be set to just a stub
 
@@ -289,6 +294,22 @@ selectStatusesLoop:
 		return gtserror.NewErrorInternalError(err)
 	}
 
+	// tombstone the account uri too, so that the account can't be dereferenced
+	// and created afresh by delayed federation if the stub is ever removed
+	if accountURI, err := url.Parse(account.URI); err == nil {
+		tombstoneID, err := id.NewULID()
+		if err != nil {
+			return gtserror.NewErrorInternalError(err)
+		}
+		if err := p.db.PutTombstone(ctx, &gtsmodel.Tombstone{
+			ID:     tombstoneID,
+			Domain: accountURI.Host,
+			URI:    account.URI,
+		}); err != nil {
+			l.Errorf("error putting tombstone for account: %s", err)
+		}
+	}
+
 	l.Infof("deleted account with username %s from domain %s", account.Username, account.Domain)
 	return nil
 }
@@ -0,0 +1,296 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"context"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+const (
+	// feedStatusesLimit is the maximum number of statuses included in a feed.
+	feedStatusesLimit = 20
+	// feedCacheTTL is how long a rendered feed is cached for before it's rendered again.
+	feedCacheTTL = 1 * time.Minute
+	// feedTitleMaxChars is the maximum length of item titles derived from the text of a status.
+	feedTitleMaxChars = 60
+)
+
+type rssFeed struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string         `xml:"title"`
+	Link        string         `xml:"link"`
+	Description string         `xml:"description"`
+	GUID        rssGUID        `xml:"guid"`
+	PubDate     string         `xml:"pubDate"`
+	Enclosures  []rssEnclosure `xml:"enclosure"`
+}
+
+type rssGUID struct {
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+	Value       string `xml:",chardata"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Length int    `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+type atomFeed struct {
+	XMLName xml.Name    `xml:"http://www.w3.org/2005/Atom feed"`
+	Title   string      `xml:"title"`
+	ID      string      `xml:"id"`
+	Updated string      `xml:"updated"`
+	Links   []atomLink  `xml:"link"`
+	Author  atomAuthor  `xml:"author"`
+	Entries []atomEntry `xml:"entry"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+	URI  string `xml:"uri"`
+}
+
+type atomLink struct {
+	Href   string `xml:"href,attr"`
+	Rel    string `xml:"rel,attr,omitempty"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length int    `xml:"length,attr,omitempty"`
+}
+
+type atomEntry struct {
+	Title     string      `xml:"title"`
+	ID        string      `xml:"id"`
+	Updated   string      `xml:"updated"`
+	Published string      `xml:"published"`
+	Links     []atomLink  `xml:"link"`
+	Content   atomContent `xml:"content"`
+}
+
+type atomContent struct {
+	Type  string `xml:"type,attr"`
+	Value string `xml:",chardata"`
+}
+
+// feedStatus is a status to be included in a feed, along with its attachments.
+type feedStatus struct {
+	status      *gtsmodel.Status
+	attachments []*gtsmodel.MediaAttachment
+}
+
+func (p *processor) StatusesFeedGet(ctx context.Context, username string, atom bool) ([]byte, gtserror.WithCode) {
+	cacheKey := username + ".rss"
+	if atom {
+		cacheKey = username + ".atom"
+	}
+	if cached, ok := p.feedCache.Get(cacheKey); ok {
+		return cached.([]byte), nil
+	}
+
+	account, err := p.db.GetLocalAccountByUsername(ctx, username)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(errors.New("account not found"))
+		}
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error getting account %s: %s", username, err))
+	}
+
+	if !account.SuspendedAt.IsZero() {
+		return nil, gtserror.NewErrorNotFound(errors.New("account is suspended"))
+	}
+
+	statuses := []feedStatus{}
+	if !account.HideCollections {
+		// only top-level public statuses authored by the account itself
+		dbStatuses, err := p.db.GetAccountStatuses(ctx, account.ID, feedStatusesLimit, true, true, "", "", false, false, true, false)
+		if err != nil && err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("db error getting statuses of account %s: %s", account.ID, err))
+		}
+		for _, s := range dbStatuses {
+			s.Account = account
+			if archived, err := p.filter.StatusArchived(ctx, s, nil); err != nil || archived {
+				continue
+			}
+
+			fs := feedStatus{status: s}
+			for _, id := range s.AttachmentIDs {
+				attachment, err := p.db.GetAttachmentByID(ctx, id)
+				if err != nil {
+					logrus.Debugf("StatusesFeedGet: error getting attachment %s: %s", id, err)
+					continue
+				}
+				fs.attachments = append(fs.attachments, attachment)
+			}
+			statuses = append(statuses, fs)
+		}
+	}
+
+	var feed interface{}
+	if atom {
+		feed = atomFeedFor(account, statuses)
+	} else {
+		feed = rssFeedFor(account, statuses)
+	}
+
+	b, err := xml.MarshalIndent(feed, "", "  ")
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error marshalling feed: %s", err))
+	}
+	b = append([]byte(xml.Header), b...)
+
+	p.feedCache.Set(cacheKey, b)
+	return b, nil
+}
+
+func rssFeedFor(account *gtsmodel.Account, statuses []feedStatus) *rssFeed {
+	feed := &rssFeed{
+		Version: "2.0",
+		Channel: rssChannel{
+			Title:       feedTitle(account),
+			Link:        account.URL,
+			Description: feedDescription(account),
+			Items:       []rssItem{},
+		},
+	}
+
+	for i, fs := range statuses {
+		if i == 0 {
+			feed.Channel.LastBuildDate = fs.status.CreatedAt.Format(time.RFC1123Z)
+		}
+
+		item := rssItem{
+			Title:       feedItemTitle(fs.status),
+			Link:        fs.status.URL,
+			Description: fs.status.Content,
+			GUID:        rssGUID{Value: fs.status.URI},
+			PubDate:     fs.status.CreatedAt.Format(time.RFC1123Z),
+		}
+		for _, a := range fs.attachments {
+			item.Enclosures = append(item.Enclosures, rssEnclosure{
+				URL:    a.URL,
+				Length: a.File.FileSize,
+				Type:   a.File.ContentType,
+			})
+		}
+		feed.Channel.Items = append(feed.Channel.Items, item)
+	}
+
+	return feed
+}
+
+func atomFeedFor(account *gtsmodel.Account, statuses []feedStatus) *atomFeed {
+	updated := account.UpdatedAt
+	if len(statuses) > 0 && statuses[0].status.CreatedAt.After(updated) {
+		updated = statuses[0].status.CreatedAt
+	}
+
+	feed := &atomFeed{
+		Title:   feedTitle(account),
+		ID:      account.URI,
+		Updated: updated.Format(time.RFC3339),
+		Links: []atomLink{
+			{Href: account.URL, Rel: "alternate", Type: "text/html"},
+			{Href: account.URL + "/feed.atom", Rel: "self", Type: "application/atom+xml"},
+		},
+		Author: atomAuthor{
+			Name: feedTitle(account),
+			URI:  account.URL,
+		},
+		Entries: []atomEntry{},
+	}
+
+	for _, fs := range statuses {
+		entry := atomEntry{
+			Title:     feedItemTitle(fs.status),
+			ID:        fs.status.URI,
+			Updated:   fs.status.UpdatedAt.Format(time.RFC3339),
+			Published: fs.status.CreatedAt.Format(time.RFC3339),
+			Links: []atomLink{
+				{Href: fs.status.URL, Rel: "alternate", Type: "text/html"},
+			},
+			Content: atomContent{
+				Type:  "html",
+				Value: fs.status.Content,
+			},
+		}
+		for _, a := range fs.attachments {
+			entry.Links = append(entry.Links, atomLink{
+				Href:   a.URL,
+				Rel:    "enclosure",
+				Type:   a.File.ContentType,
+				Length: a.File.FileSize,
+			})
+		}
+		feed.Entries = append(feed.Entries, entry)
+	}
+
+	return feed
+}
+
+// feedTitle returns the title of the feed of the given account.
+func feedTitle(account *gtsmodel.Account) string {
+	if account.DisplayName != "" {
+		return account.DisplayName
+	}
+	return "@" + account.Username
+}
+
+// feedDescription returns the description of the feed of the given account.
+func feedDescription(account *gtsmodel.Account) string {
+	return fmt.Sprintf("Public posts from @%s@%s", account.Username, viper.GetString(config.Keys.AccountDomain))
+}
+
+// feedItemTitle returns a title for the feed item of the given status: its content warning if it
+// has one, since the content shouldn't be shown without one, otherwise the beginning of its text.
+func feedItemTitle(status *gtsmodel.Status) string {
+	if status.ContentWarning != "" {
+		return status.ContentWarning
+	}
+
+	title := []rune(text.RemoveHTML(status.Content))
+	if len(title) > feedTitleMaxChars {
+		return string(title[:feedTitleMaxChars]) + "…"
+	}
+	return string(title)
+}
@@ -0,0 +1,147 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type FeedTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *FeedTestSuite) TestRSSFeed() {
+	status := suite.testStatuses["admin_account_status_1"]
+	attachment := suite.testAttachments["admin_account_status_1_attachment_1"]
+
+	b, errWithCode := suite.accountProcessor.StatusesFeedGet(context.Background(), "admin", false)
+	suite.NoError(errWithCode)
+
+	feed := struct {
+		Channel struct {
+			Title string `xml:"title"`
+			Link  string `xml:"link"`
+			Items []struct {
+				Link      string `xml:"link"`
+				GUID      string `xml:"guid"`
+				PubDate   string `xml:"pubDate"`
+				Enclosure []struct {
+					URL    string `xml:"url,attr"`
+					Length int    `xml:"length,attr"`
+					Type   string `xml:"type,attr"`
+				} `xml:"enclosure"`
+			} `xml:"item"`
+		} `xml:"channel"`
+	}{}
+	suite.NoError(xml.Unmarshal(b, &feed))
+	suite.Equal("@admin", feed.Channel.Title)
+	suite.Equal("http://localhost:8080/@admin", feed.Channel.Link)
+
+	var found bool
+	for _, item := range feed.Channel.Items {
+		if item.GUID != status.URI {
+			continue
+		}
+		found = true
+		suite.Equal(status.URL, item.Link)
+		suite.Equal(status.CreatedAt.Format(time.RFC1123Z), item.PubDate)
+		if suite.Len(item.Enclosure, 1) {
+			suite.Equal(attachment.URL, item.Enclosure[0].URL)
+			suite.Equal(attachment.File.FileSize, item.Enclosure[0].Length)
+			suite.Equal(attachment.File.ContentType, item.Enclosure[0].Type)
+		}
+	}
+	suite.True(found)
+}
+
+func (suite *FeedTestSuite) TestAtomFeed() {
+	status := suite.testStatuses["admin_account_status_1"]
+
+	b, errWithCode := suite.accountProcessor.StatusesFeedGet(context.Background(), "admin", true)
+	suite.NoError(errWithCode)
+
+	feed := struct {
+		XMLName xml.Name `xml:"http://www.w3.org/2005/Atom feed"`
+		ID      string   `xml:"id"`
+		Entries []struct {
+			ID string `xml:"id"`
+		} `xml:"entry"`
+	}{}
+	suite.NoError(xml.Unmarshal(b, &feed))
+	suite.Equal("http://localhost:8080/users/admin", feed.ID)
+
+	ids := []string{}
+	for _, e := range feed.Entries {
+		ids = append(ids, e.ID)
+	}
+	suite.Contains(ids, status.URI)
+}
+
+func (suite *FeedTestSuite) TestFeedOnlyPublic() {
+	// local_account_2_status_3 is unlisted, so it shouldn't be included
+	notPublic := suite.testStatuses["local_account_2_status_3"]
+
+	b, errWithCode := suite.accountProcessor.StatusesFeedGet(context.Background(), "1happyturtle", false)
+	suite.NoError(errWithCode)
+	suite.NotContains(string(b), notPublic.URI)
+	suite.Contains(string(b), suite.testStatuses["local_account_2_status_2"].URI)
+}
+
+func (suite *FeedTestSuite) TestFeedHideCollections() {
+	ctx := context.Background()
+	account := &gtsmodel.Account{}
+	*account = *suite.testAccounts["admin_account"]
+	account.HideCollections = true
+	_, err := suite.db.UpdateAccount(ctx, account)
+	suite.NoError(err)
+
+	b, errWithCode := suite.accountProcessor.StatusesFeedGet(ctx, "admin", false)
+	suite.NoError(errWithCode)
+	suite.NotContains(string(b), "<item>")
+}
+
+func (suite *FeedTestSuite) TestFeedSuspended() {
+	ctx := context.Background()
+	account := &gtsmodel.Account{}
+	*account = *suite.testAccounts["admin_account"]
+	account.SuspendedAt = time.Now()
+	_, err := suite.db.UpdateAccount(ctx, account)
+	suite.NoError(err)
+
+	_, errWithCode := suite.accountProcessor.StatusesFeedGet(ctx, "admin", false)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *FeedTestSuite) TestFeedUnknownAccount() {
+	_, errWithCode := suite.accountProcessor.StatusesFeedGet(context.Background(), "nobody", false)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func TestFeedTestSuite(t *testing.T) {
+	suite.Run(t, new(FeedTestSuite))
+}
@@ -0,0 +1,344 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+// followImportMaxLines is the most accounts that can be listed in a single follow import.
+const followImportMaxLines = 10000
+
+func (p *processor) FollowImportCreate(ctx context.Context, account *gtsmodel.Account, form *apimodel.FollowImportCreateRequest) (*apimodel.FollowImport, gtserror.WithCode) {
+	if form.Data == nil || form.Data.Size == 0 {
+		return nil, gtserror.NewErrorBadRequest(errors.New("FollowImportCreate: no data provided"), "no file of accounts to follow was provided")
+	}
+
+	latest, err := p.latestFollowImport(ctx, account.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("FollowImportCreate: error fetching follow imports: %s", err))
+	}
+	if latest != nil && latest.Status == gtsmodel.FollowImportPending {
+		err := fmt.Errorf("FollowImportCreate: follow import %s is still pending", latest.ID)
+		return nil, gtserror.NewErrorConflict(err, "a previous import is still being processed")
+	}
+
+	f, err := form.Data.Open()
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("FollowImportCreate: error opening data: %s", err))
+	}
+	defer f.Close()
+
+	lines, err := parseFollowImport(f)
+	if err != nil {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("FollowImportCreate: error parsing data: %s", err), err.Error())
+	}
+	if len(lines) == 0 {
+		return nil, gtserror.NewErrorBadRequest(errors.New("FollowImportCreate: no accounts in data"), "the provided file didn't list any accounts")
+	}
+	if len(lines) > followImportMaxLines {
+		err := fmt.Errorf("FollowImportCreate: %d accounts in data, the maximum is %d", len(lines), followImportMaxLines)
+		return nil, gtserror.NewErrorUnprocessableEntity(err, fmt.Sprintf("you can't import more than %d accounts at once", followImportMaxLines))
+	}
+
+	importID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	now := time.Now()
+	followImport := &gtsmodel.FollowImport{
+		ID:        importID,
+		CreatedAt: now,
+		UpdatedAt: now,
+		AccountID: account.ID,
+		Status:    gtsmodel.FollowImportPending,
+		Lines:     lines,
+	}
+	if err := p.db.Put(ctx, followImport); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("FollowImportCreate: error putting follow import: %s", err))
+	}
+
+	// following remote accounts may mean webfingering and dereferencing
+	// them, which can be slow, so we do the following asynchronously
+	p.clientWorker.Queue(messages.FromClientAPI{
+		APObjectType:   ap.ActivityFollow,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       followImport,
+		OriginAccount:  account,
+	})
+
+	return followImportToAPI(followImport), nil
+}
+
+func (p *processor) FollowImportGet(ctx context.Context, account *gtsmodel.Account) (*apimodel.FollowImport, gtserror.WithCode) {
+	latest, err := p.latestFollowImport(ctx, account.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("FollowImportGet: error fetching follow imports: %s", err))
+	}
+
+	if latest == nil {
+		return nil, gtserror.NewErrorNotFound(errors.New("FollowImportGet: no follow import found"), "no accounts to follow have been imported")
+	}
+
+	return followImportToAPI(latest), nil
+}
+
+func (p *processor) FollowImportProcess(ctx context.Context, account *gtsmodel.Account, followImport *gtsmodel.FollowImport) error {
+	l := logrus.WithFields(logrus.Fields{
+		"func":           "FollowImportProcess",
+		"username":       account.Username,
+		"followImportID": followImport.ID,
+	})
+
+	for i := range followImport.Lines {
+		line := &followImport.Lines[i]
+		if line.Result != gtsmodel.FollowImportLinePending {
+			continue
+		}
+
+		if err := p.followImportLine(ctx, account, line); err != nil {
+			l.Debugf("error following %s from line %d: %s", line.Handle, line.Line, err)
+		}
+
+		// store progress as we go, so that the account can see how the import is getting on
+		followImport.UpdatedAt = time.Now()
+		if err := p.db.UpdateByPrimaryKey(ctx, followImport); err != nil {
+			return fmt.Errorf("FollowImportProcess: error updating follow import %s: %s", followImport.ID, err)
+		}
+	}
+
+	followImport.Status = gtsmodel.FollowImportDone
+	followImport.UpdatedAt = time.Now()
+	if err := p.db.UpdateByPrimaryKey(ctx, followImport); err != nil {
+		return fmt.Errorf("FollowImportProcess: error updating follow import %s: %s", followImport.ID, err)
+	}
+
+	return nil
+}
+
+// followImportLine follows the account on the given line of a follow import, recording the outcome on the line.
+// The returned error is only for logging: the error recorded on the line is the one which is safe to show to the account.
+func (p *processor) followImportLine(ctx context.Context, account *gtsmodel.Account, line *gtsmodel.FollowImportLine) error {
+	targetAccount, err := p.resolveImportHandle(ctx, account, line.Handle)
+	if err != nil {
+		line.Result = gtsmodel.FollowImportLineFailed
+		line.Error = "account not found"
+		return err
+	}
+
+	if targetAccount.ID == account.ID {
+		line.Result = gtsmodel.FollowImportLineSkipped
+		return nil
+	}
+
+	if follows, err := p.db.IsFollowing(ctx, account, targetAccount); err != nil {
+		line.Result = gtsmodel.FollowImportLineFailed
+		line.Error = "internal error"
+		return err
+	} else if follows {
+		line.Result = gtsmodel.FollowImportLineSkipped
+		return nil
+	}
+
+	if followRequested, err := p.db.IsFollowRequested(ctx, account, targetAccount); err != nil {
+		line.Result = gtsmodel.FollowImportLineFailed
+		line.Error = "internal error"
+		return err
+	} else if followRequested {
+		line.Result = gtsmodel.FollowImportLineSkipped
+		return nil
+	}
+
+	showBoosts := line.ShowBoosts
+	notify := line.Notify
+	if _, errWithCode := p.FollowCreate(ctx, account, &apimodel.AccountFollowRequest{
+		ID:      targetAccount.ID,
+		Reblogs: &showBoosts,
+		Notify:  &notify,
+	}); errWithCode != nil {
+		line.Result = gtsmodel.FollowImportLineFailed
+		line.Error = errWithCode.Safe()
+		return errWithCode
+	}
+
+	line.Result = gtsmodel.FollowImportLineFollowed
+	return nil
+}
+
+// resolveImportHandle returns the account with the given handle, webfingering and dereferencing it if it's a remote account we haven't seen before.
+func (p *processor) resolveImportHandle(ctx context.Context, account *gtsmodel.Account, handle string) (*gtsmodel.Account, error) {
+	username, domain, err := util.ExtractMentionParts("@" + handle)
+	if err != nil {
+		return nil, err
+	}
+
+	if domain == "" || domain == viper.GetString(config.Keys.Host) || domain == viper.GetString(config.Keys.AccountDomain) {
+		return p.db.GetLocalAccountByUsername(ctx, username)
+	}
+
+	targetAccount := &gtsmodel.Account{}
+	err = p.db.GetWhere(ctx, []db.Where{
+		{Key: "username", Value: username, CaseInsensitive: true},
+		{Key: "domain", Value: domain, CaseInsensitive: true},
+	}, targetAccount)
+	if err == nil {
+		return targetAccount, nil
+	}
+	if err != db.ErrNoEntries {
+		return nil, err
+	}
+
+	acctURI, err := p.federator.FingerRemoteAccount(ctx, username, domain)
+	if err != nil {
+		return nil, fmt.Errorf("error fingering remote account with username %s and domain %s: %s", username, domain, err)
+	}
+
+	return p.federator.GetRemoteAccount(ctx, account.Username, acctURI, true, false)
+}
+
+// latestFollowImport returns the most recent follow import of the given account, or nil if there isn't one.
+func (p *processor) latestFollowImport(ctx context.Context, accountID string) (*gtsmodel.FollowImport, error) {
+	followImports := []*gtsmodel.FollowImport{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: accountID}}, &followImports); err != nil && err != db.ErrNoEntries {
+		return nil, err
+	}
+
+	var latest *gtsmodel.FollowImport
+	for _, f := range followImports {
+		// ulids sort by creation time
+		if latest == nil || f.ID > latest.ID {
+			latest = f
+		}
+	}
+	return latest, nil
+}
+
+// parseFollowImport parses a follow import in the csv format that Mastodon exports follows in, ie., with
+// the columns "Account address", "Show boosts", "Notify on new posts", and "Languages". The header line
+// is optional: without it, the columns are assumed to be in that order, and only the first is required.
+//
+// Languages aren't supported, so they're ignored. Lines with a handle that doesn't look right are marked
+// as failed straight away, while all other lines are left pending.
+func parseFollowImport(r io.Reader) ([]gtsmodel.FollowImportLine, error) {
+	reader := csv.NewReader(r)
+	reader.FieldsPerRecord = -1
+	reader.TrimLeadingSpace = true
+
+	column := func(record []string, i int) string {
+		if i < 0 || i >= len(record) {
+			return ""
+		}
+		return strings.TrimSpace(record[i])
+	}
+
+	handleColumn, showBoostsColumn, notifyColumn := 0, 1, 2
+	lines := []gtsmodel.FollowImportLine{}
+	for first := true; ; first = false {
+		record, err := reader.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("couldn't read csv: %s", err)
+		}
+
+		if first && strings.EqualFold(column(record, 0), "account address") {
+			// use the header to find the columns
+			handleColumn, showBoostsColumn, notifyColumn = -1, -1, -1
+			for i := range record {
+				switch strings.ToLower(column(record, i)) {
+				case "account address":
+					handleColumn = i
+				case "show boosts":
+					showBoostsColumn = i
+				case "notify on new posts":
+					notifyColumn = i
+				}
+			}
+			continue
+		}
+
+		handle := strings.TrimPrefix(column(record, handleColumn), "@")
+		if handle == "" {
+			continue
+		}
+
+		lineNumber, _ := reader.FieldPos(0)
+		line := gtsmodel.FollowImportLine{
+			Line:       lineNumber,
+			Handle:     handle,
+			ShowBoosts: true,
+			Result:     gtsmodel.FollowImportLinePending,
+		}
+		if showBoosts, err := strconv.ParseBool(column(record, showBoostsColumn)); err == nil {
+			line.ShowBoosts = showBoosts
+		}
+		if notify, err := strconv.ParseBool(column(record, notifyColumn)); err == nil {
+			line.Notify = notify
+		}
+		if _, _, err := util.ExtractMentionParts("@" + handle); err != nil {
+			line.Result = gtsmodel.FollowImportLineFailed
+			line.Error = "invalid account address"
+		}
+
+		lines = append(lines, line)
+	}
+
+	return lines, nil
+}
+
+func followImportToAPI(followImport *gtsmodel.FollowImport) *apimodel.FollowImport {
+	apiFollowImport := &apimodel.FollowImport{
+		ID:        followImport.ID,
+		Status:    string(followImport.Status),
+		CreatedAt: followImport.CreatedAt.Format(time.RFC3339),
+		Lines:     make([]apimodel.FollowImportLine, 0, len(followImport.Lines)),
+	}
+
+	for _, line := range followImport.Lines {
+		apiFollowImport.Lines = append(apiFollowImport.Lines, apimodel.FollowImportLine{
+			Line:   line.Line,
+			Handle: line.Handle,
+			Result: string(line.Result),
+			Error:  line.Error,
+		})
+	}
+
+	return apiFollowImport
+}
@@ -0,0 +1,119 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"bytes"
+	"context"
+	"mime/multipart"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type FollowImportTestSuite struct {
+	AccountStandardTestSuite
+}
+
+// followImportForm returns a follow import request with the given csv as its data.
+func (suite *FollowImportTestSuite) followImportForm(data string) *apimodel.FollowImportCreateRequest {
+	body := &bytes.Buffer{}
+	w := multipart.NewWriter(body)
+	part, err := w.CreateFormFile("data", "following_accounts.csv")
+	suite.NoError(err)
+	_, err = part.Write([]byte(data))
+	suite.NoError(err)
+	suite.NoError(w.Close())
+
+	form, err := multipart.NewReader(body, w.Boundary()).ReadForm(int64(body.Len()))
+	suite.NoError(err)
+
+	return &apimodel.FollowImportCreateRequest{
+		Data: form.File["data"][0],
+	}
+}
+
+func (suite *FollowImportTestSuite) TestFollowImport() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_2"]
+
+	data := "Account address,Show boosts,Notify on new posts,Languages\n" +
+		"the_mighty_zork,true,false,\n" +
+		"@1happyturtle,true,false,\n" +
+		"admin,true,false,\n" +
+		"some_user@example.org,false,true,en\n" +
+		"\n" +
+		"not a handle!!,true,false,\n"
+
+	followImport, errWithCode := suite.accountProcessor.FollowImportCreate(ctx, testAccount, suite.followImportForm(data))
+	suite.NoError(errWithCode)
+	suite.Equal("pending", followImport.Status)
+	suite.Len(followImport.Lines, 5)
+	suite.Equal("the_mighty_zork", followImport.Lines[0].Handle)
+	suite.Equal("1happyturtle", followImport.Lines[1].Handle)
+	suite.Equal(7, followImport.Lines[4].Line)
+	suite.Equal("failed", followImport.Lines[4].Result)
+	suite.Equal("invalid account address", followImport.Lines[4].Error)
+
+	// the accounts are followed in the background
+	msg := <-suite.fromClientAPIChan
+	dbFollowImport, ok := msg.GTSModel.(*gtsmodel.FollowImport)
+	suite.True(ok)
+	suite.Equal(followImport.ID, dbFollowImport.ID)
+
+	// another import can't be started while this one is pending
+	_, errWithCode = suite.accountProcessor.FollowImportCreate(ctx, testAccount, suite.followImportForm(data))
+	suite.Equal(http.StatusConflict, errWithCode.Code())
+
+	suite.NoError(suite.accountProcessor.FollowImportProcess(ctx, testAccount, dbFollowImport))
+
+	followImport, errWithCode = suite.accountProcessor.FollowImportGet(ctx, testAccount)
+	suite.NoError(errWithCode)
+	suite.Equal("done", followImport.Status)
+	suite.Equal("skipped", followImport.Lines[0].Result) // already followed
+	suite.Equal("skipped", followImport.Lines[1].Result) // self
+	suite.Equal("followed", followImport.Lines[2].Result)
+	suite.Equal("followed", followImport.Lines[3].Result)
+	suite.Equal("failed", followImport.Lines[4].Result)
+
+	// show boosts and notify should have been honoured
+	fr := &gtsmodel.FollowRequest{}
+	suite.NoError(suite.db.GetWhere(ctx, []db.Where{
+		{Key: "account_id", Value: testAccount.ID},
+		{Key: "target_account_id", Value: suite.testAccounts["remote_account_2"].ID},
+	}, fr))
+	suite.False(fr.ShowReblogs)
+	suite.True(fr.Notify)
+}
+
+func (suite *FollowImportTestSuite) TestFollowImportNoAccounts() {
+	testAccount := suite.testAccounts["local_account_2"]
+
+	_, errWithCode := suite.accountProcessor.FollowImportCreate(context.Background(), testAccount, suite.followImportForm("Account address,Show boosts,Notify on new posts,Languages\n"))
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+	suite.Equal("bad request: the provided file didn't list any accounts", errWithCode.Safe())
+}
+
+func TestFollowImportTestSuite(t *testing.T) {
+	suite.Run(t, &FollowImportTestSuite{})
+}
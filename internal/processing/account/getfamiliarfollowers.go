@@ -0,0 +1,66 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"context"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (p *processor) FamiliarFollowersGet(ctx context.Context, requestingAccount *gtsmodel.Account, targetAccountID string) (*apimodel.FamiliarFollowers, gtserror.WithCode) {
+	familiar := &apimodel.FamiliarFollowers{
+		ID:       targetAccountID,
+		Accounts: []apimodel.Account{},
+	}
+
+	// don't reveal anything about an account that's blocked in either direction
+	if blocked, err := p.db.IsBlocked(ctx, requestingAccount.ID, targetAccountID, true); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("FamiliarFollowersGet: error checking block: %s", err))
+	} else if blocked {
+		return familiar, nil
+	}
+
+	follows, err := p.db.GetAccountFamiliarFollowers(ctx, requestingAccount.ID, targetAccountID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("FamiliarFollowersGet: error getting familiar followers: %s", err))
+	}
+
+	for _, f := range follows {
+		// a familiar follower who has since blocked the requester (or vice versa) shouldn't be revealed
+		blocked, err := p.db.IsBlocked(ctx, requestingAccount.ID, f.AccountID, true)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("FamiliarFollowersGet: error checking block: %s", err))
+		}
+		if blocked || f.Account == nil {
+			continue
+		}
+
+		account, err := p.tc.AccountToAPIAccountPublic(ctx, f.Account)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("FamiliarFollowersGet: error converting account: %s", err))
+		}
+		familiar.Accounts = append(familiar.Accounts, *account)
+	}
+
+	return familiar, nil
+}
@@ -39,7 +39,7 @@ func (p *processor) StatusesGet(ctx context.Context, requestingAccount *gtsmodel
 
 	apiStatuses := []apimodel.Status{}
 
-	statuses, err := p.db.GetAccountStatuses(ctx, targetAccountID, limit, excludeReplies, excludeReblogs, maxID, minID, pinnedOnly, mediaOnly, publicOnly)
+	statuses, err := p.db.GetAccountStatuses(ctx, targetAccountID, limit, excludeReplies, excludeReblogs, maxID, minID, pinnedOnly, mediaOnly, publicOnly, false)
 	if err != nil {
 		if err == db.ErrNoEntries {
 			return apiStatuses, nil
@@ -53,6 +53,11 @@ func (p *processor) StatusesGet(ctx context.Context, requestingAccount *gtsmodel
 			continue
 		}
 
+		archived, err := p.filter.StatusArchived(ctx, s, requestingAccount)
+		if err != nil || archived {
+			continue
+		}
+
 		apiStatus, err := p.tc.StatusToAPIStatus(ctx, s, requestingAccount)
 		if err != nil {
 			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting status to api: %s", err))
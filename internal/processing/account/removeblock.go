@@ -34,24 +34,28 @@ func (p *processor) BlockRemove(ctx context.Context, requestingAccount *gtsmodel
 	// make sure the target account actually exists in our db
 	targetAccount, err := p.db.GetAccountByID(ctx, targetAccountID)
 	if err != nil {
-		return nil, gtserror.NewErrorNotFound(fmt.Errorf("BlockCreate: error getting account %s from the db: %s", targetAccountID, err))
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(fmt.Errorf("BlockRemove: account %s not found in the db: %s", targetAccountID, err))
+		}
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("BlockRemove: error getting account %s from the db: %s", targetAccountID, err))
 	}
 
 	// check if a block exists, and remove it if it does (storing the URI for later)
 	var blockChanged bool
-	block := &gtsmodel.Block{}
-	if err := p.db.GetWhere(ctx, []db.Where{
-		{Key: "account_id", Value: requestingAccount.ID},
-		{Key: "target_account_id", Value: targetAccountID},
-	}, block); err == nil {
-		block.Account = requestingAccount
-		block.TargetAccount = targetAccount
+	block, err := p.db.GetBlock(ctx, requestingAccount.ID, targetAccountID)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("BlockRemove: error getting block from db: %s", err))
+	}
+	if block != nil {
 		if err := p.db.DeleteByID(ctx, block.ID, &gtsmodel.Block{}); err != nil {
 			return nil, gtserror.NewErrorInternalError(fmt.Errorf("BlockRemove: error removing block from db: %s", err))
 		}
 		blockChanged = true
 	}
 
+	// note that we don't recreate any follows that were removed when the block was created;
+	// after an unblock, either account has to follow the other one again if they want to
+
 	// block status changed so send the UNDO activity to the channel for async processing
 	if blockChanged {
 		p.clientWorker.Queue(messages.FromClientAPI{
@@ -0,0 +1,65 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type BlockRemoveTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *BlockRemoveTestSuite) TestBlockRemove() {
+	requestingAccount := suite.testAccounts["local_account_2"]
+	targetAccount := suite.testAccounts["remote_account_1"]
+
+	relationship, errWithCode := suite.accountProcessor.BlockRemove(context.Background(), requestingAccount, targetAccount.ID)
+	suite.NoError(errWithCode)
+	suite.False(relationship.Blocking)
+
+	blocked, err := suite.db.IsBlocked(context.Background(), requestingAccount.ID, targetAccount.ID, false)
+	suite.NoError(err)
+	suite.False(blocked)
+
+	// the unblock should be federated
+	msg := <-suite.fromClientAPIChan
+	suite.Equal(ap.ActivityUndo, msg.APActivityType)
+	suite.Equal(ap.ActivityBlock, msg.APObjectType)
+	suite.Equal("http://localhost:8080/users/1happyturtle/blocks/01FEXXET6XXMF7G2V3ASZP3YQW", msg.GTSModel.(*gtsmodel.Block).URI)
+}
+
+func (suite *BlockRemoveTestSuite) TestBlockRemoveAccountNotFound() {
+	requestingAccount := suite.testAccounts["local_account_2"]
+
+	relationship, errWithCode := suite.accountProcessor.BlockRemove(context.Background(), requestingAccount, "01G5EQ8ZD3R6W0N8YJ9KXT5BVA")
+	suite.Nil(relationship)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+	suite.Contains(errWithCode.Error(), "BlockRemove: account 01G5EQ8ZD3R6W0N8YJ9KXT5BVA not found in the db")
+}
+
+func TestBlockRemoveTestSuite(t *testing.T) {
+	suite.Run(t, &BlockRemoveTestSuite{})
+}
@@ -0,0 +1,138 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"codeberg.org/gruf/go-store/storage"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+func (p *processor) RemoveAvatar(ctx context.Context, account *gtsmodel.Account) (*apimodel.Account, gtserror.WithCode) {
+	return p.removeAvatarOrHeader(ctx, account, &account.AvatarMediaAttachmentID, &account.AvatarMediaAttachment)
+}
+
+func (p *processor) RemoveHeader(ctx context.Context, account *gtsmodel.Account) (*apimodel.Account, gtserror.WithCode) {
+	return p.removeAvatarOrHeader(ctx, account, &account.HeaderMediaAttachmentID, &account.HeaderMediaAttachment)
+}
+
+// removeAvatarOrHeader clears the given attachment id and attachment of the account, deletes the
+// attachment, and federates the change. If the account has no such attachment, nothing is changed.
+func (p *processor) removeAvatarOrHeader(ctx context.Context, account *gtsmodel.Account, attachmentID *string, attachment **gtsmodel.MediaAttachment) (*apimodel.Account, gtserror.WithCode) {
+	if *attachmentID != "" {
+		oldAttachmentID := *attachmentID
+		*attachmentID = ""
+		*attachment = nil
+
+		updatedAccount, err := p.updateAccountReplacingAttachments(ctx, account, []string{oldAttachmentID})
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("removeAvatarOrHeader: %s", err))
+		}
+		account = updatedAccount
+
+		p.clientWorker.Queue(messages.FromClientAPI{
+			APObjectType:   ap.ObjectProfile,
+			APActivityType: ap.ActivityUpdate,
+			GTSModel:       account,
+			OriginAccount:  account,
+		})
+	}
+
+	acctSensitive, err := p.tc.AccountToAPIAccountSensitive(ctx, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("removeAvatarOrHeader: could not convert account into apisensitive account: %s", err))
+	}
+	return acctSensitive, nil
+}
+
+// updateAccountReplacingAttachments updates the account in the database and deletes the media attachments with the given
+// ids from it in a single transaction, so that the account never ends up pointing at an attachment that's gone. The files
+// of the deleted attachments are only removed from storage once that's been committed, since storage can't be rolled back.
+func (p *processor) updateAccountReplacingAttachments(ctx context.Context, account *gtsmodel.Account, replacedAttachmentIDs []string) (*gtsmodel.Account, error) {
+	var updatedAccount *gtsmodel.Account
+	replacedAttachments := []*gtsmodel.MediaAttachment{}
+
+	if err := p.db.RunInTx(ctx, func(tx db.DB) error {
+		var err error
+		updatedAccount, err = tx.UpdateAccount(ctx, account)
+		if err != nil {
+			return fmt.Errorf("could not update account %s: %s", account.ID, err)
+		}
+
+		for _, id := range replacedAttachmentIDs {
+			attachment, err := tx.GetAttachmentByID(ctx, id)
+			if err != nil {
+				if err == db.ErrNoEntries {
+					// attachment already gone
+					continue
+				}
+				return fmt.Errorf("error getting attachment %s: %s", id, err)
+			}
+
+			if err := tx.DeleteByID(ctx, id, attachment); err != nil && err != db.ErrNoEntries {
+				return fmt.Errorf("error removing attachment %s: %s", id, err)
+			}
+			replacedAttachments = append(replacedAttachments, attachment)
+		}
+
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, attachment := range replacedAttachments {
+		if err := p.deleteAttachmentFiles(attachment); err != nil {
+			logrus.Errorf("updateAccountReplacingAttachments: error deleting replaced attachment: %s", err)
+		}
+	}
+
+	return updatedAccount, nil
+}
+
+// deleteAttachmentFiles deletes the files of the given media attachment from storage.
+func (p *processor) deleteAttachmentFiles(attachment *gtsmodel.MediaAttachment) error {
+	errs := []string{}
+
+	if attachment.Thumbnail.Path != "" {
+		if err := p.storage.Delete(attachment.Thumbnail.Path); err != nil && err != storage.ErrNotFound {
+			errs = append(errs, fmt.Sprintf("remove thumbnail at path %s: %s", attachment.Thumbnail.Path, err))
+		}
+	}
+
+	if attachment.File.Path != "" {
+		if err := p.storage.Delete(attachment.File.Path); err != nil && err != storage.ErrNotFound {
+			errs = append(errs, fmt.Sprintf("remove file at path %s: %s", attachment.File.Path, err))
+		}
+	}
+
+	if len(errs) != 0 {
+		return fmt.Errorf("deleteAttachmentFiles: one or more errors removing files of attachment with id %s: %s", attachment.ID, strings.Join(errs, "; "))
+	}
+
+	return nil
+}
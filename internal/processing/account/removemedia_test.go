@@ -0,0 +1,112 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type RemoveMediaTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *RemoveMediaTestSuite) TestRemoveAvatar() {
+	ctx := context.Background()
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+	avatar := suite.testAttachments["local_account_1_avatar"]
+	suite.Equal(avatar.ID, testAccount.AvatarMediaAttachmentID)
+
+	apiAccount, errWithCode := suite.accountProcessor.RemoveAvatar(ctx, testAccount)
+	suite.NoError(errWithCode)
+	suite.NotNil(apiAccount)
+	suite.Empty(apiAccount.Avatar)
+
+	// the header should be left alone
+	suite.NotEmpty(apiAccount.Header)
+
+	// the account should be updated in the database
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Empty(dbAccount.AvatarMediaAttachmentID)
+	suite.Equal(testAccount.HeaderMediaAttachmentID, dbAccount.HeaderMediaAttachmentID)
+
+	// the attachment and its files should be gone
+	_, err = suite.db.GetAttachmentByID(ctx, avatar.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	_, err = suite.storage.Get(avatar.File.Path)
+	suite.Error(err)
+	_, err = suite.storage.Get(avatar.Thumbnail.Path)
+	suite.Error(err)
+
+	// the change should be federated
+	msg := <-suite.fromClientAPIChan
+	suite.Equal(ap.ActivityUpdate, msg.APActivityType)
+	suite.Equal(ap.ObjectProfile, msg.APObjectType)
+	suite.Equal(testAccount.ID, msg.OriginAccount.ID)
+}
+
+func (suite *RemoveMediaTestSuite) TestRemoveHeader() {
+	ctx := context.Background()
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+	header := suite.testAttachments["local_account_1_header"]
+	suite.Equal(header.ID, testAccount.HeaderMediaAttachmentID)
+
+	apiAccount, errWithCode := suite.accountProcessor.RemoveHeader(ctx, testAccount)
+	suite.NoError(errWithCode)
+	suite.Empty(apiAccount.Header)
+	suite.NotEmpty(apiAccount.Avatar)
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Empty(dbAccount.HeaderMediaAttachmentID)
+
+	_, err = suite.db.GetAttachmentByID(ctx, header.ID)
+	suite.ErrorIs(err, db.ErrNoEntries)
+	_, err = suite.storage.Get(header.File.Path)
+	suite.Error(err)
+
+	msg := <-suite.fromClientAPIChan
+	suite.Equal(ap.ActivityUpdate, msg.APActivityType)
+}
+
+func (suite *RemoveMediaTestSuite) TestRemoveAvatarNoAvatar() {
+	ctx := context.Background()
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_2"]
+	suite.Empty(testAccount.AvatarMediaAttachmentID)
+
+	apiAccount, errWithCode := suite.accountProcessor.RemoveAvatar(ctx, testAccount)
+	suite.NoError(errWithCode)
+	suite.NotNil(apiAccount)
+
+	// nothing changed, so nothing should be federated
+	suite.Empty(suite.fromClientAPIChan)
+}
+
+func TestRemoveMediaTestSuite(t *testing.T) {
+	suite.Run(t, new(RemoveMediaTestSuite))
+}
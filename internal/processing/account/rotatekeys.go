@@ -0,0 +1,100 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// rsaKeyBits is the size of newly generated account keys; it matches the size of keys generated for new accounts.
+const rsaKeyBits = 2048
+
+func (p *processor) RotateKeys(ctx context.Context, account *gtsmodel.Account) (*apimodel.Account, gtserror.WithCode) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("RotateKeys: error generating key: %s", err))
+	}
+
+	oldKeyID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	newKeyID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// keep the old public key around for a while, so that requests
+	// signed with it just before the rotation can still be verified
+	now := time.Now()
+	oldKey := &gtsmodel.AccountKey{
+		ID:           oldKeyID,
+		CreatedAt:    now,
+		AccountID:    account.ID,
+		PublicKey:    account.PublicKey,
+		PublicKeyURI: account.PublicKeyURI,
+		ExpiresAt:    now.Add(viper.GetDuration(config.Keys.AccountsKeyGracePeriod)),
+	}
+	if err := p.db.Put(ctx, oldKey); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("RotateKeys: error putting old key: %s", err))
+	}
+
+	// the new key gets a new uri, so that the old one can keep serving the old key
+	account.PrivateKey = privateKey
+	account.PublicKey = &privateKey.PublicKey
+	account.PublicKeyURI = uris.GenerateURIForPublicKey(account.Username, newKeyID)
+
+	updatedAccount, err := p.db.UpdateAccount(ctx, account)
+	if err != nil {
+		if err := p.db.DeleteByID(ctx, oldKey.ID, oldKey); err != nil {
+			logrus.Errorf("RotateKeys: error removing old key %s: %s", oldKey.ID, err)
+		}
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("RotateKeys: could not update account %s: %s", account.ID, err))
+	}
+
+	// send an update out so that remote instances refresh the key they have for this account
+	p.clientWorker.Queue(messages.FromClientAPI{
+		APObjectType:   ap.ObjectProfile,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       updatedAccount,
+		OriginAccount:  updatedAccount,
+	})
+
+	acctSensitive, err := p.tc.AccountToAPIAccountSensitive(ctx, updatedAccount)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("RotateKeys: could not convert account into apisensitive account: %s", err))
+	}
+	return acctSensitive, nil
+}
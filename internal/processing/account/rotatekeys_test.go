@@ -0,0 +1,69 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type AccountRotateKeysTestSuite struct {
+	AccountStandardTestSuite
+}
+
+func (suite *AccountRotateKeysTestSuite) TestRotateKeys() {
+	oldAccount := suite.testAccounts["local_account_1"]
+	account := *oldAccount
+
+	apiAccount, errWithCode := suite.accountProcessor.RotateKeys(context.Background(), &account)
+	suite.NoError(errWithCode)
+	suite.Equal(oldAccount.ID, apiAccount.ID)
+
+	// the account should have a whole new keypair at a new uri
+	dbAccount, err := suite.db.GetAccountByID(context.Background(), oldAccount.ID)
+	suite.NoError(err)
+	suite.False(oldAccount.PublicKey.Equal(dbAccount.PublicKey))
+	suite.False(oldAccount.PrivateKey.Equal(dbAccount.PrivateKey))
+	suite.True(dbAccount.PrivateKey.PublicKey.Equal(dbAccount.PublicKey))
+	suite.NotEqual(oldAccount.PublicKeyURI, dbAccount.PublicKeyURI)
+	suite.Contains(dbAccount.PublicKeyURI, oldAccount.PublicKeyURI+"/")
+
+	// the old public key should be kept for the grace period
+	oldKey := &gtsmodel.AccountKey{}
+	suite.NoError(suite.db.GetWhere(context.Background(), []db.Where{{Key: "account_id", Value: oldAccount.ID}}, oldKey))
+	suite.Equal(oldAccount.PublicKeyURI, oldKey.PublicKeyURI)
+	suite.True(oldAccount.PublicKey.Equal(oldKey.PublicKey))
+	suite.WithinDuration(time.Now().Add(24*time.Hour), oldKey.ExpiresAt, time.Minute)
+
+	// the new key should be federated in an update
+	msg := <-suite.fromClientAPIChan
+	suite.Equal(ap.ActivityUpdate, msg.APActivityType)
+	suite.Equal(ap.ObjectProfile, msg.APObjectType)
+	suite.Equal(dbAccount.PublicKeyURI, msg.GTSModel.(*gtsmodel.Account).PublicKeyURI)
+}
+
+func TestAccountRotateKeysTestSuite(t *testing.T) {
+	suite.Run(t, &AccountRotateKeysTestSuite{})
+}
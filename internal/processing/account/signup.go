@@ -0,0 +1,152 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"bufio"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// hashSignUpIP returns the hex-encoded sha256 hash of the given ip address.
+// IPv6 addresses are truncated to their /64 prefix first, since a single
+// user is often given a whole /64 to pick addresses from.
+func hashSignUpIP(ip net.IP) string {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	} else {
+		ip = ip.Mask(net.CIDRMask(64, 128))
+	}
+	sum := sha256.Sum256([]byte(ip.String()))
+	return hex.EncodeToString(sum[:])
+}
+
+// checkSignUpIP returns an error if the configured limit of sign-ups from the given ip address has been reached.
+func (p *processor) checkSignUpIP(ctx context.Context, ip net.IP) gtserror.WithCode {
+	limit := viper.GetInt(config.Keys.AccountsSignUpIPLimit)
+	if limit <= 0 {
+		return nil
+	}
+
+	// with no window, sign-ups are counted forever
+	var since time.Time
+	if window := viper.GetDuration(config.Keys.AccountsSignUpIPWindow); window > 0 {
+		since = time.Now().Add(-window)
+
+		// records from before the window are no longer useful to us, so get rid of them
+		if err := p.db.DeleteSignUpIPsBefore(ctx, since); err != nil {
+			return gtserror.NewErrorInternalError(fmt.Errorf("checkSignUpIP: error pruning sign up ips: %s", err))
+		}
+	}
+
+	count, err := p.db.CountSignUpIPs(ctx, hashSignUpIP(ip), since)
+	if err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("checkSignUpIP: error counting sign up ips: %s", err))
+	}
+
+	if count >= limit {
+		err := fmt.Errorf("sign up limit of %d reached for this ip address", limit)
+		// the soonest a sign-up could drop out of the window is a whole window from now
+		return gtserror.WithRetryAfter(gtserror.NewErrorTooManyRequests(err, err.Error()), viper.GetDuration(config.Keys.AccountsSignUpIPWindow))
+	}
+
+	return nil
+}
+
+// putSignUpIP records a sign-up from the given ip address, so that it counts towards the sign-up limit.
+func (p *processor) putSignUpIP(ctx context.Context, ip net.IP) error {
+	if viper.GetInt(config.Keys.AccountsSignUpIPLimit) <= 0 {
+		return nil
+	}
+
+	signUpIPID, err := id.NewRandomULID()
+	if err != nil {
+		return err
+	}
+
+	return p.db.Put(ctx, &gtsmodel.SignUpIP{
+		ID:     signUpIPID,
+		IPHash: hashSignUpIP(ip),
+	})
+}
+
+// checkEmailDomain returns an error if the domain of the given email address, or any domain above it,
+// is in the configured email domain blocklist, or in the configured email domain blocklist file.
+func checkEmailDomain(email string) gtserror.WithCode {
+	domain := strings.ToLower(email[strings.LastIndex(email, "@")+1:])
+
+	blocklist := viper.GetStringSlice(config.Keys.AccountsEmailDomainBlocklist)
+
+	// the file is read on every sign-up, so that it
+	// can be updated without restarting the server
+	if path := viper.GetString(config.Keys.AccountsEmailDomainBlocklistFile); path != "" {
+		fromFile, err := readEmailDomainBlocklist(path)
+		if err != nil {
+			return gtserror.NewErrorInternalError(fmt.Errorf("checkEmailDomain: error reading email domain blocklist file: %s", err))
+		}
+		blocklist = append(blocklist, fromFile...)
+	}
+
+	for _, blocked := range blocklist {
+		blocked = strings.ToLower(strings.TrimSpace(blocked))
+		if blocked == "" {
+			continue
+		}
+		if domain == blocked || strings.HasSuffix(domain, "."+blocked) {
+			err := fmt.Errorf("sign ups from email domain %s are not allowed", domain)
+			return gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// readEmailDomainBlocklist reads a list of email domains from the file at path, one domain per line.
+// Empty lines, and lines starting with '#', are skipped.
+func readEmailDomainBlocklist(path string) ([]string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	domains := []string{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		domains = append(domains, line)
+	}
+
+	return domains, scanner.Err()
+}
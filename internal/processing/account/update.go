@@ -20,6 +20,7 @@ package account
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"mime/multipart"
@@ -30,6 +31,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
@@ -38,7 +40,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
 )
 
-func (p *processor) Update(ctx context.Context, account *gtsmodel.Account, form *apimodel.UpdateCredentialsRequest) (*apimodel.Account, error) {
+func (p *processor) Update(ctx context.Context, account *gtsmodel.Account, form *apimodel.UpdateCredentialsRequest) (*apimodel.Account, gtserror.WithCode) {
 	l := logrus.WithField("func", "AccountUpdate")
 
 	if form.Discoverable != nil {
@@ -51,14 +53,14 @@ func (p *processor) Update(ctx context.Context, account *gtsmodel.Account, form
 
 	if form.DisplayName != nil {
 		if err := validate.DisplayName(*form.DisplayName); err != nil {
-			return nil, err
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
 		}
 		account.DisplayName = text.RemoveHTML(*form.DisplayName)
 	}
 
 	if form.Note != nil {
 		if err := validate.Note(*form.Note); err != nil {
-			return nil, err
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
 		}
 
 		// Set the raw note before processing
@@ -67,17 +69,37 @@ func (p *processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		// Process note to generate a valid HTML representation
 		note, err := p.processNote(ctx, *form.Note, account.ID)
 		if err != nil {
-			return nil, err
+			return nil, gtserror.NewErrorInternalError(err)
 		}
 
 		// Set updated HTML-ified note
 		account.Note = note
 	}
 
+	if form.CustomCSS != nil {
+		if !viper.GetBool(config.Keys.AccountsCustomCSSEnabled) {
+			err := errors.New("custom css is not enabled on this instance")
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+		if err := validate.CustomCSS(*form.CustomCSS); err != nil {
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		if _, err := text.SanitizeCSS(*form.CustomCSS, text.ProfileCSSScope); err != nil {
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+		account.CustomCSS = *form.CustomCSS
+	}
+
+	// attachments replaced by a new avatar or header, to be deleted along with the account update
+	replacedAttachmentIDs := []string{}
+
 	if form.Avatar != nil && form.Avatar.Size != 0 {
 		avatarInfo, err := p.UpdateAvatar(ctx, form.Avatar, account.ID)
 		if err != nil {
-			return nil, err
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		if account.AvatarMediaAttachmentID != "" {
+			replacedAttachmentIDs = append(replacedAttachmentIDs, account.AvatarMediaAttachmentID)
 		}
 		account.AvatarMediaAttachmentID = avatarInfo.ID
 		account.AvatarMediaAttachment = avatarInfo
@@ -87,7 +109,10 @@ func (p *processor) Update(ctx context.Context, account *gtsmodel.Account, form
 	if form.Header != nil && form.Header.Size != 0 {
 		headerInfo, err := p.UpdateHeader(ctx, form.Header, account.ID)
 		if err != nil {
-			return nil, err
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		if account.HeaderMediaAttachmentID != "" {
+			replacedAttachmentIDs = append(replacedAttachmentIDs, account.HeaderMediaAttachmentID)
 		}
 		account.HeaderMediaAttachmentID = headerInfo.ID
 		account.HeaderMediaAttachment = headerInfo
@@ -98,10 +123,29 @@ func (p *processor) Update(ctx context.Context, account *gtsmodel.Account, form
 		account.Locked = *form.Locked
 	}
 
+	if form.Unquotable != nil {
+		account.Unquotable = *form.Unquotable
+	}
+
+	if form.ArchiveAfterDays != nil {
+		if *form.ArchiveAfterDays < 0 {
+			err := fmt.Errorf("archive_after_days must not be negative, got %d", *form.ArchiveAfterDays)
+			return nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		account.ArchiveAfterDays = *form.ArchiveAfterDays
+	}
+
+	if form.FieldsAttributes != nil {
+		if err := validateFields(*form.FieldsAttributes); err != nil {
+			return nil, gtserror.NewErrorUnprocessableEntity(err, err.Error())
+		}
+		account.Fields = p.processFields(*form.FieldsAttributes, account.Fields)
+	}
+
 	if form.Source != nil {
 		if form.Source.Language != nil {
 			if err := validate.Language(*form.Source.Language); err != nil {
-				return nil, err
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
 			}
 			account.Language = *form.Source.Language
 		}
@@ -112,16 +156,24 @@ func (p *processor) Update(ctx context.Context, account *gtsmodel.Account, form
 
 		if form.Source.Privacy != nil {
 			if err := validate.Privacy(*form.Source.Privacy); err != nil {
-				return nil, err
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
 			}
 			privacy := p.tc.APIVisToVis(apimodel.Visibility(*form.Source.Privacy))
 			account.Privacy = privacy
 		}
+
+		if form.Source.AutoDeleteAfterDays != nil {
+			if *form.Source.AutoDeleteAfterDays < 0 {
+				err := fmt.Errorf("source[auto_delete_after_days] must not be negative, got %d", *form.Source.AutoDeleteAfterDays)
+				return nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+			account.AutoDeleteAfterDays = *form.Source.AutoDeleteAfterDays
+		}
 	}
 
-	updatedAccount, err := p.db.UpdateAccount(ctx, account)
+	updatedAccount, err := p.updateAccountReplacingAttachments(ctx, account, replacedAttachmentIDs)
 	if err != nil {
-		return nil, fmt.Errorf("could not update account %s: %s", account.ID, err)
+		return nil, gtserror.NewErrorInternalError(err)
 	}
 
 	p.clientWorker.Queue(messages.FromClientAPI{
@@ -133,11 +185,65 @@ func (p *processor) Update(ctx context.Context, account *gtsmodel.Account, form
 
 	acctSensitive, err := p.tc.AccountToAPIAccountSensitive(ctx, updatedAccount)
 	if err != nil {
-		return nil, fmt.Errorf("could not convert account into apisensitive account: %s", err)
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("could not convert account into apisensitive account: %s", err))
 	}
 	return acctSensitive, nil
 }
 
+// validateFields checks the given form fields against the configured limits on profile fields.
+func validateFields(formFields []apimodel.UpdateField) error {
+	maxFields := viper.GetInt(config.Keys.AccountsMaxFields)
+	if len(formFields) > maxFields {
+		return fmt.Errorf("%d profile fields provided but limit is %d", len(formFields), maxFields)
+	}
+
+	maxNameChars := viper.GetInt(config.Keys.AccountsFieldNameMaxChars)
+	maxValueChars := viper.GetInt(config.Keys.AccountsFieldValueMaxChars)
+	for i, f := range formFields {
+		if f.Name != nil {
+			if length := len([]rune(*f.Name)); length > maxNameChars {
+				return fmt.Errorf("name of profile field %d too long, %d characters provided but limit is %d", i, length, maxNameChars)
+			}
+		}
+		if f.Value != nil {
+			if length := len([]rune(*f.Value)); length > maxValueChars {
+				return fmt.Errorf("value of profile field %d too long, %d characters provided but limit is %d", i, length, maxValueChars)
+			}
+		}
+	}
+
+	return nil
+}
+
+// processFields converts the given form fields into account fields, skipping empty ones.
+// Fields whose value hasn't changed keep their verification from the old fields, until they're verified again.
+func (p *processor) processFields(formFields []apimodel.UpdateField, oldFields []gtsmodel.Field) []gtsmodel.Field {
+	fields := []gtsmodel.Field{}
+	for _, f := range formFields {
+		if f.Name == nil || f.Value == nil {
+			continue
+		}
+
+		field := gtsmodel.Field{
+			Name:  text.RemoveHTML(*f.Name),
+			Value: text.SanitizeHTML(*f.Value),
+		}
+		if field.Name == "" || field.Value == "" {
+			continue
+		}
+
+		for _, old := range oldFields {
+			if old.Value == field.Value {
+				field.VerifiedAt = old.VerifiedAt
+				break
+			}
+		}
+
+		fields = append(fields, field)
+	}
+	return fields
+}
+
 // UpdateAvatar does the dirty work of checking the avatar part of an account update form,
 // parsing and checking the image, and doing the necessary updates in the database for this to become
 // the account's new avatar image.
@@ -20,11 +20,16 @@ package account_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type AccountUpdateTestSuite struct {
@@ -45,8 +50,8 @@ func (suite *AccountUpdateTestSuite) TestAccountUpdateSimple() {
 	}
 
 	// should get no error from the update function, and an api model account returned
-	apiAccount, err := suite.accountProcessor.Update(context.Background(), testAccount, form)
-	suite.NoError(err)
+	apiAccount, errWithCode := suite.accountProcessor.Update(context.Background(), testAccount, form)
+	suite.NoError(errWithCode)
 	suite.NotNil(apiAccount)
 
 	// fields on the profile should be updated
@@ -88,8 +93,8 @@ go check out @1happyturtle, they have a cool account!
 	}
 
 	// should get no error from the update function, and an api model account returned
-	apiAccount, err := suite.accountProcessor.Update(context.Background(), testAccount, form)
-	suite.NoError(err)
+	apiAccount, errWithCode := suite.accountProcessor.Update(context.Background(), testAccount, form)
+	suite.NoError(errWithCode)
 	suite.NotNil(apiAccount)
 
 	// fields on the profile should be updated
@@ -113,6 +118,201 @@ go check out @1happyturtle, they have a cool account!
 	suite.Equal(noteExpected, dbAccount.Note)
 }
 
+func (suite *AccountUpdateTestSuite) TestAccountUpdateFields() {
+	ctx := context.Background()
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	// the website field was verified before, and its value isn't changing
+	verifiedAt := testrig.TimeMustParse("2022-06-01T10:00:00Z")
+	testAccount.Fields = []gtsmodel.Field{
+		{Name: "website", Value: "https://example.org", VerifiedAt: verifiedAt},
+		{Name: "old", Value: "https://old.example.org", VerifiedAt: verifiedAt},
+	}
+
+	name1, value1 := "website", "https://example.org"
+	name2, value2 := "<b>pronouns</b>", "they/them"
+	name3, value3 := "", ""
+	form := &apimodel.UpdateCredentialsRequest{
+		FieldsAttributes: &[]apimodel.UpdateField{
+			{Name: &name1, Value: &value1},
+			{Name: &name2, Value: &value2},
+			{Name: &name3, Value: &value3},
+		},
+	}
+
+	apiAccount, errWithCode := suite.accountProcessor.Update(ctx, testAccount, form)
+	suite.NoError(errWithCode)
+	suite.NotNil(apiAccount)
+
+	// the empty field should be dropped, and html stripped from the rest
+	suite.Len(apiAccount.Fields, 2)
+	suite.Equal("website", apiAccount.Fields[0].Name)
+	suite.Equal("https://example.org", apiAccount.Fields[0].Value)
+	suite.Equal("2022-06-01T10:00:00Z", apiAccount.Fields[0].VerifiedAt)
+	suite.Equal("pronouns", apiAccount.Fields[1].Name)
+	suite.Equal("they/them", apiAccount.Fields[1].Value)
+	suite.Empty(apiAccount.Fields[1].VerifiedAt)
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Len(dbAccount.Fields, 2)
+	suite.True(verifiedAt.Equal(dbAccount.Fields[0].VerifiedAt))
+}
+
+func (suite *AccountUpdateTestSuite) TestAccountUpdateFieldsSanitized() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	name, value := "website", `<a href="https://example.org" onclick="alert('hi')">my site</a><script>alert('hi')</script>`
+	form := &apimodel.UpdateCredentialsRequest{
+		FieldsAttributes: &[]apimodel.UpdateField{
+			{Name: &name, Value: &value},
+		},
+	}
+
+	apiAccount, errWithCode := suite.accountProcessor.Update(ctx, testAccount, form)
+	suite.NoError(errWithCode)
+	suite.NotNil(apiAccount)
+
+	// the link should be kept, but not the script or the onclick
+	suite.Len(apiAccount.Fields, 1)
+	suite.Equal(`<a href="https://example.org" rel="nofollow noreferrer noopener" target="_blank">my site</a>`, apiAccount.Fields[0].Value)
+}
+
+func (suite *AccountUpdateTestSuite) TestAccountUpdateTooManyFields() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+
+	name, value := "name", "value"
+	fields := []apimodel.UpdateField{}
+	for i := 0; i < 5; i++ {
+		fields = append(fields, apimodel.UpdateField{Name: &name, Value: &value})
+	}
+	form := &apimodel.UpdateCredentialsRequest{
+		FieldsAttributes: &fields,
+	}
+
+	apiAccount, errWithCode := suite.accountProcessor.Update(ctx, testAccount, form)
+	suite.Nil(apiAccount)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.Equal("unprocessable entity: 5 profile fields provided but limit is 4", errWithCode.Safe())
+}
+
+func (suite *AccountUpdateTestSuite) TestAccountUpdateFieldTooLong() {
+	ctx := context.Background()
+	testAccount := suite.testAccounts["local_account_1"]
+	viper.Set(config.Keys.AccountsFieldValueMaxChars, 10)
+
+	// 11 characters, but many more bytes
+	name, value := "pronouns", "🐢🐢🐢🐢🐢🐢🐢🐢🐢🐢🐢"
+	form := &apimodel.UpdateCredentialsRequest{
+		FieldsAttributes: &[]apimodel.UpdateField{
+			{Name: &name, Value: &value},
+		},
+	}
+
+	apiAccount, errWithCode := suite.accountProcessor.Update(ctx, testAccount, form)
+	suite.Nil(apiAccount)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.Equal("unprocessable entity: value of profile field 0 too long, 11 characters provided but limit is 10", errWithCode.Safe())
+
+	// the account shouldn't have been changed
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Empty(dbAccount.Fields)
+}
+
+func (suite *AccountUpdateTestSuite) TestAccountUpdateCustomCSS() {
+	ctx := context.Background()
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	css := ".bio { color: pink; }"
+	form := &apimodel.UpdateCredentialsRequest{
+		CustomCSS: &css,
+	}
+
+	apiAccount, errWithCode := suite.accountProcessor.Update(ctx, testAccount, form)
+	suite.NoError(errWithCode)
+	suite.Equal(css, apiAccount.CustomCSS)
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Equal(css, dbAccount.CustomCSS)
+}
+
+func (suite *AccountUpdateTestSuite) TestAccountUpdateCustomCSSNotAllowed() {
+	ctx := context.Background()
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	css := `@import url("https://evil.example.org/evil.css");`
+	form := &apimodel.UpdateCredentialsRequest{
+		CustomCSS: &css,
+	}
+
+	apiAccount, errWithCode := suite.accountProcessor.Update(ctx, testAccount, form)
+	suite.Nil(apiAccount)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+}
+
+func (suite *AccountUpdateTestSuite) TestAccountUpdateCustomCSSDisabled() {
+	ctx := context.Background()
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+	viper.Set(config.Keys.AccountsCustomCSSEnabled, false)
+
+	css := ".bio { color: pink; }"
+	form := &apimodel.UpdateCredentialsRequest{
+		CustomCSS: &css,
+	}
+
+	apiAccount, errWithCode := suite.accountProcessor.Update(ctx, testAccount, form)
+	suite.Nil(apiAccount)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.Equal("unprocessable entity: custom css is not enabled on this instance", errWithCode.Safe())
+}
+
+func (suite *AccountUpdateTestSuite) TestAccountUpdateArchiveAfterDays() {
+	ctx := context.Background()
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	days := 90
+	form := &apimodel.UpdateCredentialsRequest{
+		ArchiveAfterDays: &days,
+	}
+
+	apiAccount, errWithCode := suite.accountProcessor.Update(ctx, testAccount, form)
+	suite.NoError(errWithCode)
+	suite.Equal(days, apiAccount.ArchiveAfterDays)
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Equal(days, dbAccount.ArchiveAfterDays)
+}
+
+func (suite *AccountUpdateTestSuite) TestAccountUpdateArchiveAfterDaysNegative() {
+	ctx := context.Background()
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+
+	days := -1
+	form := &apimodel.UpdateCredentialsRequest{
+		ArchiveAfterDays: &days,
+	}
+
+	apiAccount, errWithCode := suite.accountProcessor.Update(ctx, testAccount, form)
+	suite.Nil(apiAccount)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusBadRequest, errWithCode.Code())
+}
+
 func TestAccountUpdateTestSuite(t *testing.T) {
 	suite.Run(t, new(AccountUpdateTestSuite))
 }
@@ -0,0 +1,226 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"golang.org/x/net/html"
+)
+
+const (
+	// verifyPageTimeout is how long we'll wait for the page at a profile field link, including reading it.
+	verifyPageTimeout = 10 * time.Second
+	// maxVerifyPageSize is the maximum amount of bytes of the page at a profile field link that we'll read.
+	maxVerifyPageSize = 1024 * 1024
+)
+
+func (p *processor) VerifyFields(ctx context.Context, account *gtsmodel.Account) (bool, error) {
+	if account.Domain != "" {
+		return false, fmt.Errorf("VerifyFields: account %s is not a local account", account.ID)
+	}
+
+	changed := false
+	for i, field := range account.Fields {
+		link, ok := fieldLink(field.Value)
+		if !ok {
+			if !field.VerifiedAt.IsZero() {
+				account.Fields[i].VerifiedAt = time.Time{}
+				changed = true
+			}
+			continue
+		}
+
+		page, err := p.fetchVerifyPage(ctx, link)
+		if err != nil {
+			// the page might just be down for a bit, so leave the field as
+			// it was rather than unverifying it, and try again next time
+			logrus.Debugf("VerifyFields: error fetching %s for account %s: %s", link, account.ID, err)
+			continue
+		}
+
+		verified := hasRelMeLink(page, account.URL, account.URI)
+		switch {
+		case verified && field.VerifiedAt.IsZero():
+			account.Fields[i].VerifiedAt = time.Now()
+			changed = true
+		case !verified && !field.VerifiedAt.IsZero():
+			account.Fields[i].VerifiedAt = time.Time{}
+			changed = true
+		}
+	}
+
+	if !changed {
+		return false, nil
+	}
+
+	if _, err := p.db.UpdateAccount(ctx, account); err != nil {
+		return false, fmt.Errorf("VerifyFields: error updating account %s: %s", account.ID, err)
+	}
+
+	return true, nil
+}
+
+func (p *processor) VerifyAllFields(ctx context.Context) (int, error) {
+	accounts := []*gtsmodel.Account{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "domain", Value: nil}}, &accounts); err != nil {
+		if err == db.ErrNoEntries {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("VerifyAllFields: error getting local accounts: %s", err)
+	}
+
+	updated := 0
+	for _, account := range accounts {
+		if len(account.Fields) == 0 || !account.SuspendedAt.IsZero() {
+			continue
+		}
+
+		changed, err := p.VerifyFields(ctx, account)
+		if err != nil {
+			logrus.Errorf("VerifyAllFields: error verifying fields of account %s: %s", account.ID, err)
+			continue
+		}
+
+		if changed {
+			updated++
+		}
+	}
+
+	return updated, nil
+}
+
+// fetchVerifyPage GETs the web page at the given link with the verify client, returning at most maxVerifyPageSize bytes of it.
+func (p *processor) fetchVerifyPage(ctx context.Context, link *url.URL) ([]byte, error) {
+	ctx, cancel := context.WithTimeout(ctx, verifyPageTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// this is a plain old web page rather than an activitypub resource,
+	// so there's no need to sign the request
+	req.Header.Set("Accept", "text/html")
+	req.Header.Set("User-Agent", fmt.Sprintf("%s %s", viper.GetString(config.Keys.ApplicationName), viper.GetString(config.Keys.Host)))
+
+	resp, err := p.verifyClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET request to %s failed (%d): %s", link, resp.StatusCode, resp.Status)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, maxVerifyPageSize))
+}
+
+// fieldLink returns the url in the given field value, if the text of the value is nothing but an http(s) url.
+func fieldLink(value string) (*url.URL, bool) {
+	// field values are html, but may well just be a plain url
+	value = strings.TrimSpace(html.UnescapeString(text.RemoveHTML(value)))
+	if !strings.HasPrefix(value, "https://") && !strings.HasPrefix(value, "http://") {
+		return nil, false
+	}
+
+	link, err := url.Parse(value)
+	if err != nil || link.Host == "" {
+		return nil, false
+	}
+
+	return link, true
+}
+
+// hasRelMeLink returns true if the given html page contains an <a> or <link> element
+// with rel="me", which points at one of the given profile urls.
+func hasRelMeLink(page []byte, profileURLs ...string) bool {
+	doc, err := html.Parse(bytes.NewReader(page))
+	if err != nil {
+		return false
+	}
+
+	var found bool
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if found {
+			return
+		}
+
+		if n.Type == html.ElementNode && (n.Data == "a" || n.Data == "link") {
+			var rel, href string
+			for _, attr := range n.Attr {
+				switch attr.Key {
+				case "rel":
+					rel = attr.Val
+				case "href":
+					href = attr.Val
+				}
+			}
+
+			if isRelMe(rel) && matchesProfileURL(href, profileURLs) {
+				found = true
+				return
+			}
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	return found
+}
+
+// isRelMe returns true if the given rel attribute value contains "me".
+func isRelMe(rel string) bool {
+	for _, r := range strings.Fields(rel) {
+		if strings.EqualFold(r, "me") {
+			return true
+		}
+	}
+	return false
+}
+
+// matchesProfileURL returns true if href is one of the given profile urls, ignoring any trailing slash.
+func matchesProfileURL(href string, profileURLs []string) bool {
+	href = strings.TrimSuffix(strings.TrimSpace(href), "/")
+	for _, u := range profileURLs {
+		if u != "" && href == strings.TrimSuffix(u, "/") {
+			return true
+		}
+	}
+	return false
+}
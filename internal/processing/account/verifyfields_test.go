@@ -0,0 +1,166 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package account_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/account"
+	"github.com/superseriousbusiness/gotosocial/internal/worker"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type VerifyFieldsTestSuite struct {
+	AccountStandardTestSuite
+}
+
+// processorWithPages returns an account processor whose http client serves the given pages,
+// keyed by url, and returns an error for any other url.
+func (suite *VerifyFieldsTestSuite) processorWithPages(pages map[string]string) account.Processor {
+	httpClient := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		page, ok := pages[req.URL.String()]
+		if !ok {
+			return nil, errors.New("connection refused")
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader([]byte(page))),
+		}, nil
+	})
+
+	fedWorker := worker.New[messages.FromFederator](-1, -1)
+	clientWorker := worker.New[messages.FromClientAPI](-1, -1)
+	transportController := testrig.NewTestTransportController(httpClient, suite.db, fedWorker)
+	federator := testrig.NewTestFederator(suite.db, transportController, suite.storage, suite.mediaManager, fedWorker)
+	return account.New(suite.db, suite.tc, httpClient, suite.mediaManager, suite.oauthServer, clientWorker, federator, processing.GetParseMentionFunc(suite.db, federator), suite.storage)
+}
+
+func (suite *VerifyFieldsTestSuite) TestVerifyFields() {
+	ctx := context.Background()
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+	testAccount.Fields = []gtsmodel.Field{
+		{Name: "website", Value: "https://example.org/about"},
+		{Name: "blog", Value: "https://blog.example.org"},
+		{Name: "pronouns", Value: "they/them"},
+		{Name: "key", Value: `<a href="https://example.org/key" rel="nofollow noreferrer noopener" target="_blank">https://example.org/key</a>`},
+	}
+
+	processor := suite.processorWithPages(map[string]string{
+		"https://example.org/about": `<html><body><a rel="nofollow me" href="http://localhost:8080/@the_mighty_zork">me on the fedi</a></body></html>`,
+		"https://blog.example.org":  `<html><body><a href="http://localhost:8080/@the_mighty_zork">not rel me</a></body></html>`,
+		"https://example.org/key":   `<html><head><link rel="me" href="http://localhost:8080/users/the_mighty_zork/"></head></html>`,
+	})
+
+	changed, err := processor.VerifyFields(ctx, testAccount)
+	suite.NoError(err)
+	suite.True(changed)
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.Len(dbAccount.Fields, 4)
+	suite.WithinDuration(time.Now(), dbAccount.Fields[0].VerifiedAt, time.Minute)
+	suite.True(dbAccount.Fields[1].VerifiedAt.IsZero())
+	suite.True(dbAccount.Fields[2].VerifiedAt.IsZero())
+	suite.WithinDuration(time.Now(), dbAccount.Fields[3].VerifiedAt, time.Minute)
+
+	apiAccount, err := suite.tc.AccountToAPIAccountPublic(ctx, dbAccount)
+	suite.NoError(err)
+	suite.NotEmpty(apiAccount.Fields[0].VerifiedAt)
+	suite.Empty(apiAccount.Fields[1].VerifiedAt)
+}
+
+func (suite *VerifyFieldsTestSuite) TestVerifyFieldsLinkRemoved() {
+	ctx := context.Background()
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+	testAccount.Fields = []gtsmodel.Field{
+		{Name: "website", Value: "https://example.org/about", VerifiedAt: testrig.TimeMustParse("2022-06-01T10:00:00Z")},
+	}
+
+	// the page no longer links back to the profile
+	processor := suite.processorWithPages(map[string]string{
+		"https://example.org/about": `<html><body>nothing to see here</body></html>`,
+	})
+
+	changed, err := processor.VerifyFields(ctx, testAccount)
+	suite.NoError(err)
+	suite.True(changed)
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.True(dbAccount.Fields[0].VerifiedAt.IsZero())
+}
+
+func (suite *VerifyFieldsTestSuite) TestVerifyFieldsFetchError() {
+	ctx := context.Background()
+	verifiedAt := testrig.TimeMustParse("2022-06-01T10:00:00Z")
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+	testAccount.Fields = []gtsmodel.Field{
+		{Name: "website", Value: "https://example.org/about", VerifiedAt: verifiedAt},
+		{Name: "blog", Value: "https://blog.example.org"},
+	}
+
+	// neither page can be fetched, so nothing should change
+	processor := suite.processorWithPages(map[string]string{})
+
+	changed, err := processor.VerifyFields(ctx, testAccount)
+	suite.NoError(err)
+	suite.False(changed)
+	suite.True(verifiedAt.Equal(testAccount.Fields[0].VerifiedAt))
+	suite.True(testAccount.Fields[1].VerifiedAt.IsZero())
+}
+
+func (suite *VerifyFieldsTestSuite) TestVerifyAllFields() {
+	ctx := context.Background()
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_2"]
+	testAccount.Fields = []gtsmodel.Field{
+		{Name: "website", Value: "https://example.org/turtle"},
+	}
+	_, err := suite.db.UpdateAccount(ctx, testAccount)
+	suite.NoError(err)
+
+	processor := suite.processorWithPages(map[string]string{
+		"https://example.org/turtle": `<a rel="me" href="http://localhost:8080/@1happyturtle">turtle</a>`,
+	})
+
+	updated, err := processor.VerifyAllFields(ctx)
+	suite.NoError(err)
+	suite.Equal(1, updated)
+
+	dbAccount, err := suite.db.GetAccountByID(ctx, testAccount.ID)
+	suite.NoError(err)
+	suite.False(dbAccount.Fields[0].VerifiedAt.IsZero())
+}
+
+func TestVerifyFieldsTestSuite(t *testing.T) {
+	suite.Run(t, new(VerifyFieldsTestSuite))
+}
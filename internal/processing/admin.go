@@ -30,10 +30,14 @@ func (p *processor) AdminAccountAction(ctx context.Context, authed *oauth.Auth,
 	return p.adminProcessor.AccountAction(ctx, authed.Account, form)
 }
 
-func (p *processor) AdminEmojiCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.EmojiCreateRequest) (*apimodel.Emoji, gtserror.WithCode) {
+func (p *processor) AdminEmojiCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.EmojiCreateRequest) (*apimodel.AdminEmoji, gtserror.WithCode) {
 	return p.adminProcessor.EmojiCreate(ctx, authed.Account, authed.User, form)
 }
 
+func (p *processor) AdminEmojiUpdate(ctx context.Context, authed *oauth.Auth, id string, form *apimodel.EmojiUpdateRequest) (*apimodel.AdminEmoji, gtserror.WithCode) {
+	return p.adminProcessor.EmojiUpdate(ctx, authed.Account, authed.User, id, form)
+}
+
 func (p *processor) AdminDomainBlockCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.DomainBlockCreateRequest) (*apimodel.DomainBlock, gtserror.WithCode) {
 	return p.adminProcessor.DomainBlockCreate(ctx, authed.Account, form.Domain, form.Obfuscate, form.PublicComment, form.PrivateComment, "")
 }
@@ -54,6 +58,74 @@ func (p *processor) AdminDomainBlockDelete(ctx context.Context, authed *oauth.Au
 	return p.adminProcessor.DomainBlockDelete(ctx, authed.Account, id)
 }
 
+func (p *processor) AdminDomainPauseCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.DomainPauseCreateRequest) (*apimodel.DomainPause, gtserror.WithCode) {
+	return p.adminProcessor.DomainPauseCreate(ctx, authed.Account, form.Domain, form.PrivateComment)
+}
+
+func (p *processor) AdminDomainPausesGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.DomainPause, gtserror.WithCode) {
+	return p.adminProcessor.DomainPausesGet(ctx, authed.Account)
+}
+
+func (p *processor) AdminDomainPauseDelete(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.DomainPause, gtserror.WithCode) {
+	return p.adminProcessor.DomainPauseDelete(ctx, authed.Account, id)
+}
+
+func (p *processor) AdminDomainSilenceCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.DomainSilenceCreateRequest) (*apimodel.DomainSilence, gtserror.WithCode) {
+	return p.adminProcessor.DomainSilenceCreate(ctx, authed.Account, form.Domain, form.PrivateComment)
+}
+
+func (p *processor) AdminDomainSilencesGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.DomainSilence, gtserror.WithCode) {
+	return p.adminProcessor.DomainSilencesGet(ctx, authed.Account)
+}
+
+func (p *processor) AdminDomainSilenceDelete(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.DomainSilence, gtserror.WithCode) {
+	return p.adminProcessor.DomainSilenceDelete(ctx, authed.Account, id)
+}
+
 func (p *processor) AdminMediaRemotePrune(ctx context.Context, mediaRemoteCacheDays int) gtserror.WithCode {
 	return p.adminProcessor.MediaRemotePrune(ctx, mediaRemoteCacheDays)
 }
+
+func (p *processor) AdminMediaQueueGet(ctx context.Context, authed *oauth.Auth) *apimodel.AdminMediaQueue {
+	return p.adminProcessor.MediaQueueGet(ctx)
+}
+
+func (p *processor) AdminRelationshipSeveranceCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AdminRelationshipSeveranceRequest) (*apimodel.AdminRelationshipSeverance, gtserror.WithCode) {
+	return p.adminProcessor.RelationshipSeveranceCreate(ctx, authed.Account, form)
+}
+
+func (p *processor) AdminRelationshipSeveranceGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminRelationshipSeverance, gtserror.WithCode) {
+	return p.adminProcessor.RelationshipSeveranceGet(ctx, authed.Account, id)
+}
+
+func (p *processor) AdminRegistrationsSet(ctx context.Context, authed *oauth.Auth, form *apimodel.AdminRegistrationsRequest) (*apimodel.AdminRegistrations, gtserror.WithCode) {
+	return p.adminProcessor.RegistrationsSet(ctx, authed.Account, *form.Open)
+}
+
+func (p *processor) AdminReadOnlySet(ctx context.Context, authed *oauth.Auth, form *apimodel.AdminReadOnlyRequest) (*apimodel.AdminReadOnly, gtserror.WithCode) {
+	return p.adminProcessor.ReadOnlySet(ctx, authed.Account, *form.ReadOnly, form.Message)
+}
+
+func (p *processor) AdminModerationRuleCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.ModerationRuleCreateRequest) (*apimodel.ModerationRule, gtserror.WithCode) {
+	return p.adminProcessor.ModerationRuleCreate(ctx, authed.Account, form)
+}
+
+func (p *processor) AdminModerationRulesGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.ModerationRule, gtserror.WithCode) {
+	return p.adminProcessor.ModerationRulesGet(ctx, authed.Account)
+}
+
+func (p *processor) AdminModerationRuleDelete(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.ModerationRule, gtserror.WithCode) {
+	return p.adminProcessor.ModerationRuleDelete(ctx, authed.Account, id)
+}
+
+func (p *processor) AdminModeratedStatusesGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.AdminModeratedStatus, gtserror.WithCode) {
+	return p.adminProcessor.ModeratedStatusesGet(ctx, authed.Account)
+}
+
+func (p *processor) AdminModeratedStatusApprove(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminModeratedStatus, gtserror.WithCode) {
+	return p.adminProcessor.ModeratedStatusApprove(ctx, authed.Account, id)
+}
+
+func (p *processor) AdminModeratedStatusReject(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminModeratedStatus, gtserror.WithCode) {
+	return p.adminProcessor.ModeratedStatusReject(ctx, authed.Account, id)
+}
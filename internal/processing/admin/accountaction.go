@@ -3,9 +3,11 @@ package admin
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
@@ -40,6 +42,61 @@ func (p *processor) AccountAction(ctx context.Context, account *gtsmodel.Account
 			OriginAccount:  account,
 			TargetAccount:  targetAccount,
 		})
+	case string(gtsmodel.AdminActionSilence):
+		adminAction.Type = gtsmodel.AdminActionSilence
+		// the account can keep posting as normal, its statuses are just kept out of public timelines
+		if targetAccount.SilencedAt.IsZero() {
+			targetAccount.SilencedAt = time.Now()
+			if _, err := p.db.UpdateAccount(ctx, targetAccount); err != nil {
+				return gtserror.NewErrorInternalError(fmt.Errorf("AccountAction: error silencing account %s: %s", targetAccount.ID, err))
+			}
+		}
+	case string(gtsmodel.AdminActionUnsilence):
+		adminAction.Type = gtsmodel.AdminActionUnsilence
+		if !targetAccount.SilencedAt.IsZero() {
+			targetAccount.SilencedAt = time.Time{}
+			if _, err := p.db.UpdateAccount(ctx, targetAccount); err != nil {
+				return gtserror.NewErrorInternalError(fmt.Errorf("AccountAction: error unsilencing account %s: %s", targetAccount.ID, err))
+			}
+		}
+	case string(gtsmodel.AdminActionSensitive):
+		adminAction.Type = gtsmodel.AdminActionSensitive
+		// statuses by the account will be marked as sensitive from now on, whatever the author sets
+		if targetAccount.SensitizedAt.IsZero() {
+			targetAccount.SensitizedAt = time.Now()
+			if _, err := p.db.UpdateAccount(ctx, targetAccount); err != nil {
+				return gtserror.NewErrorInternalError(fmt.Errorf("AccountAction: error marking account %s sensitive: %s", targetAccount.ID, err))
+			}
+		}
+	case string(gtsmodel.AdminActionUnsensitive):
+		adminAction.Type = gtsmodel.AdminActionUnsensitive
+		if !targetAccount.SensitizedAt.IsZero() {
+			targetAccount.SensitizedAt = time.Time{}
+			if _, err := p.db.UpdateAccount(ctx, targetAccount); err != nil {
+				return gtserror.NewErrorInternalError(fmt.Errorf("AccountAction: error unmarking account %s sensitive: %s", targetAccount.ID, err))
+			}
+		}
+	case string(gtsmodel.AdminActionUnlock):
+		adminAction.Type = gtsmodel.AdminActionUnlock
+		// only local accounts can sign in, so only they can be locked out
+		if targetAccount.Domain != "" {
+			err := fmt.Errorf("AccountAction: account %s is not a local account", targetAccount.ID)
+			return gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		user := &gtsmodel.User{}
+		if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: targetAccount.ID}}, user); err != nil {
+			return gtserror.NewErrorInternalError(fmt.Errorf("AccountAction: error getting user for account %s: %s", targetAccount.ID, err))
+		}
+		if !user.SignInLockedUntil.IsZero() || user.FailedSignInCount != 0 {
+			user.SignInLockedUntil = time.Time{}
+			user.FailedSignInCount = 0
+			user.FailedSignInAt = time.Time{}
+			if err := p.db.UpdateByPrimaryKey(ctx, user); err != nil {
+				return gtserror.NewErrorInternalError(fmt.Errorf("AccountAction: error unlocking account %s: %s", targetAccount.ID, err))
+			}
+		}
+		// the user may also have locked out the addresses they were signing in from
+		p.oauthServer.SignInFailures().ClearUser(user.ID)
 	default:
 		return gtserror.NewErrorBadRequest(fmt.Errorf("admin action type %s is not supported for this endpoint", form.Type))
 	}
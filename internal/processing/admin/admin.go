@@ -23,11 +23,13 @@ import (
 	"mime/multipart"
 
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/automod"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/worker"
 )
@@ -39,24 +41,52 @@ type Processor interface {
 	DomainBlocksGet(ctx context.Context, account *gtsmodel.Account, export bool) ([]*apimodel.DomainBlock, gtserror.WithCode)
 	DomainBlockGet(ctx context.Context, account *gtsmodel.Account, id string, export bool) (*apimodel.DomainBlock, gtserror.WithCode)
 	DomainBlockDelete(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.DomainBlock, gtserror.WithCode)
+	DomainPauseCreate(ctx context.Context, account *gtsmodel.Account, domain string, privateComment string) (*apimodel.DomainPause, gtserror.WithCode)
+	DomainPausesGet(ctx context.Context, account *gtsmodel.Account) ([]*apimodel.DomainPause, gtserror.WithCode)
+	DomainPauseDelete(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.DomainPause, gtserror.WithCode)
+	DomainSilenceCreate(ctx context.Context, account *gtsmodel.Account, domain string, privateComment string) (*apimodel.DomainSilence, gtserror.WithCode)
+	DomainSilencesGet(ctx context.Context, account *gtsmodel.Account) ([]*apimodel.DomainSilence, gtserror.WithCode)
+	DomainSilenceDelete(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.DomainSilence, gtserror.WithCode)
 	AccountAction(ctx context.Context, account *gtsmodel.Account, form *apimodel.AdminAccountActionRequest) gtserror.WithCode
-	EmojiCreate(ctx context.Context, account *gtsmodel.Account, user *gtsmodel.User, form *apimodel.EmojiCreateRequest) (*apimodel.Emoji, gtserror.WithCode)
+	EmojiCreate(ctx context.Context, account *gtsmodel.Account, user *gtsmodel.User, form *apimodel.EmojiCreateRequest) (*apimodel.AdminEmoji, gtserror.WithCode)
+	EmojiUpdate(ctx context.Context, account *gtsmodel.Account, user *gtsmodel.User, id string, form *apimodel.EmojiUpdateRequest) (*apimodel.AdminEmoji, gtserror.WithCode)
 	MediaRemotePrune(ctx context.Context, mediaRemoteCacheDays int) gtserror.WithCode
+	MediaQueueGet(ctx context.Context) *apimodel.AdminMediaQueue
+	RelationshipSeveranceCreate(ctx context.Context, account *gtsmodel.Account, form *apimodel.AdminRelationshipSeveranceRequest) (*apimodel.AdminRelationshipSeverance, gtserror.WithCode)
+	RelationshipSeveranceGet(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminRelationshipSeverance, gtserror.WithCode)
+	// RelationshipSeveranceProcess removes all follows, follow requests, and blocks involving the accounts of the given
+	// pending severance, or all accounts of its domain, updating the progress of the severance as it goes. Undos and
+	// Rejects are federated via the client API worker.
+	RelationshipSeveranceProcess(ctx context.Context, pending *PendingSeverance) error
+	RegistrationsSet(ctx context.Context, account *gtsmodel.Account, open bool) (*apimodel.AdminRegistrations, gtserror.WithCode)
+	ReadOnlySet(ctx context.Context, account *gtsmodel.Account, readOnly bool, message string) (*apimodel.AdminReadOnly, gtserror.WithCode)
+	ModerationRuleCreate(ctx context.Context, account *gtsmodel.Account, form *apimodel.ModerationRuleCreateRequest) (*apimodel.ModerationRule, gtserror.WithCode)
+	ModerationRulesGet(ctx context.Context, account *gtsmodel.Account) ([]*apimodel.ModerationRule, gtserror.WithCode)
+	ModerationRuleDelete(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.ModerationRule, gtserror.WithCode)
+	ModeratedStatusesGet(ctx context.Context, account *gtsmodel.Account) ([]*apimodel.AdminModeratedStatus, gtserror.WithCode)
+	ModeratedStatusApprove(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminModeratedStatus, gtserror.WithCode)
+	ModeratedStatusReject(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminModeratedStatus, gtserror.WithCode)
 }
 
 type processor struct {
-	tc           typeutils.TypeConverter
-	mediaManager media.Manager
-	clientWorker *worker.Worker[messages.FromClientAPI]
-	db           db.DB
+	tc              typeutils.TypeConverter
+	mediaManager    media.Manager
+	moderationRules automod.Rules
+	clientWorker    *worker.Worker[messages.FromClientAPI]
+	db              db.DB
+	oauthServer     oauth.Server
+	severances      *severances
 }
 
 // New returns a new admin processor.
-func New(db db.DB, tc typeutils.TypeConverter, mediaManager media.Manager, clientWorker *worker.Worker[messages.FromClientAPI]) Processor {
+func New(db db.DB, tc typeutils.TypeConverter, mediaManager media.Manager, oauthServer oauth.Server, moderationRules automod.Rules, clientWorker *worker.Worker[messages.FromClientAPI]) Processor {
 	return &processor{
-		tc:           tc,
-		mediaManager: mediaManager,
-		clientWorker: clientWorker,
-		db:           db,
+		tc:              tc,
+		mediaManager:    mediaManager,
+		moderationRules: moderationRules,
+		clientWorker:    clientWorker,
+		db:              db,
+		oauthServer:     oauthServer,
+		severances:      newSeverances(),
 	}
 }
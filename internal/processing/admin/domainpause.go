@@ -0,0 +1,117 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+func (p *processor) DomainPauseCreate(ctx context.Context, account *gtsmodel.Account, domain string, privateComment string) (*apimodel.DomainPause, gtserror.WithCode) {
+	// domain pauses will always be lowercase
+	domain = strings.ToLower(domain)
+
+	// if federation with this domain is already paused, just return the existing pause
+	domainPause := &gtsmodel.DomainPause{}
+	err := p.db.GetWhere(ctx, []db.Where{{Key: "domain", Value: domain}}, domainPause)
+	if err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainPauseCreate: db error checking for existence of domain pause %s: %s", domain, err))
+		}
+
+		pauseID, err := id.NewULID()
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainPauseCreate: error creating id for new domain pause %s: %s", domain, err))
+		}
+
+		domainPause = &gtsmodel.DomainPause{
+			ID:                 pauseID,
+			CreatedAt:          time.Now(),
+			UpdatedAt:          time.Now(),
+			Domain:             domain,
+			CreatedByAccountID: account.ID,
+			PrivateComment:     text.RemoveHTML(privateComment),
+		}
+
+		if err := p.db.Put(ctx, domainPause); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainPauseCreate: db error putting new domain pause %s: %s", domain, err))
+		}
+
+		logrus.WithContext(ctx).Infof("federation with %s paused by account %s", domain, account.Username)
+	}
+
+	apiDomainPause, err := p.tc.DomainPauseToAPIDomainPause(ctx, domainPause)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainPauseCreate: error converting domain pause to api representation %s: %s", domain, err))
+	}
+
+	return apiDomainPause, nil
+}
+
+func (p *processor) DomainPausesGet(ctx context.Context, account *gtsmodel.Account) ([]*apimodel.DomainPause, gtserror.WithCode) {
+	domainPauses := []*gtsmodel.DomainPause{}
+	if err := p.db.GetAll(ctx, &domainPauses); err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainPausesGet: db error getting domain pauses: %s", err))
+	}
+
+	apiDomainPauses := []*apimodel.DomainPause{}
+	for _, dp := range domainPauses {
+		apiDomainPause, err := p.tc.DomainPauseToAPIDomainPause(ctx, dp)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiDomainPauses = append(apiDomainPauses, apiDomainPause)
+	}
+
+	return apiDomainPauses, nil
+}
+
+func (p *processor) DomainPauseDelete(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.DomainPause, gtserror.WithCode) {
+	domainPause := &gtsmodel.DomainPause{}
+	if err := p.db.GetByID(ctx, id, domainPause); err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainPauseDelete: db error getting domain pause %s: %s", id, err))
+		}
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no entry for ID %s", id))
+	}
+
+	apiDomainPause, err := p.tc.DomainPauseToAPIDomainPause(ctx, domainPause)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// unlike removing a domain block, there's nothing else to undo
+	if err := p.db.DeleteByID(ctx, id, domainPause); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainPauseDelete: db error deleting domain pause %s: %s", id, err))
+	}
+
+	logrus.WithContext(ctx).Infof("federation with %s unpaused by account %s", domainPause.Domain, account.Username)
+
+	return apiDomainPause, nil
+}
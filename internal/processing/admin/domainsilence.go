@@ -0,0 +1,117 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+func (p *processor) DomainSilenceCreate(ctx context.Context, account *gtsmodel.Account, domain string, privateComment string) (*apimodel.DomainSilence, gtserror.WithCode) {
+	// domain silences will always be lowercase
+	domain = strings.ToLower(domain)
+
+	// if this domain is already silenced, just return the existing silence
+	domainSilence := &gtsmodel.DomainSilence{}
+	err := p.db.GetWhere(ctx, []db.Where{{Key: "domain", Value: domain}}, domainSilence)
+	if err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainSilenceCreate: db error checking for existence of domain silence %s: %s", domain, err))
+		}
+
+		silenceID, err := id.NewULID()
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainSilenceCreate: error creating id for new domain silence %s: %s", domain, err))
+		}
+
+		domainSilence = &gtsmodel.DomainSilence{
+			ID:                 silenceID,
+			CreatedAt:          time.Now(),
+			UpdatedAt:          time.Now(),
+			Domain:             domain,
+			CreatedByAccountID: account.ID,
+			PrivateComment:     text.RemoveHTML(privateComment),
+		}
+
+		if err := p.db.Put(ctx, domainSilence); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainSilenceCreate: db error putting new domain silence %s: %s", domain, err))
+		}
+
+		logrus.WithContext(ctx).Infof("domain %s silenced by account %s", domain, account.Username)
+	}
+
+	apiDomainSilence, err := p.tc.DomainSilenceToAPIDomainSilence(ctx, domainSilence)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainSilenceCreate: error converting domain silence to api representation %s: %s", domain, err))
+	}
+
+	return apiDomainSilence, nil
+}
+
+func (p *processor) DomainSilencesGet(ctx context.Context, account *gtsmodel.Account) ([]*apimodel.DomainSilence, gtserror.WithCode) {
+	domainSilences := []*gtsmodel.DomainSilence{}
+	if err := p.db.GetAll(ctx, &domainSilences); err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainSilencesGet: db error getting domain silences: %s", err))
+	}
+
+	apiDomainSilences := []*apimodel.DomainSilence{}
+	for _, dp := range domainSilences {
+		apiDomainSilence, err := p.tc.DomainSilenceToAPIDomainSilence(ctx, dp)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiDomainSilences = append(apiDomainSilences, apiDomainSilence)
+	}
+
+	return apiDomainSilences, nil
+}
+
+func (p *processor) DomainSilenceDelete(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.DomainSilence, gtserror.WithCode) {
+	domainSilence := &gtsmodel.DomainSilence{}
+	if err := p.db.GetByID(ctx, id, domainSilence); err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainSilenceDelete: db error getting domain silence %s: %s", id, err))
+		}
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no entry for ID %s", id))
+	}
+
+	apiDomainSilence, err := p.tc.DomainSilenceToAPIDomainSilence(ctx, domainSilence)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// unlike removing a domain block, there's nothing else to undo
+	if err := p.db.DeleteByID(ctx, id, domainSilence); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("DomainSilenceDelete: db error deleting domain silence %s: %s", id, err))
+	}
+
+	logrus.WithContext(ctx).Infof("domain %s unsilenced by account %s", domainSilence.Domain, account.Username)
+
+	return apiDomainSilence, nil
+}
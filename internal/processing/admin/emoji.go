@@ -29,10 +29,11 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
-func (p *processor) EmojiCreate(ctx context.Context, account *gtsmodel.Account, user *gtsmodel.User, form *apimodel.EmojiCreateRequest) (*apimodel.Emoji, gtserror.WithCode) {
+func (p *processor) EmojiCreate(ctx context.Context, account *gtsmodel.Account, user *gtsmodel.User, form *apimodel.EmojiCreateRequest) (*apimodel.AdminEmoji, gtserror.WithCode) {
 	if !user.Admin {
 		return nil, gtserror.NewErrorNotAuthorized(fmt.Errorf("user %s not an admin", user.ID), "user is not an admin")
 	}
@@ -49,7 +50,14 @@ func (p *processor) EmojiCreate(ctx context.Context, account *gtsmodel.Account,
 
 	emojiURI := uris.GenerateURIForEmoji(emojiID)
 
-	processingEmoji, err := p.mediaManager.ProcessEmoji(ctx, data, nil, form.Shortcode, emojiID, emojiURI, nil)
+	var ai *media.AdditionalEmojiInfo
+	if form.Category != "" {
+		ai = &media.AdditionalEmojiInfo{
+			Category: &form.Category,
+		}
+	}
+
+	processingEmoji, err := p.mediaManager.ProcessEmoji(ctx, data, nil, form.Shortcode, emojiID, emojiURI, ai)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error processing emoji: %s", err), "error processing emoji")
 	}
@@ -63,10 +71,79 @@ func (p *processor) EmojiCreate(ctx context.Context, account *gtsmodel.Account,
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error loading emoji: %s", err), "error loading emoji")
 	}
 
-	apiEmoji, err := p.tc.EmojiToAPIEmoji(ctx, emoji)
+	apiEmoji, err := p.tc.EmojiToAdminAPIEmoji(ctx, emoji)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting emoji: %s", err), "error converting emoji to api representation")
+	}
+
+	return apiEmoji, nil
+}
+
+func (p *processor) EmojiUpdate(ctx context.Context, account *gtsmodel.Account, user *gtsmodel.User, id string, form *apimodel.EmojiUpdateRequest) (*apimodel.AdminEmoji, gtserror.WithCode) {
+	if !user.Admin {
+		return nil, gtserror.NewErrorNotAuthorized(fmt.Errorf("user %s not an admin", user.ID), "user is not an admin")
+	}
+
+	emoji, err := p.db.GetEmojiByID(ctx, id)
+	if err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("EmojiUpdate: db error getting emoji %s: %s", id, err))
+		}
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("EmojiUpdate: no emoji with id %s", id))
+	}
+
+	if emoji.Domain != "" {
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("EmojiUpdate: emoji %s is from %s", id, emoji.Domain), "only emojis of this instance can be updated")
+	}
+
+	if form.Shortcode != nil && *form.Shortcode != emoji.Shortcode {
+		// make sure the new shortcode isn't taken already
+		if _, err := p.db.GetEmojiByShortcodeDomain(ctx, *form.Shortcode, ""); err == nil {
+			return nil, gtserror.NewErrorConflict(fmt.Errorf("EmojiUpdate: emoji with shortcode %s already exists", *form.Shortcode), fmt.Sprintf("emoji with shortcode %s already exists", *form.Shortcode))
+		} else if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("EmojiUpdate: db error checking shortcode %s: %s", *form.Shortcode, err))
+		}
+		emoji.Shortcode = *form.Shortcode
+	}
+
+	if form.Category != nil {
+		emoji.Category = *form.Category
+	}
+
+	if form.Disabled != nil {
+		emoji.Disabled = *form.Disabled
+	}
+
+	if _, err := p.db.UpdateEmoji(ctx, emoji); err != nil {
+		var alreadyExistsError *db.ErrAlreadyExists
+		if errors.As(err, &alreadyExistsError) {
+			return nil, gtserror.NewErrorConflict(fmt.Errorf("EmojiUpdate: emoji with shortcode %s already exists", emoji.Shortcode), fmt.Sprintf("emoji with shortcode %s already exists", emoji.Shortcode))
+		}
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("EmojiUpdate: db error updating emoji %s: %s", id, err))
+	}
+
+	if form.Image != nil {
+		data := func(innerCtx context.Context) (io.Reader, int, error) {
+			f, err := form.Image.Open()
+			return f, int(form.Image.Size), err
+		}
+
+		// the new image goes through the media pipeline just like a new emoji would, but the emoji keeps its id
+		processingEmoji, err := p.mediaManager.RefreshEmoji(ctx, data, nil, emoji.ID)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("EmojiUpdate: error processing image of emoji %s: %s", id, err), "error processing emoji")
+		}
+
+		emoji, err = processingEmoji.LoadEmoji(ctx)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("EmojiUpdate: error loading image of emoji %s: %s", id, err), "error processing emoji")
+		}
+	}
+
+	apiEmoji, err := p.tc.EmojiToAdminAPIEmoji(ctx, emoji)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting emoji: %s", err), "error converting emoji to api representation")
 	}
 
-	return &apiEmoji, nil
+	return apiEmoji, nil
 }
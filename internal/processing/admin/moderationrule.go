@@ -0,0 +1,260 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/automod"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+func (p *processor) ModerationRuleCreate(ctx context.Context, account *gtsmodel.Account, form *apimodel.ModerationRuleCreateRequest) (*apimodel.ModerationRule, gtserror.WithCode) {
+	if form.Pattern == "" {
+		err := errors.New("ModerationRuleCreate: pattern was empty")
+		return nil, gtserror.NewErrorBadRequest(err, "pattern must be set")
+	}
+
+	localAction := gtsmodel.ModerationRuleLocalAction(form.LocalAction)
+	switch localAction {
+	case "":
+		localAction = gtsmodel.ModerationRuleLocalHold
+	case gtsmodel.ModerationRuleLocalHold, gtsmodel.ModerationRuleLocalReject:
+	default:
+		err := fmt.Errorf("ModerationRuleCreate: local action %s not recognized", form.LocalAction)
+		return nil, gtserror.NewErrorBadRequest(err, "local_action must be one of: hold, reject")
+	}
+
+	remoteAction := gtsmodel.ModerationRuleRemoteAction(form.RemoteAction)
+	switch remoteAction {
+	case "":
+		remoteAction = gtsmodel.ModerationRuleRemoteFlag
+	case gtsmodel.ModerationRuleRemoteFlag, gtsmodel.ModerationRuleRemoteDrop:
+	default:
+		err := fmt.Errorf("ModerationRuleCreate: remote action %s not recognized", form.RemoteAction)
+		return nil, gtserror.NewErrorBadRequest(err, "remote_action must be one of: flag, drop")
+	}
+
+	ruleID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("ModerationRuleCreate: error creating id for new moderation rule: %s", err))
+	}
+
+	moderationRule := &gtsmodel.ModerationRule{
+		ID:                 ruleID,
+		CreatedAt:          time.Now(),
+		UpdatedAt:          time.Now(),
+		Pattern:            form.Pattern,
+		Regex:              form.Regex,
+		LocalAction:        localAction,
+		RemoteAction:       remoteAction,
+		CreatedByAccountID: account.ID,
+		PrivateComment:     text.RemoveHTML(form.PrivateComment),
+	}
+
+	// make sure the pattern compiles now, rather than finding out when we try to match statuses against it
+	if _, err := automod.Compile(moderationRule); err != nil {
+		err = fmt.Errorf("ModerationRuleCreate: error compiling pattern %s: %s", form.Pattern, err)
+		return nil, gtserror.NewErrorBadRequest(err, "pattern is not a valid regular expression")
+	}
+
+	if err := p.db.Put(ctx, moderationRule); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("ModerationRuleCreate: db error putting new moderation rule: %s", err))
+	}
+	p.moderationRules.Invalidate()
+
+	logrus.WithContext(ctx).Infof("moderation rule %s created by account %s", moderationRule.ID, account.Username)
+
+	apiModerationRule, err := p.tc.ModerationRuleToAPIModerationRule(ctx, moderationRule)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("ModerationRuleCreate: error converting moderation rule to api representation: %s", err))
+	}
+
+	return apiModerationRule, nil
+}
+
+func (p *processor) ModerationRulesGet(ctx context.Context, account *gtsmodel.Account) ([]*apimodel.ModerationRule, gtserror.WithCode) {
+	moderationRules := []*gtsmodel.ModerationRule{}
+	if err := p.db.GetAll(ctx, &moderationRules); err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("ModerationRulesGet: db error getting moderation rules: %s", err))
+	}
+
+	apiModerationRules := []*apimodel.ModerationRule{}
+	for _, r := range moderationRules {
+		apiModerationRule, err := p.tc.ModerationRuleToAPIModerationRule(ctx, r)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+		apiModerationRules = append(apiModerationRules, apiModerationRule)
+	}
+
+	return apiModerationRules, nil
+}
+
+func (p *processor) ModerationRuleDelete(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.ModerationRule, gtserror.WithCode) {
+	moderationRule := &gtsmodel.ModerationRule{}
+	if err := p.db.GetByID(ctx, id, moderationRule); err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("ModerationRuleDelete: db error getting moderation rule %s: %s", id, err))
+		}
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no entry for ID %s", id))
+	}
+
+	apiModerationRule, err := p.tc.ModerationRuleToAPIModerationRule(ctx, moderationRule)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	// statuses which already matched this rule stay held or flagged until an admin reviews them
+	if err := p.db.DeleteByID(ctx, id, moderationRule); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("ModerationRuleDelete: db error deleting moderation rule %s: %s", id, err))
+	}
+	p.moderationRules.Invalidate()
+
+	logrus.WithContext(ctx).Infof("moderation rule %s deleted by account %s", id, account.Username)
+
+	return apiModerationRule, nil
+}
+
+func (p *processor) ModeratedStatusesGet(ctx context.Context, account *gtsmodel.Account) ([]*apimodel.AdminModeratedStatus, gtserror.WithCode) {
+	statuses := []*gtsmodel.Status{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "moderation_rule_id", Value: nil, Not: true}}, &statuses); err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("ModeratedStatusesGet: db error getting moderated statuses: %s", err))
+	}
+
+	// newest first
+	sort.Slice(statuses, func(i, j int) bool {
+		return statuses[i].ID > statuses[j].ID
+	})
+
+	apiModeratedStatuses := []*apimodel.AdminModeratedStatus{}
+	for _, s := range statuses {
+		apiModeratedStatus, errWithCode := p.moderatedStatusToAPI(ctx, account, s.ID)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		apiModeratedStatuses = append(apiModeratedStatuses, apiModeratedStatus)
+	}
+
+	return apiModeratedStatuses, nil
+}
+
+func (p *processor) ModeratedStatusApprove(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminModeratedStatus, gtserror.WithCode) {
+	status, errWithCode := p.getModeratedStatus(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	held := !status.HeldAt.IsZero()
+	status.ModerationRuleID = ""
+	status.HeldAt = time.Time{}
+	if err := p.db.UpdateStatus(ctx, status); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("ModeratedStatusApprove: db error updating status %s: %s", id, err))
+	}
+
+	// a held status was never timelined or federated, so do that now as if it had just been posted
+	if held {
+		p.clientWorker.Queue(messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       status,
+			OriginAccount:  status.Account,
+		})
+	}
+
+	logrus.WithContext(ctx).Infof("moderated status %s approved by account %s", id, account.Username)
+
+	return p.moderatedStatusToAPI(ctx, account, id)
+}
+
+func (p *processor) ModeratedStatusReject(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminModeratedStatus, gtserror.WithCode) {
+	status, errWithCode := p.getModeratedStatus(ctx, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiModeratedStatus, errWithCode := p.moderatedStatusToAPI(ctx, account, id)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.db.DeleteStatusByID(ctx, id); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("ModeratedStatusReject: db error deleting status %s: %s", id, err))
+	}
+
+	// clean up the rest of the status asynchronously; held statuses were never federated, so no delete is sent out for those
+	p.clientWorker.Queue(messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityDelete,
+		GTSModel:       status,
+		OriginAccount:  status.Account,
+		TargetAccount:  status.Account,
+	})
+
+	logrus.WithContext(ctx).Infof("moderated status %s rejected by account %s", id, account.Username)
+
+	return apiModeratedStatus, nil
+}
+
+// getModeratedStatus gets the status with the given id, returning an error if it doesn't exist or isn't waiting for review.
+func (p *processor) getModeratedStatus(ctx context.Context, id string) (*gtsmodel.Status, gtserror.WithCode) {
+	status, err := p.db.GetStatusByID(ctx, id)
+	if err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("getModeratedStatus: db error getting status %s: %s", id, err))
+		}
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no entry for ID %s", id))
+	}
+
+	if status.ModerationRuleID == "" {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("getModeratedStatus: status %s is not waiting for review", id))
+	}
+
+	return status, nil
+}
+
+func (p *processor) moderatedStatusToAPI(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminModeratedStatus, gtserror.WithCode) {
+	status, err := p.db.GetStatusByID(ctx, id)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("moderatedStatusToAPI: db error getting status %s: %s", id, err))
+	}
+
+	apiStatus, err := p.tc.StatusToAPIStatus(ctx, status, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("moderatedStatusToAPI: error converting status %s to api representation: %s", id, err))
+	}
+
+	return &apimodel.AdminModeratedStatus{
+		Status:           apiStatus,
+		ModerationRuleID: status.ModerationRuleID,
+		Held:             !status.HeldAt.IsZero(),
+	}, nil
+}
@@ -0,0 +1,45 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (p *processor) ReadOnlySet(ctx context.Context, account *gtsmodel.Account, readOnly bool, message string) (*apimodel.AdminReadOnly, gtserror.WithCode) {
+	if err := p.db.SetReadOnly(ctx, readOnly, message); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("ReadOnlySet: db error setting read-only mode: %s", err))
+	}
+
+	logrus.WithContext(ctx).Infof("read-only mode set to %t by account %s", readOnly, account.Username)
+
+	// get the message back from the db, since it falls back to the config if it wasn't set
+	readOnly, message, err := p.db.GetReadOnly(ctx)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("ReadOnlySet: db error getting read-only mode: %s", err))
+	}
+
+	return &apimodel.AdminReadOnly{ReadOnly: readOnly, Message: message}, nil
+}
@@ -0,0 +1,39 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (p *processor) RegistrationsSet(ctx context.Context, account *gtsmodel.Account, open bool) (*apimodel.AdminRegistrations, gtserror.WithCode) {
+	if err := p.db.SetRegistrationsOpen(ctx, open); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("RegistrationsSet: db error setting registrations: %s", err))
+	}
+
+	logrus.WithContext(ctx).Infof("registrations set to open=%t by account %s", open, account.Username)
+
+	return &apimodel.AdminRegistrations{Open: open}, nil
+}
@@ -0,0 +1,420 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package admin
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+const (
+	severanceStatePending  = "pending"
+	severanceStateRunning  = "running"
+	severanceStateFinished = "finished"
+
+	// severanceRetention is how long the progress of a finished severance is kept for.
+	severanceRetention = 24 * time.Hour
+)
+
+// PendingSeverance is queued on the client API worker when an admin asks
+// for relationships to be severed, so that they're severed in the background.
+type PendingSeverance struct {
+	ID       string              // ID of the severance
+	Domain   string              // domain to sever relationships with, if any
+	Accounts []*gtsmodel.Account // accounts to sever relationships with, if no domain is given
+}
+
+// severances keeps track of the progress of relationship severances, so that
+// admins can check up on them. Progress is only kept in memory, so it will
+// be lost if the instance is restarted, and finished severances are
+// forgotten once they've been finished for severanceRetention.
+type severances struct {
+	mu       sync.Mutex
+	byID     map[string]*apimodel.AdminRelationshipSeverance
+	finished map[string]time.Time
+}
+
+func newSeverances() *severances {
+	return &severances{
+		byID:     make(map[string]*apimodel.AdminRelationshipSeverance),
+		finished: make(map[string]time.Time),
+	}
+}
+
+// add starts keeping track of the given severance, forgetting any that finished too long ago.
+func (s *severances) add(severance *apimodel.AdminRelationshipSeverance) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, finishedAt := range s.finished {
+		if time.Since(finishedAt) > severanceRetention {
+			delete(s.byID, id)
+			delete(s.finished, id)
+		}
+	}
+	s.byID[severance.ID] = severance
+}
+
+// finish marks the severance with the given id as finished.
+func (s *severances) finish(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if severance, ok := s.byID[id]; ok {
+		now := time.Now()
+		severance.State = severanceStateFinished
+		severance.FinishedAt = now.Format(time.RFC3339)
+		s.finished[id] = now
+	}
+}
+
+// update calls fn with the severance with the given id, while holding the lock.
+func (s *severances) update(id string, fn func(*apimodel.AdminRelationshipSeverance)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if severance, ok := s.byID[id]; ok {
+		fn(severance)
+	}
+}
+
+// get returns a copy of the severance with the given id.
+func (s *severances) get(id string) (*apimodel.AdminRelationshipSeverance, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	severance, ok := s.byID[id]
+	if !ok {
+		return nil, false
+	}
+	c := *severance
+	return &c, true
+}
+
+func (p *processor) RelationshipSeveranceCreate(ctx context.Context, account *gtsmodel.Account, form *apimodel.AdminRelationshipSeveranceRequest) (*apimodel.AdminRelationshipSeverance, gtserror.WithCode) {
+	domain := strings.ToLower(strings.TrimSpace(form.Domain))
+
+	switch {
+	case domain == "" && len(form.AccountIDs) == 0:
+		return nil, gtserror.NewErrorBadRequest(errors.New("RelationshipSeveranceCreate: no accounts or domain given"), "one of account_ids or domain must be given")
+	case domain != "" && len(form.AccountIDs) != 0:
+		return nil, gtserror.NewErrorBadRequest(errors.New("RelationshipSeveranceCreate: both accounts and domain given"), "only one of account_ids or domain may be given")
+	case domain == viper.GetString(config.Keys.Host) || domain == viper.GetString(config.Keys.AccountDomain):
+		return nil, gtserror.NewErrorBadRequest(fmt.Errorf("RelationshipSeveranceCreate: domain %s is our own domain", domain), "cannot sever relationships with our own domain")
+	}
+
+	// make sure all the given accounts exist before we start, so that typos are caught early
+	targetAccounts := make([]*gtsmodel.Account, 0, len(form.AccountIDs))
+	for _, accountID := range form.AccountIDs {
+		if accountID == account.ID {
+			return nil, gtserror.NewErrorBadRequest(errors.New("RelationshipSeveranceCreate: admin tried to sever their own relationships"), "cannot sever your own relationships")
+		}
+		targetAccount, err := p.db.GetAccountByID(ctx, accountID)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				return nil, gtserror.NewErrorNotFound(fmt.Errorf("RelationshipSeveranceCreate: account %s not found", accountID), fmt.Sprintf("account %s not found", accountID))
+			}
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("RelationshipSeveranceCreate: db error getting account %s: %s", accountID, err))
+		}
+		targetAccounts = append(targetAccounts, targetAccount)
+	}
+
+	severanceID, err := id.NewULID()
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	severance := &apimodel.AdminRelationshipSeverance{
+		ID:         severanceID,
+		AccountIDs: form.AccountIDs,
+		Domain:     domain,
+		State:      severanceStatePending,
+		CreatedAt:  time.Now().Format(time.RFC3339),
+	}
+
+	p.severances.add(severance)
+
+	// process the severance asynchronously since it might take a while
+	p.clientWorker.Queue(messages.FromClientAPI{
+		APObjectType:   ap.ObjectRelationship,
+		APActivityType: ap.ActivityDelete,
+		GTSModel: &PendingSeverance{
+			ID:       severanceID,
+			Domain:   domain,
+			Accounts: targetAccounts,
+		},
+		OriginAccount: account,
+	})
+
+	s, _ := p.severances.get(severanceID)
+	return s, nil
+}
+
+func (p *processor) RelationshipSeveranceGet(ctx context.Context, account *gtsmodel.Account, id string) (*apimodel.AdminRelationshipSeverance, gtserror.WithCode) {
+	severance, ok := p.severances.get(id)
+	if !ok {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("RelationshipSeveranceGet: relationship severance %s not found", id))
+	}
+	return severance, nil
+}
+
+func (p *processor) RelationshipSeveranceProcess(ctx context.Context, pending *PendingSeverance) error {
+	severanceID := pending.ID
+	domain := pending.Domain
+	accounts := pending.Accounts
+
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func":        "RelationshipSeveranceProcess",
+		"severanceID": severanceID,
+	})
+
+	// enumerate all accounts of the domain first, so that we know how many there are to process
+	if domain != "" {
+		limit := 20      // just select 20 accounts at a time so we don't nuke our DB/mem with one huge query
+		var maxID string // this is initially an empty string so we'll start at the top of accounts list (sorted by ID)
+
+	selectAccountsLoop:
+		for {
+			domainAccounts, err := p.db.GetInstanceAccounts(ctx, domain, maxID, limit)
+			if err != nil {
+				if err != db.ErrNoEntries {
+					// an actual error has occurred
+					l.Errorf("db error selecting accounts for domain %s: %s", domain, err)
+					p.severances.update(severanceID, func(s *apimodel.AdminRelationshipSeverance) { s.Errors++ })
+				}
+				break selectAccountsLoop
+			}
+			accounts = append(accounts, domainAccounts...)
+			maxID = domainAccounts[len(domainAccounts)-1].ID
+		}
+	}
+
+	p.severances.update(severanceID, func(s *apimodel.AdminRelationshipSeverance) {
+		s.State = severanceStateRunning
+		s.AccountsTotal = len(accounts)
+	})
+
+	for _, account := range accounts {
+		removed, errs := p.severAccountRelationships(ctx, account)
+		for _, err := range errs {
+			l.Errorf("error severing relationships of account %s: %s", account.ID, err)
+		}
+
+		p.severances.update(severanceID, func(s *apimodel.AdminRelationshipSeverance) {
+			s.AccountsProcessed++
+			s.RelationshipsRemoved += removed
+			s.Errors += len(errs)
+		})
+	}
+
+	p.severances.finish(severanceID)
+	l.Infof("done severing relationships of %d accounts", len(accounts))
+	return nil
+}
+
+// severAccountRelationships removes all follows, follow requests, and blocks involving the given account,
+// returning the number of relationships removed, and any errors encountered along the way.
+func (p *processor) severAccountRelationships(ctx context.Context, account *gtsmodel.Account) (int, []error) {
+	var (
+		removed int
+		errs    []error
+	)
+
+	// follows, in both directions
+	for _, key := range []string{"account_id", "target_account_id"} {
+		follows := []*gtsmodel.Follow{}
+		if err := p.db.GetWhere(ctx, []db.Where{{Key: key, Value: account.ID}}, &follows); err != nil && err != db.ErrNoEntries {
+			errs = append(errs, fmt.Errorf("db error selecting follows: %s", err))
+			continue
+		}
+		for _, follow := range follows {
+			if err := p.severFollow(ctx, follow); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			removed++
+		}
+	}
+
+	// follow requests, in both directions
+	for _, key := range []string{"account_id", "target_account_id"} {
+		followRequests := []*gtsmodel.FollowRequest{}
+		if err := p.db.GetWhere(ctx, []db.Where{{Key: key, Value: account.ID}}, &followRequests); err != nil && err != db.ErrNoEntries {
+			errs = append(errs, fmt.Errorf("db error selecting follow requests: %s", err))
+			continue
+		}
+		for _, followRequest := range followRequests {
+			if err := p.severFollowRequest(ctx, followRequest); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			removed++
+		}
+	}
+
+	// blocks, in both directions
+	for _, key := range []string{"account_id", "target_account_id"} {
+		blocks := []*gtsmodel.Block{}
+		if err := p.db.GetWhere(ctx, []db.Where{{Key: key, Value: account.ID}}, &blocks); err != nil && err != db.ErrNoEntries {
+			errs = append(errs, fmt.Errorf("db error selecting blocks: %s", err))
+			continue
+		}
+		for _, block := range blocks {
+			if err := p.severBlock(ctx, block); err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			removed++
+		}
+	}
+
+	return removed, errs
+}
+
+// getRelationshipAccounts fetches the origin and target accounts of a relationship.
+func (p *processor) getRelationshipAccounts(ctx context.Context, accountID string, targetAccountID string) (*gtsmodel.Account, *gtsmodel.Account, error) {
+	originAccount, err := p.db.GetAccountByID(ctx, accountID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("db error getting account %s: %s", accountID, err)
+	}
+	targetAccount, err := p.db.GetAccountByID(ctx, targetAccountID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("db error getting account %s: %s", targetAccountID, err)
+	}
+	return originAccount, targetAccount, nil
+}
+
+// severFollow removes the given follow. If the follower is local, an Undo is federated to the followed account;
+// if the followed account is local, a Reject is federated to the follower, so that it stops considering itself a follower.
+func (p *processor) severFollow(ctx context.Context, follow *gtsmodel.Follow) error {
+	originAccount, targetAccount, err := p.getRelationshipAccounts(ctx, follow.AccountID, follow.TargetAccountID)
+	if err != nil {
+		return err
+	}
+
+	if err := p.db.DeleteByID(ctx, follow.ID, follow); err != nil {
+		return fmt.Errorf("db error removing follow %s: %s", follow.ID, err)
+	}
+
+	switch {
+	case originAccount.Domain == "":
+		p.clientWorker.Queue(messages.FromClientAPI{
+			APObjectType:   ap.ActivityFollow,
+			APActivityType: ap.ActivityUndo,
+			GTSModel:       follow,
+			OriginAccount:  originAccount,
+			TargetAccount:  targetAccount,
+		})
+	case targetAccount.Domain == "":
+		p.clientWorker.Queue(messages.FromClientAPI{
+			APObjectType:   ap.ActivityFollow,
+			APActivityType: ap.ActivityReject,
+			GTSModel: &gtsmodel.FollowRequest{
+				ID:              follow.ID,
+				URI:             follow.URI,
+				AccountID:       follow.AccountID,
+				Account:         originAccount,
+				TargetAccountID: follow.TargetAccountID,
+				TargetAccount:   targetAccount,
+			},
+			OriginAccount: originAccount,
+			TargetAccount: targetAccount,
+		})
+	}
+
+	return nil
+}
+
+// severFollowRequest removes the given follow request, federating an Undo or a Reject
+// in the same way as severFollow.
+func (p *processor) severFollowRequest(ctx context.Context, followRequest *gtsmodel.FollowRequest) error {
+	originAccount, targetAccount, err := p.getRelationshipAccounts(ctx, followRequest.AccountID, followRequest.TargetAccountID)
+	if err != nil {
+		return err
+	}
+
+	if err := p.db.DeleteByID(ctx, followRequest.ID, followRequest); err != nil {
+		return fmt.Errorf("db error removing follow request %s: %s", followRequest.ID, err)
+	}
+
+	switch {
+	case originAccount.Domain == "":
+		p.clientWorker.Queue(messages.FromClientAPI{
+			APObjectType:   ap.ActivityFollow,
+			APActivityType: ap.ActivityUndo,
+			GTSModel: &gtsmodel.Follow{
+				ID:              followRequest.ID,
+				URI:             followRequest.URI,
+				AccountID:       followRequest.AccountID,
+				TargetAccountID: followRequest.TargetAccountID,
+			},
+			OriginAccount: originAccount,
+			TargetAccount: targetAccount,
+		})
+	case targetAccount.Domain == "":
+		followRequest.Account = originAccount
+		followRequest.TargetAccount = targetAccount
+		p.clientWorker.Queue(messages.FromClientAPI{
+			APObjectType:   ap.ActivityFollow,
+			APActivityType: ap.ActivityReject,
+			GTSModel:       followRequest,
+			OriginAccount:  originAccount,
+			TargetAccount:  targetAccount,
+		})
+	}
+
+	return nil
+}
+
+// severBlock removes the given block. If the blocking account is local, an Undo is federated to the blocked account.
+func (p *processor) severBlock(ctx context.Context, block *gtsmodel.Block) error {
+	originAccount, targetAccount, err := p.getRelationshipAccounts(ctx, block.AccountID, block.TargetAccountID)
+	if err != nil {
+		return err
+	}
+
+	if err := p.db.DeleteByID(ctx, block.ID, block); err != nil {
+		return fmt.Errorf("db error removing block %s: %s", block.ID, err)
+	}
+
+	if originAccount.Domain == "" {
+		block.Account = originAccount
+		block.TargetAccount = targetAccount
+		p.clientWorker.Queue(messages.FromClientAPI{
+			APObjectType:   ap.ActivityBlock,
+			APActivityType: ap.ActivityUndo,
+			GTSModel:       block,
+			OriginAccount:  originAccount,
+			TargetAccount:  targetAccount,
+		})
+	}
+
+	return nil
+}
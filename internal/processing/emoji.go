@@ -0,0 +1,88 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (p *processor) CustomEmojisGet(ctx context.Context) ([]*apimodel.Emoji, gtserror.WithCode) {
+	emojis, errWithCode := p.groupedLocalEmojis(ctx)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	apiEmojis := make([]*apimodel.Emoji, 0, len(emojis))
+	for _, e := range emojis {
+		apiEmoji, err := p.tc.EmojiToAPIEmoji(ctx, e)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("CustomEmojisGet: error converting emoji %s: %s", e.ID, err))
+		}
+		apiEmojis = append(apiEmojis, &apiEmoji)
+	}
+
+	return apiEmojis, nil
+}
+
+func (p *processor) CustomEmojiCategoriesGet(ctx context.Context) ([]string, gtserror.WithCode) {
+	emojis, errWithCode := p.groupedLocalEmojis(ctx)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// emojis are already grouped, so each new category only needs comparing with the previous one
+	categories := []string{}
+	for _, e := range emojis {
+		if e.Category == "" {
+			// uncategorized emojis are at the end, so we're done
+			break
+		}
+		if len(categories) == 0 || categories[len(categories)-1] != e.Category {
+			categories = append(categories, e.Category)
+		}
+	}
+
+	return categories, nil
+}
+
+// groupedLocalEmojis returns the enabled local emojis, grouped by category in alphabetical order, with the
+// uncategorized emojis in a bucket at the end. Within each category, emojis are in order of shortcode.
+func (p *processor) groupedLocalEmojis(ctx context.Context) ([]*gtsmodel.Emoji, gtserror.WithCode) {
+	emojis, err := p.db.GetLocalEnabledEmojis(ctx)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("groupedLocalEmojis: db error getting emojis: %s", err))
+	}
+
+	// the db gives us emojis ordered by shortcode, so a stable sort keeps that order within each category
+	sort.SliceStable(emojis, func(i, j int) bool {
+		ci, cj := emojis[i].Category, emojis[j].Category
+		if ci == "" || cj == "" {
+			return cj == "" && ci != ""
+		}
+		return ci < cj
+	})
+
+	return emojis, nil
+}
@@ -0,0 +1,87 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type EmojiTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *EmojiTestSuite) TestCustomEmojisGetGrouped() {
+	ctx := context.Background()
+
+	// put a few more emojis in the database, copied from the rainbow emoji
+	for _, e := range []struct {
+		id        string
+		shortcode string
+		category  string
+		disabled  bool
+	}{
+		{"01G5ZB3PQKTEHH5VEJ1Y7J3YNA", "blobcat_uwu", "blobcats", false},
+		{"01G5ZB3PQKTEHH5VEJ1Y7J3YNB", "aaa_blobcat", "blobcats", false},
+		{"01G5ZB3PQKTEHH5VEJ1Y7J3YNC", "acorn", "autumn", false},
+		{"01G5ZB3PQKTEHH5VEJ1Y7J3YND", "maple_leaf", "autumn", true},
+	} {
+		emoji := testrig.NewTestEmojis()["rainbow"]
+		emoji.ID = e.id
+		emoji.Shortcode = e.shortcode
+		emoji.URI = "http://localhost:8080/emoji/" + e.id
+		emoji.Category = e.category
+		emoji.Disabled = e.disabled
+		suite.NoError(suite.db.Put(ctx, emoji))
+	}
+
+	emojis, errWithCode := suite.processor.CustomEmojisGet(ctx)
+	suite.NoError(errWithCode)
+
+	shortcodes := []string{}
+	for _, e := range emojis {
+		shortcodes = append(shortcodes, e.Shortcode)
+	}
+	suite.Equal([]string{"acorn", "aaa_blobcat", "blobcat_uwu", "rainbow"}, shortcodes)
+	suite.Equal(&apimodel.Emoji{
+		Shortcode:       "acorn",
+		URL:             emojis[0].URL,
+		StaticURL:       emojis[0].StaticURL,
+		VisibleInPicker: true,
+		Category:        "autumn",
+	}, emojis[0])
+
+	categories, errWithCode := suite.processor.CustomEmojiCategoriesGet(ctx)
+	suite.NoError(errWithCode)
+	suite.Equal([]string{"autumn", "blobcats"}, categories)
+}
+
+func (suite *EmojiTestSuite) TestCustomEmojiCategoriesGetNone() {
+	categories, errWithCode := suite.processor.CustomEmojiCategoriesGet(context.Background())
+	suite.NoError(errWithCode)
+	suite.Empty(categories)
+}
+
+func TestEmojiTestSuite(t *testing.T) {
+	suite.Run(t, new(EmojiTestSuite))
+}
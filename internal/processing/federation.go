@@ -23,7 +23,9 @@ import (
 	"net/http"
 	"net/url"
 
+	"github.com/spf13/viper"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 )
 
@@ -64,5 +66,11 @@ func (p *processor) GetNodeInfo(ctx context.Context, request *http.Request) (*ap
 }
 
 func (p *processor) InboxPost(ctx context.Context, w http.ResponseWriter, r *http.Request) (bool, error) {
+	if viper.GetBool(config.Keys.InstanceReadOnlyRejectFederation) {
+		// reject the activity, so that the remote retries delivery once we're out of read-only mode
+		if errWithCode := p.checkReadOnly(ctx); errWithCode != nil {
+			return false, errWithCode
+		}
+	}
 	return p.federationProcessor.PostInbox(ctx, w, r)
 }
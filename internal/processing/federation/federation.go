@@ -23,7 +23,10 @@ import (
 	"net/http"
 	"net/url"
 
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
@@ -84,6 +87,8 @@ type processor struct {
 	federator federation.Federator
 	tc        typeutils.TypeConverter
 	filter    visibility.Filter
+
+	activeUsers *activeUsersCache
 }
 
 // New returns a new federation processor.
@@ -93,5 +98,18 @@ func New(db db.DB, tc typeutils.TypeConverter, federator federation.Federator) P
 		federator: federator,
 		tc:        tc,
 		filter:    visibility.NewFilter(db),
+
+		activeUsers: &activeUsersCache{},
+	}
+}
+
+// signatureRequired returns true if an incoming GET request for one of our
+// actors or objects should be authenticated with its http signature. This is
+// always the case when authorized fetch is enabled; otherwise, we only check
+// signatures of requests that were signed in the first place.
+func signatureRequired(ctx context.Context) bool {
+	if viper.GetBool(config.Keys.InstanceAuthorizedFetch) {
+		return true
 	}
+	return ctx.Value(ap.ContextRequestingPublicKeyVerifier) != nil
 }
@@ -22,6 +22,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"sync"
+	"time"
 
 	"github.com/spf13/viper"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -39,6 +41,21 @@ var (
 	nodeInfoProtocols = []string{"activitypub"}
 )
 
+const (
+	// activeMonthWindow and activeHalfyearWindow are the windows in which users count as active for nodeinfo.
+	activeMonthWindow    = 30 * 24 * time.Hour
+	activeHalfyearWindow = 180 * 24 * time.Hour
+)
+
+// activeUsersCache caches counts of active users for nodeinfo, since counting them means looking through
+// all the users and statuses in the database, and nodeinfo is requested often by other instances.
+type activeUsersCache struct {
+	sync.Mutex
+	counted  time.Time
+	month    int
+	halfyear int
+}
+
 func (p *processor) GetNodeInfoRel(ctx context.Context, request *http.Request) (*apimodel.WellKnownResponse, gtserror.WithCode) {
 	protocol := viper.GetString(config.Keys.Protocol)
 	host := viper.GetString(config.Keys.Host)
@@ -54,9 +71,23 @@ func (p *processor) GetNodeInfoRel(ctx context.Context, request *http.Request) (
 }
 
 func (p *processor) GetNodeInfo(ctx context.Context, request *http.Request) (*apimodel.Nodeinfo, gtserror.WithCode) {
-	openRegistration := viper.GetBool(config.Keys.AccountsRegistrationOpen)
+	openRegistration, err := p.db.GetRegistrationsOpen(ctx)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("GetNodeInfo: error checking whether registrations are open: %s", err))
+	}
 	softwareVersion := viper.GetString(config.Keys.SoftwareVersion)
 
+	host := viper.GetString(config.Keys.Host)
+	totalUsers, err := p.db.CountInstanceUsers(ctx, host)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("GetNodeInfo: error counting users: %s", err))
+	}
+
+	activeMonth, activeHalfyear, err := p.countActiveUsers(ctx)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("GetNodeInfo: error counting active users: %s", err))
+	}
+
 	return &apimodel.Nodeinfo{
 		Version: nodeInfoVersion,
 		Software: apimodel.NodeInfoSoftware{
@@ -70,8 +101,39 @@ func (p *processor) GetNodeInfo(ctx context.Context, request *http.Request) (*ap
 		},
 		OpenRegistrations: openRegistration,
 		Usage: apimodel.NodeInfoUsage{
-			Users: apimodel.NodeInfoUsers{},
+			Users: apimodel.NodeInfoUsers{
+				Total:          totalUsers,
+				ActiveMonth:    activeMonth,
+				ActiveHalfyear: activeHalfyear,
+			},
 		},
 		Metadata: make(map[string]interface{}),
 	}, nil
 }
+
+// countActiveUsers returns the number of users who have been active in the last month and the last half year,
+// counting them again only if the cached counts are older than the configured active users cache duration.
+func (p *processor) countActiveUsers(ctx context.Context) (int, int, error) {
+	c := p.activeUsers
+	c.Lock()
+	defer c.Unlock()
+
+	if !c.counted.IsZero() && time.Since(c.counted) < viper.GetDuration(config.Keys.InstanceActiveUsersCacheDuration) {
+		return c.month, c.halfyear, nil
+	}
+
+	now := time.Now()
+	month, err := p.db.CountActiveUsers(ctx, now.Add(-activeMonthWindow))
+	if err != nil {
+		return 0, 0, err
+	}
+	halfyear, err := p.db.CountActiveUsers(ctx, now.Add(-activeHalfyearWindow))
+	if err != nil {
+		return 0, 0, err
+	}
+
+	c.counted = now
+	c.month = month
+	c.halfyear = halfyear
+	return month, halfyear, nil
+}
@@ -25,6 +25,7 @@ import (
 
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 func (p *processor) GetStatus(ctx context.Context, requestedUsername string, requestedStatusID string, requestURL *url.URL) (interface{}, gtserror.WithCode) {
@@ -34,26 +35,32 @@ func (p *processor) GetStatus(ctx context.Context, requestedUsername string, req
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("database error getting account with username %s: %s", requestedUsername, err))
 	}
 
-	// authenticate the request
-	requestingAccountURI, errWithCode := p.federator.AuthenticateFederatedRequest(ctx, requestedUsername)
-	if errWithCode != nil {
-		return nil, errWithCode
-	}
-
-	requestingAccount, err := p.federator.GetRemoteAccount(ctx, requestedUsername, requestingAccountURI, false, false)
-	if err != nil {
-		return nil, gtserror.NewErrorNotAuthorized(err)
-	}
-
-	// authorize the request:
-	// 1. check if a block exists between the requester and the requestee
-	blocked, err := p.db.IsBlocked(ctx, requestedAccount.ID, requestingAccount.ID, true)
-	if err != nil {
-		return nil, gtserror.NewErrorInternalError(err)
-	}
-
-	if blocked {
-		return nil, gtserror.NewErrorNotAuthorized(fmt.Errorf("block exists between accounts %s and %s", requestedAccount.ID, requestingAccount.ID))
+	// requestingAccount stays nil for unsigned requests, which
+	// means only public statuses will be visible to the requester
+	var requestingAccount *gtsmodel.Account
+
+	if signatureRequired(ctx) {
+		// authenticate the request
+		requestingAccountURI, errWithCode := p.federator.AuthenticateFederatedRequest(ctx, requestedUsername)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		requestingAccount, err = p.federator.GetRemoteAccount(ctx, requestedUsername, requestingAccountURI, false, false)
+		if err != nil {
+			return nil, gtserror.NewErrorNotAuthorized(err)
+		}
+
+		// authorize the request:
+		// 1. check if a block exists between the requester and the requestee
+		blocked, err := p.db.IsBlocked(ctx, requestedAccount.ID, requestingAccount.ID, true)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if blocked {
+			return nil, gtserror.NewErrorNotAuthorized(fmt.Errorf("block exists between accounts %s and %s", requestedAccount.ID, requestingAccount.ID))
+		}
 	}
 
 	// get the status out of the database here
@@ -71,7 +78,7 @@ func (p *processor) GetStatus(ctx context.Context, requestedUsername string, req
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 	if !visible {
-		return nil, gtserror.NewErrorNotFound(fmt.Errorf("status with id %s not visible to user with id %s", s.ID, requestingAccount.ID))
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("status with id %s not visible to requester", s.ID))
 	}
 
 	// requester is authorized to view the status, so convert it to AP representation and serialize it
@@ -22,10 +22,13 @@ import (
 	"context"
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
@@ -39,7 +42,18 @@ func (p *processor) GetUser(ctx context.Context, requestedUsername string, reque
 	var requestedPerson vocab.ActivityStreamsPerson
 	if uris.IsPublicKeyPath(requestURL) {
 		// if it's a public key path, we don't need to authenticate but we'll only serve the bare minimum user profile needed for the public key
-		requestedPerson, err = p.tc.AccountToASMinimal(ctx, requestedAccount)
+		keyAccount, errWithCode := p.publicKeyAccount(ctx, requestedAccount, requestURL)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+
+		requestedPerson, err = p.tc.AccountToASMinimal(ctx, keyAccount)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+	} else if !signatureRequired(ctx) {
+		// authorized fetch is disabled and the request wasn't signed, so just serve the public profile
+		requestedPerson, err = p.tc.AccountToAS(ctx, requestedAccount)
 		if err != nil {
 			return nil, gtserror.NewErrorInternalError(err)
 		}
@@ -80,3 +94,41 @@ func (p *processor) GetUser(ctx context.Context, requestedUsername string, reque
 
 	return data, nil
 }
+
+// publicKeyAccount returns the account to serve at the public key path of the given request url.
+// This is usually just the given account, but if the path is that of a key which has since been
+// rotated out, a copy of the account with the old key is returned instead, as long as the old key
+// hasn't expired yet.
+func (p *processor) publicKeyAccount(ctx context.Context, account *gtsmodel.Account, requestURL *url.URL) (*gtsmodel.Account, gtserror.WithCode) {
+	currentKeyURI, err := url.Parse(account.PublicKeyURI)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("publicKeyAccount: error parsing public key uri %s: %s", account.PublicKeyURI, err))
+	}
+
+	if currentKeyURI.Path == requestURL.Path {
+		return account, nil
+	}
+
+	keys := []*gtsmodel.AccountKey{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, &keys); err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("publicKeyAccount: error getting old keys of account %s: %s", account.ID, err))
+	}
+
+	for _, key := range keys {
+		keyURI, err := url.Parse(key.PublicKeyURI)
+		if err != nil || keyURI.Path != requestURL.Path {
+			continue
+		}
+
+		if time.Now().After(key.ExpiresAt) {
+			break
+		}
+
+		keyAccount := *account
+		keyAccount.PublicKey = key.PublicKey
+		keyAccount.PublicKeyURI = key.PublicKeyURI
+		return &keyAccount, nil
+	}
+
+	return nil, gtserror.NewErrorNotFound(fmt.Errorf("publicKeyAccount: no valid public key found at %s", requestURL.Path))
+}
@@ -19,10 +19,144 @@
 package federation
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"io"
 	"net/http"
+	"strings"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 )
 
 func (p *processor) PostInbox(ctx context.Context, w http.ResponseWriter, r *http.Request) (bool, error) {
+	if activityType, filtered := activityTypeFiltered(r); filtered {
+		// accept the activity so that the remote doesn't retry delivery, but do nothing with it
+		logrus.WithContext(ctx).WithField("func", "PostInbox").Debugf("dropping activity of filtered type %s delivered to %s", activityType, r.URL)
+		w.WriteHeader(http.StatusAccepted)
+		return true, nil
+	}
+
+	normalizeEmojiReact(r)
+
 	return p.federator.FederatingActor().PostInbox(ctx, w, r)
 }
+
+// normalizeEmojiReact rewrites EmojiReact activities in the body of the given inbox request, and Undos
+// of them, into Likes carrying a Misskey-style reaction, which is how we handle emoji reactions internally.
+// This saves us from having to teach the ActivityPub library a whole new activity type, and it's safe to do
+// before the request is authenticated, since http signatures don't cover the body itself.
+//
+// If the body can't be read or parsed, or doesn't contain an EmojiReact, it's left as it was.
+func normalizeEmojiReact(r *http.Request) {
+	if r.Body == nil {
+		return
+	}
+
+	b, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return
+	}
+
+	activity := map[string]interface{}{}
+	if err := json.Unmarshal(b, &activity); err != nil {
+		return
+	}
+
+	rewritten := emojiReactToLike(activity)
+	if activity["type"] == ap.ActivityUndo {
+		if object, ok := activity["object"].(map[string]interface{}); ok {
+			rewritten = emojiReactToLike(object)
+		}
+	}
+	if !rewritten {
+		return
+	}
+
+	nb, err := json.Marshal(activity)
+	if err != nil {
+		return
+	}
+	r.Body = io.NopCloser(bytes.NewReader(nb))
+	r.ContentLength = int64(len(nb))
+}
+
+// emojiReactToLike rewrites the given EmojiReact into a Like in place, returning true if it did anything.
+func emojiReactToLike(activity map[string]interface{}) bool {
+	if activity["type"] != ap.ActivityEmojiReact {
+		return false
+	}
+	activity["type"] = ap.ActivityLike
+	if _, ok := activity[ap.MisskeyReactionProperty]; !ok {
+		activity[ap.MisskeyReactionProperty] = activity["content"]
+	}
+	return true
+}
+
+// activityTypeFiltered checks the type of the activity in the body of the given inbox request against
+// the configured accepted and rejected activity types, returning the type and true if the activity should
+// be dropped. This happens before the request is authenticated, so that filtered activities cost us as little
+// as possible. The request body is replaced after being read, so that it can still be processed as usual.
+//
+// If the body can't be read or parsed, the activity is not filtered, so that the usual processing can reject it.
+func activityTypeFiltered(r *http.Request) (string, bool) {
+	if r.Body == nil {
+		return "", false
+	}
+
+	b, err := io.ReadAll(r.Body)
+	r.Body.Close()
+	r.Body = io.NopCloser(bytes.NewReader(b))
+	if err != nil {
+		return "", false
+	}
+
+	activity := struct {
+		Type json.RawMessage `json:"type"`
+	}{}
+	if err := json.Unmarshal(b, &activity); err != nil {
+		return "", false
+	}
+
+	// type may be a single string or, in json-ld, an array of them
+	var types []string
+	var single string
+	if err := json.Unmarshal(activity.Type, &single); err == nil {
+		types = []string{single}
+	} else if err := json.Unmarshal(activity.Type, &types); err != nil {
+		return "", false
+	}
+
+	accepted := viper.GetStringSlice(config.Keys.InstanceFederationAcceptedActivityTypes)
+	rejected := viper.GetStringSlice(config.Keys.InstanceFederationRejectedActivityTypes)
+
+	// at least one of the types must be accepted (if there's an accepted list at all), and none may be rejected
+	anyAccepted := len(accepted) == 0
+	for _, t := range types {
+		if containsType(rejected, t) {
+			return t, true
+		}
+		if containsType(accepted, t) {
+			anyAccepted = true
+		}
+	}
+
+	if !anyAccepted {
+		return strings.Join(types, ","), true
+	}
+	return "", false
+}
+
+func containsType(types []string, t string) bool {
+	for _, typ := range types {
+		if strings.EqualFold(typ, t) {
+			return true
+		}
+	}
+	return false
+}
@@ -20,6 +20,10 @@ package processing
 
 import (
 	"context"
+	"errors"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -29,6 +33,9 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
+// followRequestBulkMax is the maximum number of follow requests that can be accepted or rejected in one bulk request.
+const followRequestBulkMax = 200
+
 func (p *processor) FollowRequestsGet(ctx context.Context, auth *oauth.Auth) ([]apimodel.Account, gtserror.WithCode) {
 	frs, err := p.db.GetAccountFollowRequests(ctx, auth.Account.ID)
 	if err != nil {
@@ -57,15 +64,85 @@ func (p *processor) FollowRequestsGet(ctx context.Context, auth *oauth.Auth) ([]
 }
 
 func (p *processor) FollowRequestAccept(ctx context.Context, auth *oauth.Auth, accountID string) (*apimodel.Relationship, gtserror.WithCode) {
+	if errWithCode := p.acceptFollowRequest(ctx, auth, accountID); errWithCode != nil {
+		return nil, errWithCode
+	}
+	return p.followRequestRelationship(ctx, auth, accountID)
+}
+
+func (p *processor) FollowRequestReject(ctx context.Context, auth *oauth.Auth, accountID string) (*apimodel.Relationship, gtserror.WithCode) {
+	if errWithCode := p.rejectFollowRequest(ctx, auth, accountID); errWithCode != nil {
+		return nil, errWithCode
+	}
+	return p.followRequestRelationship(ctx, auth, accountID)
+}
+
+func (p *processor) FollowRequestAcceptAll(ctx context.Context, auth *oauth.Auth) ([]apimodel.FollowRequestBulkResult, gtserror.WithCode) {
+	frs, err := p.db.GetAccountFollowRequests(ctx, auth.Account.ID)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	accountIDs := make([]string, 0, len(frs))
+	for _, fr := range frs {
+		accountIDs = append(accountIDs, fr.AccountID)
+	}
+
+	return p.bulkFollowRequests(ctx, auth, accountIDs, p.acceptFollowRequest), nil
+}
+
+func (p *processor) FollowRequestBulk(ctx context.Context, auth *oauth.Auth, form *apimodel.FollowRequestBulkRequest) ([]apimodel.FollowRequestBulkResult, gtserror.WithCode) {
+	if len(form.AccountIDs) == 0 {
+		err := errors.New("no account ids given")
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	if len(form.AccountIDs) > followRequestBulkMax {
+		err := fmt.Errorf("no more than %d account ids can be given, but %d were given", followRequestBulkMax, len(form.AccountIDs))
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	switch form.Action {
+	case apimodel.FollowRequestBulkActionAccept:
+		return p.bulkFollowRequests(ctx, auth, form.AccountIDs, p.acceptFollowRequest), nil
+	case apimodel.FollowRequestBulkActionReject:
+		return p.bulkFollowRequests(ctx, auth, form.AccountIDs, p.rejectFollowRequest), nil
+	}
+
+	err := fmt.Errorf("action %q was not recognized, must be %s or %s", form.Action, apimodel.FollowRequestBulkActionAccept, apimodel.FollowRequestBulkActionReject)
+	return nil, gtserror.NewErrorBadRequest(err, err.Error())
+}
+
+// bulkFollowRequests calls handle for the follow request from each of the given accounts in turn, and
+// reports the outcome of each one. A failure to handle one request doesn't stop the others being handled.
+func (p *processor) bulkFollowRequests(ctx context.Context, auth *oauth.Auth, accountIDs []string, handle func(context.Context, *oauth.Auth, string) gtserror.WithCode) []apimodel.FollowRequestBulkResult {
+	results := make([]apimodel.FollowRequestBulkResult, 0, len(accountIDs))
+	for _, accountID := range accountIDs {
+		result := apimodel.FollowRequestBulkResult{
+			AccountID: accountID,
+			Success:   true,
+		}
+		if errWithCode := handle(ctx, auth, accountID); errWithCode != nil {
+			logrus.WithContext(ctx).WithField("func", "bulkFollowRequests").Debugf("error handling follow request from account %s: %s", accountID, errWithCode.Error())
+			result.Success = false
+			result.Error = errWithCode.Safe()
+		}
+		results = append(results, result)
+	}
+	return results
+}
+
+// acceptFollowRequest accepts the follow request from the given account to the authed account, and federates the Accept.
+func (p *processor) acceptFollowRequest(ctx context.Context, auth *oauth.Auth, accountID string) gtserror.WithCode {
 	follow, err := p.db.AcceptFollowRequest(ctx, accountID, auth.Account.ID)
 	if err != nil {
-		return nil, gtserror.NewErrorNotFound(err)
+		return gtserror.NewErrorNotFound(err)
 	}
 
 	if follow.Account == nil {
 		followAccount, err := p.db.GetAccountByID(ctx, follow.AccountID)
 		if err != nil {
-			return nil, gtserror.NewErrorInternalError(err)
+			return gtserror.NewErrorInternalError(err)
 		}
 		follow.Account = followAccount
 	}
@@ -73,7 +150,7 @@ func (p *processor) FollowRequestAccept(ctx context.Context, auth *oauth.Auth, a
 	if follow.TargetAccount == nil {
 		followTargetAccount, err := p.db.GetAccountByID(ctx, follow.TargetAccountID)
 		if err != nil {
-			return nil, gtserror.NewErrorInternalError(err)
+			return gtserror.NewErrorInternalError(err)
 		}
 		follow.TargetAccount = followTargetAccount
 	}
@@ -86,29 +163,20 @@ func (p *processor) FollowRequestAccept(ctx context.Context, auth *oauth.Auth, a
 		TargetAccount:  follow.TargetAccount,
 	})
 
-	gtsR, err := p.db.GetRelationship(ctx, auth.Account.ID, accountID)
-	if err != nil {
-		return nil, gtserror.NewErrorInternalError(err)
-	}
-
-	r, err := p.tc.RelationshipToAPIRelationship(ctx, gtsR)
-	if err != nil {
-		return nil, gtserror.NewErrorInternalError(err)
-	}
-
-	return r, nil
+	return nil
 }
 
-func (p *processor) FollowRequestReject(ctx context.Context, auth *oauth.Auth, accountID string) (*apimodel.Relationship, gtserror.WithCode) {
+// rejectFollowRequest rejects the follow request from the given account to the authed account, and federates the Reject.
+func (p *processor) rejectFollowRequest(ctx context.Context, auth *oauth.Auth, accountID string) gtserror.WithCode {
 	followRequest, err := p.db.RejectFollowRequest(ctx, accountID, auth.Account.ID)
 	if err != nil {
-		return nil, gtserror.NewErrorNotFound(err)
+		return gtserror.NewErrorNotFound(err)
 	}
 
 	if followRequest.Account == nil {
 		a, err := p.db.GetAccountByID(ctx, followRequest.AccountID)
 		if err != nil {
-			return nil, gtserror.NewErrorInternalError(err)
+			return gtserror.NewErrorInternalError(err)
 		}
 		followRequest.Account = a
 	}
@@ -116,7 +184,7 @@ func (p *processor) FollowRequestReject(ctx context.Context, auth *oauth.Auth, a
 	if followRequest.TargetAccount == nil {
 		a, err := p.db.GetAccountByID(ctx, followRequest.TargetAccountID)
 		if err != nil {
-			return nil, gtserror.NewErrorInternalError(err)
+			return gtserror.NewErrorInternalError(err)
 		}
 		followRequest.TargetAccount = a
 	}
@@ -129,6 +197,10 @@ func (p *processor) FollowRequestReject(ctx context.Context, auth *oauth.Auth, a
 		TargetAccount:  followRequest.TargetAccount,
 	})
 
+	return nil
+}
+
+func (p *processor) followRequestRelationship(ctx context.Context, auth *oauth.Auth, accountID string) (*apimodel.Relationship, gtserror.WithCode) {
 	gtsR, err := p.db.GetRelationship(ctx, auth.Account.ID, accountID)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
@@ -141,3 +213,11 @@ func (p *processor) FollowRequestReject(ctx context.Context, auth *oauth.Auth, a
 
 	return r, nil
 }
+
+func (p *processor) FollowRequestAutoAcceptGet(ctx context.Context, auth *oauth.Auth) (*apimodel.FollowRequestAutoAccept, gtserror.WithCode) {
+	return p.accountProcessor.AutoAcceptGet(ctx, auth.Account)
+}
+
+func (p *processor) FollowRequestAutoAcceptUpdate(ctx context.Context, auth *oauth.Auth, form *apimodel.FollowRequestAutoAcceptUpdateRequest) (*apimodel.FollowRequestAutoAccept, gtserror.WithCode) {
+	return p.accountProcessor.AutoAcceptUpdate(ctx, auth.Account, form)
+}
@@ -24,12 +24,16 @@ import (
 	"fmt"
 	"net/url"
 
+	"github.com/sirupsen/logrus"
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/admin"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
 )
 
 func (p *processor) ProcessFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
@@ -44,15 +48,29 @@ func (p *processor) ProcessFromClientAPI(ctx context.Context, clientMsg messages
 			// CREATE NOTE
 			return p.processCreateStatusFromClientAPI(ctx, clientMsg)
 		case ap.ActivityFollow:
+			if _, ok := clientMsg.GTSModel.(*gtsmodel.FollowImport); ok {
+				// CREATE FOLLOWS FROM IMPORT
+				return p.processCreateFollowImportFromClientAPI(ctx, clientMsg)
+			}
 			// CREATE FOLLOW REQUEST
 			return p.processCreateFollowRequestFromClientAPI(ctx, clientMsg)
 		case ap.ActivityLike:
 			// CREATE LIKE/FAVE
 			return p.processCreateFaveFromClientAPI(ctx, clientMsg)
+		case ap.ActivityEmojiReact:
+			// CREATE EMOJI REACTION
+			return p.processCreateReactionFromClientAPI(ctx, clientMsg)
 		case ap.ActivityAnnounce:
 			// CREATE BOOST/ANNOUNCE
 			return p.processCreateAnnounceFromClientAPI(ctx, clientMsg)
+		case ap.ObjectCollection:
+			// CREATE ACCOUNT ARCHIVE
+			return p.processCreateArchiveFromClientAPI(ctx, clientMsg)
 		case ap.ActivityBlock:
+			if _, ok := clientMsg.GTSModel.(*gtsmodel.BlockImport); ok {
+				// CREATE BLOCKS FROM IMPORT
+				return p.processCreateBlockImportFromClientAPI(ctx, clientMsg)
+			}
 			// CREATE BLOCK
 			return p.processCreateBlockFromClientAPI(ctx, clientMsg)
 		}
@@ -62,6 +80,17 @@ func (p *processor) ProcessFromClientAPI(ctx context.Context, clientMsg messages
 		case ap.ObjectProfile, ap.ActorPerson:
 			// UPDATE ACCOUNT/PROFILE
 			return p.processUpdateAccountFromClientAPI(ctx, clientMsg)
+		case ap.ObjectNote:
+			if _, ok := clientMsg.GTSModel.(*status.PendingMentions); ok {
+				// UPDATE NOTE/STATUS WITH MENTIONS RESOLVED IN THE BACKGROUND
+				return p.processUpdateStatusMentionsFromClientAPI(ctx, clientMsg)
+			}
+			if _, ok := clientMsg.GTSModel.(*status.PendingLinkCard); ok {
+				// UPDATE NOTE/STATUS WITH A LINK PREVIEW CARD GENERATED IN THE BACKGROUND
+				return p.processUpdateStatusLinkCardFromClientAPI(ctx, clientMsg)
+			}
+			// UPDATE NOTE/STATUS
+			return p.processUpdateStatusFromClientAPI(ctx, clientMsg)
 		}
 	case ap.ActivityAccept:
 		// ACCEPT
@@ -87,6 +116,9 @@ func (p *processor) ProcessFromClientAPI(ctx context.Context, clientMsg messages
 		case ap.ActivityLike:
 			// UNDO LIKE/FAVE
 			return p.processUndoFaveFromClientAPI(ctx, clientMsg)
+		case ap.ActivityEmojiReact:
+			// UNDO EMOJI REACTION
+			return p.processUndoReactionFromClientAPI(ctx, clientMsg)
 		case ap.ActivityAnnounce:
 			// UNDO ANNOUNCE/BOOST
 			return p.processUndoAnnounceFromClientAPI(ctx, clientMsg)
@@ -100,6 +132,9 @@ func (p *processor) ProcessFromClientAPI(ctx context.Context, clientMsg messages
 		case ap.ObjectProfile, ap.ActorPerson:
 			// DELETE ACCOUNT/PROFILE
 			return p.processDeleteAccountFromClientAPI(ctx, clientMsg)
+		case ap.ObjectRelationship:
+			// DELETE (SEVER) RELATIONSHIPS
+			return p.processDeleteRelationshipsFromClientAPI(ctx, clientMsg)
 		}
 	}
 	return nil
@@ -140,7 +175,12 @@ func (p *processor) processCreateStatusFromClientAPI(ctx context.Context, client
 		return err
 	}
 
-	return p.federateStatus(ctx, status)
+	if err := p.federateStatus(ctx, status); err != nil {
+		return err
+	}
+
+	p.queueLinkCard(status.ID)
+	return nil
 }
 
 func (p *processor) processCreateFollowRequestFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
@@ -156,6 +196,24 @@ func (p *processor) processCreateFollowRequestFromClientAPI(ctx context.Context,
 	return p.federateFollow(ctx, followRequest, clientMsg.OriginAccount, clientMsg.TargetAccount)
 }
 
+func (p *processor) processCreateFollowImportFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
+	followImport, ok := clientMsg.GTSModel.(*gtsmodel.FollowImport)
+	if !ok {
+		return errors.New("followimport was not parseable as *gtsmodel.FollowImport")
+	}
+
+	return p.accountProcessor.FollowImportProcess(ctx, clientMsg.OriginAccount, followImport)
+}
+
+func (p *processor) processCreateArchiveFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
+	archive, ok := clientMsg.GTSModel.(*gtsmodel.AccountArchive)
+	if !ok {
+		return errors.New("archive was not parseable as *gtsmodel.AccountArchive")
+	}
+
+	return p.accountProcessor.ArchiveProcess(ctx, clientMsg.OriginAccount, archive)
+}
+
 func (p *processor) processCreateFaveFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
 	fave, ok := clientMsg.GTSModel.(*gtsmodel.StatusFave)
 	if !ok {
@@ -169,6 +227,15 @@ func (p *processor) processCreateFaveFromClientAPI(ctx context.Context, clientMs
 	return p.federateFave(ctx, fave, clientMsg.OriginAccount, clientMsg.TargetAccount)
 }
 
+func (p *processor) processCreateReactionFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
+	reaction, ok := clientMsg.GTSModel.(*gtsmodel.StatusReaction)
+	if !ok {
+		return errors.New("reaction was not parseable as *gtsmodel.StatusReaction")
+	}
+
+	return p.federateReaction(ctx, reaction, clientMsg.OriginAccount, clientMsg.TargetAccount)
+}
+
 func (p *processor) processCreateAnnounceFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
 	boostWrapperStatus, ok := clientMsg.GTSModel.(*gtsmodel.Status)
 	if !ok {
@@ -186,6 +253,15 @@ func (p *processor) processCreateAnnounceFromClientAPI(ctx context.Context, clie
 	return p.federateAnnounce(ctx, boostWrapperStatus, clientMsg.OriginAccount, clientMsg.TargetAccount)
 }
 
+func (p *processor) processCreateBlockImportFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
+	blockImport, ok := clientMsg.GTSModel.(*gtsmodel.BlockImport)
+	if !ok {
+		return errors.New("blockimport was not parseable as *gtsmodel.BlockImport")
+	}
+
+	return p.accountProcessor.BlockImportProcess(ctx, clientMsg.OriginAccount, blockImport)
+}
+
 func (p *processor) processCreateBlockFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
 	block, ok := clientMsg.GTSModel.(*gtsmodel.Block)
 	if !ok {
@@ -212,9 +288,59 @@ func (p *processor) processUpdateAccountFromClientAPI(ctx context.Context, clien
 		return errors.New("account was not parseable as *gtsmodel.Account")
 	}
 
+	// verify any links in the profile fields before we send the update out,
+	// but don't let a failure to do so stop the update from being federated
+	if _, err := p.accountProcessor.VerifyFields(ctx, account); err != nil {
+		logrus.Errorf("processUpdateAccountFromClientAPI: error verifying fields of account %s: %s", account.ID, err)
+	}
+
 	return p.federateAccountUpdate(ctx, account, clientMsg.OriginAccount)
 }
 
+func (p *processor) processUpdateStatusMentionsFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
+	pending, ok := clientMsg.GTSModel.(*status.PendingMentions)
+	if !ok {
+		return errors.New("pending mentions were not parseable as *status.PendingMentions")
+	}
+
+	return p.statusProcessor.ResolvePendingMentions(ctx, clientMsg.OriginAccount, pending)
+}
+
+func (p *processor) processUpdateStatusLinkCardFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
+	pending, ok := clientMsg.GTSModel.(*status.PendingLinkCard)
+	if !ok {
+		return errors.New("pending link card was not parseable as *status.PendingLinkCard")
+	}
+
+	// get the status fresh from the db, since it may have changed or been deleted since it was queued
+	s, err := p.db.GetStatusByID(ctx, pending.StatusID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil
+		}
+		return fmt.Errorf("processUpdateStatusLinkCardFromClientAPI: error getting status %s: %s", pending.StatusID, err)
+	}
+
+	// a missing card is nothing to worry about, since the status has already been delivered without it
+	if err := p.statusProcessor.ProcessLinkCard(ctx, s); err != nil {
+		logrus.Debugf("processUpdateStatusLinkCardFromClientAPI: error processing link card: %s", err)
+	}
+
+	return nil
+}
+
+func (p *processor) processUpdateStatusFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
+	status, ok := clientMsg.GTSModel.(*gtsmodel.Status)
+	if !ok {
+		return errors.New("note was not parseable as *gtsmodel.Status")
+	}
+
+	// The status has gained mentions since it was first federated, so send it out again.
+	// Instances which already have the status will ignore the repeated Create, and
+	// instances of the newly mentioned accounts will get the status for the first time.
+	return p.federateStatus(ctx, status)
+}
+
 func (p *processor) processAcceptFollowFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
 	follow, ok := clientMsg.GTSModel.(*gtsmodel.Follow)
 	if !ok {
@@ -250,6 +376,10 @@ func (p *processor) processUndoBlockFromClientAPI(ctx context.Context, clientMsg
 	if !ok {
 		return errors.New("undo was not parseable as *gtsmodel.Block")
 	}
+
+	// there's nothing to wipe from timelines here: statuses were already removed from them when the
+	// block was created, and new ones will be timelined as usual now that the block is gone
+
 	return p.federateUnblock(ctx, block)
 }
 
@@ -261,6 +391,14 @@ func (p *processor) processUndoFaveFromClientAPI(ctx context.Context, clientMsg
 	return p.federateUnfave(ctx, fave, clientMsg.OriginAccount, clientMsg.TargetAccount)
 }
 
+func (p *processor) processUndoReactionFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
+	reaction, ok := clientMsg.GTSModel.(*gtsmodel.StatusReaction)
+	if !ok {
+		return errors.New("undo was not parseable as *gtsmodel.StatusReaction")
+	}
+	return p.federateUnreact(ctx, reaction, clientMsg.OriginAccount, clientMsg.TargetAccount)
+}
+
 func (p *processor) processUndoAnnounceFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
 	boost, ok := clientMsg.GTSModel.(*gtsmodel.Status)
 	if !ok {
@@ -284,6 +422,12 @@ func (p *processor) processDeleteStatusFromClientAPI(ctx context.Context, client
 		statusToDelete.Account = clientMsg.OriginAccount
 	}
 
+	// tombstone the uri, so that activities for this status which are
+	// still in flight can't bring it back once it's been deleted
+	if err := p.putTombstone(ctx, statusToDelete.URI); err != nil {
+		return err
+	}
+
 	// delete all attachments for this status
 	for _, a := range statusToDelete.AttachmentIDs {
 		if err := p.mediaProcessor.Delete(ctx, a); err != nil {
@@ -329,6 +473,15 @@ func (p *processor) processDeleteAccountFromClientAPI(ctx context.Context, clien
 	return p.accountProcessor.Delete(ctx, clientMsg.TargetAccount, origin)
 }
 
+func (p *processor) processDeleteRelationshipsFromClientAPI(ctx context.Context, clientMsg messages.FromClientAPI) error {
+	pending, ok := clientMsg.GTSModel.(*admin.PendingSeverance)
+	if !ok {
+		return errors.New("severance was not parseable as *admin.PendingSeverance")
+	}
+
+	return p.adminProcessor.RelationshipSeveranceProcess(ctx, pending)
+}
+
 // TODO: move all the below functions into federation.Federator
 
 func (p *processor) federateAccountDelete(ctx context.Context, account *gtsmodel.Account) error {
@@ -431,8 +584,8 @@ func (p *processor) federateStatusDelete(ctx context.Context, status *gtsmodel.S
 		status.Account = statusAccount
 	}
 
-	// do nothing if this isn't our status
-	if status.Account.Domain != "" {
+	// do nothing if this isn't our status, or if it's still held for review and so was never federated
+	if status.Account.Domain != "" || !status.HeldAt.IsZero() {
 		return nil
 	}
 
@@ -574,6 +727,45 @@ func (p *processor) federateUnfave(ctx context.Context, fave *gtsmodel.StatusFav
 	return err
 }
 
+func (p *processor) federateUnreact(ctx context.Context, reaction *gtsmodel.StatusReaction, originAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) error {
+	// if both accounts are local there's nothing to do here
+	if originAccount.Domain == "" && targetAccount.Domain == "" {
+		return nil
+	}
+
+	// create the AS reaction
+	asReaction, err := p.tc.ReactionToAS(ctx, reaction)
+	if err != nil {
+		return fmt.Errorf("federateUnreact: error converting reaction to as format: %s", err)
+	}
+
+	targetAccountURI, err := url.Parse(targetAccount.URI)
+	if err != nil {
+		return fmt.Errorf("error parsing uri %s: %s", targetAccount.URI, err)
+	}
+
+	// create an Undo and set the appropriate actor on it
+	undo := streams.NewActivityStreamsUndo()
+	undo.SetActivityStreamsActor(asReaction.GetActivityStreamsActor())
+
+	// Set the reaction as the 'object' property.
+	undoObject := streams.NewActivityStreamsObjectProperty()
+	undoObject.AppendActivityStreamsLike(asReaction)
+	undo.SetActivityStreamsObject(undoObject)
+
+	// Set the To of the undo as the target of the reaction
+	undoTo := streams.NewActivityStreamsToProperty()
+	undoTo.AppendIRI(targetAccountURI)
+	undo.SetActivityStreamsTo(undoTo)
+
+	outboxIRI, err := url.Parse(originAccount.OutboxURI)
+	if err != nil {
+		return fmt.Errorf("federateUnreact: error parsing outboxURI %s: %s", originAccount.OutboxURI, err)
+	}
+	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, undo)
+	return err
+}
+
 func (p *processor) federateUnannounce(ctx context.Context, boost *gtsmodel.Status, originAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) error {
 	if originAccount.Domain != "" {
 		// nothing to do here
@@ -776,6 +968,27 @@ func (p *processor) federateFave(ctx context.Context, fave *gtsmodel.StatusFave,
 	return err
 }
 
+func (p *processor) federateReaction(ctx context.Context, reaction *gtsmodel.StatusReaction, originAccount *gtsmodel.Account, targetAccount *gtsmodel.Account) error {
+	// if both accounts are local there's nothing to do here
+	if originAccount.Domain == "" && targetAccount.Domain == "" {
+		return nil
+	}
+
+	// reactions are sent as likes with the reaction on them, which is what
+	// misskey does, and which pleroma understands as well as its own EmojiReact
+	asReaction, err := p.tc.ReactionToAS(ctx, reaction)
+	if err != nil {
+		return fmt.Errorf("federateReaction: error converting reaction to as format: %s", err)
+	}
+
+	outboxIRI, err := url.Parse(originAccount.OutboxURI)
+	if err != nil {
+		return fmt.Errorf("federateReaction: error parsing outboxURI %s: %s", originAccount.OutboxURI, err)
+	}
+	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, asReaction)
+	return err
+}
+
 func (p *processor) federateAnnounce(ctx context.Context, boostWrapperStatus *gtsmodel.Status, boostingAccount *gtsmodel.Account, boostedAccount *gtsmodel.Account) error {
 	announce, err := p.tc.BoostToAS(ctx, boostWrapperStatus, boostingAccount, boostedAccount)
 	if err != nil {
@@ -900,3 +1113,22 @@ func (p *processor) federateUnblock(ctx context.Context, block *gtsmodel.Block)
 	_, err = p.federator.FederatingActor().Send(ctx, outboxIRI, undo)
 	return err
 }
+
+// putTombstone records that the status or account with the given uri has been deleted.
+func (p *processor) putTombstone(ctx context.Context, uri string) error {
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return fmt.Errorf("putTombstone: error parsing uri %s: %s", uri, err)
+	}
+
+	tombstoneID, err := id.NewULID()
+	if err != nil {
+		return fmt.Errorf("putTombstone: error generating tombstone id: %s", err)
+	}
+
+	return p.db.PutTombstone(ctx, &gtsmodel.Tombstone{
+		ID:     tombstoneID,
+		Domain: parsed.Host,
+		URI:    uri,
+	})
+}
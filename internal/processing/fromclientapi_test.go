@@ -113,6 +113,140 @@ func (suite *FromClientAPITestSuite) TestProcessStreamNewStatus() {
 	suite.Empty(irrelevantStream.Messages)
 }
 
+func (suite *FromClientAPITestSuite) TestProcessStreamNewStatusFollowedTag() {
+	ctx := context.Background()
+
+	// the admin account posts a new public status with a tag: it should end up in the timeline
+	// of local_account_2, which doesn't follow the admin account, but does follow the tag
+	postingAccount := suite.testAccounts["admin_account"]
+	receivingAccount := suite.testAccounts["local_account_2"]
+	tag := suite.testTags["welcome"]
+
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.FollowedTag{
+		ID:        "01G5DBVPKAZBZ0RNKPV3MYS0PP",
+		AccountID: receivingAccount.ID,
+		TagID:     tag.ID,
+	}))
+
+	wssStream, errWithCode := suite.processor.OpenStreamForAccount(ctx, receivingAccount, stream.TimelineHome)
+	suite.NoError(errWithCode)
+
+	newStatus := &gtsmodel.Status{
+		ID:                       "01G5DBW6W4ZA1M0FE4F5YBBHE6",
+		URI:                      "http://localhost:8080/users/admin/statuses/01G5DBW6W4ZA1M0FE4F5YBBHE6",
+		URL:                      "http://localhost:8080/@admin/statuses/01G5DBW6W4ZA1M0FE4F5YBBHE6",
+		Content:                  "#welcome to everyone who follows the tag",
+		TagIDs:                   []string{tag.ID},
+		CreatedAt:                testrig.TimeMustParse("2022-06-09T12:00:00Z"),
+		UpdatedAt:                testrig.TimeMustParse("2022-06-09T12:00:00Z"),
+		Local:                    true,
+		AccountURI:               postingAccount.URI,
+		AccountID:                postingAccount.ID,
+		Visibility:               gtsmodel.VisibilityPublic,
+		CreatedWithApplicationID: "01F8MGXQRHYF5QPMTMXP78QC2F",
+		Federated:                false,
+		Boostable:                true,
+		Replyable:                true,
+		Likeable:                 true,
+		ActivityStreamsType:      ap.ObjectNote,
+	}
+	suite.NoError(suite.db.PutStatus(ctx, newStatus))
+
+	suite.NoError(suite.processor.ProcessFromClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       newStatus,
+		OriginAccount:  postingAccount,
+	}))
+
+	msg := <-wssStream.Messages
+	suite.Equal(stream.EventTypeUpdate, msg.Event)
+	statusStreamed := &model.Status{}
+	suite.NoError(json.Unmarshal([]byte(msg.Payload), statusStreamed))
+	suite.Equal(newStatus.ID, statusStreamed.ID)
+	suite.Empty(wssStream.Messages)
+}
+
+func (suite *FromClientAPITestSuite) TestProcessCreateBlockWipesTimelines() {
+	ctx := context.Background()
+
+	blockingAccount := suite.testAccounts["local_account_1"]
+	blockedAccount := suite.testAccounts["local_account_2"]
+
+	// zork and turtle follow each other, so each of them should
+	// have statuses of the other one in their cached home timeline
+	blockingTimeline, errWithCode := suite.processor.HomeTimelineGet(ctx, suite.testAutheds["local_account_1"], "", "", "", 20, false, nil)
+	suite.NoError(errWithCode)
+	suite.True(timelineHasStatusesBy(blockingTimeline.Statuses, blockedAccount.ID))
+
+	blockedTimeline, errWithCode := suite.processor.HomeTimelineGet(ctx, suite.testAutheds["local_account_2"], "", "", "", 20, false, nil)
+	suite.NoError(errWithCode)
+	suite.True(timelineHasStatusesBy(blockedTimeline.Statuses, blockingAccount.ID))
+
+	// zork blocks turtle
+	block := &gtsmodel.Block{
+		ID:              "01G5EN2YHTXF1ZJ4Q3V0DXB6TA",
+		URI:             "http://localhost:8080/users/the_mighty_zork/blocks/01G5EN2YHTXF1ZJ4Q3V0DXB6TA",
+		AccountID:       blockingAccount.ID,
+		Account:         blockingAccount,
+		TargetAccountID: blockedAccount.ID,
+		TargetAccount:   blockedAccount,
+	}
+	suite.NoError(suite.db.Put(ctx, block))
+
+	suite.NoError(suite.processor.ProcessFromClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ActivityBlock,
+		APActivityType: ap.ActivityCreate,
+		GTSModel:       block,
+		OriginAccount:  blockingAccount,
+		TargetAccount:  blockedAccount,
+	}))
+
+	// the statuses should be gone from both cached timelines now
+	blockingTimeline, errWithCode = suite.processor.HomeTimelineGet(ctx, suite.testAutheds["local_account_1"], "", "", "", 20, false, nil)
+	suite.NoError(errWithCode)
+	suite.False(timelineHasStatusesBy(blockingTimeline.Statuses, blockedAccount.ID))
+
+	blockedTimeline, errWithCode = suite.processor.HomeTimelineGet(ctx, suite.testAutheds["local_account_2"], "", "", "", 20, false, nil)
+	suite.NoError(errWithCode)
+	suite.False(timelineHasStatusesBy(blockedTimeline.Statuses, blockingAccount.ID))
+}
+
+func (suite *FromClientAPITestSuite) TestProcessDeleteStatusPutsTombstone() {
+	ctx := context.Background()
+
+	deletingAccount := suite.testAccounts["local_account_1"]
+	statusToDelete := suite.testStatuses["local_account_1_status_1"]
+
+	tombstoned, err := suite.db.TombstoneExistsWithURI(ctx, statusToDelete.URI)
+	suite.NoError(err)
+	suite.False(tombstoned)
+
+	suite.NoError(suite.db.DeleteStatusByID(ctx, statusToDelete.ID))
+	suite.NoError(suite.processor.ProcessFromClientAPI(ctx, messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityDelete,
+		GTSModel:       statusToDelete,
+		OriginAccount:  deletingAccount,
+		TargetAccount:  deletingAccount,
+	}))
+
+	// the status shouldn't be able to come back now
+	tombstoned, err = suite.db.TombstoneExistsWithURI(ctx, statusToDelete.URI)
+	suite.NoError(err)
+	suite.True(tombstoned)
+}
+
+// timelineHasStatusesBy returns true if any of the given statuses, or any status boosted by them, was authored by the given account.
+func timelineHasStatusesBy(statuses []*model.Status, accountID string) bool {
+	for _, s := range statuses {
+		if s.GetAccountID() == accountID || s.GetBoostOfAccountID() == accountID {
+			return true
+		}
+	}
+	return false
+}
+
 func TestFromClientAPITestSuite(t *testing.T) {
 	suite.Run(t, &FromClientAPITestSuite{})
 }
@@ -24,9 +24,12 @@ import (
 	"strings"
 	"sync"
 
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
 )
 
@@ -355,6 +358,30 @@ func (p *processor) timelineStatus(ctx context.Context, status *gtsmodel.Status)
 		})
 	}
 
+	// if the status is public, add fake entries for local accounts which follow any of its tags, unless they
+	// also follow the poster; whether they can actually see the status is checked when it's timelined, as usual
+	if status.Visibility == gtsmodel.VisibilityPublic && status.BoostOfID == "" && len(status.TagIDs) != 0 {
+		tagFollowerIDs, err := p.db.GetTagFollowerIDs(ctx, status.TagIDs)
+		if err != nil {
+			return fmt.Errorf("timelineStatus: error getting followers of tags of status %s: %s", status.ID, err)
+		}
+
+		alreadyTimelining := make(map[string]bool, len(follows))
+		for _, f := range follows {
+			alreadyTimelining[f.AccountID] = true
+		}
+
+		for _, accountID := range tagFollowerIDs {
+			if alreadyTimelining[accountID] {
+				continue
+			}
+			alreadyTimelining[accountID] = true
+			follows = append(follows, &gtsmodel.Follow{
+				AccountID: accountID,
+			})
+		}
+	}
+
 	wg := sync.WaitGroup{}
 	wg.Add(len(follows))
 	errors := make(chan error, len(follows))
@@ -434,6 +461,16 @@ func (p *processor) timelineStatusForAccount(ctx context.Context, status *gtsmod
 
 // deleteStatusFromTimelines completely removes the given status from all timelines.
 // It will also stream deletion of the status to all open streams.
+// queueLinkCard queues generating a preview card for the first link in the given status as a job of its own,
+// so that a slow or unreachable link doesn't hold up delivery of the status.
+func (p *processor) queueLinkCard(statusID string) {
+	p.clientWorker.Queue(messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       &status.PendingLinkCard{StatusID: statusID},
+	})
+}
+
 func (p *processor) deleteStatusFromTimelines(ctx context.Context, status *gtsmodel.Status) error {
 	if err := p.statusTimelines.WipeItemFromAllTimelines(ctx, status.ID); err != nil {
 		return err
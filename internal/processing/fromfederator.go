@@ -29,14 +29,16 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 )
 
 // ProcessFromFederator reads the APActivityType and APObjectType of an incoming message from the federator,
 // and directs the message into the appropriate side effect handler function, or simply does nothing if there's
 // no handler function defined for the combination of Activity and Object.
 func (p *processor) ProcessFromFederator(ctx context.Context, federatorMsg messages.FromFederator) error {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":           "processFromFederator",
 		"APActivityType": federatorMsg.APActivityType,
 		"APObjectType":   federatorMsg.APObjectType,
@@ -53,6 +55,9 @@ func (p *processor) ProcessFromFederator(ctx context.Context, federatorMsg messa
 		case ap.ActivityLike:
 			// CREATE A FAVE
 			return p.processCreateFaveFromFederator(ctx, federatorMsg)
+		case ap.ActivityEmojiReact:
+			// CREATE AN EMOJI REACTION
+			return p.processCreateReactionFromFederator(ctx, federatorMsg)
 		case ap.ActivityFollow:
 			// CREATE A FOLLOW REQUEST
 			return p.processCreateFollowRequestFromFederator(ctx, federatorMsg)
@@ -139,6 +144,13 @@ func (p *processor) processCreateStatusFromFederator(ctx context.Context, federa
 		status.Account = a
 	}
 
+	// check the status against the instance's moderation rules, which may drop it entirely
+	if dropped, err := p.moderateRemoteStatus(ctx, status); err != nil {
+		return err
+	} else if dropped {
+		return nil
+	}
+
 	if err := p.timelineStatus(ctx, status); err != nil {
 		return err
 	}
@@ -147,9 +159,40 @@ func (p *processor) processCreateStatusFromFederator(ctx context.Context, federa
 		return err
 	}
 
+	p.queueLinkCard(status.ID)
 	return nil
 }
 
+// moderateRemoteStatus checks the text and content warning of the given remote status against the instance's
+// moderation rules. A status matching a 'drop' rule is deleted, and true is returned; a status matching a 'flag'
+// rule is marked with the rule, so that it shows up for admins to review, but is otherwise delivered as usual.
+func (p *processor) moderateRemoteStatus(ctx context.Context, status *gtsmodel.Status) (bool, error) {
+	rule, err := p.moderationRules.Match(ctx, text.RemoveHTML(status.Content), status.ContentWarning)
+	if err != nil {
+		return false, fmt.Errorf("moderateRemoteStatus: error matching moderation rules: %s", err)
+	}
+	if rule == nil {
+		return false, nil
+	}
+
+	if rule.RemoteAction == gtsmodel.ModerationRuleRemoteDrop {
+		logrus.Debugf("moderateRemoteStatus: dropping status %s which matches moderation rule %s", status.URI, rule.ID)
+		if err := p.processDeleteStatusFromFederator(ctx, messages.FromFederator{GTSModel: status}); err != nil {
+			return false, err
+		}
+		if err := p.db.DeleteStatusByID(ctx, status.ID); err != nil {
+			return false, fmt.Errorf("moderateRemoteStatus: error deleting status %s: %s", status.ID, err)
+		}
+		return true, nil
+	}
+
+	status.ModerationRuleID = rule.ID
+	if err := p.db.UpdateStatus(ctx, status); err != nil {
+		return false, fmt.Errorf("moderateRemoteStatus: error flagging status %s: %s", status.ID, err)
+	}
+	return false, nil
+}
+
 // processCreateFaveFromFederator handles Activity Create and Object Like
 func (p *processor) processCreateFaveFromFederator(ctx context.Context, federatorMsg messages.FromFederator) error {
 	incomingFave, ok := federatorMsg.GTSModel.(*gtsmodel.StatusFave)
@@ -188,6 +231,61 @@ func (p *processor) processCreateFaveFromFederator(ctx context.Context, federato
 	return nil
 }
 
+// processCreateReactionFromFederator handles Activity Create and Object EmojiReact
+func (p *processor) processCreateReactionFromFederator(ctx context.Context, federatorMsg messages.FromFederator) error {
+	incomingReaction, ok := federatorMsg.GTSModel.(*gtsmodel.StatusReaction)
+	if !ok {
+		return errors.New("reaction was not parseable as *gtsmodel.StatusReaction")
+	}
+
+	if incomingReaction.EmojiID != "" || incomingReaction.Emoji == nil {
+		// either a unicode emoji, or a custom emoji we already have
+		return nil
+	}
+
+	// this reaction uses a custom emoji we haven't seen before, so fetch it
+	emoji, err := p.fetchReactionEmoji(ctx, federatorMsg.ReceivingAccount.Username, incomingReaction.Emoji)
+	if err != nil {
+		return fmt.Errorf("processCreateReactionFromFederator: error fetching emoji %s: %s", incomingReaction.Emoji.URI, err)
+	}
+
+	incomingReaction.EmojiID = emoji.ID
+	incomingReaction.Emoji = emoji
+	return p.db.UpdateWhere(ctx, []db.Where{{Key: "id", Value: incomingReaction.ID}}, "emoji_id", emoji.ID, &gtsmodel.StatusReaction{})
+}
+
+// fetchReactionEmoji dereferences and stores the given remote emoji, which has been extracted from a reaction.
+func (p *processor) fetchReactionEmoji(ctx context.Context, requestingUsername string, remoteEmoji *gtsmodel.Emoji) (*gtsmodel.Emoji, error) {
+	emojiID, err := id.NewULID()
+	if err != nil {
+		return nil, err
+	}
+
+	// remote emojis are only stored so that we can show reactions
+	// with them, so they shouldn't be offered to our own users
+	visibleInPicker := false
+	processingEmoji, err := p.federator.GetRemoteEmoji(ctx, requestingUsername, remoteEmoji.ImageRemoteURL, remoteEmoji.Shortcode, emojiID, remoteEmoji.URI, &media.AdditionalEmojiInfo{
+		Domain:          &remoteEmoji.Domain,
+		ImageRemoteURL:  &remoteEmoji.ImageRemoteURL,
+		VisibleInPicker: &visibleInPicker,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	emoji, err := processingEmoji.LoadEmoji(ctx)
+	if err != nil {
+		// another reaction might have fetched the same emoji in the meantime
+		known := &gtsmodel.Emoji{}
+		if dbErr := p.db.GetWhere(ctx, []db.Where{{Key: "uri", Value: remoteEmoji.URI}}, known); dbErr == nil {
+			return known, nil
+		}
+		return nil, err
+	}
+
+	return emoji, nil
+}
+
 // processCreateFollowRequestFromFederator handles Activity Create and Object Follow
 func (p *processor) processCreateFollowRequestFromFederator(ctx context.Context, federatorMsg messages.FromFederator) error {
 	followRequest, ok := federatorMsg.GTSModel.(*gtsmodel.FollowRequest)
@@ -228,11 +326,18 @@ func (p *processor) processCreateFollowRequestFromFederator(ctx context.Context,
 	}
 
 	if followRequest.TargetAccount.Locked {
-		// if the account is locked just notify the follow request and nothing else
-		return p.notifyFollowRequest(ctx, followRequest)
+		// if the account is locked and none of its auto-accept rules match, just notify the follow request and nothing else
+		autoAccept, err := p.accountProcessor.AutoAcceptsFollowRequest(ctx, followRequest.TargetAccount, followRequest.Account)
+		if err != nil {
+			return err
+		}
+		if !autoAccept {
+			return p.notifyFollowRequest(ctx, followRequest)
+		}
 	}
 
-	// if the target account isn't locked, we should already accept the follow and notify about the new follower instead
+	// if the target account isn't locked, or the follow request is auto-accepted,
+	// we should already accept the follow and notify about the new follower instead
 	follow, err := p.db.AcceptFollowRequest(ctx, followRequest.AccountID, followRequest.TargetAccountID)
 	if err != nil {
 		return err
@@ -351,10 +456,11 @@ func (p *processor) processDeleteStatusFromFederator(ctx context.Context, federa
 		return errors.New("note was not parseable as *gtsmodel.Status")
 	}
 
-	// delete all attachments for this status
+	// delete all attachments for this status; remote media might
+	// not be cached in storage, so don't stop at the first error
 	for _, a := range statusToDelete.AttachmentIDs {
 		if err := p.mediaProcessor.Delete(ctx, a); err != nil {
-			return err
+			logrus.Errorf("processDeleteStatusFromFederator: error deleting attachment %s of status %s: %s", a, statusToDelete.ID, err)
 		}
 	}
 
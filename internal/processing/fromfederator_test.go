@@ -354,7 +354,7 @@ func (suite *FromFederatorTestSuite) TestProcessAccountDelete() {
 	suite.False(zorkFollowsSatan)
 
 	// no statuses from foss satan should be left in the database
-	dbStatuses, err := suite.db.GetAccountStatuses(ctx, deletedAccount.ID, 0, false, false, "", "", false, false, false)
+	dbStatuses, err := suite.db.GetAccountStatuses(ctx, deletedAccount.ID, 0, false, false, "", "", false, false, false, false)
 	suite.ErrorIs(err, db.ErrNoEntries)
 	suite.Empty(dbStatuses)
 
@@ -502,6 +502,72 @@ func (suite *FromFederatorTestSuite) TestProcessFollowRequestUnlocked() {
 	suite.Equal("Accept", accept.Type)
 }
 
+func (suite *FromFederatorTestSuite) TestProcessFollowRequestLockedAutoAccepted() {
+	ctx := context.Background()
+
+	originAccount := suite.testAccounts["remote_account_1"]
+
+	// target is a locked account, which auto-accepts follow requests from satan's domain
+	targetAccount := &gtsmodel.Account{}
+	*targetAccount = *suite.testAccounts["local_account_2"]
+	targetAccount.AutoAcceptDomains = []string{"fossbros-anonymous.io"}
+	_, err := suite.db.UpdateAccount(ctx, targetAccount)
+	suite.NoError(err)
+
+	wssStream, errWithCode := suite.processor.OpenStreamForAccount(context.Background(), targetAccount, stream.TimelineHome)
+	suite.NoError(errWithCode)
+
+	// put the follow request in the database as though it had passed through the federating db already
+	satanFollowRequestTurtle := &gtsmodel.FollowRequest{
+		ID:              "01FGRYAVAWWPP926J175QGM0WV",
+		CreatedAt:       time.Now(),
+		UpdatedAt:       time.Now(),
+		AccountID:       originAccount.ID,
+		Account:         originAccount,
+		TargetAccountID: targetAccount.ID,
+		TargetAccount:   targetAccount,
+		ShowReblogs:     true,
+		URI:             fmt.Sprintf("%s/follows/01FGRYAVAWWPP926J175QGM0WV", originAccount.URI),
+		Notify:          false,
+	}
+
+	err = suite.db.Put(ctx, satanFollowRequestTurtle)
+	suite.NoError(err)
+
+	err = suite.processor.ProcessFromFederator(ctx, messages.FromFederator{
+		APObjectType:     ap.ActivityFollow,
+		APActivityType:   ap.ActivityCreate,
+		GTSModel:         satanFollowRequestTurtle,
+		ReceivingAccount: targetAccount,
+	})
+	suite.NoError(err)
+
+	// a follow notification should be streamed, not a follow request one
+	msg := <-wssStream.Messages
+	suite.Equal(stream.EventTypeNotification, msg.Event)
+	notif := &model.Notification{}
+	err = json.Unmarshal([]byte(msg.Payload), notif)
+	suite.NoError(err)
+	suite.Equal("follow", notif.Type)
+	suite.Equal(originAccount.ID, notif.Account.ID)
+
+	// satan should now follow turtle
+	follows, err := suite.db.IsFollowing(ctx, originAccount, targetAccount)
+	suite.NoError(err)
+	suite.True(follows)
+
+	// an accept message should be sent to satan's inbox
+	suite.Len(suite.sentHTTPRequests, 1)
+	accept := &struct {
+		Actor string `json:"actor"`
+		Type  string `json:"type"`
+	}{}
+	err = json.Unmarshal(suite.sentHTTPRequests[originAccount.InboxURI], accept)
+	suite.NoError(err)
+	suite.Equal(targetAccount.URI, accept.Actor)
+	suite.Equal("Accept", accept.Type)
+}
+
 // TestCreateStatusFromIRI checks if a forwarded status can be dereferenced by the processor.
 func (suite *FromFederatorTestSuite) TestCreateStatusFromIRI() {
 	ctx := context.Background()
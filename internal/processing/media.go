@@ -27,6 +27,9 @@ import (
 )
 
 func (p *processor) MediaCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AttachmentRequest) (*apimodel.Attachment, error) {
+	if errWithCode := p.checkReadOnly(ctx); errWithCode != nil {
+		return nil, errWithCode
+	}
 	return p.mediaProcessor.Create(ctx, authed.Account, form)
 }
 
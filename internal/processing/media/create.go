@@ -23,7 +23,9 @@ import (
 	"fmt"
 	"io"
 
+	"github.com/spf13/viper"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/media"
 )
@@ -39,19 +41,30 @@ func (p *processor) Create(ctx context.Context, account *gtsmodel.Account, form
 		return nil, fmt.Errorf("could not parse focus value %s: %s", form.Focus, err)
 	}
 
-	// process the media attachment and load it immediately
-	media, err := p.mediaManager.ProcessMedia(ctx, data, nil, account.ID, &media.AdditionalMediaInfo{
+	ai := &media.AdditionalMediaInfo{
 		Description: &form.Description,
 		FocusX:      &focusX,
 		FocusY:      &focusY,
-	})
-	if err != nil {
-		return nil, err
 	}
 
-	attachment, err := media.LoadAttachment(ctx)
-	if err != nil {
-		return nil, err
+	var attachment *gtsmodel.MediaAttachment
+	if form.File.Size > int64(viper.GetInt(config.Keys.MediaSyncProcessingMaxSize)) {
+		// big uploads are stored now but processed in the background, so we just return a placeholder
+		attachment, err = p.mediaManager.ProcessMediaAsync(ctx, data, nil, account.ID, ai)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		// process the media attachment and load it immediately
+		processingMedia, err := p.mediaManager.ProcessMedia(ctx, data, nil, account.ID, ai)
+		if err != nil {
+			return nil, err
+		}
+
+		attachment, err = processingMedia.LoadAttachment(ctx)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// prepare the frontend representation now -- if there are any errors here at least we can bail without
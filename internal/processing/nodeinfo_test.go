@@ -0,0 +1,76 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type NodeInfoTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *NodeInfoTestSuite) TestNodeInfoUsers() {
+	ctx := context.Background()
+
+	totalUsers, err := suite.db.CountInstanceUsers(ctx, viper.GetString(config.Keys.Host))
+	suite.NoError(err)
+	activeMonth, err := suite.db.CountActiveUsers(ctx, time.Now().Add(-30*24*time.Hour))
+	suite.NoError(err)
+	activeHalfyear, err := suite.db.CountActiveUsers(ctx, time.Now().Add(-180*24*time.Hour))
+	suite.NoError(err)
+
+	nodeInfo, errWithCode := suite.processor.GetNodeInfo(ctx, nil)
+	suite.NoError(errWithCode)
+	suite.Equal(totalUsers, nodeInfo.Usage.Users.Total)
+	suite.Equal(activeMonth, nodeInfo.Usage.Users.ActiveMonth)
+	suite.Equal(activeHalfyear, nodeInfo.Usage.Users.ActiveHalfyear)
+
+	// a user who has never logged in before logs in
+	user := suite.testUsers["unconfirmed_account"]
+	suite.NoError(suite.db.UpdateWhere(ctx, []db.Where{{Key: "id", Value: user.ID}}, "last_login", time.Now(), &gtsmodel.User{}))
+
+	// the counts are cached, so they don't change straight away
+	nodeInfo, errWithCode = suite.processor.GetNodeInfo(ctx, nil)
+	suite.NoError(errWithCode)
+	suite.Equal(activeMonth, nodeInfo.Usage.Users.ActiveMonth)
+	suite.Equal(activeHalfyear, nodeInfo.Usage.Users.ActiveHalfyear)
+
+	// without the cache, the new login is counted
+	viper.Set(config.Keys.InstanceActiveUsersCacheDuration, 0)
+	defer viper.Set(config.Keys.InstanceActiveUsersCacheDuration, testrig.TestDefaults.InstanceActiveUsersCacheDuration)
+
+	nodeInfo, errWithCode = suite.processor.GetNodeInfo(ctx, nil)
+	suite.NoError(errWithCode)
+	suite.Equal(activeMonth+1, nodeInfo.Usage.Users.ActiveMonth)
+	suite.Equal(activeHalfyear+1, nodeInfo.Usage.Users.ActiveHalfyear)
+}
+
+func TestNodeInfoTestSuite(t *testing.T) {
+	suite.Run(t, &NodeInfoTestSuite{})
+}
@@ -0,0 +1,108 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+
+	"github.com/spf13/viper"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+const (
+	oEmbedDefaultWidth  = 400
+	oEmbedDefaultHeight = 300
+	oEmbedCacheAge      = 86400 // 1 day
+)
+
+func (p *processor) OEmbedGet(ctx context.Context, form *apimodel.OEmbedRequest) (*apimodel.OEmbed, gtserror.WithCode) {
+	if form.Format != "" && form.Format != "json" {
+		err := fmt.Errorf("format %s not supported", form.Format)
+		return nil, gtserror.NewErrorNotImplemented(err, err.Error())
+	}
+
+	status, err := p.db.GetStatusByURI(ctx, form.URL)
+	if err == db.ErrNoEntries {
+		// the url of the status web page is more likely to be embedded than its activitypub uri
+		status, err = p.db.GetStatusByURL(ctx, form.URL)
+	}
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("OEmbedGet: db error getting status %s: %s", form.URL, err))
+	}
+
+	// only embed public statuses from this instance, since we can't vouch for anything else
+	if !status.Local || status.Visibility != gtsmodel.VisibilityPublic {
+		return nil, gtserror.NewErrorNotFound(errors.New("status is not a local public status"))
+	}
+
+	if status.Account == nil {
+		status.Account, err = p.db.GetAccountByID(ctx, status.AccountID)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("OEmbedGet: db error getting account %s: %s", status.AccountID, err))
+		}
+	}
+
+	host := viper.GetString(config.Keys.Host)
+	instance, errWithCode := p.InstanceGet(ctx, host)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	width := oEmbedDefaultWidth
+	if form.MaxWidth > 0 && form.MaxWidth < width {
+		width = form.MaxWidth
+	}
+	height := oEmbedDefaultHeight
+	if form.MaxHeight > 0 && form.MaxHeight < height {
+		height = form.MaxHeight
+	}
+
+	authorName := status.Account.DisplayName
+	if authorName == "" {
+		authorName = status.Account.Username
+	}
+
+	embedHTML := fmt.Sprintf(
+		`<iframe src="%s" class="gotosocial-embed" style="max-width: 100%%; border: 0" width="%d" height="%d" allowfullscreen="allowfullscreen"></iframe>`,
+		html.EscapeString(status.URL+"/embed"), width, height,
+	)
+
+	return &apimodel.OEmbed{
+		Type:         "rich",
+		Version:      "1.0",
+		AuthorName:   authorName,
+		AuthorURL:    status.Account.URL,
+		ProviderName: instance.Title,
+		ProviderURL:  viper.GetString(config.Keys.Protocol) + "://" + host + "/",
+		CacheAge:     oEmbedCacheAge,
+		HTML:         embedHTML,
+		Width:        width,
+		Height:       height,
+	}, nil
+}
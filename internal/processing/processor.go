@@ -24,7 +24,9 @@ import (
 	"net/url"
 
 	"codeberg.org/gruf/go-store/kv"
+	"github.com/ReneKroon/ttlcache"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/automod"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/email"
 	"github.com/superseriousbusiness/gotosocial/internal/federation"
@@ -42,6 +44,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/processing/user"
 	"github.com/superseriousbusiness/gotosocial/internal/stream"
 	"github.com/superseriousbusiness/gotosocial/internal/timeline"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
 	"github.com/superseriousbusiness/gotosocial/internal/typeutils"
 	"github.com/superseriousbusiness/gotosocial/internal/visibility"
 	"github.com/superseriousbusiness/gotosocial/internal/worker"
@@ -72,7 +75,7 @@ type Processor interface {
 	*/
 
 	// AccountCreate processes the given form for creating a new account, returning an oauth token for that account if successful.
-	AccountCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountCreateRequest) (*apimodel.Token, error)
+	AccountCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountCreateRequest) (*apimodel.Token, gtserror.WithCode)
 	// AccountDeleteLocal processes the delete of a LOCAL account using the given form.
 	AccountDeleteLocal(ctx context.Context, authed *oauth.Auth, form *apimodel.AccountDeleteRequest) gtserror.WithCode
 	// AccountGet processes the given request for account information.
@@ -80,14 +83,18 @@ type Processor interface {
 	// AccountGet processes the given request for account information.
 	AccountGetLocalByUsername(ctx context.Context, authed *oauth.Auth, username string) (*apimodel.Account, gtserror.WithCode)
 	// AccountUpdate processes the update of an account with the given form
-	AccountUpdate(ctx context.Context, authed *oauth.Auth, form *apimodel.UpdateCredentialsRequest) (*apimodel.Account, error)
+	AccountUpdate(ctx context.Context, authed *oauth.Auth, form *apimodel.UpdateCredentialsRequest) (*apimodel.Account, gtserror.WithCode)
 	// AccountStatusesGet fetches a number of statuses (in time descending order) from the given account, filtered by visibility for
 	// the account given in authed.
 	AccountStatusesGet(ctx context.Context, authed *oauth.Auth, targetAccountID string, limit int, excludeReplies bool, excludeReblogs bool, maxID string, minID string, pinned bool, mediaOnly bool, publicOnly bool) ([]apimodel.Status, gtserror.WithCode)
+	// AccountStatusesFeedGet renders an RSS feed, or an Atom feed if atom is true, of the recent public statuses of the local account with the given username.
+	AccountStatusesFeedGet(ctx context.Context, username string, atom bool) ([]byte, gtserror.WithCode)
 	// AccountFollowersGet fetches a list of the target account's followers.
 	AccountFollowersGet(ctx context.Context, authed *oauth.Auth, targetAccountID string) ([]apimodel.Account, gtserror.WithCode)
 	// AccountFollowingGet fetches a list of the accounts that target account is following.
 	AccountFollowingGet(ctx context.Context, authed *oauth.Auth, targetAccountID string) ([]apimodel.Account, gtserror.WithCode)
+	// AccountFamiliarFollowersGet fetches the followers of the target account that the authed account also follows.
+	AccountFamiliarFollowersGet(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.FamiliarFollowers, gtserror.WithCode)
 	// AccountRelationshipGet returns a relationship model describing the relationship of the targetAccount to the Authed account.
 	AccountRelationshipGet(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
 	// AccountFollowCreate handles a follow request to an account, either remote or local.
@@ -98,11 +105,38 @@ type Processor interface {
 	AccountBlockCreate(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
 	// AccountBlockRemove handles the removal of a block from authed account to target account, either remote or local.
 	AccountBlockRemove(ctx context.Context, authed *oauth.Auth, targetAccountID string) (*apimodel.Relationship, gtserror.WithCode)
+	// AccountAvatarDelete removes the avatar of the authed account, and federates the change.
+	AccountAvatarDelete(ctx context.Context, authed *oauth.Auth) (*apimodel.Account, gtserror.WithCode)
+	// AccountHeaderDelete removes the header of the authed account, and federates the change.
+	AccountHeaderDelete(ctx context.Context, authed *oauth.Auth) (*apimodel.Account, gtserror.WithCode)
+	// AccountRotateKeys replaces the keypair of the authed account with a new one, and federates the new public key.
+	AccountRotateKeys(ctx context.Context, authed *oauth.Auth) (*apimodel.Account, gtserror.WithCode)
+	// AccountVerifyAllFields re-verifies the links in the profile fields of all local accounts, returning the number of accounts that changed.
+	AccountVerifyAllFields(ctx context.Context) (int, error)
+	// AccountArchiveCreate requests a new archive of the authed account's data, which will be generated in the background.
+	AccountArchiveCreate(ctx context.Context, authed *oauth.Auth) (*apimodel.AccountArchive, gtserror.WithCode)
+	// AccountArchiveGet returns the most recently requested archive of the authed account's data.
+	AccountArchiveGet(ctx context.Context, authed *oauth.Auth) (*apimodel.AccountArchive, gtserror.WithCode)
+	// AccountArchiveDownload returns the content of the given archive, if the signed download link is valid.
+	AccountArchiveDownload(ctx context.Context, archiveID string, expires string, signature string) (*apimodel.Content, gtserror.WithCode)
+	// AccountFollowImportCreate imports a csv of accounts for the authed account to follow, which will be followed in the background.
+	AccountFollowImportCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.FollowImportCreateRequest) (*apimodel.FollowImport, gtserror.WithCode)
+	// AccountFollowImportGet returns the most recent follow import of the authed account.
+	AccountFollowImportGet(ctx context.Context, authed *oauth.Auth) (*apimodel.FollowImport, gtserror.WithCode)
+	// AccountBlockImportCreate imports a csv of accounts for the authed account to block, which will be blocked in the background.
+	AccountBlockImportCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.BlockImportCreateRequest) (*apimodel.BlockImport, gtserror.WithCode)
+	// AccountBlockImportGet returns the most recent block import of the authed account.
+	AccountBlockImportGet(ctx context.Context, authed *oauth.Auth) (*apimodel.BlockImport, gtserror.WithCode)
+	// AccountBlocksExport returns a csv of the accounts blocked by the authed account.
+	AccountBlocksExport(ctx context.Context, authed *oauth.Auth) (*apimodel.Content, gtserror.WithCode)
 
 	// AdminAccountAction handles the creation/execution of an action on an account.
 	AdminAccountAction(ctx context.Context, authed *oauth.Auth, form *apimodel.AdminAccountActionRequest) gtserror.WithCode
 	// AdminEmojiCreate handles the creation of a new instance emoji by an admin, using the given form.
-	AdminEmojiCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.EmojiCreateRequest) (*apimodel.Emoji, gtserror.WithCode)
+	AdminEmojiCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.EmojiCreateRequest) (*apimodel.AdminEmoji, gtserror.WithCode)
+	// AdminEmojiUpdate updates the local instance emoji with the given id, using the given form. If a new image
+	// is given, it's processed and stored in place of the old one, and the emoji keeps its id.
+	AdminEmojiUpdate(ctx context.Context, authed *oauth.Auth, id string, form *apimodel.EmojiUpdateRequest) (*apimodel.AdminEmoji, gtserror.WithCode)
 	// AdminDomainBlockCreate handles the creation of a new domain block by an admin, using the given form.
 	AdminDomainBlockCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.DomainBlockCreateRequest) (*apimodel.DomainBlock, gtserror.WithCode)
 	// AdminDomainBlocksImport handles the import of multiple domain blocks by an admin, using the given form.
@@ -113,8 +147,43 @@ type Processor interface {
 	AdminDomainBlockGet(ctx context.Context, authed *oauth.Auth, id string, export bool) (*apimodel.DomainBlock, gtserror.WithCode)
 	// AdminDomainBlockDelete deletes one domain block, specified by ID, returning the deleted domain block.
 	AdminDomainBlockDelete(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.DomainBlock, gtserror.WithCode)
+	// AdminDomainPauseCreate pauses federation with a domain, without any of the side effects of a domain block.
+	AdminDomainPauseCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.DomainPauseCreateRequest) (*apimodel.DomainPause, gtserror.WithCode)
+	// AdminDomainPausesGet returns a list of domains that federation is currently paused with.
+	AdminDomainPausesGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.DomainPause, gtserror.WithCode)
+	// AdminDomainPauseDelete deletes one domain pause, specified by ID, returning the deleted domain pause.
+	AdminDomainPauseDelete(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.DomainPause, gtserror.WithCode)
+	// AdminDomainSilenceCreate silences a domain, keeping statuses from its accounts out of public timelines and trends.
+	AdminDomainSilenceCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.DomainSilenceCreateRequest) (*apimodel.DomainSilence, gtserror.WithCode)
+	// AdminDomainSilencesGet returns a list of domains that are currently silenced.
+	AdminDomainSilencesGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.DomainSilence, gtserror.WithCode)
+	// AdminDomainSilenceDelete deletes one domain silence, specified by ID, returning the deleted domain silence.
+	AdminDomainSilenceDelete(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.DomainSilence, gtserror.WithCode)
 	// AdminMediaRemotePrune triggers a prune of remote media according to the given number of mediaRemoteCacheDays
 	AdminMediaRemotePrune(ctx context.Context, mediaRemoteCacheDays int) gtserror.WithCode
+	// AdminMediaQueueGet returns the current depth and size of the media processing queue.
+	AdminMediaQueueGet(ctx context.Context, authed *oauth.Auth) *apimodel.AdminMediaQueue
+	// AdminRelationshipSeveranceCreate starts removing all follows, follow requests, and blocks involving the
+	// accounts or domain given in the form, returning the severance so that its progress can be checked.
+	AdminRelationshipSeveranceCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AdminRelationshipSeveranceRequest) (*apimodel.AdminRelationshipSeverance, gtserror.WithCode)
+	// AdminRelationshipSeveranceGet returns the current progress of one relationship severance, specified by ID.
+	AdminRelationshipSeveranceGet(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminRelationshipSeverance, gtserror.WithCode)
+	// AdminRegistrationsSet opens or closes registrations at runtime, overriding the config until it is set again.
+	AdminRegistrationsSet(ctx context.Context, authed *oauth.Auth, form *apimodel.AdminRegistrationsRequest) (*apimodel.AdminRegistrations, gtserror.WithCode)
+	// AdminReadOnlySet switches read-only mode on or off at runtime, overriding the config until it is set again.
+	AdminReadOnlySet(ctx context.Context, authed *oauth.Auth, form *apimodel.AdminReadOnlyRequest) (*apimodel.AdminReadOnly, gtserror.WithCode)
+	// AdminModerationRuleCreate creates a moderation rule, which statuses are checked against as they're created or received.
+	AdminModerationRuleCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.ModerationRuleCreateRequest) (*apimodel.ModerationRule, gtserror.WithCode)
+	// AdminModerationRulesGet returns a list of all moderation rules.
+	AdminModerationRulesGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.ModerationRule, gtserror.WithCode)
+	// AdminModerationRuleDelete deletes one moderation rule, specified by ID, returning the deleted rule.
+	AdminModerationRuleDelete(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.ModerationRule, gtserror.WithCode)
+	// AdminModeratedStatusesGet returns a list of statuses which matched a moderation rule and are waiting for review, newest first.
+	AdminModeratedStatusesGet(ctx context.Context, authed *oauth.Auth) ([]*apimodel.AdminModeratedStatus, gtserror.WithCode)
+	// AdminModeratedStatusApprove approves one moderated status, specified by ID, delivering it as usual if it was held.
+	AdminModeratedStatusApprove(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminModeratedStatus, gtserror.WithCode)
+	// AdminModeratedStatusReject rejects one moderated status, specified by ID, deleting it.
+	AdminModeratedStatusReject(ctx context.Context, authed *oauth.Auth, id string) (*apimodel.AdminModeratedStatus, gtserror.WithCode)
 
 	// AppCreate processes the creation of a new API application
 	AppCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.ApplicationCreateRequest) (*apimodel.Application, error)
@@ -131,6 +200,21 @@ type Processor interface {
 	FollowRequestAccept(ctx context.Context, auth *oauth.Auth, accountID string) (*apimodel.Relationship, gtserror.WithCode)
 	// FollowRequestReject handles the rejection of a follow request from the given account ID.
 	FollowRequestReject(ctx context.Context, auth *oauth.Auth, accountID string) (*apimodel.Relationship, gtserror.WithCode)
+	// FollowRequestAcceptAll accepts all pending follow requests to the authed account, reporting the outcome of each one.
+	FollowRequestAcceptAll(ctx context.Context, auth *oauth.Auth) ([]apimodel.FollowRequestBulkResult, gtserror.WithCode)
+	// FollowRequestBulk accepts or rejects the follow requests from the account IDs in the given form, reporting the outcome of each one.
+	FollowRequestBulk(ctx context.Context, auth *oauth.Auth, form *apimodel.FollowRequestBulkRequest) ([]apimodel.FollowRequestBulkResult, gtserror.WithCode)
+	// FollowRequestAutoAcceptGet returns the follow request auto-accept rules of the authed account.
+	FollowRequestAutoAcceptGet(ctx context.Context, auth *oauth.Auth) (*apimodel.FollowRequestAutoAccept, gtserror.WithCode)
+	// FollowRequestAutoAcceptUpdate updates the follow request auto-accept rules of the authed account with the given form.
+	FollowRequestAutoAcceptUpdate(ctx context.Context, auth *oauth.Auth, form *apimodel.FollowRequestAutoAcceptUpdateRequest) (*apimodel.FollowRequestAutoAccept, gtserror.WithCode)
+
+	// CustomEmojisGet returns the custom emojis of this instance which haven't been disabled, grouped by category.
+	// Categories are in alphabetical order, with uncategorized emojis at the end.
+	CustomEmojisGet(ctx context.Context) ([]*apimodel.Emoji, gtserror.WithCode)
+	// CustomEmojiCategoriesGet returns the names of the categories of the custom emojis of this instance, in the
+	// same order as they are grouped by CustomEmojisGet.
+	CustomEmojiCategoriesGet(ctx context.Context) ([]string, gtserror.WithCode)
 
 	// InstanceGet retrieves instance information for serving at api/v1/instance
 	InstanceGet(ctx context.Context, domain string) (*apimodel.Instance, gtserror.WithCode)
@@ -149,13 +233,19 @@ type Processor interface {
 	// NotificationsGet
 	NotificationsGet(ctx context.Context, authed *oauth.Auth, limit int, maxID string, sinceID string) ([]*apimodel.Notification, gtserror.WithCode)
 
+	// OEmbedGet returns an oEmbed representation of the local public status with the given url or uri.
+	OEmbedGet(ctx context.Context, form *apimodel.OEmbedRequest) (*apimodel.OEmbed, gtserror.WithCode)
+
 	// SearchGet performs a search with the given params, resolving/dereferencing remotely as desired
 	SearchGet(ctx context.Context, authed *oauth.Auth, searchQuery *apimodel.SearchQuery) (*apimodel.SearchResult, gtserror.WithCode)
 
 	// StatusCreate processes the given form to create a new status, returning the api model representation of that status if it's OK.
 	StatusCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AdvancedStatusCreateForm) (*apimodel.Status, error)
 	// StatusDelete processes the delete of a given status, returning the deleted status if the delete goes through.
-	StatusDelete(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, error)
+	// If preserveMedia is true, the media of the status is detached from it rather than deleted, so that it can be reused.
+	StatusDelete(ctx context.Context, authed *oauth.Auth, targetStatusID string, preserveMedia bool) (*apimodel.Status, error)
+	// StatusDeleteForRedraft deletes the given status, returning its source and detached media so that it can be drafted again.
+	StatusDeleteForRedraft(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.StatusRedraft, gtserror.WithCode)
 	// StatusFave processes the faving of a given status, returning the updated status if the fave goes through.
 	StatusFave(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, error)
 	// StatusBoost processes the boost/reblog of a given status, returning the newly-created boost if all is well.
@@ -172,11 +262,34 @@ type Processor interface {
 	StatusUnfave(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, error)
 	// StatusGetContext returns the context (previous and following posts) from the given status ID
 	StatusGetContext(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Context, gtserror.WithCode)
+	// StatusReact adds the given emoji reaction to the given status, returning the updated status if the reaction goes through.
+	StatusReact(ctx context.Context, authed *oauth.Auth, targetStatusID string, reaction string) (*apimodel.Status, gtserror.WithCode)
+	// StatusUnreact removes the given emoji reaction from the given status, returning the updated status.
+	StatusUnreact(ctx context.Context, authed *oauth.Auth, targetStatusID string, reaction string) (*apimodel.Status, gtserror.WithCode)
+	// StatusGetSource returns the source text of the given status ID, as long as it belongs to the authed account.
+	StatusGetSource(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.StatusSource, gtserror.WithCode)
+	// StatusAutoDeleteAll deletes old statuses of all local accounts which have opted into auto-deletion, returning the number of statuses deleted.
+	StatusAutoDeleteAll(ctx context.Context) (int, error)
+
+	// TagGet returns the tag with the given name, noting whether the authed account follows it.
+	TagGet(ctx context.Context, authed *oauth.Auth, name string) (*apimodel.Tag, gtserror.WithCode)
+	// TagFollow makes the authed account follow the tag with the given name, so that public statuses using it appear in its home timeline.
+	TagFollow(ctx context.Context, authed *oauth.Auth, name string) (*apimodel.Tag, gtserror.WithCode)
+	// TagUnfollow makes the authed account stop following the tag with the given name.
+	TagUnfollow(ctx context.Context, authed *oauth.Auth, name string) (*apimodel.Tag, gtserror.WithCode)
+	// FollowedTagsGet returns the tags followed by the authed account.
+	FollowedTagsGet(ctx context.Context, authed *oauth.Auth) ([]apimodel.Tag, gtserror.WithCode)
+	// TrendingTagsGet returns up to limit hashtags which have been used by the most accounts in public statuses recently.
+	TrendingTagsGet(ctx context.Context, limit int) ([]apimodel.Tag, gtserror.WithCode)
+	// SuggestionsGet returns up to limit accounts which the authed account might like to follow, based on who the accounts it follows follow.
+	SuggestionsGet(ctx context.Context, authed *oauth.Auth, limit int) ([]apimodel.Account, gtserror.WithCode)
 
 	// HomeTimelineGet returns statuses from the home timeline, with the given filters/parameters.
-	HomeTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int, local bool) (*apimodel.StatusTimelineResponse, gtserror.WithCode)
+	// If languages is not nil, only statuses matching the language filter will be returned.
+	HomeTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int, local bool, languages *db.LanguageFilter) (*apimodel.StatusTimelineResponse, gtserror.WithCode)
 	// PublicTimelineGet returns statuses from the public/local timeline, with the given filters/parameters.
-	PublicTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int, local bool) (*apimodel.StatusTimelineResponse, gtserror.WithCode)
+	// If languages is not nil, only statuses matching the language filter will be returned.
+	PublicTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int, local bool, languages *db.LanguageFilter) (*apimodel.StatusTimelineResponse, gtserror.WithCode)
 	// FavedTimelineGet returns faved statuses, with the given filters/parameters.
 	FavedTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, minID string, limit int) (*apimodel.StatusTimelineResponse, gtserror.WithCode)
 
@@ -240,14 +353,17 @@ type processor struct {
 	clientWorker *worker.Worker[messages.FromClientAPI]
 	fedWorker    *worker.Worker[messages.FromFederator]
 
-	federator       federation.Federator
-	tc              typeutils.TypeConverter
-	oauthServer     oauth.Server
-	mediaManager    media.Manager
-	storage         *kv.KVStore
-	statusTimelines timeline.Manager
-	db              db.DB
-	filter          visibility.Filter
+	federator        federation.Federator
+	tc               typeutils.TypeConverter
+	oauthServer      oauth.Server
+	mediaManager     media.Manager
+	storage          *kv.KVStore
+	statusTimelines  timeline.Manager
+	db               db.DB
+	filter           visibility.Filter
+	trendsCache      *ttlcache.Cache
+	suggestionsCache *ttlcache.Cache
+	moderationRules  automod.Rules
 
 	/*
 		SUB-PROCESSORS
@@ -276,27 +392,40 @@ func NewProcessor(
 ) Processor {
 	parseMentionFunc := GetParseMentionFunc(db, federator)
 
-	statusProcessor := status.New(db, tc, clientWorker, parseMentionFunc)
+	moderationRules := automod.New(db)
+
+	statusProcessor := status.New(db, tc, transport.NewPublicClient(db), moderationRules, clientWorker, parseMentionFunc)
 	streamingProcessor := streaming.New(db, oauthServer)
-	accountProcessor := account.New(db, tc, mediaManager, oauthServer, clientWorker, federator, parseMentionFunc)
-	adminProcessor := admin.New(db, tc, mediaManager, clientWorker)
+	accountProcessor := account.New(db, tc, transport.NewPublicClient(db), mediaManager, oauthServer, clientWorker, federator, parseMentionFunc, storage)
+	adminProcessor := admin.New(db, tc, mediaManager, oauthServer, moderationRules, clientWorker)
 	mediaProcessor := mediaProcessor.New(db, tc, mediaManager, federator.TransportController(), storage)
 	userProcessor := user.New(db, emailSender)
 	federationProcessor := federationProcessor.New(db, tc, federator)
 	filter := visibility.NewFilter(db)
 
+	trendsCache := ttlcache.NewCache()
+	trendsCache.SetTTL(trendsCacheTTL)
+	trendsCache.SkipTtlExtensionOnHit(true)
+
+	suggestionsCache := ttlcache.NewCache()
+	suggestionsCache.SetTTL(suggestionsCacheTTL)
+	suggestionsCache.SkipTtlExtensionOnHit(true)
+
 	return &processor{
 		clientWorker: clientWorker,
 		fedWorker:    fedWorker,
 
-		federator:       federator,
-		tc:              tc,
-		oauthServer:     oauthServer,
-		mediaManager:    mediaManager,
-		storage:         storage,
-		statusTimelines: timeline.NewManager(StatusGrabFunction(db), StatusFilterFunction(db, filter), StatusPrepareFunction(db, tc), StatusSkipInsertFunction()),
-		db:              db,
-		filter:          visibility.NewFilter(db),
+		federator:        federator,
+		tc:               tc,
+		oauthServer:      oauthServer,
+		mediaManager:     mediaManager,
+		storage:          storage,
+		statusTimelines:  timeline.NewManager(StatusGrabFunction(db), StatusFilterFunction(db, filter), StatusPrepareFunction(db, tc), StatusSkipInsertFunction()),
+		db:               db,
+		filter:           visibility.NewFilter(db),
+		trendsCache:      trendsCache,
+		suggestionsCache: suggestionsCache,
+		moderationRules:  moderationRules,
 
 		accountProcessor:    accountProcessor,
 		adminProcessor:      adminProcessor,
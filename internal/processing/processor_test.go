@@ -92,6 +92,11 @@ func (suite *ProcessingStandardTestSuite) SetupSuite() {
 			User:        suite.testUsers["local_account_1"],
 			Account:     suite.testAccounts["local_account_1"],
 		},
+		"local_account_2": {
+			Application: suite.testApplications["local_account_2"],
+			User:        suite.testUsers["local_account_2"],
+			Account:     suite.testAccounts["local_account_2"],
+		},
 	}
 	suite.testBlocks = testrig.NewTestBlocks()
 }
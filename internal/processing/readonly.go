@@ -0,0 +1,41 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+// checkReadOnly returns a service unavailable error carrying the read-only message
+// if this instance is currently in read-only mode, so that writes can be rejected.
+// It returns nil if the instance isn't read-only.
+func (p *processor) checkReadOnly(ctx context.Context) gtserror.WithCode {
+	readOnly, message, err := p.db.GetReadOnly(ctx)
+	if err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("checkReadOnly: error checking whether instance is read-only: %s", err))
+	}
+	if readOnly {
+		return gtserror.WithErrorCode(gtserror.NewErrorServiceUnavailable(errors.New("checkReadOnly: instance is read-only"), message), gtserror.CodeReadOnly)
+	}
+	return nil
+}
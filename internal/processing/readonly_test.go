@@ -0,0 +1,74 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+type ReadOnlyTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *ReadOnlyTestSuite) TestStatusCreateReadOnly() {
+	ctx := context.Background()
+	authed := &oauth.Auth{
+		Application: suite.testApplications["application_1"],
+		User:        suite.testUsers["local_account_1"],
+		Account:     suite.testAccounts["local_account_1"],
+	}
+
+	viper.Set(config.Keys.InstanceReadOnly, true)
+	viper.Set(config.Keys.InstanceReadOnlyMessage, "migrating, back soon")
+
+	statusCreateForm := &apimodel.AdvancedStatusCreateForm{
+		StatusCreateRequest: apimodel.StatusCreateRequest{
+			Status:     "is anyone there?",
+			Visibility: apimodel.VisibilityPublic,
+			Language:   "en",
+			Format:     apimodel.StatusFormatPlain,
+		},
+	}
+
+	apiStatus, err := suite.processor.StatusCreate(ctx, authed, statusCreateForm)
+	suite.Nil(apiStatus)
+	errWithCode, ok := err.(gtserror.WithCode)
+	suite.True(ok)
+	suite.Equal(http.StatusServiceUnavailable, errWithCode.Code())
+	suite.Equal("service unavailable: migrating, back soon", errWithCode.Safe())
+
+	// once we're out of read-only mode, statuses can be created again
+	suite.NoError(suite.db.SetReadOnly(ctx, false, ""))
+	apiStatus, err = suite.processor.StatusCreate(ctx, authed, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+}
+
+func TestReadOnlyTestSuite(t *testing.T) {
+	suite.Run(t, &ReadOnlyTestSuite{})
+}
@@ -36,7 +36,7 @@ import (
 )
 
 func (p *processor) SearchGet(ctx context.Context, authed *oauth.Auth, searchQuery *apimodel.SearchQuery) (*apimodel.SearchResult, gtserror.WithCode) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":  "SearchGet",
 		"query": searchQuery.Query,
 	})
@@ -50,9 +50,6 @@ func (p *processor) SearchGet(ctx context.Context, authed *oauth.Auth, searchQue
 	foundStatuses := []*gtsmodel.Status{}
 	// foundHashtags := []*gtsmodel.Tag{}
 
-	// convert the query to lowercase and trim leading/trailing spaces
-	query := strings.ToLower(strings.TrimSpace(searchQuery.Query))
-
 	var foundOne bool
 	// check if the query is something like @whatever_username@example.org -- this means it's a remote account
 	if _, domain, err := util.ExtractMentionParts(searchQuery.Query); err == nil && domain != "" {
@@ -65,19 +62,23 @@ func (p *processor) SearchGet(ctx context.Context, authed *oauth.Auth, searchQue
 		}
 	}
 
-	// check if the query is a URI and just do a lookup for that, straight up
+	// check if the query is a URL and just do a lookup for that, straight up; the query isn't lowercased
+	// here, since the path of a URL is case sensitive
 	if !foundOne {
-		if uri, err := url.Parse(query); err == nil {
+		if uri, ok := searchURL(searchQuery.Query); ok {
 			// 1. check if it's a status
 			if foundStatus, err := p.searchStatusByURI(ctx, authed, uri, searchQuery.Resolve); err == nil && foundStatus != nil {
 				foundStatuses = append(foundStatuses, foundStatus)
+				foundOne = true
 				l.Debug("got a status by searching by URI")
 			}
 
-			// 2. check if it's an account
-			if foundAccount, err := p.searchAccountByURI(ctx, authed, uri, searchQuery.Resolve); err == nil && foundAccount != nil {
-				foundAccounts = append(foundAccounts, foundAccount)
-				l.Debug("got an account by searching by URI")
+			// 2. if it's not a status, check if it's an account
+			if !foundOne {
+				if foundAccount, err := p.searchAccountByURI(ctx, authed, uri, searchQuery.Resolve); err == nil && foundAccount != nil {
+					foundAccounts = append(foundAccounts, foundAccount)
+					l.Debug("got an account by searching by URI")
+				}
 			}
 		}
 	}
@@ -87,6 +88,18 @@ func (p *processor) SearchGet(ctx context.Context, authed *oauth.Auth, searchQue
 		and then converting them into our frontend format.
 	*/
 	for _, foundAccount := range foundAccounts {
+		// accounts we've stored from a domain that's since been blocked aren't shown at all
+		if foundAccount.Domain != "" {
+			if blocked, err := p.db.IsDomainBlocked(ctx, foundAccount.Domain); err != nil || blocked {
+				continue
+			}
+		}
+
+		// silenced accounts are only shown to their followers
+		if p.silencedFor(ctx, foundAccount, authed.Account) {
+			continue
+		}
+
 		// make sure there's no block in either direction between the account and the requester
 		if blocked, err := p.db.IsBlocked(ctx, authed.Account.ID, foundAccount.ID, true); err == nil && !blocked {
 			// all good, convert it and add it to the results
@@ -101,6 +114,17 @@ func (p *processor) SearchGet(ctx context.Context, authed *oauth.Auth, searchQue
 			continue
 		}
 
+		if foundStatus.Account == nil {
+			statusAccount, err := p.db.GetAccountByID(ctx, foundStatus.AccountID)
+			if err != nil {
+				continue
+			}
+			foundStatus.Account = statusAccount
+		}
+		if p.silencedFor(ctx, foundStatus.Account, authed.Account) {
+			continue
+		}
+
 		apiStatus, err := p.tc.StatusToAPIStatus(ctx, foundStatus, authed.Account)
 		if err != nil {
 			continue
@@ -112,8 +136,28 @@ func (p *processor) SearchGet(ctx context.Context, authed *oauth.Auth, searchQue
 	return results, nil
 }
 
+// searchURL returns the given search query parsed as a URL, and true, if it's an absolute http or https URL.
+func searchURL(query string) (*url.URL, bool) {
+	uri, err := url.Parse(strings.TrimSpace(query))
+	if err != nil || uri.Host == "" || (uri.Scheme != "http" && uri.Scheme != "https") {
+		return nil, false
+	}
+	return uri, true
+}
+
+// silencedFor returns true if the given account has been silenced by an admin, and so should be left out
+// of search results for the requesting account, which is the case unless the requester follows it.
+func (p *processor) silencedFor(ctx context.Context, account *gtsmodel.Account, requestingAccount *gtsmodel.Account) bool {
+	if account.SilencedAt.IsZero() || account.ID == requestingAccount.ID {
+		return false
+	}
+
+	follows, err := p.db.IsFollowing(ctx, requestingAccount, account)
+	return err != nil || !follows
+}
+
 func (p *processor) searchStatusByURI(ctx context.Context, authed *oauth.Auth, uri *url.URL, resolve bool) (*gtsmodel.Status, error) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":    "searchStatusByURI",
 		"uri":     uri.String(),
 		"resolve": resolve,
@@ -125,8 +169,8 @@ func (p *processor) searchStatusByURI(ctx context.Context, authed *oauth.Auth, u
 		return maybeStatus, nil
 	}
 
-	// we don't have it locally so dereference it if we're allowed to
-	if resolve {
+	// we don't have it locally so dereference it if we're allowed to, and if it's not one of ours
+	if resolve && !p.isLocalURI(uri) {
 		status, _, _, err := p.federator.GetRemoteStatus(ctx, authed.Account.Username, uri, true, true)
 		if err == nil {
 			if err := p.federator.DereferenceRemoteThread(ctx, authed.Account.Username, uri); err != nil {
@@ -146,7 +190,7 @@ func (p *processor) searchAccountByURI(ctx context.Context, authed *oauth.Auth,
 		return maybeAccount, nil
 	}
 
-	if resolve {
+	if resolve && !p.isLocalURI(uri) {
 		// we don't have it locally so try and dereference it
 		account, err := p.federator.GetRemoteAccount(ctx, authed.Account.Username, uri, true, true)
 		if err != nil {
@@ -157,6 +201,11 @@ func (p *processor) searchAccountByURI(ctx context.Context, authed *oauth.Auth,
 	return nil, nil
 }
 
+// isLocalURI returns true if the given URI points at this instance, in which case there's nothing to dereference.
+func (p *processor) isLocalURI(uri *url.URL) bool {
+	return strings.EqualFold(uri.Host, viper.GetString(config.Keys.Host)) || strings.EqualFold(uri.Host, viper.GetString(config.Keys.AccountDomain))
+}
+
 func (p *processor) searchAccountByMention(ctx context.Context, authed *oauth.Auth, mention string, resolve bool) (*gtsmodel.Account, error) {
 	// query is for a remote account
 	username, domain, err := util.ExtractMentionParts(mention)
@@ -195,7 +244,7 @@ func (p *processor) searchAccountByMention(ctx context.Context, authed *oauth.Au
 	if resolve {
 		// we're allowed to resolve it so let's try
 		// first we need to webfinger the remote account to convert the username and domain into the activitypub URI for the account
-		acctURI, err := p.federator.FingerRemoteAccount(ctx, authed.Account.Username, username, domain)
+		acctURI, err := p.federator.FingerRemoteAccount(ctx, username, domain)
 		if err != nil {
 			// something went wrong doing the webfinger lookup so we can't process the request
 			return nil, fmt.Errorf("error fingering remote account with username %s and domain %s: %s", username, domain, err)
@@ -0,0 +1,86 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type SearchTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *SearchTestSuite) TestSearchStatusByURL() {
+	ctx := context.Background()
+	status := suite.testStatuses["remote_account_1_status_1"]
+
+	// status ids are upper case, so the query mustn't be lowercased
+	results, errWithCode := suite.processor.SearchGet(ctx, suite.testAutheds["local_account_1"], &apimodel.SearchQuery{Query: status.URL})
+	suite.NoError(errWithCode)
+	suite.Len(results.Statuses, 1)
+	suite.Equal(status.ID, results.Statuses[0].ID)
+	suite.Empty(results.Accounts)
+}
+
+func (suite *SearchTestSuite) TestSearchResolveAccountByURL() {
+	ctx := context.Background()
+	account := suite.testAccounts["remote_account_2"]
+
+	// forget about the account, so that it has to be dereferenced again
+	suite.NoError(suite.db.DeleteByID(ctx, account.ID, &gtsmodel.Account{}))
+
+	// without resolve, only accounts we already know about are returned
+	results, errWithCode := suite.processor.SearchGet(ctx, suite.testAutheds["local_account_1"], &apimodel.SearchQuery{Query: account.URI})
+	suite.NoError(errWithCode)
+	suite.Empty(results.Accounts)
+
+	results, errWithCode = suite.processor.SearchGet(ctx, suite.testAutheds["local_account_1"], &apimodel.SearchQuery{Query: account.URI, Resolve: true})
+	suite.NoError(errWithCode)
+	suite.Len(results.Accounts, 1)
+	suite.Equal(account.Username, results.Accounts[0].Username)
+
+	// the account is stored now
+	dbAccount, err := suite.db.GetAccountByURI(ctx, account.URI)
+	suite.NoError(err)
+	suite.Equal(account.Username, dbAccount.Username)
+}
+
+func (suite *SearchTestSuite) TestSearchDomainBlockedAccount() {
+	ctx := context.Background()
+	account := suite.testAccounts["remote_account_1"]
+
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.DomainBlock{
+		ID:                 "01G5QF6AHZ5CNJTWGA8G3MV1ZB",
+		Domain:             account.Domain,
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}))
+
+	results, errWithCode := suite.processor.SearchGet(ctx, suite.testAutheds["local_account_1"], &apimodel.SearchQuery{Query: account.URI, Resolve: true})
+	suite.NoError(errWithCode)
+	suite.Empty(results.Accounts)
+}
+
+func TestSearchTestSuite(t *testing.T) {
+	suite.Run(t, new(SearchTestSuite))
+}
@@ -27,11 +27,18 @@ import (
 )
 
 func (p *processor) StatusCreate(ctx context.Context, authed *oauth.Auth, form *apimodel.AdvancedStatusCreateForm) (*apimodel.Status, error) {
+	if errWithCode := p.checkReadOnly(ctx); errWithCode != nil {
+		return nil, errWithCode
+	}
 	return p.statusProcessor.Create(ctx, authed.Account, authed.Application, form)
 }
 
-func (p *processor) StatusDelete(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, error) {
-	return p.statusProcessor.Delete(ctx, authed.Account, targetStatusID)
+func (p *processor) StatusDelete(ctx context.Context, authed *oauth.Auth, targetStatusID string, preserveMedia bool) (*apimodel.Status, error) {
+	return p.statusProcessor.Delete(ctx, authed.Account, targetStatusID, preserveMedia)
+}
+
+func (p *processor) StatusDeleteForRedraft(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.StatusRedraft, gtserror.WithCode) {
+	return p.statusProcessor.DeleteForRedraft(ctx, authed.Account, targetStatusID)
 }
 
 func (p *processor) StatusFave(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, error) {
@@ -39,6 +46,9 @@ func (p *processor) StatusFave(ctx context.Context, authed *oauth.Auth, targetSt
 }
 
 func (p *processor) StatusBoost(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Status, gtserror.WithCode) {
+	if errWithCode := p.checkReadOnly(ctx); errWithCode != nil {
+		return nil, errWithCode
+	}
 	return p.statusProcessor.Boost(ctx, authed.Account, authed.Application, targetStatusID)
 }
 
@@ -65,3 +75,19 @@ func (p *processor) StatusUnfave(ctx context.Context, authed *oauth.Auth, target
 func (p *processor) StatusGetContext(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.Context, gtserror.WithCode) {
 	return p.statusProcessor.Context(ctx, authed.Account, targetStatusID)
 }
+
+func (p *processor) StatusReact(ctx context.Context, authed *oauth.Auth, targetStatusID string, reaction string) (*apimodel.Status, gtserror.WithCode) {
+	return p.statusProcessor.React(ctx, authed.Account, targetStatusID, reaction)
+}
+
+func (p *processor) StatusUnreact(ctx context.Context, authed *oauth.Auth, targetStatusID string, reaction string) (*apimodel.Status, gtserror.WithCode) {
+	return p.statusProcessor.Unreact(ctx, authed.Account, targetStatusID, reaction)
+}
+
+func (p *processor) StatusGetSource(ctx context.Context, authed *oauth.Auth, targetStatusID string) (*apimodel.StatusSource, gtserror.WithCode) {
+	return p.statusProcessor.Source(ctx, authed.Account, targetStatusID)
+}
+
+func (p *processor) StatusAutoDeleteAll(ctx context.Context) (int, error) {
+	return p.statusProcessor.AutoDeleteAll(ctx)
+}
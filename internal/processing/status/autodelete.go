@@ -0,0 +1,128 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+)
+
+// autoDeletePageSize is how many statuses to select at a time when looking for statuses to auto-delete.
+const autoDeletePageSize = 20
+
+func (p *processor) AutoDelete(ctx context.Context, account *gtsmodel.Account) (int, error) {
+	if account.AutoDeleteAfterDays <= 0 {
+		return 0, nil
+	}
+
+	// status ids are ulids, so every status created before the cutoff has an id lower than this one
+	cutoff := time.Now().AddDate(0, 0, -account.AutoDeleteAfterDays)
+	maxID, err := id.NewULIDFromTime(cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("AutoDelete: error creating max id: %s", err)
+	}
+
+	deleted := 0
+	for {
+		if err := ctx.Err(); err != nil {
+			return deleted, err
+		}
+
+		statuses, err := p.db.GetAccountStatuses(ctx, account.ID, autoDeletePageSize, false, true, maxID, "", false, false, false, false)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				// no older statuses left for this account so we're done
+				return deleted, nil
+			}
+			return deleted, fmt.Errorf("AutoDelete: db error selecting statuses for account %s: %s", account.ID, err)
+		}
+
+		for _, s := range statuses {
+			maxID = s.ID
+
+			if s.CreatedAt.After(cutoff) {
+				// the id of the status doesn't match its creation time, so don't trust it
+				continue
+			}
+
+			keep, err := p.keepFromAutoDelete(ctx, account, s)
+			if err != nil {
+				return deleted, fmt.Errorf("AutoDelete: error checking status %s: %s", s.ID, err)
+			}
+			if keep {
+				continue
+			}
+
+			if _, errWithCode := p.Delete(ctx, account, s.ID, false); errWithCode != nil {
+				return deleted, fmt.Errorf("AutoDelete: error deleting status %s: %s", s.ID, errWithCode)
+			}
+			deleted++
+		}
+	}
+}
+
+func (p *processor) AutoDeleteAll(ctx context.Context) (int, error) {
+	accounts := []*gtsmodel.Account{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "domain", Value: nil}}, &accounts); err != nil {
+		if err == db.ErrNoEntries {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("AutoDeleteAll: error getting local accounts: %s", err)
+	}
+
+	deleted := 0
+	for _, account := range accounts {
+		if account.AutoDeleteAfterDays <= 0 || !account.SuspendedAt.IsZero() {
+			continue
+		}
+
+		d, err := p.AutoDelete(ctx, account)
+		deleted += d
+		if err != nil {
+			if ctx.Err() != nil {
+				return deleted, err
+			}
+			logrus.Errorf("AutoDeleteAll: error auto-deleting statuses of account %s: %s", account.ID, err)
+		}
+	}
+
+	return deleted, nil
+}
+
+// keepFromAutoDelete returns true if the given status should be kept, even though it's old enough to be
+// auto-deleted. Like Mastodon, we keep statuses which the account has pinned, bookmarked, or faved itself.
+func (p *processor) keepFromAutoDelete(ctx context.Context, account *gtsmodel.Account, status *gtsmodel.Status) (bool, error) {
+	if status.Pinned {
+		return true, nil
+	}
+
+	if bookmarked, err := p.db.IsStatusBookmarkedBy(ctx, status, account.ID); err != nil {
+		return false, err
+	} else if bookmarked {
+		return true, nil
+	}
+
+	return p.db.IsStatusFavedBy(ctx, status, account.ID)
+}
@@ -0,0 +1,86 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type StatusAutoDeleteTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusAutoDeleteTestSuite) TestAutoDelete() {
+	ctx := context.Background()
+
+	account := &gtsmodel.Account{}
+	*account = *suite.testAccounts["local_account_1"]
+	account.AutoDeleteAfterDays = 1
+
+	// pinned statuses are kept
+	pinned := &gtsmodel.Status{}
+	*pinned = *suite.testStatuses["local_account_1_status_2"]
+	pinned.Pinned = true
+	suite.NoError(suite.db.UpdateStatus(ctx, pinned))
+
+	// and so are statuses bookmarked by their author
+	bookmarked := suite.testStatuses["local_account_1_status_3"]
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.StatusBookmark{
+		ID:              "01G63QH2X6QEHMP7A8NYNEQ1YB",
+		AccountID:       account.ID,
+		TargetAccountID: account.ID,
+		StatusID:        bookmarked.ID,
+	}))
+
+	deleted, err := suite.status.AutoDelete(ctx, account)
+	suite.NoError(err)
+	suite.Equal(1, deleted)
+
+	// the old status is gone
+	err = suite.db.GetByID(ctx, suite.testStatuses["local_account_1_status_1"].ID, &gtsmodel.Status{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// but the kept and recent ones are still there
+	for _, s := range []string{"local_account_1_status_2", "local_account_1_status_3", "local_account_1_status_4", "local_account_1_status_5"} {
+		err = suite.db.GetByID(ctx, suite.testStatuses[s].ID, &gtsmodel.Status{})
+		suite.NoError(err)
+	}
+}
+
+func (suite *StatusAutoDeleteTestSuite) TestAutoDeleteDisabled() {
+	ctx := context.Background()
+
+	account := suite.testAccounts["local_account_1"]
+
+	deleted, err := suite.status.AutoDelete(ctx, account)
+	suite.NoError(err)
+	suite.Zero(deleted)
+
+	err = suite.db.GetByID(ctx, suite.testStatuses["local_account_1_status_1"].ID, &gtsmodel.Status{})
+	suite.NoError(err)
+}
+
+func TestStatusAutoDeleteTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusAutoDeleteTestSuite))
+}
@@ -20,20 +20,26 @@ package status
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/spf13/viper"
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/id"
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
-	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/uris"
 )
 
 func (p *processor) Create(ctx context.Context, account *gtsmodel.Account, application *gtsmodel.Application, form *apimodel.AdvancedStatusCreateForm) (*apimodel.Status, gtserror.WithCode) {
+	if errWithCode := p.checkDuplicate(ctx, account, form); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	accountURIs := uris.GenerateURIsForAccount(account.Username)
 	thisStatusID, err := id.NewULID()
 	if err != nil {
@@ -49,22 +55,35 @@ func (p *processor) Create(ctx context.Context, account *gtsmodel.Account, appli
 		Local:                    true,
 		AccountID:                account.ID,
 		AccountURI:               account.URI,
-		ContentWarning:           text.SanitizeCaption(form.SpoilerText),
 		ActivityStreamsType:      ap.ObjectNote,
-		Sensitive:                form.Sensitive,
 		Language:                 form.Language,
 		CreatedWithApplicationID: application.ID,
 		Text:                     form.Status,
 	}
 
-	if err := p.ProcessReplyToID(ctx, form, account.ID, newStatus); err != nil {
+	// statuses which match a moderation rule are either rejected outright, or held for review
+	if errWithCode := p.checkModerationRules(ctx, form, newStatus); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if errWithCode := p.ProcessReplyToID(ctx, form, account.ID, newStatus); errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.ProcessContentWarning(ctx, form, account, newStatus); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	if errWithCode := p.ProcessQuoteID(ctx, form, account, newStatus); errWithCode != nil {
+		return nil, errWithCode
+	}
+
 	if err := p.ProcessMediaIDs(ctx, form, account.ID, newStatus); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	p.processAutoContentWarning(form, newStatus)
+
 	if err := p.ProcessVisibility(ctx, form, account.Privacy, newStatus); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
@@ -73,10 +92,28 @@ func (p *processor) Create(ctx context.Context, account *gtsmodel.Account, appli
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	if err := p.ProcessMentions(ctx, form, account.ID, newStatus); err != nil {
+	// very new accounts can't post publicly, to make throwaway accounts less useful for spam
+	tooNew, err := p.accountTooNew(ctx, account)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+	if tooNew && newStatus.Visibility == gtsmodel.VisibilityPublic {
+		minAge := viper.GetDuration(config.Keys.AccountsMinAge)
+		return nil, gtserror.NewErrorForbidden(errors.New("statuscreate: account is too new to post publicly"), fmt.Sprintf("accounts younger than %s can't post publicly", minAge))
+	}
+
+	pendingMentions, err := p.ProcessMentions(ctx, form, account.ID, newStatus)
+	if err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
+	// nor can they mention accounts that didn't initiate contact with them
+	if tooNew {
+		if errWithCode := p.checkNewAccountMentions(ctx, account, newStatus); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
 	if err := p.ProcessTags(ctx, form, account.ID, newStatus); err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
 	}
@@ -94,13 +131,33 @@ func (p *processor) Create(ctx context.Context, account *gtsmodel.Account, appli
 		return nil, gtserror.NewErrorInternalError(err)
 	}
 
-	// send it back to the processor for async processing
-	p.clientWorker.Queue(messages.FromClientAPI{
-		APObjectType:   ap.ObjectNote,
-		APActivityType: ap.ActivityCreate,
-		GTSModel:       newStatus,
-		OriginAccount:  account,
-	})
+	// send it back to the processor for async processing, unless it's held for review,
+	// in which case it'll only be timelined and federated once an admin approves it
+	if newStatus.HeldAt.IsZero() {
+		p.clientWorker.Queue(messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       newStatus,
+			OriginAccount:  account,
+		})
+	}
+
+	// link any mentions we couldn't resolve in time once they're resolved in the background
+	if len(pendingMentions) != 0 {
+		p.clientWorker.Queue(messages.FromClientAPI{
+			APObjectType:   ap.ObjectNote,
+			APActivityType: ap.ActivityUpdate,
+			GTSModel: &PendingMentions{
+				StatusID: newStatus.ID,
+				Form:     form,
+				TooNew:   tooNew,
+				Mentions: newStatus.Mentions,
+				Tags:     newStatus.Tags,
+				Names:    pendingMentions,
+			},
+			OriginAccount: account,
+		})
+	}
 
 	// return the frontend representation of the new status to the submitter
 	apiStatus, err := p.tc.StatusToAPIStatus(ctx, newStatus, account)
@@ -20,10 +20,16 @@ package status_test
 
 import (
 	"context"
+	"net/http"
 	"testing"
+	"time"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
 type StatusCreateTestSuite struct {
@@ -35,6 +41,7 @@ func (suite *StatusCreateTestSuite) TestProcessContentWarningWithQuotationMarks(
 
 	creatingAccount := suite.testAccounts["local_account_1"]
 	creatingApplication := suite.testApplications["application_1"]
+	spoilerText := "\"test\"" // these should not be html-escaped when the final text is rendered
 
 	statusCreateForm := &model.AdvancedStatusCreateForm{
 		StatusCreateRequest: model.StatusCreateRequest{
@@ -42,8 +49,8 @@ func (suite *StatusCreateTestSuite) TestProcessContentWarningWithQuotationMarks(
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
-			SpoilerText: "\"test\"", // these should not be html-escaped when the final text is rendered
+			Sensitive:   nil,
+			SpoilerText: &spoilerText,
 			Visibility:  model.VisibilityPublic,
 			ScheduledAt: "",
 			Language:    "en",
@@ -69,6 +76,7 @@ func (suite *StatusCreateTestSuite) TestProcessContentWarningWithHTMLEscapedQuot
 
 	creatingAccount := suite.testAccounts["local_account_1"]
 	creatingApplication := suite.testApplications["application_1"]
+	spoilerText := "&#34test&#34" // the html-escaped quotation marks should appear as normal quotation marks in the finished text
 
 	statusCreateForm := &model.AdvancedStatusCreateForm{
 		StatusCreateRequest: model.StatusCreateRequest{
@@ -76,8 +84,8 @@ func (suite *StatusCreateTestSuite) TestProcessContentWarningWithHTMLEscapedQuot
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
-			SpoilerText: "&#34test&#34", // the html-escaped quotation marks should appear as normal quotation marks in the finished text
+			Sensitive:   nil,
+			SpoilerText: &spoilerText,
 			Visibility:  model.VisibilityPublic,
 			ScheduledAt: "",
 			Language:    "en",
@@ -98,6 +106,512 @@ func (suite *StatusCreateTestSuite) TestProcessContentWarningWithHTMLEscapedQuot
 	suite.Equal("\"test\"", apiStatus.SpoilerText)
 }
 
+func (suite *StatusCreateTestSuite) TestCreateReplyInheritContentWarning() {
+	ctx := context.Background()
+	viper.Set(config.Keys.StatusesReplyInheritCW, true)
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	repliedStatus := suite.testStatuses["admin_account_status_2"]
+
+	statusCreateForm := &model.AdvancedStatusCreateForm{
+		StatusCreateRequest: model.StatusCreateRequest{
+			Status:      "cute puppies!",
+			InReplyToID: repliedStatus.ID,
+			Visibility:  model.VisibilityPublic,
+			Language:    "en",
+			Format:      model.StatusFormatPlain,
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+
+	suite.Equal("open to see some puppies", apiStatus.SpoilerText)
+	suite.True(apiStatus.Sensitive)
+}
+
+func (suite *StatusCreateTestSuite) TestCreateReplyInheritContentWarningDisabled() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	repliedStatus := suite.testStatuses["admin_account_status_2"]
+
+	statusCreateForm := &model.AdvancedStatusCreateForm{
+		StatusCreateRequest: model.StatusCreateRequest{
+			Status:      "cute puppies!",
+			InReplyToID: repliedStatus.ID,
+			Visibility:  model.VisibilityPublic,
+			Language:    "en",
+			Format:      model.StatusFormatPlain,
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+
+	suite.Empty(apiStatus.SpoilerText)
+	suite.False(apiStatus.Sensitive)
+}
+
+func (suite *StatusCreateTestSuite) TestCreateReplyClearContentWarning() {
+	ctx := context.Background()
+	viper.Set(config.Keys.StatusesReplyInheritCW, true)
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	repliedStatus := suite.testStatuses["admin_account_status_2"]
+
+	// an explicitly empty spoiler and sensitive flag should override the replied status
+	spoilerText := ""
+	sensitive := false
+
+	statusCreateForm := &model.AdvancedStatusCreateForm{
+		StatusCreateRequest: model.StatusCreateRequest{
+			Status:      "cute puppies!",
+			InReplyToID: repliedStatus.ID,
+			Sensitive:   &sensitive,
+			SpoilerText: &spoilerText,
+			Visibility:  model.VisibilityPublic,
+			Language:    "en",
+			Format:      model.StatusFormatPlain,
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+
+	suite.Empty(apiStatus.SpoilerText)
+	suite.False(apiStatus.Sensitive)
+}
+
+func (suite *StatusCreateTestSuite) TestCreateReplyOwnContentWarning() {
+	ctx := context.Background()
+	viper.Set(config.Keys.StatusesReplyInheritCW, true)
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	repliedStatus := suite.testStatuses["admin_account_status_2"]
+	spoilerText := "more puppies"
+
+	statusCreateForm := &model.AdvancedStatusCreateForm{
+		StatusCreateRequest: model.StatusCreateRequest{
+			Status:      "cute puppies!",
+			InReplyToID: repliedStatus.ID,
+			SpoilerText: &spoilerText,
+			Visibility:  model.VisibilityPublic,
+			Language:    "en",
+			Format:      model.StatusFormatPlain,
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+
+	suite.Equal("more puppies", apiStatus.SpoilerText)
+	suite.False(apiStatus.Sensitive)
+}
+
+func (suite *StatusCreateTestSuite) TestCreateNoParentContentWarning() {
+	ctx := context.Background()
+	viper.Set(config.Keys.StatusesReplyInheritCW, true)
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	statusCreateForm := &model.AdvancedStatusCreateForm{
+		StatusCreateRequest: model.StatusCreateRequest{
+			Status:     "just a normal status",
+			Visibility: model.VisibilityPublic,
+			Language:   "en",
+			Format:     model.StatusFormatPlain,
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+
+	suite.Empty(apiStatus.SpoilerText)
+	suite.False(apiStatus.Sensitive)
+}
+
+func (suite *StatusCreateTestSuite) TestCreateAccountDefaultSensitive() {
+	ctx := context.Background()
+
+	// copy the account so we don't change it for other tests
+	creatingAccount := &gtsmodel.Account{}
+	*creatingAccount = *suite.testAccounts["local_account_1"]
+	creatingAccount.Sensitive = true
+	creatingApplication := suite.testApplications["application_1"]
+
+	statusCreateForm := &model.AdvancedStatusCreateForm{
+		StatusCreateRequest: model.StatusCreateRequest{
+			Status:     "just a normal status",
+			Visibility: model.VisibilityPublic,
+			Language:   "en",
+			Format:     model.StatusFormatPlain,
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+
+	suite.True(apiStatus.Sensitive)
+}
+
+func (suite *StatusCreateTestSuite) TestCreateAccountSensitized() {
+	ctx := context.Background()
+
+	// copy the account so we don't change it for other tests
+	creatingAccount := &gtsmodel.Account{}
+	*creatingAccount = *suite.testAccounts["local_account_1"]
+	creatingAccount.SensitizedAt = time.Now()
+	creatingApplication := suite.testApplications["application_1"]
+
+	// the author explicitly says the status isn't sensitive, but that's overridden
+	sensitive := false
+	statusCreateForm := &model.AdvancedStatusCreateForm{
+		StatusCreateRequest: model.StatusCreateRequest{
+			Status:     "just a normal status",
+			Sensitive:  &sensitive,
+			Visibility: model.VisibilityPublic,
+			Language:   "en",
+			Format:     model.StatusFormatPlain,
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.NotNil(apiStatus)
+
+	suite.True(apiStatus.Sensitive)
+}
+
+func (suite *StatusCreateTestSuite) TestCreateQuote() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	quotedStatus := suite.testStatuses["local_account_2_status_4"]
+
+	statusCreateForm := &model.AdvancedStatusCreateForm{
+		StatusCreateRequest: model.StatusCreateRequest{
+			Status:     "look at this",
+			QuoteID:    quotedStatus.ID,
+			Visibility: model.VisibilityPublic,
+			Language:   "en",
+			Format:     model.StatusFormatPlain,
+		},
+	}
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(errWithCode)
+	suite.NotNil(apiStatus)
+
+	suite.Equal(quotedStatus.ID, apiStatus.QuoteID)
+	suite.NotNil(apiStatus.Quote)
+	suite.Equal(quotedStatus.ID, apiStatus.Quote.ID)
+	suite.Equal(`<p>look at this</p><p class="quote-inline">RE: <a href="http://localhost:8080/@1happyturtle/statuses/01F8MHCP5P2NWYQ416SBA0XSEV">http://localhost:8080/@1happyturtle/statuses/01F8MHCP5P2NWYQ416SBA0XSEV</a></p>`, apiStatus.Content)
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, apiStatus.ID)
+	suite.NoError(err)
+	suite.Equal(quotedStatus.ID, dbStatus.QuoteOfID)
+	suite.Equal(quotedStatus.URI, dbStatus.QuoteOfURI)
+}
+
+func (suite *StatusCreateTestSuite) TestCreateQuoteUnquotable() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	quotedStatus := suite.testStatuses["local_account_2_status_4"]
+
+	// the author of the quoted status opts out of being quoted
+	quotedAccount := suite.testAccounts["local_account_2"]
+	quotedAccount.Unquotable = true
+	_, err := suite.db.UpdateAccount(ctx, quotedAccount)
+	suite.NoError(err)
+
+	statusCreateForm := &model.AdvancedStatusCreateForm{
+		StatusCreateRequest: model.StatusCreateRequest{
+			Status:     "look at this",
+			QuoteID:    quotedStatus.ID,
+			Visibility: model.VisibilityPublic,
+			Language:   "en",
+			Format:     model.StatusFormatPlain,
+		},
+	}
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.Nil(apiStatus)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+	suite.Equal("forbidden: the author of status with id 01F8MHCP5P2NWYQ416SBA0XSEV does not allow their statuses to be quoted", errWithCode.Safe())
+}
+
+func (suite *StatusCreateTestSuite) TestCreateReplyPolicy() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	statusCreateForm := &model.AdvancedStatusCreateForm{
+		StatusCreateRequest: model.StatusCreateRequest{
+			Status:      "no replies please",
+			Visibility:  model.VisibilityPublic,
+			ReplyPolicy: model.ReplyPolicyNobody,
+			Language:    "en",
+			Format:      model.StatusFormatPlain,
+		},
+	}
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(errWithCode)
+	suite.NotNil(apiStatus)
+	suite.Equal(model.ReplyPolicyNobody, apiStatus.ReplyPolicy)
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, apiStatus.ID)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.ReplyPolicyNobody, dbStatus.ReplyPolicy)
+}
+
+func (suite *StatusCreateTestSuite) TestCreateReplyNotPermitted() {
+	ctx := context.Background()
+
+	// turtle doesn't follow the admin
+	creatingAccount := suite.testAccounts["local_account_2"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	// the admin only wants replies from followers
+	repliedStatus := *suite.testStatuses["admin_account_status_1"]
+	repliedStatus.ReplyPolicy = gtsmodel.ReplyPolicyFollowers
+	suite.NoError(suite.db.UpdateStatus(ctx, &repliedStatus))
+
+	statusCreateForm := &model.AdvancedStatusCreateForm{
+		StatusCreateRequest: model.StatusCreateRequest{
+			Status:      "hello admin",
+			InReplyToID: repliedStatus.ID,
+			Visibility:  model.VisibilityPublic,
+			Language:    "en",
+			Format:      model.StatusFormatPlain,
+		},
+	}
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.Nil(apiStatus)
+	suite.NotNil(errWithCode)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+	suite.Equal("forbidden: the author of this status doesn't permit you to reply to it", errWithCode.Safe())
+
+	// zork follows the admin, so they can reply
+	apiStatus, errWithCode = suite.status.Create(ctx, suite.testAccounts["local_account_1"], creatingApplication, statusCreateForm)
+	suite.NoError(errWithCode)
+	suite.NotNil(apiStatus)
+}
+
+func (suite *StatusCreateTestSuite) TestCreateTooNew() {
+	ctx := context.Background()
+
+	// turtle's account is younger than this
+	creatingAccount := suite.testAccounts["local_account_2"]
+	creatingApplication := suite.testApplications["application_1"]
+	viper.Set(config.Keys.AccountsMinAge, 200*time.Hour)
+
+	newForm := func(status string, visibility model.Visibility) *model.AdvancedStatusCreateForm {
+		return &model.AdvancedStatusCreateForm{
+			StatusCreateRequest: model.StatusCreateRequest{
+				Status:     status,
+				Visibility: visibility,
+				Language:   "en",
+				Format:     model.StatusFormatPlain,
+			},
+		}
+	}
+
+	// no public posts
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, newForm("hello world", model.VisibilityPublic))
+	suite.Nil(apiStatus)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// admin doesn't follow turtle and has never mentioned turtle, so can't be mentioned
+	apiStatus, errWithCode = suite.status.Create(ctx, creatingAccount, creatingApplication, newForm("hey @admin", model.VisibilityUnlisted))
+	suite.Nil(apiStatus)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	// zork follows turtle, so can be mentioned
+	apiStatus, errWithCode = suite.status.Create(ctx, creatingAccount, creatingApplication, newForm("hey @the_mighty_zork", model.VisibilityUnlisted))
+	suite.NoError(errWithCode)
+	suite.Len(apiStatus.Mentions, 1)
+
+	// once the account is old enough, anything goes
+	viper.Set(config.Keys.AccountsMinAge, 24*time.Hour)
+	apiStatus, errWithCode = suite.status.Create(ctx, creatingAccount, creatingApplication, newForm("hey @admin", model.VisibilityPublic))
+	suite.NoError(errWithCode)
+	suite.Len(apiStatus.Mentions, 1)
+}
+
+func (suite *StatusCreateTestSuite) TestCreateDuplicate() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	viper.Set(config.Keys.StatusesDuplicateWindow, time.Hour)
+
+	newForm := func(status string, allowDuplicate bool) *model.AdvancedStatusCreateForm {
+		return &model.AdvancedStatusCreateForm{
+			StatusCreateRequest: model.StatusCreateRequest{
+				Status:         status,
+				Visibility:     model.VisibilityPublic,
+				Language:       "en",
+				Format:         model.StatusFormatPlain,
+				AllowDuplicate: allowDuplicate,
+			},
+		}
+	}
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, newForm("buy my stuff @admin", false))
+	suite.NoError(errWithCode)
+	suite.NotNil(apiStatus)
+
+	// same text with different mentions and spacing is still a duplicate
+	apiStatus, errWithCode = suite.status.Create(ctx, creatingAccount, creatingApplication, newForm("buy my   stuff @1happyturtle", false))
+	suite.Nil(apiStatus)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.Equal("unprocessable entity: duplicate status", errWithCode.Safe())
+	suite.Equal(gtserror.CodeDuplicateStatus, errWithCode.ErrorCode())
+
+	// unless the user says it's on purpose
+	apiStatus, errWithCode = suite.status.Create(ctx, creatingAccount, creatingApplication, newForm("buy my stuff", true))
+	suite.NoError(errWithCode)
+	suite.NotNil(apiStatus)
+
+	// different text is fine
+	apiStatus, errWithCode = suite.status.Create(ctx, creatingAccount, creatingApplication, newForm("buy my other stuff", false))
+	suite.NoError(errWithCode)
+	suite.NotNil(apiStatus)
+
+	// and so are duplicates once the window has passed
+	viper.Set(config.Keys.StatusesDuplicateWindow, time.Nanosecond)
+	apiStatus, errWithCode = suite.status.Create(ctx, creatingAccount, creatingApplication, newForm("buy my other stuff", false))
+	suite.NoError(errWithCode)
+	suite.NotNil(apiStatus)
+}
+
+func (suite *StatusCreateTestSuite) TestCreateModerationRules() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.ModerationRule{
+		ID:                 "01G5Q2D4XJ0DVB0BXF6Z7QKQ3R",
+		Pattern:            "sunglasses",
+		LocalAction:        gtsmodel.ModerationRuleLocalHold,
+		RemoteAction:       gtsmodel.ModerationRuleRemoteFlag,
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}))
+	suite.NoError(suite.db.Put(ctx, &gtsmodel.ModerationRule{
+		ID:                 "01G5Q2DVD1D4J2ZV6T7MG3R4QA",
+		Pattern:            `free\s+crypto`,
+		Regex:              true,
+		LocalAction:        gtsmodel.ModerationRuleLocalReject,
+		RemoteAction:       gtsmodel.ModerationRuleRemoteDrop,
+		CreatedByAccountID: suite.testAccounts["admin_account"].ID,
+	}))
+
+	newForm := func(status string) *model.AdvancedStatusCreateForm {
+		return &model.AdvancedStatusCreateForm{
+			StatusCreateRequest: model.StatusCreateRequest{
+				Status:     status,
+				Visibility: model.VisibilityPublic,
+				Language:   "en",
+				Format:     model.StatusFormatPlain,
+			},
+		}
+	}
+
+	// matching statuses are held for review, but the author still gets them back
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, newForm("cheap SUNGLASSES here!"))
+	suite.NoError(errWithCode)
+	suite.True(apiStatus.PendingReview)
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, apiStatus.ID)
+	suite.NoError(err)
+	suite.Equal("01G5Q2D4XJ0DVB0BXF6Z7QKQ3R", dbStatus.ModerationRuleID)
+	suite.False(dbStatus.HeldAt.IsZero())
+
+	// or rejected outright
+	apiStatus, errWithCode = suite.status.Create(ctx, creatingAccount, creatingApplication, newForm("get your free   crypto"))
+	suite.Nil(apiStatus)
+	suite.Equal(http.StatusUnprocessableEntity, errWithCode.Code())
+	suite.Equal("unprocessable entity: status rejected: it contains content which isn't allowed on this instance", errWithCode.Safe())
+
+	// plain patterns only match whole words
+	apiStatus, errWithCode = suite.status.Create(ctx, creatingAccount, creatingApplication, newForm("my sunglassesless summer"))
+	suite.NoError(errWithCode)
+	suite.False(apiStatus.PendingReview)
+}
+
+func (suite *StatusCreateTestSuite) TestCreateAutoContentWarning() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	viper.Set(config.Keys.StatusesAutoCWMediaOnly, true)
+	viper.Set(config.Keys.StatusesAutoCWMaxLinks, 2)
+
+	newForm := func(status string, mediaIDs []string, skipAutoCW bool) *model.AdvancedStatusCreateForm {
+		return &model.AdvancedStatusCreateForm{
+			StatusCreateRequest: model.StatusCreateRequest{
+				Status:     status,
+				MediaIDs:   mediaIDs,
+				Visibility: model.VisibilityPublic,
+				Language:   "en",
+				Format:     model.StatusFormatPlain,
+				SkipAutoCW: skipAutoCW,
+			},
+		}
+	}
+
+	// media without any text gets a content warning
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, newForm("  ", []string{suite.testAttachments["local_account_1_unattached_1"].ID}, false))
+	suite.NoError(errWithCode)
+	suite.Equal("Unlabelled media or links", apiStatus.SpoilerText)
+	suite.True(apiStatus.Sensitive)
+
+	// as do statuses with too many links, counting each link only once
+	apiStatus, errWithCode = suite.status.Create(ctx, creatingAccount, creatingApplication, newForm("https://example.org/a https://example.org/b https://example.org/c", nil, false))
+	suite.NoError(errWithCode)
+	suite.Equal("Unlabelled media or links", apiStatus.SpoilerText)
+	suite.True(apiStatus.Sensitive)
+
+	apiStatus, errWithCode = suite.status.Create(ctx, creatingAccount, creatingApplication, newForm("https://example.org/a https://example.org/b https://example.org/a", nil, false))
+	suite.NoError(errWithCode)
+	suite.Empty(apiStatus.SpoilerText)
+	suite.False(apiStatus.Sensitive)
+
+	// unless the author opts out
+	apiStatus, errWithCode = suite.status.Create(ctx, creatingAccount, creatingApplication, newForm("https://example.org/a https://example.org/b https://example.org/c", nil, true))
+	suite.NoError(errWithCode)
+	suite.Empty(apiStatus.SpoilerText)
+	suite.False(apiStatus.Sensitive)
+
+	// or sets a content warning of their own
+	form := newForm("https://example.org/a https://example.org/b https://example.org/c", nil, false)
+	spoilerText := "links!"
+	form.SpoilerText = &spoilerText
+	apiStatus, errWithCode = suite.status.Create(ctx, creatingAccount, creatingApplication, form)
+	suite.NoError(errWithCode)
+	suite.Equal("links!", apiStatus.SpoilerText)
+}
+
 func TestStatusCreateTestSuite(t *testing.T) {
 	suite.Run(t, new(StatusCreateTestSuite))
 }
@@ -22,6 +22,7 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
@@ -30,7 +31,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/messages"
 )
 
-func (p *processor) Delete(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode) {
+func (p *processor) Delete(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string, preserveMedia bool) (*apimodel.Status, gtserror.WithCode) {
 	targetStatus, err := p.db.GetStatusByID(ctx, targetStatusID)
 	if err != nil {
 		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error fetching status %s: %s", targetStatusID, err))
@@ -48,6 +49,12 @@ func (p *processor) Delete(ctx context.Context, requestingAccount *gtsmodel.Acco
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting status %s to frontend representation: %s", targetStatus.ID, err))
 	}
 
+	if preserveMedia {
+		if _, errWithCode := p.detachAttachments(ctx, targetStatus); errWithCode != nil {
+			return nil, errWithCode
+		}
+	}
+
 	if err := p.db.DeleteByID(ctx, targetStatus.ID, &gtsmodel.Status{}); err != nil {
 		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error deleting status from the database: %s", err))
 	}
@@ -63,3 +70,69 @@ func (p *processor) Delete(ctx context.Context, requestingAccount *gtsmodel.Acco
 
 	return apiStatus, nil
 }
+
+func (p *processor) DeleteForRedraft(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.StatusRedraft, gtserror.WithCode) {
+	targetStatus, err := p.db.GetStatusByID(ctx, targetStatusID)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error fetching status %s: %s", targetStatusID, err))
+	}
+
+	if targetStatus.AccountID != requestingAccount.ID {
+		return nil, gtserror.NewErrorForbidden(errors.New("status doesn't belong to requesting account"), "only the author of a status can redraft it")
+	}
+
+	sourceText, reconstructed := statusSourceText(targetStatus)
+	redraft := &apimodel.StatusRedraft{
+		ID:            targetStatus.ID,
+		Text:          sourceText,
+		SpoilerText:   targetStatus.ContentWarning,
+		Reconstructed: reconstructed,
+		Visibility:    p.tc.VisToAPIVis(ctx, targetStatus.Visibility),
+		Language:      targetStatus.Language,
+	}
+
+	// detach the media rather than deleting it, so that it can be attached to the redraft
+	mediaIDs, errWithCode := p.detachAttachments(ctx, targetStatus)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+	redraft.MediaIDs = mediaIDs
+
+	if err := p.db.DeleteByID(ctx, targetStatus.ID, &gtsmodel.Status{}); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error deleting status from the database: %s", err))
+	}
+
+	// send it back to the processor for async processing, which will federate the delete;
+	// the status no longer has any attachments, so the processor will leave the media alone
+	p.clientWorker.Queue(messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityDelete,
+		GTSModel:       targetStatus,
+		OriginAccount:  requestingAccount,
+		TargetAccount:  requestingAccount,
+	})
+
+	return redraft, nil
+}
+
+// detachAttachments clears the status ID of the attachments of the given status, and removes them from the
+// status, so that deleting the status leaves the media alone, free to be attached to a new status by its owner.
+// The IDs of the detached attachments are returned.
+func (p *processor) detachAttachments(ctx context.Context, status *gtsmodel.Status) ([]string, gtserror.WithCode) {
+	mediaIDs := []string{}
+	for _, attachmentID := range status.AttachmentIDs {
+		attachment, err := p.db.GetAttachmentByID(ctx, attachmentID)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error fetching attachment %s: %s", attachmentID, err))
+		}
+		attachment.StatusID = ""
+		attachment.UpdatedAt = time.Now()
+		if err := p.db.UpdateByPrimaryKey(ctx, attachment); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error detaching attachment %s: %s", attachmentID, err))
+		}
+		mediaIDs = append(mediaIDs, attachmentID)
+	}
+	status.AttachmentIDs = nil
+	status.Attachments = nil
+	return mediaIDs, nil
+}
@@ -0,0 +1,105 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type StatusDeleteTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusDeleteTestSuite) TestDeletePreserveMedia() {
+	ctx := context.Background()
+
+	requestingAccount := suite.testAccounts["admin_account"]
+	requestingApplication := suite.testApplications["application_1"]
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+
+	apiStatus, errWithCode := suite.status.Delete(ctx, requestingAccount, targetStatus.ID, true)
+	suite.NoError(errWithCode)
+	suite.Len(apiStatus.MediaAttachments, 1)
+
+	attachment, err := suite.db.GetAttachmentByID(ctx, "01F8MH6NEM8D7527KZAECTCR76")
+	suite.NoError(err)
+	suite.Empty(attachment.StatusID)
+
+	// the detached media can be attached to a new status
+	statusCreateForm := &model.AdvancedStatusCreateForm{
+		StatusCreateRequest: model.StatusCreateRequest{
+			Status:     "hello world again!",
+			MediaIDs:   []string{"01F8MH6NEM8D7527KZAECTCR76"},
+			Visibility: model.VisibilityPublic,
+			Format:     model.StatusFormatPlain,
+		},
+	}
+	newStatus, err := suite.status.Create(ctx, requestingAccount, requestingApplication, statusCreateForm)
+	suite.NoError(err)
+	suite.Len(newStatus.MediaAttachments, 1)
+	suite.Equal("01F8MH6NEM8D7527KZAECTCR76", newStatus.MediaAttachments[0].ID)
+}
+
+func (suite *StatusDeleteTestSuite) TestDeleteForRedraft() {
+	ctx := context.Background()
+
+	requestingAccount := suite.testAccounts["admin_account"]
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+
+	redraft, errWithCode := suite.status.DeleteForRedraft(ctx, requestingAccount, targetStatus.ID)
+	suite.NoError(errWithCode)
+	suite.Equal(targetStatus.ID, redraft.ID)
+	suite.Equal("hello world! #welcome ! first post on the instance :rainbow: !", redraft.Text)
+	suite.Equal([]string{"01F8MH6NEM8D7527KZAECTCR76"}, redraft.MediaIDs)
+	suite.Equal("public", string(redraft.Visibility))
+
+	// the status should be gone
+	err := suite.db.GetByID(ctx, targetStatus.ID, &gtsmodel.Status{})
+	suite.ErrorIs(err, db.ErrNoEntries)
+
+	// but the attachment should still be there, detached from it
+	attachment, err := suite.db.GetAttachmentByID(ctx, "01F8MH6NEM8D7527KZAECTCR76")
+	suite.NoError(err)
+	suite.Empty(attachment.StatusID)
+}
+
+func (suite *StatusDeleteTestSuite) TestDeleteForRedraftNotOwnStatus() {
+	ctx := context.Background()
+
+	requestingAccount := suite.testAccounts["local_account_2"]
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+
+	redraft, errWithCode := suite.status.DeleteForRedraft(ctx, requestingAccount, targetStatus.ID)
+	suite.Nil(redraft)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+
+	dbStatus := &gtsmodel.Status{}
+	suite.NoError(suite.db.GetByID(ctx, targetStatus.ID, dbStatus))
+}
+
+func TestStatusDeleteTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusDeleteTestSuite))
+}
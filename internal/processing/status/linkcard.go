@@ -0,0 +1,409 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"golang.org/x/net/html"
+)
+
+const (
+	// linkCardMaxTitle is the maximum length in characters of the title of a preview card.
+	linkCardMaxTitle = 300
+	// linkCardMaxDescription is the maximum length in characters of the description of a preview card.
+	linkCardMaxDescription = 1000
+)
+
+// PendingLinkCard is queued on the client API worker once a new status has been timelined (and federated,
+// if it's local), so that a preview card for it can be generated in the background without holding it up.
+type PendingLinkCard struct {
+	StatusID string // ID of the new status
+}
+
+func (p *processor) ProcessLinkCard(ctx context.Context, status *gtsmodel.Status) error {
+	if !viper.GetBool(config.Keys.StatusesLinkPreviewEnabled) || status.PreviewCardID != "" {
+		return nil
+	}
+
+	link, ok := firstLink(status.Content)
+	if !ok {
+		return nil
+	}
+
+	card, err := p.getLinkCard(ctx, link)
+	if err != nil {
+		return fmt.Errorf("ProcessLinkCard: error getting card for %s: %s", link, err)
+	}
+	if card == nil {
+		// the page didn't have enough metadata for a card, or didn't want one
+		return nil
+	}
+
+	status.PreviewCardID = card.ID
+	status.PreviewCard = card
+	if err := p.db.UpdateStatus(ctx, status); err != nil {
+		return fmt.Errorf("ProcessLinkCard: error updating status %s: %s", status.ID, err)
+	}
+
+	return nil
+}
+
+// getLinkCard returns the stored preview card for the given link, or generates and stores a new one
+// from the metadata of the linked page. If no card can be generated for the page, nil is returned.
+func (p *processor) getLinkCard(ctx context.Context, link *url.URL) (*gtsmodel.PreviewCard, error) {
+	card, err := p.db.GetPreviewCardByURL(ctx, link.String())
+	if err == nil {
+		return card, nil
+	}
+	if err != db.ErrNoEntries {
+		return nil, fmt.Errorf("error checking for existing card: %s", err)
+	}
+
+	if blocked, err := p.db.IsURIBlocked(ctx, link); err != nil {
+		return nil, fmt.Errorf("error checking domain block: %s", err)
+	} else if blocked {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, viper.GetDuration(config.Keys.StatusesLinkPreviewTimeout))
+	defer cancel()
+
+	page, err := p.fetchLink(ctx, link, "text/html")
+	if err != nil {
+		return nil, fmt.Errorf("error fetching page: %s", err)
+	}
+
+	meta := parsePageMetadata(page, link)
+	if meta.noPreview {
+		return nil, nil
+	}
+
+	card = &gtsmodel.PreviewCard{
+		URL:          link.String(),
+		Title:        meta.title,
+		Description:  meta.description,
+		Type:         "link",
+		ProviderName: meta.siteName,
+		ImageURL:     meta.image,
+	}
+
+	if meta.oEmbed != nil {
+		// oEmbed data is richer than opengraph, but the page can still opt out of it
+		if blocked, err := p.db.IsURIBlocked(ctx, meta.oEmbed); err == nil && !blocked {
+			p.applyOEmbed(ctx, meta.oEmbed, card)
+		}
+	}
+
+	card.Title = truncate(card.Title, linkCardMaxTitle)
+	card.Description = truncate(card.Description, linkCardMaxDescription)
+	if card.Title == "" {
+		return nil, nil
+	}
+
+	cardID, err := id.NewULID()
+	if err != nil {
+		return nil, err
+	}
+	card.ID = cardID
+
+	if err := p.db.Put(ctx, card); err != nil {
+		var alreadyExists *db.ErrAlreadyExists
+		if !errors.As(err, &alreadyExists) {
+			return nil, fmt.Errorf("error storing card: %s", err)
+		}
+		// another status with the same link got there first, so use its card
+		return p.db.GetPreviewCardByURL(ctx, link.String())
+	}
+
+	return card, nil
+}
+
+// fetchLink GETs the given http(s) url with the link client, returning at most the configured max size of the response body.
+func (p *processor) fetchLink(ctx context.Context, link *url.URL, accept string) ([]byte, error) {
+	if link.Scheme != "http" && link.Scheme != "https" {
+		return nil, fmt.Errorf("refusing to fetch %s url", link.Scheme)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, link.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", accept)
+	req.Header.Set("User-Agent", fmt.Sprintf("%s %s", viper.GetString(config.Keys.ApplicationName), viper.GetString(config.Keys.Host)))
+
+	resp, err := p.linkClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("GET request to %s failed (%d): %s", link, resp.StatusCode, resp.Status)
+	}
+
+	return io.ReadAll(io.LimitReader(resp.Body, int64(viper.GetInt(config.Keys.StatusesLinkPreviewMaxSize))))
+}
+
+// oEmbed is the subset of an oEmbed response (https://oembed.com) that we use for preview cards.
+type oEmbed struct {
+	Type         string          `json:"type"`
+	Title        string          `json:"title"`
+	AuthorName   string          `json:"author_name"`
+	AuthorURL    string          `json:"author_url"`
+	ProviderName string          `json:"provider_name"`
+	ProviderURL  string          `json:"provider_url"`
+	URL          string          `json:"url"`
+	ThumbnailURL string          `json:"thumbnail_url"`
+	Width        json.RawMessage `json:"width"`
+	Height       json.RawMessage `json:"height"`
+}
+
+// applyOEmbed fetches the oEmbed metadata at the given url, and fills in the given card with it.
+// The html of rich and video embeds is deliberately left out, since we can't sanitize it safely.
+// Errors are ignored, since the card will still be fine with just the opengraph metadata.
+func (p *processor) applyOEmbed(ctx context.Context, oEmbedURL *url.URL, card *gtsmodel.PreviewCard) {
+	b, err := p.fetchLink(ctx, oEmbedURL, "application/json")
+	if err != nil {
+		return
+	}
+
+	o := &oEmbed{}
+	if err := json.Unmarshal(b, o); err != nil {
+		return
+	}
+
+	switch o.Type {
+	case "photo", "video", "rich":
+		card.Type = o.Type
+	}
+	if o.Title != "" {
+		card.Title = o.Title
+	}
+	if o.ProviderName != "" {
+		card.ProviderName = o.ProviderName
+	}
+	card.AuthorName = o.AuthorName
+	card.AuthorURL = httpURL(o.AuthorURL, oEmbedURL)
+	card.ProviderURL = httpURL(o.ProviderURL, oEmbedURL)
+	if thumbnail := httpURL(o.ThumbnailURL, oEmbedURL); thumbnail != "" && card.ImageURL == "" {
+		card.ImageURL = thumbnail
+	}
+	if card.Type == "photo" {
+		card.EmbedURL = httpURL(o.URL, oEmbedURL)
+	}
+	card.Width = oEmbedDimension(o.Width)
+	card.Height = oEmbedDimension(o.Height)
+}
+
+// oEmbedDimension parses an oEmbed width or height, which some providers send as a string rather than a number.
+func oEmbedDimension(raw json.RawMessage) int {
+	s := strings.Trim(string(raw), `"`)
+	i, err := strconv.Atoi(s)
+	if err != nil || i < 0 {
+		return 0
+	}
+	return i
+}
+
+// pageMetadata is the metadata of a linked page which is relevant for generating a preview card.
+type pageMetadata struct {
+	title       string
+	description string
+	siteName    string
+	image       string
+	oEmbed      *url.URL
+	noPreview   bool
+}
+
+// parsePageMetadata parses the opengraph tags, title, description, oEmbed discovery link and robots
+// directives out of the given html page, resolving any relative urls against the given page url.
+func parsePageMetadata(page []byte, pageURL *url.URL) *pageMetadata {
+	meta := &pageMetadata{}
+
+	doc, err := html.Parse(bytes.NewReader(page))
+	if err != nil {
+		return meta
+	}
+
+	var title, description, ogTitle, ogDescription string
+	var noImage bool
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if n.Type == html.ElementNode {
+			switch n.Data {
+			case "title":
+				if n.FirstChild != nil && n.FirstChild.Type == html.TextNode && title == "" {
+					title = n.FirstChild.Data
+				}
+			case "meta":
+				name := strings.ToLower(attr(n, "name"))
+				if name == "" {
+					name = strings.ToLower(attr(n, "property"))
+				}
+				content := attr(n, "content")
+				switch name {
+				case "robots":
+					for _, directive := range strings.Split(strings.ToLower(content), ",") {
+						switch strings.TrimSpace(directive) {
+						case "noai", "nosnippet", "none":
+							meta.noPreview = true
+						case "noimageai":
+							noImage = true
+						}
+					}
+				case "description":
+					description = content
+				case "og:title":
+					ogTitle = content
+				case "og:description":
+					ogDescription = content
+				case "og:site_name":
+					meta.siteName = content
+				case "og:image":
+					if meta.image == "" {
+						meta.image = httpURL(content, pageURL)
+					}
+				}
+			case "link":
+				if strings.EqualFold(attr(n, "rel"), "alternate") && strings.EqualFold(attr(n, "type"), "application/json+oembed") {
+					if oEmbedURL := httpURL(attr(n, "href"), pageURL); oEmbedURL != "" && meta.oEmbed == nil {
+						meta.oEmbed, _ = url.Parse(oEmbedURL)
+					}
+				}
+			}
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	meta.title = strings.TrimSpace(firstNonEmpty(ogTitle, title))
+	meta.description = strings.TrimSpace(firstNonEmpty(ogDescription, description))
+	meta.siteName = strings.TrimSpace(meta.siteName)
+	if noImage {
+		meta.image = ""
+	}
+
+	return meta
+}
+
+// firstLink returns the first link in the given status html which isn't a mention or a hashtag.
+func firstLink(content string) (*url.URL, bool) {
+	doc, err := html.Parse(strings.NewReader(content))
+	if err != nil {
+		return nil, false
+	}
+
+	var link *url.URL
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		if link != nil {
+			return
+		}
+
+		if n.Type == html.ElementNode && n.Data == "a" && !isMentionOrHashtag(n) {
+			if u, err := url.Parse(attr(n, "href")); err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != "" {
+				link = u
+				return
+			}
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	return link, link != nil
+}
+
+// isMentionOrHashtag returns true if the given <a> element is a mention or hashtag link, going by its class or rel.
+func isMentionOrHashtag(n *html.Node) bool {
+	for _, class := range strings.Fields(attr(n, "class")) {
+		if class == "mention" || class == "hashtag" || class == "u-url" {
+			return true
+		}
+	}
+	for _, rel := range strings.Fields(attr(n, "rel")) {
+		if rel == "tag" {
+			return true
+		}
+	}
+	return false
+}
+
+// attr returns the value of the given attribute of the given node, or an empty string if it's not set.
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
+
+// httpURL resolves the given possibly-relative url against base, returning it
+// only if it's an http(s) url, and an empty string otherwise.
+func httpURL(raw string, base *url.URL) string {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return ""
+	}
+
+	u, err := base.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return ""
+	}
+	return u.String()
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if strings.TrimSpace(v) != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+// truncate cuts the given string down to at most max characters, adding an ellipsis if anything was cut.
+func truncate(s string, max int) string {
+	r := []rune(s)
+	if len(r) <= max {
+		return s
+	}
+	return string(r[:max-1]) + "…"
+}
@@ -0,0 +1,179 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/automod"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/processing"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusLinkCardTestSuite struct {
+	StatusStandardTestSuite
+}
+
+// processorWithPages returns a status processor whose http client serves the given pages,
+// keyed by url, and returns an error for any other url.
+func (suite *StatusLinkCardTestSuite) processorWithPages(pages map[string]string) status.Processor {
+	httpClient := testrig.NewMockHTTPClient(func(req *http.Request) (*http.Response, error) {
+		page, ok := pages[req.URL.String()]
+		if !ok {
+			return nil, errors.New("connection refused")
+		}
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Body:       io.NopCloser(bytes.NewReader([]byte(page))),
+		}, nil
+	})
+
+	return status.New(suite.db, suite.typeConverter, httpClient, automod.New(suite.db), suite.clientWorker, processing.GetParseMentionFunc(suite.db, suite.federator))
+}
+
+// newStatusWithContent returns a copy of the given test status with the given content.
+func (suite *StatusLinkCardTestSuite) newStatusWithContent(testStatus string, content string) *gtsmodel.Status {
+	s := &gtsmodel.Status{}
+	*s = *suite.testStatuses[testStatus]
+	s.Content = content
+	return s
+}
+
+func (suite *StatusLinkCardTestSuite) TestProcessLinkCard() {
+	ctx := context.Background()
+	viper.Set(config.Keys.StatusesLinkPreviewEnabled, true)
+
+	processor := suite.processorWithPages(map[string]string{
+		"https://example.org/article": `<html><head>
+<title>fallback title</title>
+<meta property="og:title" content="Is Water Wet?">
+<meta property="og:description" content="We ask an expert.">
+<meta property="og:site_name" content="Example News">
+<meta property="og:image" content="/images/water.jpg">
+<link rel="alternate" type="application/json+oembed" href="https://example.org/oembed?url=article">
+</head></html>`,
+		"https://example.org/oembed?url=article": `{"type":"rich","author_name":"weewee","author_url":"https://example.org/authors/weewee","width":"600","height":400,"html":"<script>alert(1)</script>"}`,
+	})
+
+	testStatus := suite.newStatusWithContent("local_account_1_status_1", `<p><span class="h-card"><a href="http://localhost:8080/@1happyturtle" class="u-url mention">@<span>1happyturtle</span></a></span> read this: <a href="https://example.org/article" rel="nofollow noreferrer noopener" target="_blank">https://example.org/article</a></p>`)
+	err := processor.ProcessLinkCard(ctx, testStatus)
+	suite.NoError(err)
+	suite.NotEmpty(testStatus.PreviewCardID)
+
+	card, err := suite.db.GetPreviewCardByURL(ctx, "https://example.org/article")
+	suite.NoError(err)
+	suite.Equal(testStatus.PreviewCardID, card.ID)
+	suite.Equal("Is Water Wet?", card.Title)
+	suite.Equal("We ask an expert.", card.Description)
+	suite.Equal("rich", card.Type)
+	suite.Equal("Example News", card.ProviderName)
+	suite.Equal("weewee", card.AuthorName)
+	suite.Equal("https://example.org/authors/weewee", card.AuthorURL)
+	suite.Equal("https://example.org/images/water.jpg", card.ImageURL)
+	suite.Equal(600, card.Width)
+	suite.Equal(400, card.Height)
+
+	dbStatus, err := suite.db.GetStatusByID(ctx, testStatus.ID)
+	suite.NoError(err)
+	suite.Equal(card.ID, dbStatus.PreviewCardID)
+
+	apiStatus, err := suite.typeConverter.StatusToAPIStatus(ctx, dbStatus, nil)
+	suite.NoError(err)
+	suite.NotNil(apiStatus.Card)
+	suite.Equal("Is Water Wet?", apiStatus.Card.Title)
+	suite.Empty(apiStatus.Card.HTML)
+
+	// another status with the same link gets the same card, without the page being fetched again
+	otherStatus := suite.newStatusWithContent("local_account_1_status_2", `<p><a href="https://example.org/article">same link</a></p>`)
+	err = suite.processorWithPages(nil).ProcessLinkCard(ctx, otherStatus)
+	suite.NoError(err)
+	suite.Equal(card.ID, otherStatus.PreviewCardID)
+}
+
+func (suite *StatusLinkCardTestSuite) TestProcessLinkCardNoPreview() {
+	ctx := context.Background()
+	viper.Set(config.Keys.StatusesLinkPreviewEnabled, true)
+
+	processor := suite.processorWithPages(map[string]string{
+		"https://example.org/private":  `<html><head><meta name="robots" content="index, noai"><meta property="og:title" content="Not for you"></head></html>`,
+		"https://example.org/untitled": `<html><body>nothing to see here</body></html>`,
+	})
+
+	for _, link := range []string{
+		"https://example.org/private",   // opted out of previews
+		"https://example.org/untitled",  // nothing to make a card from
+		"https://replyguys.com/article", // blocked domain, so never fetched
+	} {
+		testStatus := suite.newStatusWithContent("local_account_1_status_1", `<p><a href="`+link+`">`+link+`</a></p>`)
+		err := processor.ProcessLinkCard(ctx, testStatus)
+		suite.NoError(err)
+		suite.Empty(testStatus.PreviewCardID)
+	}
+
+	// hashtags and mentions aren't links worth previewing
+	testStatus := suite.newStatusWithContent("local_account_1_status_1", `<p><a href="http://localhost:8080/tags/welcome" class="mention hashtag" rel="tag">#<span>welcome</span></a></p>`)
+	err := processor.ProcessLinkCard(ctx, testStatus)
+	suite.NoError(err)
+	suite.Empty(testStatus.PreviewCardID)
+}
+
+func (suite *StatusLinkCardTestSuite) TestProcessLinkCardMaxSize() {
+	ctx := context.Background()
+	viper.Set(config.Keys.StatusesLinkPreviewEnabled, true)
+	viper.Set(config.Keys.StatusesLinkPreviewMaxSize, 64)
+
+	// the title is beyond the max size, so it's never read
+	processor := suite.processorWithPages(map[string]string{
+		"https://example.org/article": `<html><head><!-- ` + strings.Repeat("padding ", 16) + ` --><meta property="og:title" content="Is Water Wet?"></head></html>`,
+	})
+
+	testStatus := suite.newStatusWithContent("local_account_1_status_1", `<p><a href="https://example.org/article">link</a></p>`)
+	err := processor.ProcessLinkCard(ctx, testStatus)
+	suite.NoError(err)
+	suite.Empty(testStatus.PreviewCardID)
+}
+
+func (suite *StatusLinkCardTestSuite) TestProcessLinkCardDisabled() {
+	ctx := context.Background()
+	viper.Set(config.Keys.StatusesLinkPreviewEnabled, false)
+
+	processor := suite.processorWithPages(map[string]string{
+		"https://example.org/article": `<html><head><meta property="og:title" content="Is Water Wet?"></head></html>`,
+	})
+
+	testStatus := suite.newStatusWithContent("local_account_1_status_1", `<p><a href="https://example.org/article">link</a></p>`)
+	err := processor.ProcessLinkCard(ctx, testStatus)
+	suite.NoError(err)
+	suite.Empty(testStatus.PreviewCardID)
+}
+
+func TestStatusLinkCardTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusLinkCardTestSuite))
+}
@@ -0,0 +1,163 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status_test
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/automod"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/processing/status"
+	"github.com/superseriousbusiness/gotosocial/internal/worker"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type StatusMentionTestSuite struct {
+	StatusStandardTestSuite
+}
+
+// slowParseMention returns a ParseMentionFunc which takes longer than the
+// timeout to resolve a mention the first time, and resolves it straight away after that.
+func (suite *StatusMentionTestSuite) slowParseMention(origin *gtsmodel.Account, target *gtsmodel.Account) gtsmodel.ParseMentionFunc {
+	var mu sync.Mutex
+	calls := 0
+
+	return func(ctx context.Context, targetAccount string, _ string, statusID string) (*gtsmodel.Mention, error) {
+		mu.Lock()
+		calls++
+		first := calls == 1
+		mu.Unlock()
+
+		if first {
+			<-ctx.Done()
+			return nil, ctx.Err()
+		}
+
+		mentionID, err := id.NewRandomULID()
+		if err != nil {
+			return nil, err
+		}
+
+		return &gtsmodel.Mention{
+			ID:               mentionID,
+			StatusID:         statusID,
+			OriginAccountID:  origin.ID,
+			OriginAccountURI: origin.URI,
+			TargetAccountID:  target.ID,
+			NameString:       targetAccount,
+			TargetAccountURI: target.URI,
+			TargetAccountURL: target.URL,
+		}, nil
+	}
+}
+
+func (suite *StatusMentionTestSuite) TestCreateWithSlowMention() {
+	ctx := context.Background()
+	viper.Set(config.Keys.StatusesMentionResolveTimeout, 50*time.Millisecond)
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	mentionedAccount := suite.testAccounts["remote_account_1"]
+
+	fromClientAPIChan := make(chan messages.FromClientAPI, 10)
+	clientWorker := worker.New[messages.FromClientAPI](-1, -1)
+	clientWorker.SetProcessor(func(_ context.Context, msg messages.FromClientAPI) error {
+		fromClientAPIChan <- msg
+		return nil
+	})
+	suite.NoError(clientWorker.Start())
+	defer func() { _ = clientWorker.Stop() }()
+
+	processor := status.New(suite.db, suite.typeConverter, testrig.NewMockHTTPClient(nil), automod.New(suite.db), clientWorker, suite.slowParseMention(creatingAccount, mentionedAccount))
+
+	statusCreateForm := &model.AdvancedStatusCreateForm{
+		StatusCreateRequest: model.StatusCreateRequest{
+			Status:     "hey @foss_satan@fossbros-anonymous.io, you're slow",
+			Visibility: model.VisibilityPublic,
+			Format:     model.StatusFormatPlain,
+		},
+	}
+
+	start := time.Now()
+	apiStatus, errWithCode := processor.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(errWithCode)
+	suite.Less(time.Since(start), 5*time.Second)
+
+	// the mention couldn't be resolved in time, so it's not linked yet
+	suite.Empty(apiStatus.Mentions)
+	suite.NotContains(apiStatus.Content, mentionedAccount.URL)
+
+	// it's queued to be resolved in the background
+	var pending *status.PendingMentions
+	for pending == nil {
+		msg := <-fromClientAPIChan
+		if p, ok := msg.GTSModel.(*status.PendingMentions); ok {
+			suite.Equal(creatingAccount.ID, msg.OriginAccount.ID)
+			pending = p
+		}
+	}
+	suite.Equal(apiStatus.ID, pending.StatusID)
+	suite.Equal([]string{"@foss_satan@fossbros-anonymous.io"}, pending.Names)
+
+	// and it should be linked once it's been resolved
+	suite.NoError(processor.ResolvePendingMentions(ctx, creatingAccount, pending))
+	dbStatus, err := suite.db.GetStatusByID(ctx, apiStatus.ID)
+	suite.NoError(err)
+	suite.Len(dbStatus.MentionIDs, 1)
+
+	mention, err := suite.db.GetMention(ctx, dbStatus.MentionIDs[0])
+	suite.NoError(err)
+	suite.Equal(mentionedAccount.ID, mention.TargetAccountID)
+	suite.True(strings.Contains(dbStatus.Content, `href="`+mentionedAccount.URL+`"`), dbStatus.Content)
+}
+
+func (suite *StatusMentionTestSuite) TestCreateWithLocalMentionNoTimeout() {
+	ctx := context.Background()
+	viper.Set(config.Keys.StatusesMentionResolveTimeout, time.Nanosecond)
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+
+	statusCreateForm := &model.AdvancedStatusCreateForm{
+		StatusCreateRequest: model.StatusCreateRequest{
+			Status:     "hey @admin, local accounts don't time out",
+			Visibility: model.VisibilityPublic,
+			Format:     model.StatusFormatPlain,
+		},
+	}
+
+	apiStatus, errWithCode := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(errWithCode)
+	suite.Len(apiStatus.Mentions, 1)
+	suite.Equal(suite.testAccounts["admin_account"].ID, apiStatus.Mentions[0].ID)
+}
+
+func TestStatusMentionTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusMentionTestSuite))
+}
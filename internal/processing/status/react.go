@@ -0,0 +1,175 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"unicode"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+	"github.com/superseriousbusiness/gotosocial/internal/uris"
+)
+
+// maximumUnicodeReactionLength is the maximum number of runes in a unicode emoji reaction.
+// It's generous, since emoji made of zero-width-joined sequences can get quite long.
+const maximumUnicodeReactionLength = 16
+
+func (p *processor) React(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string, reaction string) (*apimodel.Status, gtserror.WithCode) {
+	targetStatus, errWithCode := p.getReactableStatus(ctx, requestingAccount, targetStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+	if !targetStatus.Likeable {
+		return nil, gtserror.NewErrorForbidden(errors.New("status is not reactable"))
+	}
+
+	name, emoji, errWithCode := p.parseReaction(ctx, reaction)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// first check if we've already reacted in this way, if so we don't need to do anything
+	err := p.db.GetWhere(ctx, []db.Where{{Key: "status_id", Value: targetStatus.ID}, {Key: "account_id", Value: requestingAccount.ID}, {Key: "name", Value: name}}, &gtsmodel.StatusReaction{})
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error fetching existing reaction from database: %s", err))
+	}
+
+	if err == db.ErrNoEntries {
+		thisReactionID, err := id.NewULID()
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		gtsReaction := &gtsmodel.StatusReaction{
+			ID:              thisReactionID,
+			AccountID:       requestingAccount.ID,
+			Account:         requestingAccount,
+			TargetAccountID: targetStatus.AccountID,
+			TargetAccount:   targetStatus.Account,
+			StatusID:        targetStatus.ID,
+			Status:          targetStatus,
+			Name:            name,
+			URI:             uris.GenerateURIForLike(requestingAccount.Username, thisReactionID),
+		}
+		if emoji != nil {
+			gtsReaction.EmojiID = emoji.ID
+			gtsReaction.Emoji = emoji
+		}
+
+		if err := p.db.Put(ctx, gtsReaction); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error putting reaction in database: %s", err))
+		}
+
+		// send it back to the processor for async processing
+		p.clientWorker.Queue(messages.FromClientAPI{
+			APObjectType:   ap.ActivityEmojiReact,
+			APActivityType: ap.ActivityCreate,
+			GTSModel:       gtsReaction,
+			OriginAccount:  requestingAccount,
+			TargetAccount:  targetStatus.Account,
+		})
+	}
+
+	apiStatus, err := p.tc.StatusToAPIStatus(ctx, targetStatus, requestingAccount)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting status %s to frontend representation: %s", targetStatus.ID, err))
+	}
+
+	return apiStatus, nil
+}
+
+// getReactableStatus fetches the given status, making sure that it's visible to the requesting account.
+func (p *processor) getReactableStatus(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*gtsmodel.Status, gtserror.WithCode) {
+	targetStatus, err := p.db.GetStatusByID(ctx, targetStatusID)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error fetching status %s: %s", targetStatusID, err))
+	}
+	if targetStatus.Account == nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("no status owner for status %s", targetStatusID))
+	}
+
+	visible, err := p.filter.StatusVisible(ctx, targetStatus, requestingAccount)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error seeing if status %s is visible: %s", targetStatus.ID, err))
+	}
+	if !visible {
+		return nil, gtserror.NewErrorNotFound(errors.New("status is not visible"))
+	}
+
+	return targetStatus, nil
+}
+
+// parseReaction checks that the given reaction is either a unicode emoji, or the shortcode of one of this instance's
+// custom emojis (with or without the surrounding colons). It returns the name that the reaction should be stored under,
+// which is the shortcode wrapped in colons for custom emojis, and the custom emoji if there is one.
+func (p *processor) parseReaction(ctx context.Context, reaction string) (string, *gtsmodel.Emoji, gtserror.WithCode) {
+	reaction = strings.TrimSpace(reaction)
+
+	if shortcode := strings.Trim(reaction, ":"); regexes.EmojiShortcode.MatchString(shortcode) {
+		emoji, err := p.db.GetEmojiByShortcodeDomain(ctx, shortcode, "")
+		if err != nil {
+			if err == db.ErrNoEntries {
+				err = fmt.Errorf("no custom emoji found with shortcode %s", shortcode)
+				return "", nil, gtserror.NewErrorBadRequest(err, err.Error())
+			}
+			return "", nil, gtserror.NewErrorInternalError(fmt.Errorf("error fetching emoji %s from database: %s", shortcode, err))
+		}
+		if emoji.Disabled {
+			err := fmt.Errorf("custom emoji %s is disabled", shortcode)
+			return "", nil, gtserror.NewErrorBadRequest(err, err.Error())
+		}
+		return ":" + shortcode + ":", emoji, nil
+	}
+
+	if !isUnicodeEmoji(reaction) {
+		err := fmt.Errorf("reaction %q is neither a unicode emoji nor a custom emoji shortcode", reaction)
+		return "", nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+	return reaction, nil, nil
+}
+
+// isUnicodeEmoji does a rough check of whether s looks like a single unicode emoji: short, with no
+// spaces or colons in it, and containing at least one symbol. It doesn't try to be exhaustive.
+func isUnicodeEmoji(s string) bool {
+	runes := []rune(s)
+	if len(runes) == 0 || len(runes) > maximumUnicodeReactionLength {
+		return false
+	}
+
+	var symbol bool
+	for _, r := range runes {
+		if unicode.IsSpace(r) || r == ':' || r == '<' || r == '>' {
+			return false
+		}
+		if unicode.Is(unicode.So, r) {
+			symbol = true
+		}
+	}
+	return symbol
+}
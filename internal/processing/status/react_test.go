@@ -0,0 +1,130 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+)
+
+type StatusReactTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusReactTestSuite) TestReactUnicode() {
+	ctx := context.Background()
+
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+
+	apiStatus, errWithCode := suite.status.React(ctx, requestingAccount, targetStatus.ID, "🌈")
+	suite.NoError(errWithCode)
+	suite.Len(apiStatus.Reactions, 1)
+	suite.Equal("🌈", apiStatus.Reactions[0].Name)
+	suite.Equal(1, apiStatus.Reactions[0].Count)
+	suite.True(apiStatus.Reactions[0].Me)
+	suite.Empty(apiStatus.Reactions[0].URL)
+
+	// reacting the same way again shouldn't change anything
+	apiStatus, errWithCode = suite.status.React(ctx, requestingAccount, targetStatus.ID, "🌈")
+	suite.NoError(errWithCode)
+	suite.Len(apiStatus.Reactions, 1)
+	suite.Equal(1, apiStatus.Reactions[0].Count)
+
+	// someone else reacting the same way should bump the count
+	apiStatus, errWithCode = suite.status.React(ctx, suite.testAccounts["local_account_2"], targetStatus.ID, "🌈")
+	suite.NoError(errWithCode)
+	suite.Len(apiStatus.Reactions, 1)
+	suite.Equal(2, apiStatus.Reactions[0].Count)
+	suite.True(apiStatus.Reactions[0].Me)
+}
+
+func (suite *StatusReactTestSuite) TestReactCustomEmoji() {
+	ctx := context.Background()
+
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+
+	apiStatus, errWithCode := suite.status.React(ctx, requestingAccount, targetStatus.ID, ":rainbow:")
+	suite.NoError(errWithCode)
+	suite.Len(apiStatus.Reactions, 1)
+	suite.Equal("rainbow", apiStatus.Reactions[0].Name)
+	suite.Equal(1, apiStatus.Reactions[0].Count)
+	suite.Equal("http://localhost:8080/fileserver/01F8MH261H1KSV3GW3016GZRY3/emoji/original/01F8MH9H8E4VG3KDYJR9EGPXCQ.png", apiStatus.Reactions[0].URL)
+
+	// the colons are optional, and both forms should count as the same reaction
+	apiStatus, errWithCode = suite.status.React(ctx, requestingAccount, targetStatus.ID, "rainbow")
+	suite.NoError(errWithCode)
+	suite.Len(apiStatus.Reactions, 1)
+	suite.Equal(1, apiStatus.Reactions[0].Count)
+}
+
+func (suite *StatusReactTestSuite) TestReactInvalid() {
+	ctx := context.Background()
+
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+
+	for _, reaction := range []string{"", "hello world", "definitely_not_an_emoji", "<b>🌈</b>"} {
+		apiStatus, errWithCode := suite.status.React(ctx, requestingAccount, targetStatus.ID, reaction)
+		suite.Nil(apiStatus)
+		suite.Equal(http.StatusBadRequest, errWithCode.Code(), reaction)
+	}
+}
+
+func (suite *StatusReactTestSuite) TestReactNotVisible() {
+	ctx := context.Background()
+
+	// this is a direct message that the admin account isn't part of
+	requestingAccount := suite.testAccounts["admin_account"]
+	targetStatus := suite.testStatuses["local_account_2_status_6"]
+
+	apiStatus, errWithCode := suite.status.React(ctx, requestingAccount, targetStatus.ID, "🌈")
+	suite.Nil(apiStatus)
+	suite.Equal(http.StatusNotFound, errWithCode.Code())
+}
+
+func (suite *StatusReactTestSuite) TestUnreact() {
+	ctx := context.Background()
+
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["admin_account_status_1"]
+
+	_, errWithCode := suite.status.React(ctx, requestingAccount, targetStatus.ID, ":rainbow:")
+	suite.NoError(errWithCode)
+	_, errWithCode = suite.status.React(ctx, requestingAccount, targetStatus.ID, "🌈")
+	suite.NoError(errWithCode)
+
+	apiStatus, errWithCode := suite.status.Unreact(ctx, requestingAccount, targetStatus.ID, "rainbow")
+	suite.NoError(errWithCode)
+	suite.Len(apiStatus.Reactions, 1)
+	suite.Equal("🌈", apiStatus.Reactions[0].Name)
+
+	// removing a reaction that isn't there is fine
+	apiStatus, errWithCode = suite.status.Unreact(ctx, requestingAccount, targetStatus.ID, "rainbow")
+	suite.NoError(errWithCode)
+	suite.Len(apiStatus.Reactions, 1)
+}
+
+func TestStatusReactTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusReactTestSuite))
+}
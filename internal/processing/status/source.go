@@ -0,0 +1,59 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+)
+
+func (p *processor) Source(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string) (*apimodel.StatusSource, gtserror.WithCode) {
+	targetStatus, err := p.db.GetStatusByID(ctx, targetStatusID)
+	if err != nil {
+		return nil, gtserror.NewErrorNotFound(fmt.Errorf("error fetching status %s: %s", targetStatusID, err))
+	}
+
+	if targetStatus.AccountID != requestingAccount.ID {
+		return nil, gtserror.NewErrorForbidden(errors.New("status doesn't belong to requesting account"), "only the author of a status can see its source")
+	}
+
+	sourceText, reconstructed := statusSourceText(targetStatus)
+	return &apimodel.StatusSource{
+		ID:            targetStatus.ID,
+		Text:          sourceText,
+		SpoilerText:   targetStatus.ContentWarning,
+		Reconstructed: reconstructed,
+	}, nil
+}
+
+// statusSourceText returns the source text of the given status, and whether it had to be reconstructed from the status content.
+func statusSourceText(status *gtsmodel.Status) (string, bool) {
+	if status.Text == "" && status.Content != "" {
+		// this status was created before we stored the source
+		// text, so do the best we can with the formatted content
+		return text.ToPlain(status.Content), true
+	}
+	return status.Text, false
+}
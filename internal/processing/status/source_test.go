@@ -0,0 +1,87 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+)
+
+type StatusSourceTestSuite struct {
+	StatusStandardTestSuite
+}
+
+func (suite *StatusSourceTestSuite) TestSourceOwnStatus() {
+	ctx := context.Background()
+
+	creatingAccount := suite.testAccounts["local_account_1"]
+	creatingApplication := suite.testApplications["application_1"]
+	spoilerText := "some spoilers"
+
+	statusCreateForm := &model.AdvancedStatusCreateForm{
+		StatusCreateRequest: model.StatusCreateRequest{
+			Status:      "this is a **status** with #welcome",
+			SpoilerText: &spoilerText,
+			Visibility:  model.VisibilityPublic,
+			Format:      model.StatusFormatMarkdown,
+		},
+	}
+
+	apiStatus, err := suite.status.Create(ctx, creatingAccount, creatingApplication, statusCreateForm)
+	suite.NoError(err)
+
+	source, errWithCode := suite.status.Source(ctx, creatingAccount, apiStatus.ID)
+	suite.NoError(errWithCode)
+	suite.Equal(apiStatus.ID, source.ID)
+	suite.Equal("this is a **status** with #welcome", source.Text)
+	suite.Equal("some spoilers", source.SpoilerText)
+	suite.False(source.Reconstructed)
+}
+
+func (suite *StatusSourceTestSuite) TestSourceReconstructed() {
+	ctx := context.Background()
+
+	requestingAccount := suite.testAccounts["local_account_1"]
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	source, errWithCode := suite.status.Source(ctx, requestingAccount, targetStatus.ID)
+	suite.NoError(errWithCode)
+	suite.Equal("hello everyone!", source.Text)
+	suite.Equal("introduction post", source.SpoilerText)
+	suite.True(source.Reconstructed)
+}
+
+func (suite *StatusSourceTestSuite) TestSourceNotOwnStatus() {
+	ctx := context.Background()
+
+	requestingAccount := suite.testAccounts["local_account_2"]
+	targetStatus := suite.testStatuses["local_account_1_status_1"]
+
+	source, errWithCode := suite.status.Source(ctx, requestingAccount, targetStatus.ID)
+	suite.Nil(source)
+	suite.Equal(http.StatusForbidden, errWithCode.Code())
+}
+
+func TestStatusSourceTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusSourceTestSuite))
+}
@@ -21,7 +21,9 @@ package status
 import (
 	"context"
 
+	"github.com/superseriousbusiness/activity/pub"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/automod"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -37,7 +39,11 @@ type Processor interface {
 	// Create processes the given form to create a new status, returning the api model representation of that status if it's OK.
 	Create(ctx context.Context, account *gtsmodel.Account, application *gtsmodel.Application, form *apimodel.AdvancedStatusCreateForm) (*apimodel.Status, gtserror.WithCode)
 	// Delete processes the delete of a given status, returning the deleted status if the delete goes through.
-	Delete(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
+	// If preserveMedia is true, the media of the status is detached from it rather than deleted, so that it can be reused.
+	Delete(ctx context.Context, account *gtsmodel.Account, targetStatusID string, preserveMedia bool) (*apimodel.Status, gtserror.WithCode)
+	// DeleteForRedraft deletes the given status like Delete, but returns its source instead, and detaches
+	// its media rather than deleting it, so that the status can be drafted again. Only the author can do this.
+	DeleteForRedraft(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.StatusRedraft, gtserror.WithCode)
 	// Fave processes the faving of a given status, returning the updated status if the fave goes through.
 	Fave(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
 	// Boost processes the boost/reblog of a given status, returning the newly-created boost if all is well.
@@ -54,38 +60,70 @@ type Processor interface {
 	Unfave(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Status, gtserror.WithCode)
 	// Context returns the context (previous and following posts) from the given status ID
 	Context(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.Context, gtserror.WithCode)
+	// React adds the given emoji reaction to a given status, returning the updated status if the reaction goes through.
+	// The reaction can be either a unicode emoji, or the shortcode of one of this instance's custom emojis.
+	React(ctx context.Context, account *gtsmodel.Account, targetStatusID string, reaction string) (*apimodel.Status, gtserror.WithCode)
+	// Unreact removes the given emoji reaction from a given status, returning the updated status.
+	Unreact(ctx context.Context, account *gtsmodel.Account, targetStatusID string, reaction string) (*apimodel.Status, gtserror.WithCode)
+	// Source returns the source text of the given status, for editing. Only the author of the status can get its source.
+	Source(ctx context.Context, account *gtsmodel.Account, targetStatusID string) (*apimodel.StatusSource, gtserror.WithCode)
+	// AutoDelete deletes the statuses of the given account which are older than its auto-delete setting allows, and which
+	// it hasn't pinned, bookmarked or faved, returning the number of statuses deleted. Deletes are federated as usual.
+	AutoDelete(ctx context.Context, account *gtsmodel.Account) (int, error)
+	// AutoDeleteAll calls AutoDelete for every local account which has opted into auto-deletion, returning the total number of statuses deleted.
+	AutoDeleteAll(ctx context.Context) (int, error)
 
 	/*
 		PROCESSING UTILS
 	*/
 
 	ProcessVisibility(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountDefaultVis gtsmodel.Visibility, status *gtsmodel.Status) error
-	ProcessReplyToID(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, thisAccountID string, status *gtsmodel.Status) error
+	ProcessReplyToID(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, thisAccountID string, status *gtsmodel.Status) gtserror.WithCode
+	// ProcessContentWarning sets the content warning and sensitivity of the status from the form, falling back to
+	// those of the status being replied to (if configured), or to the default sensitivity of the given account.
+	ProcessContentWarning(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, account *gtsmodel.Account, status *gtsmodel.Status) error
+	// ProcessQuoteID links the status quoted in the form to the status, if the quoted status can be quoted by the given account.
+	ProcessQuoteID(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, account *gtsmodel.Account, status *gtsmodel.Status) gtserror.WithCode
 	ProcessMediaIDs(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, thisAccountID string, status *gtsmodel.Status) error
 	ProcessLanguage(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountDefaultLanguage string, status *gtsmodel.Status) error
-	ProcessMentions(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountID string, status *gtsmodel.Status) error
+	// ProcessMentions links the mentions in the form to the status. It returns the names of any mentioned
+	// accounts which couldn't be resolved within the configured timeout, so that they can be resolved later.
+	ProcessMentions(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountID string, status *gtsmodel.Status) ([]string, error)
 	ProcessTags(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountID string, status *gtsmodel.Status) error
 	ProcessEmojis(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountID string, status *gtsmodel.Status) error
 	ProcessContent(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountID string, status *gtsmodel.Status) error
+	// ResolvePendingMentions resolves mentions of a new status which couldn't be resolved in time when the status was created,
+	// then links them in the status content and sends the status to the newly mentioned accounts. If the author is too new
+	// to mention accounts that didn't initiate contact, mentions of such accounts are dropped.
+	ResolvePendingMentions(ctx context.Context, account *gtsmodel.Account, pending *PendingMentions) error
+	// ProcessLinkCard fetches the first link in the content of the given stored status, and attaches a preview card
+	// for it to the status, if the linked page has enough metadata for one. Cards are shared between statuses by url.
+	// This makes outgoing requests, so it should only be called asynchronously, after the status has been created.
+	ProcessLinkCard(ctx context.Context, status *gtsmodel.Status) error
 }
 
 type processor struct {
-	tc           typeutils.TypeConverter
-	db           db.DB
-	filter       visibility.Filter
-	formatter    text.Formatter
-	clientWorker *worker.Worker[messages.FromClientAPI]
-	parseMention gtsmodel.ParseMentionFunc
+	tc              typeutils.TypeConverter
+	db              db.DB
+	filter          visibility.Filter
+	formatter       text.Formatter
+	linkClient      pub.HttpClient
+	moderationRules automod.Rules
+	clientWorker    *worker.Worker[messages.FromClientAPI]
+	parseMention    gtsmodel.ParseMentionFunc
 }
 
-// New returns a new status processor.
-func New(db db.DB, tc typeutils.TypeConverter, clientWorker *worker.Worker[messages.FromClientAPI], parseMention gtsmodel.ParseMentionFunc) Processor {
+// New returns a new status processor. The given link client is used for fetching links to generate
+// preview cards, and since those links come from users, it shouldn't be able to reach private addresses.
+func New(db db.DB, tc typeutils.TypeConverter, linkClient pub.HttpClient, moderationRules automod.Rules, clientWorker *worker.Worker[messages.FromClientAPI], parseMention gtsmodel.ParseMentionFunc) Processor {
 	return &processor{
-		tc:           tc,
-		db:           db,
-		filter:       visibility.NewFilter(db),
-		formatter:    text.NewFormatter(db),
-		clientWorker: clientWorker,
-		parseMention: parseMention,
+		tc:              tc,
+		db:              db,
+		filter:          visibility.NewFilter(db),
+		formatter:       text.NewFormatter(db),
+		linkClient:      linkClient,
+		moderationRules: moderationRules,
+		clientWorker:    clientWorker,
+		parseMention:    parseMention,
 	}
 }
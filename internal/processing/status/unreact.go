@@ -0,0 +1,74 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package status
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+)
+
+func (p *processor) Unreact(ctx context.Context, requestingAccount *gtsmodel.Account, targetStatusID string, reaction string) (*apimodel.Status, gtserror.WithCode) {
+	targetStatus, errWithCode := p.getReactableStatus(ctx, requestingAccount, targetStatusID)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	name, _, errWithCode := p.parseReaction(ctx, reaction)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	// check if we actually have a reaction like this for this status
+	where := []db.Where{{Key: "status_id", Value: targetStatus.ID}, {Key: "account_id", Value: requestingAccount.ID}, {Key: "name", Value: name}}
+	gtsReaction := &gtsmodel.StatusReaction{}
+	err := p.db.GetWhere(ctx, where, gtsReaction)
+	if err != nil && err != db.ErrNoEntries {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error fetching existing reaction from database: %s", err))
+	}
+
+	if err == nil {
+		// we had a reaction, so take some action to get rid of it
+		if err := p.db.DeleteWhere(ctx, where, &gtsmodel.StatusReaction{}); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("error removing reaction: %s", err))
+		}
+
+		// send it back to the processor for async processing
+		p.clientWorker.Queue(messages.FromClientAPI{
+			APObjectType:   ap.ActivityEmojiReact,
+			APActivityType: ap.ActivityUndo,
+			GTSModel:       gtsReaction,
+			OriginAccount:  requestingAccount,
+			TargetAccount:  targetStatus.Account,
+		})
+	}
+
+	apiStatus, err := p.tc.StatusToAPIStatus(ctx, targetStatus, requestingAccount)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("error converting status %s to frontend representation: %s", targetStatus.ID, err))
+	}
+
+	return apiStatus, nil
+}
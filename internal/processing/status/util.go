@@ -22,15 +22,28 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"html"
+	"strings"
+	"time"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/messages"
+	"github.com/superseriousbusiness/gotosocial/internal/regexes"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
 	"github.com/superseriousbusiness/gotosocial/internal/util"
 )
 
+// pendingMentionResolveTimeout is how long we'll keep trying to resolve, in the background,
+// mentions which couldn't be resolved before the status mentioning them was created.
+const pendingMentionResolveTimeout = 5 * time.Minute
+
 func (p *processor) ProcessVisibility(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountDefaultVis gtsmodel.Visibility, status *gtsmodel.Status) error {
 	// by default all flags are set to true
 	federated := true
@@ -50,6 +63,11 @@ func (p *processor) ProcessVisibility(ctx context.Context, form *apimodel.Advanc
 		vis = gtsmodel.VisibilityDefault
 	}
 
+	// a reply that doesn't set its own visibility shouldn't be more visible than the status it replies to
+	if form.Visibility == "" && status.InReplyTo != nil && viper.GetBool(config.Keys.StatusesReplyInheritVisibility) {
+		vis = leastVisible(vis, status.InReplyTo.Visibility)
+	}
+
 	switch vis {
 	case gtsmodel.VisibilityPublic:
 		// for public, there's no need to change any of the advanced flags from true regardless of what the user filled out
@@ -101,10 +119,37 @@ func (p *processor) ProcessVisibility(ctx context.Context, form *apimodel.Advanc
 	status.Boostable = boostable
 	status.Replyable = replyable
 	status.Likeable = likeable
+
+	status.ReplyPolicy = gtsmodel.ReplyPolicyEveryone
+	if form.ReplyPolicy != "" {
+		status.ReplyPolicy = gtsmodel.ReplyPolicy(form.ReplyPolicy)
+	}
 	return nil
 }
 
-func (p *processor) ProcessReplyToID(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, thisAccountID string, status *gtsmodel.Status) error {
+// visibilityOrder lists visibilities from most to least visible.
+var visibilityOrder = []gtsmodel.Visibility{
+	gtsmodel.VisibilityPublic,
+	gtsmodel.VisibilityUnlocked,
+	gtsmodel.VisibilityFollowersOnly,
+	gtsmodel.VisibilityMutualsOnly,
+	gtsmodel.VisibilityDirect,
+}
+
+// leastVisible returns whichever of the two given visibilities is the least visible.
+func leastVisible(a gtsmodel.Visibility, b gtsmodel.Visibility) gtsmodel.Visibility {
+	for _, vis := range visibilityOrder {
+		switch vis {
+		case a:
+			return b
+		case b:
+			return a
+		}
+	}
+	return a
+}
+
+func (p *processor) ProcessReplyToID(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, thisAccountID string, status *gtsmodel.Status) gtserror.WithCode {
 	if form.InReplyToID == "" {
 		return nil
 	}
@@ -114,6 +159,7 @@ func (p *processor) ProcessReplyToID(ctx context.Context, form *apimodel.Advance
 	// 1. Does the replied status exist in the database?
 	// 2. Is the replied status marked as replyable?
 	// 3. Does a block exist between either the current account or the account that posted the status it's replying to?
+	// 4. Does the reply policy of the replied status permit the current account to reply to it?
 	//
 	// If this is all OK, then we fetch the repliedStatus and the repliedAccount for later processing.
 	repliedStatus := &gtsmodel.Status{}
@@ -121,31 +167,138 @@ func (p *processor) ProcessReplyToID(ctx context.Context, form *apimodel.Advance
 	// check replied status exists + is replyable
 	if err := p.db.GetByID(ctx, form.InReplyToID, repliedStatus); err != nil {
 		if err == db.ErrNoEntries {
-			return fmt.Errorf("status with id %s not replyable because it doesn't exist", form.InReplyToID)
+			return gtserror.NewErrorInternalError(fmt.Errorf("status with id %s not replyable because it doesn't exist", form.InReplyToID))
 		}
-		return fmt.Errorf("status with id %s not replyable: %s", form.InReplyToID, err)
+		return gtserror.NewErrorInternalError(fmt.Errorf("status with id %s not replyable: %s", form.InReplyToID, err))
 	}
 	if !repliedStatus.Replyable {
-		return fmt.Errorf("status with id %s is marked as not replyable", form.InReplyToID)
+		return gtserror.NewErrorInternalError(fmt.Errorf("status with id %s is marked as not replyable", form.InReplyToID))
 	}
 
 	// check replied account is known to us
 	if err := p.db.GetByID(ctx, repliedStatus.AccountID, repliedAccount); err != nil {
 		if err == db.ErrNoEntries {
-			return fmt.Errorf("status with id %s not replyable because account id %s is not known", form.InReplyToID, repliedStatus.AccountID)
+			return gtserror.NewErrorInternalError(fmt.Errorf("status with id %s not replyable because account id %s is not known", form.InReplyToID, repliedStatus.AccountID))
 		}
-		return fmt.Errorf("status with id %s not replyable: %s", form.InReplyToID, err)
+		return gtserror.NewErrorInternalError(fmt.Errorf("status with id %s not replyable: %s", form.InReplyToID, err))
 	}
 	// check if a block exists
 	if blocked, err := p.db.IsBlocked(ctx, thisAccountID, repliedAccount.ID, true); err != nil {
 		if err != db.ErrNoEntries {
-			return fmt.Errorf("status with id %s not replyable: %s", form.InReplyToID, err)
+			return gtserror.NewErrorInternalError(fmt.Errorf("status with id %s not replyable: %s", form.InReplyToID, err))
 		}
 	} else if blocked {
-		return fmt.Errorf("status with id %s not replyable", form.InReplyToID)
+		return gtserror.NewErrorInternalError(fmt.Errorf("status with id %s not replyable", form.InReplyToID))
 	}
+
+	// check the reply policy
+	repliedStatus.Account = repliedAccount
+	thisAccount, err := p.db.GetAccountByID(ctx, thisAccountID)
+	if err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("status with id %s not replyable: %s", form.InReplyToID, err))
+	}
+	if replyable, err := p.filter.StatusReplyable(ctx, repliedStatus, thisAccount); err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("status with id %s not replyable: %s", form.InReplyToID, err))
+	} else if !replyable {
+		err := fmt.Errorf("status with id %s can't be replied to by account %s because of its reply policy", form.InReplyToID, thisAccountID)
+		return gtserror.NewErrorForbidden(err, "the author of this status doesn't permit you to reply to it")
+	}
+
 	status.InReplyToID = repliedStatus.ID
+	status.InReplyTo = repliedStatus
 	status.InReplyToAccountID = repliedAccount.ID
+	status.InReplyToAccount = repliedAccount
+
+	return nil
+}
+
+func (p *processor) ProcessContentWarning(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, account *gtsmodel.Account, status *gtsmodel.Status) error {
+	status.Sensitive = account.Sensitive
+
+	switch {
+	case form.SpoilerText != nil:
+		// an explicit content warning always wins, even an empty one
+		status.ContentWarning = text.SanitizeCaption(*form.SpoilerText)
+	case status.InReplyTo != nil && viper.GetBool(config.Keys.StatusesReplyInheritCW):
+		status.ContentWarning = status.InReplyTo.ContentWarning
+		status.Sensitive = status.Sensitive || status.InReplyTo.Sensitive
+	}
+
+	if form.Sensitive != nil {
+		status.Sensitive = *form.Sensitive
+	}
+
+	// an admin has marked everything this account posts as sensitive
+	if !account.SensitizedAt.IsZero() {
+		status.Sensitive = true
+	}
+
+	return nil
+}
+
+// processAutoContentWarning gives the status the instance's automatic content warning, and marks it as sensitive, if it
+// has media but no text, or more links than allowed, depending on config. An explicit content warning from the author,
+// even an empty one, always wins, as does asking for no automatic content warning, and so does an inherited one.
+func (p *processor) processAutoContentWarning(form *apimodel.AdvancedStatusCreateForm, status *gtsmodel.Status) {
+	if form.SkipAutoCW || form.SpoilerText != nil || status.ContentWarning != "" {
+		return
+	}
+
+	mediaOnly := viper.GetBool(config.Keys.StatusesAutoCWMediaOnly) && len(form.MediaIDs) != 0 && strings.TrimSpace(form.Status) == ""
+
+	maxLinks := viper.GetInt(config.Keys.StatusesAutoCWMaxLinks)
+	tooManyLinks := maxLinks > 0 && len(text.FindLinks(form.Status)) > maxLinks
+
+	if mediaOnly || tooManyLinks {
+		status.ContentWarning = text.SanitizeCaption(viper.GetString(config.Keys.StatusesAutoCWText))
+		status.Sensitive = true
+	}
+}
+
+func (p *processor) ProcessQuoteID(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, account *gtsmodel.Account, status *gtsmodel.Status) gtserror.WithCode {
+	if form.QuoteID == "" {
+		return nil
+	}
+
+	quotedStatus, err := p.db.GetStatusByID(ctx, form.QuoteID)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return gtserror.NewErrorNotFound(fmt.Errorf("status with id %s not quotable because it doesn't exist", form.QuoteID))
+		}
+		return gtserror.NewErrorInternalError(fmt.Errorf("status with id %s not quotable: %s", form.QuoteID, err))
+	}
+
+	// quoting a boost means quoting the boosted status
+	if quotedStatus.BoostOfID != "" {
+		quotedStatus, err = p.db.GetStatusByID(ctx, quotedStatus.BoostOfID)
+		if err != nil {
+			return gtserror.NewErrorNotFound(fmt.Errorf("status with id %s not quotable because the boosted status can't be found: %s", form.QuoteID, err))
+		}
+	}
+
+	// this also checks for blocks in either direction
+	visible, err := p.filter.StatusVisible(ctx, quotedStatus, account)
+	if err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("status with id %s not quotable: %s", form.QuoteID, err))
+	}
+	if !visible {
+		return gtserror.NewErrorNotFound(fmt.Errorf("status with id %s not quotable because it's not visible", form.QuoteID))
+	}
+
+	// quotes are visible to people who might not be able to see the quoted status, so only allow public ones
+	if quotedStatus.Visibility != gtsmodel.VisibilityPublic && quotedStatus.Visibility != gtsmodel.VisibilityUnlocked {
+		err := fmt.Errorf("status with id %s is not quotable because of its visibility", form.QuoteID)
+		return gtserror.NewErrorForbidden(err, err.Error())
+	}
+
+	if quotedStatus.AccountID != account.ID && quotedStatus.Account != nil && quotedStatus.Account.Unquotable {
+		err := fmt.Errorf("the author of status with id %s does not allow their statuses to be quoted", form.QuoteID)
+		return gtserror.NewErrorForbidden(err, err.Error())
+	}
+
+	status.QuoteOfID = quotedStatus.ID
+	status.QuoteOfURI = quotedStatus.URI
+	status.QuoteOf = quotedStatus
 
 	return nil
 }
@@ -191,14 +344,31 @@ func (p *processor) ProcessLanguage(ctx context.Context, form *apimodel.Advanced
 	return nil
 }
 
-func (p *processor) ProcessMentions(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountID string, status *gtsmodel.Status) error {
+func (p *processor) ProcessMentions(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountID string, status *gtsmodel.Status) ([]string, error) {
 	mentionedAccountNames := util.DeriveMentionNamesFromText(form.Status)
 	mentions := []*gtsmodel.Mention{}
 	mentionIDs := []string{}
+	pending := []string{}
+
+	// resolving remote accounts we haven't seen before can take a
+	// while, so only spend so long on it before giving up for now
+	resolveCtx, cancel := context.WithTimeout(ctx, viper.GetDuration(config.Keys.StatusesMentionResolveTimeout))
+	defer cancel()
 
 	for _, mentionedAccountName := range mentionedAccountNames {
-		gtsMention, err := p.parseMention(ctx, mentionedAccountName, accountID, status.ID)
+		// local accounts are just a db lookup away, so don't hold them to the timeout
+		mentionCtx := resolveCtx
+		if !strings.Contains(strings.TrimPrefix(mentionedAccountName, "@"), "@") {
+			mentionCtx = ctx
+		}
+
+		gtsMention, err := p.parseMention(mentionCtx, mentionedAccountName, accountID, status.ID)
 		if err != nil {
+			if errors.Is(mentionCtx.Err(), context.DeadlineExceeded) {
+				logrus.Debugf("ProcessMentions: timed out resolving mention %s from status, will try again later", mentionedAccountName)
+				pending = append(pending, mentionedAccountName)
+				continue
+			}
 			logrus.Errorf("ProcessMentions: error parsing mention %s from status: %s", mentionedAccountName, err)
 			continue
 		}
@@ -216,6 +386,91 @@ func (p *processor) ProcessMentions(ctx context.Context, form *apimodel.Advanced
 	// add just the ids of the mentioned accounts to the status for putting in the db
 	status.MentionIDs = mentionIDs
 
+	return pending, nil
+}
+
+// PendingMentions is queued on the client API worker when some of the mentions
+// of a new status couldn't be resolved in time, so that they can be resolved in the background.
+type PendingMentions struct {
+	StatusID string                             // ID of the new status
+	Form     *apimodel.AdvancedStatusCreateForm // form the status was created from
+	TooNew   bool                               // whether the author is too new to mention accounts that didn't initiate contact
+	Mentions []*gtsmodel.Mention                // mentions the status was created with
+	Tags     []*gtsmodel.Tag                    // tags the status was created with
+	Names    []string                           // names of the mentioned accounts which still need resolving
+}
+
+func (p *processor) ResolvePendingMentions(ctx context.Context, account *gtsmodel.Account, pending *PendingMentions) error {
+	ctx, cancel := context.WithTimeout(ctx, pendingMentionResolveTimeout)
+	defer cancel()
+
+	statusID := pending.StatusID
+	resolved := []*gtsmodel.Mention{}
+	for _, mentionedAccountName := range pending.Names {
+		gtsMention, err := p.parseMention(ctx, mentionedAccountName, account.ID, statusID)
+		if err != nil {
+			logrus.Errorf("ResolvePendingMentions: error parsing mention %s from status %s: %s", mentionedAccountName, statusID, err)
+			continue
+		}
+
+		if pending.TooNew {
+			if initiated, err := p.contactInitiated(ctx, account, gtsMention.TargetAccountID); err != nil {
+				logrus.Errorf("ResolvePendingMentions: error checking contact with mentioned account %s: %s", gtsMention.TargetAccountID, err)
+				continue
+			} else if !initiated {
+				logrus.Debugf("ResolvePendingMentions: dropping mention %s from too new account %s", mentionedAccountName, account.ID)
+				continue
+			}
+		}
+
+		if err := p.db.Put(ctx, gtsMention); err != nil {
+			logrus.Errorf("ResolvePendingMentions: error putting mention in db: %s", err)
+			continue
+		}
+
+		resolved = append(resolved, gtsMention)
+	}
+
+	if len(resolved) == 0 {
+		return nil
+	}
+
+	// get a fresh copy of the status to work on, since the
+	// one we were created with may still be in use elsewhere
+	status, err := p.db.GetStatusByID(ctx, statusID)
+	if err != nil {
+		return fmt.Errorf("ResolvePendingMentions: error getting status %s: %s", statusID, err)
+	}
+
+	status.Mentions = append(append([]*gtsmodel.Mention{}, pending.Mentions...), resolved...)
+	status.MentionIDs = make([]string, 0, len(status.Mentions))
+	for _, m := range status.Mentions {
+		status.MentionIDs = append(status.MentionIDs, m.ID)
+	}
+	status.Tags = pending.Tags
+
+	// format the content again so that the new mentions are linked
+	if err := p.ProcessContent(ctx, pending.Form, account.ID, status); err != nil {
+		return fmt.Errorf("ResolvePendingMentions: error processing content of status %s: %s", statusID, err)
+	}
+	status.UpdatedAt = time.Now()
+
+	if err := p.db.UpdateStatus(ctx, status); err != nil {
+		return fmt.Errorf("ResolvePendingMentions: error updating status %s: %s", statusID, err)
+	}
+
+	// held statuses are only sent out once they're approved, with all their mentions
+	if !status.HeldAt.IsZero() {
+		return nil
+	}
+
+	// send it back to the processor so the newly mentioned accounts get the status
+	p.clientWorker.Queue(messages.FromClientAPI{
+		APObjectType:   ap.ObjectNote,
+		APActivityType: ap.ActivityUpdate,
+		GTSModel:       status,
+		OriginAccount:  account,
+	})
 	return nil
 }
 
@@ -258,31 +513,184 @@ func (p *processor) ProcessEmojis(ctx context.Context, form *apimodel.AdvancedSt
 }
 
 func (p *processor) ProcessContent(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, accountID string, status *gtsmodel.Status) error {
-	// if there's nothing in the status at all we can just return early
-	if form.Status == "" {
-		status.Content = ""
+	var formatted string
+
+	// if there's nothing in the status at all we don't need to format anything
+	if form.Status != "" {
+		// if format wasn't specified we should set the default
+		if form.Format == "" {
+			form.Format = apimodel.StatusFormatDefault
+		}
+
+		// remove any existing html from the status
+		content := text.RemoveHTML(form.Status)
+
+		// parse content out of the status depending on what format has been submitted
+		switch form.Format {
+		case apimodel.StatusFormatPlain:
+			formatted = p.formatter.FromPlain(ctx, content, status.Mentions, status.Tags)
+		case apimodel.StatusFormatMarkdown:
+			formatted = p.formatter.FromMarkdown(ctx, content, status.Mentions, status.Tags)
+		default:
+			return fmt.Errorf("format %s not recognised as a valid status format", form.Format)
+		}
+	}
+
+	// link the quoted status at the end, for software that doesn't understand quotes
+	if status.QuoteOfID != "" {
+		if status.QuoteOf == nil {
+			quotedStatus, err := p.db.GetStatusByID(ctx, status.QuoteOfID)
+			if err != nil {
+				return fmt.Errorf("error getting quoted status %s: %s", status.QuoteOfID, err)
+			}
+			status.QuoteOf = quotedStatus
+		}
+		formatted += quoteInline(status.QuoteOf)
+	}
+
+	status.Content = formatted
+	return nil
+}
+
+// quoteInline returns the link to a quoted status that goes at the end of the content of a status quoting it.
+// It uses the same markup as the other software that does quotes, so that it can be hidden where the quote is shown.
+func quoteInline(quotedStatus *gtsmodel.Status) string {
+	link := quotedStatus.URL
+	if link == "" {
+		link = quotedStatus.URI
+	}
+	link = html.EscapeString(link)
+	return `<p class="quote-inline">RE: <a href="` + link + `">` + link + `</a></p>`
+}
+
+// accountTooNew returns true if the given local account is younger than the configured
+// minimum account age, and isn't exempt from it, so it can't post publicly or mention freely.
+func (p *processor) accountTooNew(ctx context.Context, account *gtsmodel.Account) (bool, error) {
+	user := &gtsmodel.User{}
+	if err := p.db.GetWhere(ctx, []db.Where{{Key: "account_id", Value: account.ID}}, user); err != nil {
+		return false, fmt.Errorf("accountTooNew: error getting user for account %s: %s", account.ID, err)
+	}
+	return util.AccountTooNew(account, user), nil
+}
+
+// checkNewAccountMentions returns an error if the given status, by an account which is too new to mention accounts
+// freely, mentions any account which didn't initiate contact with it. Mentions of the status are removed if so.
+func (p *processor) checkNewAccountMentions(ctx context.Context, account *gtsmodel.Account, status *gtsmodel.Status) gtserror.WithCode {
+	for _, mention := range status.Mentions {
+		initiated, err := p.contactInitiated(ctx, account, mention.TargetAccountID)
+		if err != nil {
+			return gtserror.NewErrorInternalError(fmt.Errorf("checkNewAccountMentions: error checking contact with mentioned account %s: %s", mention.TargetAccountID, err))
+		}
+		if initiated {
+			continue
+		}
+
+		// the mentions were already put in the database, but the status won't be, so clean them up
+		for _, m := range status.Mentions {
+			if err := p.db.DeleteByID(ctx, m.ID, &gtsmodel.Mention{}); err != nil {
+				logrus.Errorf("checkNewAccountMentions: error deleting mention %s: %s", m.ID, err)
+			}
+		}
+
+		minAge := viper.GetDuration(config.Keys.AccountsMinAge)
+		err = fmt.Errorf("checkNewAccountMentions: account is too new to mention account %s", mention.TargetAccountID)
+		return gtserror.NewErrorForbidden(err, fmt.Sprintf("accounts younger than %s can only mention accounts that follow them or have mentioned them", minAge))
+	}
+
+	return nil
+}
+
+// contactInitiated returns true if the account with the given ID initiated contact
+// with the given account, by following it or mentioning it.
+func (p *processor) contactInitiated(ctx context.Context, account *gtsmodel.Account, targetAccountID string) (bool, error) {
+	if targetAccountID == account.ID {
+		// accounts can always mention themselves
+		return true, nil
+	}
+
+	follows, err := p.db.IsFollowing(ctx, &gtsmodel.Account{ID: targetAccountID}, account)
+	if err != nil {
+		return false, err
+	}
+	if follows {
+		return true, nil
+	}
+
+	if err := p.db.GetWhere(ctx, []db.Where{
+		{Key: "origin_account_id", Value: targetAccountID},
+		{Key: "target_account_id", Value: account.ID},
+	}, &gtsmodel.Mention{}); err != nil {
+		if err == db.ErrNoEntries {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+// checkDuplicate returns an error if the given status text is the same as that of the previous status
+// of the given account, and that status was posted within the configured duplicate window.
+func (p *processor) checkDuplicate(ctx context.Context, account *gtsmodel.Account, form *apimodel.AdvancedStatusCreateForm) gtserror.WithCode {
+	window := viper.GetDuration(config.Keys.StatusesDuplicateWindow)
+	if window <= 0 || form.AllowDuplicate || len(form.MediaIDs) != 0 {
+		// media is always freshly uploaded, so statuses with media are never duplicates
 		return nil
 	}
 
-	// if format wasn't specified we should set the default
-	if form.Format == "" {
-		form.Format = apimodel.StatusFormatDefault
+	normalized := normalizeForDuplicate(form.Status)
+	if normalized == "" {
+		return nil
 	}
 
-	// remove any existing html from the status
-	content := text.RemoveHTML(form.Status)
+	statuses, err := p.db.GetAccountStatuses(ctx, account.ID, 1, false, true, "", "", false, false, false, false)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil
+		}
+		return gtserror.NewErrorInternalError(fmt.Errorf("checkDuplicate: error getting previous status of account %s: %s", account.ID, err))
+	}
 
-	// parse content out of the status depending on what format has been submitted
-	var formatted string
-	switch form.Format {
-	case apimodel.StatusFormatPlain:
-		formatted = p.formatter.FromPlain(ctx, content, status.Mentions, status.Tags)
-	case apimodel.StatusFormatMarkdown:
-		formatted = p.formatter.FromMarkdown(ctx, content, status.Mentions, status.Tags)
-	default:
-		return fmt.Errorf("format %s not recognised as a valid status format", form.Format)
+	if len(statuses) == 0 || time.Since(statuses[0].CreatedAt) > window {
+		return nil
 	}
 
-	status.Content = formatted
+	if normalizeForDuplicate(statuses[0].Text) == normalized {
+		err := fmt.Errorf("checkDuplicate: status is a duplicate of status %s", statuses[0].ID)
+		return gtserror.WithErrorCode(gtserror.NewErrorUnprocessableEntity(err, "duplicate status"), gtserror.CodeDuplicateStatus)
+	}
+
+	return nil
+}
+
+// normalizeForDuplicate strips mentions and whitespace from the given status text, so that
+// statuses which only differ in who they mention, or in their spacing, compare as equal.
+func normalizeForDuplicate(statusText string) string {
+	statusText = regexes.MentionFinder.ReplaceAllString(statusText, "")
+	return strings.Join(strings.Fields(statusText), "")
+}
+
+// checkModerationRules checks the text and content warning in the given form against the instance's moderation rules. It returns
+// an error if the status should be rejected, and marks the given status as held if it should be held for review by an admin.
+func (p *processor) checkModerationRules(ctx context.Context, form *apimodel.AdvancedStatusCreateForm, status *gtsmodel.Status) gtserror.WithCode {
+	var spoilerText string
+	if form.SpoilerText != nil {
+		spoilerText = *form.SpoilerText
+	}
+
+	rule, err := p.moderationRules.Match(ctx, form.Status, spoilerText)
+	if err != nil {
+		return gtserror.NewErrorInternalError(fmt.Errorf("checkModerationRules: error matching moderation rules: %s", err))
+	}
+	if rule == nil {
+		return nil
+	}
+
+	if rule.LocalAction == gtsmodel.ModerationRuleLocalReject {
+		err := fmt.Errorf("checkModerationRules: status matches moderation rule %s", rule.ID)
+		return gtserror.NewErrorUnprocessableEntity(err, "status rejected: it contains content which isn't allowed on this instance")
+	}
+
+	status.ModerationRuleID = rule.ID
+	status.HeldAt = time.Now()
 	return nil
 }
@@ -23,9 +23,11 @@ import (
 	"fmt"
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
@@ -59,8 +61,8 @@ func (suite *UtilTestSuite) TestProcessMentions1() {
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
-			SpoilerText: "",
+			Sensitive:   nil,
+			SpoilerText: nil,
 			Visibility:  model.VisibilityPublic,
 			ScheduledAt: "",
 			Language:    "en",
@@ -78,8 +80,9 @@ func (suite *UtilTestSuite) TestProcessMentions1() {
 		ID: "01FCTDD78JJMX3K9KPXQ7ZQ8BJ",
 	}
 
-	err := suite.status.ProcessMentions(context.Background(), form, creatingAccount.ID, status)
+	pending, err := suite.status.ProcessMentions(context.Background(), form, creatingAccount.ID, status)
 	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), pending)
 
 	assert.Len(suite.T(), status.Mentions, 1)
 	newMention := status.Mentions[0]
@@ -109,8 +112,8 @@ func (suite *UtilTestSuite) TestProcessContentFull1() {
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
-			SpoilerText: "",
+			Sensitive:   nil,
+			SpoilerText: nil,
 			Visibility:  model.VisibilityPublic,
 			ScheduledAt: "",
 			Language:    "en",
@@ -128,8 +131,9 @@ func (suite *UtilTestSuite) TestProcessContentFull1() {
 		ID: "01FCTDD78JJMX3K9KPXQ7ZQ8BJ",
 	}
 
-	err := suite.status.ProcessMentions(context.Background(), form, creatingAccount.ID, status)
+	pending, err := suite.status.ProcessMentions(context.Background(), form, creatingAccount.ID, status)
 	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), pending)
 	assert.Empty(suite.T(), status.Content) // shouldn't be set yet
 
 	err = suite.status.ProcessTags(context.Background(), form, creatingAccount.ID, status)
@@ -158,8 +162,8 @@ func (suite *UtilTestSuite) TestProcessContentPartial1() {
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
-			SpoilerText: "",
+			Sensitive:   nil,
+			SpoilerText: nil,
 			Visibility:  model.VisibilityPublic,
 			ScheduledAt: "",
 			Language:    "en",
@@ -177,8 +181,9 @@ func (suite *UtilTestSuite) TestProcessContentPartial1() {
 		ID: "01FCTDD78JJMX3K9KPXQ7ZQ8BJ",
 	}
 
-	err := suite.status.ProcessMentions(context.Background(), form, creatingAccount.ID, status)
+	pending, err := suite.status.ProcessMentions(context.Background(), form, creatingAccount.ID, status)
 	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), pending)
 	assert.Empty(suite.T(), status.Content) // shouldn't be set yet
 
 	/*
@@ -200,8 +205,8 @@ func (suite *UtilTestSuite) TestProcessMentions2() {
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
-			SpoilerText: "",
+			Sensitive:   nil,
+			SpoilerText: nil,
 			Visibility:  model.VisibilityPublic,
 			ScheduledAt: "",
 			Language:    "en",
@@ -219,8 +224,9 @@ func (suite *UtilTestSuite) TestProcessMentions2() {
 		ID: "01FCTDD78JJMX3K9KPXQ7ZQ8BJ",
 	}
 
-	err := suite.status.ProcessMentions(context.Background(), form, creatingAccount.ID, status)
+	pending, err := suite.status.ProcessMentions(context.Background(), form, creatingAccount.ID, status)
 	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), pending)
 
 	assert.Len(suite.T(), status.Mentions, 1)
 	newMention := status.Mentions[0]
@@ -250,8 +256,8 @@ func (suite *UtilTestSuite) TestProcessContentFull2() {
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
-			SpoilerText: "",
+			Sensitive:   nil,
+			SpoilerText: nil,
 			Visibility:  model.VisibilityPublic,
 			ScheduledAt: "",
 			Language:    "en",
@@ -269,8 +275,9 @@ func (suite *UtilTestSuite) TestProcessContentFull2() {
 		ID: "01FCTDD78JJMX3K9KPXQ7ZQ8BJ",
 	}
 
-	err := suite.status.ProcessMentions(context.Background(), form, creatingAccount.ID, status)
+	pending, err := suite.status.ProcessMentions(context.Background(), form, creatingAccount.ID, status)
 	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), pending)
 	assert.Empty(suite.T(), status.Content) // shouldn't be set yet
 
 	err = suite.status.ProcessTags(context.Background(), form, creatingAccount.ID, status)
@@ -300,8 +307,8 @@ func (suite *UtilTestSuite) TestProcessContentPartial2() {
 			MediaIDs:    []string{},
 			Poll:        nil,
 			InReplyToID: "",
-			Sensitive:   false,
-			SpoilerText: "",
+			Sensitive:   nil,
+			SpoilerText: nil,
 			Visibility:  model.VisibilityPublic,
 			ScheduledAt: "",
 			Language:    "en",
@@ -319,8 +326,9 @@ func (suite *UtilTestSuite) TestProcessContentPartial2() {
 		ID: "01FCTDD78JJMX3K9KPXQ7ZQ8BJ",
 	}
 
-	err := suite.status.ProcessMentions(context.Background(), form, creatingAccount.ID, status)
+	pending, err := suite.status.ProcessMentions(context.Background(), form, creatingAccount.ID, status)
 	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), pending)
 	assert.Empty(suite.T(), status.Content) // shouldn't be set yet
 
 	/*
@@ -334,6 +342,65 @@ func (suite *UtilTestSuite) TestProcessContentPartial2() {
 	// assert.Equal(suite.T(), statusText2ExpectedPartial, status.Content)
 }
 
+func (suite *UtilTestSuite) TestProcessVisibilityReplyInherit() {
+	viper.Set(config.Keys.StatusesReplyInheritVisibility, true)
+	defer viper.Set(config.Keys.StatusesReplyInheritVisibility, false)
+
+	for _, test := range []struct {
+		parentVis   gtsmodel.Visibility
+		accountVis  gtsmodel.Visibility
+		expectedVis gtsmodel.Visibility
+	}{
+		{gtsmodel.VisibilityPublic, gtsmodel.VisibilityPublic, gtsmodel.VisibilityPublic},
+		{gtsmodel.VisibilityUnlocked, gtsmodel.VisibilityPublic, gtsmodel.VisibilityUnlocked},
+		{gtsmodel.VisibilityFollowersOnly, gtsmodel.VisibilityPublic, gtsmodel.VisibilityFollowersOnly},
+		{gtsmodel.VisibilityMutualsOnly, gtsmodel.VisibilityPublic, gtsmodel.VisibilityMutualsOnly},
+		{gtsmodel.VisibilityDirect, gtsmodel.VisibilityPublic, gtsmodel.VisibilityDirect},
+		// the account default still applies if it's less visible than the parent
+		{gtsmodel.VisibilityPublic, gtsmodel.VisibilityFollowersOnly, gtsmodel.VisibilityFollowersOnly},
+		{gtsmodel.VisibilityUnlocked, "", gtsmodel.VisibilityUnlocked},
+	} {
+		form := &model.AdvancedStatusCreateForm{}
+		status := &gtsmodel.Status{
+			InReplyTo: &gtsmodel.Status{Visibility: test.parentVis},
+		}
+
+		err := suite.status.ProcessVisibility(context.Background(), form, test.accountVis, status)
+		suite.NoError(err)
+		suite.Equal(test.expectedVis, status.Visibility, "reply to %s status with account default %q", test.parentVis, test.accountVis)
+	}
+}
+
+func (suite *UtilTestSuite) TestProcessVisibilityReplyInheritExplicit() {
+	viper.Set(config.Keys.StatusesReplyInheritVisibility, true)
+	defer viper.Set(config.Keys.StatusesReplyInheritVisibility, false)
+
+	// an explicitly set visibility always wins
+	form := &model.AdvancedStatusCreateForm{
+		StatusCreateRequest: model.StatusCreateRequest{
+			Visibility: model.VisibilityPublic,
+		},
+	}
+	status := &gtsmodel.Status{
+		InReplyTo: &gtsmodel.Status{Visibility: gtsmodel.VisibilityDirect},
+	}
+
+	err := suite.status.ProcessVisibility(context.Background(), form, gtsmodel.VisibilityPublic, status)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.VisibilityPublic, status.Visibility)
+}
+
+func (suite *UtilTestSuite) TestProcessVisibilityReplyInheritDisabled() {
+	form := &model.AdvancedStatusCreateForm{}
+	status := &gtsmodel.Status{
+		InReplyTo: &gtsmodel.Status{Visibility: gtsmodel.VisibilityDirect},
+	}
+
+	err := suite.status.ProcessVisibility(context.Background(), form, gtsmodel.VisibilityPublic, status)
+	suite.NoError(err)
+	suite.Equal(gtsmodel.VisibilityPublic, status.Visibility)
+}
+
 func TestUtilTestSuite(t *testing.T) {
 	suite.Run(t, new(UtilTestSuite))
 }
@@ -43,7 +43,7 @@ const boostReinsertionDepth = 50
 // StatusGrabFunction returns a function that satisfies the GrabFunction interface in internal/timeline.
 func StatusGrabFunction(database db.DB) timeline.GrabFunction {
 	return func(ctx context.Context, timelineAccountID string, maxID string, sinceID string, minID string, limit int) ([]timeline.Timelineable, bool, error) {
-		statuses, err := database.GetHomeTimeline(ctx, timelineAccountID, maxID, sinceID, minID, limit, false)
+		statuses, err := database.GetHomeTimeline(ctx, timelineAccountID, maxID, sinceID, minID, limit, false, nil)
 		if err != nil {
 			if err == db.ErrNoEntries {
 				return nil, true, nil // we just don't have enough statuses left in the db so return stop = true
@@ -172,7 +172,13 @@ func (p *processor) packageStatusResponse(statuses []*apimodel.Status, path stri
 	return resp, nil
 }
 
-func (p *processor) HomeTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int, local bool) (*apimodel.StatusTimelineResponse, gtserror.WithCode) {
+func (p *processor) HomeTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int, local bool, languages *db.LanguageFilter) (*apimodel.StatusTimelineResponse, gtserror.WithCode) {
+	if languages != nil {
+		// the in-memory timelines don't know anything about languages,
+		// so go to the db for language-filtered home timelines instead
+		return p.languageHomeTimelineGet(ctx, authed, maxID, sinceID, minID, limit, local, languages)
+	}
+
 	preparedItems, err := p.statusTimelines.GetTimeline(ctx, authed.Account.ID, maxID, sinceID, minID, limit, local)
 	if err != nil {
 		return nil, gtserror.NewErrorInternalError(err)
@@ -196,8 +202,35 @@ func (p *processor) HomeTimelineGet(ctx context.Context, authed *oauth.Auth, max
 	return p.packageStatusResponse(statuses, "api/v1/timelines/home", statuses[len(preparedItems)-1].ID, statuses[0].ID, limit)
 }
 
-func (p *processor) PublicTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int, local bool) (*apimodel.StatusTimelineResponse, gtserror.WithCode) {
-	statuses, err := p.db.GetPublicTimeline(ctx, authed.Account.ID, maxID, sinceID, minID, limit, local)
+func (p *processor) languageHomeTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int, local bool, languages *db.LanguageFilter) (*apimodel.StatusTimelineResponse, gtserror.WithCode) {
+	statuses, err := p.db.GetHomeTimeline(ctx, authed.Account.ID, maxID, sinceID, minID, limit, local, languages)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			// there are just no entries left
+			return &apimodel.StatusTimelineResponse{
+				Statuses: []*apimodel.Status{},
+			}, nil
+		}
+		// there's an actual error
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	s, err := p.filterHomeStatuses(ctx, authed, statuses)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(err)
+	}
+
+	if len(s) == 0 {
+		return &apimodel.StatusTimelineResponse{
+			Statuses: []*apimodel.Status{},
+		}, nil
+	}
+
+	return p.packageStatusResponse(s, "api/v1/timelines/home", s[len(s)-1].ID, s[0].ID, limit)
+}
+
+func (p *processor) PublicTimelineGet(ctx context.Context, authed *oauth.Auth, maxID string, sinceID string, minID string, limit int, local bool, languages *db.LanguageFilter) (*apimodel.StatusTimelineResponse, gtserror.WithCode) {
+	statuses, err := p.db.GetPublicTimeline(ctx, authed.Account.ID, maxID, sinceID, minID, limit, local, languages)
 	if err != nil {
 		if err == db.ErrNoEntries {
 			// there are just no entries left
@@ -285,6 +318,32 @@ func (p *processor) filterPublicStatuses(ctx context.Context, authed *oauth.Auth
 	return apiStatuses, nil
 }
 
+func (p *processor) filterHomeStatuses(ctx context.Context, authed *oauth.Auth, statuses []*gtsmodel.Status) ([]*apimodel.Status, error) {
+	l := logrus.WithField("func", "filterHomeStatuses")
+
+	apiStatuses := []*apimodel.Status{}
+	for _, s := range statuses {
+		timelineable, err := p.filter.StatusHometimelineable(ctx, s, authed.Account)
+		if err != nil {
+			l.Debugf("filterHomeStatuses: skipping status %s because of an error checking status visibility: %s", s.ID, err)
+			continue
+		}
+		if !timelineable {
+			continue
+		}
+
+		apiStatus, err := p.tc.StatusToAPIStatus(ctx, s, authed.Account)
+		if err != nil {
+			l.Debugf("filterHomeStatuses: skipping status %s because it couldn't be converted to its api representation: %s", s.ID, err)
+			continue
+		}
+
+		apiStatuses = append(apiStatuses, apiStatus)
+	}
+
+	return apiStatuses, nil
+}
+
 func (p *processor) filterFavedStatuses(ctx context.Context, authed *oauth.Auth, statuses []*gtsmodel.Status) ([]*apimodel.Status, error) {
 	l := logrus.WithField("func", "filterFavedStatuses")
 
@@ -0,0 +1,60 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type StatusTimelineTestSuite struct {
+	ProcessingStandardTestSuite
+}
+
+func (suite *StatusTimelineTestSuite) TestHomeTimelineGetLanguages() {
+	authed := suite.testAutheds["local_account_1"]
+
+	resp, errWithCode := suite.processor.HomeTimelineGet(context.Background(), authed, "", "", "", 20, false, nil)
+	suite.NoError(errWithCode)
+	suite.NotEmpty(resp.Statuses)
+
+	german := resp.Statuses[0]
+	suite.NoError(suite.db.UpdateWhere(context.Background(), []db.Where{{Key: "id", Value: german.ID}}, "language", "de", &gtsmodel.Status{}))
+
+	resp, errWithCode = suite.processor.HomeTimelineGet(context.Background(), authed, "", "", "", 20, false, &db.LanguageFilter{Languages: []string{"de"}})
+	suite.NoError(errWithCode)
+	if suite.Len(resp.Statuses, 1) {
+		suite.Equal(german.ID, resp.Statuses[0].ID)
+		suite.Equal("de", resp.Statuses[0].Language)
+	}
+
+	resp, errWithCode = suite.processor.HomeTimelineGet(context.Background(), authed, "", "", "", 20, false, &db.LanguageFilter{Languages: []string{"en"}})
+	suite.NoError(errWithCode)
+	for _, s := range resp.Statuses {
+		suite.NotEqual(german.ID, s.ID)
+	}
+}
+
+func TestStatusTimelineTestSuite(t *testing.T) {
+	suite.Run(t, &StatusTimelineTestSuite{})
+}
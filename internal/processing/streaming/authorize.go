@@ -23,6 +23,7 @@ import (
 	"fmt"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
 
 func (p *processor) AuthorizeStreamingRequest(ctx context.Context, accessToken string) (*gtsmodel.Account, error) {
@@ -31,6 +32,10 @@ func (p *processor) AuthorizeStreamingRequest(ctx context.Context, accessToken s
 		return nil, fmt.Errorf("AuthorizeStreamingRequest: error loading access token: %s", err)
 	}
 
+	if !oauth.HasScope(ti.GetScope(), oauth.ScopeReadStreaming) {
+		return nil, fmt.Errorf("AuthorizeStreamingRequest: token does not have required scope %s", oauth.ScopeReadStreaming)
+	}
+
 	uid := ti.GetUserID()
 	if uid == "" {
 		return nil, fmt.Errorf("AuthorizeStreamingRequest: no userid in token")
@@ -0,0 +1,96 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+)
+
+const (
+	// suggestionsMax is the maximum number of suggestions worked out, and cached, for each account.
+	suggestionsMax = 80
+	// suggestionsCacheTTL is how long an account's suggestions are cached for before they're worked out again,
+	// since counting the follows of every account an account follows is quite an expensive query.
+	suggestionsCacheTTL = 30 * time.Minute
+)
+
+func (p *processor) SuggestionsGet(ctx context.Context, authed *oauth.Auth, limit int) ([]apimodel.Account, gtserror.WithCode) {
+	accounts := []apimodel.Account{}
+	if !viper.GetBool(config.Keys.AccountsSuggestionsEnabled) {
+		return accounts, nil
+	}
+
+	var suggestedIDs []string
+	if cached, ok := p.suggestionsCache.Get(authed.Account.ID); ok {
+		suggestedIDs = cached.([]string)
+	} else {
+		var err error
+		suggestedIDs, err = p.db.GetAccountFollowSuggestions(ctx, authed.Account.ID, suggestionsMax)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("SuggestionsGet: db error getting suggestions: %s", err))
+		}
+		p.suggestionsCache.Set(authed.Account.ID, suggestedIDs)
+	}
+
+	for _, suggestedID := range suggestedIDs {
+		if len(accounts) >= limit {
+			break
+		}
+
+		// cached suggestions may be out of date, so check the
+		// account hasn't been followed, blocked or suspended since
+		suggested, err := p.db.GetAccountByID(ctx, suggestedID)
+		if err != nil {
+			if err == db.ErrNoEntries {
+				continue
+			}
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("SuggestionsGet: db error getting account %s: %s", suggestedID, err))
+		}
+		if !suggested.SuspendedAt.IsZero() {
+			continue
+		}
+
+		relationship, err := p.db.GetRelationship(ctx, authed.Account.ID, suggestedID)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("SuggestionsGet: db error getting relationship with %s: %s", suggestedID, err))
+		}
+		if relationship.Following || relationship.Requested || relationship.Blocking || relationship.BlockedBy {
+			continue
+		}
+
+		apiAccount, err := p.tc.AccountToAPIAccountPublic(ctx, suggested)
+		if err != nil {
+			logrus.Errorf("SuggestionsGet: error converting account %s: %s", suggestedID, err)
+			continue
+		}
+		accounts = append(accounts, *apiAccount)
+	}
+
+	return accounts, nil
+}
@@ -0,0 +1,167 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/id"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/regexes"
+)
+
+func (p *processor) TagGet(ctx context.Context, authed *oauth.Auth, name string) (*apimodel.Tag, gtserror.WithCode) {
+	tag, errWithCode := p.getListableTag(ctx, name)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	following, err := p.db.IsFollowingTag(ctx, authed.Account.ID, tag.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("TagGet: db error checking follow of tag %s: %s", tag.ID, err))
+	}
+
+	return p.apiTag(ctx, tag, following)
+}
+
+func (p *processor) TagFollow(ctx context.Context, authed *oauth.Auth, name string) (*apimodel.Tag, gtserror.WithCode) {
+	if !regexes.HashtagName.MatchString(name) {
+		err := fmt.Errorf("%s is not a valid hashtag", name)
+		return nil, gtserror.NewErrorBadRequest(err, err.Error())
+	}
+
+	tag, err := p.db.GetTagByName(ctx, name)
+	if err != nil {
+		if err != db.ErrNoEntries {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("TagFollow: db error getting tag %s: %s", name, err))
+		}
+
+		// nobody has used this tag yet, but it's fine to follow it in case someone does
+		tags, err := p.db.TagStringsToTags(ctx, []string{name}, authed.Account.ID)
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("TagFollow: error creating tag %s: %s", name, err))
+		}
+		if len(tags) != 1 {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("TagFollow: expected 1 tag to be created for %s, got %d", name, len(tags)))
+		}
+		tag = tags[0]
+
+		if err := p.db.Put(ctx, tag); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("TagFollow: db error putting tag %s: %s", name, err))
+		}
+	}
+
+	if !tag.Listable || !tag.Useable {
+		return nil, gtserror.NewErrorNotFound(errors.New("tag is not listable"))
+	}
+
+	following, err := p.db.IsFollowingTag(ctx, authed.Account.ID, tag.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("TagFollow: db error checking follow of tag %s: %s", tag.ID, err))
+	}
+
+	if !following {
+		followedTagID, err := id.NewULID()
+		if err != nil {
+			return nil, gtserror.NewErrorInternalError(err)
+		}
+
+		if err := p.db.Put(ctx, &gtsmodel.FollowedTag{
+			ID:        followedTagID,
+			AccountID: authed.Account.ID,
+			TagID:     tag.ID,
+		}); err != nil {
+			return nil, gtserror.NewErrorInternalError(fmt.Errorf("TagFollow: db error following tag %s: %s", tag.ID, err))
+		}
+	}
+
+	return p.apiTag(ctx, tag, true)
+}
+
+func (p *processor) TagUnfollow(ctx context.Context, authed *oauth.Auth, name string) (*apimodel.Tag, gtserror.WithCode) {
+	tag, errWithCode := p.getListableTag(ctx, name)
+	if errWithCode != nil {
+		return nil, errWithCode
+	}
+
+	if err := p.db.DeleteWhere(ctx, []db.Where{
+		{Key: "account_id", Value: authed.Account.ID},
+		{Key: "tag_id", Value: tag.ID},
+	}, &[]*gtsmodel.FollowedTag{}); err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("TagUnfollow: db error unfollowing tag %s: %s", tag.ID, err))
+	}
+
+	return p.apiTag(ctx, tag, false)
+}
+
+func (p *processor) FollowedTagsGet(ctx context.Context, authed *oauth.Auth) ([]apimodel.Tag, gtserror.WithCode) {
+	followedTags, err := p.db.GetFollowedTags(ctx, authed.Account.ID)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("FollowedTagsGet: db error getting followed tags: %s", err))
+	}
+
+	apiTags := make([]apimodel.Tag, 0, len(followedTags))
+	for _, followedTag := range followedTags {
+		if followedTag.Tag == nil {
+			// tag was deleted in the meantime
+			continue
+		}
+
+		apiTag, errWithCode := p.apiTag(ctx, followedTag.Tag, true)
+		if errWithCode != nil {
+			return nil, errWithCode
+		}
+		apiTags = append(apiTags, *apiTag)
+	}
+
+	return apiTags, nil
+}
+
+// getListableTag returns the tag with the given name, or a 404 if there's no such tag or it's not listable.
+func (p *processor) getListableTag(ctx context.Context, name string) (*gtsmodel.Tag, gtserror.WithCode) {
+	tag, err := p.db.GetTagByName(ctx, name)
+	if err != nil {
+		if err == db.ErrNoEntries {
+			return nil, gtserror.NewErrorNotFound(err)
+		}
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("getListableTag: db error getting tag %s: %s", name, err))
+	}
+
+	if !tag.Listable {
+		return nil, gtserror.NewErrorNotFound(errors.New("tag is not listable"))
+	}
+
+	return tag, nil
+}
+
+// apiTag converts the given tag to its api representation, noting whether the requesting account follows it.
+func (p *processor) apiTag(ctx context.Context, tag *gtsmodel.Tag, following bool) (*apimodel.Tag, gtserror.WithCode) {
+	apiTag, err := p.tc.TagToAPITag(ctx, tag)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("apiTag: error converting tag %s: %s", tag.ID, err))
+	}
+	apiTag.Following = &following
+	return &apiTag, nil
+}
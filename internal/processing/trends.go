@@ -0,0 +1,63 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package processing
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	apimodel "github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
+)
+
+const (
+	// trendsWithinHours is how far back we look at tag usage when working out which tags are trending.
+	trendsWithinHours = 7 * 24
+	// trendsCacheTTL is how long trending tags are cached for before they're worked out again,
+	// since counting tag usage across all recent statuses is quite an expensive query.
+	trendsCacheTTL = 15 * time.Minute
+)
+
+func (p *processor) TrendingTagsGet(ctx context.Context, limit int) ([]apimodel.Tag, gtserror.WithCode) {
+	cacheKey := strconv.Itoa(limit)
+	if cached, ok := p.trendsCache.Get(cacheKey); ok {
+		return cached.([]apimodel.Tag), nil
+	}
+
+	trending, err := p.db.GetTrendingTags(ctx, trendsWithinHours, limit)
+	if err != nil {
+		return nil, gtserror.NewErrorInternalError(fmt.Errorf("TrendingTagsGet: db error getting trending tags: %s", err))
+	}
+
+	apiTags := make([]apimodel.Tag, 0, len(trending))
+	for _, t := range trending {
+		apiTag, err := p.tc.TrendingTagToAPITag(ctx, t)
+		if err != nil {
+			logrus.Errorf("TrendingTagsGet: error converting tag %s: %s", t.Tag.ID, err)
+			continue
+		}
+		apiTags = append(apiTags, apiTag)
+	}
+
+	p.trendsCache.Set(cacheKey, apiTags)
+	return apiTags, nil
+}
@@ -21,6 +21,8 @@ package user
 import (
 	"context"
 
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 	"github.com/superseriousbusiness/gotosocial/internal/validate"
@@ -36,7 +38,7 @@ func (p *processor) ChangePassword(ctx context.Context, user *gtsmodel.User, old
 		return gtserror.NewErrorBadRequest(err, err.Error())
 	}
 
-	newPasswordHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
+	newPasswordHash, err := bcrypt.GenerateFromPassword([]byte(newPassword), viper.GetInt(config.Keys.AccountsPasswordBcryptCost))
 	if err != nil {
 		return gtserror.NewErrorInternalError(err, "error hashing password")
 	}
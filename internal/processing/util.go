@@ -115,7 +115,7 @@ func GetParseMentionFunc(dbConn db.DB, federator federation.Federator) gtsmodel.
 					fingeringUsername = originAccount.Username
 				}
 
-				acctURI, err := federator.FingerRemoteAccount(ctx, fingeringUsername, username, domain)
+				acctURI, err := federator.FingerRemoteAccount(ctx, username, domain)
 				if err != nil {
 					// something went wrong doing the webfinger lookup so we can't process the request
 					return nil, fmt.Errorf("error fingering remote account with username %s and domain %s: %s", username, domain, err)
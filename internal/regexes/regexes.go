@@ -77,6 +77,9 @@ var (
 	// It returns just the string part of the hashtag, not the # symbol.
 	HashtagFinder = regexp.MustCompile(hashtagFinder)
 
+	// HashtagName validates the name of a hashtag, without the # symbol.
+	HashtagName = regexp.MustCompile(fmt.Sprintf(`^[a-zA-Z0-9]{1,%d}$`, maximumHashtagLength))
+
 	emojiShortcode = fmt.Sprintf(`\w{2,%d}`, maximumEmojiShortcodeLength)
 	// EmojiShortcode validates an emoji name.
 	EmojiShortcode = regexp.MustCompile(fmt.Sprintf("^%s$", emojiShortcode))
@@ -96,7 +99,8 @@ var (
 	UserPath = regexp.MustCompile(userPathString)
 
 	publicKeyPath = fmt.Sprintf(`^?/%s/(%s)/%s`, users, usernameString, publicKey)
-	// PublicKeyPath parses a path that validates and captures the username part from eg /users/example_username/main-key
+	// PublicKeyPath parses a path that validates and captures the username part from eg /users/example_username/main-key,
+	// or from the path of a rotated key, eg /users/example_username/main-key/01F7XTH1QGBAPMGF49WJZ91XGC
 	PublicKeyPath = regexp.MustCompile(publicKeyPath)
 
 	inboxPath = fmt.Sprintf(`^/?%s/(%s)/%s$`, users, usernameString, inbox)
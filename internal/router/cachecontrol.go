@@ -0,0 +1,81 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// hashedAssetRegex matches filenames which contain a content hash before their extension,
+// eg., "bundle.3f9a1c2b.js" or "fork-awesome-5eb8a3f1.woff2". The content behind such a
+// filename never changes, since changing the content would also change the filename.
+var hashedAssetRegex = regexp.MustCompile(`[.-][0-9a-fA-F]{8,}\.[0-9a-zA-Z]+$`)
+
+// CacheControl returns a Cache-Control header value allowing the response to be cached for
+// maxAge, or requiring that it's revalidated every time if maxAge is 0 or less.
+func CacheControl(maxAge time.Duration, immutable bool) string {
+	if maxAge <= 0 {
+		return "no-cache"
+	}
+	value := fmt.Sprintf("max-age=%d", int(maxAge.Seconds()))
+	if immutable {
+		value += ", immutable"
+	}
+	return value
+}
+
+// AssetCacheControl returns a middleware for static asset handlers, which sets Cache-Control headers configured by the
+// web asset cache settings in the viper config store on successful responses. Assets with a content hash in their
+// filename are given a longer max age, and marked immutable.
+func AssetCacheControl() gin.HandlerFunc {
+	value := CacheControl(viper.GetDuration(config.Keys.WebAssetCacheMaxAge), false)
+	hashedValue := CacheControl(viper.GetDuration(config.Keys.WebAssetHashedCacheMaxAge), true)
+
+	return func(c *gin.Context) {
+		v := value
+		if hashedAssetRegex.MatchString(c.Request.URL.Path) {
+			v = hashedValue
+		}
+
+		c.Writer = &cacheControlWriter{ResponseWriter: c.Writer, value: v}
+		c.Next()
+	}
+}
+
+// cacheControlWriter sets a Cache-Control header just before the response headers are
+// written, as long as the response is a successful one, so that errors aren't cached.
+type cacheControlWriter struct {
+	gin.ResponseWriter
+	value string
+}
+
+func (w *cacheControlWriter) WriteHeader(code int) {
+	switch code {
+	case http.StatusOK, http.StatusPartialContent, http.StatusNotModified:
+		w.Header().Set("Cache-Control", w.value)
+	}
+	w.ResponseWriter.WriteHeader(code)
+}
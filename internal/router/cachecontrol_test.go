@@ -0,0 +1,95 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package router_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/router"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type CacheControlTestSuite struct {
+	suite.Suite
+	dir string
+}
+
+func (suite *CacheControlTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+
+	suite.dir = suite.T().TempDir()
+	for _, name := range []string{"base.css", "bundle.3f9a1c2b.js"} {
+		suite.NoError(os.WriteFile(filepath.Join(suite.dir, name), []byte("some asset"), 0600))
+	}
+}
+
+// request gets the given path from an engine serving the test assets with the asset cache control middleware.
+func (suite *CacheControlTestSuite) request(path string) *httptest.ResponseRecorder {
+	engine := gin.New()
+	engine.Group("", router.AssetCacheControl()).StaticFS("/assets", http.Dir(suite.dir))
+
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://localhost:8080"+path, nil))
+	return recorder
+}
+
+func (suite *CacheControlTestSuite) TestAsset() {
+	recorder := suite.request("/assets/base.css")
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("max-age=3600", recorder.Header().Get("Cache-Control"))
+}
+
+func (suite *CacheControlTestSuite) TestHashedAsset() {
+	recorder := suite.request("/assets/bundle.3f9a1c2b.js")
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("max-age=31536000, immutable", recorder.Header().Get("Cache-Control"))
+}
+
+func (suite *CacheControlTestSuite) TestAssetNoCache() {
+	viper.Set(config.Keys.WebAssetCacheMaxAge, 0)
+
+	recorder := suite.request("/assets/base.css")
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("no-cache", recorder.Header().Get("Cache-Control"))
+}
+
+func (suite *CacheControlTestSuite) TestMissingAsset() {
+	recorder := suite.request("/assets/missing.css")
+	suite.Equal(http.StatusNotFound, recorder.Code)
+	suite.Empty(recorder.Header().Get("Cache-Control"))
+}
+
+func (suite *CacheControlTestSuite) TestCacheControl() {
+	suite.Equal("max-age=86400", router.CacheControl(24*time.Hour, false))
+	suite.Equal("max-age=60, immutable", router.CacheControl(time.Minute, true))
+	suite.Equal("no-cache", router.CacheControl(0, true))
+}
+
+func TestCacheControlTestSuite(t *testing.T) {
+	suite.Run(t, new(CacheControlTestSuite))
+}
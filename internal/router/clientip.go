@@ -0,0 +1,69 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package router
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// UseClientIP sets up the given gin engine to take the real IP of clients from the configured
+// trusted proxy headers, but only for requests that come from one of the configured trusted proxies.
+// For requests that don't, or when no headers are configured, the remote address of the request is used.
+func UseClientIP(engine *gin.Engine) error {
+	keys := config.Keys
+
+	if err := engine.SetTrustedProxies(viper.GetStringSlice(keys.TrustedProxies)); err != nil {
+		return err
+	}
+
+	headers := []string{}
+	for _, header := range viper.GetStringSlice(keys.TrustedProxyHeaders) {
+		header = strings.TrimSpace(header)
+		if !validHeaderName(header) {
+			return fmt.Errorf("invalid value %q for %s: not a valid header name", header, keys.TrustedProxyHeaders)
+		}
+		headers = append(headers, http.CanonicalHeaderKey(header))
+	}
+
+	engine.ForwardedByClientIP = len(headers) != 0
+	engine.RemoteIPHeaders = headers
+	return nil
+}
+
+// validHeaderName returns true if the given string is a valid http header name, ie., a non-empty token.
+func validHeaderName(name string) bool {
+	if name == "" {
+		return false
+	}
+	for _, r := range name {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9':
+		case strings.ContainsRune("!#$%&'*+-.^_`|~", r):
+		default:
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,104 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package router_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/router"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type ClientIPTestSuite struct {
+	suite.Suite
+}
+
+func (suite *ClientIPTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+}
+
+// clientIP returns the client IP that an engine using the configured trusted proxy settings
+// sees for a request from the given remote address, with the given headers.
+func (suite *ClientIPTestSuite) clientIP(remoteAddr string, header http.Header) string {
+	engine := gin.New()
+	suite.NoError(router.UseClientIP(engine))
+
+	var clientIP string
+	engine.GET("/", func(c *gin.Context) {
+		clientIP = c.ClientIP()
+		c.Status(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "http://localhost:8080/", nil)
+	req.RemoteAddr = remoteAddr
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	engine.ServeHTTP(httptest.NewRecorder(), req)
+	return clientIP
+}
+
+func (suite *ClientIPTestSuite) TestForwardedForFromTrustedProxy() {
+	ip := suite.clientIP("127.0.0.1:12345", http.Header{"X-Forwarded-For": {"192.0.2.1"}})
+	suite.Equal("192.0.2.1", ip)
+}
+
+func (suite *ClientIPTestSuite) TestForwardedForFromUntrustedProxy() {
+	ip := suite.clientIP("198.51.100.1:12345", http.Header{"X-Forwarded-For": {"192.0.2.1"}})
+	suite.Equal("198.51.100.1", ip)
+}
+
+func (suite *ClientIPTestSuite) TestConfiguredHeader() {
+	viper.Set(config.Keys.TrustedProxyHeaders, []string{"cf-connecting-ip"})
+
+	// only the configured header should be used
+	ip := suite.clientIP("127.0.0.1:12345", http.Header{
+		"X-Forwarded-For":  {"192.0.2.1"},
+		"Cf-Connecting-Ip": {"192.0.2.2"},
+	})
+	suite.Equal("192.0.2.2", ip)
+
+	// and only from trusted proxies
+	ip = suite.clientIP("198.51.100.1:12345", http.Header{"Cf-Connecting-Ip": {"192.0.2.2"}})
+	suite.Equal("198.51.100.1", ip)
+}
+
+func (suite *ClientIPTestSuite) TestNoHeaders() {
+	viper.Set(config.Keys.TrustedProxyHeaders, []string{})
+
+	ip := suite.clientIP("127.0.0.1:12345", http.Header{"X-Forwarded-For": {"192.0.2.1"}})
+	suite.Equal("127.0.0.1", ip)
+}
+
+func (suite *ClientIPTestSuite) TestInvalidHeader() {
+	viper.Set(config.Keys.TrustedProxyHeaders, []string{"X-Real IP"})
+
+	err := router.UseClientIP(gin.New())
+	suite.EqualError(err, `invalid value "X-Real IP" for trusted-proxy-headers: not a valid header name`)
+}
+
+func TestClientIPTestSuite(t *testing.T) {
+	suite.Run(t, &ClientIPTestSuite{})
+}
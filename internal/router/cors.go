@@ -19,69 +19,140 @@
 package router
 
 import (
+	"fmt"
+	"net/url"
+	"strings"
 	"time"
 
 	"github.com/gin-contrib/cors"
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 )
 
-var corsConfig = cors.Config{
-	// TODO: make this customizable so instance admins can specify an origin for CORS requests
-	AllowAllOrigins: true,
-
-	// adds the following:
-	// 	"chrome-extension://"
-	// 	"safari-extension://"
-	// 	"moz-extension://"
-	// 	"ms-browser-extension://"
-	AllowBrowserExtensions: true,
-	AllowMethods: []string{
-		"POST",
-		"PUT",
-		"DELETE",
-		"GET",
-		"PATCH",
-		"OPTIONS",
-	},
-	AllowHeaders: []string{
-		// basic cors stuff
-		"Origin",
-		"Content-Length",
-		"Content-Type",
-
-		// needed to pass oauth bearer tokens
-		"Authorization",
-
-		// needed for websocket upgrade requests
-		"Upgrade",
-		"Sec-WebSocket-Extensions",
-		"Sec-WebSocket-Key",
-		"Sec-WebSocket-Protocol",
-		"Sec-WebSocket-Version",
-		"Connection",
-	},
-	AllowWebSockets: true,
-	ExposeHeaders: []string{
-		// needed for accessing next/prev links when making GET timeline requests
-		"Link",
-
-		// needed so clients can handle rate limits
-		"X-RateLimit-Reset",
-		"X-RateLimit-Limit",
-		"X-RateLimit-Remaining",
-		"X-Request-Id",
-
-		// websocket stuff
-		"Connection",
-		"Sec-WebSocket-Accept",
-		"Upgrade",
-	},
-	MaxAge: 2 * time.Minute,
+// websocketHeaders are always allowed in cors requests, so that
+// streaming connections can be upgraded no matter how cors is configured
+var websocketHeaders = []string{
+	"Upgrade",
+	"Sec-WebSocket-Extensions",
+	"Sec-WebSocket-Key",
+	"Sec-WebSocket-Protocol",
+	"Sec-WebSocket-Version",
+	"Connection",
 }
 
-// useCors attaches the corsConfig above to the given gin engine
+// corsConfig returns a cors config derived from the allowed origins, methods and headers in the viper config store.
+func corsConfig() (cors.Config, error) {
+	keys := config.Keys
+
+	corsConfig := cors.Config{
+		// adds the following:
+		// 	"chrome-extension://"
+		// 	"safari-extension://"
+		// 	"moz-extension://"
+		// 	"ms-browser-extension://"
+		AllowBrowserExtensions: true,
+		AllowMethods:           viper.GetStringSlice(keys.CORSAllowMethods),
+		AllowWebSockets:        true,
+		AllowWildcard:          true,
+		ExposeHeaders: []string{
+			// needed for accessing next/prev links when making GET timeline requests
+			"Link",
+
+			// needed so clients can handle rate limits
+			"X-RateLimit-Reset",
+			"X-RateLimit-Limit",
+			"X-RateLimit-Remaining",
+			"X-Request-Id",
+
+			// websocket stuff
+			"Connection",
+			"Sec-WebSocket-Accept",
+			"Upgrade",
+		},
+		MaxAge: 2 * time.Minute,
+	}
+
+	for _, origin := range viper.GetStringSlice(keys.CORSAllowOrigins) {
+		if origin == "*" {
+			corsConfig.AllowAllOrigins = true
+			corsConfig.AllowOrigins = nil
+			break
+		}
+		if err := validateOrigin(origin); err != nil {
+			return cors.Config{}, fmt.Errorf("invalid value %q for %s: %s", origin, keys.CORSAllowOrigins, err)
+		}
+		corsConfig.AllowOrigins = append(corsConfig.AllowOrigins, origin)
+	}
+
+	headers := viper.GetStringSlice(keys.CORSAllowHeaders)
+	for _, wsHeader := range websocketHeaders {
+		if !containsFold(headers, wsHeader) {
+			headers = append(headers, wsHeader)
+		}
+	}
+	corsConfig.AllowHeaders = headers
+
+	if err := corsConfig.Validate(); err != nil {
+		return cors.Config{}, err
+	}
+
+	return corsConfig, nil
+}
+
+// validateOrigin checks that the given origin is a scheme and a host, with an optional
+// port, and nothing else. The host may start with a wildcard label, eg., "https://*.example.org".
+func validateOrigin(origin string) error {
+	scheme, host, ok := strings.Cut(origin, "://")
+	if !ok || scheme == "" || host == "" {
+		return fmt.Errorf("origin must be of the form scheme://host")
+	}
+
+	if count := strings.Count(origin, "*"); count > 1 || (count == 1 && !strings.HasPrefix(host, "*.")) {
+		return fmt.Errorf("a wildcard may only be used as the first label of the host, eg., %s://*.example.org", scheme)
+	}
+
+	u, err := url.Parse(scheme + "://" + strings.Replace(host, "*", "wildcard", 1))
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case u.Host == "":
+		return fmt.Errorf("origin has no host")
+	case u.User != nil, u.Path != "", u.RawQuery != "", u.Fragment != "", strings.HasSuffix(origin, "?"), strings.HasSuffix(origin, "#"):
+		return fmt.Errorf("origin must not contain anything other than a scheme, host and port")
+	}
+
+	return nil
+}
+
+// containsFold returns true if the given slice contains the given string, ignoring case.
+func containsFold(slice []string, s string) bool {
+	for _, v := range slice {
+		if strings.EqualFold(v, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// CORS returns a cors middleware handler which uses the allowed origins, methods and headers
+// in the viper config store. It returns an error if any of the allowed origins aren't valid.
+func CORS() (gin.HandlerFunc, error) {
+	corsConfig, err := corsConfig()
+	if err != nil {
+		return nil, err
+	}
+	return cors.New(corsConfig), nil
+}
+
+// useCors attaches the cors middleware returned by CORS to the given gin engine
 func useCors(engine *gin.Engine) error {
-	c := cors.New(corsConfig)
+	c, err := CORS()
+	if err != nil {
+		return err
+	}
 	engine.Use(c)
 	return nil
 }
@@ -0,0 +1,137 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package router_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/router"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type CORSTestSuite struct {
+	suite.Suite
+}
+
+func (suite *CORSTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+}
+
+// request makes a request with the given method and origin to an engine using the configured cors middleware.
+func (suite *CORSTestSuite) request(method string, origin string, header http.Header) *httptest.ResponseRecorder {
+	c, err := router.CORS()
+	suite.NoError(err)
+
+	engine := gin.New()
+	engine.Use(c)
+	engine.Handle(method, "/api/v1/streaming", func(c *gin.Context) {
+		c.Status(http.StatusOK)
+	})
+
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(method, "http://localhost:8080/api/v1/streaming", nil)
+	for k, v := range header {
+		req.Header[k] = v
+	}
+	req.Header.Set("Origin", origin)
+	engine.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func (suite *CORSTestSuite) TestAllowAllOriginsByDefault() {
+	recorder := suite.request(http.MethodGet, "https://pinafore.social", nil)
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("*", recorder.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func (suite *CORSTestSuite) TestAllowedOrigin() {
+	viper.Set(config.Keys.CORSAllowOrigins, []string{"https://my.web.client", "https://*.example.org"})
+
+	recorder := suite.request(http.MethodGet, "https://my.web.client", nil)
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("https://my.web.client", recorder.Header().Get("Access-Control-Allow-Origin"))
+
+	recorder = suite.request(http.MethodGet, "https://client.example.org", nil)
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("https://client.example.org", recorder.Header().Get("Access-Control-Allow-Origin"))
+}
+
+func (suite *CORSTestSuite) TestDisallowedOrigin() {
+	viper.Set(config.Keys.CORSAllowOrigins, []string{"https://my.web.client"})
+
+	recorder := suite.request(http.MethodGet, "https://pinafore.social", nil)
+	suite.Equal(http.StatusForbidden, recorder.Code)
+}
+
+func (suite *CORSTestSuite) TestDisallowedOriginWebsocket() {
+	viper.Set(config.Keys.CORSAllowOrigins, []string{"https://my.web.client"})
+
+	// websocket upgrades to the streaming api must be subject to the same origin policy
+	recorder := suite.request(http.MethodGet, "https://pinafore.social", http.Header{
+		"Connection": {"Upgrade"},
+		"Upgrade":    {"websocket"},
+	})
+	suite.Equal(http.StatusForbidden, recorder.Code)
+}
+
+func (suite *CORSTestSuite) TestWebsocketHeadersAlwaysAllowed() {
+	viper.Set(config.Keys.CORSAllowHeaders, []string{"Authorization"})
+
+	recorder := suite.request(http.MethodOptions, "https://pinafore.social", http.Header{
+		"Access-Control-Request-Method": {http.MethodGet},
+	})
+	suite.Equal(http.StatusNoContent, recorder.Code)
+	suite.Contains(recorder.Header().Get("Access-Control-Allow-Headers"), "Authorization")
+	suite.Contains(recorder.Header().Get("Access-Control-Allow-Headers"), "Sec-Websocket-Key")
+}
+
+func (suite *CORSTestSuite) TestMethods() {
+	viper.Set(config.Keys.CORSAllowMethods, []string{"GET"})
+
+	recorder := suite.request(http.MethodOptions, "https://pinafore.social", http.Header{
+		"Access-Control-Request-Method": {http.MethodGet},
+	})
+	suite.Equal("GET", recorder.Header().Get("Access-Control-Allow-Methods"))
+}
+
+func (suite *CORSTestSuite) TestInvalidOrigins() {
+	for _, origin := range []string{
+		"my.web.client",
+		"https://my.web.client/some/path",
+		"https://my.web.client?query=true",
+		"https://*.*.example.org",
+		"https://example.*",
+		"ftp://my.web.client",
+		"https://",
+	} {
+		viper.Set(config.Keys.CORSAllowOrigins, []string{origin})
+		_, err := router.CORS()
+		suite.Error(err, origin)
+	}
+}
+
+func TestCORSTestSuite(t *testing.T) {
+	suite.Run(t, &CORSTestSuite{})
+}
@@ -19,12 +19,242 @@
 package router
 
 import (
-	ginGzip "github.com/gin-contrib/gzip"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
 	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 )
 
+// gzipper compresses http responses. Compared to just compressing
+// everything, it skips responses which are already compressed, which
+// have one of the excluded content types, or whose size is outside
+// of the configured thresholds, so that we don't waste cpu time.
+type gzipper struct {
+	excluded []string
+	minSize  int
+	maxSize  int
+	pool     sync.Pool
+}
+
+// Gzip returns a gzip compression middleware handler configured by the gzip settings in the viper config store.
+// It returns nil and no error if compression is disabled, or an error if the configured compression level is invalid.
+func Gzip() (gin.HandlerFunc, error) {
+	keys := config.Keys
+
+	level := viper.GetInt(keys.GzipLevel)
+	if level == 0 {
+		return nil, nil
+	}
+	if level < gzip.DefaultCompression || level > gzip.BestCompression {
+		return nil, fmt.Errorf("invalid value %d for %s: must be between %d and %d", level, keys.GzipLevel, gzip.DefaultCompression, gzip.BestCompression)
+	}
+
+	g := &gzipper{
+		minSize: viper.GetInt(keys.GzipMinSize),
+		maxSize: viper.GetInt(keys.GzipMaxSize),
+		pool: sync.Pool{
+			New: func() interface{} {
+				// we checked the level above so this can't fail
+				gz, _ := gzip.NewWriterLevel(io.Discard, level)
+				return gz
+			},
+		},
+	}
+	for _, ct := range viper.GetStringSlice(keys.GzipExcludedContentTypes) {
+		g.excluded = append(g.excluded, strings.ToLower(strings.TrimSpace(ct)))
+	}
+
+	return g.handle, nil
+}
+
+func (g *gzipper) handle(c *gin.Context) {
+	req := c.Request
+	if req.Method == http.MethodHead ||
+		!strings.Contains(req.Header.Get("Accept-Encoding"), "gzip") ||
+		strings.Contains(req.Header.Get("Connection"), "Upgrade") ||
+		strings.Contains(req.Header.Get("Accept"), "text/event-stream") {
+		return
+	}
+
+	c.Header("Vary", "Accept-Encoding")
+	w := &gzipWriter{ResponseWriter: c.Writer, g: g}
+	c.Writer = w
+	defer w.close()
+
+	c.Next()
+}
+
+// excludes returns true if the given content type is excluded from compression.
+func (g *gzipper) excludes(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+
+	for _, excluded := range g.excluded {
+		if excluded == mediaType {
+			return true
+		}
+		if prefix := strings.TrimSuffix(excluded, "*"); prefix != excluded && strings.HasPrefix(mediaType, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// gzipWriter wraps a gin.ResponseWriter, and decides whether to compress
+// the response once it knows enough about it to do so, ie., once either the
+// response size is known, or enough of the body has been written to tell
+// whether it's within the configured thresholds. Without a maximum size,
+// that's as soon as the minimum size has been written; with one, the body
+// is buffered until it either ends or turns out to be too large.
+type gzipWriter struct {
+	gin.ResponseWriter
+	g       *gzipper
+	buf     []byte       // start of the body, buffered until we've decided
+	decided bool         // whether we've decided to compress or not yet
+	gz      *gzip.Writer // set if we decided to compress
+}
+
+func (w *gzipWriter) Write(b []byte) (int, error) {
+	if w.decided {
+		if w.gz != nil {
+			return w.gz.Write(b)
+		}
+		return w.ResponseWriter.Write(b)
+	}
+
+	w.buf = append(w.buf, b...)
+	if w.Header().Get("Content-Length") == "" && !w.bufferedEnough() {
+		// too early to tell
+		return len(b), nil
+	}
+
+	if err := w.decide(false); err != nil {
+		return 0, err
+	}
+	return len(b), nil
+}
+
+// bufferedEnough returns true if enough of a response of unknown length
+// has been buffered to decide whether to compress it before it ends.
+func (w *gzipWriter) bufferedEnough() bool {
+	if w.g.maxSize > 0 {
+		return len(w.buf) > w.g.maxSize
+	}
+	return len(w.buf) >= w.g.minSize
+}
+
+func (w *gzipWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *gzipWriter) Written() bool {
+	return len(w.buf) > 0 || w.ResponseWriter.Written()
+}
+
+func (w *gzipWriter) WriteHeaderNow() {
+	if !w.decided {
+		_ = w.decide(false)
+	}
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *gzipWriter) Flush() {
+	if !w.decided {
+		_ = w.decide(false)
+	}
+	if w.gz != nil {
+		_ = w.gz.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// decide decides whether to compress the response, and writes out anything that was buffered so far.
+// Complete should be true if the whole response has been buffered, so that its size is known.
+func (w *gzipWriter) decide(complete bool) error {
+	w.decided = true
+	buf := w.buf
+	w.buf = nil
+
+	if w.shouldCompress(buf, complete) {
+		w.Header().Del("Content-Length")
+		w.Header().Set("Content-Encoding", "gzip")
+		w.gz = w.g.pool.Get().(*gzip.Writer)
+		w.gz.Reset(w.ResponseWriter)
+	}
+
+	if len(buf) == 0 {
+		return nil
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+func (w *gzipWriter) shouldCompress(buf []byte, complete bool) bool {
+	header := w.Header()
+
+	if header.Get("Content-Encoding") != "" {
+		// already compressed
+		return false
+	}
+
+	switch status := w.Status(); {
+	case status < http.StatusOK, status == http.StatusNoContent, status == http.StatusNotModified:
+		// no body to compress
+		return false
+	}
+
+	if contentLength := header.Get("Content-Length"); contentLength != "" {
+		size, err := strconv.Atoi(contentLength)
+		if err != nil || size < w.g.minSize || (w.g.maxSize > 0 && size > w.g.maxSize) {
+			return false
+		}
+	} else if len(buf) == 0 || len(buf) < w.g.minSize {
+		return false
+	} else if w.g.maxSize > 0 && (!complete || len(buf) > w.g.maxSize) {
+		// either too large, or streamed, so that we can't tell how large it'll end up
+		return false
+	}
+
+	contentType := header.Get("Content-Type")
+	if contentType == "" {
+		// sniff the content type now, since net/http can't once it's compressed
+		contentType = http.DetectContentType(buf)
+		header.Set("Content-Type", contentType)
+	}
+	return !w.g.excludes(contentType)
+}
+
+// close finishes the response, writing out anything still buffered.
+func (w *gzipWriter) close() {
+	if !w.decided {
+		_ = w.decide(true)
+	}
+	if w.gz != nil {
+		_ = w.gz.Close()
+		w.gz.Reset(io.Discard)
+		w.g.pool.Put(w.gz)
+		w.gz = nil
+	}
+}
+
+// useGzip attaches the gzip middleware returned by Gzip to the given gin engine, if gzip compression is enabled
 func useGzip(engine *gin.Engine) error {
-	gzipMiddleware := ginGzip.Gzip(ginGzip.DefaultCompression)
-	engine.Use(gzipMiddleware)
+	g, err := Gzip()
+	if err != nil {
+		return err
+	}
+	if g != nil {
+		engine.Use(g)
+	}
 	return nil
 }
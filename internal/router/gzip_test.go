@@ -0,0 +1,188 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package router_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/router"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type GzipTestSuite struct {
+	suite.Suite
+}
+
+func (suite *GzipTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+}
+
+// request gets a response from an engine using the configured gzip middleware, which serves the given handler.
+func (suite *GzipTestSuite) request(handler gin.HandlerFunc) *httptest.ResponseRecorder {
+	g, err := router.Gzip()
+	suite.NoError(err)
+
+	engine := gin.New()
+	if g != nil {
+		engine.Use(g)
+	}
+	engine.GET("/", handler)
+
+	recorder := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "http://localhost:8080/", nil)
+	req.Header.Set("Accept-Encoding", "gzip, deflate, br")
+	engine.ServeHTTP(recorder, req)
+	return recorder
+}
+
+func (suite *GzipTestSuite) gunzip(b []byte) string {
+	r, err := gzip.NewReader(bytes.NewReader(b))
+	suite.NoError(err)
+	uncompressed, err := io.ReadAll(r)
+	suite.NoError(err)
+	return string(uncompressed)
+}
+
+var bigJSON = `{"note":"` + strings.Repeat("a", 2048) + `"}`
+
+func (suite *GzipTestSuite) TestCompressJSON() {
+	recorder := suite.request(func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json; charset=utf-8", []byte(bigJSON))
+	})
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("gzip", recorder.Header().Get("Content-Encoding"))
+	suite.Equal("application/json; charset=utf-8", recorder.Header().Get("Content-Type"))
+	suite.Equal(bigJSON, suite.gunzip(recorder.Body.Bytes()))
+}
+
+func (suite *GzipTestSuite) TestCompressUnknownLength() {
+	recorder := suite.request(func(c *gin.Context) {
+		c.Header("Content-Type", "text/html")
+		c.Status(http.StatusOK)
+		for i := 0; i < 100; i++ {
+			_, _ = c.Writer.WriteString("<p>hello world</p>")
+		}
+	})
+	suite.Equal("gzip", recorder.Header().Get("Content-Encoding"))
+	suite.Equal(strings.Repeat("<p>hello world</p>", 100), suite.gunzip(recorder.Body.Bytes()))
+}
+
+func (suite *GzipTestSuite) TestDontCompressSmall() {
+	recorder := suite.request(func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"error": "not found"})
+	})
+	suite.Empty(recorder.Header().Get("Content-Encoding"))
+	suite.Equal(`{"error":"not found"}`, recorder.Body.String())
+}
+
+func (suite *GzipTestSuite) TestDontCompressLarge() {
+	viper.Set(config.Keys.GzipMaxSize, 1500)
+
+	recorder := suite.request(func(c *gin.Context) {
+		c.DataFromReader(http.StatusOK, int64(len(bigJSON)), "application/json", strings.NewReader(bigJSON), nil)
+	})
+	suite.Empty(recorder.Header().Get("Content-Encoding"))
+	suite.Equal(bigJSON, recorder.Body.String())
+}
+
+func (suite *GzipTestSuite) TestDontCompressLargeUnknownLength() {
+	viper.Set(config.Keys.GzipMaxSize, 1500)
+
+	recorder := suite.request(func(c *gin.Context) {
+		c.Header("Content-Type", "text/html")
+		c.Status(http.StatusOK)
+		for i := 0; i < 100; i++ {
+			_, _ = c.Writer.WriteString("<p>hello world</p>")
+		}
+	})
+	suite.Empty(recorder.Header().Get("Content-Encoding"))
+	suite.Equal(strings.Repeat("<p>hello world</p>", 100), recorder.Body.String())
+
+	// responses of unknown length within the limit are still compressed
+	recorder = suite.request(func(c *gin.Context) {
+		c.Header("Content-Type", "text/html")
+		c.Status(http.StatusOK)
+		for i := 0; i < 70; i++ {
+			_, _ = c.Writer.WriteString("<p>hello world</p>")
+		}
+	})
+	suite.Equal("gzip", recorder.Header().Get("Content-Encoding"))
+	suite.Equal(strings.Repeat("<p>hello world</p>", 70), suite.gunzip(recorder.Body.Bytes()))
+
+	// but streamed ones aren't, since we can't tell how large they'll get
+	recorder = suite.request(func(c *gin.Context) {
+		c.Header("Content-Type", "text/html")
+		c.Status(http.StatusOK)
+		for i := 0; i < 70; i++ {
+			_, _ = c.Writer.WriteString("<p>hello world</p>")
+			c.Writer.Flush()
+		}
+	})
+	suite.Empty(recorder.Header().Get("Content-Encoding"))
+	suite.Equal(strings.Repeat("<p>hello world</p>", 70), recorder.Body.String())
+}
+
+func (suite *GzipTestSuite) TestDontCompressMedia() {
+	jpeg := append([]byte{0xff, 0xd8, 0xff}, bytes.Repeat([]byte{0}, 4096)...)
+
+	recorder := suite.request(func(c *gin.Context) {
+		c.DataFromReader(http.StatusOK, int64(len(jpeg)), "image/jpeg", bytes.NewReader(jpeg), nil)
+	})
+	suite.Empty(recorder.Header().Get("Content-Encoding"))
+	suite.Equal(jpeg, recorder.Body.Bytes())
+
+	// sniffed content types are excluded too
+	recorder = suite.request(func(c *gin.Context) {
+		_, _ = c.Writer.Write(jpeg)
+	})
+	suite.Empty(recorder.Header().Get("Content-Encoding"))
+	suite.Equal("image/jpeg", recorder.Header().Get("Content-Type"))
+	suite.Equal(jpeg, recorder.Body.Bytes())
+}
+
+func (suite *GzipTestSuite) TestDisabled() {
+	viper.Set(config.Keys.GzipLevel, 0)
+
+	recorder := suite.request(func(c *gin.Context) {
+		c.Data(http.StatusOK, "application/json", []byte(bigJSON))
+	})
+	suite.Empty(recorder.Header().Get("Content-Encoding"))
+	suite.Equal(bigJSON, recorder.Body.String())
+}
+
+func (suite *GzipTestSuite) TestInvalidLevel() {
+	viper.Set(config.Keys.GzipLevel, 10)
+
+	_, err := router.Gzip()
+	suite.EqualError(err, "invalid value 10 for gzip-level: must be between -1 and 9")
+}
+
+func TestGzipTestSuite(t *testing.T) {
+	suite.Run(t, &GzipTestSuite{})
+}
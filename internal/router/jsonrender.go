@@ -0,0 +1,253 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package router
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+const (
+	// PrettyQueryKey is the query parameter with which clients can ask for JSON responses from the client API to be pretty-printed, or not.
+	PrettyQueryKey = "pretty"
+	// OmitEmptyQueryKey is the query parameter with which clients can ask for null and empty fields to be omitted from JSON responses from the client API, or not.
+	OmitEmptyQueryKey = "omit_empty"
+)
+
+// JSONRendering returns a middleware which renders JSON responses from the client API according to the
+// instance-api-json settings in the viper config store, or the pretty and omit_empty query parameters of
+// the request if they're set, so that this doesn't have to be done in every handler.
+//
+// Only responses to requests under /api/ with the application/json content type are touched, so ActivityPub
+// responses, which have their own content types and paths, are always left exactly as they were rendered.
+func JSONRendering() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		if !strings.HasPrefix(c.Request.URL.Path, "/api/") {
+			c.Next()
+			return
+		}
+
+		pretty := queryBool(c, PrettyQueryKey, viper.GetBool(config.Keys.InstanceAPIJSONPretty))
+		omitEmpty := queryBool(c, OmitEmptyQueryKey, viper.GetBool(config.Keys.InstanceAPIJSONOmitEmpty))
+		if !pretty && !omitEmpty {
+			// nothing to do, so don't bother buffering the response
+			c.Next()
+			return
+		}
+
+		w := &jsonRenderingWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+		w.flush(pretty, omitEmpty)
+	}
+}
+
+// queryBool parses the given query parameter of the request as a bool, returning def if it's not set or not a bool.
+func queryBool(c *gin.Context, key string, def bool) bool {
+	if v, err := strconv.ParseBool(c.Query(key)); err == nil {
+		return v
+	}
+	return def
+}
+
+// jsonRenderingWriter buffers json responses so that they can be rendered again once the handler is done.
+// Anything else, such as file downloads, is passed straight through without being buffered.
+type jsonRenderingWriter struct {
+	gin.ResponseWriter
+	decided   bool
+	buffering bool
+	buf       bytes.Buffer
+}
+
+func (w *jsonRenderingWriter) Write(b []byte) (int, error) {
+	if !w.decided {
+		w.decided = true
+		mediaType, _, _ := mime.ParseMediaType(w.Header().Get("Content-Type"))
+		w.buffering = mediaType == "application/json"
+	}
+	if !w.buffering {
+		return w.ResponseWriter.Write(b)
+	}
+	return w.buf.Write(b)
+}
+
+func (w *jsonRenderingWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// flush renders the buffered response, if there is one, and writes it out. If it isn't valid json after
+// all, it's written out just as it was.
+func (w *jsonRenderingWriter) flush(pretty bool, omitEmpty bool) {
+	if w.buf.Len() == 0 {
+		return
+	}
+
+	b := w.buf.Bytes()
+	if rendered, err := renderJSON(b, pretty, omitEmpty); err == nil {
+		b = rendered
+	}
+
+	w.Header().Del("Content-Length")
+	_, _ = w.ResponseWriter.Write(b)
+}
+
+// renderJSON renders the given json again, omitting null and empty fields and indenting it as requested.
+func renderJSON(b []byte, pretty bool, omitEmpty bool) ([]byte, error) {
+	if omitEmpty {
+		dec := json.NewDecoder(bytes.NewReader(b))
+		dec.UseNumber()
+		v, err := decodeOrdered(dec)
+		if err != nil {
+			return nil, err
+		}
+		if b, err = json.Marshal(omitEmptyFields(v)); err != nil {
+			return nil, err
+		}
+	}
+
+	if !pretty {
+		return b, nil
+	}
+
+	var buf bytes.Buffer
+	if err := json.Indent(&buf, b, "", "  "); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// jsonObject is a json object which keeps its fields in the order they were decoded in, so
+// that rendering a response again doesn't shuffle the fields into alphabetical order.
+type jsonObject []jsonField
+
+type jsonField struct {
+	key   string
+	value interface{}
+}
+
+func (o jsonObject) MarshalJSON() ([]byte, error) {
+	buf := bytes.Buffer{}
+	buf.WriteByte('{')
+	for i, f := range o {
+		if i > 0 {
+			buf.WriteByte(',')
+		}
+		k, err := json.Marshal(f.key)
+		if err != nil {
+			return nil, err
+		}
+		v, err := json.Marshal(f.value)
+		if err != nil {
+			return nil, err
+		}
+		buf.Write(k)
+		buf.WriteByte(':')
+		buf.Write(v)
+	}
+	buf.WriteByte('}')
+	return buf.Bytes(), nil
+}
+
+// decodeOrdered decodes the next json value from the given decoder, decoding objects as jsonObjects.
+func decodeOrdered(dec *json.Decoder) (interface{}, error) {
+	t, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	switch t {
+	case json.Delim('{'):
+		o := jsonObject{}
+		for dec.More() {
+			k, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			v, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			o = append(o, jsonField{key: k.(string), value: v})
+		}
+		// closing brace
+		_, err = dec.Token()
+		return o, err
+	case json.Delim('['):
+		a := []interface{}{}
+		for dec.More() {
+			v, err := decodeOrdered(dec)
+			if err != nil {
+				return nil, err
+			}
+			a = append(a, v)
+		}
+		// closing bracket
+		_, err = dec.Token()
+		return a, err
+	default:
+		// string, number, bool or null
+		return t, nil
+	}
+}
+
+// omitEmptyFields removes fields which are null, empty strings, empty arrays or empty objects from the given
+// decoded json value, and from any objects inside it. Values in arrays are kept, since removing them would
+// change what the array means.
+func omitEmptyFields(v interface{}) interface{} {
+	switch v := v.(type) {
+	case jsonObject:
+		kept := jsonObject{}
+		for _, f := range v {
+			f.value = omitEmptyFields(f.value)
+			if !emptyJSON(f.value) {
+				kept = append(kept, f)
+			}
+		}
+		return kept
+	case []interface{}:
+		for i := range v {
+			v[i] = omitEmptyFields(v[i])
+		}
+		return v
+	default:
+		return v
+	}
+}
+
+func emptyJSON(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case jsonObject:
+		return len(v) == 0
+	case []interface{}:
+		return len(v) == 0
+	default:
+		return false
+	}
+}
@@ -0,0 +1,133 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package router_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/router"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type JSONRenderingTestSuite struct {
+	suite.Suite
+}
+
+type testThing struct {
+	Name   string            `json:"name"`
+	Note   string            `json:"note"`
+	Avatar *string           `json:"avatar"`
+	Tags   []string          `json:"tags"`
+	Emojis []string          `json:"emojis"`
+	Meta   map[string]string `json:"meta"`
+	Count  int               `json:"count"`
+	Locked bool              `json:"locked"`
+}
+
+func (suite *JSONRenderingTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+}
+
+// request gets the given path from an engine with the json rendering middleware.
+func (suite *JSONRenderingTestSuite) request(path string) string {
+	thing := testThing{
+		Name:   "<b>some thing</b>",
+		Tags:   []string{"", "welcome"},
+		Emojis: []string{},
+		Meta:   map[string]string{},
+	}
+
+	engine := gin.New()
+	engine.Use(router.JSONRendering())
+	engine.GET("/api/v1/thing", func(c *gin.Context) {
+		c.JSON(http.StatusOK, thing)
+	})
+	engine.GET("/users/some_user", func(c *gin.Context) {
+		b, err := json.Marshal(thing)
+		suite.NoError(err)
+		c.Data(http.StatusOK, "application/activity+json", b)
+	})
+
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://localhost:8080"+path, nil))
+	suite.Equal(http.StatusOK, recorder.Code)
+	return recorder.Body.String()
+}
+
+const compactThing = `{"name":"\u003cb\u003esome thing\u003c/b\u003e","note":"","avatar":null,"tags":["","welcome"],"emojis":[],"meta":{},"count":0,"locked":false}`
+
+func (suite *JSONRenderingTestSuite) TestDefault() {
+	suite.Equal(compactThing, suite.request("/api/v1/thing"))
+}
+
+func (suite *JSONRenderingTestSuite) TestPretty() {
+	suite.Equal(`{
+  "name": "\u003cb\u003esome thing\u003c/b\u003e",
+  "note": "",
+  "avatar": null,
+  "tags": [
+    "",
+    "welcome"
+  ],
+  "emojis": [],
+  "meta": {},
+  "count": 0,
+  "locked": false
+}`, suite.request("/api/v1/thing?pretty=true"))
+}
+
+func (suite *JSONRenderingTestSuite) TestOmitEmpty() {
+	suite.Equal(`{"name":"\u003cb\u003esome thing\u003c/b\u003e","tags":["","welcome"],"count":0,"locked":false}`, suite.request("/api/v1/thing?omit_empty=true"))
+}
+
+func (suite *JSONRenderingTestSuite) TestConfiguredDefaults() {
+	viper.Set(config.Keys.InstanceAPIJSONPretty, true)
+	viper.Set(config.Keys.InstanceAPIJSONOmitEmpty, true)
+
+	suite.Equal(`{
+  "name": "\u003cb\u003esome thing\u003c/b\u003e",
+  "tags": [
+    "",
+    "welcome"
+  ],
+  "count": 0,
+  "locked": false
+}`, suite.request("/api/v1/thing"))
+
+	// clients can still turn them off
+	suite.Equal(compactThing, suite.request("/api/v1/thing?pretty=false&omit_empty=false"))
+}
+
+func (suite *JSONRenderingTestSuite) TestActivityPubUntouched() {
+	viper.Set(config.Keys.InstanceAPIJSONPretty, true)
+	viper.Set(config.Keys.InstanceAPIJSONOmitEmpty, true)
+
+	suite.Equal(compactThing, suite.request("/users/some_user?pretty=true"))
+}
+
+func TestJSONRenderingTestSuite(t *testing.T) {
+	suite.Run(t, new(JSONRenderingTestSuite))
+}
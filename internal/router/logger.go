@@ -52,7 +52,7 @@ func loggingMiddleware() gin.HandlerFunc {
 				path = path + "?" + raw
 			}
 
-			l := logrus.WithFields(logrus.Fields{
+			l := logrus.WithContext(c.Request.Context()).WithFields(logrus.Fields{
 				"latency":    latency,
 				"clientIP":   clientIP,
 				"userAgent":  userAgent,
@@ -63,9 +63,9 @@ type router struct {
 	certManager *autocert.Manager
 }
 
-// Add Gin StaticFS handler
+// Add Gin StaticFS handler, which sets Cache-Control headers on the files it serves
 func (r *router) AttachStaticFS(relativePath string, fs http.FileSystem) {
-	r.engine.StaticFS(relativePath, fs)
+	r.engine.Group("", AssetCacheControl()).StaticFS(relativePath, fs)
 }
 
 // Start starts the router nicely. It will serve two handlers if letsencrypt is enabled, and only the web/API handler if letsencrypt is not enabled.
@@ -153,14 +153,14 @@ func New(ctx context.Context, db db.DB) (Router, error) {
 	engine := gin.New()
 
 	engine.Use(gin.RecoveryWithWriter(logrus.StandardLogger().Writer()))
+	engine.Use(requestIDMiddleware())
 	engine.Use(loggingMiddleware())
 
 	// 8 MiB
 	engine.MaxMultipartMemory = 8 << 20
 
-	// set up IP forwarding via x-forward-* headers.
-	trustedProxies := viper.GetStringSlice(keys.TrustedProxies)
-	if err := engine.SetTrustedProxies(trustedProxies); err != nil {
+	// set up finding the real client IP from the headers set by trusted proxies.
+	if err := UseClientIP(engine); err != nil {
 		return nil, err
 	}
 
@@ -169,11 +169,17 @@ func New(ctx context.Context, db db.DB) (Router, error) {
 		return nil, err
 	}
 
+	// set security headers on html responses
+	useSecurityHeaders(engine)
+
 	// enable gzip compression on the engine
 	if err := useGzip(engine); err != nil {
 		return nil, err
 	}
 
+	// render json responses from the client API as configured
+	engine.Use(JSONRendering())
+
 	// enable session store middleware on the engine
 	if err := useSession(ctx, db, engine); err != nil {
 		return nil, err
@@ -0,0 +1,152 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package router
+
+import (
+	"fmt"
+	"mime"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// allowFramingKey is the gin context key used to mark a response as allowed to be shown in a frame on other sites.
+const allowFramingKey = "gts-allow-framing"
+
+// defaultContentSecurityPolicy returns a Content-Security-Policy which only allows resources from this instance
+// itself, plus inline styles (for custom profile css) and websocket connections to the streaming api.
+// Inline scripts, plugins and framing by other sites are all blocked.
+func defaultContentSecurityPolicy() string {
+	wsProtocol := "wss"
+	if viper.GetString(config.Keys.Protocol) == "http" {
+		wsProtocol = "ws"
+	}
+	host := viper.GetString(config.Keys.Host)
+
+	return strings.Join([]string{
+		"default-src 'self'",
+		"script-src 'self'",
+		"style-src 'self' 'unsafe-inline'",
+		"img-src 'self' data: blob:",
+		"media-src 'self' blob:",
+		fmt.Sprintf("connect-src 'self' %s://%s", wsProtocol, host),
+		"object-src 'none'",
+		"base-uri 'self'",
+		"frame-ancestors 'none'",
+	}, "; ")
+}
+
+// withoutFrameAncestors returns the given Content-Security-Policy with any frame-ancestors directive removed.
+func withoutFrameAncestors(policy string) string {
+	directives := []string{}
+	for _, d := range strings.Split(policy, ";") {
+		d = strings.TrimSpace(d)
+		if d == "" || strings.HasPrefix(strings.ToLower(d), "frame-ancestors") {
+			continue
+		}
+		directives = append(directives, d)
+	}
+	return strings.Join(directives, "; ")
+}
+
+// securityHeaders holds the values of the security headers sent along with html responses.
+type securityHeaders struct {
+	policy        string // policy for ordinary pages
+	framingPolicy string // policy for pages which may be framed by other sites
+}
+
+// SecurityHeaders returns a middleware which sets Content-Security-Policy, X-Content-Type-Options, Referrer-Policy
+// and X-Frame-Options headers on html responses. The policy is taken from the web content security policy setting in
+// the viper config store, or a default policy allowing only resources from this instance is used if that's not set.
+func SecurityHeaders() gin.HandlerFunc {
+	policy := strings.TrimSpace(viper.GetString(config.Keys.WebContentSecurityPolicy))
+	if policy == "" {
+		policy = defaultContentSecurityPolicy()
+	}
+
+	h := &securityHeaders{
+		policy:        policy,
+		framingPolicy: withoutFrameAncestors(policy),
+	}
+
+	return func(c *gin.Context) {
+		c.Writer = &securityHeadersWriter{ResponseWriter: c.Writer, c: c, headers: h}
+		c.Next()
+	}
+}
+
+// AllowFraming marks the response to the given request as one that other sites may show
+// in a frame, eg., for embeds. It must be called before the response is written.
+func AllowFraming(c *gin.Context) {
+	c.Set(allowFramingKey, true)
+}
+
+// securityHeadersWriter sets security headers just before the response headers are written, since
+// we only know whether the response is html once the handler has set the Content-Type header.
+type securityHeadersWriter struct {
+	gin.ResponseWriter
+	c       *gin.Context
+	headers *securityHeaders
+	set     bool
+}
+
+func (w *securityHeadersWriter) setHeaders() {
+	if w.set {
+		return
+	}
+	w.set = true
+
+	mediaType, _, err := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	if err != nil || mediaType != "text/html" {
+		return
+	}
+
+	header := w.Header()
+	header.Set("X-Content-Type-Options", "nosniff")
+	header.Set("Referrer-Policy", "same-origin")
+
+	if w.c.GetBool(allowFramingKey) {
+		header.Set("Content-Security-Policy", w.headers.framingPolicy)
+		return
+	}
+	header.Set("Content-Security-Policy", w.headers.policy)
+	header.Set("X-Frame-Options", "DENY")
+}
+
+func (w *securityHeadersWriter) WriteHeaderNow() {
+	w.setHeaders()
+	w.ResponseWriter.WriteHeaderNow()
+}
+
+func (w *securityHeadersWriter) Write(data []byte) (int, error) {
+	w.setHeaders()
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *securityHeadersWriter) WriteString(s string) (int, error) {
+	w.setHeaders()
+	return w.ResponseWriter.WriteString(s)
+}
+
+// useSecurityHeaders attaches the middleware returned by SecurityHeaders to the given gin engine
+func useSecurityHeaders(engine *gin.Engine) {
+	engine.Use(SecurityHeaders())
+}
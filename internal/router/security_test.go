@@ -0,0 +1,97 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package router_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/router"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type SecurityHeadersTestSuite struct {
+	suite.Suite
+}
+
+func (suite *SecurityHeadersTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+}
+
+// request gets the given path from an engine with the security headers middleware.
+func (suite *SecurityHeadersTestSuite) request(path string) *httptest.ResponseRecorder {
+	engine := gin.New()
+	engine.Use(router.SecurityHeaders())
+	engine.GET("/page", func(c *gin.Context) {
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte("<html></html>"))
+	})
+	engine.GET("/embed", func(c *gin.Context) {
+		router.AllowFraming(c)
+		c.Data(http.StatusOK, "text/html; charset=utf-8", []byte("<html></html>"))
+	})
+	engine.GET("/api", func(c *gin.Context) {
+		c.JSON(http.StatusOK, gin.H{"hello": "world"})
+	})
+
+	recorder := httptest.NewRecorder()
+	engine.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "http://localhost:8080"+path, nil))
+	return recorder
+}
+
+func (suite *SecurityHeadersTestSuite) TestHTMLDefaultPolicy() {
+	recorder := suite.request("/page")
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("default-src 'self'; script-src 'self'; style-src 'self' 'unsafe-inline'; img-src 'self' data: blob:; media-src 'self' blob:; connect-src 'self' ws://localhost:8080; object-src 'none'; base-uri 'self'; frame-ancestors 'none'", recorder.Header().Get("Content-Security-Policy"))
+	suite.Equal("nosniff", recorder.Header().Get("X-Content-Type-Options"))
+	suite.Equal("same-origin", recorder.Header().Get("Referrer-Policy"))
+	suite.Equal("DENY", recorder.Header().Get("X-Frame-Options"))
+}
+
+func (suite *SecurityHeadersTestSuite) TestHTMLConfiguredPolicy() {
+	viper.Set(config.Keys.WebContentSecurityPolicy, "default-src 'self' https://cdn.example.org")
+
+	recorder := suite.request("/page")
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Equal("default-src 'self' https://cdn.example.org", recorder.Header().Get("Content-Security-Policy"))
+	suite.Equal("DENY", recorder.Header().Get("X-Frame-Options"))
+}
+
+func (suite *SecurityHeadersTestSuite) TestHTMLAllowFraming() {
+	recorder := suite.request("/embed")
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.NotContains(recorder.Header().Get("Content-Security-Policy"), "frame-ancestors")
+	suite.Contains(recorder.Header().Get("Content-Security-Policy"), "script-src 'self'")
+	suite.Empty(recorder.Header().Get("X-Frame-Options"))
+}
+
+func (suite *SecurityHeadersTestSuite) TestNotHTML() {
+	recorder := suite.request("/api")
+	suite.Equal(http.StatusOK, recorder.Code)
+	suite.Empty(recorder.Header().Get("Content-Security-Policy"))
+	suite.Empty(recorder.Header().Get("X-Frame-Options"))
+}
+
+func TestSecurityHeadersTestSuite(t *testing.T) {
+	suite.Run(t, &SecurityHeadersTestSuite{})
+}
@@ -24,26 +24,68 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
+	"time"
 
 	"github.com/gin-contrib/sessions"
 	"github.com/gin-contrib/sessions/memstore"
 	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"golang.org/x/net/idna"
 )
 
-// SessionOptions returns the standard set of options to use for each session.
-func SessionOptions() sessions.Options {
+// SessionOptions returns the standard set of options to use for each session,
+// or an error if the session cookie settings in the viper config store are invalid.
+func SessionOptions() (sessions.Options, error) {
+	keys := config.Keys
+
+	domain := viper.GetString(keys.SessionCookieDomain)
+	if domain == "" {
+		domain = viper.GetString(keys.Host)
+	}
+
+	// unless it's been set explicitly, session cookies are only secure if we're served over https,
+	// since browsers may refuse to send secure cookies over plain http
+	secure := viper.GetString(keys.Protocol) == "https"
+	if viper.IsSet(keys.SessionCookieSecure) {
+		secure = viper.GetBool(keys.SessionCookieSecure)
+	}
+	if !secure && viper.GetString(keys.Protocol) == "https" {
+		return sessions.Options{}, fmt.Errorf("%s cannot be false when %s is https", keys.SessionCookieSecure, keys.Protocol)
+	}
+
+	var sameSite http.SameSite
+	switch s := viper.GetString(keys.SessionCookieSameSite); strings.ToLower(s) {
+	case "lax":
+		sameSite = http.SameSiteLaxMode
+	case "strict":
+		sameSite = http.SameSiteStrictMode
+	case "none":
+		// browsers reject SameSite=None cookies which aren't also Secure
+		if !secure {
+			return sessions.Options{}, fmt.Errorf("%s cannot be none when %s is false", keys.SessionCookieSameSite, keys.SessionCookieSecure)
+		}
+		sameSite = http.SameSiteNoneMode
+	default:
+		return sessions.Options{}, fmt.Errorf("invalid value %q for %s: must be one of lax, strict, none", s, keys.SessionCookieSameSite)
+	}
+
+	maxAge := viper.GetDuration(keys.SessionCookieMaxAge)
+	if maxAge < time.Second {
+		return sessions.Options{}, fmt.Errorf("invalid value %s for %s: must be at least 1s", maxAge, keys.SessionCookieMaxAge)
+	}
+
 	return sessions.Options{
 		Path:     "/",
-		Domain:   viper.GetString(config.Keys.Host),
-		MaxAge:   120,                                              // 2 minutes
-		Secure:   viper.GetString(config.Keys.Protocol) == "https", // only use cookie over https
-		HttpOnly: true,                                             // exclude javascript from inspecting cookie
-		SameSite: http.SameSiteDefaultMode,                         // https://datatracker.ietf.org/doc/html/draft-ietf-httpbis-cookie-same-site-00#section-4.1.1
-	}
+		Domain:   domain,
+		MaxAge:   int(maxAge.Seconds()),
+		Secure:   secure,   // only use cookie over https
+		HttpOnly: true,     // exclude javascript from inspecting cookie
+		SameSite: sameSite, // https://datatracker.ietf.org/doc/html/draft-ietf-httpbis-cookie-same-site-00#section-4.1.1
+	}, nil
 }
 
 // SessionName is a utility function that derives an appropriate session name from the hostname.
@@ -82,8 +124,16 @@ func useSession(ctx context.Context, sessionDB db.Session, engine *gin.Engine) e
 		return errors.New("router session was nil")
 	}
 
+	sessionOptions, err := SessionOptions()
+	if err != nil {
+		return err
+	}
+	if sessionOptions.Secure && viper.GetString(config.Keys.Protocol) != "https" {
+		logrus.Warnf("%s is true but %s is not https: browsers may refuse to send session cookies, which will prevent signing in", config.Keys.SessionCookieSecure, config.Keys.Protocol)
+	}
+
 	store := memstore.NewStore(rs.Auth, rs.Crypt)
-	store.Options(SessionOptions())
+	store.Options(sessionOptions)
 
 	sessionName, err := SessionName()
 	if err != nil {
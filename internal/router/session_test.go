@@ -19,7 +19,9 @@
 package router_test
 
 import (
+	"net/http"
 	"testing"
+	"time"
 
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/suite"
@@ -91,6 +93,72 @@ func (suite *SessionTestSuite) TestDeriveSessionIDNOK() {
 	suite.Equal("gotosocial-xn--fid-gna.org", sessionName)
 }
 
+func (suite *SessionTestSuite) TestSessionOptionsDefault() {
+	options, err := router.SessionOptions()
+	suite.NoError(err)
+	suite.Equal("localhost:8080", options.Domain)
+	suite.Equal(120, options.MaxAge)
+	suite.Equal(http.SameSiteLaxMode, options.SameSite)
+	suite.False(options.Secure)
+	suite.True(options.HttpOnly)
+}
+
+func (suite *SessionTestSuite) TestSessionOptionsConfigured() {
+	viper.Set(config.Keys.Protocol, "https")
+	viper.Set(config.Keys.Host, "gts.example.org")
+	viper.Set(config.Keys.SessionCookieDomain, "example.org")
+	viper.Set(config.Keys.SessionCookieSameSite, "None")
+	viper.Set(config.Keys.SessionCookieSecure, true)
+	viper.Set(config.Keys.SessionCookieMaxAge, 10*time.Minute)
+
+	options, err := router.SessionOptions()
+	suite.NoError(err)
+	suite.Equal("example.org", options.Domain)
+	suite.Equal(600, options.MaxAge)
+	suite.Equal(http.SameSiteNoneMode, options.SameSite)
+	suite.True(options.Secure)
+}
+
+func (suite *SessionTestSuite) TestSessionOptionsSecureFromProtocol() {
+	// testrig config sets session-cookie-secure, so unset it again to get the default
+	viper.Reset()
+	viper.Set(config.Keys.Host, "gts.example.org")
+	viper.Set(config.Keys.SessionCookieSameSite, "lax")
+	viper.Set(config.Keys.SessionCookieMaxAge, 2*time.Minute)
+
+	viper.Set(config.Keys.Protocol, "http")
+	options, err := router.SessionOptions()
+	suite.NoError(err)
+	suite.False(options.Secure)
+
+	viper.Set(config.Keys.Protocol, "https")
+	options, err = router.SessionOptions()
+	suite.NoError(err)
+	suite.True(options.Secure)
+}
+
+func (suite *SessionTestSuite) TestSessionOptionsInsecureHTTPS() {
+	viper.Set(config.Keys.Protocol, "https")
+	viper.Set(config.Keys.SessionCookieSecure, false)
+
+	_, err := router.SessionOptions()
+	suite.EqualError(err, "session-cookie-secure cannot be false when protocol is https")
+}
+
+func (suite *SessionTestSuite) TestSessionOptionsSameSiteNoneInsecure() {
+	viper.Set(config.Keys.SessionCookieSameSite, "none")
+
+	_, err := router.SessionOptions()
+	suite.EqualError(err, "session-cookie-samesite cannot be none when session-cookie-secure is false")
+}
+
+func (suite *SessionTestSuite) TestSessionOptionsInvalidSameSite() {
+	viper.Set(config.Keys.SessionCookieSameSite, "sometimes")
+
+	_, err := router.SessionOptions()
+	suite.EqualError(err, `invalid value "sometimes" for session-cookie-samesite: must be one of lax, strict, none`)
+}
+
 func TestSessionTestSuite(t *testing.T) {
 	suite.Run(t, &SessionTestSuite{})
 }
@@ -0,0 +1,190 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+// Job is a piece of recurring work that can be registered with a Scheduler.
+type Job struct {
+	// Name of the job, used when logging run outcomes.
+	Name string
+	// Interval is how long to wait between runs of the job.
+	// The first run will happen one interval after the scheduler is started.
+	Interval time.Duration
+	// Jitter is the maximum random amount of time that will be added to
+	// each interval, so that jobs registered at the same time don't all run
+	// at once. It can be 0, in which case the job runs exactly on interval.
+	Jitter time.Duration
+	// Run does the actual work of the job. The provided context will be
+	// cancelled when the scheduler is stopped, so long-running jobs
+	// should check it and return early when it's done.
+	Run func(ctx context.Context) error
+}
+
+// Scheduler runs registered jobs periodically in the background,
+// until it is stopped. It is safe for concurrent use.
+type Scheduler struct {
+	mu      sync.Mutex
+	jobs    []Job
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+	started bool
+}
+
+// New returns a new, unstarted Scheduler.
+func New() *Scheduler {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Scheduler{
+		ctx:    ctx,
+		cancel: cancel,
+	}
+}
+
+// Register adds the given job to the scheduler. If the scheduler
+// has already been started, the job will start being scheduled straight away.
+func (s *Scheduler) Register(job Job) error {
+	if job.Name == "" {
+		return errors.New("Register: job name was empty")
+	}
+	if job.Interval <= 0 {
+		return fmt.Errorf("Register: job %s interval must be greater than 0", job.Name)
+	}
+	if job.Jitter < 0 {
+		return fmt.Errorf("Register: job %s jitter must not be negative", job.Name)
+	}
+	if job.Run == nil {
+		return fmt.Errorf("Register: job %s run function was nil", job.Name)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.ctx.Err() != nil {
+		return fmt.Errorf("Register: scheduler is stopped, can't register job %s", job.Name)
+	}
+
+	s.jobs = append(s.jobs, job)
+	if s.started {
+		s.schedule(job)
+	}
+	return nil
+}
+
+// Start starts running all registered jobs on their intervals.
+func (s *Scheduler) Start() error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.started {
+		return errors.New("Start: scheduler already started")
+	}
+	if s.ctx.Err() != nil {
+		return errors.New("Start: scheduler is stopped")
+	}
+	s.started = true
+
+	for _, job := range s.jobs {
+		s.schedule(job)
+	}
+
+	logrus.Infof("scheduler: started with %d job(s)", len(s.jobs))
+	return nil
+}
+
+// Stop cancels the context of any running jobs, and prevents any further
+// jobs from running. It blocks until running jobs have returned, or until
+// the given context is done, whichever comes first.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	s.mu.Lock()
+	s.cancel()
+	s.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		logrus.Info("scheduler: stopped")
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("Stop: gave up waiting for jobs to finish: %s", ctx.Err())
+	}
+}
+
+// schedule starts a goroutine which runs the given job on its interval until
+// the scheduler is stopped. s.mu must be held by the caller.
+func (s *Scheduler) schedule(job Job) {
+	s.wg.Add(1)
+	go func() {
+		defer s.wg.Done()
+
+		for {
+			timer := time.NewTimer(nextDelay(job))
+			select {
+			case <-s.ctx.Done():
+				timer.Stop()
+				return
+			case <-timer.C:
+				run(s.ctx, job)
+			}
+		}
+	}()
+}
+
+// nextDelay returns how long to wait before the next run of the given job.
+func nextDelay(job Job) time.Duration {
+	delay := job.Interval
+	if job.Jitter > 0 {
+		delay += time.Duration(rand.Int63n(int64(job.Jitter))) //nolint:gosec
+	}
+	return delay
+}
+
+// run runs the given job once, logging the outcome. Panics are recovered
+// and logged so that a single broken job can't bring the whole server down.
+func run(ctx context.Context, job Job) {
+	l := logrus.WithField("job", job.Name)
+	begin := time.Now()
+
+	defer func() {
+		if r := recover(); r != nil {
+			l.Errorf("scheduler: job panicked after %s: %v", time.Since(begin), r)
+		}
+	}()
+
+	l.Debug("scheduler: running job")
+	if err := job.Run(ctx); err != nil {
+		l.Errorf("scheduler: job failed after %s: %s", time.Since(begin), err)
+		return
+	}
+	l.Infof("scheduler: job finished in %s", time.Since(begin))
+}
@@ -0,0 +1,119 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package scheduler_test
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/scheduler"
+)
+
+type SchedulerTestSuite struct {
+	suite.Suite
+}
+
+func (suite *SchedulerTestSuite) TestRunsJobsRepeatedly() {
+	s := scheduler.New()
+
+	var runs int32
+	suite.NoError(s.Register(scheduler.Job{
+		Name:     "counter",
+		Interval: 10 * time.Millisecond,
+		Jitter:   5 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			atomic.AddInt32(&runs, 1)
+			return nil
+		},
+	}))
+
+	// a failing or panicking job shouldn't stop other jobs from running
+	suite.NoError(s.Register(scheduler.Job{
+		Name:     "broken",
+		Interval: 10 * time.Millisecond,
+		Run: func(ctx context.Context) error {
+			if atomic.LoadInt32(&runs)%2 == 0 {
+				panic("oh no")
+			}
+			return errors.New("oh no")
+		},
+	}))
+
+	suite.NoError(s.Start())
+	suite.Eventually(func() bool {
+		return atomic.LoadInt32(&runs) >= 3
+	}, 5*time.Second, 5*time.Millisecond)
+
+	suite.NoError(s.Stop(context.Background()))
+
+	// no more runs should happen once stopped
+	stoppedAt := atomic.LoadInt32(&runs)
+	time.Sleep(50 * time.Millisecond)
+	suite.Equal(stoppedAt, atomic.LoadInt32(&runs))
+}
+
+func (suite *SchedulerTestSuite) TestStopCancelsRunningJob() {
+	s := scheduler.New()
+
+	started := make(chan struct{})
+	var cancelled int32
+	suite.NoError(s.Register(scheduler.Job{
+		Name:     "long running",
+		Interval: time.Millisecond,
+		Run: func(ctx context.Context) error {
+			select {
+			case started <- struct{}{}:
+			default:
+			}
+			<-ctx.Done()
+			atomic.StoreInt32(&cancelled, 1)
+			return ctx.Err()
+		},
+	}))
+
+	suite.NoError(s.Start())
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	suite.NoError(s.Stop(ctx))
+	suite.EqualValues(1, atomic.LoadInt32(&cancelled))
+
+	// registering after stopping should fail
+	suite.Error(s.Register(scheduler.Job{
+		Name:     "too late",
+		Interval: time.Millisecond,
+		Run:      func(ctx context.Context) error { return nil },
+	}))
+}
+
+func (suite *SchedulerTestSuite) TestRegisterInvalidJob() {
+	s := scheduler.New()
+	suite.Error(s.Register(scheduler.Job{Name: "no interval", Run: func(ctx context.Context) error { return nil }}))
+	suite.Error(s.Register(scheduler.Job{Name: "no run func", Interval: time.Second}))
+	suite.Error(s.Register(scheduler.Job{Interval: time.Second, Run: func(ctx context.Context) error { return nil }}))
+}
+
+func TestSchedulerTestSuite(t *testing.T) {
+	suite.Run(t, &SchedulerTestSuite{})
+}
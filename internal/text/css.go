@@ -0,0 +1,317 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package text
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+// ProfileCSSScope is the selector that custom profile css is scoped to.
+// The profile template wraps the profile in an element matching it.
+const ProfileCSSScope = "#profile"
+
+// cssForbidden contains (lowercased) substrings which are never allowed anywhere in custom CSS,
+// because they can be used to run scripts, or to smuggle in things we'd otherwise catch.
+var cssForbidden = []string{
+	"<",
+	"\\",
+	"expression(",
+	"javascript:",
+	"vbscript:",
+	"behavior",
+	"-moz-binding",
+	"image-set(",
+}
+
+// cssPositionFixedRegex matches declarations which would let an element break out of the profile container.
+var cssPositionFixedRegex = regexp.MustCompile(`position\s*:\s*(fixed|sticky)`)
+
+// cssURLRegex matches url() references, capturing their contents.
+var cssURLRegex = regexp.MustCompile(`(?i)url\(\s*([^)]*)\)`)
+
+// SanitizeCSS checks the given user-provided css, and returns a version of it in which every
+// selector is prefixed with the given scope selector, so that it only applies inside the element
+// matched by scope. An error is returned if the css can't be parsed, or contains anything disallowed:
+//
+//   - at-rules other than @media, @supports and @keyframes (so no @import);
+//   - fixed or sticky positioning, which could be used to cover the rest of the page;
+//   - url() references to hosts other than this one and accounts-custom-css-allowed-hosts;
+//   - anything which could be used to run scripts or escape the style element.
+func SanitizeCSS(css string, scope string) (string, error) {
+	css, err := stripCSSComments(css)
+	if err != nil {
+		return "", err
+	}
+
+	lower := strings.ToLower(css)
+	for _, f := range cssForbidden {
+		if strings.Contains(lower, f) {
+			return "", fmt.Errorf("css must not contain %q", f)
+		}
+	}
+
+	if cssPositionFixedRegex.MatchString(lower) {
+		return "", errors.New("css must not use fixed or sticky positioning")
+	}
+
+	for _, match := range cssURLRegex.FindAllStringSubmatch(css, -1) {
+		if err := checkCSSURL(match[1]); err != nil {
+			return "", err
+		}
+	}
+
+	if err := checkCSSStrings(css); err != nil {
+		return "", err
+	}
+
+	return scopeCSSRules(css, scope)
+}
+
+// checkCSSStrings returns an error if any quoted string in css contains a newline or is unterminated.
+//
+// Browsers end a string at a newline, whereas indexUnquoted would carry on treating the
+// following rules as quoted, letting them through scopeCSSRules without being scoped.
+func checkCSSStrings(css string) error {
+	var quote byte
+	for i := 0; i < len(css); i++ {
+		switch {
+		case quote != 0:
+			switch css[i] {
+			case quote:
+				quote = 0
+			case '\n', '\r', '\f':
+				return errors.New("css contains a string with a newline in it")
+			}
+		case css[i] == '"' || css[i] == '\'':
+			quote = css[i]
+		}
+	}
+	if quote != 0 {
+		return errors.New("css contains an unterminated string")
+	}
+	return nil
+}
+
+// stripCSSComments removes all /* comments */ from css.
+func stripCSSComments(css string) (string, error) {
+	var b strings.Builder
+	for {
+		start := strings.Index(css, "/*")
+		if start == -1 {
+			b.WriteString(css)
+			return b.String(), nil
+		}
+		end := strings.Index(css[start+2:], "*/")
+		if end == -1 {
+			return "", errors.New("css contains an unterminated comment")
+		}
+		b.WriteString(css[:start])
+		b.WriteString(" ")
+		css = css[start+2+end+2:]
+	}
+}
+
+// checkCSSURL returns an error if the given contents of a url() point anywhere other
+// than this instance or one of the hosts allowed by accounts-custom-css-allowed-hosts.
+func checkCSSURL(raw string) error {
+	raw = strings.Trim(strings.TrimSpace(raw), `"'`)
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("css contains an invalid url %q: %s", raw, err)
+	}
+
+	if u.Scheme == "" && u.Host == "" {
+		// relative url, so it points at this instance
+		return nil
+	}
+
+	if u.Scheme != "" && u.Scheme != "https" && u.Scheme != "http" {
+		return fmt.Errorf("css url %q has disallowed scheme %q", raw, u.Scheme)
+	}
+
+	// configured hosts may or may not include a port
+	allowedHosts := append([]string{viper.GetString(config.Keys.Host)}, viper.GetStringSlice(config.Keys.AccountsCustomCSSAllowedHosts)...)
+	for _, allowed := range allowedHosts {
+		if strings.EqualFold(u.Host, allowed) || strings.EqualFold(u.Hostname(), allowed) {
+			return nil
+		}
+	}
+
+	return fmt.Errorf("css url %q points to a host which is not allowed", raw)
+}
+
+// scopeCSSRules parses the given rules, prefixing the selectors of each with scope.
+// The blocks of @media and @supports rules are scoped recursively.
+func scopeCSSRules(css string, scope string) (string, error) {
+	var b strings.Builder
+	for {
+		css = strings.TrimSpace(css)
+		if css == "" {
+			return b.String(), nil
+		}
+
+		open := indexUnquoted(css, "{;}")
+		if open == -1 || css[open] != '{' {
+			return "", fmt.Errorf("css rule %q has no block", truncateCSS(css))
+		}
+		closing, err := matchingBrace(css, open)
+		if err != nil {
+			return "", err
+		}
+
+		prelude := strings.TrimSpace(css[:open])
+		block := css[open+1 : closing]
+		css = css[closing+1:]
+
+		if prelude == "" {
+			return "", errors.New("css contains a block without a selector")
+		}
+
+		if prelude[0] != '@' {
+			if indexUnquoted(block, "{}") != -1 {
+				return "", fmt.Errorf("css rule %q contains nested blocks", truncateCSS(prelude))
+			}
+			selectors, err := scopeSelectors(prelude, scope)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(selectors)
+			b.WriteString(" {")
+			b.WriteString(strings.TrimSpace(block))
+			b.WriteString("}\n")
+			continue
+		}
+
+		name := strings.ToLower(prelude[1:])
+		if i := strings.IndexAny(name, " \t\r\n("); i != -1 {
+			name = name[:i]
+		}
+		switch name {
+		case "media", "supports":
+			inner, err := scopeCSSRules(block, scope)
+			if err != nil {
+				return "", err
+			}
+			b.WriteString(prelude)
+			b.WriteString(" {\n")
+			b.WriteString(inner)
+			b.WriteString("}\n")
+		case "keyframes", "-webkit-keyframes":
+			// keyframe selectors are percentages, not elements, so there's nothing to scope
+			b.WriteString(prelude)
+			b.WriteString(" {")
+			b.WriteString(strings.TrimSpace(block))
+			b.WriteString("}\n")
+		default:
+			return "", fmt.Errorf("css at-rule @%s is not allowed", name)
+		}
+	}
+}
+
+// scopeSelectors prefixes each selector in the given comma-separated list with scope.
+//
+// Selectors starting with a combinator are rejected, since eg "~ footer" would be
+// scoped to "#profile ~ footer" and so match elements outside of the scope.
+func scopeSelectors(selectors string, scope string) (string, error) {
+	scoped := []string{}
+	depth := 0
+	start := 0
+	for i := 0; i <= len(selectors); i++ {
+		if i < len(selectors) {
+			switch selectors[i] {
+			case '(', '[':
+				depth++
+				continue
+			case ')', ']':
+				depth--
+				continue
+			case ',':
+				if depth > 0 {
+					continue
+				}
+			default:
+				continue
+			}
+		}
+		if s := strings.TrimSpace(selectors[start:i]); s != "" {
+			if strings.ContainsRune(">~+", rune(s[0])) {
+				return "", fmt.Errorf("css selector %q must not start with a combinator", s)
+			}
+			scoped = append(scoped, scope+" "+s)
+		}
+		start = i + 1
+	}
+	return strings.Join(scoped, ", "), nil
+}
+
+// indexUnquoted returns the index of the first of chars in s which isn't inside a quoted string, or -1.
+func indexUnquoted(s string, chars string) int {
+	var quote byte
+	for i := 0; i < len(s); i++ {
+		switch {
+		case quote != 0:
+			if s[i] == quote {
+				quote = 0
+			}
+		case s[i] == '"' || s[i] == '\'':
+			quote = s[i]
+		case strings.IndexByte(chars, s[i]) != -1:
+			return i
+		}
+	}
+	return -1
+}
+
+// matchingBrace returns the index of the brace which closes the one opened at s[open].
+func matchingBrace(s string, open int) (int, error) {
+	depth := 0
+	for i := open; i < len(s); {
+		next := indexUnquoted(s[i:], "{}")
+		if next == -1 {
+			break
+		}
+		i += next
+		if s[i] == '{' {
+			depth++
+		} else {
+			depth--
+		}
+		if depth == 0 {
+			return i, nil
+		}
+		i++
+	}
+	return -1, errors.New("css contains an unclosed block")
+}
+
+// truncateCSS shortens s for use in error messages.
+func truncateCSS(s string) string {
+	if len(s) > 40 {
+		return s[:40] + "..."
+	}
+	return s
+}
@@ -0,0 +1,116 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package text_test
+
+import (
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/testrig"
+)
+
+type CSSTestSuite struct {
+	suite.Suite
+}
+
+func (suite *CSSTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+}
+
+func (suite *CSSTestSuite) TestSanitizeCSSScoped() {
+	css := `/* make it pink */
+.profile, .bio p { background: pink; color: #333 }
+a:is(.mention, .hashtag) { text-decoration: none; }
+@media (max-width: 600px) {
+  .avatar img { width: 50%; }
+}
+@keyframes spin { from { transform: rotate(0deg) } to { transform: rotate(360deg) } }
+.headerimage { background-image: url("/fileserver/header.png"); }`
+
+	scoped, err := text.SanitizeCSS(css, "#profile")
+	suite.NoError(err)
+	suite.Equal(`#profile .profile, #profile .bio p {background: pink; color: #333}
+#profile a:is(.mention, .hashtag) {text-decoration: none;}
+@media (max-width: 600px) {
+#profile .avatar img {width: 50%;}
+}
+@keyframes spin {from { transform: rotate(0deg) } to { transform: rotate(360deg) }}
+#profile .headerimage {background-image: url("/fileserver/header.png");}
+`, scoped)
+}
+
+func (suite *CSSTestSuite) TestSanitizeCSSURLs() {
+	_, err := text.SanitizeCSS(`.bio { background: url(http://localhost:8080/some/image.png) }`, "#profile")
+	suite.NoError(err)
+
+	_, err = text.SanitizeCSS(`.bio { background: url('https://cdn.example.org/image.png') }`, "#profile")
+	suite.EqualError(err, `css url "https://cdn.example.org/image.png" points to a host which is not allowed`)
+
+	_, err = text.SanitizeCSS(`.bio { background: URL(//cdn.example.org/image.png) }`, "#profile")
+	suite.EqualError(err, `css url "//cdn.example.org/image.png" points to a host which is not allowed`)
+
+	viper.Set(config.Keys.AccountsCustomCSSAllowedHosts, []string{"cdn.example.org"})
+	_, err = text.SanitizeCSS(`.bio { background: url('https://cdn.example.org/image.png') }`, "#profile")
+	suite.NoError(err)
+
+	_, err = text.SanitizeCSS(`.bio { background: url(data:image/png;base64,AAAA) }`, "#profile")
+	suite.EqualError(err, `css url "data:image/png;base64,AAAA" has disallowed scheme "data"`)
+}
+
+func (suite *CSSTestSuite) TestSanitizeCSSNotAllowed() {
+	for css, expected := range map[string]string{
+		`@import url("/other.css");`:                                 `css rule "@import url(\"/other.css\");" has no block`,
+		`@font-face { font-family: x; src: url(/x.ttf) }`:            `css at-rule @font-face is not allowed`,
+		`.bio { color: red } </style><script>alert(1)</script>`:      `css must not contain "<"`,
+		`.bio { width: expression(alert(1)) }`:                       `css must not contain "expression("`,
+		`.bio { background: url(javascript:alert(1)) }`:              `css must not contain "javascript:"`,
+		`.bio { background: \75rl(https://evil.example.org/x.png) }`: `css must not contain "\\"`,
+		`.bio { position: fixed; top: 0; left: 0 }`:                  `css must not use fixed or sticky positioning`,
+		`.bio { color: red`:                                          `css contains an unclosed block`,
+		`.bio { color: red } }`:                                      `css rule "}" has no block`,
+		`.bio { color: red } /* sneaky`:                              `css contains an unterminated comment`,
+		`.bio { .nested { color: red } }`:                            `css rule ".bio" contains nested blocks`,
+		`.bio, ~ footer { display: none }`:                           `css selector "~ footer" must not start with a combinator`,
+		`+ .page { display: none }`:                                  `css selector "+ .page" must not start with a combinator`,
+		`@media print { > .bio { color: red } }`:                     `css selector "> .bio" must not start with a combinator`,
+	} {
+		_, err := text.SanitizeCSS(css, "#profile")
+		suite.EqualError(err, expected, css)
+	}
+}
+
+func (suite *CSSTestSuite) TestSanitizeCSSStrings() {
+	// a newline ends a string, so the body rule here isn't quoted and mustn't get through unscoped
+	_, err := text.SanitizeCSS("a { content: \"x\n} body { display: none } a { content: 'y\" }", "#profile")
+	suite.EqualError(err, "css contains a string with a newline in it")
+
+	_, err = text.SanitizeCSS(`.bio { content: "x }`, "#profile")
+	suite.EqualError(err, "css contains an unterminated string")
+
+	sanitized, err := text.SanitizeCSS(`.bio::after { content: "} body {" }`, "#profile")
+	suite.NoError(err)
+	suite.Equal("#profile .bio::after {content: \"} body {\"}\n", sanitized)
+}
+
+func TestCSSTestSuite(t *testing.T) {
+	suite.Run(t, &CSSTestSuite{})
+}
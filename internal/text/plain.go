@@ -20,6 +20,8 @@ package text
 
 import (
 	"context"
+	"html"
+	"regexp"
 	"strings"
 
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -31,6 +33,12 @@ var breakReplacer = strings.NewReplacer(
 	"\n", "<br/>",
 )
 
+// paragraphRegex matches the boundaries between HTML paragraphs.
+var paragraphRegex = regexp.MustCompile(`(?i)</p>\s*<p[^>]*>`)
+
+// breakRegex matches HTML line breaks.
+var breakRegex = regexp.MustCompile(`(?i)<br\s*/?>`)
+
 func (f *formatter) FromPlain(ctx context.Context, plain string, mentions []*gtsmodel.Mention, tags []*gtsmodel.Tag) string {
 	content := preformat(plain)
 
@@ -54,3 +62,12 @@ func (f *formatter) FromPlain(ctx context.Context, plain string, mentions []*gts
 
 	return postformat(content)
 }
+
+// ToPlain does a best-effort conversion of formatted HTML, such as the content of a status, back into plain text.
+// Paragraphs and line breaks become new-lines, and all other HTML is removed, keeping just the text inside it.
+func ToPlain(formatted string) string {
+	plain := paragraphRegex.ReplaceAllString(formatted, "\n\n")
+	plain = breakRegex.ReplaceAllString(plain, "\n")
+	plain = RemoveHTML(plain)
+	return strings.TrimSpace(html.UnescapeString(plain))
+}
@@ -26,6 +26,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 )
 
 const (
@@ -77,6 +78,14 @@ func (suite *PlainTestSuite) TestParseMoreComplex() {
 	assert.Equal(suite.T(), moreComplexFull, f)
 }
 
+func (suite *PlainTestSuite) TestToPlain() {
+	p := text.ToPlain(moreComplexFull)
+	assert.Equal(suite.T(), "Another test @foss_satan\n\n#Hashtag\n\nText", p)
+
+	p = text.ToPlain("<p>first paragraph &amp; stuff</p><p>second<br/>paragraph</p>")
+	assert.Equal(suite.T(), "first paragraph & stuff\n\nsecond\nparagraph", p)
+}
+
 func TestPlainTestSuite(t *testing.T) {
 	suite.Run(t, new(PlainTestSuite))
 }
@@ -20,8 +20,12 @@ package text
 
 import (
 	"regexp"
+	"strings"
+	"sync/atomic"
 
 	"github.com/microcosm-cc/bluemonday"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 )
 
 // '[A]llows a broad selection of HTML elements and attributes that are safe for user generated content.
@@ -46,11 +50,90 @@ var regular *bluemonday.Policy = bluemonday.UGCPolicy().
 // Source: https://github.com/microcosm-cc/bluemonday#usage
 var strict *bluemonday.Policy = bluemonday.StrictPolicy()
 
+// remoteClassRegex matches the classes we allow on elements from remote content: microformats
+// classes like h-card or u-url, and the handful of classes Mastodon uses to render mentions and links.
+var remoteClassRegex = regexp.MustCompile(`^(((h|p|u|dt|e)-[\w-]+|mention|hashtag|invisible|ellipsis)( |$))+$`)
+
+// remote caches the policy for sanitizing HTML received from remote instances, along with the
+// config it was built from. The policy is built the first time it's needed, and only rebuilt
+// if the configured elements or attributes change.
+var remote atomic.Value // *remoteSanitizer
+
+type remoteSanitizer struct {
+	policy     *bluemonday.Policy
+	elements   []string
+	attributes []string
+}
+
+// remotePolicy returns the cached policy for sanitizing HTML received from remote instances,
+// building it first if it hasn't been built yet or the config has changed since it was.
+func remotePolicy() *bluemonday.Policy {
+	elements := viper.GetStringSlice(config.Keys.StatusesRemoteAllowedElements)
+	attributes := viper.GetStringSlice(config.Keys.StatusesRemoteAllowedAttributes)
+
+	if r, ok := remote.Load().(*remoteSanitizer); ok && equalStrings(r.elements, elements) && equalStrings(r.attributes, attributes) {
+		return r.policy
+	}
+
+	p := newRemotePolicy(elements, attributes)
+	remote.Store(&remoteSanitizer{policy: p, elements: elements, attributes: attributes})
+	return p
+}
+
+// newRemotePolicy returns a policy which allows through only the given elements, and attributes in the form element.attribute.
+func newRemotePolicy(elements []string, attributes []string) *bluemonday.Policy {
+	p := bluemonday.NewPolicy()
+	p.AllowStandardURLs()
+	p.RequireNoReferrerOnLinks(true).
+		RequireNoFollowOnLinks(true).
+		AddTargetBlankToFullyQualifiedLinks(true)
+
+	p.AllowElements(elements...)
+
+	for _, a := range attributes {
+		element, attr, ok := strings.Cut(a, ".")
+		if !ok || element == "" || attr == "" {
+			continue
+		}
+
+		if attr == "class" {
+			p.AllowAttrs(attr).Matching(remoteClassRegex).OnElements(element)
+		} else {
+			p.AllowAttrs(attr).OnElements(element)
+		}
+	}
+
+	return p
+}
+
+func equalStrings(a []string, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
+
 // SanitizeHTML cleans up HTML in the given string, allowing through only safe HTML elements.
 func SanitizeHTML(in string) string {
 	return regular.Sanitize(in)
 }
 
+// SanitizeRemoteHTML cleans up HTML received from a remote instance, allowing through
+// only the elements and attributes permitted by the config.
+func SanitizeRemoteHTML(in string) string {
+	// The sanitizer escapes apostrophes, which are perfectly
+	// safe in HTML text and in the double-quoted attribute values
+	// it produces, so we put them back to keep content readable.
+	//
+	// Don't unescape anything else here; that would undo the sanitization.
+	return strings.ReplaceAll(remotePolicy().Sanitize(in), "&#39;", "'")
+}
+
 // RemoveHTML removes all HTML from the given string.
 func RemoveHTML(in string) string {
 	return strict.Sanitize(in)
@@ -21,8 +21,11 @@ package text_test
 import (
 	"testing"
 
+	"github.com/spf13/viper"
 	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/text"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 const (
@@ -39,12 +42,31 @@ const (
 
 	sanitizeOutgoing  = `<p>gotta test some fucking &#39;&#39;&#39;&#39;&#39;&#39;&#39;&#39;&#39; marks</p>`
 	sanitizedOutgoing = `<p>gotta test some fucking &#39;&#39;&#39;&#39;&#39;&#39;&#39;&#39;&#39; marks</p>`
+
+	remoteHTML          = `<p>here's a <span class="h-card"><a href="https://example.org/@someone" class="u-url mention">@<span>someone</span></a></span> and a <strong>bold</strong> <code>claim</code></p><ol start="3"><li>item</li></ol>`
+	sanitizedRemoteHTML = `<p>here's a <span class="h-card"><a href="https://example.org/@someone" class="u-url mention" rel="nofollow noreferrer noopener" target="_blank">@<span>someone</span></a></span> and a <strong>bold</strong> <code>claim</code></p><ol start="3"><li>item</li></ol>`
+
+	remoteScript          = `<p>hello</p><script>alert("pwned")</script><script src="https://evil.example.org/x.js"></script>`
+	sanitizedRemoteScript = `<p>hello</p>`
+
+	remoteJavascriptURL          = `<p><a href="javascript:alert(document.cookie)">click me</a> <a href="JaVaScRiPt:alert(1)">or me</a></p>`
+	sanitizedRemoteJavascriptURL = `<p>click me or me</p>`
+
+	remoteEventHandlers          = `<p onclick="alert(1)">look <img src="x" onerror="alert(1)"> <span class="evil" onmouseover="alert(1)" style="position:fixed">here</span></p>`
+	sanitizedRemoteEventHandlers = `<p>look  <span>here</span></p>`
+
+	remoteNotAllowed          = `<h1>big</h1><iframe src="https://evil.example.org"></iframe><form action="https://evil.example.org"><input type="password"></form><p>text</p>`
+	sanitizedRemoteNotAllowed = `big<p>text</p>`
 )
 
 type SanitizeTestSuite struct {
 	suite.Suite
 }
 
+func (suite *SanitizeTestSuite) SetupTest() {
+	testrig.InitTestConfig()
+}
+
 func (suite *SanitizeTestSuite) TestRemoveHTML() {
 	s := text.RemoveHTML(removeHTML)
 	suite.Equal(removedHTML, s)
@@ -70,6 +92,45 @@ func (suite *SanitizeTestSuite) TestSanitizeWithEscaped() {
 	suite.Equal(withEscapedExpected, s)
 }
 
+func (suite *SanitizeTestSuite) TestSanitizeRemoteHTML() {
+	s := text.SanitizeRemoteHTML(remoteHTML)
+	suite.Equal(sanitizedRemoteHTML, s)
+}
+
+func (suite *SanitizeTestSuite) TestSanitizeRemoteScript() {
+	s := text.SanitizeRemoteHTML(remoteScript)
+	suite.Equal(sanitizedRemoteScript, s)
+}
+
+func (suite *SanitizeTestSuite) TestSanitizeRemoteJavascriptURL() {
+	s := text.SanitizeRemoteHTML(remoteJavascriptURL)
+	suite.Equal(sanitizedRemoteJavascriptURL, s)
+}
+
+func (suite *SanitizeTestSuite) TestSanitizeRemoteEventHandlers() {
+	s := text.SanitizeRemoteHTML(remoteEventHandlers)
+	suite.Equal(sanitizedRemoteEventHandlers, s)
+}
+
+func (suite *SanitizeTestSuite) TestSanitizeRemoteNotAllowed() {
+	s := text.SanitizeRemoteHTML(remoteNotAllowed)
+	suite.Equal(sanitizedRemoteNotAllowed, s)
+}
+
+func (suite *SanitizeTestSuite) TestSanitizeRemoteEscapedScript() {
+	// escaped html should stay escaped, and not be turned back into tags
+	s := text.SanitizeRemoteHTML(`<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>`)
+	suite.Equal(`<p>&lt;script&gt;alert(1)&lt;/script&gt;</p>`, s)
+}
+
+func (suite *SanitizeTestSuite) TestSanitizeRemoteConfigured() {
+	viper.Set(config.Keys.StatusesRemoteAllowedElements, []string{"p", "a"})
+	viper.Set(config.Keys.StatusesRemoteAllowedAttributes, []string{"a.href"})
+
+	s := text.SanitizeRemoteHTML(remoteHTML)
+	suite.Equal(`<p>here's a <a href="https://example.org/@someone" rel="nofollow noreferrer noopener" target="_blank">@someone</a> and a bold claim</p>item`, s)
+}
+
 func TestSanitizeTestSuite(t *testing.T) {
 	suite.Run(t, new(SanitizeTestSuite))
 }
@@ -30,7 +30,7 @@ import (
 const retries = 5
 
 func (t *timeline) Get(ctx context.Context, amount int, maxID string, sinceID string, minID string, prepareNext bool) ([]Preparable, error) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":      "Get",
 		"accountID": t.accountID,
 		"amount":    amount,
@@ -136,7 +136,7 @@ func (t *timeline) GetXFromTop(ctx context.Context, amount int) ([]Preparable, e
 }
 
 func (t *timeline) GetXBehindID(ctx context.Context, amount int, behindID string, attempts *int) ([]Preparable, error) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":     "GetXBehindID",
 		"amount":   amount,
 		"behindID": behindID,
@@ -28,7 +28,7 @@ import (
 )
 
 func (t *timeline) IndexBefore(ctx context.Context, itemID string, amount int) error {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":   "IndexBefore",
 		"amount": amount,
 	})
@@ -81,7 +81,7 @@ grabloop:
 }
 
 func (t *timeline) IndexBehind(ctx context.Context, itemID string, amount int) error {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":   "IndexBehind",
 		"amount": amount,
 	})
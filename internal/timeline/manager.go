@@ -74,7 +74,7 @@ type Manager interface {
 	Remove(ctx context.Context, timelineAccountID string, itemID string) (int, error)
 	// WipeItemFromAllTimelines removes one item from the index and prepared items of all timelines
 	WipeItemFromAllTimelines(ctx context.Context, itemID string) error
-	// WipeStatusesFromAccountID removes all items by the given accountID from the timelineAccountID's timelines.
+	// WipeItemsFromAccountID removes all items by the given accountID, and boosts of them, from the timelineAccountID's timelines.
 	WipeItemsFromAccountID(ctx context.Context, timelineAccountID string, accountID string) error
 }
 
@@ -98,7 +98,7 @@ type manager struct {
 }
 
 func (m *manager) Ingest(ctx context.Context, item Timelineable, timelineAccountID string) (bool, error) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":              "Ingest",
 		"timelineAccountID": timelineAccountID,
 		"itemID":            item.GetID(),
@@ -114,7 +114,7 @@ func (m *manager) Ingest(ctx context.Context, item Timelineable, timelineAccount
 }
 
 func (m *manager) IngestAndPrepare(ctx context.Context, item Timelineable, timelineAccountID string) (bool, error) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":              "IngestAndPrepare",
 		"timelineAccountID": timelineAccountID,
 		"itemID":            item.GetID(),
@@ -130,7 +130,7 @@ func (m *manager) IngestAndPrepare(ctx context.Context, item Timelineable, timel
 }
 
 func (m *manager) Remove(ctx context.Context, timelineAccountID string, itemID string) (int, error) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":              "Remove",
 		"timelineAccountID": timelineAccountID,
 		"itemID":            itemID,
@@ -146,7 +146,7 @@ func (m *manager) Remove(ctx context.Context, timelineAccountID string, itemID s
 }
 
 func (m *manager) GetTimeline(ctx context.Context, timelineAccountID string, maxID string, sinceID string, minID string, limit int, local bool) ([]Preparable, error) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":              "GetTimeline",
 		"timelineAccountID": timelineAccountID,
 	})
@@ -29,7 +29,7 @@ import (
 )
 
 func (t *timeline) prepareNextQuery(ctx context.Context, amount int, maxID string, sinceID string, minID string) error {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":    "prepareNextQuery",
 		"amount":  amount,
 		"maxID":   maxID,
@@ -169,7 +169,7 @@ prepareloop:
 }
 
 func (t *timeline) PrepareFromTop(ctx context.Context, amount int) error {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":   "PrepareFromTop",
 		"amount": amount,
 	})
@@ -27,7 +27,7 @@ import (
 )
 
 func (t *timeline) Remove(ctx context.Context, statusID string) (int, error) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":            "Remove",
 		"accountTimeline": t.accountID,
 		"statusID":        statusID,
@@ -79,7 +79,7 @@ func (t *timeline) Remove(ctx context.Context, statusID string) (int, error) {
 }
 
 func (t *timeline) RemoveAllBy(ctx context.Context, accountID string) (int, error) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":            "RemoveAllBy",
 		"accountTimeline": t.accountID,
 		"accountID":       accountID,
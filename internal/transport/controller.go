@@ -39,6 +39,10 @@ import (
 type Controller interface {
 	NewTransport(pubKeyID string, privkey crypto.PrivateKey) (Transport, error)
 	NewTransportForUsername(ctx context.Context, username string) (Transport, error)
+	// NewTransportForInstance returns a transport which signs requests with the key of the instance actor.
+	// It should be used for requests made on behalf of the instance as a whole rather than any particular user,
+	// such as webfinger and nodeinfo lookups, so that remote servers can't tell which user triggered the request.
+	NewTransportForInstance(ctx context.Context) (Transport, error)
 }
 
 type controller struct {
@@ -128,6 +132,7 @@ func (c *controller) NewTransport(pubKeyID string, privkey crypto.PrivateKey) (T
 	sigTransport := pub.NewHttpSigTransport(c.client, c.appAgent, c.clock, getSigner, postSigner, pubKeyID, privkey)
 
 	return &transport{
+		db:                           c.db,
 		client:                       c.client,
 		appAgent:                     c.appAgent,
 		gofedAgent:                   "(go-fed/activity v1.0.0)",
@@ -137,6 +142,8 @@ func (c *controller) NewTransport(pubKeyID string, privkey crypto.PrivateKey) (T
 		sigTransport:                 sigTransport,
 		getSigner:                    getSigner,
 		getSignerMu:                  &sync.Mutex{},
+		postSigner:                   postSigner,
+		postSignerMu:                 &sync.Mutex{},
 		dereferenceFollowersShortcut: c.dereferenceFollowersShortcut,
 		dereferenceUserShortcut:      c.dereferenceUserShortcut,
 	}, nil
@@ -146,14 +153,11 @@ func (c *controller) NewTransportForUsername(ctx context.Context, username strin
 	// We need an account to use to create a transport for dereferecing something.
 	// If a username has been given, we can fetch the account with that username and use it.
 	// Otherwise, we can take the instance account and use those credentials to make the request.
-	var u string
 	if username == "" {
-		u = viper.GetString(config.Keys.Host)
-	} else {
-		u = username
+		return c.NewTransportForInstance(ctx)
 	}
 
-	ourAccount, err := c.db.GetLocalAccountByUsername(ctx, u)
+	ourAccount, err := c.db.GetLocalAccountByUsername(ctx, username)
 	if err != nil {
 		return nil, fmt.Errorf("error getting account %s from db: %s", username, err)
 	}
@@ -164,3 +168,16 @@ func (c *controller) NewTransportForUsername(ctx context.Context, username strin
 	}
 	return transport, nil
 }
+
+func (c *controller) NewTransportForInstance(ctx context.Context) (Transport, error) {
+	instanceAccount, err := c.db.GetInstanceAccount(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("error getting instance account from db: %s", err)
+	}
+
+	transport, err := c.NewTransport(instanceAccount.PublicKeyURI, instanceAccount.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("error creating transport for instance account: %s", err)
+	}
+	return transport, nil
+}
@@ -72,6 +72,16 @@ func (t *transport) Deliver(ctx context.Context, b []byte, to *url.URL) error {
 		return nil
 	}
 
+	// if federation with the 'to' host is paused, just drop this delivery
+	paused, err := t.db.IsDomainPaused(ctx, to.Host)
+	if err != nil {
+		return fmt.Errorf("Deliver: error checking domain pause for %s: %s", to.Host, err)
+	}
+	if paused {
+		logrus.Debugf("Deliver: dropping delivery to %s since federation with its domain is paused", to.String())
+		return nil
+	}
+
 	logrus.Debugf("Deliver: posting as %s to %s", t.pubKeyID, to.String())
 	return t.sigTransport.Deliver(ctx, b, to)
 }
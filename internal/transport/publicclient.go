@@ -0,0 +1,115 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package transport
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+)
+
+// maxPublicClientRedirects is the maximum number of redirects a public client will follow for one request.
+const maxPublicClientRedirects = 5
+
+// ErrNonPublicAddress is returned when a public client refuses to connect to a private, loopback, link-local or otherwise non-public address.
+var ErrNonPublicAddress = errors.New("refusing to connect to non-public address")
+
+// ErrBlockedRedirect is returned when a public client refuses to follow a redirect to a blocked domain.
+var ErrBlockedRedirect = errors.New("refusing to follow redirect to blocked domain")
+
+// NewPublicClient returns an http client for fetching arbitrary urls given to us by users, such as the links in their statuses.
+// It only makes requests to http and https urls, including when following redirects, and refuses to connect to any address
+// that isn't on the public internet, so that users can't get us to make requests to this server or its internal network.
+//
+// Addresses are checked after dns resolution, just before connecting, so a hostname can't be pointed at a private address
+// to get around the check, and every redirect is checked the same way. Redirects to domains blocked in the given domain
+// db aren't followed either, since we shouldn't be fetching anything from them just because someone else linked to them. Proxies from the environment are deliberately not
+// used, since we'd only be checking the address of the proxy and not the address the proxy connects to. The client has no
+// overall timeout: requests should be given a context with a deadline instead.
+func NewPublicClient(domainDB db.Domain) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control:   publicAddressControl,
+	}
+
+	return &http.Client{
+		Transport: &http.Transport{
+			DialContext:           dialer.DialContext,
+			ForceAttemptHTTP2:     true,
+			MaxIdleConns:          100,
+			IdleConnTimeout:       90 * time.Second,
+			TLSHandshakeTimeout:   10 * time.Second,
+			ExpectContinueTimeout: 1 * time.Second,
+		},
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxPublicClientRedirects {
+				return fmt.Errorf("stopped after %d redirects", maxPublicClientRedirects)
+			}
+			if req.URL.Scheme != "http" && req.URL.Scheme != "https" {
+				return fmt.Errorf("refusing to follow redirect to %s url", req.URL.Scheme)
+			}
+			blocked, err := domainDB.IsURIBlocked(req.Context(), req.URL)
+			if err != nil {
+				return fmt.Errorf("error checking domain block of redirect to %s: %s", req.URL, err)
+			}
+			if blocked {
+				return fmt.Errorf("%w %s", ErrBlockedRedirect, req.URL.Host)
+			}
+			return nil
+		},
+	}
+}
+
+// publicAddressControl is a net.Dialer control function which aborts connections to non-public addresses.
+func publicAddressControl(network string, address string, c syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil || !IsPublicIP(ip) {
+		return fmt.Errorf("%w %s", ErrNonPublicAddress, host)
+	}
+
+	return nil
+}
+
+// IsPublicIP returns true if the given ip address is a globally routable unicast address, and not
+// a private, loopback, link-local, multicast or unspecified one (such as 10.0.0.1, 127.0.0.1 or ::1).
+func IsPublicIP(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+		// carrier-grade nat (100.64.0.0/10) isn't covered by IsPrivate, and 0.0.0.0/8 means "this network"
+		if ip[0] == 0 || (ip[0] == 100 && ip[1]&0xc0 == 64) {
+			return false
+		}
+	}
+
+	return ip.IsGlobalUnicast() &&
+		!ip.IsPrivate() &&
+		!ip.IsLoopback() &&
+		!ip.IsLinkLocalUnicast()
+}
@@ -0,0 +1,106 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package transport_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
+	"github.com/superseriousbusiness/gotosocial/internal/transport"
+)
+
+type PublicClientTestSuite struct {
+	suite.Suite
+}
+
+func (suite *PublicClientTestSuite) TestIsPublicIP() {
+	for ip, public := range map[string]bool{
+		"93.184.216.34":                      true,
+		"2606:2800:220:1:248:1893:25c8:1946": true,
+		"127.0.0.1":                          false,
+		"10.1.2.3":                           false,
+		"172.16.0.1":                         false,
+		"192.168.1.1":                        false,
+		"169.254.169.254":                    false,
+		"100.64.0.1":                         false,
+		"0.0.0.0":                            false,
+		"224.0.0.1":                          false,
+		"::1":                                false,
+		"fe80::1":                            false,
+		"fd00::1":                            false,
+		"::ffff:127.0.0.1":                   false,
+	} {
+		suite.Equal(public, transport.IsPublicIP(net.ParseIP(ip)), ip)
+	}
+}
+
+func (suite *PublicClientTestSuite) TestRefusesLoopback() {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	req, err := http.NewRequestWithContext(context.Background(), http.MethodGet, server.URL, nil)
+	suite.NoError(err)
+
+	_, err = transport.NewPublicClient(&blockedDomains{}).Do(req)
+	suite.True(errors.Is(err, transport.ErrNonPublicAddress))
+}
+
+func (suite *PublicClientTestSuite) TestRefusesBlockedRedirect() {
+	client := transport.NewPublicClient(&blockedDomains{domains: []string{"replyguys.com"}})
+
+	from, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://example.org/some/link", nil)
+	suite.NoError(err)
+
+	to, err := http.NewRequestWithContext(context.Background(), http.MethodGet, "https://replyguys.com/some/page", nil)
+	suite.NoError(err)
+	err = client.CheckRedirect(to, []*http.Request{from})
+	suite.True(errors.Is(err, transport.ErrBlockedRedirect))
+
+	to, err = http.NewRequestWithContext(context.Background(), http.MethodGet, "https://example.org/some/page", nil)
+	suite.NoError(err)
+	suite.NoError(client.CheckRedirect(to, []*http.Request{from}))
+}
+
+// blockedDomains is a domain db which only knows whether uris are blocked.
+type blockedDomains struct {
+	db.Domain
+	domains []string
+}
+
+func (b *blockedDomains) IsURIBlocked(ctx context.Context, uri *url.URL) (bool, db.Error) {
+	for _, domain := range b.domains {
+		if uri.Hostname() == domain {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func TestPublicClientTestSuite(t *testing.T) {
+	suite.Run(t, new(PublicClientTestSuite))
+}
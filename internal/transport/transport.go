@@ -22,11 +22,13 @@ import (
 	"context"
 	"crypto"
 	"io"
+	"net/http"
 	"net/url"
 	"sync"
 
 	"github.com/go-fed/httpsig"
 	"github.com/superseriousbusiness/activity/pub"
+	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
 
@@ -43,12 +45,16 @@ type Transport interface {
 	DereferenceInstance(ctx context.Context, iri *url.URL) (*gtsmodel.Instance, error)
 	// Finger performs a webfinger request with the given username and domain, and returns the bytes from the response body.
 	Finger(ctx context.Context, targetUsername string, targetDomains string) ([]byte, error)
+	// SignRequest signs the given outgoing request with the key of the actor that this transport belongs to.
+	// body should be the body of the request for requests that have one (such as POST), or nil otherwise.
+	SignRequest(req *http.Request, body []byte) error
 	// SigTransport returns the underlying http signature transport wrapped by the GoToSocial transport.
 	SigTransport() pub.Transport
 }
 
 // transport implements the Transport interface
 type transport struct {
+	db           db.DB
 	client       pub.HttpClient
 	appAgent     string
 	gofedAgent   string
@@ -58,6 +64,8 @@ type transport struct {
 	sigTransport *pub.HttpSigTransport
 	getSigner    httpsig.Signer
 	getSignerMu  *sync.Mutex
+	postSigner   httpsig.Signer
+	postSignerMu *sync.Mutex
 
 	// shortcuts for dereferencing things that exist on our instance without making an http call to ourself
 
@@ -65,6 +73,19 @@ type transport struct {
 	dereferenceUserShortcut      func(ctx context.Context, iri *url.URL) ([]byte, error)
 }
 
+func (t *transport) SignRequest(req *http.Request, body []byte) error {
+	// requests without a body are signed without a digest
+	if body == nil {
+		t.getSignerMu.Lock()
+		defer t.getSignerMu.Unlock()
+		return t.getSigner.SignRequest(t.privkey, t.pubKeyID, req, nil)
+	}
+
+	t.postSignerMu.Lock()
+	defer t.postSignerMu.Unlock()
+	return t.postSigner.SignRequest(t.privkey, t.pubKeyID, req, body)
+}
+
 func (t *transport) SigTransport() pub.Transport {
 	return t.sigTransport
 }
@@ -22,12 +22,14 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"strings"
 
 	"github.com/sirupsen/logrus"
 
 	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 )
 
 func (c *converter) ASRepresentationToAccount(ctx context.Context, accountable ap.Accountable, update bool) (*gtsmodel.Account, error) {
@@ -81,7 +83,7 @@ func (c *converter) ASRepresentationToAccount(ctx context.Context, accountable a
 	// we default to the username, but take the more nuanced name property if it exists
 	acct.DisplayName = username
 	if displayName, err := ap.ExtractName(accountable); err == nil {
-		acct.DisplayName = displayName
+		acct.DisplayName = text.SanitizeCaption(displayName)
 	}
 
 	// TODO: fields aka attachment array
@@ -89,7 +91,7 @@ func (c *converter) ASRepresentationToAccount(ctx context.Context, accountable a
 	// note aka summary
 	note, err := ap.ExtractSummary(accountable)
 	if err == nil && note != "" {
-		acct.Note = note
+		acct.Note = text.SanitizeRemoteHTML(note)
 	}
 
 	// check for bot and actor type
@@ -194,7 +196,7 @@ func (c *converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 	if content, err := ap.ExtractContent(statusable); err != nil {
 		l.Infof("ASStatusToStatus: error extracting status content: %s", err)
 	} else {
-		status.Content = content
+		status.Content = text.SanitizeRemoteHTML(content)
 	}
 
 	// attachments to dereference and fetch later on (we don't do that here)
@@ -229,7 +231,7 @@ func (c *converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 	if cw, err := ap.ExtractSummary(statusable); err != nil {
 		l.Infof("ASStatusToStatus: error extracting status summary: %s", err)
 	} else {
-		status.ContentWarning = cw
+		status.ContentWarning = text.SanitizeCaption(cw)
 	}
 
 	// when was this status created?
@@ -279,6 +281,21 @@ func (c *converter) ASStatusToStatus(ctx context.Context, statusable ap.Statusab
 		}
 	}
 
+	// check if there's a post that this status quotes
+	quoteURI := ap.ExtractQuoteURI(statusable)
+	if quoteURI != nil {
+		status.QuoteOfURI = quoteURI.String()
+
+		// we don't go and dereference quoted statuses, but if we've got it already we can link it
+		if quotedStatus, err := c.db.GetStatusByURI(ctx, quoteURI.String()); err == nil {
+			status.QuoteOfID = quotedStatus.ID
+			status.QuoteOf = quotedStatus
+		}
+	}
+
+	// who is allowed to reply to this status
+	status.ReplyPolicy = ap.ExtractReplyPolicy(statusable)
+
 	// visibility entry for this status
 	visibility, err := ap.ExtractVisibility(statusable, status.Account.FollowersURI)
 	if err != nil {
@@ -422,6 +439,61 @@ func (c *converter) ASLikeToFave(ctx context.Context, likeable ap.Likeable) (*gt
 	}, nil
 }
 
+func (c *converter) ASLikeToReaction(ctx context.Context, reactable ap.Reactable) (*gtsmodel.StatusReaction, error) {
+	name := ap.ExtractReaction(reactable)
+	if name == "" {
+		return nil, errors.New("ASLikeToReaction: like did not carry a reaction")
+	}
+
+	// the accounts and status involved are just the same as for a fave
+	fave, err := c.ASLikeToFave(ctx, reactable)
+	if err != nil {
+		return nil, fmt.Errorf("ASLikeToReaction: %s", err)
+	}
+
+	reaction := &gtsmodel.StatusReaction{
+		AccountID:       fave.AccountID,
+		Account:         fave.Account,
+		TargetAccountID: fave.TargetAccountID,
+		TargetAccount:   fave.TargetAccount,
+		StatusID:        fave.StatusID,
+		Status:          fave.Status,
+		Name:            name,
+		URI:             fave.URI,
+	}
+
+	shortcode := strings.Trim(name, ":")
+	if shortcode == name {
+		// not a custom emoji
+		return reaction, nil
+	}
+
+	// find the emoji tag that goes with this reaction
+	emojis, err := ap.ExtractEmojis(reactable)
+	if err != nil {
+		return nil, fmt.Errorf("ASLikeToReaction: error extracting emojis: %s", err)
+	}
+	for _, e := range emojis {
+		if e.Shortcode != shortcode {
+			continue
+		}
+
+		// we might have seen this emoji before
+		known := &gtsmodel.Emoji{}
+		if err := c.db.GetWhere(ctx, []db.Where{{Key: "uri", Value: e.URI}}, known); err == nil {
+			reaction.EmojiID = known.ID
+			reaction.Emoji = known
+		} else if err == db.ErrNoEntries {
+			reaction.Emoji = e
+		} else {
+			return nil, fmt.Errorf("ASLikeToReaction: error fetching emoji %s from database: %s", e.URI, err)
+		}
+		break
+	}
+
+	return reaction, nil
+}
+
 func (c *converter) ASBlockToBlock(ctx context.Context, blockable ap.Blockable) (*gtsmodel.Block, error) {
 	idProp := blockable.GetJSONLDId()
 	if idProp == nil || !idProp.IsIRI() {
@@ -66,8 +66,14 @@ type TypeConverter interface {
 	MentionToAPIMention(ctx context.Context, m *gtsmodel.Mention) (model.Mention, error)
 	// EmojiToAPIEmoji converts a gts model emoji into its api (frontend) representation for serialization on the API.
 	EmojiToAPIEmoji(ctx context.Context, e *gtsmodel.Emoji) (model.Emoji, error)
+	// EmojiToAdminAPIEmoji converts a gts model emoji into its admin api representation, which includes its id and state.
+	EmojiToAdminAPIEmoji(ctx context.Context, e *gtsmodel.Emoji) (*model.AdminEmoji, error)
 	// TagToAPITag converts a gts model tag into its api (frontend) representation for serialization on the API.
 	TagToAPITag(ctx context.Context, t *gtsmodel.Tag) (model.Tag, error)
+	// TrendingTagToAPITag converts a gts model trending tag into an api tag, including its usage history.
+	TrendingTagToAPITag(ctx context.Context, t *gtsmodel.TrendingTag) (model.Tag, error)
+	// PreviewCardToAPICard converts a gts model preview card into its api representation, for serving as part of a status.
+	PreviewCardToAPICard(ctx context.Context, p *gtsmodel.PreviewCard) (*model.Card, error)
 	// StatusToAPIStatus converts a gts model status into its api (frontend) representation for serialization on the API.
 	//
 	// Requesting account can be nil.
@@ -82,6 +88,12 @@ type TypeConverter interface {
 	NotificationToAPINotification(ctx context.Context, n *gtsmodel.Notification) (*model.Notification, error)
 	// DomainBlockToAPIDomainBlock converts a gts model domin block into a api domain block, for serving at /api/v1/admin/domain_blocks
 	DomainBlockToAPIDomainBlock(ctx context.Context, b *gtsmodel.DomainBlock, export bool) (*model.DomainBlock, error)
+	// DomainPauseToAPIDomainPause converts a gts model domain pause into an api domain pause, for serving at /api/v1/admin/domain_pauses
+	DomainPauseToAPIDomainPause(ctx context.Context, p *gtsmodel.DomainPause) (*model.DomainPause, error)
+	// DomainSilenceToAPIDomainSilence converts a gts model domain silence into an api domain silence, for serving at /api/v1/admin/domain_silences
+	DomainSilenceToAPIDomainSilence(ctx context.Context, s *gtsmodel.DomainSilence) (*model.DomainSilence, error)
+	// ModerationRuleToAPIModerationRule converts a gts model moderation rule into an api moderation rule, for serving at /api/v1/admin/moderation_rules
+	ModerationRuleToAPIModerationRule(ctx context.Context, r *gtsmodel.ModerationRule) (*model.ModerationRule, error)
 
 	/*
 		FRONTEND (api) MODEL TO INTERNAL (gts) MODEL
@@ -108,6 +120,9 @@ type TypeConverter interface {
 	ASFollowToFollow(ctx context.Context, followable ap.Followable) (*gtsmodel.Follow, error)
 	// ASLikeToFave converts a remote activitystreams 'like' representation into a gts model status fave.
 	ASLikeToFave(ctx context.Context, likeable ap.Likeable) (*gtsmodel.StatusFave, error)
+	// ASLikeToReaction converts a remote activitystreams 'like' carrying an emoji reaction into a gts model status reaction.
+	// If the reaction uses a custom emoji we don't have yet, Emoji will be set to the unsaved emoji, and EmojiID will be empty.
+	ASLikeToReaction(ctx context.Context, reactable ap.Reactable) (*gtsmodel.StatusReaction, error)
 	// ASBlockToBlock converts a remote activity streams 'block' representation into a gts model block.
 	ASBlockToBlock(ctx context.Context, blockable ap.Blockable) (*gtsmodel.Block, error)
 	// ASAnnounceToStatus converts an activitystreams 'announce' into a status.
@@ -146,6 +161,10 @@ type TypeConverter interface {
 	AttachmentToAS(ctx context.Context, a *gtsmodel.MediaAttachment) (vocab.ActivityStreamsDocument, error)
 	// FaveToAS converts a gts model status fave into an activityStreams LIKE, suitable for federation.
 	FaveToAS(ctx context.Context, f *gtsmodel.StatusFave) (vocab.ActivityStreamsLike, error)
+	// ReactionToAS converts a gts model status reaction into an activityStreams LIKE carrying the reaction, suitable for federation.
+	ReactionToAS(ctx context.Context, r *gtsmodel.StatusReaction) (vocab.ActivityStreamsLike, error)
+	// EmojiToAS converts a gts model custom emoji into an activityStreams Emoji, suitable for use as a tag.
+	EmojiToAS(ctx context.Context, e *gtsmodel.Emoji) (vocab.TootEmoji, error)
 	// BoostToAS converts a gts model boost into an activityStreams ANNOUNCE, suitable for federation
 	BoostToAS(ctx context.Context, boostWrapperStatus *gtsmodel.Status, boostingAccount *gtsmodel.Account, boostedAccount *gtsmodel.Account) (vocab.ActivityStreamsAnnounce, error)
 	// BlockToAS converts a gts model block into an activityStreams BLOCK, suitable for federation.
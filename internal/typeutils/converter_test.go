@@ -367,6 +367,34 @@ const (
 		}
 	  }	  
 	`
+	quoteStatusActivityJson = `
+	{
+		"@context": [
+		  "https://www.w3.org/ns/activitystreams",
+		  {
+			"fedibird": "http://fedibird.com/ns#",
+			"quoteUri": "fedibird:quoteUri",
+			"quoteUrl": "as:quoteUrl"
+		  }
+		],
+		"id": "http://fossbros-anonymous.io/users/foss_satan/statuses/108445115482763111",
+		"type": "Note",
+		"published": "2022-06-08T12:00:00Z",
+		"url": "http://fossbros-anonymous.io/@foss_satan/108445115482763111",
+		"attributedTo": "http://fossbros-anonymous.io/users/foss_satan",
+		"to": [
+		  "https://www.w3.org/ns/activitystreams#Public"
+		],
+		"cc": [
+		  "http://fossbros-anonymous.io/users/foss_satan/followers"
+		],
+		"content": "<p>what a post</p><p class=\"quote-inline\">RE: <a href=\"http://localhost:8080/@the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY\">http://localhost:8080/@the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY</a></p>",
+		"quoteUri": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+		"quoteUrl": "http://localhost:8080/users/the_mighty_zork/statuses/01F8MHAMCHF6Y650WCRSCP4WMY",
+		"attachment": [],
+		"tag": []
+	  }
+	`
 )
 
 type TypeUtilsTestSuite struct {
@@ -74,6 +74,7 @@ func (c *converter) StatusToBoost(ctx context.Context, s *gtsmodel.Status, boost
 		Boostable:           s.Boostable,
 		Replyable:           s.Replyable,
 		Likeable:            s.Likeable,
+		ReplyPolicy:         s.ReplyPolicy,
 
 		// attach these here for convenience -- the boosted status/account won't go in the DB
 		// but they're needed in the processor and for the frontend. Since we have them, we can
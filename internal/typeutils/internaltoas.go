@@ -24,12 +24,14 @@ import (
 	"encoding/pem"
 	"fmt"
 	"net/url"
+	"time"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 	"github.com/superseriousbusiness/activity/pub"
 	"github.com/superseriousbusiness/activity/streams"
 	"github.com/superseriousbusiness/activity/streams/vocab"
+	"github.com/superseriousbusiness/gotosocial/internal/ap"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/gtserror"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
@@ -207,7 +209,22 @@ func (c *converter) AccountToAS(ctx context.Context, a *gtsmodel.Account) (vocab
 
 	// attachment
 	// Used for profile fields.
-	// TODO: The PropertyValue type has to be added: https://schema.org/PropertyValue
+	// The PropertyValue type (https://schema.org/PropertyValue) isn't in the activity library, so set them by hand.
+	if len(a.Fields) != 0 {
+		fields := make([]interface{}, 0, len(a.Fields))
+		for _, f := range a.Fields {
+			field := map[string]interface{}{
+				"type":  ap.ObjectPropertyValue,
+				"name":  f.Name,
+				"value": f.Value,
+			}
+			if !f.VerifiedAt.IsZero() {
+				field[ap.VerifiedAtProperty] = f.VerifiedAt.UTC().Format(time.RFC3339)
+			}
+			fields = append(fields, field)
+		}
+		person.GetUnknownProperties()["attachment"] = fields
+	}
 
 	// endpoints
 	// NOT IMPLEMENTED -- this is for shared inbox which we don't use
@@ -409,6 +426,20 @@ func (c *converter) StatusToAS(ctx context.Context, s *gtsmodel.Status) (vocab.A
 		status.SetActivityStreamsInReplyTo(inReplyToProp)
 	}
 
+	// quote
+	// there's no standard property for this yet, so set all the ones other software looks for
+	if s.QuoteOfURI != "" {
+		for _, p := range ap.QuoteProperties {
+			status.GetUnknownProperties()[p] = s.QuoteOfURI
+		}
+	}
+
+	// reply policy
+	// only set if it's not the default, since other software doesn't know about it anyway
+	if s.ReplyPolicy != "" && s.ReplyPolicy != gtsmodel.ReplyPolicyEveryone {
+		status.GetUnknownProperties()[ap.ReplyPolicyProperty] = string(s.ReplyPolicy)
+	}
+
 	// published
 	publishedProp := streams.NewActivityStreamsPublishedProperty()
 	publishedProp.Set(s.CreatedAt)
@@ -761,6 +792,107 @@ func (c *converter) FaveToAS(ctx context.Context, f *gtsmodel.StatusFave) (vocab
 	return like, nil
 }
 
+/*
+	We want to end up with something like this, which Misskey understands as a reaction,
+	and which other software that doesn't know about reactions will still see as a like:
+
+	{
+	"@context": "https://www.w3.org/ns/activitystreams",
+	"actor": "http://localhost:8080/users/the_mighty_zork",
+	"id": "http://localhost:8080/users/the_mighty_zork/liked/01G4A4ZJ0KV1BNY60C6YW5ABRC",
+	"object": "http://fossbros-anonymous.io/users/foss_satan/statuses/01FVW7JHQFSFK166WWKR8CBA6M",
+	"type": "Like",
+	"content": ":rainbow:",
+	"_misskey_reaction": ":rainbow:",
+	"tag": [{"type": "Emoji", "name": ":rainbow:", ...}]
+	}
+*/
+func (c *converter) ReactionToAS(ctx context.Context, r *gtsmodel.StatusReaction) (vocab.ActivityStreamsLike, error) {
+	// a reaction is just a like with some extra bits on, so start from that
+	like, err := c.FaveToAS(ctx, &gtsmodel.StatusFave{
+		ID:              r.ID,
+		AccountID:       r.AccountID,
+		Account:         r.Account,
+		TargetAccountID: r.TargetAccountID,
+		TargetAccount:   r.TargetAccount,
+		StatusID:        r.StatusID,
+		Status:          r.Status,
+		URI:             r.URI,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("ReactionToAS: error converting reaction to like: %s", err)
+	}
+
+	contentProp := streams.NewActivityStreamsContentProperty()
+	contentProp.AppendXMLSchemaString(r.Name)
+	like.SetActivityStreamsContent(contentProp)
+	like.GetUnknownProperties()[ap.MisskeyReactionProperty] = r.Name
+
+	if r.EmojiID == "" {
+		// unicode emoji, nothing else to do
+		return like, nil
+	}
+
+	if r.Emoji == nil {
+		e := &gtsmodel.Emoji{}
+		if err := c.db.GetByID(ctx, r.EmojiID, e); err != nil {
+			return nil, fmt.Errorf("ReactionToAS: error fetching emoji %s from database: %s", r.EmojiID, err)
+		}
+		r.Emoji = e
+	}
+
+	emoji, err := c.EmojiToAS(ctx, r.Emoji)
+	if err != nil {
+		return nil, fmt.Errorf("ReactionToAS: error converting emoji: %s", err)
+	}
+	tagProp := streams.NewActivityStreamsTagProperty()
+	tagProp.AppendTootEmoji(emoji)
+	like.SetActivityStreamsTag(tagProp)
+
+	return like, nil
+}
+
+func (c *converter) EmojiToAS(ctx context.Context, e *gtsmodel.Emoji) (vocab.TootEmoji, error) {
+	emoji := streams.NewTootEmoji()
+
+	idProp := streams.NewJSONLDIdProperty()
+	idIRI, err := url.Parse(e.URI)
+	if err != nil {
+		return nil, fmt.Errorf("EmojiToAS: error parsing uri %s: %s", e.URI, err)
+	}
+	idProp.Set(idIRI)
+	emoji.SetJSONLDId(idProp)
+
+	nameProp := streams.NewActivityStreamsNameProperty()
+	nameProp.AppendXMLSchemaString(":" + e.Shortcode + ":")
+	emoji.SetActivityStreamsName(nameProp)
+
+	updatedProp := streams.NewActivityStreamsUpdatedProperty()
+	updatedProp.Set(e.ImageUpdatedAt)
+	emoji.SetActivityStreamsUpdated(updatedProp)
+
+	imageURL := e.ImageURL
+	if imageURL == "" {
+		imageURL = e.ImageRemoteURL
+	}
+	imageIRI, err := url.Parse(imageURL)
+	if err != nil {
+		return nil, fmt.Errorf("EmojiToAS: error parsing url %s: %s", imageURL, err)
+	}
+	image := streams.NewActivityStreamsImage()
+	mediaTypeProp := streams.NewActivityStreamsMediaTypeProperty()
+	mediaTypeProp.Set(e.ImageContentType)
+	image.SetActivityStreamsMediaType(mediaTypeProp)
+	urlProp := streams.NewActivityStreamsUrlProperty()
+	urlProp.AppendIRI(imageIRI)
+	image.SetActivityStreamsUrl(urlProp)
+	iconProp := streams.NewActivityStreamsIconProperty()
+	iconProp.AppendActivityStreamsImage(image)
+	emoji.SetActivityStreamsIcon(iconProp)
+
+	return emoji, nil
+}
+
 func (c *converter) BoostToAS(ctx context.Context, boostWrapperStatus *gtsmodel.Status, boostingAccount *gtsmodel.Account, boostedAccount *gtsmodel.Account) (vocab.ActivityStreamsAnnounce, error) {
 	// the boosted status is probably pinned to the boostWrapperStatus but double check to make sure
 	if boostWrapperStatus.BoostOf == nil {
@@ -27,6 +27,8 @@ import (
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/suite"
 	"github.com/superseriousbusiness/activity/streams"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/testrig"
 )
 
 type InternalToASTestSuite struct {
@@ -49,6 +51,26 @@ func (suite *InternalToASTestSuite) TestAccountToAS() {
 	// TODO: write assertions here, rn we're just eyeballing the output
 }
 
+func (suite *InternalToASTestSuite) TestAccountToASWithFields() {
+	testAccount := &gtsmodel.Account{}
+	*testAccount = *suite.testAccounts["local_account_1"]
+	testAccount.Fields = []gtsmodel.Field{
+		{Name: "website", Value: "https://example.org", VerifiedAt: testrig.TimeMustParse("2022-06-01T10:00:00Z")},
+		{Name: "pronouns", Value: "they/them"},
+	}
+
+	asPerson, err := suite.typeconverter.AccountToAS(context.Background(), testAccount)
+	suite.NoError(err)
+
+	ser, err := streams.Serialize(asPerson)
+	suite.NoError(err)
+
+	bytes, err := json.Marshal(ser["attachment"])
+	suite.NoError(err)
+
+	suite.Equal(`[{"name":"website","type":"PropertyValue","value":"https://example.org","verifiedAt":"2022-06-01T10:00:00Z"},{"name":"pronouns","type":"PropertyValue","value":"they/them"}]`, string(bytes))
+}
+
 func (suite *InternalToASTestSuite) TestOutboxToASCollection() {
 	testAccount := suite.testAccounts["admin_account"]
 	ctx := context.Background()
@@ -127,12 +149,65 @@ func (suite *InternalToASTestSuite) TestStatusToASNotSensitive() {
 	suite.Equal(`{"@context":"https://www.w3.org/ns/activitystreams","attachment":[],"attributedTo":"http://localhost:8080/users/admin","cc":"http://localhost:8080/users/admin/followers","content":"hello world! #welcome ! first post on the instance :rainbow: !","id":"http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R","published":"2021-10-20T11:36:45Z","replies":{"first":{"id":"http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R/replies?page=true","next":"http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R/replies?only_other_accounts=false\u0026page=true","partOf":"http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R/replies","type":"CollectionPage"},"id":"http://localhost:8080/users/admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R/replies","type":"Collection"},"sensitive":false,"summary":"","tag":[],"to":"https://www.w3.org/ns/activitystreams#Public","type":"Note","url":"http://localhost:8080/@admin/statuses/01F8MH75CBF9JFX4ZAD54N0W0R"}`, string(bytes))
 }
 
+func (suite *InternalToASTestSuite) TestStatusToASWithQuote() {
+	testStatus := &gtsmodel.Status{}
+	*testStatus = *suite.testStatuses["local_account_1_status_1"]
+	quotedStatus := suite.testStatuses["local_account_2_status_4"]
+	testStatus.ID = "01G50TB0WJ5F1WYG6J4G2NQM4M" // use a new id so we don't get a cached note
+	testStatus.QuoteOfID = quotedStatus.ID
+	testStatus.QuoteOfURI = quotedStatus.URI
+	ctx := context.Background()
+
+	asStatus, err := suite.typeconverter.StatusToAS(ctx, testStatus)
+	suite.NoError(err)
+
+	ser, err := streams.Serialize(asStatus)
+	suite.NoError(err)
+
+	suite.Equal(quotedStatus.URI, ser["quoteUri"])
+	suite.Equal(quotedStatus.URI, ser["quoteUrl"])
+	suite.Equal(quotedStatus.URI, ser["_misskey_quote"])
+}
+
+func (suite *InternalToASTestSuite) TestReactionToAS() {
+	ctx := context.Background()
+
+	reaction := &gtsmodel.StatusReaction{
+		ID:              "01G4A4ZJ0KV1BNY60C6YW5ABRC",
+		AccountID:       suite.testAccounts["local_account_1"].ID,
+		TargetAccountID: suite.testAccounts["remote_account_1"].ID,
+		StatusID:        suite.testStatuses["remote_account_1_status_1"].ID,
+		Name:            ":rainbow:",
+		EmojiID:         "01F8MH9H8E4VG3KDYJR9EGPXCQ",
+		URI:             "http://localhost:8080/users/the_mighty_zork/liked/01G4A4ZJ0KV1BNY60C6YW5ABRC",
+	}
+
+	asReaction, err := suite.typeconverter.ReactionToAS(ctx, reaction)
+	suite.NoError(err)
+
+	ser, err := streams.Serialize(asReaction)
+	suite.NoError(err)
+
+	// the test emoji's updated time is always now, so don't bother comparing it
+	tag, ok := ser["tag"].(map[string]interface{})
+	suite.True(ok)
+	delete(tag, "updated")
+
+	// the order of the contexts isn't stable, so don't bother comparing those either
+	delete(ser, "@context")
+
+	bytes, err := json.Marshal(ser)
+	suite.NoError(err)
+
+	suite.Equal(`{"_misskey_reaction":":rainbow:","actor":"http://localhost:8080/users/the_mighty_zork","content":":rainbow:","id":"http://localhost:8080/users/the_mighty_zork/liked/01G4A4ZJ0KV1BNY60C6YW5ABRC","object":"http://fossbros-anonymous.io/users/foss_satan/statuses/01FVW7JHQFSFK166WWKR8CBA6M","tag":{"icon":{"mediaType":"image/png","type":"Image","url":"http://localhost:8080/fileserver/01F8MH261H1KSV3GW3016GZRY3/emoji/original/01F8MH9H8E4VG3KDYJR9EGPXCQ.png"},"id":"http://localhost:8080/emoji/01F8MH9H8E4VG3KDYJR9EGPXCQ","name":":rainbow:","type":"Emoji"},"to":"http://fossbros-anonymous.io/users/foss_satan","type":"Like"}`, string(bytes))
+}
+
 func (suite *InternalToASTestSuite) TestStatusesToASOutboxPage() {
 	testAccount := suite.testAccounts["admin_account"]
 	ctx := context.Background()
 
 	// get public statuses from testaccount
-	statuses, err := suite.db.GetAccountStatuses(ctx, testAccount.ID, 30, true, true, "", "", false, false, true)
+	statuses, err := suite.db.GetAccountStatuses(ctx, testAccount.ID, 30, true, true, "", "", false, false, true, false)
 	suite.NoError(err)
 
 	page, err := suite.typeconverter.StatusesToASOutboxPage(ctx, testAccount.OutboxURI, "", "", statuses)
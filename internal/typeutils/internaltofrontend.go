@@ -21,6 +21,7 @@ package typeutils
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -31,6 +32,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/media"
 )
 
 func (c *converter) AccountToAPIAccountSensitive(ctx context.Context, a *gtsmodel.Account) (*model.Account, error) {
@@ -58,6 +60,7 @@ func (c *converter) AccountToAPIAccountSensitive(ctx context.Context, a *gtsmode
 		Privacy:             c.VisToAPIVis(ctx, a.Privacy),
 		Sensitive:           a.Sensitive,
 		Language:            a.Language,
+		AutoDeleteAfterDays: a.AutoDeleteAfterDays,
 		Note:                a.NoteRaw,
 		Fields:              apiAccount.Fields,
 		FollowRequestsCount: frc,
@@ -162,27 +165,36 @@ func (c *converter) AccountToAPIAccountPublic(ctx context.Context, a *gtsmodel.A
 		suspended = true
 	}
 
+	var customCSS string
+	if a.Domain == "" && viper.GetBool(config.Keys.AccountsCustomCSSEnabled) {
+		customCSS = a.CustomCSS
+	}
+
 	accountFrontend := &model.Account{
-		ID:             a.ID,
-		Username:       a.Username,
-		Acct:           acct,
-		DisplayName:    a.DisplayName,
-		Locked:         a.Locked,
-		Bot:            a.Bot,
-		CreatedAt:      a.CreatedAt.Format(time.RFC3339),
-		Note:           a.Note,
-		URL:            a.URL,
-		Avatar:         aviURL,
-		AvatarStatic:   aviURLStatic,
-		Header:         headerURL,
-		HeaderStatic:   headerURLStatic,
-		FollowersCount: followersCount,
-		FollowingCount: followingCount,
-		StatusesCount:  statusesCount,
-		LastStatusAt:   lastStatusAt,
-		Emojis:         emojis, // TODO: implement this
-		Fields:         fields,
-		Suspended:      suspended,
+		ID:               a.ID,
+		Username:         a.Username,
+		Acct:             acct,
+		DisplayName:      a.DisplayName,
+		Locked:           a.Locked,
+		Unquotable:       a.Unquotable,
+		ArchiveAfterDays: a.ArchiveAfterDays,
+		Bot:              a.Bot,
+		CreatedAt:        a.CreatedAt.Format(time.RFC3339),
+		Note:             a.Note,
+		URL:              a.URL,
+		Avatar:           aviURL,
+		AvatarStatic:     aviURLStatic,
+		Header:           headerURL,
+		HeaderStatic:     headerURLStatic,
+		FollowersCount:   followersCount,
+		FollowingCount:   followingCount,
+		StatusesCount:    statusesCount,
+		LastStatusAt:     lastStatusAt,
+		Emojis:           emojis, // TODO: implement this
+		Fields:           fields,
+		Suspended:        suspended,
+		Limited:          !a.SilencedAt.IsZero(),
+		CustomCSS:        customCSS,
 	}
 
 	return accountFrontend, nil
@@ -234,10 +246,17 @@ func (c *converter) AppToAPIAppPublic(ctx context.Context, a *gtsmodel.Applicati
 }
 
 func (c *converter) AttachmentToAPIAttachment(ctx context.Context, a *gtsmodel.MediaAttachment) (model.Attachment, error) {
+	// like mastodon, we only give out the url of
+	// an attachment once it's finished processing
+	url := a.URL
+	if a.Processing != gtsmodel.ProcessingStatusProcessed {
+		url = ""
+	}
+
 	return model.Attachment{
 		ID:               a.ID,
 		Type:             strings.ToLower(string(a.Type)),
-		URL:              a.URL,
+		URL:              url,
 		PreviewURL:       a.Thumbnail.URL,
 		RemoteURL:        a.RemoteURL,
 		PreviewRemoteURL: a.Thumbnail.RemoteURL,
@@ -299,7 +318,21 @@ func (c *converter) EmojiToAPIEmoji(ctx context.Context, e *gtsmodel.Emoji) (mod
 		URL:             e.ImageURL,
 		StaticURL:       e.ImageStaticURL,
 		VisibleInPicker: e.VisibleInPicker,
-		Category:        e.CategoryID,
+		Category:        e.Category,
+	}, nil
+}
+
+func (c *converter) EmojiToAdminAPIEmoji(ctx context.Context, e *gtsmodel.Emoji) (*model.AdminEmoji, error) {
+	apiEmoji, err := c.EmojiToAPIEmoji(ctx, e)
+	if err != nil {
+		return nil, err
+	}
+
+	return &model.AdminEmoji{
+		Emoji:     &apiEmoji,
+		ID:        e.ID,
+		Disabled:  e.Disabled,
+		UpdatedAt: e.UpdatedAt.Format(time.RFC3339),
 	}, nil
 }
 
@@ -310,6 +343,24 @@ func (c *converter) TagToAPITag(ctx context.Context, t *gtsmodel.Tag) (model.Tag
 	}, nil
 }
 
+func (c *converter) TrendingTagToAPITag(ctx context.Context, t *gtsmodel.TrendingTag) (model.Tag, error) {
+	apiTag, err := c.TagToAPITag(ctx, t.Tag)
+	if err != nil {
+		return model.Tag{}, err
+	}
+
+	apiTag.History = make([]model.TagHistory, 0, len(t.History))
+	for _, h := range t.History {
+		apiTag.History = append(apiTag.History, model.TagHistory{
+			Day:      strconv.FormatInt(h.Day.Unix(), 10),
+			Uses:     strconv.Itoa(h.Uses),
+			Accounts: strconv.Itoa(h.Accounts),
+		})
+	}
+
+	return apiTag, nil
+}
+
 func (c *converter) StatusToAPIStatus(ctx context.Context, s *gtsmodel.Status, requestingAccount *gtsmodel.Account) (*model.Status, error) {
 	repliesCount, err := c.db.CountStatusReplies(ctx, s)
 	if err != nil {
@@ -355,6 +406,30 @@ func (c *converter) StatusToAPIStatus(ctx context.Context, s *gtsmodel.Status, r
 		}
 	}
 
+	var apiQuotedStatus *model.Status
+	if s.QuoteOfID != "" {
+		if s.QuoteOf == nil {
+			qs, err := c.db.GetStatusByID(ctx, s.QuoteOfID)
+			if err != nil && err != db.ErrNoEntries {
+				return nil, fmt.Errorf("error getting quoted status with id %s: %s", s.QuoteOfID, err)
+			}
+			s.QuoteOf = qs
+		}
+
+		// we don't check visibility of the quoted status for the requesting account here,
+		// so only show it if it's visible to everyone anyway; the link in the content is still there otherwise
+		if s.QuoteOf != nil && (s.QuoteOf.Visibility == gtsmodel.VisibilityPublic || s.QuoteOf.Visibility == gtsmodel.VisibilityUnlocked) {
+			// only go one quote deep, so don't let the quoted status pull in its own quote
+			qs := *s.QuoteOf
+			qs.QuoteOfID = ""
+			apiQuotedStatus, err = c.StatusToAPIStatus(ctx, &qs, requestingAccount)
+			if err != nil {
+				return nil, fmt.Errorf("error converting quoted status to apitype: %s", err)
+			}
+			apiQuotedStatus.QuoteID = s.QuoteOf.QuoteOfID
+		}
+	}
+
 	var apiApplication *model.Application
 	if s.CreatedWithApplicationID != "" {
 		gtsApplication := &gtsmodel.Application{}
@@ -500,9 +575,36 @@ func (c *converter) StatusToAPIStatus(ctx context.Context, s *gtsmodel.Status, r
 		}
 	}
 
+	apiReactions, err := c.statusReactionsToAPIReactions(ctx, s, requestingAccount)
+	if err != nil {
+		return nil, fmt.Errorf("error converting reactions: %s", err)
+	}
+
 	var apiCard *model.Card
+	if s.PreviewCardID != "" {
+		if s.PreviewCard == nil {
+			card, err := c.db.GetPreviewCardByID(ctx, s.PreviewCardID)
+			if err != nil {
+				logrus.Errorf("error getting preview card with id %s: %s", s.PreviewCardID, err)
+			}
+			s.PreviewCard = card
+		}
+		if s.PreviewCard != nil {
+			apiCard, err = c.PreviewCardToAPICard(ctx, s.PreviewCard)
+			if err != nil {
+				return nil, fmt.Errorf("error converting preview card: %s", err)
+			}
+		}
+	}
+
 	var apiPoll *model.Poll
 
+	// statuses from before reply policies existed can be replied to by everyone
+	replyPolicy := model.ReplyPolicy(s.ReplyPolicy)
+	if replyPolicy == "" {
+		replyPolicy = model.ReplyPolicyEveryone
+	}
+
 	statusInteractions := &statusInteractions{}
 	si, err := c.interactionsWithStatusForAccount(ctx, s, requestingAccount)
 	if err == nil {
@@ -514,9 +616,10 @@ func (c *converter) StatusToAPIStatus(ctx context.Context, s *gtsmodel.Status, r
 		CreatedAt:          s.CreatedAt.Format(time.RFC3339),
 		InReplyToID:        s.InReplyToID,
 		InReplyToAccountID: s.InReplyToAccountID,
-		Sensitive:          s.Sensitive,
+		Sensitive:          s.Sensitive || !s.Account.SensitizedAt.IsZero(),
 		SpoilerText:        s.ContentWarning,
 		Visibility:         c.VisToAPIVis(ctx, s.Visibility),
+		ReplyPolicy:        replyPolicy,
 		Language:           s.Language,
 		URI:                s.URI,
 		URL:                s.URL,
@@ -528,6 +631,7 @@ func (c *converter) StatusToAPIStatus(ctx context.Context, s *gtsmodel.Status, r
 		Muted:              statusInteractions.Muted,
 		Reblogged:          statusInteractions.Reblogged,
 		Pinned:             s.Pinned,
+		PendingReview:      !s.HeldAt.IsZero(),
 		Content:            s.Content,
 		Application:        apiApplication,
 		Account:            apiAuthorAccount,
@@ -535,8 +639,10 @@ func (c *converter) StatusToAPIStatus(ctx context.Context, s *gtsmodel.Status, r
 		Mentions:           apiMentions,
 		Tags:               apiTags,
 		Emojis:             apiEmojis,
-		Card:               apiCard, // TODO: implement cards
+		Card:               apiCard,
 		Poll:               apiPoll, // TODO: implement polls
+		Reactions:          apiReactions,
+		QuoteID:            s.QuoteOfID,
 		Text:               s.Text,
 	}
 
@@ -544,9 +650,66 @@ func (c *converter) StatusToAPIStatus(ctx context.Context, s *gtsmodel.Status, r
 		apiStatus.Reblog = &model.StatusReblogged{Status: apiRebloggedStatus}
 	}
 
+	if apiQuotedStatus != nil {
+		apiStatus.Quote = &model.StatusQuoted{Status: apiQuotedStatus}
+	}
+
 	return apiStatus, nil
 }
 
+func (c *converter) PreviewCardToAPICard(ctx context.Context, p *gtsmodel.PreviewCard) (*model.Card, error) {
+	return &model.Card{
+		URL:          p.URL,
+		Title:        p.Title,
+		Description:  p.Description,
+		Type:         p.Type,
+		AuthorName:   p.AuthorName,
+		AuthorURL:    p.AuthorURL,
+		ProviderName: p.ProviderName,
+		ProviderURL:  p.ProviderURL,
+		Width:        p.Width,
+		Height:       p.Height,
+		Image:        p.ImageURL,
+		EmbedURL:     p.EmbedURL,
+	}, nil
+}
+
+// statusReactionsToAPIReactions tallies up the emoji reactions to the given status, in the order
+// in which each reaction was first added. requestingAccount may be nil if the request is unauthenticated.
+func (c *converter) statusReactionsToAPIReactions(ctx context.Context, s *gtsmodel.Status, requestingAccount *gtsmodel.Account) ([]model.StatusReaction, error) {
+	reactions, err := c.db.GetStatusReactions(ctx, s)
+	if err != nil {
+		return nil, err
+	}
+
+	apiReactions := []model.StatusReaction{}
+	indexes := make(map[string]int, len(reactions))
+	for _, r := range reactions {
+		i, ok := indexes[r.Name]
+		if !ok {
+			i = len(apiReactions)
+			indexes[r.Name] = i
+			apiReactions = append(apiReactions, model.StatusReaction{
+				Name: strings.Trim(r.Name, ":"),
+			})
+		}
+
+		// not every reaction with a custom emoji will necessarily have
+		// the emoji resolved, so take the urls from the first one that does
+		if apiReactions[i].URL == "" && r.Emoji != nil {
+			apiReactions[i].URL = r.Emoji.ImageURL
+			apiReactions[i].StaticURL = r.Emoji.ImageStaticURL
+		}
+
+		apiReactions[i].Count++
+		if requestingAccount != nil && r.AccountID == requestingAccount.ID {
+			apiReactions[i].Me = true
+		}
+	}
+
+	return apiReactions, nil
+}
+
 // VisToapi converts a gts visibility into its api equivalent
 func (c *converter) VisToAPIVis(ctx context.Context, m gtsmodel.Visibility) model.Visibility {
 	switch m {
@@ -593,14 +756,36 @@ func (c *converter) InstanceToAPIInstance(ctx context.Context, i *gtsmodel.Insta
 			mi.Stats["domain_count"] = domainCount
 		}
 
-		mi.Registrations = viper.GetBool(keys.AccountsRegistrationOpen)
+		registrationsOpen, err := c.db.GetRegistrationsOpen(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("InstanceToAPIInstance: error checking whether registrations are open: %s", err)
+		}
+		mi.Registrations = registrationsOpen
+
+		readOnly, readOnlyMessage, err := c.db.GetReadOnly(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("InstanceToAPIInstance: error checking whether instance is read-only: %s", err)
+		}
+		mi.ReadOnly = readOnly
+		if readOnly {
+			mi.ReadOnlyMessage = readOnlyMessage
+		}
+
 		mi.ApprovalRequired = viper.GetBool(keys.AccountsApprovalRequired)
 		mi.InvitesEnabled = false // TODO
 		mi.MaxTootChars = uint(viper.GetInt(keys.StatusesMaxChars))
+		mi.ThumbnailMaxDimension = media.ThumbnailMaxDimension()
+		mi.EmojiMaxSize = uint(viper.GetInt(keys.MediaEmojiMaxSize))
+		mi.EmojiMaxDimension = uint(viper.GetInt(keys.MediaEmojiMaxDimension))
+		mi.MaxProfileFields = uint(viper.GetInt(keys.AccountsMaxFields))
+		mi.ProfileFieldNameMaxChars = uint(viper.GetInt(keys.AccountsFieldNameMaxChars))
+		mi.ProfileFieldValueMaxChars = uint(viper.GetInt(keys.AccountsFieldValueMaxChars))
+		mi.MinAccountAge = uint(viper.GetDuration(keys.AccountsMinAge).Seconds())
 		mi.URLS = &model.InstanceURLs{
 			StreamingAPI: fmt.Sprintf("wss://%s", host),
 		}
 		mi.Version = viper.GetString(keys.SoftwareVersion)
+		mi.Languages = viper.GetStringSlice(keys.InstanceLanguages)
 	}
 
 	// get the instance account if it exists and just skip if it doesn't
@@ -720,3 +905,36 @@ func (c *converter) DomainBlockToAPIDomainBlock(ctx context.Context, b *gtsmodel
 
 	return domainBlock, nil
 }
+
+func (c *converter) DomainPauseToAPIDomainPause(ctx context.Context, p *gtsmodel.DomainPause) (*model.DomainPause, error) {
+	return &model.DomainPause{
+		ID:             p.ID,
+		Domain:         p.Domain,
+		PrivateComment: p.PrivateComment,
+		CreatedBy:      p.CreatedByAccountID,
+		CreatedAt:      p.CreatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+func (c *converter) DomainSilenceToAPIDomainSilence(ctx context.Context, s *gtsmodel.DomainSilence) (*model.DomainSilence, error) {
+	return &model.DomainSilence{
+		ID:             s.ID,
+		Domain:         s.Domain,
+		PrivateComment: s.PrivateComment,
+		CreatedBy:      s.CreatedByAccountID,
+		CreatedAt:      s.CreatedAt.Format(time.RFC3339),
+	}, nil
+}
+
+func (c *converter) ModerationRuleToAPIModerationRule(ctx context.Context, r *gtsmodel.ModerationRule) (*model.ModerationRule, error) {
+	return &model.ModerationRule{
+		ID:             r.ID,
+		Pattern:        r.Pattern,
+		Regex:          r.Regex,
+		LocalAction:    string(r.LocalAction),
+		RemoteAction:   string(r.RemoteAction),
+		PrivateComment: r.PrivateComment,
+		CreatedBy:      r.CreatedByAccountID,
+		CreatedAt:      r.CreatedAt.Format(time.RFC3339),
+	}, nil
+}
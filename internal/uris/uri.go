@@ -108,6 +108,14 @@ func GenerateURIForBlock(username string, thisBlockID string) string {
 	return fmt.Sprintf("%s://%s/%s/%s/%s/%s", protocol, host, UsersPath, username, BlocksPath, thisBlockID)
 }
 
+// GenerateURIForPublicKey returns the AP URI for a rotated public key -- something like:
+// https://example.org/users/whatever_user/main-key/01F7XTH1QGBAPMGF49WJZ91XGC
+func GenerateURIForPublicKey(username string, thisKeyID string) string {
+	protocol := viper.GetString(config.Keys.Protocol)
+	host := viper.GetString(config.Keys.Host)
+	return fmt.Sprintf("%s://%s/%s/%s/%s/%s", protocol, host, UsersPath, username, PublicKeyPath, thisKeyID)
+}
+
 // GenerateURIForEmailConfirm returns a link for email confirmation -- something like:
 // https://example.org/confirm_email?token=490e337c-0162-454f-ac48-4b22bb92a205
 func GenerateURIForEmailConfirm(token string) string {
@@ -221,7 +229,8 @@ func IsStatusesPath(id *url.URL) bool {
 	return regexes.StatusesPath.MatchString(id.Path)
 }
 
-// IsPublicKeyPath returns true if the given URL path corresponds to eg /users/example_username/main-key
+// IsPublicKeyPath returns true if the given URL path corresponds to eg /users/example_username/main-key,
+// or to the path of a rotated key, eg /users/example_username/main-key/01F7XTH1QGBAPMGF49WJZ91XGC
 func IsPublicKeyPath(id *url.URL) bool {
 	return regexes.PublicKeyPath.MatchString(id.Path)
 }
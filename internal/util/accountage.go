@@ -0,0 +1,49 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package util
+
+import (
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+// AccountTooNew returns true if the given local account, belonging to the given user, is younger than the
+// configured minimum account age, so it shouldn't be allowed to follow other accounts, post publicly, or mention
+// accounts that didn't initiate contact with it. Admins, moderators, and accounts with a verified profile link are exempt.
+func AccountTooNew(account *gtsmodel.Account, user *gtsmodel.User) bool {
+	minAge := viper.GetDuration(config.Keys.AccountsMinAge)
+	if minAge <= 0 || time.Since(account.CreatedAt) >= minAge {
+		return false
+	}
+
+	if user != nil && (user.Admin || user.Moderator) {
+		return false
+	}
+
+	for _, field := range account.Fields {
+		if !field.VerifiedAt.IsZero() {
+			return false
+		}
+	}
+
+	return true
+}
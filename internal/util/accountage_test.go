@@ -0,0 +1,62 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package util_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+	"github.com/superseriousbusiness/gotosocial/internal/util"
+)
+
+type AccountAgeTestSuite struct {
+	suite.Suite
+}
+
+func (suite *AccountAgeTestSuite) TestAccountTooNew() {
+	account := &gtsmodel.Account{CreatedAt: time.Now().Add(-1 * time.Hour)}
+	user := &gtsmodel.User{}
+
+	// no minimum age
+	viper.Set(config.Keys.AccountsMinAge, 0)
+	suite.False(util.AccountTooNew(account, user))
+
+	// old enough
+	viper.Set(config.Keys.AccountsMinAge, 30*time.Minute)
+	suite.False(util.AccountTooNew(account, user))
+
+	// too new
+	viper.Set(config.Keys.AccountsMinAge, 24*time.Hour)
+	suite.True(util.AccountTooNew(account, user))
+
+	// too new, but a moderator
+	suite.False(util.AccountTooNew(account, &gtsmodel.User{Moderator: true}))
+
+	// too new, but with a verified profile link
+	account.Fields = []gtsmodel.Field{{Name: "website", Value: "https://example.org", VerifiedAt: time.Now()}}
+	suite.False(util.AccountTooNew(account, user))
+}
+
+func TestAccountAgeTestSuite(t *testing.T) {
+	suite.Run(t, &AccountAgeTestSuite{})
+}
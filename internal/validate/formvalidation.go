@@ -40,6 +40,8 @@ const (
 	maximumDescriptionLength      = 5000
 	maximumSiteTermsLength        = 5000
 	maximumUsernameLength         = 64
+	maximumCustomCSSLength        = 5000
+	maximumEmojiCategoryLength    = 64
 	// maximumEmojiShortcodeLength   = 30
 	// maximumHashtagLength          = 30
 )
@@ -135,6 +137,15 @@ func Note(note string) error {
 	return nil
 }
 
+// CustomCSS checks that the given custom profile css is not too long.
+// The css itself is checked when it's sanitized.
+func CustomCSS(css string) error {
+	if length := len([]rune(css)); length > maximumCustomCSSLength {
+		return fmt.Errorf("custom css should be no more than %d chars but given css was %d", maximumCustomCSSLength, length)
+	}
+	return nil
+}
+
 // Privacy checks that the desired privacy setting is valid
 func Privacy(privacy string) error {
 	if privacy == "" {
@@ -157,6 +168,14 @@ func EmojiShortcode(shortcode string) error {
 	return nil
 }
 
+// EmojiCategory ensures that the given emoji category name is within spec.
+func EmojiCategory(category string) error {
+	if length := len([]rune(category)); length > maximumEmojiCategoryLength {
+		return fmt.Errorf("emoji category should be no more than %d chars but given category was %d", maximumEmojiCategoryLength, length)
+	}
+	return nil
+}
+
 // SiteTitle ensures that the given site title is within spec.
 func SiteTitle(siteTitle string) error {
 	if len(siteTitle) > maximumSiteTitleLength {
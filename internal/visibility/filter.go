@@ -50,6 +50,17 @@ type Filter interface {
 	//
 	// this function will call StatusVisible internally so it's not necessary to call it beforehand.
 	StatusBoostable(ctx context.Context, targetStatus *gtsmodel.Status, requestingAccount *gtsmodel.Account) (bool, error)
+
+	// StatusArchived returns true if targetStatus is old enough to be hidden from public view, going by the settings
+	// of its author and the instance. Statuses are never hidden from their own author.
+	//
+	// This function doesn't check whether the status is visible at all, so do that beforehand if necessary.
+	StatusArchived(ctx context.Context, targetStatus *gtsmodel.Status, requestingAccount *gtsmodel.Account) (bool, error)
+
+	// StatusReplyable returns true if the reply policy of targetStatus permits the requesting account to reply to it.
+	//
+	// This function doesn't check whether the status is visible or replyable at all, so do that beforehand if necessary.
+	StatusReplyable(ctx context.Context, targetStatus *gtsmodel.Status, requestingAccount *gtsmodel.Account) (bool, error)
 }
 
 type filter struct {
@@ -0,0 +1,61 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package visibility
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (f *filter) StatusArchived(ctx context.Context, targetStatus *gtsmodel.Status, requestingAccount *gtsmodel.Account) (bool, error) {
+	// the author can always see their own statuses
+	if requestingAccount != nil && requestingAccount.ID == targetStatus.AccountID {
+		return false, nil
+	}
+
+	targetAccount := targetStatus.Account
+	if targetAccount == nil {
+		var err error
+		targetAccount, err = f.db.GetAccountByID(ctx, targetStatus.AccountID)
+		if err != nil {
+			return false, fmt.Errorf("StatusArchived: error getting author of status %s: %s", targetStatus.ID, err)
+		}
+	}
+
+	return statusArchived(targetStatus, targetAccount), nil
+}
+
+// statusArchived returns true if the given status, authored by the given account, is old
+// enough to be hidden from public view, going by the settings of the account and instance.
+func statusArchived(targetStatus *gtsmodel.Status, targetAccount *gtsmodel.Account) bool {
+	days := targetAccount.ArchiveAfterDays
+	if days == 0 {
+		days = viper.GetInt(config.Keys.StatusesArchiveAfterDays)
+	}
+	if days <= 0 {
+		return false
+	}
+
+	return targetStatus.CreatedAt.Before(time.Now().AddDate(0, 0, -days))
+}
@@ -0,0 +1,127 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package visibility_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+)
+
+type StatusArchivedTestSuite struct {
+	FilterStandardTestSuite
+}
+
+func (suite *StatusArchivedTestSuite) TestNotArchivedByDefault() {
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	ctx := context.Background()
+
+	archived, err := suite.filter.StatusArchived(ctx, testStatus, nil)
+	suite.NoError(err)
+
+	suite.False(archived)
+}
+
+func (suite *StatusArchivedTestSuite) TestOldStatusArchived() {
+	viper.Set(config.Keys.StatusesArchiveAfterDays, 30)
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	testAccount := suite.testAccounts["local_account_1"]
+	ctx := context.Background()
+
+	archived, err := suite.filter.StatusArchived(ctx, testStatus, testAccount)
+	suite.NoError(err)
+	suite.True(archived)
+
+	// the status is still visible when fetched directly
+	visible, err := suite.filter.StatusVisible(ctx, testStatus, testAccount)
+	suite.NoError(err)
+	suite.True(visible)
+
+	// but not in the public timeline
+	timelineable, err := suite.filter.StatusPublictimelineable(ctx, testStatus, testAccount)
+	suite.NoError(err)
+	suite.False(timelineable)
+}
+
+func (suite *StatusArchivedTestSuite) TestOldStatusArchivedHideDirect() {
+	viper.Set(config.Keys.StatusesArchiveAfterDays, 30)
+	viper.Set(config.Keys.StatusesArchiveHideDirect, true)
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	testAccount := suite.testAccounts["local_account_1"]
+	ctx := context.Background()
+
+	visible, err := suite.filter.StatusVisible(ctx, testStatus, testAccount)
+	suite.NoError(err)
+	suite.False(visible)
+
+	// the author can still see it
+	visible, err = suite.filter.StatusVisible(ctx, testStatus, suite.testAccounts["admin_account"])
+	suite.NoError(err)
+	suite.True(visible)
+}
+
+func (suite *StatusArchivedTestSuite) TestOwnOldStatusNotArchived() {
+	viper.Set(config.Keys.StatusesArchiveAfterDays, 30)
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	testAccount := suite.testAccounts["admin_account"]
+	ctx := context.Background()
+
+	archived, err := suite.filter.StatusArchived(ctx, testStatus, testAccount)
+	suite.NoError(err)
+
+	suite.False(archived)
+}
+
+func (suite *StatusArchivedTestSuite) TestNewStatusNotArchived() {
+	viper.Set(config.Keys.StatusesArchiveAfterDays, 30)
+	testStatus := suite.testStatuses["local_account_2_status_5"]
+	ctx := context.Background()
+
+	archived, err := suite.filter.StatusArchived(ctx, testStatus, nil)
+	suite.NoError(err)
+
+	suite.False(archived)
+}
+
+func (suite *StatusArchivedTestSuite) TestAccountOverride() {
+	testStatus := *suite.testStatuses["admin_account_status_1"]
+	testAccount := *suite.testAccounts["admin_account"]
+	testAccount.ArchiveAfterDays = 30
+	testStatus.Account = &testAccount
+	ctx := context.Background()
+
+	// the account hides its old statuses even though the instance doesn't
+	archived, err := suite.filter.StatusArchived(ctx, &testStatus, nil)
+	suite.NoError(err)
+	suite.True(archived)
+
+	// the account keeps its statuses visible for longer than the instance would
+	viper.Set(config.Keys.StatusesArchiveAfterDays, 30)
+	testAccount.ArchiveAfterDays = 36500
+	archived, err = suite.filter.StatusArchived(ctx, &testStatus, nil)
+	suite.NoError(err)
+	suite.False(archived)
+}
+
+func TestStatusArchivedTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusArchivedTestSuite))
+}
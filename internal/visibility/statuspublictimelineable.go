@@ -27,7 +27,7 @@ import (
 )
 
 func (f *filter) StatusPublictimelineable(ctx context.Context, targetStatus *gtsmodel.Status, timelineOwnerAccount *gtsmodel.Account) (bool, error) {
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":     "StatusPublictimelineable",
 		"statusID": targetStatus.ID,
 	})
@@ -57,5 +57,15 @@ func (f *filter) StatusPublictimelineable(ctx context.Context, targetStatus *gts
 		return false, nil
 	}
 
+	archived, err := f.StatusArchived(ctx, targetStatus, timelineOwnerAccount)
+	if err != nil {
+		return false, fmt.Errorf("StatusPublictimelineable: error checking whether status with id %s is archived: %s", targetStatus.ID, err)
+	}
+
+	if archived {
+		l.Debug("status is not publicTimelineable because it's archived")
+		return false, nil
+	}
+
 	return true, nil
 }
@@ -0,0 +1,76 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package visibility
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+func (f *filter) StatusReplyable(ctx context.Context, targetStatus *gtsmodel.Status, requestingAccount *gtsmodel.Account) (bool, error) {
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
+		"func": "StatusReplyable",
+	})
+
+	// the author should always be able to continue their own threads
+	if requestingAccount.ID == targetStatus.AccountID {
+		l.Trace("status is replyable because author is replier")
+		return true, nil
+	}
+
+	switch targetStatus.ReplyPolicy {
+	case gtsmodel.ReplyPolicyNobody:
+		l.Trace("status is not replyable because its reply policy is nobody")
+		return false, nil
+	case gtsmodel.ReplyPolicyFollowers:
+		targetAccount := targetStatus.Account
+		if targetAccount == nil {
+			var err error
+			targetAccount, err = f.db.GetAccountByID(ctx, targetStatus.AccountID)
+			if err != nil {
+				return false, fmt.Errorf("error getting author of status %s: %s", targetStatus.ID, err)
+			}
+		}
+
+		follows, err := f.db.IsFollowing(ctx, requestingAccount, targetAccount)
+		if err != nil {
+			return false, fmt.Errorf("error checking whether account %s follows the author of status %s: %s", requestingAccount.ID, targetStatus.ID, err)
+		}
+		return follows, nil
+	case gtsmodel.ReplyPolicyMentioned:
+		mentions, err := f.db.GetMentions(ctx, targetStatus.MentionIDs)
+		if err != nil {
+			return false, fmt.Errorf("error getting mentions of status %s: %s", targetStatus.ID, err)
+		}
+
+		for _, mention := range mentions {
+			if mention.TargetAccountID == requestingAccount.ID {
+				return true, nil
+			}
+		}
+		l.Trace("status is not replyable because replier isn't mentioned in it")
+		return false, nil
+	}
+
+	// everyone, or no policy at all
+	return true, nil
+}
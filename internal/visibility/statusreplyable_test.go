@@ -0,0 +1,118 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package visibility_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/suite"
+	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
+)
+
+type StatusReplyableTestSuite struct {
+	FilterStandardTestSuite
+}
+
+func (suite *StatusReplyableTestSuite) TestNoPolicyReplyable() {
+	testStatus := suite.testStatuses["admin_account_status_1"]
+	testAccount := suite.testAccounts["remote_account_1"]
+	ctx := context.Background()
+
+	replyable, err := suite.filter.StatusReplyable(ctx, testStatus, testAccount)
+	suite.NoError(err)
+
+	suite.True(replyable)
+}
+
+func (suite *StatusReplyableTestSuite) TestOwnNobodyReplyable() {
+	testStatus := *suite.testStatuses["admin_account_status_1"]
+	testStatus.ReplyPolicy = gtsmodel.ReplyPolicyNobody
+	testAccount := suite.testAccounts["admin_account"]
+	ctx := context.Background()
+
+	replyable, err := suite.filter.StatusReplyable(ctx, &testStatus, testAccount)
+	suite.NoError(err)
+
+	suite.True(replyable)
+}
+
+func (suite *StatusReplyableTestSuite) TestNobodyNotReplyable() {
+	testStatus := *suite.testStatuses["admin_account_status_1"]
+	testStatus.ReplyPolicy = gtsmodel.ReplyPolicyNobody
+	testAccount := suite.testAccounts["local_account_1"]
+	ctx := context.Background()
+
+	replyable, err := suite.filter.StatusReplyable(ctx, &testStatus, testAccount)
+	suite.NoError(err)
+
+	suite.False(replyable)
+}
+
+func (suite *StatusReplyableTestSuite) TestFollowersReplyable() {
+	testStatus := *suite.testStatuses["admin_account_status_1"]
+	testStatus.ReplyPolicy = gtsmodel.ReplyPolicyFollowers
+	testAccount := suite.testAccounts["local_account_1"]
+	ctx := context.Background()
+
+	replyable, err := suite.filter.StatusReplyable(ctx, &testStatus, testAccount)
+	suite.NoError(err)
+
+	suite.True(replyable)
+}
+
+func (suite *StatusReplyableTestSuite) TestFollowersNotReplyable() {
+	testStatus := *suite.testStatuses["admin_account_status_1"]
+	testStatus.ReplyPolicy = gtsmodel.ReplyPolicyFollowers
+	testAccount := suite.testAccounts["local_account_2"]
+	ctx := context.Background()
+
+	replyable, err := suite.filter.StatusReplyable(ctx, &testStatus, testAccount)
+	suite.NoError(err)
+
+	suite.False(replyable)
+}
+
+func (suite *StatusReplyableTestSuite) TestMentionedReplyable() {
+	testStatus := *suite.testStatuses["local_account_2_status_5"]
+	testStatus.ReplyPolicy = gtsmodel.ReplyPolicyMentioned
+	testAccount := suite.testAccounts["local_account_1"]
+	ctx := context.Background()
+
+	replyable, err := suite.filter.StatusReplyable(ctx, &testStatus, testAccount)
+	suite.NoError(err)
+
+	suite.True(replyable)
+}
+
+func (suite *StatusReplyableTestSuite) TestMentionedNotReplyable() {
+	testStatus := *suite.testStatuses["local_account_2_status_5"]
+	testStatus.ReplyPolicy = gtsmodel.ReplyPolicyMentioned
+	testAccount := suite.testAccounts["admin_account"]
+	ctx := context.Background()
+
+	replyable, err := suite.filter.StatusReplyable(ctx, &testStatus, testAccount)
+	suite.NoError(err)
+
+	suite.False(replyable)
+}
+
+func TestStatusReplyableTestSuite(t *testing.T) {
+	suite.Run(t, new(StatusReplyableTestSuite))
+}
@@ -23,6 +23,8 @@ import (
 	"fmt"
 
 	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/db"
 	"github.com/superseriousbusiness/gotosocial/internal/gtsmodel"
 )
@@ -30,7 +32,7 @@ import (
 func (f *filter) StatusVisible(ctx context.Context, targetStatus *gtsmodel.Status, requestingAccount *gtsmodel.Account) (bool, error) {
 	const getBoosted = true
 
-	l := logrus.WithFields(logrus.Fields{
+	l := logrus.WithContext(ctx).WithFields(logrus.Fields{
 		"func":     "StatusVisible",
 		"statusID": targetStatus.ID,
 	})
@@ -64,6 +66,19 @@ func (f *filter) StatusVisible(ctx context.Context, targetStatus *gtsmodel.Statu
 		return false, nil
 	}
 
+	// if the target status is held for review by an admin, only its author may see it
+	if !targetStatus.HeldAt.IsZero() && (requestingAccount == nil || requestingAccount.ID != targetStatus.AccountID) {
+		l.Trace("target status is held for review")
+		return false, nil
+	}
+
+	// if the target status is old enough to be hidden from public view, and the instance hides
+	// such statuses from direct fetches as well, only its author may see it
+	if viper.GetBool(config.Keys.StatusesArchiveHideDirect) && statusArchived(targetStatus, targetAccount) && (requestingAccount == nil || requestingAccount.ID != targetStatus.AccountID) {
+		l.Trace("target status is archived")
+		return false, nil
+	}
+
 	// if the target user doesn't exist (anymore) then the status also shouldn't be visible
 	// note: we only do this for local users
 	if targetAccount.Domain == "" {
@@ -42,6 +42,9 @@ const (
 	statusIDKey      = "status"
 	profilePath      = "/@:" + usernameKey
 	statusPath       = profilePath + "/statuses/:" + statusIDKey
+	statusEmbedPath  = statusPath + "/embed"
+	rssFeedPath      = profilePath + "/feed.rss"
+	atomFeedPath     = profilePath + "/feed.atom"
 )
 
 // Module implements the api.ClientModule interface for web pages.
@@ -155,9 +158,16 @@ func (m *Module) Route(s router.Router) error {
 	// serve profile pages at /@username
 	s.AttachHandler(http.MethodGet, profilePath, m.profileTemplateHandler)
 
+	// serve feeds of public statuses at /@username/feed.rss and /@username/feed.atom
+	s.AttachHandler(http.MethodGet, rssFeedPath, m.rssFeedHandler)
+	s.AttachHandler(http.MethodGet, atomFeedPath, m.atomFeedHandler)
+
 	// serve statuses
 	s.AttachHandler(http.MethodGet, statusPath, m.threadTemplateHandler)
 
+	// serve embeddable versions of public statuses, for oembed
+	s.AttachHandler(http.MethodGet, statusEmbedPath, m.embedTemplateHandler)
+
 	// serve email confirmation page at /confirm_email?token=whatever
 	s.AttachHandler(http.MethodGet, confirmEmailPath, m.confirmEmailGETHandler)
 
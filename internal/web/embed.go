@@ -0,0 +1,84 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/viper"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
+	"github.com/superseriousbusiness/gotosocial/internal/config"
+	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/router"
+)
+
+// embedTemplateHandler serves a bare-bones page containing just one public status,
+// which is used as the iframe source of oembeds of that status.
+func (m *Module) embedTemplateHandler(c *gin.Context) {
+	l := logrus.WithField("func", "embedTemplateGET")
+	l.Trace("rendering embed template")
+
+	ctx := c.Request.Context()
+
+	// usernames on our instance will always be lowercase
+	username := strings.ToLower(c.Param(usernameKey))
+	if username == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no account username specified"})
+		return
+	}
+
+	// status ids will always be uppercase
+	statusID := strings.ToUpper(c.Param(statusIDKey))
+	if statusID == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no status id specified"})
+		return
+	}
+
+	host := viper.GetString(config.Keys.Host)
+	instance, errWithCode := m.processor.InstanceGet(ctx, host)
+	if errWithCode != nil {
+		l.Debugf("error getting instance from processor: %s", errWithCode.Error())
+		c.JSON(http.StatusInternalServerError, gin.H{"error": "internal server error"})
+		return
+	}
+
+	// embeds are fetched by visitors of other sites, so never authenticate them
+	status, err := m.processor.StatusGet(ctx, &oauth.Auth{}, statusID)
+	if err != nil {
+		m.NotFoundHandler(c)
+		return
+	}
+
+	if !strings.EqualFold(username, status.Account.Username) || status.Visibility != model.VisibilityPublic {
+		m.NotFoundHandler(c)
+		return
+	}
+
+	// the whole point of this page is to be shown in iframes on other sites
+	router.AllowFraming(c)
+
+	c.HTML(http.StatusOK, "embed.tmpl", gin.H{
+		"instance":    instance,
+		"status":      status,
+		"stylesheets": []string{"/assets/Fork-Awesome/css/fork-awesome.min.css", "/assets/status.css"},
+	})
+}
@@ -0,0 +1,66 @@
+/*
+   GoToSocial
+   Copyright (C) 2021-2022 GoToSocial Authors admin@gotosocial.org
+
+   This program is free software: you can redistribute it and/or modify
+   it under the terms of the GNU Affero General Public License as published by
+   the Free Software Foundation, either version 3 of the License, or
+   (at your option) any later version.
+
+   This program is distributed in the hope that it will be useful,
+   but WITHOUT ANY WARRANTY; without even the implied warranty of
+   MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+   GNU Affero General Public License for more details.
+
+   You should have received a copy of the GNU Affero General Public License
+   along with this program.  If not, see <http://www.gnu.org/licenses/>.
+*/
+
+package web
+
+import (
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/sirupsen/logrus"
+	"github.com/superseriousbusiness/gotosocial/internal/api"
+)
+
+// rssFeedHandler serves an RSS feed of the recent public statuses of an account.
+func (m *Module) rssFeedHandler(c *gin.Context) {
+	m.feedHandler(c, false)
+}
+
+// atomFeedHandler serves an Atom feed of the recent public statuses of an account.
+func (m *Module) atomFeedHandler(c *gin.Context) {
+	m.feedHandler(c, true)
+}
+
+func (m *Module) feedHandler(c *gin.Context, atom bool) {
+	l := logrus.WithField("func", "feedHandler")
+
+	// usernames on our instance will always be lowercase
+	username := strings.ToLower(c.Param(usernameKey))
+	if username == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "no account username specified"})
+		return
+	}
+
+	feed, errWithCode := m.processor.AccountStatusesFeedGet(c.Request.Context(), username, atom)
+	if errWithCode != nil {
+		l.Debugf("error getting feed from processor: %s", errWithCode.Error())
+		if errWithCode.Code() == http.StatusNotFound {
+			m.NotFoundHandler(c)
+			return
+		}
+		api.ErrorHandler(c, errWithCode)
+		return
+	}
+
+	contentType := "application/rss+xml; charset=utf-8"
+	if atom {
+		contentType = "application/atom+xml; charset=utf-8"
+	}
+	c.Data(http.StatusOK, contentType, feed)
+}
@@ -22,6 +22,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"html/template"
 	"math/rand"
 	"net/http"
 
@@ -32,6 +33,7 @@ import (
 	"github.com/superseriousbusiness/gotosocial/internal/api"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
+	"github.com/superseriousbusiness/gotosocial/internal/text"
 )
 
 func (m *Module) profileTemplateHandler(c *gin.Context) {
@@ -55,7 +57,7 @@ func (m *Module) profileTemplateHandler(c *gin.Context) {
 	instance, errWithCode := m.processor.InstanceGet(ctx, viper.GetString(config.Keys.Host))
 	if errWithCode != nil {
 		l.Debugf("error getting instance from processor: %s", errWithCode.Error())
-		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		api.ErrorHandler(c, errWithCode)
 		return
 	}
 
@@ -66,7 +68,7 @@ func (m *Module) profileTemplateHandler(c *gin.Context) {
 			m.NotFoundHandler(c)
 			return
 		}
-		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		api.ErrorHandler(c, errWithCode)
 		return
 	}
 
@@ -83,7 +85,7 @@ func (m *Module) profileTemplateHandler(c *gin.Context) {
 	statuses, errWithCode := m.processor.AccountStatusesGet(ctx, authed, account.ID, 10, true, true, "", "", false, false, true)
 	if errWithCode != nil {
 		l.Debugf("error getting statuses from processor: %s", errWithCode.Error())
-		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		api.ErrorHandler(c, errWithCode)
 		return
 	}
 
@@ -98,10 +100,27 @@ func (m *Module) profileTemplateHandler(c *gin.Context) {
 		}
 	}
 
+	// the css was checked when it was set, but check it again in
+	// case the config has changed since then, and scope it; if custom
+	// css has been disabled since it was set, just leave it out
+	var customCSS template.CSS
+	if account.CustomCSS != "" && viper.GetBool(config.Keys.AccountsCustomCSSEnabled) {
+		css, err := text.SanitizeCSS(account.CustomCSS, text.ProfileCSSScope)
+		if err != nil {
+			l.Debugf("not including custom css of account %s: %s", account.ID, err)
+		} else {
+			/* #nosec G203 */
+			customCSS = template.CSS(css)
+		}
+	}
+
 	c.HTML(http.StatusOK, "profile.tmpl", gin.H{
-		"instance": instance,
-		"account":  account,
-		"statuses": statuses,
+		"instance":  instance,
+		"account":   account,
+		"statuses":  statuses,
+		"customCSS": customCSS,
+		"rssFeed":   account.URL + "/feed.rss",
+		"atomFeed":  account.URL + "/feed.atom",
 		"stylesheets": []string{
 			"/assets/Fork-Awesome/css/fork-awesome.min.css",
 			"/assets/status.css",
@@ -124,7 +143,7 @@ func (m *Module) returnAPRepresentation(ctx context.Context, c *gin.Context, use
 	user, errWithCode := m.processor.GetFediUser(ctx, username, c.Request.URL) // GetFediUser handles auth as well
 	if errWithCode != nil {
 		logrus.Infof(errWithCode.Error())
-		c.JSON(errWithCode.Code(), gin.H{"error": errWithCode.Safe()})
+		api.ErrorHandler(c, errWithCode)
 		return
 	}
 
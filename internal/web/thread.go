@@ -19,13 +19,17 @@
 package web
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/sirupsen/logrus"
 	"github.com/spf13/viper"
 
 	"github.com/gin-gonic/gin"
+	"github.com/superseriousbusiness/gotosocial/internal/api/client/oembed"
+	"github.com/superseriousbusiness/gotosocial/internal/api/model"
 	"github.com/superseriousbusiness/gotosocial/internal/config"
 	"github.com/superseriousbusiness/gotosocial/internal/oauth"
 )
@@ -82,10 +86,17 @@ func (m *Module) threadTemplateHandler(c *gin.Context) {
 		return
 	}
 
+	// let other sites discover how to embed public statuses
+	var oEmbedURL string
+	if status.Visibility == model.VisibilityPublic {
+		oEmbedURL = fmt.Sprintf("%s://%s%s?url=%s", viper.GetString(config.Keys.Protocol), host, oembed.BasePath, url.QueryEscape(status.URL))
+	}
+
 	c.HTML(http.StatusOK, "thread.tmpl", gin.H{
 		"instance":    instance,
 		"status":      status,
 		"context":     context,
+		"oembed":      oEmbedURL,
 		"stylesheets": []string{"/assets/Fork-Awesome/css/fork-awesome.min.css", "/assets/status.css"},
 	})
 }
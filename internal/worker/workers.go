@@ -102,3 +102,21 @@ func (w *Worker[MsgType]) Queue(msg MsgType) {
 		}
 	})
 }
+
+// QueueNoBlock will attempt to queue provided message to be processed when there's a free worker,
+// returning false without blocking if the queue is currently full or the worker has been stopped.
+func (w *Worker[MsgType]) QueueNoBlock(msg MsgType) bool {
+	logrus.Tracef("%s queueing message without blocking (workers=%d queue=%d): %+v",
+		w.prefix, w.workers.Workers(), w.workers.Queue(), msg,
+	)
+	return w.workers.EnqueueNoBlock(func(ctx context.Context) {
+		if err := w.process(ctx, msg); err != nil {
+			logrus.Errorf("%s %v", w.prefix, err)
+		}
+	})
+}
+
+// Queued returns the number of messages currently waiting in the queue for a free worker.
+func (w *Worker[MsgType]) Queued() int {
+	return w.workers.Queue()
+}
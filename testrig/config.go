@@ -20,6 +20,7 @@ package testrig
 
 import (
 	"reflect"
+	"time"
 
 	"github.com/coreos/go-oidc/v3/oidc"
 	"github.com/spf13/viper"
@@ -58,45 +59,130 @@ func InitTestConfig() {
 
 // TestDefaults returns a Values struct with values set that are suitable for local testing.
 var TestDefaults = config.Values{
-	LogLevel:        "trace",
-	LogDbQueries:    true,
-	ApplicationName: "gotosocial",
-	ConfigPath:      "",
-	Host:            "localhost:8080",
-	AccountDomain:   "localhost:8080",
-	Protocol:        "http",
-	BindAddress:     "127.0.0.1",
-	Port:            8080,
-	TrustedProxies:  []string{"127.0.0.1/32"},
-
-	DbType:     "sqlite",
-	DbAddress:  ":memory:",
-	DbPort:     5432,
-	DbUser:     "postgres",
-	DbPassword: "postgres",
-	DbDatabase: "postgres",
-
-	WebTemplateBaseDir: "./web/template/",
-	WebAssetBaseDir:    "./web/assets/",
-
-	AccountsRegistrationOpen: true,
-	AccountsApprovalRequired: true,
-	AccountsReasonRequired:   true,
-
-	MediaImageMaxSize:        1048576, // 1mb
-	MediaVideoMaxSize:        5242880, // 5mb
-	MediaDescriptionMinChars: 0,
-	MediaDescriptionMaxChars: 500,
-	MediaRemoteCacheDays:     30,
+	LogLevel:                 "trace",
+	LogDbQueries:             true,
+	LogSamplePeriod:          time.Minute,
+	ApplicationName:          "gotosocial",
+	ConfigPath:               "",
+	Host:                     "localhost:8080",
+	AccountDomain:            "localhost:8080",
+	Protocol:                 "http",
+	BindAddress:              "127.0.0.1",
+	Port:                     8080,
+	TrustedProxies:           []string{"127.0.0.1/32"},
+	TrustedProxyHeaders:      []string{"X-Forwarded-For", "X-Real-IP"},
+	CORSAllowOrigins:         []string{"*"},
+	CORSAllowMethods:         []string{"POST", "PUT", "DELETE", "GET", "PATCH", "OPTIONS"},
+	CORSAllowHeaders:         []string{"Origin", "Content-Length", "Content-Type", "Authorization"},
+	GzipLevel:                -1,
+	GzipExcludedContentTypes: []string{"image/*", "video/*", "audio/*", "application/gzip", "application/zip"},
+	GzipMinSize:              1024,
+	GzipMaxSize:              0,
+	SessionCookieDomain:      "",
+	SessionCookieSameSite:    "lax",
+	SessionCookieSecure:      false,
+	SessionCookieMaxAge:      2 * time.Minute,
+
+	DbType:               "sqlite",
+	DbAddress:            ":memory:",
+	DbPort:               5432,
+	DbUser:               "postgres",
+	DbPassword:           "postgres",
+	DbDatabase:           "postgres",
+	DbSlowQueryThreshold: time.Second,
+
+	WebTemplateBaseDir:        "./web/template/",
+	WebAssetBaseDir:           "./web/assets/",
+	WebAssetCacheMaxAge:       time.Hour,
+	WebAssetHashedCacheMaxAge: 365 * 24 * time.Hour,
+	WebContentSecurityPolicy:  "",
+
+	InstanceAuthorizedFetch:                 true,
+	InstanceFederationAcceptedActivityTypes: []string{"Accept", "Add", "Announce", "Arrive", "Block", "Create", "Delete", "Dislike", "EmojiReact", "Flag", "Follow", "Ignore", "Invite", "Join", "Leave", "Like", "Listen", "Move", "Offer", "Question", "Read", "Reject", "Remove", "TentativeAccept", "TentativeReject", "Travel", "Undo", "Update", "View"},
+	InstanceFederationRejectedActivityTypes: []string{},
+	InstanceLanguages:                       []string{"en"},
+	InstanceReadOnly:                        false,
+	InstanceReadOnlyMessage:                 "",
+	InstanceReadOnlyRejectFederation:        false,
+	FederationMaxThreadDepth:                20,
+	InstanceMaxPageSize:                     40,
+	InstanceAPIJSONPretty:                   false,
+	InstanceAPIJSONOmitEmpty:                false,
+	InstanceActiveUsersCacheDuration:        time.Hour,
+
+	AccountsRegistrationOpen:          true,
+	AccountsApprovalRequired:          true,
+	AccountsReasonRequired:            true,
+	AccountsArchiveInterval:           7 * 24 * time.Hour,
+	AccountsKeyGracePeriod:            24 * time.Hour,
+	AccountsSignUpIPLimit:             0,
+	AccountsSignUpIPWindow:            24 * time.Hour,
+	AccountsEmailDomainBlocklist:      []string{},
+	AccountsEmailDomainBlocklistFile:  "",
+	AccountsMaxFields:                 4,
+	AccountsFieldNameMaxChars:         255,
+	AccountsFieldValueMaxChars:        255,
+	AccountsCustomCSSEnabled:          true,
+	AccountsCustomCSSAllowedHosts:     []string{},
+	AccountsFieldVerificationInterval: 24 * time.Hour,
+	AccountsSuggestionsEnabled:        true,
+	AccountsMaxFollowing:              7500,
+	AccountsMinAge:                    0,
+	AccountsRemoteRefreshInterval:     0,
+	AccountsPasswordBcryptCost:        10,
+	AccountsSignInMaxFailures:         10,
+	AccountsSignInFailureWindow:       15 * time.Minute,
+	AccountsSignInLockout:             15 * time.Minute,
+
+	OAuthTokenCleanupInterval:  time.Hour,
+	OAuthAccessTokenExpiry:     0,
+	OAuthRefreshTokenExpiry:    30 * 24 * time.Hour,
+	OAuthTokenInactivityExpiry: 0,
+
+	MediaImageMaxSize:             1048576, // 1mb
+	MediaVideoMaxSize:             5242880, // 5mb
+	MediaImageMaxDimension:        16384,
+	MediaImageMaxPixels:           40000000,
+	MediaThumbnailMaxDimension:    512,
+	MediaEmojiMaxSize:             51200,
+	MediaEmojiMaxDimension:        128,
+	MediaDescriptionMinChars:      0,
+	MediaDescriptionMaxChars:      500,
+	MediaAutoDescribeEnabled:      false,
+	MediaAutoDescribeURL:          "",
+	MediaRemoteCacheDays:          30,
+	MediaRemoteCachePruneInterval: 24 * time.Hour,
+	MediaProcessingConcurrency:    0,
+	MediaProcessingQueueSize:      100,
+	MediaSyncProcessingMaxSize:    1048576, // 1mb
+	MediaCacheMaxAge:              24 * time.Hour,
+	MediaUnattachedRetention:      24 * time.Hour,
 
 	StorageBackend:       "local",
 	StorageLocalBasePath: "/gotosocial/storage",
 
-	StatusesMaxChars:           5000,
-	StatusesCWMaxChars:         100,
-	StatusesPollMaxOptions:     6,
-	StatusesPollOptionMaxChars: 50,
-	StatusesMediaMaxFiles:      6,
+	StatusesMaxChars:                5000,
+	StatusesCWMaxChars:              100,
+	StatusesReplyInheritCW:          false,
+	StatusesReplyInheritVisibility:  false,
+	StatusesArchiveAfterDays:        0,
+	StatusesArchiveHideDirect:       false,
+	StatusesPollMaxOptions:          6,
+	StatusesPollOptionMaxChars:      50,
+	StatusesMediaMaxFiles:           6,
+	StatusesMentionResolveTimeout:   5 * time.Second,
+	StatusesRemoteAllowedElements:   []string{"p", "br", "span", "a", "del", "pre", "code", "em", "strong", "b", "i", "u", "ul", "ol", "li", "blockquote"},
+	StatusesRemoteAllowedAttributes: []string{"a.href", "a.rel", "a.class", "span.class", "ol.start", "ol.reversed", "li.value"},
+	StatusesTombstoneRetention:      30 * 24 * time.Hour,
+	StatusesAutoDeleteInterval:      1 * time.Hour,
+	StatusesLinkPreviewEnabled:      false,
+	StatusesLinkPreviewTimeout:      10 * time.Second,
+	StatusesLinkPreviewMaxSize:      1048576, // 1mb
+	StatusesDuplicateWindow:         0,
+	StatusesAutoCWMediaOnly:         false,
+	StatusesAutoCWMaxLinks:          0,
+	StatusesAutoCWText:              "Unlabelled media or links",
+	StatusesDeletePreserveMedia:     false,
 
 	LetsEncryptEnabled:      false,
 	LetsEncryptPort:         0,
@@ -36,15 +36,19 @@ var testModels = []interface{}{
 	&gtsmodel.Application{},
 	&gtsmodel.Block{},
 	&gtsmodel.DomainBlock{},
+	&gtsmodel.DomainPause{},
+	&gtsmodel.DomainSilence{},
 	&gtsmodel.EmailDomainBlock{},
 	&gtsmodel.Follow{},
 	&gtsmodel.FollowRequest{},
 	&gtsmodel.MediaAttachment{},
 	&gtsmodel.Mention{},
+	&gtsmodel.ModerationRule{},
 	&gtsmodel.Status{},
 	&gtsmodel.StatusToEmoji{},
 	&gtsmodel.StatusToTag{},
 	&gtsmodel.StatusFave{},
+	&gtsmodel.StatusReaction{},
 	&gtsmodel.StatusBookmark{},
 	&gtsmodel.StatusMute{},
 	&gtsmodel.Tag{},
@@ -52,9 +56,18 @@ var testModels = []interface{}{
 	&gtsmodel.Emoji{},
 	&gtsmodel.Instance{},
 	&gtsmodel.Notification{},
+	&gtsmodel.PreviewCard{},
 	&gtsmodel.RouterSession{},
 	&gtsmodel.Token{},
 	&gtsmodel.Client{},
+	&gtsmodel.AccountArchive{},
+	&gtsmodel.FollowImport{},
+	&gtsmodel.BlockImport{},
+	&gtsmodel.AccountKey{},
+	&gtsmodel.SignUpIP{},
+	&gtsmodel.Tombstone{},
+	&gtsmodel.Setting{},
+	&gtsmodel.FollowedTag{},
 }
 
 // NewTestDB returns a new initialized, empty database for testing.
@@ -158,6 +158,7 @@ func NewTestUsers() map[string]*gtsmodel.User {
 			LastSignInAt:           time.Time{},
 			LastSignInIP:           nil,
 			SignInCount:            0,
+			LastLogin:              time.Time{},
 			InviteID:               "",
 			ChosenLanguages:        []string{},
 			FilteredLanguages:      []string{},
@@ -188,6 +189,7 @@ func NewTestUsers() map[string]*gtsmodel.User {
 			LastSignInAt:           time.Now().Add(-2 * time.Hour),
 			LastSignInIP:           net.ParseIP("89.122.255.1"),
 			SignInCount:            78,
+			LastLogin:              time.Now().Add(-10 * time.Minute),
 			InviteID:               "",
 			ChosenLanguages:        []string{"en"},
 			FilteredLanguages:      []string{},
@@ -218,6 +220,7 @@ func NewTestUsers() map[string]*gtsmodel.User {
 			LastSignInAt:           time.Now().Add(-2 * time.Hour),
 			LastSignInIP:           net.ParseIP("147.111.231.154"),
 			SignInCount:            9,
+			LastLogin:              time.Now().Add(-30 * time.Minute),
 			InviteID:               "",
 			ChosenLanguages:        []string{"en"},
 			FilteredLanguages:      []string{},
@@ -248,6 +251,7 @@ func NewTestUsers() map[string]*gtsmodel.User {
 			LastSignInAt:           time.Now().Add(-2 * time.Hour),
 			LastSignInIP:           net.ParseIP("198.98.21.15"),
 			SignInCount:            9,
+			LastLogin:              time.Now().Add(-30 * time.Minute),
 			InviteID:               "",
 			ChosenLanguages:        []string{"en"},
 			FilteredLanguages:      []string{},
@@ -0,0 +1,13 @@
+Array.from(document.getElementsByClassName("spoiler-label")).forEach((label) => {
+	let checkbox = document.getElementById(label.htmlFor);
+	function update() {
+		if(checkbox.checked) {
+			label.innerHTML = "Show more";
+		} else {
+			label.innerHTML = "Show less";
+		}
+	}
+	update();
+
+	label.addEventListener("click", () => {setTimeout(update, 1)});
+});